@@ -0,0 +1,46 @@
+"""
+Semantic Search Example with sentence-transformers
+
+Indexes a few sentences with their embeddings and text, then runs a vector
+search and a hybrid search. Requires: pip install sentence-transformers
+"""
+
+from sentence_transformers import SentenceTransformer
+from vector_db import VectorClient
+
+
+SENTENCES = [
+    "HNSW builds a layered proximity graph for fast nearest neighbor search.",
+    "Full-text search ranks documents with BM25 over an inverted index.",
+    "Hybrid search fuses vector similarity with keyword relevance.",
+    "The cat sat on the warm windowsill all afternoon.",
+    "Quantization compresses embeddings to reduce memory usage.",
+]
+
+
+def main():
+    model = SentenceTransformer("all-MiniLM-L6-v2")  # 384 dimensions
+
+    with VectorClient("localhost:50051") as client:
+        # encode returns a float32 numpy array; the client converts it
+        embeddings = model.encode(SENTENCES)
+        result = client.batch_insert(
+            "sentences",
+            [(emb, {"index": str(i)}, text) for i, (emb, text) in enumerate(zip(embeddings, SENTENCES))],
+        )
+        print(f"Inserted {result['inserted_count']} sentences")
+
+        query = "How do graph indexes find similar vectors?"
+        query_vector = model.encode(query)
+
+        print(f"\nVector search: {query}")
+        for r in client.search("sentences", query_vector, k=3):
+            print(f"  {r.distance:.4f}  {r.text}")
+
+        print(f"\nHybrid search: {query}")
+        for r in client.hybrid_search("sentences", query_vector, query_text=query, k=3):
+            print(f"  {r.distance:.4f}  {r.text}")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,92 @@
+#!/usr/bin/env python3
+"""
+Generate the Python gRPC stubs for the Vector Database API
+
+Compiles pkg/api/grpc/proto/vector.proto into vector_db/proto so the client
+can be installed and published without a separate protoc step. The
+google.api.http annotations only matter to the REST gateway, so they are
+stripped first and googleapis is not needed on the include path.
+
+Usage:
+    pip install grpcio-tools
+    python scripts/generate_stubs.py
+"""
+
+import os
+import re
+import shutil
+import sys
+import tempfile
+
+HERE = os.path.dirname(os.path.abspath(__file__))
+CLIENT_ROOT = os.path.dirname(HERE)
+PROTO_FILE = os.path.join(CLIENT_ROOT, '..', '..', 'pkg', 'api', 'grpc', 'proto', 'vector.proto')
+OUTPUT_DIR = os.path.join(CLIENT_ROOT, 'vector_db', 'proto')
+
+
+def strip_http_annotations(source: str) -> str:
+    """Remove the googleapis import and option (google.api.http) blocks"""
+    source = source.replace('import "google/api/annotations.proto";\n', '')
+
+    pattern = re.compile(r'\s*option \(google\.api\.http\) = \{')
+    while True:
+        match = pattern.search(source)
+        if match is None:
+            return source
+
+        # Blocks nest (additional_bindings), so match braces to find the end
+        depth, end = 1, match.end()
+        while depth > 0:
+            if source[end] == '{':
+                depth += 1
+            elif source[end] == '}':
+                depth -= 1
+            end += 1
+        if source[end] == ';':
+            end += 1
+        source = source[:match.start()] + source[end:]
+
+
+def main() -> int:
+    try:
+        from grpc_tools import protoc
+    except ImportError:
+        print("Error: grpcio-tools is required: pip install grpcio-tools", file=sys.stderr)
+        return 1
+
+    with open(PROTO_FILE, 'r', encoding='utf-8') as f:
+        source = strip_http_annotations(f.read())
+
+    workdir = tempfile.mkdtemp()
+    try:
+        with open(os.path.join(workdir, 'vector.proto'), 'w', encoding='utf-8') as f:
+            f.write(source)
+
+        os.makedirs(OUTPUT_DIR, exist_ok=True)
+        result = protoc.main([
+            'grpc_tools.protoc',
+            f'-I{workdir}',
+            f'--python_out={OUTPUT_DIR}',
+            f'--grpc_python_out={OUTPUT_DIR}',
+            os.path.join(workdir, 'vector.proto'),
+        ])
+        if result != 0:
+            print("Error: protoc failed", file=sys.stderr)
+            return result
+    finally:
+        shutil.rmtree(workdir)
+
+    # protoc emits a top-level import; make it relative to the package
+    grpc_stub = os.path.join(OUTPUT_DIR, 'vector_pb2_grpc.py')
+    with open(grpc_stub, 'r', encoding='utf-8') as f:
+        code = f.read()
+    code = code.replace('import vector_pb2 as vector__pb2', 'from . import vector_pb2 as vector__pb2')
+    with open(grpc_stub, 'w', encoding='utf-8') as f:
+        f.write(code)
+
+    print(f"Generated stubs in {os.path.relpath(OUTPUT_DIR, CLIENT_ROOT)}")
+    return 0
+
+
+if __name__ == '__main__':
+    sys.exit(main())
@@ -12,7 +12,7 @@ with open("requirements.txt", "r", encoding="utf-8") as fh:
 
 setup(
     name="vector-db-client",
-    version="1.1.0",
+    version="1.2.0",
     author="Vector Database Team",
     author_email="support@vectordb.example.com",
     description="Python client for Vector Database with HNSW and NSG indexing",
@@ -41,5 +41,8 @@ setup(
             "black>=22.0.0",
             "mypy>=0.950",
         ],
+        "examples": [
+            "sentence-transformers>=2.2.0",
+        ],
     },
 )
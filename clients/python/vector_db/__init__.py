@@ -4,7 +4,7 @@ Vector Database Python Client
 A Python client library for the Vector Database with HNSW and NSG indexing.
 """
 
-from .client import VectorDBClient, SearchResult
+from .client import VectorClient, VectorDBClient, SearchResult, to_float32
 
-__version__ = "1.1.0"
-__all__ = ["VectorDBClient", "SearchResult"]
+__version__ = "1.2.0"
+__all__ = ["VectorClient", "VectorDBClient", "SearchResult", "to_float32"]
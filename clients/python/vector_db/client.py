@@ -5,22 +5,44 @@ Main client for interacting with the Vector Database gRPC API.
 """
 
 import grpc
-from typing import List, Dict, Optional, Tuple
+from array import array
+from typing import Any, Iterable, List, Dict, Optional, Sequence, Tuple, Union
 from dataclasses import dataclass
-import sys
-import os
-
-# Add proto path for imports
-sys.path.append(os.path.join(os.path.dirname(__file__), '..', '..', '..', 'pkg', 'api', 'grpc', 'proto'))
 
 try:
-    import vector_pb2
-    import vector_pb2_grpc
+    from .proto import vector_pb2
+    from .proto import vector_pb2_grpc
 except ImportError:
-    print("Error: gRPC proto files not found. Please generate them first:")
-    print("  cd pkg/api/grpc/proto && python -m grpc_tools.protoc -I. --python_out=. --grpc_python_out=. vector.proto")
+    print("Error: gRPC stubs not found. Please generate them first:")
+    print("  cd clients/python && python scripts/generate_stubs.py")
     raise
 
+# Anything that iterates to numbers: lists, tuples, numpy arrays, torch tensors
+VectorLike = Union[Sequence[float], Any]
+
+
+def to_float32(vector: VectorLike) -> List[float]:
+    """
+    Convert a vector to a list of float32 values
+
+    The server stores float32, so values are rounded here the same way the
+    Go CLI converts its parsed float64 values. numpy arrays and tensors are
+    accepted, including a single-row 2-D array as returned by most embedding
+    models for one input.
+    """
+    if hasattr(vector, "tolist"):
+        vector = vector.tolist()
+    if len(vector) == 1 and isinstance(vector[0], (list, tuple)):
+        vector = vector[0]
+    if len(vector) == 0:
+        raise ValueError("vector is required")
+    return array('f', (float(v) for v in vector)).tolist()
+
+
+def _text(text: Optional[str]) -> Optional[str]:
+    """Return text only when set, like the CLI's -text flag: "" means no text"""
+    return text if text else None
+
 
 @dataclass
 class SearchResult:
@@ -34,14 +56,14 @@ class SearchResult:
     text_score: Optional[float] = None
 
 
-class VectorDBClient:
+class VectorClient:
     """
     Vector Database Client
 
     A Python client for interacting with the Vector Database gRPC API.
 
     Example:
-        >>> client = VectorDBClient("localhost:50051")
+        >>> client = VectorClient("localhost:50051")
         >>> vector_id = client.insert(
         ...     namespace="default",
         ...     vector=[0.1, 0.2, 0.3, ...],
@@ -88,7 +110,7 @@ class VectorDBClient:
     def __exit__(self, exc_type, exc_val, exc_tb):
         self.close()
 
-    def insert(self, namespace: str, vector: List[float],
+    def insert(self, namespace: str, vector: VectorLike,
                metadata: Optional[Dict[str, str]] = None,
                text: Optional[str] = None,
                id: Optional[str] = None) -> str:
@@ -97,9 +119,9 @@ class VectorDBClient:
 
         Args:
             namespace: Namespace for multi-tenancy
-            vector: Vector embedding (list of floats)
+            vector: Vector embedding (list of floats or numpy array)
             metadata: Optional metadata key-value pairs
-            text: Optional text content for full-text search
+            text: Optional text content for full-text search (empty means none)
             id: Optional custom ID (auto-generated if not provided)
 
         Returns:
@@ -114,9 +136,9 @@ class VectorDBClient:
         """
         request = vector_pb2.InsertRequest(
             namespace=namespace,
-            vector=vector,
+            vector=to_float32(vector),
             metadata=metadata or {},
-            text=text,
+            text=_text(text),
             id=id
         )
 
@@ -127,7 +149,7 @@ class VectorDBClient:
 
         return response.id
 
-    def search(self, namespace: str, query_vector: List[float], k: int = 10,
+    def search(self, namespace: str, query_vector: VectorLike, k: int = 10,
                ef_search: int = 50,
                filter_dict: Optional[Dict] = None,
                distance_metric: str = "cosine") -> List[SearchResult]:
@@ -157,7 +179,7 @@ class VectorDBClient:
         """
         request = vector_pb2.SearchRequest(
             namespace=namespace,
-            query_vector=query_vector,
+            query_vector=to_float32(query_vector),
             k=k,
             ef_search=ef_search,
             distance_metric=distance_metric
@@ -181,7 +203,7 @@ class VectorDBClient:
             for r in response.results
         ]
 
-    def hybrid_search(self, namespace: str, query_vector: List[float],
+    def hybrid_search(self, namespace: str, query_vector: VectorLike,
                      query_text: str, k: int = 10,
                      ef_search: int = 50,
                      fusion_method: str = "rrf",
@@ -220,7 +242,7 @@ class VectorDBClient:
 
         request = vector_pb2.HybridSearchRequest(
             namespace=namespace,
-            query_vector=query_vector,
+            query_vector=to_float32(query_vector),
             query_text=query_text,
             k=k,
             ef_search=ef_search,
@@ -245,13 +267,16 @@ class VectorDBClient:
             for r in response.results
         ]
 
-    def batch_insert(self, namespace: str, vectors: List[Tuple[List[float], Dict[str, str]]]) -> Dict:
+    def batch_insert(self, namespace: str,
+                     vectors: Iterable[Union[Tuple[VectorLike, Dict[str, str]],
+                                             Tuple[VectorLike, Dict[str, str], Optional[str]]]]) -> Dict:
         """
         Insert multiple vectors efficiently
 
         Args:
             namespace: Namespace for vectors
-            vectors: List of (vector, metadata) tuples
+            vectors: (vector, metadata) or (vector, metadata, text) tuples;
+                any iterable, so large loads can be streamed from a generator
 
         Returns:
             Dictionary with inserted_count, failed_count, and inserted_ids
@@ -265,11 +290,14 @@ class VectorDBClient:
             >>> print(f"Inserted {result['inserted_count']} vectors")
         """
         def request_generator():
-            for vector, metadata in vectors:
+            for item in vectors:
+                vector, metadata = item[0], item[1]
+                text = item[2] if len(item) > 2 else None
                 yield vector_pb2.InsertRequest(
                     namespace=namespace,
-                    vector=vector,
-                    metadata=metadata
+                    vector=to_float32(vector),
+                    metadata=metadata or {},
+                    text=_text(text)
                 )
 
         response = self.stub.BatchInsert(request_generator())
@@ -283,7 +311,7 @@ class VectorDBClient:
         }
 
     def update(self, namespace: str, id: str,
-               vector: Optional[List[float]] = None,
+               vector: Optional[VectorLike] = None,
                metadata: Optional[Dict[str, str]] = None,
                text: Optional[str] = None) -> bool:
         """
@@ -302,7 +330,7 @@ class VectorDBClient:
         request = vector_pb2.UpdateRequest(
             namespace=namespace,
             id=id,
-            vector=vector or [],
+            vector=to_float32(vector) if vector is not None else [],
             metadata=metadata or {},
             text=text
         )
@@ -380,3 +408,7 @@ class VectorDBClient:
             "uptime_seconds": response.uptime_seconds,
             "details": dict(response.details)
         }
+
+
+# VectorDBClient is the original name of VectorClient
+VectorDBClient = VectorClient
@@ -0,0 +1,6 @@
+"""
+Generated gRPC stubs for the Vector Database API
+
+Run scripts/generate_stubs.py to (re)generate vector_pb2.py and
+vector_pb2_grpc.py from pkg/api/grpc/proto/vector.proto.
+"""
@@ -1,15 +1,19 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/therealutkarshpriyadarshi/vector/internal/embed"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -42,10 +46,16 @@ func main() {
 	switch command {
 	case "insert":
 		handleInsert(os.Args[2:])
+	case "import":
+		handleImport(os.Args[2:])
+	case "export":
+		handleExport(os.Args[2:])
 	case "search":
 		handleSearch(os.Args[2:])
 	case "hybrid-search":
 		handleHybridSearch(os.Args[2:])
+	case "benchmark":
+		handleBenchmark(os.Args[2:])
 	case "delete":
 		handleDelete(os.Args[2:])
 	case "update":
@@ -54,6 +64,24 @@ func main() {
 		handleStats(os.Args[2:])
 	case "health":
 		handleHealth(os.Args[2:])
+	case "validate":
+		handleValidate(os.Args[2:])
+	case "snapshot":
+		handleSnapshot(os.Args[2:])
+	case "restore":
+		handleRestore(os.Args[2:])
+	case "compact":
+		handleCompact(os.Args[2:])
+	case "migrate":
+		handleMigrate(os.Args[2:])
+	case "create-namespace":
+		handleCreateNamespace(os.Args[2:])
+	case "describe-namespace":
+		handleDescribeNamespace(os.Args[2:])
+	case "list-namespaces":
+		handleListNamespaces(os.Args[2:])
+	case "drop-namespace":
+		handleDropNamespace(os.Args[2:])
 	case "version":
 		fmt.Printf("vector-cli version %s\n", version)
 	case "help", "-h", "--help":
@@ -68,33 +96,26 @@ func main() {
 func handleInsert(args []string) {
 	fs := flag.NewFlagSet("insert", flag.ExitOnError)
 	var (
-		vectorStr  = fs.String("vector", "", "vector as JSON array (required)")
+		vectorStr  = fs.String("vector", "", "vector as JSON array (required unless -embed is set)")
 		metadataStr = fs.String("metadata", "{}", "metadata as JSON object")
 		text       = fs.String("text", "", "text content for full-text search")
+		embedWith  = fs.String("embed", "", "embed -text with this provider (openai) instead of passing -vector")
 	)
 	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
 	fs.StringVar(&namespace, "namespace", namespace, "namespace")
 	fs.Parse(args)
 
-	if *vectorStr == "" {
-		fmt.Println("Error: -vector is required")
+	if *embedWith != "" && *text == "" {
+		fmt.Println("Error: -embed requires -text")
 		fs.Usage()
 		os.Exit(1)
 	}
-
-	// Parse vector
-	var vector []float64
-	if err := json.Unmarshal([]byte(*vectorStr), &vector); err != nil {
-		fmt.Printf("Error parsing vector: %v\n", err)
+	if *embedWith == "" && *vectorStr == "" {
+		fmt.Println("Error: -vector is required")
+		fs.Usage()
 		os.Exit(1)
 	}
 
-	// Convert to float32
-	vector32 := make([]float32, len(vector))
-	for i, v := range vector {
-		vector32[i] = float32(v)
-	}
-
 	// Parse metadata
 	var metadata map[string]string
 	if err := json.Unmarshal([]byte(*metadataStr), &metadata); err != nil {
@@ -106,6 +127,13 @@ func handleInsert(args []string) {
 	client, conn := connectToServer()
 	defer conn.Close()
 
+	var vector32 []float32
+	if *embedWith != "" {
+		vector32 = embedText(client, *embedWith, *text)
+	} else {
+		vector32 = parseVector(*vectorStr, "vector")
+	}
+
 	// Create request
 	req := &proto.InsertRequest{
 		Namespace: namespace,
@@ -134,47 +162,412 @@ func handleInsert(args []string) {
 	fmt.Printf("✓ Inserted vector with ID: %s\n", resp.Id)
 }
 
+// jsonlRecord is one line of a JSON-lines file read by import and written
+// by export. Metadata values are strings, numbers or booleans; numbers
+// and booleans are stored as typed metadata.
+type jsonlRecord struct {
+	Vector     []float32              `json:"vector"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Text       string                 `json:"text,omitempty"`
+	ExternalID string                 `json:"external_id,omitempty"`
+}
+
+func handleImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	var (
+		file      = fs.String("file", "", "JSON-lines file, one {\"vector\", \"metadata\", \"text\", \"external_id\"} object per line (required)")
+		batchSize = fs.Int("batch-size", 500, "vectors sent per BatchInsert stream")
+		strict    = fs.Bool("strict", false, "abort on the first malformed line instead of skipping it")
+	)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", namespace, "namespace")
+	fs.Parse(args)
+
+	if *file == "" {
+		fmt.Println("Error: -file is required")
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *batchSize < 1 {
+		fmt.Println("Error: -batch-size must be at least 1")
+		os.Exit(1)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	progress := &progressBar{total: info.Size()}
+	var inserted, failed, skipped int
+	batch := make([]*proto.InsertRequest, 0, *batchSize)
+	batchLines := make([]int, 0, *batchSize)
+
+	// flush streams the pending batch and reports the server's per-item errors
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		resp, err := sendImportBatch(client, batch)
+		if err != nil {
+			progress.clear()
+			fmt.Printf("Error: lines %d-%d: %v\n", batchLines[0], batchLines[len(batchLines)-1], err)
+			os.Exit(1)
+		}
+		inserted += int(resp.InsertedCount)
+		failed += int(resp.FailedCount)
+		if len(resp.Errors) > 0 {
+			progress.clear()
+			for _, msg := range resp.Errors {
+				// Errors name the item's position in the batch; report its line
+				var n int
+				if _, err := fmt.Sscanf(msg, "item %d:", &n); err == nil && n >= 0 && n < len(batchLines) {
+					msg = fmt.Sprintf("line %d:%s", batchLines[n], strings.SplitN(msg, ":", 2)[1])
+				}
+				fmt.Printf("Failed %s\n", msg)
+			}
+		}
+		batch = batch[:0]
+		batchLines = batchLines[:0]
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		progress.done += int64(len(scanner.Bytes())) + 1
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		req, err := parseImportRecord(scanner.Bytes())
+		if err != nil {
+			progress.clear()
+			if *strict {
+				fmt.Printf("Error: line %d: %v\n", line, err)
+				os.Exit(1)
+			}
+			fmt.Printf("Skipping line %d: %v\n", line, err)
+			skipped++
+			continue
+		}
+
+		batch = append(batch, req)
+		batchLines = append(batchLines, line)
+		if len(batch) == *batchSize {
+			flush()
+			progress.draw(fmt.Sprintf("inserted: %d  failed: %d", inserted, failed+skipped))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		progress.clear()
+		fmt.Printf("Error reading %s after line %d: %v\n", *file, line, err)
+		os.Exit(1)
+	}
+	flush()
+	progress.done = progress.total
+	progress.draw(fmt.Sprintf("inserted: %d  failed: %d", inserted, failed+skipped))
+	progress.clear()
+
+	fmt.Printf("✓ Imported %s into namespace %s\n", *file, namespace)
+	fmt.Printf("  Inserted: %d\n", inserted)
+	fmt.Printf("  Failed:   %d (%d malformed lines, %d rejected by the server)\n", failed+skipped, skipped, failed)
+	if failed+skipped > 0 {
+		os.Exit(1)
+	}
+}
+
+func handleExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var (
+		file      = fs.String("file", "", "JSON-lines file to write, in the import format (required)")
+		limit     = fs.Int64("limit", 0, "maximum vectors to export (0 = all)")
+		cursor    = fs.String("cursor", "", "resume an interrupted export from this cursor, appending to -file")
+		batchSize = fs.Int("batch-size", 1000, "vectors per streamed batch")
+	)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", namespace, "namespace")
+	fs.Parse(args)
+
+	if *file == "" {
+		fmt.Println("Error: -file is required")
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	// A resumed export continues the file the interrupted one wrote
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if *cursor != "" {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(*file, flags, 0644)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// The stream lasts as long as the export, so no request timeout applies
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Export(ctx, &proto.ExportRequest{
+		Namespace: namespace,
+		Cursor:    *cursor,
+		Limit:     *limit,
+		BatchSize: int32(*batchSize),
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+	progress := &progressBar{}
+	var exported int64
+	resumeFrom := *cursor
+	for {
+		batch, err := stream.Recv()
+		if err == nil {
+			for _, v := range batch.Vectors {
+				if err = encoder.Encode(exportRecord(v)); err != nil {
+					break
+				}
+			}
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			// Only a batch already written leaves a cursor to resume from
+			resumable := progress.drawn && resumeFrom != ""
+			progress.clear()
+			fmt.Printf("Error: %v\n", err)
+			if resumable {
+				fmt.Printf("Resume with: vector-cli export -namespace %s -file %s -cursor %s\n", namespace, *file, resumeFrom)
+			}
+			os.Exit(1)
+		}
+
+		// Everything up to the batch's cursor is now on disk
+		resumeFrom = batch.NextCursor
+		exported += int64(len(batch.Vectors))
+		progress.total = batch.Total
+		progress.done += int64(len(batch.Vectors))
+		progress.draw(fmt.Sprintf("exported: %d", exported))
+		if batch.Done {
+			break
+		}
+	}
+	progress.clear()
+
+	fmt.Printf("✓ Exported %d vectors from namespace %s to %s\n", exported, namespace, *file)
+	if *limit > 0 && resumeFrom != "" {
+		fmt.Printf("  Cursor: %s (pass with -cursor to continue past -limit)\n", resumeFrom)
+	}
+}
+
+// parseImportRecord decodes one import line into an insert request
+func parseImportRecord(data []byte) (*proto.InsertRequest, error) {
+	var record jsonlRecord
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&record); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if len(record.Vector) == 0 {
+		return nil, fmt.Errorf("missing \"vector\"")
+	}
+
+	req := &proto.InsertRequest{
+		Namespace: namespace,
+		Vector:    record.Vector,
+		Metadata:  make(map[string]string),
+	}
+	for k, v := range record.Metadata {
+		switch v := v.(type) {
+		case string:
+			req.Metadata[k] = v
+		case bool:
+			req.TypedMetadata = setTypedMetadata(req.TypedMetadata, k, &proto.MetadataValue{BoolValue: &v})
+		case json.Number:
+			if i, err := v.Int64(); err == nil {
+				req.TypedMetadata = setTypedMetadata(req.TypedMetadata, k, &proto.MetadataValue{IntValue: &i})
+			} else if f, err := v.Float64(); err == nil {
+				req.TypedMetadata = setTypedMetadata(req.TypedMetadata, k, &proto.MetadataValue{DoubleValue: &f})
+			} else {
+				return nil, fmt.Errorf("metadata %q: %v", k, err)
+			}
+		default:
+			return nil, fmt.Errorf("metadata %q: expected a string, number or boolean", k)
+		}
+	}
+	if record.Text != "" {
+		req.Text = &record.Text
+	}
+	if record.ExternalID != "" {
+		req.ExternalId = &record.ExternalID
+	}
+	return req, nil
+}
+
+// setTypedMetadata adds a typed metadata value, creating the map if needed
+func setTypedMetadata(typed map[string]*proto.MetadataValue, key string, value *proto.MetadataValue) map[string]*proto.MetadataValue {
+	if typed == nil {
+		typed = make(map[string]*proto.MetadataValue)
+	}
+	typed[key] = value
+	return typed
+}
+
+// exportRecord converts an exported vector to its JSON-lines form, keeping
+// typed metadata values typed
+func exportRecord(v *proto.FetchResult) jsonlRecord {
+	record := jsonlRecord{Vector: v.Vector}
+	if len(v.Metadata) > 0 {
+		record.Metadata = make(map[string]interface{}, len(v.Metadata))
+		for k, value := range v.Metadata {
+			record.Metadata[k] = value
+		}
+		for k, value := range v.TypedMetadata {
+			switch {
+			case value.IntValue != nil:
+				record.Metadata[k] = *value.IntValue
+			case value.DoubleValue != nil:
+				record.Metadata[k] = floatNumber(*value.DoubleValue)
+			case value.BoolValue != nil:
+				record.Metadata[k] = *value.BoolValue
+			}
+		}
+	}
+	if v.Text != nil {
+		record.Text = *v.Text
+	}
+	if v.ExternalId != nil {
+		record.ExternalID = *v.ExternalId
+	}
+	return record
+}
+
+// floatNumber formats a float with a decimal point, so a whole number is
+// imported back as a float rather than an integer
+func floatNumber(f float64) json.Number {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEn") {
+		s += ".0"
+	}
+	return json.Number(s)
+}
+
+// sendImportBatch inserts a batch through one BatchInsert stream
+func sendImportBatch(client proto.VectorDBClient, batch []*proto.InsertRequest) (*proto.BatchInsertResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range batch {
+		if err := stream.Send(req); err != nil {
+			// The server's error is returned by CloseAndRecv
+			break
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// progressBar draws the progress of an import or export as a single line
+// rewritten in place
+type progressBar struct {
+	done  int64 // Units completed: bytes read by import, vectors written by export
+	total int64
+	drawn bool
+}
+
+const progressBarWidth = 30
+
+func (p *progressBar) draw(counts string) {
+	fraction := 1.0
+	if p.total > 0 && p.done < p.total {
+		fraction = float64(p.done) / float64(p.total)
+	}
+	filled := int(fraction * progressBarWidth)
+	fmt.Printf("\r[%s%s] %3.0f%%  %s",
+		strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled),
+		fraction*100, counts)
+	p.drawn = true
+}
+
+// clear ends the progress line so other output starts on a fresh line
+func (p *progressBar) clear() {
+	if p.drawn {
+		fmt.Println()
+		p.drawn = false
+	}
+}
+
 func handleSearch(args []string) {
 	fs := flag.NewFlagSet("search", flag.ExitOnError)
 	var (
-		queryVectorStr = fs.String("query", "", "query vector as JSON array (required)")
+		queryVectorStr = fs.String("query", "", "query vector as JSON array (required unless -embed is set)")
+		queryText     = fs.String("query-text", "", "query text to embed (with -embed)")
+		embedWith     = fs.String("embed", "", "embed -query-text with this provider (openai) instead of passing -query")
 		k             = fs.Int("k", 10, "number of results to return")
 		efSearch      = fs.Int("ef", 50, "HNSW efSearch parameter")
 		showVector    = fs.Bool("show-vector", false, "show vectors in results")
+		asOf          = fs.Int64("as-of", 0, "search the newest snapshot taken at or before this Unix time in milliseconds")
 	)
 	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
 	fs.StringVar(&namespace, "namespace", namespace, "namespace")
 	fs.Parse(args)
 
-	if *queryVectorStr == "" {
-		fmt.Println("Error: -query is required")
+	if *embedWith != "" && *queryText == "" {
+		fmt.Println("Error: -embed requires -query-text")
 		fs.Usage()
 		os.Exit(1)
 	}
-
-	// Parse query vector
-	var queryVector []float64
-	if err := json.Unmarshal([]byte(*queryVectorStr), &queryVector); err != nil {
-		fmt.Printf("Error parsing query vector: %v\n", err)
+	if *embedWith == "" && *queryVectorStr == "" {
+		fmt.Println("Error: -query is required")
+		fs.Usage()
 		os.Exit(1)
 	}
 
-	// Convert to float32
-	queryVector32 := make([]float32, len(queryVector))
-	for i, v := range queryVector {
-		queryVector32[i] = float32(v)
-	}
-
 	// Connect to server
 	client, conn := connectToServer()
 	defer conn.Close()
 
+	var queryVector32 []float32
+	if *embedWith != "" {
+		queryVector32 = embedText(client, *embedWith, *queryText)
+	} else {
+		queryVector32 = parseVector(*queryVectorStr, "query vector")
+	}
+
 	// Create request
 	req := &proto.SearchRequest{
 		Namespace:   namespace,
 		QueryVector: queryVector32,
 		K:           int32(*k),
 		EfSearch:    int32(*efSearch),
+		AsOf:        *asOf,
 	}
 
 	// Send request
@@ -194,8 +587,9 @@ func handleSearch(args []string) {
 func handleHybridSearch(args []string) {
 	fs := flag.NewFlagSet("hybrid-search", flag.ExitOnError)
 	var (
-		queryVectorStr = fs.String("query-vector", "", "query vector as JSON array (required)")
+		queryVectorStr = fs.String("query-vector", "", "query vector as JSON array (required unless -embed is set)")
 		queryText     = fs.String("query-text", "", "query text (required)")
+		embedWith     = fs.String("embed", "", "embed -query-text with this provider (openai) instead of passing -query-vector")
 		k             = fs.Int("k", 10, "number of results to return")
 		efSearch      = fs.Int("ef", 50, "HNSW efSearch parameter")
 		showVector    = fs.Bool("show-vector", false, "show vectors in results")
@@ -204,29 +598,23 @@ func handleHybridSearch(args []string) {
 	fs.StringVar(&namespace, "namespace", namespace, "namespace")
 	fs.Parse(args)
 
-	if *queryVectorStr == "" || *queryText == "" {
-		fmt.Println("Error: both -query-vector and -query-text are required")
+	if (*queryVectorStr == "" && *embedWith == "") || *queryText == "" {
+		fmt.Println("Error: both -query-vector (or -embed) and -query-text are required")
 		fs.Usage()
 		os.Exit(1)
 	}
 
-	// Parse query vector
-	var queryVector []float64
-	if err := json.Unmarshal([]byte(*queryVectorStr), &queryVector); err != nil {
-		fmt.Printf("Error parsing query vector: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Convert to float32
-	queryVector32 := make([]float32, len(queryVector))
-	for i, v := range queryVector {
-		queryVector32[i] = float32(v)
-	}
-
 	// Connect to server
 	client, conn := connectToServer()
 	defer conn.Close()
 
+	var queryVector32 []float32
+	if *embedWith != "" {
+		queryVector32 = embedText(client, *embedWith, *queryText)
+	} else {
+		queryVector32 = parseVector(*queryVectorStr, "query vector")
+	}
+
 	// Create request
 	req := &proto.HybridSearchRequest{
 		Namespace:   namespace,
@@ -250,6 +638,243 @@ func handleHybridSearch(args []string) {
 	displaySearchResults(resp, *showVector)
 }
 
+// benchmarkQuery is one line of a benchmark queries file
+type benchmarkQuery struct {
+	Vector []float32 `json:"vector"`
+}
+
+// benchmarkTruth is one line of a ground-truth file: the IDs, internal
+// (numbers) or external (strings), of the true nearest neighbors of the
+// query on the same line, nearest first
+type benchmarkTruth struct {
+	IDs []interface{} `json:"ids"`
+}
+
+// benchmarkRun is the outcome of running every query at one efSearch
+type benchmarkRun struct {
+	efSearch  int
+	latencies []time.Duration
+	elapsed   time.Duration
+	recall    float64
+}
+
+func handleBenchmark(args []string) {
+	fs := flag.NewFlagSet("benchmark", flag.ExitOnError)
+	var (
+		queriesFile = fs.String("queries", "", "JSON-lines file, one {\"vector\"} object per line (required)")
+		truthFile   = fs.String("ground-truth", "", "JSON-lines file, one {\"ids\"} object per query line, to measure recall")
+		k           = fs.Int("k", 10, "number of results per query")
+		efList      = fs.String("ef", "50", "comma-separated efSearch values to sweep")
+		warmup      = fs.Int("warmup", 100, "untimed queries run before measuring")
+	)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", namespace, "namespace")
+	fs.Parse(args)
+
+	if *queriesFile == "" {
+		fmt.Println("Error: -queries is required")
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *k < 1 {
+		fmt.Println("Error: -k must be at least 1")
+		os.Exit(1)
+	}
+	var efValues []int
+	for _, s := range strings.Split(*efList, ",") {
+		ef, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || ef < 1 {
+			fmt.Printf("Error: invalid -ef value %q\n", s)
+			os.Exit(1)
+		}
+		efValues = append(efValues, ef)
+	}
+
+	var queries []benchmarkQuery
+	if err := readJSONLines(*queriesFile, func(data []byte) error {
+		var q benchmarkQuery
+		if err := json.Unmarshal(data, &q); err != nil {
+			return fmt.Errorf("invalid JSON: %v", err)
+		}
+		if len(q.Vector) == 0 {
+			return fmt.Errorf("missing \"vector\"")
+		}
+		queries = append(queries, q)
+		return nil
+	}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(queries) == 0 {
+		fmt.Println("Error: no queries")
+		os.Exit(1)
+	}
+
+	var truth [][]string
+	if *truthFile != "" {
+		if err := readJSONLines(*truthFile, func(data []byte) error {
+			var t benchmarkTruth
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			if err := decoder.Decode(&t); err != nil {
+				return fmt.Errorf("invalid JSON: %v", err)
+			}
+			ids := make([]string, len(t.IDs))
+			for i, id := range t.IDs {
+				switch id := id.(type) {
+				case string:
+					ids[i] = id
+				case json.Number:
+					ids[i] = id.String()
+				default:
+					return fmt.Errorf("ids: expected strings or numbers")
+				}
+			}
+			truth = append(truth, ids)
+			return nil
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(truth) != len(queries) {
+			fmt.Printf("Error: %d queries but %d ground-truth lines\n", len(queries), len(truth))
+			os.Exit(1)
+		}
+	}
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	search := func(q benchmarkQuery, ef int) *proto.SearchResponse {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		resp, err := client.Search(ctx, &proto.SearchRequest{
+			Namespace:   namespace,
+			QueryVector: q.Vector,
+			K:           int32(*k),
+			EfSearch:    int32(ef),
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return resp
+	}
+
+	// Warm the connection and the server's caches
+	for i := 0; i < *warmup; i++ {
+		search(queries[i%len(queries)], efValues[0])
+	}
+
+	fmt.Printf("Namespace: %s\n", namespace)
+	fmt.Printf("Queries: %d\n", len(queries))
+	fmt.Printf("k: %d\n", *k)
+
+	runs := make([]benchmarkRun, 0, len(efValues))
+	for _, ef := range efValues {
+		run := benchmarkRun{efSearch: ef, latencies: make([]time.Duration, len(queries))}
+		var recallSum float64
+		start := time.Now()
+		for i, q := range queries {
+			queryStart := time.Now()
+			resp := search(q, ef)
+			run.latencies[i] = time.Since(queryStart)
+			if truth != nil {
+				recallSum += recallAtK(resp.Results, truth[i], *k)
+			}
+		}
+		run.elapsed = time.Since(start)
+		run.recall = recallSum / float64(len(queries))
+		sort.Slice(run.latencies, func(a, b int) bool { return run.latencies[a] < run.latencies[b] })
+		runs = append(runs, run)
+
+		fmt.Printf("\nefSearch=%d:\n", ef)
+		if truth != nil {
+			fmt.Printf("  Recall@%d: %.2f%%\n", *k, run.recall*100)
+		}
+		fmt.Printf("  QPS: %.0f\n", float64(len(queries))/run.elapsed.Seconds())
+		fmt.Printf("  Latency p50: %.2f ms\n", latencyPercentile(run.latencies, 0.50))
+		fmt.Printf("  Latency p95: %.2f ms\n", latencyPercentile(run.latencies, 0.95))
+		fmt.Printf("  Latency p99: %.2f ms\n", latencyPercentile(run.latencies, 0.99))
+	}
+
+	if len(runs) > 1 {
+		fmt.Printf("\n%-10s %10s %10s %10s %10s %10s\n", "efSearch", "recall", "QPS", "p50 ms", "p95 ms", "p99 ms")
+		for _, run := range runs {
+			recall := "-"
+			if truth != nil {
+				recall = fmt.Sprintf("%.2f%%", run.recall*100)
+			}
+			fmt.Printf("%-10d %10s %10.0f %10.2f %10.2f %10.2f\n", run.efSearch, recall,
+				float64(len(queries))/run.elapsed.Seconds(),
+				latencyPercentile(run.latencies, 0.50),
+				latencyPercentile(run.latencies, 0.95),
+				latencyPercentile(run.latencies, 0.99))
+		}
+	}
+}
+
+// readJSONLines calls parse with each non-blank line of a file, stopping at
+// the first error
+func readJSONLines(path string, parse func(data []byte) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		if err := parse(scanner.Bytes()); err != nil {
+			return fmt.Errorf("%s line %d: %v", path, line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// recallAtK returns the fraction of the first k true neighbors found among
+// the results, matching either the internal or the external ID
+func recallAtK(results []*proto.SearchResult, truth []string, k int) float64 {
+	if len(truth) > k {
+		truth = truth[:k]
+	}
+	if len(truth) == 0 {
+		return 1
+	}
+
+	found := make(map[string]bool, 2*len(results))
+	for _, r := range results {
+		found[r.Id] = true
+		if r.ExternalId != nil {
+			found[*r.ExternalId] = true
+		}
+	}
+	hits := 0
+	for _, id := range truth {
+		if found[id] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(truth))
+}
+
+// latencyPercentile returns the p-th percentile of sorted latencies in
+// milliseconds
+func latencyPercentile(sorted []time.Duration, p float64) float64 {
+	i := int(float64(len(sorted)) * p)
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return float64(sorted[i].Microseconds()) / 1000
+}
+
 func handleDelete(args []string) {
 	fs := flag.NewFlagSet("delete", flag.ExitOnError)
 	var (
@@ -371,6 +996,7 @@ func handleUpdate(args []string) {
 func handleStats(args []string) {
 	fs := flag.NewFlagSet("stats", flag.ExitOnError)
 	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	verbose := fs.Bool("verbose", false, "show the HNSW layer histogram of each namespace")
 	fs.Parse(args)
 
 	// Connect to server
@@ -401,9 +1027,368 @@ func handleStats(args []string) {
 		fmt.Printf("    Vectors:    %d\n", stats.VectorCount)
 		fmt.Printf("    Dimensions: %d\n", stats.Dimensions)
 		fmt.Printf("    Memory:     %d bytes\n", stats.MemoryBytes)
+		if *verbose {
+			printLayerHistogram(stats)
+		}
+	}
+}
+
+// printLayerHistogram prints each graph layer's node count as a bar, top
+// layer first, with its average degree
+func printLayerHistogram(stats *proto.NamespaceStats) {
+	fmt.Printf("    Max Layer:  %d\n", stats.MaxLayer)
+	if len(stats.Layers) == 0 {
+		return
+	}
+
+	const barWidth = 40
+	base := stats.Layers[0].Nodes
+	fmt.Println("    Layers:")
+	for i := len(stats.Layers) - 1; i >= 0; i-- {
+		layer := stats.Layers[i]
+		width := 0
+		if base > 0 {
+			width = int(layer.Nodes * barWidth / base)
+		}
+		if width == 0 && layer.Nodes > 0 {
+			width = 1
+		}
+		fmt.Printf("      L%-3d %-*s %8d nodes  avg degree %.1f\n",
+			layer.Layer, barWidth, strings.Repeat("#", width), layer.Nodes, layer.AvgDegree)
+	}
+}
+
+func handleValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", namespace, "namespace")
+	fs.Parse(args)
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.Validate(ctx, &proto.ValidateRequest{Namespace: namespace})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !resp.Valid {
+		fmt.Printf("✗ Namespace %s failed validation with %d violation(s):\n", namespace, resp.ViolationCount)
+		for _, v := range resp.Violations {
+			fmt.Printf("  - %s\n", v)
+		}
+		if more := int(resp.ViolationCount) - len(resp.Violations); more > 0 {
+			fmt.Printf("  ... and %d more\n", more)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Namespace %s is valid (%d nodes checked in %.2fms)\n", namespace, resp.NodesChecked, resp.ValidateTimeMs)
+}
+
+func handleSnapshot(args []string) {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.Parse(args)
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.Snapshot(ctx, &proto.SnapshotRequest{})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Display progress as namespaces are written
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if progress.Done {
+			fmt.Printf("✓ Snapshot written to %s\n", progress.Path)
+			fmt.Printf("  Namespaces: %d\n", progress.NamespacesTotal)
+			fmt.Printf("  Vectors:    %d\n", progress.VectorsWritten)
+			fmt.Printf("  Size:       %d bytes\n", progress.SizeBytes)
+			fmt.Printf("  SHA-256:    %s\n", progress.Checksum)
+			fmt.Printf("  Time:       %.2fms\n", progress.SnapshotTimeMs)
+			fmt.Printf("  As of:      %d (search it with -as-of)\n", progress.CapturedAt)
+			return
+		}
+		fmt.Printf("  [%d/%d] %s (%d vectors so far)\n",
+			progress.NamespacesDone, progress.NamespacesTotal, progress.Namespace, progress.VectorsWritten)
+	}
+}
+
+func handleRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	path := fs.String("path", "", "snapshot file (name or path in the server's snapshot directory)")
+	prefix := fs.String("prefix", "", "prefix for restored namespace names")
+	checksum := fs.String("checksum", "", "expected SHA-256 of the snapshot")
+	fs.Parse(args)
+
+	if *path == "" {
+		fmt.Println("Error: -path is required")
+		os.Exit(1)
+	}
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.Restore(ctx, &proto.RestoreRequest{
+		Path:     *path,
+		Prefix:   *prefix,
+		Checksum: *checksum,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Restored %d vectors into %d namespaces (%.2fms)\n",
+		resp.VectorsRestored, len(resp.Namespaces), resp.RestoreTimeMs)
+	for _, ns := range resp.Namespaces {
+		fmt.Printf("  - %s\n", ns)
+	}
+}
+
+func handleCompact(args []string) {
+	fs := flag.NewFlagSet("compact", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", namespace, "namespace")
+	fs.Parse(args)
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.Compact(ctx, &proto.CompactRequest{Namespace: namespace})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Compacted namespace %s (%.2fms)\n", namespace, resp.CompactTimeMs)
+	fmt.Printf("  Nodes:   %d -> %d\n", resp.NodesBefore, resp.NodesAfter)
+	fmt.Printf("  Deletes: %d reclaimed\n", resp.DeletedNodes)
+	fmt.Printf("  Memory:  %d -> %d bytes\n", resp.MemoryBeforeBytes, resp.MemoryAfterBytes)
+}
+
+func handleMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", namespace, "namespace")
+	indexType := fs.String("type", "", "target index type: hnsw, flat, ivf_pq or scann (required)")
+	fs.Parse(args)
+
+	if *indexType == "" {
+		fmt.Println("Error: -type is required")
+		os.Exit(1)
+	}
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request; interrupting the CLI cancels the migration
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.Migrate(ctx, &proto.MigrateRequest{Namespace: namespace, IndexType: *indexType})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Display progress as the migration advances
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if progress.Done {
+			fmt.Printf("✓ Migrated namespace %s to %s (%.2fms)\n", namespace, progress.IndexType, progress.MigrateTimeMs)
+			fmt.Printf("  Vectors: %d\n", progress.VectorsTotal)
+			fmt.Printf("  Memory:  %d -> %d bytes\n", progress.MemoryBeforeBytes, progress.MemoryAfterBytes)
+			return
+		}
+		fmt.Printf("  %s: %d/%d vectors\n", progress.Phase, progress.VectorsDone, progress.VectorsTotal)
 	}
 }
 
+func handleCreateNamespace(args []string) {
+	fs := flag.NewFlagSet("create-namespace", flag.ExitOnError)
+	var (
+		m              = fs.Int("m", 0, "HNSW links per node (default: server setting)")
+		efConstruction = fs.Int("ef-construction", 0, "HNSW candidate list size during insertion (default: server setting)")
+		dimensions     = fs.Int("dimensions", 0, "vector dimension (default: fixed by the first insert)")
+		metric         = fs.String("metric", "", "retrieval metric: cosine, euclidean or dot_product")
+		indexType      = fs.String("type", "", "index type: hnsw, or flat to always search exactly")
+		quantMin       = fs.Float64("quantization-min", 0, "float value int8 -127 and uint8 0 codes map to")
+		quantMax       = fs.Float64("quantization-max", 0, "float value int8 127 and uint8 255 codes map to")
+	)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", "", "namespace to create (required)")
+	fs.Parse(args)
+
+	if namespace == "" {
+		fmt.Println("Error: -namespace is required")
+		os.Exit(1)
+	}
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.CreateNamespace(ctx, &proto.CreateNamespaceRequest{
+		Namespace:       namespace,
+		M:               int32(*m),
+		EfConstruction:  int32(*efConstruction),
+		Dimensions:      int32(*dimensions),
+		Metric:          *metric,
+		IndexType:       *indexType,
+		QuantizationMin: float32(*quantMin),
+		QuantizationMax: float32(*quantMax),
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	dims := "any"
+	if resp.Dimensions > 0 {
+		dims = fmt.Sprint(resp.Dimensions)
+	}
+	fmt.Printf("✓ Created namespace %s (%s, M=%d, efConstruction=%d, dimensions=%s, metric=%s)\n",
+		resp.Namespace, resp.IndexType, resp.M, resp.EfConstruction, dims, resp.Metric)
+}
+
+func handleDescribeNamespace(args []string) {
+	fs := flag.NewFlagSet("describe-namespace", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", namespace, "namespace")
+	fs.Parse(args)
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: namespace})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	dims := "any"
+	if resp.Dimensions > 0 {
+		dims = fmt.Sprint(resp.Dimensions)
+	}
+	limit := func(v int32) string {
+		if v == 0 {
+			return "unlimited"
+		}
+		return fmt.Sprint(v)
+	}
+	fmt.Printf("Namespace:        %s\n", resp.Namespace)
+	fmt.Printf("Index type:       %s\n", resp.IndexType)
+	fmt.Printf("M:                %d\n", resp.M)
+	fmt.Printf("efConstruction:   %d\n", resp.EfConstruction)
+	fmt.Printf("efSearch:         %d\n", resp.DefaultEfSearch)
+	fmt.Printf("Max k:            %s\n", limit(resp.MaxK))
+	fmt.Printf("Max efSearch:     %s\n", limit(resp.MaxEfSearch))
+	fmt.Printf("Dimensions:       %s\n", dims)
+	fmt.Printf("Metric:           %s\n", resp.Metric)
+	fmt.Printf("Vectors:          %d\n", resp.VectorCount)
+	fmt.Printf("Memory:           %.2f MB\n", float64(resp.MemoryBytes)/(1024*1024))
+}
+
+func handleListNamespaces(args []string) {
+	fs := flag.NewFlagSet("list-namespaces", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.Parse(args)
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.ListNamespaces(ctx, &proto.ListNamespacesRequest{})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, ns := range resp.Namespaces {
+		fmt.Println(ns)
+	}
+}
+
+func handleDropNamespace(args []string) {
+	fs := flag.NewFlagSet("drop-namespace", flag.ExitOnError)
+	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
+	fs.StringVar(&namespace, "namespace", "", "namespace to drop (required)")
+	fs.Parse(args)
+
+	// No default here: dropping the default namespace by accident loses data
+	if namespace == "" {
+		fmt.Println("Error: -namespace is required")
+		os.Exit(1)
+	}
+
+	// Connect to server
+	client, conn := connectToServer()
+	defer conn.Close()
+
+	// Send request
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.DropNamespace(ctx, &proto.DropNamespaceRequest{Namespace: namespace})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Dropped namespace %s (%d vectors)\n", namespace, resp.VectorsDropped)
+}
+
 func handleHealth(args []string) {
 	fs := flag.NewFlagSet("health", flag.ExitOnError)
 	fs.StringVar(&serverAddr, "server", serverAddr, "gRPC server address")
@@ -458,6 +1443,55 @@ func connectToServer() (proto.VectorDBClient, *grpc.ClientConn) {
 	return proto.NewVectorDBClient(conn), conn
 }
 
+// parseVector parses a JSON array flag value, exiting on error
+func parseVector(s, name string) []float32 {
+	var vector []float64
+	if err := json.Unmarshal([]byte(s), &vector); err != nil {
+		fmt.Printf("Error parsing %s: %v\n", name, err)
+		os.Exit(1)
+	}
+
+	// Convert to float32
+	vector32 := make([]float32, len(vector))
+	for i, v := range vector {
+		vector32[i] = float32(v)
+	}
+	return vector32
+}
+
+// embedText embeds text with an embedding provider and checks the vector
+// matches the dimension of the namespace's stored vectors, exiting on error
+func embedText(client proto.VectorDBClient, provider, text string) []float32 {
+	embedder, err := embed.New(provider)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	vector, err := embed.EmbedOne(ctx, embedder, text)
+	if err != nil {
+		fmt.Printf("Error embedding text with %s: %v\n", embedder.Model(), err)
+		os.Exit(1)
+	}
+
+	// Empty namespaces accept any dimension
+	stats, err := client.GetStats(ctx, &proto.StatsRequest{Namespace: &namespace})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if ns, ok := stats.NamespaceStats[namespace]; ok && ns.VectorCount > 0 && int(ns.Dimensions) != len(vector) {
+		fmt.Printf("Error: %s produces %d-dimensional vectors, but namespace %s holds %d-dimensional vectors\n",
+			embedder.Model(), len(vector), namespace, ns.Dimensions)
+		os.Exit(1)
+	}
+
+	return vector
+}
+
 func displaySearchResults(resp *proto.SearchResponse, showVector bool) {
 	if resp.Error != nil && *resp.Error != "" {
 		fmt.Printf("Search error: %s\n", *resp.Error)
@@ -538,12 +1572,24 @@ Usage:
 
 Commands:
   insert          Insert a vector with metadata
+  import          Bulk insert vectors from a JSON-lines file
+  export          Write a namespace's vectors to a JSON-lines file
   search          Search for similar vectors
   hybrid-search   Hybrid search (vector + text)
+  benchmark       Measure search latency and recall on your own queries
   delete          Delete a vector by ID
   update          Update a vector
   stats           Get database statistics
   health          Check server health
+  validate        Check a namespace's index for graph corruption
+  snapshot        Write all namespaces to a snapshot file on the server
+  restore         Restore namespaces from a snapshot file
+  compact         Rebuild a namespace's index to reclaim deleted vectors
+  migrate         Rebuild a namespace's index as another index type
+  create-namespace Create a namespace with its own index parameters
+  describe-namespace Show a namespace's index parameters and size
+  list-namespaces List all namespaces
+  drop-namespace  Delete a namespace and all of its vectors
   version         Show version
   help            Show this help message
 
@@ -560,18 +1606,33 @@ Examples:
     -metadata '{"title": "Document 1", "category": "tech"}' \
     -text "This is a test document"
 
+  # Bulk insert a JSON-lines file, one {"vector", "metadata", "text"} per line
+  vector-cli import -file data.jsonl -namespace docs -batch-size 1000
+
+  # Export a namespace in the same format, resuming an interrupted export
+  vector-cli export -namespace docs -file docs.jsonl
+  vector-cli export -namespace docs -file docs.jsonl -cursor 41999
+
   # Search for similar vectors
   vector-cli search \
     -query '[0.15, 0.25, 0.35]' \
     -k 10 \
     -ef 50
 
+  # Embed text with OpenAI (reads OPENAI_API_KEY) and insert or search
+  vector-cli insert -embed openai -text "This is a test document"
+  vector-cli search -embed openai -query-text "test document" -k 10
+
   # Hybrid search (vector + text)
   vector-cli hybrid-search \
     -query-vector '[0.1, 0.2, 0.3]' \
     -query-text "machine learning" \
     -k 10
 
+  # Measure recall@10 and latency percentiles, sweeping efSearch
+  vector-cli benchmark -namespace docs -queries queries.jsonl \
+    -ground-truth gt.jsonl -k 10 -ef 50,100,200
+
   # Delete a vector
   vector-cli delete -id 12345
 
@@ -584,9 +1645,36 @@ Examples:
   # Get database statistics
   vector-cli stats
 
+  # Include the HNSW layer histogram to check M against the data size
+  vector-cli stats -verbose
+
   # Check server health
   vector-cli health
 
+  # Check the index graph of a namespace
+  vector-cli validate -namespace production
+
+  # Back up every namespace and restore it alongside the originals
+  vector-cli snapshot
+  vector-cli restore -path snapshot-20250101-120000.000000000.snap -prefix restored-
+
+  # Rebuild a namespace's index after many deletes
+  vector-cli compact -namespace production
+
+  # Serve a small namespace by exact full scans instead of the HNSW graph
+  vector-cli migrate -namespace production -type flat
+
+  # Search a large namespace through a trained IVF-PQ index
+  vector-cli migrate -namespace production -type ivf_pq
+
+  # Give a namespace its own graph parameters before the first insert
+  vector-cli create-namespace -namespace images -m 32 -ef-construction 400 -dimensions 512
+  vector-cli describe-namespace -namespace images
+
+  # Remove an experiment's namespace
+  vector-cli list-namespaces
+  vector-cli drop-namespace -namespace experiment-42
+
   # Use custom server and namespace
   vector-cli search \
     -server my-server:50051 \
@@ -15,6 +15,7 @@ import (
 	"github.com/therealutkarshpriyadarshi/vector/pkg/api/rest"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/api/rest/middleware"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/config"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/observability"
 )
 
 var (
@@ -64,6 +65,22 @@ func main() {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
 
+	// Export search spans before any request can arrive
+	var shutdownTracing func(context.Context) error
+	if cfg.Tracing.Enabled {
+		var err error
+		shutdownTracing, err = observability.InitTracing(context.Background(), observability.TracingConfig{
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			ServiceName: cfg.Tracing.ServiceName,
+			SampleRatio: cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			log.Fatalf("Failed to initialize tracing: %v", err)
+		}
+		log.Printf("Tracing enabled, exporting to %s", cfg.Tracing.Endpoint)
+	}
+
 	// Create gRPC server
 	log.Println("Initializing Vector Database server...")
 	grpcServer, err := grpcserver.NewServer(cfg)
@@ -103,6 +120,7 @@ func main() {
 				Host:        cfg.REST.Host,
 				Port:        cfg.REST.Port,
 				GRPCAddress: cfg.Server.Address(),
+				GRPCCredentials: grpcServer.GatewayCredentials(),
 				CORSEnabled: cfg.REST.CORSEnabled,
 				CORSOrigins: cfg.REST.CORSOrigins,
 				Auth: middleware.AuthConfig{
@@ -110,6 +128,7 @@ func main() {
 					JWTSecret:    cfg.REST.JWTSecret,
 					PublicPaths:  cfg.REST.PublicPaths,
 					AdminPaths:   cfg.REST.AdminPaths,
+					APIKeys:      cfg.Server.APIKeys,
 				},
 				RateLimit: middleware.RateLimitConfig{
 					Enabled:        cfg.REST.RateLimitEnabled,
@@ -118,7 +137,15 @@ func main() {
 					PerIP:          cfg.REST.RateLimitPerIP,
 					PerUser:        cfg.REST.RateLimitPerUser,
 					GlobalLimit:    cfg.REST.RateLimitGlobal,
+					SkipPaths:      cfg.REST.RateLimitSkipPaths,
 				},
+				Compression: middleware.CompressionConfig{
+					Enabled:  cfg.REST.CompressionEnabled,
+					MinBytes: cfg.REST.CompressionMinBytes,
+				},
+			}
+			if cfg.Metrics.Enabled {
+				restConfig.MetricsPath = cfg.Metrics.Path
 			}
 
 			var err error
@@ -170,17 +197,28 @@ func main() {
 	// Wait for all goroutines to finish
 	wg.Wait()
 
+	// Flush buffered spans
+	if shutdownTracing != nil {
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("Error shutting down tracing: %v", err)
+		}
+	}
+
 	log.Println("Servers stopped. Goodbye!")
 }
 
+// loadConfig loads the config file, if any, with environment variables
+// overriding its values
 func loadConfig(configFile string) *config.Config {
-	// TODO: support loading from YAML/JSON config file
-	if configFile != "" {
-		log.Printf("Warning: config file support not yet implemented, using environment variables")
+	if configFile == "" {
+		return config.LoadFromEnv()
 	}
 
-	// Load from environment variables
-	cfg := config.LoadFromEnv()
+	cfg, err := config.LoadFromFile(configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	log.Printf("Loaded configuration from %s", configFile)
 
 	return cfg
 }
@@ -210,7 +248,14 @@ func printStartupInfo(cfg *config.Config) {
 	fmt.Println("╠════════════════════════════════════════════════════════╣")
 	fmt.Printf("║ Address:          %-35s ║\n", cfg.Server.Address())
 	fmt.Printf("║ TLS Enabled:      %-35v ║\n", cfg.Server.EnableTLS)
+	if cfg.Server.EnableTLS {
+		fmt.Printf("║ Client Certs:     %-35v ║\n", cfg.Server.ClientCAFile != "")
+	}
 	fmt.Printf("║ Max Connections:  %-35d ║\n", cfg.Server.MaxConnections)
+	fmt.Printf("║ Compression:      %-35v ║\n", cfg.Server.EnableCompression)
+	if cfg.Server.EnableReflection {
+		fmt.Printf("║ Reflection:       %-35s ║\n", "on (grpcurl, Postman)")
+	}
 	fmt.Println("╠════════════════════════════════════════════════════════╣")
 	fmt.Println("║            REST API Configuration                      ║")
 	fmt.Println("╠════════════════════════════════════════════════════════╣")
@@ -263,9 +308,12 @@ func showUsage() {
 	fmt.Println("  VECTOR_ENABLE_TLS          Enable TLS (true/false)")
 	fmt.Println("  VECTOR_TLS_CERT            TLS certificate file")
 	fmt.Println("  VECTOR_TLS_KEY             TLS key file")
+	fmt.Println("  VECTOR_TLS_CLIENT_CA       CA file for verifying client certificates (enables mutual TLS)")
+	fmt.Println("  VECTOR_ENABLE_REFLECTION   Serve gRPC reflection (default: true, false with TLS)")
 	fmt.Println("  VECTOR_HNSW_M              HNSW M parameter")
 	fmt.Println("  VECTOR_HNSW_EF_CONSTRUCTION HNSW efConstruction")
 	fmt.Println("  VECTOR_DIMENSIONS          Vector dimensions")
+	fmt.Println("  VECTOR_MAX_DIMENSIONS      Largest vector accepted on insert")
 	fmt.Println("  VECTOR_CACHE_ENABLED       Enable query cache (true/false)")
 	fmt.Println("  VECTOR_CACHE_CAPACITY      Cache capacity")
 	fmt.Println("  VECTOR_CACHE_TTL           Cache TTL (e.g., 5m)")
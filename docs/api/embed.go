@@ -0,0 +1,10 @@
+// Package api embeds the REST API's OpenAPI specification, so the server
+// always serves the spec it was built with.
+package api
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI 3 specification in openapi.yaml
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"math/rand"
 	"os"
 	"strings"
 	"time"
@@ -305,7 +304,3 @@ func truncateString(s string, maxLen int) string {
 	}
 	return s[:maxLen] + "..."
 }
-
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
@@ -0,0 +1,42 @@
+package embed
+
+import (
+	"context"
+	"fmt"
+)
+
+// Embedder turns text into embedding vectors
+type Embedder interface {
+	// Embed returns one vector per input text, in input order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Model returns the name of the model producing the embeddings
+	Model() string
+}
+
+// Provider names accepted by New
+const (
+	ProviderOpenAI = "openai"
+)
+
+// New returns the embedder for a provider, configured from the environment
+func New(provider string) (Embedder, error) {
+	switch provider {
+	case ProviderOpenAI:
+		return NewOpenAIFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q (supported: %s)", provider, ProviderOpenAI)
+	}
+}
+
+// EmbedOne embeds a single text
+func EmbedOne(ctx context.Context, e Embedder, text string) ([]float32, error) {
+	vectors, err := e.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("expected 1 embedding, got %d", len(vectors))
+	}
+	return vectors[0], nil
+}
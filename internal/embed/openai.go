@@ -0,0 +1,149 @@
+package embed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// OpenAI defaults
+const (
+	DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+	DefaultOpenAIModel   = "text-embedding-3-small"
+)
+
+// OpenAIConfig configures an OpenAI embedder
+type OpenAIConfig struct {
+	APIKey     string       // API key (required)
+	Model      string       // Embedding model (default: text-embedding-3-small)
+	BaseURL    string       // API base URL, for proxies and compatible servers (default: https://api.openai.com/v1)
+	HTTPClient *http.Client // Client for API calls (default: 30s timeout)
+}
+
+// OpenAI embeds text with the OpenAI embeddings API
+type OpenAI struct {
+	apiKey  string
+	model   string
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenAI creates an OpenAI embedder
+func NewOpenAI(config OpenAIConfig) (*OpenAI, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("OpenAI API key is required")
+	}
+	if config.Model == "" {
+		config.Model = DefaultOpenAIModel
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultOpenAIBaseURL
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &OpenAI{
+		apiKey:  config.APIKey,
+		model:   config.Model,
+		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+		client:  config.HTTPClient,
+	}, nil
+}
+
+// NewOpenAIFromEnv creates an OpenAI embedder from OPENAI_API_KEY, with
+// optional OPENAI_EMBEDDING_MODEL and OPENAI_BASE_URL overrides
+func NewOpenAIFromEnv() (*OpenAI, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+	return NewOpenAI(OpenAIConfig{
+		APIKey:  apiKey,
+		Model:   os.Getenv("OPENAI_EMBEDDING_MODEL"),
+		BaseURL: os.Getenv("OPENAI_BASE_URL"),
+	})
+}
+
+// Model returns the embedding model name
+func (o *OpenAI) Model() string {
+	return o.model
+}
+
+type openAIRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
+
+// Embed calls the embeddings API once for all texts
+func (o *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("no texts to embed")
+	}
+
+	body, err := json.Marshal(openAIRequest{Model: o.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode embedding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedding request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI embedding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAI response: %w", err)
+	}
+
+	var parsed openAIResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("OpenAI API returned %s", resp.Status)
+		}
+		return nil, fmt.Errorf("failed to parse OpenAI response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil && parsed.Error.Message != "" {
+			return nil, fmt.Errorf("OpenAI API returned %s: %s", resp.Status, parsed.Error.Message)
+		}
+		return nil, fmt.Errorf("OpenAI API returned %s", resp.Status)
+	}
+
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("OpenAI returned %d embeddings for %d texts", len(parsed.Data), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(texts) || vectors[d.Index] != nil {
+			return nil, fmt.Errorf("OpenAI returned an embedding with unexpected index %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
@@ -0,0 +1,95 @@
+package embed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Unexpected Authorization header %q", got)
+		}
+
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.Model != DefaultOpenAIModel || len(req.Input) != 2 {
+			t.Errorf("Unexpected request %+v", req)
+		}
+
+		// Out of order, as the API does not promise ordering
+		w.Write([]byte(`{"data": [
+			{"index": 1, "embedding": [0.3, 0.4]},
+			{"index": 0, "embedding": [0.1, 0.2]}
+		]}`))
+	}))
+	defer server.Close()
+
+	e, err := NewOpenAI(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOpenAI failed: %v", err)
+	}
+
+	vectors, err := e.Embed(context.Background(), []string{"first", "second"})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 0.1 || vectors[1][1] != 0.4 {
+		t.Errorf("Unexpected embeddings %v", vectors)
+	}
+}
+
+func TestOpenAIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": {"message": "Incorrect API key provided", "type": "invalid_request_error"}}`))
+	}))
+
+	e, _ := NewOpenAI(OpenAIConfig{APIKey: "bad-key", BaseURL: server.URL})
+	_, err := EmbedOne(context.Background(), e, "text")
+	if err == nil || !strings.Contains(err.Error(), "Incorrect API key provided") {
+		t.Errorf("Expected API error message, got %v", err)
+	}
+
+	// Network failures are reported as request failures
+	server.Close()
+	_, err = EmbedOne(context.Background(), e, "text")
+	if err == nil || !strings.Contains(err.Error(), "request failed") {
+		t.Errorf("Expected request failure, got %v", err)
+	}
+
+	if _, err := NewOpenAI(OpenAIConfig{}); err == nil {
+		t.Error("Expected error without an API key")
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	t.Setenv("OPENAI_EMBEDDING_MODEL", "text-embedding-3-large")
+
+	e, err := New(ProviderOpenAI)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if e.Model() != "text-embedding-3-large" {
+		t.Errorf("Expected model from environment, got %s", e.Model())
+	}
+
+	if _, err := New("word2vec"); err == nil {
+		t.Error("Expected error for unknown provider")
+	}
+
+	t.Setenv("OPENAI_API_KEY", "")
+	if _, err := New(ProviderOpenAI); err == nil || !strings.Contains(err.Error(), "OPENAI_API_KEY") {
+		t.Errorf("Expected missing key error, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build amd64 && !purego
+
+package quantization
+
+import "golang.org/x/sys/cpu"
+
+// useAVX2 selects the AVX2+FMA kernels in distance_amd64.s. Build with
+// -tags purego to force the portable loops.
+var useAVX2 = cpu.X86.HasAVX2 && cpu.X86.HasFMA
+
+// The kernels sum eight lanes at a time, so results can differ from the
+// portable loops in the last bits of float32 rounding.
+
+//go:noescape
+func squaredEuclideanAVX2(a, b []float32) float32
+
+//go:noescape
+func dotProductAVX2(a, b []float32) float32
+
+func squaredEuclidean(a, b []float32) float32 {
+	if useAVX2 {
+		// The kernels read len(a) elements of b; panic like the loop would
+		return squaredEuclideanAVX2(a, b[:len(a)])
+	}
+	return squaredEuclideanGeneric(a, b)
+}
+
+func dotProduct(a, b []float32) float32 {
+	if useAVX2 {
+		return dotProductAVX2(a, b[:len(a)])
+	}
+	return dotProductGeneric(a, b)
+}
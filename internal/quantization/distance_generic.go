@@ -0,0 +1,13 @@
+//go:build !amd64 || purego
+
+package quantization
+
+const useAVX2 = false
+
+func squaredEuclidean(a, b []float32) float32 {
+	return squaredEuclideanGeneric(a, b)
+}
+
+func dotProduct(a, b []float32) float32 {
+	return dotProductGeneric(a, b)
+}
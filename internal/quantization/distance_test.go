@@ -0,0 +1,92 @@
+package quantization
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDistanceKernelsMatchGeneric(t *testing.T) {
+	if !useAVX2 {
+		t.Log("SIMD kernels unavailable; checking the portable loops only")
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	// Cover every tail length around the 8- and 32-lane blocks
+	lengths := []int{768, 1024, 1536}
+	for n := 0; n <= 70; n++ {
+		lengths = append(lengths, n)
+	}
+
+	for _, n := range lengths {
+		a := make([]float32, n)
+		b := make([]float32, n)
+		for i := range a {
+			a[i] = rng.Float32()*2 - 1
+			b[i] = rng.Float32()*2 - 1
+		}
+
+		check := func(name string, got, want float32) {
+			tolerance := 1e-5 * math.Max(1, math.Abs(float64(want)))
+			if math.Abs(float64(got-want)) > tolerance {
+				t.Errorf("%s, length %d: got %v, expected %v", name, n, got, want)
+			}
+		}
+		check("squared euclidean", squaredEuclidean(a, b), squaredEuclideanGeneric(a, b))
+		check("dot product", dotProduct(a, b), dotProductGeneric(a, b))
+		check("euclidean", EuclideanDistanceFloat32(a, b), float32(math.Sqrt(float64(squaredEuclideanGeneric(a, b)))))
+		check("dot product", DotProductFloat32(a, b), dotProductGeneric(a, b))
+		if n > 0 {
+			normA := math.Sqrt(float64(dotProductGeneric(a, a)))
+			normB := math.Sqrt(float64(dotProductGeneric(b, b)))
+			check("cosine", CosineDistanceFloat32(a, b), float32(1-float64(dotProductGeneric(a, b))/(normA*normB)))
+		}
+	}
+
+	// A longer second operand is read only up to len(a)
+	a := []float32{1, 2, 3}
+	b := []float32{4, 5, 6, 100}
+	if got := DotProductFloat32(a, b); got != 32 {
+		t.Errorf("Expected dot product 32, got %v", got)
+	}
+	if got := squaredEuclidean(a, b); got != 27 {
+		t.Errorf("Expected squared distance 27, got %v", got)
+	}
+}
+
+func TestDistanceKernelsShortOperandPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a shorter second operand to panic")
+		}
+	}()
+	DotProductFloat32(make([]float32, 16), make([]float32, 8))
+}
+
+// The Generic sub-benchmarks run the portable loops for comparison
+func BenchmarkDistance(b *testing.B) {
+	for _, dim := range []int{128, 768} {
+		x := generateRandomVectors(1, dim)[0]
+		y := generateRandomVectors(1, dim)[0]
+
+		benchmarks := []struct {
+			name string
+			fn   func(a, b []float32) float32
+		}{
+			{"EuclideanGeneric", squaredEuclideanGeneric},
+			{"Euclidean", squaredEuclidean},
+			{"DotGeneric", dotProductGeneric},
+			{"Dot", dotProduct},
+		}
+		for _, bm := range benchmarks {
+			b.Run(fmt.Sprintf("%s/dim=%d", bm.name, dim), func(b *testing.B) {
+				var sink float32
+				for i := 0; i < b.N; i++ {
+					sink += bm.fn(x, y)
+				}
+				_ = sink
+			})
+		}
+	}
+}
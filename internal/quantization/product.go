@@ -76,8 +76,9 @@ func (pq *ProductQuantizer) Train(vectors [][]float32) error {
 		fmt.Printf("  Compression: %.1fx\n", pq.GetCompressionRatio(dimensions))
 	}
 
-	// Train a codebook for each subvector using k-means++
-	for sv := 0; sv < pq.numSubvectors; sv++ {
+	// Train a codebook for each subvector using k-means++. Codebooks are
+	// independent, so they train concurrently.
+	err := ForEachSubvector(pq.numSubvectors, func(sv int) error {
 		if pq.config.Verbose {
 			fmt.Printf("  Training codebook %d/%d...\n", sv+1, pq.numSubvectors)
 		}
@@ -99,6 +100,10 @@ func (pq *ProductQuantizer) Train(vectors [][]float32) error {
 		}
 
 		pq.codebooks[sv] = centroids
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	if pq.config.Verbose {
@@ -34,6 +34,57 @@ func TestProductQuantizer_Train(t *testing.T) {
 	}
 }
 
+func TestProductQuantizer_TrainDeterministic(t *testing.T) {
+	vectors := generateRandomVectors(500, 64)
+
+	// Codebooks train concurrently; the same seed must still give the same codebooks
+	first := NewProductQuantizer(16, 4)
+	if err := first.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	for run := 0; run < 3; run++ {
+		pq := NewProductQuantizer(16, 4)
+		if err := pq.Train(vectors); err != nil {
+			t.Fatalf("Train failed: %v", err)
+		}
+		for sv := range first.codebooks {
+			for c := range first.codebooks[sv] {
+				for d := range first.codebooks[sv][c] {
+					if pq.codebooks[sv][c][d] != first.codebooks[sv][c][d] {
+						t.Fatalf("Run %d: codebook %d centroid %d differs", run, sv, c)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestForEachSubvector(t *testing.T) {
+	done := make([]bool, 100)
+	if err := ForEachSubvector(len(done), func(sv int) error {
+		done[sv] = true
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachSubvector failed: %v", err)
+	}
+	for sv, ok := range done {
+		if !ok {
+			t.Errorf("Subvector %d was not visited", sv)
+		}
+	}
+
+	// The lowest failing index wins regardless of scheduling
+	err := ForEachSubvector(50, func(sv int) error {
+		if sv%10 == 7 {
+			return fmt.Errorf("subvector %d failed", sv)
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "subvector 7 failed" {
+		t.Errorf("Expected the error from subvector 7, got %v", err)
+	}
+}
+
 func TestProductQuantizer_Encode(t *testing.T) {
 	pq := NewProductQuantizer(4, 6) // 4 subvectors, 6 bits
 
@@ -366,6 +417,7 @@ func computeRecallForQuery(exactNeighbors, approxNeighbors []int) float32 {
 
 // Benchmarks
 
+// Codebooks train on GOMAXPROCS goroutines; compare -cpu 1,2,4,8 for scaling
 func BenchmarkProductQuantizer_Train(b *testing.B) {
 	pq := NewProductQuantizer(16, 8)
 	vectors := generateRandomVectors(1000, 768)
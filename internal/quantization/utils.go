@@ -4,39 +4,50 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"runtime"
+	"sync"
 )
 
 // EuclideanDistanceFloat32 computes Euclidean distance between two float32 vectors
+// Uses SIMD instructions when the CPU supports them (see distance_amd64.go)
 func EuclideanDistanceFloat32(a, b []float32) float32 {
+	return float32(math.Sqrt(float64(squaredEuclidean(a, b))))
+}
+
+// squaredEuclideanGeneric is the portable squared Euclidean distance
+func squaredEuclideanGeneric(a, b []float32) float32 {
 	var sum float32
 	for i := range a {
 		diff := a[i] - b[i]
 		sum += diff * diff
 	}
-	return float32(math.Sqrt(float64(sum)))
+	return sum
 }
 
-// CosineDistanceFloat32 computes cosine distance (1 - cosine similarity)
+// CosineDistanceFloat32 computes cosine distance (1 - cosine similarity),
+// in [0, 2] with 0 for vectors pointing the same way. A zero vector is at
+// distance 1 from everything. Uses SIMD instructions when the CPU supports
+// them (see distance_amd64.go)
 func CosineDistanceFloat32(a, b []float32) float32 {
-	var dotProduct, normA, normB float32
-	for i := range a {
-		dotProduct += a[i] * b[i]
-		normA += a[i] * a[i]
-		normB += b[i] * b[i]
-	}
-	normA = float32(math.Sqrt(float64(normA)))
-	normB = float32(math.Sqrt(float64(normB)))
+	normA := float32(math.Sqrt(float64(dotProduct(a, a))))
+	normB := float32(math.Sqrt(float64(dotProduct(b, b))))
 
 	if normA == 0 || normB == 0 {
 		return 1.0 // Maximum distance
 	}
 
-	cosineSim := dotProduct / (normA * normB)
+	cosineSim := dotProduct(a, b) / (normA * normB)
 	return 1.0 - cosineSim
 }
 
 // DotProductFloat32 computes dot product between two vectors
+// Uses SIMD instructions when the CPU supports them (see distance_amd64.go)
 func DotProductFloat32(a, b []float32) float32 {
+	return dotProduct(a, b)
+}
+
+// dotProductGeneric is the portable dot product
+func dotProductGeneric(a, b []float32) float32 {
 	var sum float32
 	for i := range a {
 		sum += a[i] * b[i]
@@ -66,6 +77,47 @@ func Normalize(v []float32) []float32 {
 	return result
 }
 
+// ForEachSubvector calls fn for every subvector index in [0, n) on a
+// worker pool sized to GOMAXPROCS. Calls must be independent of each other;
+// fn writes its results to its own slot. Returns the error of the lowest
+// failing index, so the outcome does not depend on scheduling.
+//
+// KMeansPlusPlus seeds its own generator from config.RandomSeed on every
+// call, so training subvector codebooks this way is as deterministic as
+// training them in order.
+func ForEachSubvector(n int, fn func(sv int) error) error {
+	errs := make([]error, n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sv := range jobs {
+				errs[sv] = fn(sv)
+			}
+		}()
+	}
+
+	for sv := 0; sv < n; sv++ {
+		jobs <- sv
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // KMeansPlusPlus performs k-means clustering with k-means++ initialization
 // This provides better initialization than random selection
 func KMeansPlusPlus(vectors [][]float32, k int, config *QuantizationConfig) ([][]float32, error) {
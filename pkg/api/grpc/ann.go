@@ -0,0 +1,334 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/ivf"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/migrate"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/scann"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// quantizedMinVectors is the fewest vectors an IVF-PQ or SCANN index is
+// trained on
+const quantizedMinVectors = 256
+
+// quantizedTrainSize bounds the vectors IVF-PQ and SCANN are trained on
+const quantizedTrainSize = 10000
+
+// annProbeEf is the efSearch that probes one more partition of an IVF-PQ
+// or SCANN index
+const annProbeEf = 10
+
+// annIndex is a trained quantized index a namespace is searched through:
+// *ivf.IVFPQ or *scann.SCANN
+type annIndex interface {
+	Insert(vec []float32, id int, metadata map[string]interface{}) error
+	Remove(id int) error
+	Search(query []float32, k, nprobe int) ([]int, []float32, error)
+	GetMemoryUsage() int64
+}
+
+// namespaceANN is a namespace's IVF-PQ or SCANN index. It keeps only
+// compressed codes: the namespace's flat HNSW index still stores every
+// vector, serves fetches, hybrid, range and multi-vector searches, and
+// rescores the candidates the quantized index returns. The codes are held
+// on top of the full-precision vectors, so a quantized namespace uses more
+// memory than a flat one, not less; it trades memory for search speed.
+type namespaceANN struct {
+	indexType  string // migrate.TypeIVFPQ or migrate.TypeSCANN
+	index      annIndex
+	partitions int
+}
+
+// memoryUsage returns the bytes held by the quantized index, 0 for none
+func (a *namespaceANN) memoryUsage() int64 {
+	if a == nil {
+		return 0
+	}
+	return a.index.GetMemoryUsage()
+}
+
+// nprobe returns the partitions a search with efSearch probes
+func (a *namespaceANN) nprobe(efSearch int) int {
+	nprobe := efSearch / annProbeEf
+	if nprobe < 1 {
+		nprobe = 1
+	}
+	if nprobe > a.partitions {
+		nprobe = a.partitions
+	}
+	return nprobe
+}
+
+// quantizedType reports whether an index type is served through an
+// IVF-PQ or SCANN index
+func quantizedType(indexType string) bool {
+	return indexType == migrate.TypeIVFPQ || indexType == migrate.TypeSCANN
+}
+
+// quantizedOptions returns the migration options training an IVF-PQ or
+// SCANN index on n vectors of dim dimensions under a retrieval metric:
+// sqrt(n) partitions, as many subvectors as divide dim up to 16, and 8-bit
+// codes once there are enough vectors to train 256 centroids per subvector
+func quantizedOptions(indexType string, n, dim int, metric string) (migrate.Options, int) {
+	partitions := int(math.Sqrt(float64(n)))
+	subvectors := 16
+	for dim%subvectors != 0 {
+		subvectors--
+	}
+	bits := 4
+	if n >= 16*quantizedMinVectors {
+		bits = 8
+	}
+
+	var distanceMetric quantization.DistanceMetric
+	switch metric {
+	case MetricEuclidean:
+		distanceMetric = quantization.EuclideanDistance
+	case MetricDotProduct:
+		distanceMetric = quantization.DotProductDistance
+	default:
+		distanceMetric = quantization.CosineDistance
+	}
+
+	opts := migrate.Options{Type: indexType, TrainSize: quantizedTrainSize}
+	if indexType == migrate.TypeIVFPQ {
+		opts.IVFPQ = ivf.ConfigPQ{
+			NumCentroids:  partitions,
+			NumSubvectors: subvectors,
+			BitsPerCode:   bits,
+			Metric:        distanceMetric,
+		}
+	} else {
+		// The flat store rescores candidates, so SCANN keeps codes only
+		config := scann.DefaultConfig()
+		config.NumPartitions = partitions
+		config.NumSubvectors = subvectors
+		config.BitsPerCode = bits
+		config.Metric = distanceMetric
+		config.StoreVectors = false
+		config.UseReordering = false
+		opts.SCANN = config
+	}
+	return opts, partitions
+}
+
+// buildIndexType rebuilds a namespace's vectors as the target index type
+// without swapping anything in. HNSW and flat namespaces get a new store;
+// IVF-PQ and SCANN namespaces keep a flat store, rebuilt only if it builds
+// a graph, and get a quantized index trained over it. Writes may continue
+// while it runs; Migrate catches the result up with them before the swap.
+func (s *Server) buildIndexType(ctx context.Context, namespace string, index *hnsw.Index, target string, progress func(migrate.Progress)) (*hnsw.Index, *namespaceANN, error) {
+	store := index
+	if !quantizedType(target) || indexType(index.Config()) != migrate.TypeFlat {
+		storeType := target
+		if quantizedType(target) {
+			storeType = migrate.TypeFlat
+		}
+		migrated, err := migrate.Migrate(ctx, migrate.Index{HNSW: index}, migrate.Options{Type: storeType, Progress: progress})
+		if err != nil {
+			return nil, nil, err
+		}
+		store = migrated.HNSW
+	}
+	if !quantizedType(target) {
+		return store, nil, nil
+	}
+
+	if size := store.Size(); size < quantizedMinVectors {
+		return nil, nil, status.Errorf(codes.FailedPrecondition,
+			"namespace %q holds %d vectors; %s indexes are trained on at least %d", namespace, size, target, quantizedMinVectors)
+	}
+	s.mu.RLock()
+	metric := s.retrievalMetricLocked(namespace)
+	s.mu.RUnlock()
+
+	opts, partitions := quantizedOptions(target, int(store.Size()), store.Dimension(), metric)
+	opts.Progress = progress
+	trained, err := migrate.Migrate(ctx, migrate.Index{HNSW: store}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ann := &namespaceANN{indexType: target, partitions: partitions}
+	if trained.IVFPQ != nil {
+		ann.index = trained.IVFPQ
+	} else {
+		ann.index = trained.SCANN
+	}
+	return store, ann, nil
+}
+
+// installIndex swaps a rebuilt store and quantized index in and records
+// the index type among the namespace's declared parameters
+func (s *Server) installIndex(namespace, target string, store *hnsw.Index, ann *namespaceANN) {
+	s.mu.Lock()
+	s.indexes[namespace] = store
+	s.hybridSearch[namespace] = s.newHybridSearch(store, s.textIndexes[namespace])
+	if ann != nil {
+		s.anns[namespace] = ann
+	} else {
+		delete(s.anns, namespace)
+	}
+	config := s.indexConfigs[namespace]
+	config.IndexType = target
+	s.indexConfigs[namespace] = config
+	s.mu.Unlock()
+
+	// The new index may order near-ties differently
+	s.invalidateResultCache(namespace)
+	s.updateIndexMetrics(namespace, store)
+	s.syncQuota(namespace, store)
+}
+
+// recoverIndexType rebuilds a recovered namespace as the index type a
+// Migrate logged, once its vectors have been replayed. Should that fail,
+// the namespace is served exactly from its vectors.
+func (s *Server) recoverIndexType(namespace string) {
+	s.mu.RLock()
+	index := s.indexes[namespace]
+	declared := s.indexConfigs[namespace].IndexType
+	s.mu.RUnlock()
+
+	if !needsIndexType(index, declared) {
+		return
+	}
+
+	store, ann, err := s.buildIndexType(context.Background(), namespace, index, declared, nil)
+	if err != nil {
+		log.Printf("Warning: failed to rebuild namespace %s as %s, searching it exactly: %v", namespace, declared, err)
+		return
+	}
+	s.installIndex(namespace, declared, store, ann)
+}
+
+// restoreIndexType rebuilds a restored namespace as the index type its
+// snapshot recorded. Unlike recovery, writes may already reach the
+// namespace, so it is migrated as Migrate would. Should that fail, the
+// namespace is served exactly from its vectors.
+func (s *Server) restoreIndexType(namespace string) {
+	s.mu.RLock()
+	index := s.indexes[namespace]
+	declared := s.indexConfigs[namespace].IndexType
+	s.mu.RUnlock()
+
+	if !needsIndexType(index, declared) {
+		return
+	}
+
+	if _, _, _, err := s.migrateNamespace(context.Background(), namespace, declared, nil); err != nil {
+		log.Printf("Warning: failed to rebuild restored namespace %s as %s, searching it exactly: %v", namespace, declared, err)
+	}
+}
+
+// needsIndexType reports whether index must be rebuilt to serve as the
+// declared index type. IVF-PQ and SCANN indexes are never saved, so a
+// namespace declaring one always is.
+func needsIndexType(index *hnsw.Index, declared string) bool {
+	if index == nil || declared == "" {
+		return false
+	}
+	return quantizedType(declared) || indexType(index.Config()) != declared
+}
+
+// annFor returns a namespace's IVF-PQ or SCANN index, or nil
+func (s *Server) annFor(namespace string) *namespaceANN {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.anns[namespace]
+}
+
+// annWritten records a write to a stored vector for a migration of the
+// namespace in progress and returns its IVF-PQ or SCANN index, or nil.
+// Every write to a stored vector passes through it, by way of annInsert,
+// annUpdate or annRemove.
+func (s *Server) annWritten(namespace string, id uint64) *namespaceANN {
+	s.mu.RLock()
+	ann, journal := s.anns[namespace], s.migrations[namespace]
+	s.mu.RUnlock()
+
+	if journal != nil {
+		journal.add(id)
+	}
+	return ann
+}
+
+// annInsert adds a stored vector to the namespace's IVF-PQ or SCANN index,
+// if it has one. The flat store already holds the vector and searches
+// rescore from it, so a failure only costs recall and is logged.
+func (s *Server) annInsert(namespace string, id uint64, vector []float32) {
+	if ann := s.annWritten(namespace, id); ann != nil {
+		if err := ann.index.Insert(vector, int(id), nil); err != nil {
+			log.Printf("Warning: failed to add vector %d to the %s index of namespace %s: %v", id, ann.indexType, namespace, err)
+		}
+	}
+}
+
+// annUpdate replaces an updated vector in the namespace's IVF-PQ or SCANN
+// index, if it has one
+func (s *Server) annUpdate(namespace string, id uint64, vector []float32) {
+	if ann := s.annWritten(namespace, id); ann != nil {
+		_ = ann.index.Remove(int(id)) // Missing if an earlier insert failed
+		if err := ann.index.Insert(vector, int(id), nil); err != nil {
+			log.Printf("Warning: failed to update vector %d in the %s index of namespace %s: %v", id, ann.indexType, namespace, err)
+		}
+	}
+}
+
+// annRemove drops a deleted vector from the namespace's IVF-PQ or SCANN
+// index, if it has one. Searches skip vectors the flat store no longer
+// holds, so an entry left behind is harmless.
+func (s *Server) annRemove(namespace string, id uint64) {
+	if ann := s.annWritten(namespace, id); ann != nil {
+		_ = ann.index.Remove(int(id))
+	}
+}
+
+// annSearch searches a namespace through its IVF-PQ or SCANN index. The
+// quantized candidates are rescored exactly from the flat store, which also
+// drops any deleted since. While fewer than k pass the filter, the
+// candidates and probed partitions double until every partition has been
+// searched.
+func (s *Server) annSearch(namespace string, index *hnsw.Index, ann *namespaceANN, query []float32, k, efSearch int, filter search.Filter, prof *searchProfile) ([]hnsw.Result, error) {
+	fetch := k
+	if efSearch > fetch {
+		fetch = efSearch
+	}
+	nprobe := ann.nprobe(efSearch)
+	distanceFunc := index.Config().DistanceFunc
+
+	for {
+		ids, _, err := ann.index.Search(query, fetch, nprobe)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]hnsw.Result, len(ids))
+		for i, id := range ids {
+			candidates[i] = hnsw.Result{ID: uint64(id)}
+		}
+		results := rerankResults(index, query, candidates, len(candidates), distanceFunc)
+		results = s.filterResults(namespace, results, filter, prof)
+		if len(results) >= k {
+			return results[:k], nil
+		}
+
+		// Fewer candidates than requested from every partition means the
+		// whole index was searched
+		if nprobe == ann.partitions && len(ids) < fetch {
+			return results, nil
+		}
+		fetch *= 2
+		nprobe *= 2
+		if nprobe > ann.partitions {
+			nprobe = ann.partitions
+		}
+	}
+}
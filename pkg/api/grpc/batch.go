@@ -0,0 +1,227 @@
+package grpc
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BatchInsert on_error modes
+const (
+	OnErrorContinue = "continue" // Skip failed items and insert the rest
+	OnErrorAbort    = "abort"    // Stop at the first failed item and roll back the batch
+)
+
+// errBatchAborted is the error of items an aborted batch never inserted
+const errBatchAborted = "not inserted: batch aborted"
+
+// batchItem tracks one streamed BatchInsert request. The receiving loop
+// reserves its ID and claims its external ID; a worker indexes it and
+// records any failure.
+type batchItem struct {
+	req        *proto.InsertRequest
+	index      *hnsw.Index
+	textIndex  *search.FullTextIndex
+	id         uint64
+	inPlace    bool // Upsert of the stored vector id
+	claim      *externalClaim
+	quotaBytes int64 // Memory admitted against the namespace's quota
+	err        string
+}
+
+// batchInsertWorkers returns how many goroutines index a BatchInsert stream
+func (s *Server) batchInsertWorkers() int {
+	if s.config.Database.BatchInsertWorkers < 1 {
+		return 1
+	}
+	return s.config.Database.BatchInsertWorkers
+}
+
+// parseOnError reports whether a batch's on_error mode is "abort"
+func parseOnError(mode string) (bool, error) {
+	switch mode {
+	case "", OnErrorContinue:
+		return false, nil
+	case OnErrorAbort:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown on_error %q: expected %q or %q", mode, OnErrorContinue, OnErrorAbort)
+	}
+}
+
+// prepareBatchItem validates one batch request and reserves its ID, so
+// items get IDs in batch order. It reports whether the item is ready for
+// insertBatchItem; a skipped item carries the reason in err. externalIDs
+// holds the (namespace, external ID) pairs of earlier items.
+func (s *Server) prepareBatchItem(req *proto.InsertRequest, externalIDs map[[2]string]bool) (*batchItem, bool) {
+	item := &batchItem{req: req}
+
+	// Validate each item up front so a malformed or oversized vector is
+	// reported and skipped instead of failing the rest of the batch
+	if err := s.resolveInsertVector(req); err != nil {
+		item.err = err.Error()
+		return item, false
+	}
+	if err := validateInsertRequest(req); err != nil {
+		item.err = err.Error()
+		return item, false
+	}
+	if err := s.checkVectorSize(req.Namespace, len(req.Vector)); err != nil {
+		item.err = status.Convert(err).Message()
+		return item, false
+	}
+	if err := s.normalizeInsertVector(req); err != nil {
+		item.err = err.Error()
+		return item, false
+	}
+
+	index, textIndex, _, err := s.getNamespaceIndexes(req.Namespace)
+	if err != nil {
+		item.err = status.Convert(err).Message()
+		return item, false
+	}
+
+	if req.ExternalId != nil {
+		key := [2]string{req.Namespace, *req.ExternalId}
+		if externalIDs[key] {
+			item.err = fmt.Sprintf("external ID %q repeats an earlier item in the batch", *req.ExternalId)
+			return item, false
+		}
+		externalIDs[key] = true
+	}
+
+	item.index = index
+	item.textIndex = textIndex
+	if id, ok := s.upsertTarget(req, index); ok {
+		item.id = id
+		item.inPlace = true
+		return item, true
+	}
+	item.quotaBytes, err = s.admitInsert(req)
+	if err != nil {
+		item.err = status.Convert(err).Message()
+		return item, false
+	}
+	item.id = index.ReserveID()
+	item.claim, err = s.claimExternalID(req, item.id)
+	if err != nil {
+		s.releaseInsert(req.Namespace, item.quotaBytes)
+		item.err = status.Convert(err).Message()
+		return item, false
+	}
+	return item, true
+}
+
+// finishBatch summarizes processed items, then invalidates the result
+// cache and refreshes the index gauges of every namespace they touched
+func (s *Server) finishBatch(items []*batchItem) (inserted, failed int32, insertedIDs, errors []string) {
+	touched := make(map[string]*hnsw.Index)
+	for n, item := range items {
+		if item.err != "" {
+			failed++
+			errors = append(errors, fmt.Sprintf("item %d: %s", n, item.err))
+			continue
+		}
+		inserted++
+		insertedIDs = append(insertedIDs, strconv.FormatUint(item.id, 10))
+		touched[item.req.Namespace] = item.index
+	}
+
+	for ns, index := range touched {
+		s.invalidateResultCache(ns)
+		s.updateIndexMetrics(ns, index)
+	}
+	return inserted, failed, insertedIDs, errors
+}
+
+// insertBatchItem logs and indexes one reserved item, or replaces the
+// stored vector an upsert item targets, recording any failure
+func (s *Server) insertBatchItem(item *batchItem, efConstruction int) {
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
+	if item.inPlace {
+		if err := s.replaceInPlace(item.req, item.index, item.textIndex, item.id); err != nil {
+			item.err = err.Error()
+		}
+		return
+	}
+
+	if err := s.appendWAL(item.req.Namespace, insertRecord(item.req, item.id)); err != nil {
+		item.claim.release()
+		s.releaseInsert(item.req.Namespace, item.quotaBytes)
+		item.err = err.Error()
+		return
+	}
+	if err := item.index.InsertWithIDAndEf(item.id, item.req.Vector, efConstruction); err != nil {
+		item.claim.release()
+		s.releaseInsert(item.req.Namespace, item.quotaBytes)
+		item.err = err.Error()
+		return
+	}
+	s.annInsert(item.req.Namespace, item.id, item.req.Vector)
+	s.storeDocument(item.req, item.id, item.textIndex)
+	s.dropReplaced(item.req.Namespace, item.index, item.textIndex, item.claim)
+}
+
+// skipBatchItem releases the external ID and quota an item reserved, once
+// its batch has aborted before the item was inserted
+func (s *Server) skipBatchItem(item *batchItem) {
+	item.claim.release()
+	s.releaseInsert(item.req.Namespace, item.quotaBytes)
+	item.err = errBatchAborted
+}
+
+// abortBatch deletes every vector an aborted batch inserted, then returns
+// the stream error that aborted it or else the first failed item as an
+// Aborted error. Aborted batches hold no upserts, so deleting the inserted
+// vectors restores each namespace's previous contents.
+func (s *Server) abortBatch(items []*batchItem, streamErr error) error {
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
+	rolledBack := make(map[string]int)
+	touched := make(map[string]*hnsw.Index)
+	cause := ""
+	for n, item := range items {
+		if item.err != "" {
+			if cause == "" && item.err != errBatchAborted {
+				cause = fmt.Sprintf("item %d: %s", n, item.err)
+			}
+			continue
+		}
+
+		ns := item.req.Namespace
+		touched[ns] = item.index
+		deleted, err := s.deleteVector(ns, item.index, item.textIndex, item.id)
+		if err != nil {
+			log.Printf("Warning: failed to roll back vector %d in namespace %s: %v", item.id, ns, err)
+			continue
+		}
+		// Already gone if a concurrent Delete removed it
+		if !deleted {
+			continue
+		}
+		s.removeExternalID(ns, item.id)
+		rolledBack[ns]++
+	}
+
+	total := 0
+	for ns, index := range touched {
+		s.invalidateResultCache(ns)
+		s.recordDelete(ns, index, rolledBack[ns])
+		total += rolledBack[ns]
+	}
+	log.Printf("Batch insert aborted: rolled back %d inserted vectors", total)
+
+	if streamErr != nil {
+		return streamErr
+	}
+	return status.Errorf(codes.Aborted, "batch aborted at %s (rolled back %d inserted vectors)", cause, total)
+}
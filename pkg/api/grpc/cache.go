@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/cache"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+)
+
+// resultCacheKey returns the cache key of a validated search with its
+// converted filter, and the namespace's current generation. ok is false
+// when the search must not be cached: the cache is disabled or the caller
+// asked for a profile, which only a real search can produce.
+func (s *Server) resultCacheKey(req *proto.SearchRequest, filter search.Filter) (key cache.Key, generation uint64, ok bool) {
+	// A reranker may not be deterministic, so its results are never cached
+	if s.resultCache == nil || req.Profile || req.Reranker != "" {
+		return cache.Key{}, 0, false
+	}
+
+	// Key on the canonical form, so reordered And/Or children and
+	// permuted In lists share an entry
+	var filterKey string
+	if filter != nil {
+		filterKey = filter.String()
+	}
+
+	// Key on the threshold min_score resolves to, so equivalent searches share an entry
+	var maxDistance *float32
+	if req.MaxDistance != nil || req.MinScore != nil {
+		metrics, _ := s.metricsFor(req.Namespace)
+		threshold := searchMaxDistance(req, metrics)
+		maxDistance = &threshold
+	}
+
+	// Read the generation first so a write racing with the search discards its result
+	generation = s.resultCache.Generation(req.Namespace)
+	key = cache.Query{
+		Namespace:  req.Namespace,
+		Vector:     req.QueryVector,
+		K:          int(req.K),
+		Offset:     int(req.Offset),
+		EfSearch:   int(req.EfSearch),
+		Filter:     filterKey,
+		GuaranteeK: req.GuaranteeK,
+		CountTotal: req.CountTotal,
+		ScoreMode:  req.ScoreMode,
+		Fields:     req.Fields,
+
+		MaxDistance: maxDistance,
+	}.Key()
+	return key, generation, true
+}
+
+// cachedSearch returns a copy of a cached response, timed from start
+func (s *Server) cachedSearch(key cache.Key, start time.Time) (*proto.SearchResponse, bool) {
+	value, found := s.resultCache.Get(key)
+	if !found {
+		if s.metrics != nil {
+			s.metrics.RecordCacheMiss()
+		}
+		return nil, false
+	}
+	if s.metrics != nil {
+		s.metrics.RecordCacheHit()
+	}
+
+	// Results are shared with the cache and never modified after a search
+	cached := value.(*proto.SearchResponse)
+	searchTime := time.Since(start)
+	s.recordSearch(searchTime, len(cached.Results))
+
+	return &proto.SearchResponse{
+		Results:           cached.Results,
+		TotalResults:      cached.TotalResults,
+		SearchTimeMs:      float32(searchTime.Milliseconds()),
+		Truncated:         cached.Truncated,
+		EffectiveEfSearch: cached.EffectiveEfSearch,
+		Exact:             cached.Exact,
+		TotalMatches:      cached.TotalMatches,
+	}, true
+}
+
+// storeSearch caches a response computed at generation
+func (s *Server) storeSearch(key cache.Key, generation uint64, resp *proto.SearchResponse) {
+	s.resultCache.Put(key, generation, resp)
+	if s.metrics != nil {
+		s.metrics.UpdateCacheSize(s.resultCache.Len())
+	}
+}
+
+// invalidateResultCache drops a namespace's cached results after a write
+func (s *Server) invalidateResultCache(namespace string) {
+	if s.resultCache == nil {
+		return
+	}
+	s.resultCache.Invalidate(namespace)
+	if s.metrics != nil {
+		s.metrics.UpdateCacheSize(s.resultCache.Len())
+	}
+}
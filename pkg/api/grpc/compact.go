@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Compact rebuilds a namespace's HNSW graph from its live vectors. Writes
+// to every namespace wait until it finishes, while searches keep using the
+// old graph until the rebuilt one is swapped in. Vector IDs are kept, so
+// metadata, external IDs and the WAL are unaffected.
+func (s *Server) Compact(ctx context.Context, req *proto.CompactRequest) (*proto.CompactResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.RLock()
+	index := s.indexes[req.Namespace]
+	s.mu.RUnlock()
+
+	if index == nil {
+		return nil, status.Errorf(codes.NotFound, "namespace %q not found", req.Namespace)
+	}
+
+	start := time.Now()
+	stats, err := index.Compact()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	compactTime := time.Since(start)
+
+	// The rebuilt graph may order near-ties differently
+	s.invalidateResultCache(req.Namespace)
+	s.updateIndexMetrics(req.Namespace, index)
+	s.syncQuota(req.Namespace, index)
+
+	log.Printf("Compacted namespace %s: %d nodes, %d deletes reclaimed, memory %d -> %d bytes (took %v)",
+		req.Namespace, stats.NodesAfter, stats.Deleted, stats.MemoryBefore, stats.MemoryAfter, compactTime)
+
+	return &proto.CompactResponse{
+		NodesBefore:       stats.NodesBefore,
+		NodesAfter:        stats.NodesAfter,
+		DeletedNodes:      stats.Deleted,
+		MemoryBeforeBytes: stats.MemoryBefore,
+		MemoryAfterBytes:  stats.MemoryAfter,
+		CompactTimeMs:     float32(compactTime.Seconds() * 1000),
+	}, nil
+}
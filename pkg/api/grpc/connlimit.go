@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rejectGrace is how long a connection over the limit stays open so its
+// RPCs can fail with ResourceExhausted instead of a bare connection reset
+const rejectGrace = time.Second
+
+// connLimitListener enforces Server.MaxConnections. Connections accepted
+// beyond the limit are still handed to gRPC, which has no way to refuse a
+// connection with a status, but they are marked rejected: their RPCs fail
+// with ResourceExhausted and the connection is closed after rejectGrace.
+type connLimitListener struct {
+	net.Listener
+	limit int
+
+	mu     sync.Mutex
+	active int                     // Open connections within the limit
+	conns  map[string]*limitedConn // remote address -> open connection
+}
+
+// newConnLimitListener limits the connections accepted by l to limit
+func newConnLimitListener(l net.Listener, limit int) *connLimitListener {
+	return &connLimitListener{
+		Listener: l,
+		limit:    limit,
+		conns:    make(map[string]*limitedConn),
+	}
+}
+
+// Accept admits the next connection, or marks it rejected if the limit
+// is reached
+func (l *connLimitListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &limitedConn{Conn: conn, listener: l}
+	l.mu.Lock()
+	if l.active >= l.limit {
+		c.rejected = true
+	} else {
+		l.active++
+	}
+	l.conns[conn.RemoteAddr().String()] = c
+	l.mu.Unlock()
+
+	if c.rejected {
+		time.AfterFunc(rejectGrace, func() { c.Close() })
+	}
+	return c, nil
+}
+
+// Active returns the number of open connections within the limit
+func (l *connLimitListener) Active() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active
+}
+
+// rejected reports whether the connection an RPC arrived on is over the limit
+func (l *connLimitListener) rejected(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.conns[p.Addr.String()]
+	return c != nil && c.rejected
+}
+
+// release forgets a closed connection
+func (l *connLimitListener) release(c *limitedConn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conns[c.RemoteAddr().String()] == c {
+		delete(l.conns, c.RemoteAddr().String())
+	}
+	if !c.rejected {
+		l.active--
+	}
+}
+
+// limitedConn releases its slot in the listener when closed
+type limitedConn struct {
+	net.Conn
+	listener  *connLimitListener
+	rejected  bool
+	closeOnce sync.Once
+}
+
+// Close closes the connection and frees its slot
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(func() { c.listener.release(c) })
+	return err
+}
+
+// errTooManyConnections is returned for RPCs on connections over the limit
+func (s *Server) errTooManyConnections() error {
+	return status.Errorf(codes.ResourceExhausted, "too many connections (limit %d); retry later", s.config.Server.MaxConnections)
+}
+
+// connLimitUnaryInterceptor fails unary RPCs on rejected connections
+func (s *Server) connLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.connLimit.rejected(ctx) {
+		return nil, s.errTooManyConnections()
+	}
+	return handler(ctx, req)
+}
+
+// connLimitStreamInterceptor fails streaming RPCs on rejected connections
+func (s *Server) connLimitStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if s.connLimit.rejected(ss.Context()) {
+		return s.errTooManyConnections()
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Dimension mismatch policies
+const (
+	DimensionPolicyStrict           = "strict"             // Refuse with InvalidArgument naming expected vs actual
+	DimensionPolicyRejectWithDetail = "reject-with-detail" // Refuse and attach structured error details
+)
+
+// SetDimensionPolicy overrides the dimension mismatch policy for a namespace
+func (s *Server) SetDimensionPolicy(namespace, policy string) error {
+	switch policy {
+	case DimensionPolicyStrict, DimensionPolicyRejectWithDetail:
+	default:
+		return fmt.Errorf("unknown dimension policy: %q", policy)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dimensionPolicies[namespace] = policy
+	return nil
+}
+
+// dimensionPolicy returns the policy for a namespace, falling back to the configured default
+func (s *Server) dimensionPolicy(namespace string) string {
+	s.mu.RLock()
+	policy, ok := s.dimensionPolicies[namespace]
+	s.mu.RUnlock()
+
+	if ok {
+		return policy
+	}
+	if s.config.HNSW.DimensionPolicy != "" {
+		return s.config.HNSW.DimensionPolicy
+	}
+	return DimensionPolicyStrict
+}
+
+// dimensionsLocked returns the dimension a namespace's vectors must have:
+// the index's once it holds vectors, else the declared one (0 = any). The
+// caller holds s.mu.
+func (s *Server) dimensionsLocked(namespace string, index *hnsw.Index) int {
+	if d := index.Dimension(); d > 0 {
+		return d
+	}
+	return s.indexConfigs[namespace].Dimensions
+}
+
+// checkVectorSize rejects vectors longer than the configured maximum or not
+// matching the namespace dimension. It runs before the namespace is created,
+// so an oversized vector never reaches the index.
+func (s *Server) checkVectorSize(namespace string, actual int) error {
+	if max := s.config.HNSW.MaxDimensions; max > 0 && actual > max {
+		return status.Errorf(codes.InvalidArgument,
+			"vector has %d dimensions, exceeds maximum of %d", actual, max)
+	}
+
+	s.mu.RLock()
+	index := s.indexes[namespace]
+	s.mu.RUnlock()
+
+	if index == nil {
+		return nil
+	}
+	return s.checkDimension(namespace, index, actual)
+}
+
+// checkDimension verifies a vector matches the namespace dimension.
+// Empty namespaces accept any dimension unless one was declared; the first
+// insert fixes it. Returns a gRPC status error on mismatch.
+func (s *Server) checkDimension(namespace string, index *hnsw.Index, actual int) error {
+	s.mu.RLock()
+	expected := s.dimensionsLocked(namespace, index)
+	s.mu.RUnlock()
+	if expected == 0 || expected == actual {
+		return nil
+	}
+
+	if s.dimensionPolicy(namespace) != DimensionPolicyRejectWithDetail {
+		return status.Errorf(codes.InvalidArgument,
+			"vector dimension mismatch: expected %d, got %d", expected, actual)
+	}
+
+	diff := actual - expected
+	hint := fmt.Sprintf("%d too many", diff)
+	if diff < 0 {
+		hint = fmt.Sprintf("%d too few", -diff)
+	}
+	msg := fmt.Sprintf("vector dimension mismatch in namespace %q: expected %d, got %d (%s); "+
+		"the vector was likely produced by a different embedding model version", namespace, expected, actual, hint)
+
+	st := status.New(codes.InvalidArgument, msg)
+	detailed, err := st.WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       "vector",
+				Description: fmt.Sprintf("expected %d dimensions, got %d", expected, actual),
+			}},
+		},
+		&errdetails.ErrorInfo{
+			Reason: "DIMENSION_MISMATCH",
+			Domain: "vector",
+			Metadata: map[string]string{
+				"namespace":          namespace,
+				"expected_dimension": strconv.Itoa(expected),
+				"actual_dimension":   strconv.Itoa(actual),
+			},
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"fmt"
+	"math"
+)
+
+// SetEfSearchMultiplier overrides the efSearch multiplier for a namespace.
+// Searches use efSearch = max(requested, k * multiplier); 0 disables scaling.
+func (s *Server) SetEfSearchMultiplier(namespace string, multiplier float64) error {
+	if multiplier < 0 || math.IsNaN(multiplier) || math.IsInf(multiplier, 0) {
+		return fmt.Errorf("invalid efSearch multiplier: %v (must be >= 0)", multiplier)
+	}
+
+	s.mu.Lock()
+	s.efSearchMultipliers[namespace] = multiplier
+	s.mu.Unlock()
+
+	s.invalidateResultCache(namespace)
+	return nil
+}
+
+// efSearchMultiplier returns the multiplier for a namespace, falling back to the configured default
+func (s *Server) efSearchMultiplier(namespace string) float64 {
+	s.mu.RLock()
+	multiplier, ok := s.efSearchMultipliers[namespace]
+	s.mu.RUnlock()
+
+	if ok {
+		return multiplier
+	}
+	return s.config.HNSW.EfSearchMultiplier
+}
+
+// effectiveEfSearch scales efSearch with k so large-k queries probe deeper
+func (s *Server) effectiveEfSearch(namespace string, efSearch, k int) int {
+	return scaleEfSearch(efSearch, k, s.efSearchMultiplier(namespace))
+}
+
+// scaleEfSearch returns max(efSearch, k * multiplier, k)
+func scaleEfSearch(efSearch, k int, multiplier float64) int {
+	if scaled := int(math.Ceil(float64(k) * multiplier)); scaled > efSearch {
+		efSearch = scaled
+	}
+	// The index never searches with fewer than k candidates
+	if efSearch < k {
+		efSearch = k
+	}
+	return efSearch
+}
+
+// searchLimits holds a namespace's efSearch default and the largest k and
+// ef_search its searches may request (0 = unlimited)
+type searchLimits struct {
+	DefaultEfSearch int
+	MaxK            int
+	MaxEfSearch     int
+}
+
+// searchLimits returns the HNSW defaults and limits with the namespace's
+// overrides applied: zero keeps the global setting and a negative limit is
+// lifted
+func (s *Server) searchLimits(namespace string) searchLimits {
+	limits := searchLimits{
+		DefaultEfSearch: s.config.HNSW.DefaultEfSearch,
+		MaxK:            s.config.HNSW.MaxK,
+		MaxEfSearch:     s.config.HNSW.MaxEfSearch,
+	}
+	if override, ok := s.config.HNSW.Namespaces[namespace]; ok {
+		if override.DefaultEfSearch > 0 {
+			limits.DefaultEfSearch = override.DefaultEfSearch
+		}
+		if override.MaxK != 0 {
+			limits.MaxK = override.MaxK
+		}
+		if override.MaxEfSearch != 0 {
+			limits.MaxEfSearch = override.MaxEfSearch
+		}
+	}
+	if limits.MaxK < 0 {
+		limits.MaxK = 0
+	}
+	if limits.MaxEfSearch < 0 {
+		limits.MaxEfSearch = 0
+	}
+	return limits
+}
+
+// check rejects a requested k or ef_search above the limits
+func (l searchLimits) check(k, efSearch int) error {
+	if l.MaxK > 0 && k > l.MaxK {
+		return fmt.Errorf("k = %d exceeds the maximum of %d", k, l.MaxK)
+	}
+	if l.MaxEfSearch > 0 && efSearch > l.MaxEfSearch {
+		return fmt.Errorf("ef_search = %d exceeds the maximum of %d", efSearch, l.MaxEfSearch)
+	}
+	return nil
+}
+
+// efSearch returns the requested efSearch, or the default when it is 0
+func (l searchLimits) efSearch(requested int) int {
+	if requested == 0 {
+		return l.DefaultEfSearch
+	}
+	return requested
+}
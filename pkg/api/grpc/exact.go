@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+)
+
+// useExactSearch reports whether a namespace is small enough that a
+// brute-force scan is cheaper than a graph search, or has no graph yet
+// (see HNSWConfig.FlatThreshold)
+func (s *Server) useExactSearch(index *hnsw.Index) bool {
+	threshold := s.config.HNSW.ExactSearchThreshold
+	return (threshold > 0 && index.Size() <= int64(threshold)) || index.Flat()
+}
+
+// countMatches counts the namespace's vectors that pass filter, or all of
+// them without one. A filtered count evaluates every vector's metadata.
+func (s *Server) countMatches(namespace string, index *hnsw.Index, filter search.Filter) int64 {
+	if filter == nil {
+		return index.Size()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int64
+	for _, metadata := range s.metadata[namespace] {
+		if filter.Match(metadata) {
+			count++
+		}
+	}
+	return count
+}
+
+// exactSearch scans the whole namespace. With a filter every vector is
+// ranked first, so up to k matches are found whenever they exist.
+func (s *Server) exactSearch(namespace string, index *hnsw.Index, query []float32, k int, filter search.Filter, prof *searchProfile) ([]hnsw.Result, error) {
+	fetch := k
+	if filter != nil {
+		fetch = int(index.Size())
+	}
+
+	searchResult, err := index.ExactSearchWithProfile(query, fetch, prof.hnswProfile())
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.filterResults(namespace, searchResult.Results, filter, prof)
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results, nil
+}
@@ -0,0 +1,204 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultExportBatchSize is the number of vectors per Export batch when the
+// request sets none
+const defaultExportBatchSize = 1000
+
+// Scan page sizes
+const (
+	defaultScanPageSize = 100
+	maxScanPageSize     = 10000
+)
+
+// Export implements the Export RPC. The IDs to send are listed when the
+// export starts: vectors inserted later are not included, and vectors
+// deleted before their batch is read are skipped. Each batch is read under
+// the read locks, so writes proceed between batches.
+//
+// The cursor is the last ID of a batch, so an export resumed from it
+// continues with the next ID whatever was written in between.
+func (s *Server) Export(req *proto.ExportRequest, stream proto.VectorDB_ExportServer) error {
+	if req.Namespace == "" {
+		return status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if req.Limit < 0 {
+		return status.Error(codes.InvalidArgument, "limit must be >= 0")
+	}
+	if req.BatchSize < 0 {
+		return status.Error(codes.InvalidArgument, "batch_size must be >= 0")
+	}
+
+	ids, err := s.exportIDs(req)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	index := s.indexes[req.Namespace]
+	textIndex := s.textIndexes[req.Namespace]
+	externalIDs := s.externalIDs[req.Namespace]
+	s.mu.RUnlock()
+
+	batchSize := int(req.BatchSize)
+	if batchSize == 0 {
+		batchSize = defaultExportBatchSize
+	}
+
+	total := int64(len(ids))
+	cursor := req.Cursor
+	var exported int64
+	for {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		n := batchSize
+		if n > len(ids) {
+			n = len(ids)
+		}
+		vectors := s.storedVectors(req.Namespace, index, textIndex, externalIDs, ids[:n], true)
+		if n > 0 {
+			cursor = strconv.FormatUint(ids[n-1], 10)
+		}
+		ids = ids[n:]
+		exported += int64(len(vectors))
+
+		if err := stream.Send(&proto.ExportBatch{
+			Vectors:    vectors,
+			NextCursor: cursor,
+			Done:       len(ids) == 0,
+			Total:      total,
+		}); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			break
+		}
+	}
+
+	log.Printf("Exported %d vectors from namespace %s", exported, req.Namespace)
+	return nil
+}
+
+// exportIDs lists the IDs an export sends: those after the cursor, in
+// ascending order, up to the limit
+func (s *Server) exportIDs(req *proto.ExportRequest) ([]uint64, error) {
+	s.mu.RLock()
+	index, exists := s.indexes[req.Namespace]
+	s.mu.RUnlock()
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "namespace %q not found", req.Namespace)
+	}
+
+	ids := index.IDs()
+	if req.Cursor != "" {
+		after, err := strconv.ParseUint(req.Cursor, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor %q", req.Cursor)
+		}
+		ids = ids[sort.Search(len(ids), func(i int) bool { return ids[i] > after }):]
+	}
+	if req.Limit > 0 && int64(len(ids)) > req.Limit {
+		ids = ids[:req.Limit]
+	}
+	return ids, nil
+}
+
+// Scan implements the Scan RPC. Each page lists the IDs after the cursor
+// afresh, so pages stay consistent under concurrent writes: a vector is
+// returned at most once, vectors inserted mid-scan are returned by a later
+// page (IDs only grow), and vectors deleted before their page are skipped.
+func (s *Server) Scan(ctx context.Context, req *proto.ScanRequest) (*proto.ScanResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if req.PageSize < 0 || req.PageSize > maxScanPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 0 and %d", maxScanPageSize)
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultScanPageSize
+	}
+
+	var from uint64
+	if req.Cursor != "" {
+		after, err := strconv.ParseUint(req.Cursor, 10, 64)
+		if err != nil || after == math.MaxUint64 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor %q", req.Cursor)
+		}
+		from = after + 1
+	}
+
+	s.mu.RLock()
+	index, exists := s.indexes[req.Namespace]
+	textIndex := s.textIndexes[req.Namespace]
+	externalIDs := s.externalIDs[req.Namespace]
+	s.mu.RUnlock()
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "namespace %q not found", req.Namespace)
+	}
+
+	// One ID past the page tells whether another page follows
+	ids := index.IDsFrom(from, pageSize+1)
+	resp := &proto.ScanResponse{}
+	if len(ids) > pageSize {
+		ids = ids[:pageSize]
+		resp.NextCursor = strconv.FormatUint(ids[pageSize-1], 10)
+	}
+	resp.Results = s.storedVectors(req.Namespace, index, textIndex, externalIDs, ids, req.IncludeVector)
+	return resp, nil
+}
+
+// storedVectors reads stored vectors with their metadata, text and external
+// IDs under the read lock, skipping any deleted since the IDs were listed.
+// Vector values are left out unless withVectors is set.
+func (s *Server) storedVectors(namespace string, index *hnsw.Index, textIndex *search.FullTextIndex, externalIDs *idMap, ids []uint64, withVectors bool) []*proto.FetchResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vectors := make([]*proto.FetchResult, 0, len(ids))
+	for _, id := range ids {
+		node := index.GetNode(id)
+		if node == nil {
+			continue
+		}
+
+		metadataProto, typedProto := metadataToProto(s.metadata[namespace][id])
+		result := &proto.FetchResult{
+			Id:            strconv.FormatUint(id, 10),
+			Found:         true,
+			Metadata:      metadataProto,
+			TypedMetadata: typedProto,
+		}
+		if withVectors {
+			result.Vector = make([]float32, len(node.Vector()))
+			copy(result.Vector, node.Vector())
+		}
+		if textIndex != nil {
+			if doc := textIndex.GetDocument(id); doc != nil {
+				result.Text = stringPtr(doc.Text)
+			}
+		}
+		if externalIDs != nil {
+			if externalID, ok := externalIDs.ExternalID(id); ok {
+				result.ExternalId = stringPtr(externalID)
+			}
+		}
+		vectors = append(vectors, result)
+	}
+	return vectors
+}
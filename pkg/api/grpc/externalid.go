@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Vectors inserted with an external_id are keyed by it in the namespace's
+// idMap. Requests that take a vector ID accept either form: a registered
+// external ID resolves first, so a numeric external ID shadows the
+// internal ID with the same digits.
+
+// externalIDMap returns a namespace's external ID map, or nil if the
+// namespace does not exist
+func (s *Server) externalIDMap(namespace string) *idMap {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.externalIDs[namespace]
+}
+
+// resolveID maps a requested vector ID, external or internal, to the
+// internal ID. It reports false if the ID is neither.
+func (s *Server) resolveID(namespace, rawID string) (uint64, bool) {
+	if ids := s.externalIDMap(namespace); ids != nil {
+		if id, ok := ids.Get(rawID); ok {
+			return id, true
+		}
+	}
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	return id, err == nil
+}
+
+// externalIDOf returns the external ID a vector was inserted with, if any
+func (s *Server) externalIDOf(namespace string, id uint64) *string {
+	ids := s.externalIDMap(namespace)
+	if ids == nil {
+		return nil
+	}
+	if externalID, ok := ids.ExternalID(id); ok {
+		return stringPtr(externalID)
+	}
+	return nil
+}
+
+// externalClaim is an external ID mapped to a reserved ID ahead of the insert
+type externalClaim struct {
+	ids        *idMap
+	externalID string
+	id         uint64
+	previous   uint64 // Internal ID an upsert took the external ID from
+	replaced   bool
+}
+
+// claimExternalID maps an insert's external ID, if it has one, to the
+// reserved ID. A taken external ID fails with AlreadyExists unless the
+// request upserts; a full map fails with ResourceExhausted.
+func (s *Server) claimExternalID(req *proto.InsertRequest, id uint64) (*externalClaim, error) {
+	if req.ExternalId == nil {
+		return nil, nil
+	}
+
+	ids := s.externalIDMap(req.Namespace)
+	if ids == nil {
+		return nil, status.Errorf(codes.NotFound, "namespace %s was dropped", req.Namespace)
+	}
+
+	previous, replaced, err := ids.Claim(*req.ExternalId, id, req.Upsert)
+	switch {
+	case errors.Is(err, ErrExternalIDExists):
+		return nil, status.Errorf(codes.AlreadyExists, "external ID %q already exists in namespace %s", *req.ExternalId, req.Namespace)
+	case errors.Is(err, ErrIDMapFull):
+		return nil, status.Errorf(codes.ResourceExhausted, "cannot add external ID %q: %v", *req.ExternalId, err)
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &externalClaim{
+		ids:        ids,
+		externalID: *req.ExternalId,
+		id:         id,
+		previous:   previous,
+		replaced:   replaced,
+	}, nil
+}
+
+// release undoes the claim of an insert that failed
+func (c *externalClaim) release() {
+	if c != nil {
+		c.ids.Unclaim(c.externalID, c.id, c.previous, c.replaced)
+	}
+}
+
+// dropReplaced deletes the vector an upsert took the external ID from.
+// Upserts of a stored vector replace it in place, so this only happens
+// when upserts of a new external ID race and both insert; the later claim
+// wins. The upsert has already succeeded, so a failure to log the delete
+// is only logged, and the vector kept. A loser whose insert is still in
+// flight may stay stored without an external ID, as an evicted mapping does.
+func (s *Server) dropReplaced(namespace string, index *hnsw.Index, textIndex *search.FullTextIndex, c *externalClaim) {
+	if c == nil || !c.replaced {
+		return
+	}
+
+	deleted, err := s.deleteVector(namespace, index, textIndex, c.previous)
+	if err != nil {
+		log.Printf("Warning: failed to delete replaced vector %d in namespace %s: %v", c.previous, namespace, err)
+		return
+	}
+	// Already gone if it was deleted by its internal ID
+	if !deleted {
+		return
+	}
+	s.recordDelete(namespace, index, 1)
+}
+
+// removeExternalID drops the external ID of a deleted vector
+func (s *Server) removeExternalID(namespace string, id uint64) {
+	if ids := s.externalIDMap(namespace); ids != nil {
+		ids.RemoveInternal(id)
+	}
+}
+
+// restoreExternalID maps a recovered vector's external ID without the
+// uniqueness check, since the logged insert already passed it
+func (s *Server) restoreExternalID(namespace, externalID string, id uint64) {
+	ids := s.externalIDMap(namespace)
+	if ids == nil {
+		return
+	}
+	if _, err := ids.Put(externalID, id); err != nil {
+		log.Printf("Warning: dropping external ID %q of vector %d in namespace %s: %v", externalID, id, namespace, err)
+	}
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result field names accepted in SearchRequest.fields. Any other field
+// must name a metadata key as "metadata.<key>".
+const (
+	FieldVector   = "vector"
+	FieldText     = "text"
+	FieldMetadata = "metadata"
+)
+
+// resultFields is a parsed result field projection. IDs, distances, scores
+// and external IDs are always returned; a nil *resultFields returns the
+// vector and all metadata, as when no fields are requested.
+type resultFields struct {
+	vector       bool
+	text         bool
+	allMetadata  bool
+	metadataKeys map[string]bool
+}
+
+// parseResultFields parses a request's fields, returning nil when it asks
+// for none
+func parseResultFields(fields []string) (*resultFields, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	f := &resultFields{}
+	for _, field := range fields {
+		switch field {
+		case FieldVector:
+			f.vector = true
+		case FieldText:
+			f.text = true
+		case FieldMetadata:
+			f.allMetadata = true
+		default:
+			key, ok := strings.CutPrefix(field, FieldMetadata+".")
+			if !ok || key == "" {
+				return nil, fmt.Errorf("invalid field %q: expected %q, %q, %q or \"metadata.<key>\"",
+					field, FieldVector, FieldText, FieldMetadata)
+			}
+			if f.metadataKeys == nil {
+				f.metadataKeys = make(map[string]bool)
+			}
+			f.metadataKeys[key] = true
+		}
+	}
+	return f, nil
+}
+
+// includeVector reports whether results carry their vector
+func (f *resultFields) includeVector() bool {
+	return f == nil || f.vector
+}
+
+// includeText reports whether results carry their text. Results only
+// carry text when it is requested.
+func (f *resultFields) includeText() bool {
+	return f != nil && f.text
+}
+
+// projectMetadata returns the requested subset of a result's metadata
+func (f *resultFields) projectMetadata(meta map[string]interface{}) map[string]interface{} {
+	if f == nil || f.allMetadata {
+		return meta
+	}
+	projected := make(map[string]interface{}, len(f.metadataKeys))
+	for key := range f.metadataKeys {
+		if v, ok := meta[key]; ok {
+			projected[key] = v
+		}
+	}
+	return projected
+}
@@ -2,15 +2,21 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"runtime"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/wal"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -19,6 +25,17 @@ import (
 func (s *Server) Insert(ctx context.Context, req *proto.InsertRequest) (*proto.InsertResponse, error) {
 	start := time.Now()
 
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
+	// Decode a quantized vector before validation looks at it
+	if err := s.resolveInsertVector(req); err != nil {
+		return &proto.InsertResponse{
+			Success: false,
+			Error:   stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Validate request
 	if err := validateInsertRequest(req); err != nil {
 		return &proto.InsertResponse{
@@ -27,13 +44,29 @@ func (s *Server) Insert(ctx context.Context, req *proto.InsertRequest) (*proto.I
 		}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Enforce the maximum and namespace dimension
+	if err := s.checkVectorSize(req.Namespace, len(req.Vector)); err != nil {
+		return &proto.InsertResponse{
+			Success: false,
+			Error:   stringPtr(status.Convert(err).Message()),
+		}, err
+	}
+
+	// Scale to unit length in cosine namespaces that normalize on insert
+	if err := s.normalizeInsertVector(req); err != nil {
+		return &proto.InsertResponse{
+			Success: false,
+			Error:   stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Get indexes for namespace
 	index, textIndex, _, err := s.getNamespaceIndexes(req.Namespace)
 	if err != nil {
 		return &proto.InsertResponse{
 			Success: false,
-			Error:   stringPtr(err.Error()),
-		}, status.Error(codes.Internal, err.Error())
+			Error:   stringPtr(status.Convert(err).Message()),
+		}, namespaceStatus(err)
 	}
 
 	// Convert to float32 vector
@@ -42,26 +75,91 @@ func (s *Server) Insert(ctx context.Context, req *proto.InsertRequest) (*proto.I
 		vector[i] = v
 	}
 
-	// Insert into HNSW index
-	id, err := index.Insert(vector)
+	// An upsert of a stored vector replaces it under the same ID
+	if id, ok := s.upsertTarget(req, index); ok {
+		if err := s.replaceInPlace(req, index, textIndex, id); err != nil {
+			return &proto.InsertResponse{
+				Success: false,
+				Error:   stringPtr(err.Error()),
+			}, status.Error(codes.Internal, err.Error())
+		}
+		s.invalidateResultCache(req.Namespace)
+		s.recordUpdate(req.Namespace, index, 1)
+
+		log.Printf("Upserted vector %d in namespace %s (took %v)", id, req.Namespace, time.Since(start))
+
+		return &proto.InsertResponse{
+			Id:      strconv.FormatUint(id, 10),
+			Success: true,
+		}, nil
+	}
+
+	// Count the new vector against the namespace's quotas
+	quotaBytes, err := s.admitInsert(req)
+	if err != nil {
+		return &proto.InsertResponse{
+			Success: false,
+			Error:   stringPtr(status.Convert(err).Message()),
+		}, err
+	}
+
+	// Log the insert under a reserved ID before indexing it, so a delete
+	// of the new vector can never be logged ahead of its insert
+	id := index.ReserveID()
+
+	// Claim the external ID first so a duplicate is rejected before
+	// anything is logged
+	claim, err := s.claimExternalID(req, id)
 	if err != nil {
+		s.releaseInsert(req.Namespace, quotaBytes)
+		return &proto.InsertResponse{
+			Success: false,
+			Error:   stringPtr(status.Convert(err).Message()),
+		}, err
+	}
+
+	if err := s.appendWAL(req.Namespace, insertRecord(req, id)); err != nil {
+		claim.release()
+		s.releaseInsert(req.Namespace, quotaBytes)
 		return &proto.InsertResponse{
 			Success: false,
 			Error:   stringPtr(err.Error()),
 		}, status.Error(codes.Internal, err.Error())
 	}
 
-	// Store metadata
+	// Insert into HNSW index
+	if err := index.InsertWithIDAndEf(id, vector, int(req.EfConstruction)); err != nil {
+		claim.release()
+		s.releaseInsert(req.Namespace, quotaBytes)
+		return &proto.InsertResponse{
+			Success: false,
+			Error:   stringPtr(err.Error()),
+		}, status.Error(codes.Internal, err.Error())
+	}
+	s.annInsert(req.Namespace, id, vector)
+
+	s.storeDocument(req, id, textIndex)
+	s.dropReplaced(req.Namespace, index, textIndex, claim)
+	s.invalidateResultCache(req.Namespace)
+	s.recordInsert(req.Namespace, index, 1)
+
+	log.Printf("Inserted vector %d in namespace %s (took %v)", id, req.Namespace, time.Since(start))
+
+	return &proto.InsertResponse{
+		Id:      strconv.FormatUint(id, 10),
+		Success: true,
+	}, nil
+}
+
+// storeDocument records metadata for an inserted vector and indexes its text
+func (s *Server) storeDocument(req *proto.InsertRequest, id uint64, textIndex *search.FullTextIndex) {
 	s.mu.Lock()
 	metadataStore := s.metadata[req.Namespace]
 	if metadataStore == nil {
 		metadataStore = make(map[uint64]map[string]interface{})
 		s.metadata[req.Namespace] = metadataStore
 	}
-	metaMap := make(map[string]interface{})
-	for k, v := range req.Metadata {
-		metaMap[k] = v
-	}
+	metaMap := documentMetadata(req.Metadata, typedMetadataValues(req.TypedMetadata))
 	metadataStore[id] = metaMap
 	s.mu.Unlock()
 
@@ -77,32 +175,236 @@ func (s *Server) Insert(ctx context.Context, req *proto.InsertRequest) (*proto.I
 			log.Printf("Warning: failed to index text for vector %d: %v", id, err)
 		}
 	}
+}
 
-	log.Printf("Inserted vector %d in namespace %s (took %v)", id, req.Namespace, time.Since(start))
+// insertRecord builds the write-ahead log record for an insert
+func insertRecord(req *proto.InsertRequest, id uint64) *wal.Record {
+	return &wal.Record{
+		Op:            wal.OpInsert,
+		ID:            id,
+		Vector:        req.Vector,
+		Metadata:      req.Metadata,
+		TypedMetadata: typedMetadataValues(req.TypedMetadata),
+		Text:          req.GetText(),
+		ExternalID:    req.GetExternalId(),
+	}
+}
 
-	return &proto.InsertResponse{
-		Id:      strconv.FormatUint(id, 10),
-		Success: true,
-	}, nil
+// updateDocument replaces the metadata and/or text of a stored vector.
+// Empty metadata and typed metadata, or a nil or empty text, leaves that
+// part unchanged.
+func (s *Server) updateDocument(namespace string, textIndex *search.FullTextIndex, id uint64, metadata map[string]string, typed map[string]interface{}, text *string) {
+	// Update metadata if provided
+	if len(metadata) > 0 || len(typed) > 0 {
+		s.mu.Lock()
+		if metadataStore, ok := s.metadata[namespace]; ok {
+			metadataStore[id] = documentMetadata(metadata, typed)
+		}
+		s.mu.Unlock()
+	}
+
+	// Update text index if text provided
+	if text != nil && *text != "" {
+		// Remove old document
+		textIndex.Remove(id)
+
+		// Get updated metadata
+		s.mu.RLock()
+		var meta map[string]interface{}
+		if metadataStore, ok := s.metadata[namespace]; ok {
+			if m, ok := metadataStore[id]; ok {
+				meta = m
+			}
+		}
+		s.mu.RUnlock()
+
+		// Index new document
+		doc := &search.Document{
+			ID:       id,
+			Text:     *text,
+			Metadata: meta,
+		}
+
+		if err := textIndex.Index(doc); err != nil {
+			log.Printf("Warning: failed to update text for vector %d: %v", id, err)
+		}
+	}
+}
+
+// mergeDocument updates a vector's text like updateDocument, but merges
+// metadata into its stored metadata and then deletes removeKeys from it.
+// The merge runs under the write lock, so concurrent merges of different
+// keys all apply. The stored map is replaced rather than modified, since
+// readers may still hold it.
+func (s *Server) mergeDocument(namespace string, textIndex *search.FullTextIndex, id uint64, metadata map[string]string, typed map[string]interface{}, removeKeys []string, text *string) {
+	if len(metadata) > 0 || len(typed) > 0 || len(removeKeys) > 0 {
+		s.mu.Lock()
+		if metadataStore, ok := s.metadata[namespace]; ok {
+			merged := documentMetadata(metadata, typed)
+			for k, v := range metadataStore[id] {
+				if _, ok := merged[k]; !ok {
+					merged[k] = v
+				}
+			}
+			for _, k := range removeKeys {
+				delete(merged, k)
+			}
+			metadataStore[id] = merged
+		}
+		s.mu.Unlock()
+	}
+
+	s.updateDocument(namespace, textIndex, id, nil, nil, text)
+}
+
+// removeDocument drops the text and metadata of a deleted vector
+func (s *Server) removeDocument(namespace string, textIndex *search.FullTextIndex, id uint64) {
+	// Delete from text index
+	textIndex.Remove(id)
+
+	// Delete metadata
+	s.mu.Lock()
+	if metadataStore, ok := s.metadata[namespace]; ok {
+		delete(metadataStore, id)
+	}
+	s.mu.Unlock()
+}
+
+// deleteVector logs the delete of a stored vector, then removes it from the
+// index, the quantized index and the document stores. It reports false and
+// logs nothing when the vector is not stored. The external ID is left to
+// the caller.
+func (s *Server) deleteVector(namespace string, index *hnsw.Index, textIndex *search.FullTextIndex, id uint64) (bool, error) {
+	defer s.lockVector(namespace, id)()
+
+	if index.GetNode(id) == nil {
+		return false, nil
+	}
+	if err := s.appendWAL(namespace, &wal.Record{Op: wal.OpDelete, ID: id}); err != nil {
+		return false, err
+	}
+	if err := index.Delete(id); err != nil {
+		return false, err
+	}
+	s.annRemove(namespace, id)
+	s.removeDocument(namespace, textIndex, id)
+	return true, nil
 }
 
 // Search implements the Search RPC
 func (s *Server) Search(ctx context.Context, req *proto.SearchRequest) (*proto.SearchResponse, error) {
 	start := time.Now()
 
+	resp, err := s.search(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	log.Printf("Search in namespace %s returned %d results (took %v)", req.Namespace, len(resp.Results), time.Since(start))
+	return resp, nil
+}
+
+// search runs one k-NN query; Search and BatchSearch share it
+func (s *Server) search(ctx context.Context, req *proto.SearchRequest) (resp *proto.SearchResponse, err error) {
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, "vector.Search")
+	resultCount := 0
+	defer func() { endSpan(span, resultCount, err) }()
+
+	// Decode a quantized query before validation looks at it
+	if err := s.resolveQueryVector(req); err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Validate request
-	if err := validateSearchRequest(req); err != nil {
+	if err := validateSearchRequest(req, s.searchLimits(req.Namespace)); err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// as_of searches check the snapshot's dimension instead
+	if req.AsOf == 0 {
+		if err := s.checkVectorSize(req.Namespace, len(req.QueryVector)); err != nil {
+			return &proto.SearchResponse{
+				Error: stringPtr(status.Convert(err).Message()),
+			}, err
+		}
+	}
+	if req.RerankDepth < 0 {
+		err := fmt.Errorf("rerank_depth must be >= 0")
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	reranker, err := s.rerankerFor(req.Reranker)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	fields, err := parseResultFields(req.Fields)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if depth := int64(req.Offset) + int64(req.K); depth > int64(s.config.HNSW.SearchMaxWindow) {
+		err := fmt.Errorf("offset + k = %d exceeds the maximum of %d", depth, s.config.HNSW.SearchMaxWindow)
 		return &proto.SearchResponse{
 			Error: stringPtr(err.Error()),
 		}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Serve point-in-time searches from a snapshot rather than the live
+	// namespace; they bypass the result cache
+	if req.AsOf != 0 {
+		resp, err = s.searchAsOf(ctx, req, fields, start)
+		if err != nil {
+			return &proto.SearchResponse{
+				Error: stringPtr(status.Convert(err).Message()),
+			}, err
+		}
+		resultCount = len(resp.Results)
+		return resp, nil
+	}
+
+	query, err := s.normalizeQuery(req.Namespace, req.QueryVector)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.QueryVector = query
+
+	// Convert filter if provided
+	var filter search.Filter
+	if req.Filter != nil {
+		filter, err = protoFilterToFilter(req.Filter)
+		if err != nil {
+			return &proto.SearchResponse{
+				Error: stringPtr(err.Error()),
+			}, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid filter: %v", err))
+		}
+	}
+
+	// Serve repeated queries from the result cache
+	cacheKey, generation, cacheable := s.resultCacheKey(req, filter)
+	if cacheable {
+		if cached, ok := s.cachedSearch(cacheKey, start); ok {
+			resultCount = len(cached.Results)
+			return cached, nil
+		}
+	}
+
 	// Get indexes for namespace
 	index, _, _, err := s.getNamespaceIndexes(req.Namespace)
 	if err != nil {
 		return &proto.SearchResponse{
-			Error: stringPtr(err.Error()),
-		}, status.Error(codes.Internal, err.Error())
+			Error: stringPtr(status.Convert(err).Message()),
+		}, namespaceStatus(err)
 	}
 
 	// Convert to float32 vector
@@ -111,23 +413,329 @@ func (s *Server) Search(ctx context.Context, req *proto.SearchRequest) (*proto.S
 		queryVector[i] = v
 	}
 
-	// Use efSearch from request or default
-	efSearch := int(req.EfSearch)
-	if efSearch == 0 {
-		efSearch = s.config.HNSW.DefaultEfSearch
+	// Use efSearch from request or the namespace default
+	efSearch := s.searchLimits(req.Namespace).efSearch(int(req.EfSearch))
+
+	// Rank every result up to the end of the requested page, then cut the
+	// page out, so a deep page costs as much as one search with a large k
+	k := int(req.K)
+	offset := int(req.Offset)
+	depth := offset + k
+
+	// Scale efSearch with the depth so large-k queries keep their recall
+	efSearch = s.effectiveEfSearch(req.Namespace, efSearch, depth)
+	setSearchAttributes(span, req.Namespace, k, efSearch)
+
+	// Gather extra candidates when the namespace reranks under another metric
+	fetchK := depth
+	metrics, _ := s.metricsFor(req.Namespace)
+	var rerankFunc hnsw.DistanceFunc
+	if metrics.Rerank != "" && metrics.Rerank != metrics.Retrieval {
+		rerankFunc, _ = distanceFuncForMetric(metrics.Rerank)
+		fetchK = metrics.rerankDepth(depth, efSearch)
+	}
+
+	// Results beyond the threshold are dropped; a plain graph search under
+	// the same metric also stops early once its candidates pass it
+	maxDistance := searchMaxDistance(req, metrics)
+	graphMaxDistance := float32(math.Inf(1))
+	if rerankFunc == nil {
+		graphMaxDistance = maxDistance
+	}
+
+	// A reranker sees the top rerank_depth candidates, cut to the page after
+	keep := depth
+	if reranker != nil {
+		keep = candidateDepth(int(req.RerankDepth), depth, efSearch)
+		if keep > fetchK {
+			fetchK = keep
+		}
 	}
 
-	// Perform search
-	searchResult, err := index.Search(queryVector, int(req.K), efSearch)
+	// Perform search and apply filter
+	prof := newSearchProfile(req.Profile)
+	var results []hnsw.Result
+	var truncated bool
+	ann := s.annFor(req.Namespace)
+	exact := ann == nil && s.useExactSearch(index)
+	if ann != nil {
+		// IVF-PQ and SCANN namespaces keep their vectors flat and are
+		// searched through their quantized index
+		_, annSpan := startSearchSpan(ctx, "vector.QuantizedSearch", req.Namespace, fetchK, efSearch)
+		results, err = s.annSearch(req.Namespace, index, ann, queryVector, fetchK, efSearch, filter, prof)
+		endSpan(annSpan, len(results), err)
+	} else if exact {
+		_, exactSpan := startSearchSpan(ctx, "vector.ExactSearch", req.Namespace, fetchK, efSearch)
+		results, err = s.exactSearch(req.Namespace, index, queryVector, fetchK, filter, prof)
+		endSpan(exactSpan, len(results), err)
+	} else if req.GuaranteeK && filter != nil {
+		_, backfillSpan := startSearchSpan(ctx, "vector.SearchWithBackfill", req.Namespace, fetchK, efSearch)
+		results, truncated, err = s.searchWithBackfill(ctx, req.Namespace, index, queryVector, fetchK, efSearch, filter, prof)
+		endSpan(backfillSpan, len(results), err)
+	} else if filter != nil {
+		// Evaluate the filter during traversal so selective filters still fill k
+		_, graphSpan := startSearchSpan(ctx, "hnsw.SearchFiltered", req.Namespace, fetchK, efSearch)
+		var searchResult *hnsw.SearchResult
+		predicate := s.filterPredicate(req.Namespace, filter, prof)
+		searchResult, err = index.SearchFilteredCtx(ctx, queryVector, fetchK, efSearch, predicate,
+			s.config.HNSW.FilteredSearchMaxVisited, prof.hnswProfile())
+		if err == nil {
+			results, truncated = searchResult.Results, searchResult.Truncated
+			endSpan(graphSpan, len(results), nil)
+		} else {
+			endSpan(graphSpan, 0, err)
+		}
+	} else {
+		_, graphSpan := startSearchSpan(ctx, "hnsw.Search", req.Namespace, fetchK, efSearch)
+		var searchResult *hnsw.SearchResult
+		searchResult, err = index.SearchWithinCtx(ctx, queryVector, fetchK, efSearch, graphMaxDistance, prof.hnswProfile())
+		if err == nil {
+			endSpan(graphSpan, len(searchResult.Results), nil)
+
+			_, filterSpan := startSearchSpan(ctx, "vector.ApplyFilter", req.Namespace, fetchK, efSearch)
+			results = s.filterResults(req.Namespace, searchResult.Results, filter, prof)
+			endSpan(filterSpan, len(results), nil)
+		} else {
+			endSpan(graphSpan, 0, err)
+		}
+	}
 	if err != nil {
 		return &proto.SearchResponse{
 			Error: stringPtr(err.Error()),
-		}, status.Error(codes.Internal, err.Error())
+		}, searchStatus(err)
+	}
+
+	if rerankFunc != nil {
+		results = rerankResults(index, queryVector, results, keep, rerankFunc)
+	}
+	results = withinMaxDistance(results, maxDistance)
+	if reranker != nil {
+		if len(results) > keep {
+			results = results[:keep]
+		}
+		_, rerankSpan := startSearchSpan(ctx, "vector.Rerank", req.Namespace, len(results), efSearch)
+		results = s.applyReranker(req.Namespace, index, reranker, queryVector, results, depth)
+		endSpan(rerankSpan, len(results), nil)
+	}
+	if offset >= len(results) {
+		results = nil
+	} else {
+		results = results[offset:]
+	}
+
+	var totalMatches int64
+	if req.CountTotal {
+		_, countSpan := startSearchSpan(ctx, "vector.CountMatches", req.Namespace, k, efSearch)
+		totalMatches = s.countMatches(req.Namespace, index, filter)
+		endSpan(countSpan, int(totalMatches), nil)
+	}
+
+	// Convert results to proto
+	_, convertSpan := startSearchSpan(ctx, "vector.ResultsToProto", req.Namespace, k, efSearch)
+	protoResults := make([]*proto.SearchResult, 0, len(results))
+	for _, r := range results {
+		protoResults = append(protoResults, s.resultToProto(req.Namespace, r, fields))
 	}
+	if req.ScoreMode == ScoreModeSimilarity {
+		metric := scoreMetric(metrics)
+		for _, r := range protoResults {
+			r.Score = floatPtr(similarityScore(metric, r.Distance))
+		}
+	}
+	endSpan(convertSpan, len(protoResults), nil)
+	resultCount = len(protoResults)
+
+	searchTime := time.Since(start)
+	s.recordSearch(searchTime, len(protoResults))
+
+	resp = &proto.SearchResponse{
+		Results:           protoResults,
+		TotalResults:      int32(len(protoResults)),
+		SearchTimeMs:      float32(searchTime.Milliseconds()),
+		Truncated:         truncated,
+		Profile:           prof.toProto(),
+		EffectiveEfSearch: int32(efSearch),
+		Exact:             exact,
+		TotalMatches:      totalMatches,
+	}
+	if cacheable {
+		s.storeSearch(cacheKey, generation, resp)
+	}
+	return resp, nil
+}
+
+// BatchSearch implements the BatchSearch RPC
+// Queries run concurrently on a worker pool sized to GOMAXPROCS. A query
+// that fails gets an error in its own response; the rest still complete.
+func (s *Server) BatchSearch(ctx context.Context, req *proto.BatchSearchRequest) (*proto.BatchSearchResponse, error) {
+	start := time.Now()
 
-	results := searchResult.Results
+	if err := validateBatchSearchRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	responses := make([]*proto.SearchResponse, len(req.Queries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(req.Queries) {
+		workers = len(req.Queries)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				resp, err := s.search(ctx, &proto.SearchRequest{
+					Namespace:   req.Namespace,
+					QueryVector: req.Queries[i].GetValues(),
+					K:           req.K,
+					EfSearch:    req.EfSearch,
+					Filter:      req.Filter,
+				})
+				if err != nil {
+					resp = &proto.SearchResponse{Error: stringPtr(status.Convert(err).Message())}
+				}
+				responses[i] = resp
+			}
+		}()
+	}
+
+	for i := range req.Queries {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	var failed int32
+	for _, resp := range responses {
+		if resp.Error != nil {
+			failed++
+		}
+	}
 
-	// Convert filter if provided and apply
+	searchTime := time.Since(start)
+	log.Printf("Batch search in namespace %s ran %d queries, %d failed (took %v)",
+		req.Namespace, len(req.Queries), failed, searchTime)
+
+	return &proto.BatchSearchResponse{
+		Responses:    responses,
+		FailedCount:  failed,
+		SearchTimeMs: float32(searchTime.Milliseconds()),
+	}, nil
+}
+
+// searchWithBackfill searches then filters, doubling the candidate count and
+// efSearch until k results pass the filter. It stops once every vector has been
+// considered, or reports truncated when the configured work cap is reached first.
+func (s *Server) searchWithBackfill(ctx context.Context, namespace string, index *hnsw.Index, query []float32, k, efSearch int, filter search.Filter, prof *searchProfile) ([]hnsw.Result, bool, error) {
+	maxCandidates := s.config.HNSW.GuaranteeKMaxCandidates
+	if maxCandidates <= 0 {
+		maxCandidates = 10000
+	}
+	if maxCandidates < k {
+		maxCandidates = k
+	}
+
+	fetch := k
+	for {
+		searchResult, err := index.SearchWithProfileCtx(ctx, query, fetch, efSearch, prof.hnswProfile())
+		if err != nil {
+			return nil, false, err
+		}
+
+		results := s.filterResults(namespace, searchResult.Results, filter, prof)
+		if len(results) >= k {
+			return results[:k], false, nil
+		}
+
+		// Fewer results than requested means the whole index was searched
+		if len(searchResult.Results) < fetch || int64(fetch) >= index.Size() {
+			return results, false, nil
+		}
+		if fetch >= maxCandidates {
+			return results, true, nil
+		}
+
+		fetch *= 2
+		if fetch > maxCandidates {
+			fetch = maxCandidates
+		}
+		efSearch *= 2
+	}
+}
+
+// HybridSearch implements the HybridSearch RPC
+func (s *Server) HybridSearch(ctx context.Context, req *proto.HybridSearchRequest) (resp *proto.SearchResponse, err error) {
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, "vector.HybridSearch")
+	resultCount := 0
+	defer func() { endSpan(span, resultCount, err) }()
+
+	// Validate request
+	if err := validateHybridSearchRequest(req, s.searchLimits(req.Namespace)); err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	reranker, err := s.rerankerFor(req.Reranker)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkVectorSize(req.Namespace, len(req.QueryVector)); err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(status.Convert(err).Message()),
+		}, err
+	}
+
+	query, err := s.normalizeQuery(req.Namespace, req.QueryVector)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.QueryVector = query
+
+	// Get indexes for namespace
+	index, _, hybridSearch, err := s.getNamespaceIndexes(req.Namespace)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(status.Convert(err).Message()),
+		}, namespaceStatus(err)
+	}
+
+	// Convert to float32 vector
+	queryVector := make([]float32, len(req.QueryVector))
+	for i, v := range req.QueryVector {
+		queryVector[i] = v
+	}
+
+	// Use efSearch from request or the namespace default
+	efSearch := s.searchLimits(req.Namespace).efSearch(int(req.EfSearch))
+
+	// Perform hybrid search, fusing enough candidates for the reranker
+	k := int(req.K)
+	fetchK := k
+	if reranker != nil {
+		fetchK = candidateDepth(int(req.RerankDepth), k, efSearch)
+	}
+	setSearchAttributes(span, req.Namespace, k, efSearch)
+	_, fusionSpan := startSearchSpan(ctx, "hybrid.Search", req.Namespace, fetchK, efSearch)
+	results := hybridSearch.Search(queryVector, req.QueryText, fetchK, efSearch)
+	endSpan(fusionSpan, len(results), nil)
+
+	// Apply filter if provided
 	if req.Filter != nil {
 		filter, err := protoFilterToFilter(req.Filter)
 		if err != nil {
@@ -135,19 +743,28 @@ func (s *Server) Search(ctx context.Context, req *proto.SearchRequest) (*proto.S
 				Error: stringPtr(err.Error()),
 			}, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid filter: %v", err))
 		}
+		_, filterSpan := startSearchSpan(ctx, "vector.ApplyFilter", req.Namespace, k, efSearch)
+		results = applyFilterToHybridResults(results, filter)
+		endSpan(filterSpan, len(results), nil)
+	}
 
-		// Apply filter
-		results = s.applyFilterToResults(req.Namespace, results, filter)
+	if reranker != nil {
+		_, rerankSpan := startSearchSpan(ctx, "vector.Rerank", req.Namespace, len(results), efSearch)
+		results = s.applyHybridReranker(req.Namespace, index, reranker, queryVector, req.QueryText, results, k)
+		endSpan(rerankSpan, len(results), nil)
 	}
 
 	// Convert results to proto
+	_, convertSpan := startSearchSpan(ctx, "vector.ResultsToProto", req.Namespace, k, efSearch)
 	protoResults := make([]*proto.SearchResult, 0, len(results))
 	for _, r := range results {
-		protoResults = append(protoResults, s.resultToProto(req.Namespace, r))
+		protoResults = append(protoResults, s.hybridResultToProto(req.Namespace, r))
 	}
+	endSpan(convertSpan, len(protoResults), nil)
+	resultCount = len(protoResults)
 
 	searchTime := time.Since(start)
-	log.Printf("Search in namespace %s returned %d results (took %v)", req.Namespace, len(protoResults), searchTime)
+	log.Printf("Hybrid search in namespace %s returned %d results (took %v)", req.Namespace, len(protoResults), searchTime)
 
 	return &proto.SearchResponse{
 		Results:      protoResults,
@@ -156,69 +773,244 @@ func (s *Server) Search(ctx context.Context, req *proto.SearchRequest) (*proto.S
 	}, nil
 }
 
-// HybridSearch implements the HybridSearch RPC
-func (s *Server) HybridSearch(ctx context.Context, req *proto.HybridSearchRequest) (*proto.SearchResponse, error) {
+// RangeSearch implements the RangeSearch RPC
+func (s *Server) RangeSearch(ctx context.Context, req *proto.RangeSearchRequest) (*proto.SearchResponse, error) {
+	start := time.Now()
+
+	// Validate request
+	if err := validateRangeSearchRequest(req, s.searchLimits(req.Namespace)); err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkVectorSize(req.Namespace, len(req.QueryVector)); err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(status.Convert(err).Message()),
+		}, err
+	}
+
+	query, err := s.normalizeQuery(req.Namespace, req.QueryVector)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.QueryVector = query
+
+	// Get indexes for namespace
+	index, _, _, err := s.getNamespaceIndexes(req.Namespace)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(status.Convert(err).Message()),
+		}, namespaceStatus(err)
+	}
+
+	// Requests may lower the configured cap but not raise it
+	maxResults := s.config.HNSW.RangeSearchMaxResults
+	if req.MaxResults > 0 && int(req.MaxResults) < maxResults {
+		maxResults = int(req.MaxResults)
+	}
+
+	efSearch := s.searchLimits(req.Namespace).efSearch(int(req.EfSearch))
+
+	var results []hnsw.Result
+	var truncated bool
+	exact := s.useExactSearch(index)
+	if exact {
+		var searchResult *hnsw.SearchResult
+		searchResult, err = index.ExactSearch(req.QueryVector, maxResults+1)
+		if err == nil {
+			for _, r := range searchResult.Results {
+				if r.Distance > req.Radius {
+					break
+				}
+				results = append(results, r)
+			}
+			if len(results) > maxResults {
+				results, truncated = results[:maxResults], true
+			}
+		}
+	} else {
+		results, truncated, err = index.RangeSearch(req.QueryVector, req.Radius, efSearch, maxResults)
+	}
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.Internal, err.Error())
+	}
+
+	// Convert results to proto
+	protoResults := make([]*proto.SearchResult, 0, len(results))
+	for _, r := range results {
+		protoResults = append(protoResults, s.resultToProto(req.Namespace, r, nil))
+	}
+
+	searchTime := time.Since(start)
+	log.Printf("Range search in namespace %s returned %d results (took %v)", req.Namespace, len(protoResults), searchTime)
+
+	return &proto.SearchResponse{
+		Results:      protoResults,
+		TotalResults: int32(len(protoResults)),
+		SearchTimeMs: float32(searchTime.Milliseconds()),
+		Truncated:    truncated,
+		Exact:        exact,
+	}, nil
+}
+
+// MultiVectorSearch implements the MultiVectorSearch RPC
+// With group_by set, vectors sharing that metadata value are scored as one
+// document and the result is the document's vector closest to any query.
+func (s *Server) MultiVectorSearch(ctx context.Context, req *proto.MultiVectorSearchRequest) (*proto.SearchResponse, error) {
 	start := time.Now()
 
 	// Validate request
-	if err := validateHybridSearchRequest(req); err != nil {
+	if err := validateMultiVectorSearchRequest(req, s.searchLimits(req.Namespace)); err != nil {
 		return &proto.SearchResponse{
 			Error: stringPtr(err.Error()),
 		}, status.Error(codes.InvalidArgument, err.Error())
 	}
+	for _, q := range req.QueryVectors {
+		if err := s.checkVectorSize(req.Namespace, len(q.GetValues())); err != nil {
+			return &proto.SearchResponse{
+				Error: stringPtr(status.Convert(err).Message()),
+			}, err
+		}
+	}
+
+	// Get indexes for namespace
+	index, _, _, err := s.getNamespaceIndexes(req.Namespace)
+	if err != nil {
+		return &proto.SearchResponse{
+			Error: stringPtr(status.Convert(err).Message()),
+		}, namespaceStatus(err)
+	}
+
+	queries := make([][]float32, len(req.QueryVectors))
+	for i, q := range req.QueryVectors {
+		queries[i], err = s.normalizeQuery(req.Namespace, q.GetValues())
+		if err != nil {
+			return &proto.SearchResponse{
+				Error: stringPtr(err.Error()),
+			}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	efSearch := s.searchLimits(req.Namespace).efSearch(int(req.EfSearch))
+
+	opts := hnsw.MultiVectorOptions{Aggregation: hnsw.AggregateSum}
+	if req.Aggregation == "mean" {
+		opts.Aggregation = hnsw.AggregateMean
+	}
+	if req.GroupBy != nil {
+		field := *req.GroupBy
+		opts.DocumentOf = func(id uint64) (string, bool) {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			value, ok := s.metadata[req.Namespace][id][field]
+			if !ok {
+				return "", false
+			}
+			return fmt.Sprintf("%v", value), true
+		}
+	}
 
-	// Get indexes for namespace
-	_, _, hybridSearch, err := s.getNamespaceIndexes(req.Namespace)
+	searchResult, err := index.MultiVectorSearchWithOptions(queries, int(req.K), efSearch, opts)
 	if err != nil {
 		return &proto.SearchResponse{
 			Error: stringPtr(err.Error()),
 		}, status.Error(codes.Internal, err.Error())
 	}
 
-	// Convert to float32 vector
-	queryVector := make([]float32, len(req.QueryVector))
-	for i, v := range req.QueryVector {
-		queryVector[i] = v
+	// Convert results to proto
+	protoResults := make([]*proto.SearchResult, 0, len(searchResult.Results))
+	for _, r := range searchResult.Results {
+		protoResults = append(protoResults, s.resultToProto(req.Namespace, r, nil))
 	}
 
-	// Use efSearch from request or default
-	efSearch := int(req.EfSearch)
-	if efSearch == 0 {
-		efSearch = s.config.HNSW.DefaultEfSearch
+	searchTime := time.Since(start)
+	log.Printf("Multi-vector search in namespace %s with %d query vectors returned %d results (took %v)",
+		req.Namespace, len(queries), len(protoResults), searchTime)
+
+	return &proto.SearchResponse{
+		Results:           protoResults,
+		TotalResults:      int32(len(protoResults)),
+		SearchTimeMs:      float32(searchTime.Milliseconds()),
+		EffectiveEfSearch: int32(efSearch),
+	}, nil
+}
+
+// Fetch implements the Fetch RPC. IDs that are malformed or not stored are
+// reported per result without failing the request.
+func (s *Server) Fetch(ctx context.Context, req *proto.FetchRequest) (*proto.FetchResponse, error) {
+	if err := validateFetchRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	// Perform hybrid search
-	results := hybridSearch.Search(queryVector, req.QueryText, int(req.K), efSearch)
+	// Look up the namespace without creating it
+	s.mu.RLock()
+	index := s.indexes[req.Namespace]
+	textIndex := s.textIndexes[req.Namespace]
+	s.mu.RUnlock()
 
-	// Apply filter if provided
-	if req.Filter != nil {
-		filter, err := protoFilterToFilter(req.Filter)
-		if err != nil {
-			return &proto.SearchResponse{
-				Error: stringPtr(err.Error()),
-			}, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid filter: %v", err))
+	resp := &proto.FetchResponse{Results: make([]*proto.FetchResult, len(req.Ids))}
+	for i, rawID := range req.Ids {
+		result := s.fetchOne(req.Namespace, index, textIndex, rawID)
+		if result.Found {
+			resp.FoundCount++
+		} else {
+			resp.NotFoundCount++
 		}
-		results = applyFilterToHybridResults(results, filter)
+		resp.Results[i] = result
 	}
 
-	// Convert results to proto
-	protoResults := make([]*proto.SearchResult, 0, len(results))
-	for _, r := range results {
-		protoResults = append(protoResults, s.hybridResultToProto(req.Namespace, r))
+	return resp, nil
+}
+
+// fetchOne reads back a single stored vector with its metadata and text
+func (s *Server) fetchOne(namespace string, index *hnsw.Index, textIndex *search.FullTextIndex, rawID string) *proto.FetchResult {
+	id, ok := s.resolveID(namespace, rawID)
+	if !ok {
+		return &proto.FetchResult{Id: rawID, Error: stringPtr("invalid ID format")}
 	}
 
-	searchTime := time.Since(start)
-	log.Printf("Hybrid search in namespace %s returned %d results (took %v)", req.Namespace, len(protoResults), searchTime)
+	var node *hnsw.Node
+	if index != nil {
+		node = index.GetNode(id)
+	}
+	if node == nil {
+		return &proto.FetchResult{Id: rawID, Error: stringPtr("not found")}
+	}
 
-	return &proto.SearchResponse{
-		Results:      protoResults,
-		TotalResults: int32(len(protoResults)),
-		SearchTimeMs: float32(searchTime.Milliseconds()),
-	}, nil
+	vector := make([]float32, len(node.Vector()))
+	copy(vector, node.Vector())
+
+	s.mu.RLock()
+	metadataProto, typedProto := metadataToProto(s.metadata[namespace][id])
+	s.mu.RUnlock()
+
+	var text *string
+	if textIndex != nil {
+		if doc := textIndex.GetDocument(id); doc != nil {
+			text = stringPtr(doc.Text)
+		}
+	}
+
+	return &proto.FetchResult{
+		Id:            rawID,
+		Found:         true,
+		Vector:        vector,
+		Metadata:      metadataProto,
+		TypedMetadata: typedProto,
+		Text:          text,
+		ExternalId:    s.externalIDOf(namespace, id),
+	}
 }
 
 // Delete implements the Delete RPC
 func (s *Server) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
 	// Validate request
 	if req.Namespace == "" {
 		return &proto.DeleteResponse{
@@ -232,8 +1024,8 @@ func (s *Server) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.D
 	if err != nil {
 		return &proto.DeleteResponse{
 			Success: false,
-			Error:   stringPtr(err.Error()),
-		}, status.Error(codes.Internal, err.Error())
+			Error:   stringPtr(status.Convert(err).Message()),
+		}, namespaceStatus(err)
 	}
 
 	var deletedCount int32
@@ -242,30 +1034,26 @@ func (s *Server) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.D
 	switch selector := req.Selector.(type) {
 	case *proto.DeleteRequest_Id:
 		// Delete by ID
-		id, err := strconv.ParseUint(selector.Id, 10, 64)
-		if err != nil {
+		id, ok := s.resolveID(req.Namespace, selector.Id)
+		if !ok {
 			return &proto.DeleteResponse{
 				Success: false,
 				Error:   stringPtr("invalid ID format"),
 			}, status.Error(codes.InvalidArgument, "invalid ID format")
 		}
 
-		if err := index.Delete(id); err != nil {
+		deleted, err := s.deleteVector(req.Namespace, index, textIndex, id)
+		if err == nil && !deleted {
+			err = fmt.Errorf("node with ID %d not found", id)
+		}
+		if err != nil {
 			return &proto.DeleteResponse{
 				Success: false,
 				Error:   stringPtr(err.Error()),
 			}, status.Error(codes.Internal, err.Error())
 		}
-
-		// Delete from text index
-		textIndex.Remove(id)
-
-		// Delete metadata
-		s.mu.Lock()
-		if metadataStore, ok := s.metadata[req.Namespace]; ok {
-			delete(metadataStore, id)
-		}
-		s.mu.Unlock()
+		s.removeExternalID(req.Namespace, id)
+		s.invalidateResultCache(req.Namespace)
 
 		deletedCount = 1
 
@@ -283,6 +1071,8 @@ func (s *Server) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.D
 		}, status.Error(codes.InvalidArgument, "either id or filter must be specified")
 	}
 
+	s.recordDelete(req.Namespace, index, int(deletedCount))
+
 	log.Printf("Deleted %d vectors in namespace %s", deletedCount, req.Namespace)
 
 	return &proto.DeleteResponse{
@@ -293,6 +1083,9 @@ func (s *Server) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.D
 
 // Update implements the Update RPC
 func (s *Server) Update(ctx context.Context, req *proto.UpdateRequest) (*proto.UpdateResponse, error) {
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
 	// Validate request
 	if req.Namespace == "" || req.Id == "" {
 		return &proto.UpdateResponse{
@@ -300,32 +1093,70 @@ func (s *Server) Update(ctx context.Context, req *proto.UpdateRequest) (*proto.U
 			Error:   stringPtr("namespace and id are required"),
 		}, status.Error(codes.InvalidArgument, "namespace and id are required")
 	}
+	if err := validateTypedMetadata(req.TypedMetadata); err != nil {
+		return &proto.UpdateResponse{
+			Success: false,
+			Error:   stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateRemoveKeys(req); err != nil {
+		return &proto.UpdateResponse{
+			Success: false,
+			Error:   stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateFinite("vector", req.Vector); err != nil {
+		return &proto.UpdateResponse{
+			Success: false,
+			Error:   stringPtr(err.Error()),
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	// Get indexes for namespace
 	index, textIndex, _, err := s.getNamespaceIndexes(req.Namespace)
 	if err != nil {
 		return &proto.UpdateResponse{
 			Success: false,
-			Error:   stringPtr(err.Error()),
-		}, status.Error(codes.Internal, err.Error())
+			Error:   stringPtr(status.Convert(err).Message()),
+		}, namespaceStatus(err)
 	}
 
-	id, err := strconv.ParseUint(req.Id, 10, 64)
-	if err != nil {
+	id, ok := s.resolveID(req.Namespace, req.Id)
+	if !ok {
 		return &proto.UpdateResponse{
 			Success: false,
 			Error:   stringPtr("invalid ID format"),
 		}, status.Error(codes.InvalidArgument, "invalid ID format")
 	}
 
-	// Update vector if provided
+	// The update is logged before it is applied, both under the vector's
+	// lock, so writes to one vector are logged in the order they apply
+	defer s.lockVector(req.Namespace, id)()
+
+	// Check a vector update before logging it
+	var vector []float32
 	if len(req.Vector) > 0 {
-		vector := make([]float32, len(req.Vector))
+		if err := s.checkDimension(req.Namespace, index, len(req.Vector)); err != nil {
+			return &proto.UpdateResponse{
+				Success: false,
+				Error:   stringPtr(status.Convert(err).Message()),
+			}, err
+		}
+
+		if err := s.normalizeUpdateVector(req, id); err != nil {
+			return &proto.UpdateResponse{
+				Success: false,
+				Error:   stringPtr(err.Error()),
+			}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		vector = make([]float32, len(req.Vector))
 		for i, v := range req.Vector {
 			vector[i] = v
 		}
 
-		if err := index.Update(id, vector); err != nil {
+		if index.GetNode(id) == nil {
+			err := fmt.Errorf("node with ID %d not found", id)
 			return &proto.UpdateResponse{
 				Success: false,
 				Error:   stringPtr(err.Error()),
@@ -333,45 +1164,43 @@ func (s *Server) Update(ctx context.Context, req *proto.UpdateRequest) (*proto.U
 		}
 	}
 
-	// Update metadata if provided
-	if len(req.Metadata) > 0 {
-		s.mu.Lock()
-		if metadataStore, ok := s.metadata[req.Namespace]; ok {
-			metaMap := make(map[string]interface{})
-			for k, v := range req.Metadata {
-				metaMap[k] = v
-			}
-			metadataStore[id] = metaMap
-		}
-		s.mu.Unlock()
+	typed := typedMetadataValues(req.TypedMetadata)
+	op := wal.OpUpdate
+	if req.Merge {
+		op = wal.OpMergeUpdate
+	}
+	if err := s.appendWAL(req.Namespace, &wal.Record{
+		Op:            op,
+		ID:            id,
+		Vector:        req.Vector,
+		Metadata:      req.Metadata,
+		TypedMetadata: typed,
+		Text:          req.GetText(),
+		RemoveKeys:    req.RemoveKeys,
+	}); err != nil {
+		return &proto.UpdateResponse{
+			Success: false,
+			Error:   stringPtr(err.Error()),
+		}, status.Error(codes.Internal, err.Error())
 	}
 
-	// Update text index if text provided
-	if req.Text != nil && *req.Text != "" {
-		// Remove old document
-		textIndex.Remove(id)
-
-		// Get updated metadata
-		s.mu.RLock()
-		var metadata map[string]interface{}
-		if metadataStore, ok := s.metadata[req.Namespace]; ok {
-			if meta, ok := metadataStore[id]; ok {
-				metadata = meta
-			}
-		}
-		s.mu.RUnlock()
-
-		// Index new document
-		doc := &search.Document{
-			ID:       id,
-			Text:     *req.Text,
-			Metadata: metadata,
-		}
-
-		if err := textIndex.Index(doc); err != nil {
-			log.Printf("Warning: failed to update text for vector %s: %v", req.Id, err)
+	if vector != nil {
+		if err := index.Update(id, vector); err != nil {
+			return &proto.UpdateResponse{
+				Success: false,
+				Error:   stringPtr(err.Error()),
+			}, status.Error(codes.Internal, err.Error())
 		}
+		s.annUpdate(req.Namespace, id, vector)
+	}
+	if req.Merge {
+		s.mergeDocument(req.Namespace, textIndex, id, req.Metadata, typed, req.RemoveKeys, req.Text)
+	} else {
+		s.updateDocument(req.Namespace, textIndex, id, req.Metadata, typed, req.Text)
 	}
+	s.invalidateResultCache(req.Namespace)
+
+	s.recordUpdate(req.Namespace, index, 1)
 
 	log.Printf("Updated vector %s in namespace %s", req.Id, req.Namespace)
 
@@ -381,40 +1210,98 @@ func (s *Server) Update(ctx context.Context, req *proto.UpdateRequest) (*proto.U
 }
 
 // BatchInsert implements the BatchInsert streaming RPC
+// IDs are reserved in stream order as items arrive and the vectors are then
+// indexed by a bounded worker pool, so InsertedIds follow input order even
+// though graph insertion runs concurrently. The first message's
+// ef_construction and on_error apply to the whole batch. An external ID
+// may appear only once per batch, since its items are indexed in no fixed
+// order.
+//
+// With on_error "abort" the batch stops at the first failed item: no
+// further items are read or inserted, every vector the batch inserted is
+// deleted again, and the stream fails with Aborted.
 func (s *Server) BatchInsert(stream proto.VectorDB_BatchInsertServer) error {
 	start := time.Now()
-	var insertedCount, failedCount int32
-	var insertedIDs []string
-	var errors []string
+	var efConstruction int
+	var abort bool
+	var aborted atomic.Bool // An item failed in abort mode
+
+	jobs := make(chan *batchItem, s.batchInsertWorkers())
+	var wg sync.WaitGroup
+	for w := 0; w < s.batchInsertWorkers(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				if abort && aborted.Load() {
+					s.skipBatchItem(item)
+					continue
+				}
+				s.insertBatchItem(item, efConstruction)
+				if abort && item.err != "" {
+					aborted.Store(true)
+				}
+			}
+		}()
+	}
 
-	for {
+	var items []*batchItem
+	var streamErr error
+	externalIDs := make(map[[2]string]bool) // (namespace, external ID) pairs in the batch
+	for !aborted.Load() {
 		req, err := stream.Recv()
 		if err == io.EOF {
 			// End of stream
 			break
 		}
 		if err != nil {
-			return status.Error(codes.Internal, fmt.Sprintf("stream error: %v", err))
-		}
-
-		// Insert each vector
-		resp, err := s.Insert(stream.Context(), req)
-		if err != nil || !resp.Success {
-			failedCount++
-			errMsg := "unknown error"
-			if resp.Error != nil {
-				errMsg = *resp.Error
-			} else if err != nil {
-				errMsg = err.Error()
+			streamErr = status.Error(codes.Internal, fmt.Sprintf("stream error: %v", err))
+			break
+		}
+
+		if len(items) == 0 {
+			if req.EfConstruction < 0 {
+				streamErr = status.Error(codes.InvalidArgument, "ef_construction must be >= 0")
+				break
 			}
-			errors = append(errors, errMsg)
-		} else {
-			insertedCount++
-			insertedIDs = append(insertedIDs, resp.Id)
+			efConstruction = int(req.EfConstruction)
+			if abort, err = parseOnError(req.OnError); err != nil {
+				streamErr = status.Error(codes.InvalidArgument, err.Error())
+				break
+			}
+		}
+
+		// A replaced vector could not be restored by a rollback
+		if abort && req.Upsert {
+			items = append(items, &batchItem{req: req, err: "upsert is not supported with on_error \"abort\""})
+			aborted.Store(true)
+			break
 		}
+
+		item, ok := s.prepareBatchItem(req, externalIDs)
+		items = append(items, item)
+		if ok {
+			jobs <- item
+		} else if abort {
+			aborted.Store(true)
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if abort && (streamErr != nil || aborted.Load()) {
+		return s.abortBatch(items, streamErr)
+	}
+	if streamErr != nil {
+		return streamErr
 	}
 
+	insertedCount, failedCount, insertedIDs, errors := s.finishBatch(items)
 	totalTime := time.Since(start)
+	if s.metrics != nil {
+		s.metrics.RecordBatchInsert(totalTime, int(insertedCount))
+	}
 	log.Printf("Batch insert completed: %d succeeded, %d failed (took %v)",
 		insertedCount, failedCount, totalTime)
 
@@ -434,7 +1321,7 @@ func (s *Server) GetStats(ctx context.Context, req *proto.StatsRequest) (*proto.
 	resp := &proto.StatsResponse{
 		TotalVectors:     0,
 		TotalNamespaces:  int64(stats["namespaces"].(int)),
-		MemoryUsageBytes: 0, // TODO: implement memory tracking
+		MemoryUsageBytes: 0,
 		NamespaceStats:   make(map[string]*proto.NamespaceStats),
 	}
 
@@ -442,17 +1329,42 @@ func (s *Server) GetStats(ctx context.Context, req *proto.StatsRequest) (*proto.
 	nsStats := stats["namespace_stats"].(map[string]map[string]interface{})
 	for ns, nsStat := range nsStats {
 		vectorCount := int64(nsStat["vector_count"].(int))
+		memoryBytes := nsStat["memory_bytes"].(int64)
 		resp.TotalVectors += vectorCount
+		resp.MemoryUsageBytes += memoryBytes
+		if s.metrics != nil {
+			s.metrics.UpdateIndexMemory(ns, memoryBytes)
+		}
 
-		dimensions := s.config.HNSW.Dimensions
+		dimensions := nsStat["dimensions"].(int)
 		if dimensions == 0 {
 			dimensions = 768 // default
 		}
 
+		maxLayer := nsStat["max_layer"].(int)
+		if s.metrics != nil {
+			s.metrics.UpdateIndexMaxLayer(ns, maxLayer)
+		}
+
+		layerStats := nsStat["layers"].([]hnsw.LayerStats)
+		layers := make([]*proto.LayerStats, len(layerStats))
+		for i, layer := range layerStats {
+			layers[i] = &proto.LayerStats{
+				Layer:     int32(layer.Layer),
+				Nodes:     int64(layer.Nodes),
+				AvgDegree: float32(layer.AvgDegree),
+			}
+		}
+
 		resp.NamespaceStats[ns] = &proto.NamespaceStats{
-			VectorCount: vectorCount,
-			MemoryBytes: 0, // TODO: implement memory tracking
-			Dimensions:  int32(dimensions),
+			VectorCount:    vectorCount,
+			MemoryBytes:    memoryBytes,
+			Dimensions:     int32(dimensions),
+			MaxLayer:       int32(maxLayer),
+			Layers:         layers,
+			M:              int32(nsStat["m"].(int)),
+			EfConstruction: int32(nsStat["ef_construction"].(int)),
+			Metric:         nsStat["metric"].(string),
 		}
 	}
 
@@ -472,6 +1384,9 @@ func (s *Server) HealthCheck(ctx context.Context, req *proto.HealthCheckRequest)
 	if isShutdown {
 		status = "unhealthy"
 		details["reason"] = "server is shutting down"
+	} else if s.warming.Load() {
+		status = "warming"
+		details["reason"] = "indexes are warming up"
 	}
 
 	// Add namespace count
@@ -480,6 +1395,10 @@ func (s *Server) HealthCheck(ctx context.Context, req *proto.HealthCheckRequest)
 	s.mu.RUnlock()
 
 	details["namespaces"] = strconv.Itoa(namespaceCount)
+	if s.connLimit != nil {
+		details["connections"] = strconv.Itoa(s.connLimit.Active())
+	}
+	details["max_connections"] = strconv.Itoa(s.config.Server.MaxConnections)
 	details["cache_enabled"] = strconv.FormatBool(s.config.Cache.Enabled)
 
 	return &proto.HealthCheckResponse{
@@ -490,6 +1409,42 @@ func (s *Server) HealthCheck(ctx context.Context, req *proto.HealthCheckRequest)
 	}, nil
 }
 
+// Validate implements the Validate RPC. A graph with violations is a
+// successful call with valid=false; only an unknown namespace fails.
+func (s *Server) Validate(ctx context.Context, req *proto.ValidateRequest) (*proto.ValidateResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+
+	s.mu.RLock()
+	index := s.indexes[req.Namespace]
+	s.mu.RUnlock()
+
+	if index == nil {
+		return nil, status.Errorf(codes.NotFound, "namespace %q not found", req.Namespace)
+	}
+
+	start := time.Now()
+	resp := &proto.ValidateResponse{
+		Valid:        true,
+		NodesChecked: int64(index.Size()),
+	}
+
+	if err := index.Validate(); err != nil {
+		var verr *hnsw.ValidationError
+		if !errors.As(err, &verr) {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Valid = false
+		resp.ViolationCount = int32(verr.Total)
+		resp.Violations = verr.Violations
+		log.Printf("Namespace %s failed validation: %v", req.Namespace, err)
+	}
+	resp.ValidateTimeMs = float32(time.Since(start).Seconds() * 1000)
+
+	return resp, nil
+}
+
 // Helper methods
 
 func (s *Server) applyFilterToResults(namespace string, results []hnsw.Result, filter search.Filter) []hnsw.Result {
@@ -526,7 +1481,9 @@ func applyFilterToHybridResults(results []*search.HybridSearchResult, filter sea
 	return filtered
 }
 
-func (s *Server) resultToProto(namespace string, r hnsw.Result) *proto.SearchResult {
+// resultToProto converts a search result, returning only the requested
+// fields (every field for nil)
+func (s *Server) resultToProto(namespace string, r hnsw.Result, fields *resultFields) *proto.SearchResult {
 	// Get metadata
 	s.mu.RLock()
 	var metadata map[string]interface{}
@@ -537,38 +1494,42 @@ func (s *Server) resultToProto(namespace string, r hnsw.Result) *proto.SearchRes
 	}
 	s.mu.RUnlock()
 
-	// Convert metadata to string map
-	metadataProto := make(map[string]string)
-	for k, v := range metadata {
-		metadataProto[k] = fmt.Sprintf("%v", v)
-	}
+	metadataProto, typedProto := metadataToProto(fields.projectMetadata(metadata))
 
-	// Get vector from index
 	s.mu.RLock()
 	index := s.indexes[namespace]
+	textIndex := s.textIndexes[namespace]
 	s.mu.RUnlock()
 
+	// Get vector from index
 	var vector []float32
-	if index != nil {
+	if index != nil && fields.includeVector() {
 		if node := index.GetNode(r.ID); node != nil {
 			vector = node.Vector()
 		}
 	}
 
+	// Get text from text index
+	var text *string
+	if textIndex != nil && fields.includeText() {
+		if doc := textIndex.GetDocument(r.ID); doc != nil {
+			text = &doc.Text
+		}
+	}
+
 	return &proto.SearchResult{
-		Id:       strconv.FormatUint(r.ID, 10),
-		Distance: r.Distance,
-		Vector:   vector,
-		Metadata: metadataProto,
+		Id:            strconv.FormatUint(r.ID, 10),
+		Distance:      r.Distance,
+		Vector:        vector,
+		Metadata:      metadataProto,
+		TypedMetadata: typedProto,
+		Text:          text,
+		ExternalId:    s.externalIDOf(namespace, r.ID),
 	}
 }
 
 func (s *Server) hybridResultToProto(namespace string, r *search.HybridSearchResult) *proto.SearchResult {
-	// Convert metadata to string map
-	metadataProto := make(map[string]string)
-	for k, v := range r.Metadata {
-		metadataProto[k] = fmt.Sprintf("%v", v)
-	}
+	metadataProto, typedProto := metadataToProto(r.Metadata)
 
 	// Get vector from index
 	s.mu.RLock()
@@ -594,15 +1555,32 @@ func (s *Server) hybridResultToProto(namespace string, r *search.HybridSearchRes
 		}
 	}
 
-	return &proto.SearchResult{
-		Id:          strconv.FormatUint(r.ID, 10),
-		Distance:    r.VectorScore,
-		Vector:      vector,
-		Metadata:    metadataProto,
-		Text:        text,
-		VectorScore: &r.VectorScore,
-		TextScore:   floatPtr(float32(r.TextScore)),
+	result := &proto.SearchResult{
+		Id:            strconv.FormatUint(r.ID, 10),
+		Distance:      r.VectorScore,
+		Vector:        vector,
+		Metadata:      metadataProto,
+		TypedMetadata: typedProto,
+		Text:          text,
+		VectorScore:   &r.VectorScore,
+		TextScore:     floatPtr(float32(r.TextScore)),
+		VectorRank:    fusionRank(r.VectorRank),
+		TextRank:      fusionRank(r.TextRank),
+		ExternalId:    s.externalIDOf(namespace, r.ID),
+	}
+	if r.Snippet != "" {
+		result.Snippet = stringPtr(r.Snippet)
+	}
+	return result
+}
+
+// fusionRank converts a hybrid result's rank in one of the fused lists to
+// its wire form, where -1 rather than 0 marks a result absent from the list
+func fusionRank(rank int) *int32 {
+	if rank == 0 {
+		return int32Ptr(-1)
 	}
+	return int32Ptr(int32(rank))
 }
 
 // Validation helpers
@@ -614,35 +1592,145 @@ func validateInsertRequest(req *proto.InsertRequest) error {
 	if len(req.Vector) == 0 {
 		return fmt.Errorf("vector is required")
 	}
-	return nil
+	if err := validateFinite("vector", req.Vector); err != nil {
+		return err
+	}
+	if req.EfConstruction < 0 {
+		return fmt.Errorf("ef_construction must be >= 0")
+	}
+	if req.ExternalId != nil && *req.ExternalId == "" {
+		return fmt.Errorf("external_id must not be empty")
+	}
+	if req.Upsert && req.ExternalId == nil && req.GetId() == "" {
+		return fmt.Errorf("upsert requires external_id or id")
+	}
+	return validateTypedMetadata(req.TypedMetadata)
 }
 
-func validateSearchRequest(req *proto.SearchRequest) error {
+func validateSearchRequest(req *proto.SearchRequest, limits searchLimits) error {
 	if req.Namespace == "" {
 		return fmt.Errorf("namespace is required")
 	}
 	if len(req.QueryVector) == 0 {
 		return fmt.Errorf("query vector is required")
 	}
+	if err := validateFinite("query vector", req.QueryVector); err != nil {
+		return err
+	}
+	if req.K <= 0 {
+		return fmt.Errorf("k must be > 0")
+	}
+	if req.Offset < 0 {
+		return fmt.Errorf("offset must be >= 0")
+	}
+	if req.MaxDistance != nil && (math.IsNaN(float64(*req.MaxDistance)) || math.IsInf(float64(*req.MaxDistance), 0)) {
+		return fmt.Errorf("max_distance must be finite, got %v", *req.MaxDistance)
+	}
+	if req.MinScore != nil && !(*req.MinScore >= 0 && *req.MinScore <= 1) {
+		return fmt.Errorf("min_score must be in [0,1], got %v", *req.MinScore)
+	}
+	if err := limits.check(int(req.K), int(req.EfSearch)); err != nil {
+		return err
+	}
+	return validateScoreMode(req.ScoreMode)
+}
+
+func validateBatchSearchRequest(req *proto.BatchSearchRequest) error {
+	if req.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if len(req.Queries) == 0 {
+		return fmt.Errorf("at least one query is required")
+	}
 	if req.K <= 0 {
 		return fmt.Errorf("k must be > 0")
 	}
 	return nil
 }
 
-func validateHybridSearchRequest(req *proto.HybridSearchRequest) error {
+func validateMultiVectorSearchRequest(req *proto.MultiVectorSearchRequest, limits searchLimits) error {
+	if req.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if len(req.QueryVectors) == 0 {
+		return fmt.Errorf("at least one query vector is required")
+	}
+	for i, q := range req.QueryVectors {
+		if len(q.GetValues()) == 0 {
+			return fmt.Errorf("query vector %d is empty", i)
+		}
+		if err := validateFinite(fmt.Sprintf("query vector %d", i), q.GetValues()); err != nil {
+			return err
+		}
+	}
+	if req.K <= 0 {
+		return fmt.Errorf("k must be > 0")
+	}
+	if req.Aggregation != "" && req.Aggregation != "sum" && req.Aggregation != "mean" {
+		return fmt.Errorf("aggregation must be \"sum\" or \"mean\", got %q", req.Aggregation)
+	}
+	return limits.check(int(req.K), int(req.EfSearch))
+}
+
+func validateFetchRequest(req *proto.FetchRequest) error {
+	if req.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if len(req.Ids) == 0 {
+		return fmt.Errorf("at least one id is required")
+	}
+	return nil
+}
+
+func validateRangeSearchRequest(req *proto.RangeSearchRequest, limits searchLimits) error {
+	if req.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if len(req.QueryVector) == 0 {
+		return fmt.Errorf("query vector is required")
+	}
+	if err := validateFinite("query vector", req.QueryVector); err != nil {
+		return err
+	}
+	if req.Radius < 0 {
+		return fmt.Errorf("radius must be >= 0")
+	}
+	if req.MaxResults < 0 {
+		return fmt.Errorf("max_results must be >= 0")
+	}
+	return limits.check(0, int(req.EfSearch))
+}
+
+func validateHybridSearchRequest(req *proto.HybridSearchRequest, limits searchLimits) error {
 	if req.Namespace == "" {
 		return fmt.Errorf("namespace is required")
 	}
 	if len(req.QueryVector) == 0 {
 		return fmt.Errorf("query vector is required")
 	}
+	if err := validateFinite("query vector", req.QueryVector); err != nil {
+		return err
+	}
 	if req.QueryText == "" {
 		return fmt.Errorf("query text is required")
 	}
 	if req.K <= 0 {
 		return fmt.Errorf("k must be > 0")
 	}
+	if req.RerankDepth < 0 {
+		return fmt.Errorf("rerank_depth must be >= 0")
+	}
+	return limits.check(int(req.K), int(req.EfSearch))
+}
+
+// validateFinite rejects NaN and infinite components, which would poison
+// every distance computed against the vector
+func validateFinite(name string, vector []float32) error {
+	for i, v := range vector {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return fmt.Errorf("%s component %d is %v; components must be finite", name, i, v)
+		}
+	}
 	return nil
 }
 
@@ -658,6 +1746,8 @@ func protoFilterToFilter(pf *proto.Filter) (search.Filter, error) {
 		return protoListToFilter(ft.List)
 	case *proto.Filter_GeoRadius:
 		return protoGeoRadiusToFilter(ft.GeoRadius)
+	case *proto.Filter_GeoBoundingBox:
+		return protoGeoBoundingBoxToFilter(ft.GeoBoundingBox)
 	case *proto.Filter_Exists:
 		return protoExistsToFilter(ft.Exists)
 	case *proto.Filter_Composite:
@@ -688,6 +1778,12 @@ func protoComparisonToFilter(cf *proto.ComparisonFilter) (search.Filter, error)
 		return search.Gte(cf.Field, value), nil
 	case "lte":
 		return search.Lte(cf.Field, value), nil
+	case "ieq":
+		return search.IEq(cf.Field, cf.Value), nil
+	case "contains":
+		return search.Contains(cf.Field, cf.Value), nil
+	case "starts_with":
+		return search.StartsWith(cf.Field, cf.Value), nil
 	default:
 		return nil, fmt.Errorf("unknown comparison operator: %s", cf.Operator)
 	}
@@ -748,6 +1844,13 @@ func protoGeoRadiusToFilter(gf *proto.GeoRadiusFilter) (search.Filter, error) {
 	return search.GeoRadius(gf.Field, gf.Latitude, gf.Longitude, gf.RadiusKm), nil
 }
 
+func protoGeoBoundingBoxToFilter(bf *proto.GeoBoundingBoxFilter) (search.Filter, error) {
+	if bf.MinLatitude > bf.MaxLatitude {
+		return nil, fmt.Errorf("geo bounding box min_latitude %v is above max_latitude %v", bf.MinLatitude, bf.MaxLatitude)
+	}
+	return search.GeoBoundingBox(bf.Field, bf.MinLatitude, bf.MinLongitude, bf.MaxLatitude, bf.MaxLongitude), nil
+}
+
 func protoExistsToFilter(ef *proto.ExistsFilter) (search.Filter, error) {
 	return search.Exists(ef.Field), nil
 }
@@ -779,6 +1882,26 @@ func protoCompositeToFilter(cf *proto.CompositeFilter) (search.Filter, error) {
 
 // Utility helpers
 
+// searchStatus converts a failed search to a gRPC status. A search cut
+// short by the caller's deadline or cancellation keeps that code instead of
+// being reported as an internal error.
+func searchStatus(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// namespaceStatus converts a failure to get a namespace's indexes to a gRPC
+// status, keeping the NotFound a strict server returns for a namespace that
+// was never created
+func namespaceStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -786,3 +1909,7 @@ func stringPtr(s string) *string {
 func floatPtr(f float32) *float32 {
 	return &f
 }
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,221 @@
+package grpc
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+// Overflow policies for the external ID map
+const (
+	IDMapOverflowReject = "reject" // Refuse new external IDs once the map is full
+	IDMapOverflowEvict  = "evict"  // Drop the least recently used mapping to make room
+)
+
+// ErrIDMapFull is returned when a new external ID is rejected because the map is at capacity
+var ErrIDMapFull = errors.New("external ID map is full")
+
+// ErrExternalIDExists is returned when claiming an external ID that is already mapped
+var ErrExternalIDExists = errors.New("external ID already exists")
+
+// idMap is a bounded, thread-safe mapping from client-supplied external IDs
+// to internal index IDs. Without a cap, clients that churn external IDs would
+// grow the map without limit.
+type idMap struct {
+	capacity int    // Max mappings (0 = unbounded)
+	policy   string // Behavior when full (reject or evict)
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	byInternal map[uint64]string
+	lru        *list.List
+
+	// Statistics
+	evictions  int64
+	rejections int64
+}
+
+// idMapEntry is a single external -> internal mapping
+type idMapEntry struct {
+	externalID string
+	internalID uint64
+}
+
+// IDMapStats holds statistics for an external ID map
+type IDMapStats struct {
+	Size       int
+	Capacity   int
+	Policy     string
+	Evictions  int64
+	Rejections int64
+}
+
+// newIDMap creates an external ID map with the given capacity and overflow policy
+func newIDMap(capacity int, policy string) *idMap {
+	if policy == "" {
+		policy = IDMapOverflowReject
+	}
+	return &idMap{
+		capacity:   capacity,
+		policy:     policy,
+		entries:    make(map[string]*list.Element),
+		byInternal: make(map[uint64]string),
+		lru:        list.New(),
+	}
+}
+
+// Put maps externalID to internalID.
+// Existing mappings are updated in place. When the map is full, a new
+// mapping is either rejected with ErrIDMapFull or evicts the least recently
+// used mapping, depending on the policy. The evicted external ID (if any) is returned.
+func (m *idMap) Put(externalID string, internalID uint64) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.putLocked(externalID, internalID)
+}
+
+// Claim maps externalID to internalID unless it is already mapped, in which
+// case it fails with ErrExternalIDExists or, with replace, remaps it and
+// returns the internal ID it replaced. Checking and mapping under one lock
+// keeps concurrent inserts of the same external ID from both succeeding.
+func (m *idMap) Claim(externalID string, internalID uint64, replace bool) (previous uint64, replaced bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, exists := m.entries[externalID]; exists {
+		if !replace {
+			return 0, false, ErrExternalIDExists
+		}
+		previous = elem.Value.(*idMapEntry).internalID
+		replaced = true
+	}
+	if _, err := m.putLocked(externalID, internalID); err != nil {
+		return 0, false, err
+	}
+	return previous, replaced, nil
+}
+
+// Unclaim undoes a Claim of externalID for internalID, restoring the
+// previous internal ID if the claim replaced one. A mapping that changed
+// since the claim is left alone.
+func (m *idMap) Unclaim(externalID string, internalID, previous uint64, replaced bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, exists := m.entries[externalID]
+	if !exists || elem.Value.(*idMapEntry).internalID != internalID {
+		return
+	}
+	if !replaced {
+		m.removeElement(elem)
+		return
+	}
+	m.putLocked(externalID, previous)
+}
+
+// putLocked implements Put (caller must hold lock)
+func (m *idMap) putLocked(externalID string, internalID uint64) (string, error) {
+	if elem, exists := m.entries[externalID]; exists {
+		entry := elem.Value.(*idMapEntry)
+		delete(m.byInternal, entry.internalID)
+		entry.internalID = internalID
+		m.byInternal[internalID] = externalID
+		m.lru.MoveToFront(elem)
+		return "", nil
+	}
+
+	evicted := ""
+	if m.capacity > 0 && m.lru.Len() >= m.capacity {
+		if m.policy != IDMapOverflowEvict {
+			m.rejections++
+			return "", ErrIDMapFull
+		}
+		oldest := m.lru.Back()
+		evicted = oldest.Value.(*idMapEntry).externalID
+		m.removeElement(oldest)
+		m.evictions++
+	}
+
+	elem := m.lru.PushFront(&idMapEntry{externalID: externalID, internalID: internalID})
+	m.entries[externalID] = elem
+	m.byInternal[internalID] = externalID
+
+	return evicted, nil
+}
+
+// Get returns the internal ID for an external ID and marks it as recently used
+func (m *idMap) Get(externalID string) (uint64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, exists := m.entries[externalID]
+	if !exists {
+		return 0, false
+	}
+	m.lru.MoveToFront(elem)
+	return elem.Value.(*idMapEntry).internalID, true
+}
+
+// ExternalID returns the external ID mapped to an internal ID
+func (m *idMap) ExternalID(internalID uint64) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	externalID, exists := m.byInternal[internalID]
+	return externalID, exists
+}
+
+// Remove deletes the mapping for an external ID
+func (m *idMap) Remove(externalID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, exists := m.entries[externalID]
+	if !exists {
+		return false
+	}
+	m.removeElement(elem)
+	return true
+}
+
+// RemoveInternal deletes the mapping that points at an internal ID
+func (m *idMap) RemoveInternal(internalID uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	externalID, exists := m.byInternal[internalID]
+	if !exists {
+		return false
+	}
+	m.removeElement(m.entries[externalID])
+	return true
+}
+
+// Len returns the number of mappings
+func (m *idMap) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// Stats returns map statistics
+func (m *idMap) Stats() IDMapStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return IDMapStats{
+		Size:       m.lru.Len(),
+		Capacity:   m.capacity,
+		Policy:     m.policy,
+		Evictions:  m.evictions,
+		Rejections: m.rejections,
+	}
+}
+
+// removeElement removes a list element and its index entries (caller must hold lock)
+func (m *idMap) removeElement(elem *list.Element) {
+	entry := elem.Value.(*idMapEntry)
+	m.lru.Remove(elem)
+	delete(m.entries, entry.externalID)
+	delete(m.byInternal, entry.internalID)
+}
@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIDMapRejectWhenFull(t *testing.T) {
+	m := newIDMap(2, IDMapOverflowReject)
+
+	if _, err := m.Put("a", 1); err != nil {
+		t.Fatalf("Put a failed: %v", err)
+	}
+	if _, err := m.Put("b", 2); err != nil {
+		t.Fatalf("Put b failed: %v", err)
+	}
+
+	if _, err := m.Put("c", 3); !errors.Is(err, ErrIDMapFull) {
+		t.Fatalf("Expected ErrIDMapFull, got %v", err)
+	}
+
+	// Updating an existing mapping must still succeed at capacity
+	if _, err := m.Put("a", 10); err != nil {
+		t.Fatalf("Update of existing mapping failed: %v", err)
+	}
+	if id, ok := m.Get("a"); !ok || id != 10 {
+		t.Errorf("Expected a -> 10, got %d (found=%v)", id, ok)
+	}
+	if _, ok := m.ExternalID(1); ok {
+		t.Error("Stale reverse mapping for internal ID 1 should be gone")
+	}
+
+	stats := m.Stats()
+	if stats.Size != 2 {
+		t.Errorf("Expected size 2, got %d", stats.Size)
+	}
+	if stats.Rejections != 1 {
+		t.Errorf("Expected 1 rejection, got %d", stats.Rejections)
+	}
+	if stats.Evictions != 0 {
+		t.Errorf("Expected 0 evictions, got %d", stats.Evictions)
+	}
+}
+
+func TestIDMapEvictLRU(t *testing.T) {
+	m := newIDMap(2, IDMapOverflowEvict)
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	// Touch "a" so "b" becomes least recently used
+	m.Get("a")
+
+	evicted, err := m.Put("c", 3)
+	if err != nil {
+		t.Fatalf("Put c failed: %v", err)
+	}
+	if evicted != "b" {
+		t.Errorf("Expected b to be evicted, got %q", evicted)
+	}
+
+	if _, ok := m.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	if _, ok := m.ExternalID(2); ok {
+		t.Error("Reverse mapping for evicted entry should be gone")
+	}
+	if id, ok := m.Get("c"); !ok || id != 3 {
+		t.Errorf("Expected c -> 3, got %d (found=%v)", id, ok)
+	}
+
+	stats := m.Stats()
+	if stats.Size != 2 {
+		t.Errorf("Expected size 2, got %d", stats.Size)
+	}
+	if stats.Evictions != 1 {
+		t.Errorf("Expected 1 eviction, got %d", stats.Evictions)
+	}
+}
+
+func TestIDMapUnbounded(t *testing.T) {
+	m := newIDMap(0, IDMapOverflowReject)
+
+	for i := 0; i < 1000; i++ {
+		if _, err := m.Put(fmt.Sprintf("ext-%d", i), uint64(i)); err != nil {
+			t.Fatalf("Put %d failed: %v", i, err)
+		}
+	}
+	if m.Len() != 1000 {
+		t.Errorf("Expected 1000 mappings, got %d", m.Len())
+	}
+}
+
+func TestIDMapRemove(t *testing.T) {
+	m := newIDMap(1, IDMapOverflowReject)
+
+	m.Put("a", 1)
+	if !m.RemoveInternal(1) {
+		t.Fatal("RemoveInternal should find mapping")
+	}
+	if m.Len() != 0 {
+		t.Errorf("Expected empty map, got %d", m.Len())
+	}
+
+	// Freed slot can be reused
+	if _, err := m.Put("b", 2); err != nil {
+		t.Fatalf("Put after remove failed: %v", err)
+	}
+	if !m.Remove("b") {
+		t.Error("Remove should find mapping")
+	}
+}
+
+func TestIDMapClaim(t *testing.T) {
+	m := newIDMap(0, IDMapOverflowReject)
+
+	if _, replaced, err := m.Claim("a", 1, false); err != nil || replaced {
+		t.Fatalf("Claim a failed: replaced=%v err=%v", replaced, err)
+	}
+	if _, _, err := m.Claim("a", 2, false); !errors.Is(err, ErrExternalIDExists) {
+		t.Fatalf("Expected ErrExternalIDExists, got %v", err)
+	}
+	if id, _ := m.Get("a"); id != 1 {
+		t.Errorf("Rejected claim changed the mapping to %d", id)
+	}
+
+	previous, replaced, err := m.Claim("a", 2, true)
+	if err != nil || !replaced || previous != 1 {
+		t.Fatalf("Expected a replaced from 1, got previous=%d replaced=%v err=%v", previous, replaced, err)
+	}
+	if _, ok := m.ExternalID(1); ok {
+		t.Error("Reverse mapping for the replaced internal ID should be gone")
+	}
+	if ext, _ := m.ExternalID(2); ext != "a" {
+		t.Errorf("Expected 2 -> a, got %q", ext)
+	}
+}
+
+func TestIDMapUnclaim(t *testing.T) {
+	m := newIDMap(0, IDMapOverflowReject)
+
+	m.Claim("a", 1, false)
+	m.Unclaim("a", 1, 0, false)
+	if _, ok := m.Get("a"); ok {
+		t.Error("Unclaim of a new mapping should remove it")
+	}
+
+	m.Claim("b", 2, false)
+	previous, replaced, _ := m.Claim("b", 3, true)
+	m.Unclaim("b", 3, previous, replaced)
+	if id, _ := m.Get("b"); id != 2 {
+		t.Errorf("Expected b restored to 2, got %d", id)
+	}
+
+	// A mapping claimed again since is kept
+	m.Claim("b", 4, true)
+	m.Unclaim("b", 3, 2, true)
+	if id, _ := m.Get("b"); id != 4 {
+		t.Errorf("Expected b -> 4 kept, got %d", id)
+	}
+}
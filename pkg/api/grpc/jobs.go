@@ -0,0 +1,243 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Job states reported by GetJobStatus
+const (
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobCancelled = "cancelled" // The server shut down before the job finished
+)
+
+// insertJob is an AsyncBatchInsert running in the background. items[n] is
+// set once item n is prepared, and done[n] once it is finished.
+type insertJob struct {
+	id      string
+	started time.Time
+
+	mu       sync.Mutex
+	state    string
+	items    []*batchItem
+	done     []bool
+	inserted int
+	failed   int
+	finished time.Time // Zero while running
+}
+
+// newJobID returns a random job handle
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "job-" + hex.EncodeToString(b), nil
+}
+
+// finishItem records that item n has been inserted or has failed
+func (j *insertJob) finishItem(n int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.done[n] = true
+	if j.items[n].err != "" {
+		j.failed++
+	} else {
+		j.inserted++
+	}
+}
+
+// finish moves the job to a terminal state
+func (j *insertJob) finish(state string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.state = state
+	j.finished = time.Now()
+}
+
+// expired reports whether a finished job has outlived ttl
+func (j *insertJob) expired(now time.Time, ttl time.Duration) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return !j.finished.IsZero() && now.Sub(j.finished) > ttl
+}
+
+// status reports the job's progress
+func (j *insertJob) status() *proto.JobStatusResponse {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	resp := &proto.JobStatusResponse{
+		JobId:     j.id,
+		State:     j.state,
+		Total:     int32(len(j.items)),
+		Inserted:  int32(j.inserted),
+		Failed:    int32(j.failed),
+		Remaining: int32(len(j.items) - j.inserted - j.failed),
+	}
+	for n, item := range j.items {
+		if !j.done[n] {
+			continue
+		}
+		if item.err != "" {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("item %d: %s", n, item.err))
+		} else {
+			resp.InsertedIds = append(resp.InsertedIds, strconv.FormatUint(item.id, 10))
+		}
+	}
+
+	end := j.finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	resp.ElapsedMs = float32(end.Sub(j.started).Milliseconds())
+	return resp
+}
+
+// AsyncBatchInsert implements the AsyncBatchInsert RPC. It queues the items
+// and returns a job ID at once; a background job inserts them as
+// BatchInsert would, and GetJobStatus reports its progress.
+func (s *Server) AsyncBatchInsert(ctx context.Context, req *proto.AsyncBatchInsertRequest) (*proto.AsyncBatchInsertResponse, error) {
+	if len(req.Items) == 0 {
+		return &proto.AsyncBatchInsertResponse{
+			Error: stringPtr("items is required"),
+		}, status.Error(codes.InvalidArgument, "items is required")
+	}
+	if req.Items[0].EfConstruction < 0 {
+		return &proto.AsyncBatchInsertResponse{
+			Error: stringPtr("ef_construction must be >= 0"),
+		}, status.Error(codes.InvalidArgument, "ef_construction must be >= 0")
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return &proto.AsyncBatchInsertResponse{
+			Error: stringPtr(err.Error()),
+		}, status.Error(codes.Internal, err.Error())
+	}
+
+	job := &insertJob{
+		id:      id,
+		started: time.Now(),
+		state:   JobRunning,
+		items:   make([]*batchItem, len(req.Items)),
+		done:    make([]bool, len(req.Items)),
+	}
+
+	s.expireJobs()
+	s.jobsMu.Lock()
+	s.jobs[id] = job
+	s.jobsMu.Unlock()
+
+	s.jobsWG.Add(1)
+	go s.runInsertJob(job, req.Items)
+
+	return &proto.AsyncBatchInsertResponse{
+		JobId: id,
+		Total: int32(len(req.Items)),
+	}, nil
+}
+
+// GetJobStatus implements the GetJobStatus RPC
+func (s *Server) GetJobStatus(ctx context.Context, req *proto.JobStatusRequest) (*proto.JobStatusResponse, error) {
+	if req.JobId == "" {
+		return &proto.JobStatusResponse{
+			Error: stringPtr("job_id is required"),
+		}, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	s.expireJobs()
+	s.jobsMu.Lock()
+	job := s.jobs[req.JobId]
+	s.jobsMu.Unlock()
+
+	if job == nil {
+		err := fmt.Sprintf("job %s not found (finished jobs expire after %v)", req.JobId, s.config.Database.JobTTL)
+		return &proto.JobStatusResponse{
+			JobId: req.JobId,
+			Error: stringPtr(err),
+		}, status.Error(codes.NotFound, err)
+	}
+	return job.status(), nil
+}
+
+// runInsertJob prepares the job's items in order and indexes them with the
+// BatchInsert workers. Once the server stops, remaining items are left
+// unprocessed and the job is cancelled.
+func (s *Server) runInsertJob(job *insertJob, reqs []*proto.InsertRequest) {
+	defer s.jobsWG.Done()
+
+	efConstruction := int(reqs[0].EfConstruction)
+	queue := make(chan int, s.batchInsertWorkers())
+	var wg sync.WaitGroup
+	for w := 0; w < s.batchInsertWorkers(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range queue {
+				s.insertBatchItem(job.items[n], efConstruction)
+				job.finishItem(n)
+			}
+		}()
+	}
+
+	prepared := 0
+	externalIDs := make(map[[2]string]bool) // (namespace, external ID) pairs in the job
+	for n, req := range reqs {
+		if s.jobsCtx.Err() != nil {
+			break
+		}
+		item, ok := s.prepareBatchItem(req, externalIDs)
+		job.mu.Lock()
+		job.items[n] = item
+		job.mu.Unlock()
+		prepared++
+		if ok {
+			queue <- n
+		} else {
+			job.finishItem(n)
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+
+	inserted, failed, _, _ := s.finishBatch(job.items[:prepared])
+	state := JobCompleted
+	if prepared < len(reqs) {
+		state = JobCancelled
+	}
+	job.finish(state)
+
+	log.Printf("Async batch insert %s %s: %d succeeded, %d failed, %d not processed (took %v)",
+		job.id, state, inserted, failed, len(reqs)-prepared, time.Since(job.started))
+}
+
+// expireJobs forgets finished jobs older than the job TTL
+func (s *Server) expireJobs() {
+	now := time.Now()
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	for id, job := range s.jobs {
+		if job.expired(now, s.config.Database.JobTTL) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// stopJobs cancels running jobs and waits for their in-flight items
+func (s *Server) stopJobs() {
+	s.cancelJobs()
+	s.jobsWG.Wait()
+}
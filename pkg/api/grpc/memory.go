@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+)
+
+// metadataEntryOverhead approximates a map entry's key, value and bucket share
+const metadataEntryOverhead = 48
+
+// nodeOverhead approximates an HNSW node's struct and neighbor list headers
+const nodeOverhead = 128
+
+// namespaceMemoryUsage estimates the bytes held by a namespace: its vector
+// indexes, full-text index and metadata. The caller must hold s.mu.
+func (s *Server) namespaceMemoryUsage(namespace string) int64 {
+	var total int64
+	if index := s.indexes[namespace]; index != nil {
+		total += index.MemoryUsage()
+	}
+	total += s.anns[namespace].memoryUsage()
+	if textIndex := s.textIndexes[namespace]; textIndex != nil {
+		total += textIndex.MemoryUsage()
+	}
+	for _, meta := range s.metadata[namespace] {
+		total += metadataEntryOverhead
+		for k, v := range meta {
+			total += metadataEntryOverhead + int64(len(k))
+			if str, ok := v.(string); ok {
+				total += int64(len(str))
+			} else {
+				total += int64(len(fmt.Sprint(v)))
+			}
+		}
+	}
+	return total
+}
+
+// insertMemoryEstimate approximates the bytes an insert adds to its
+// namespace: the stored vector, its layer-0 links, text and metadata. It
+// is checked against memory quotas without walking the graph.
+func (s *Server) insertMemoryEstimate(req *proto.InsertRequest) int64 {
+	elementBytes := int64(4)
+	if s.config.HNSW.StorageDType == "float16" {
+		elementBytes = 2
+	}
+
+	total := int64(nodeOverhead + metadataEntryOverhead)
+	total += int64(len(req.Vector)) * elementBytes
+	total += int64(2*s.config.HNSW.M) * 8
+	total += int64(len(req.GetText()))
+	for k, v := range req.Metadata {
+		total += metadataEntryOverhead + int64(len(k)+len(v))
+	}
+	for k, v := range req.TypedMetadata {
+		total += metadataEntryOverhead + int64(len(k)) + int64(len(fmt.Sprint(v)))
+	}
+	return total
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+)
+
+// Stored metadata values are strings, or int64, float64 and bool from
+// typed_metadata, so numeric and boolean filters compare native values.
+
+// validateTypedMetadata checks that every typed value sets exactly one field
+func validateTypedMetadata(typed map[string]*proto.MetadataValue) error {
+	for k, v := range typed {
+		set := 0
+		if v != nil {
+			for _, ok := range []bool{v.StringValue != nil, v.IntValue != nil, v.DoubleValue != nil, v.BoolValue != nil} {
+				if ok {
+					set++
+				}
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("typed_metadata %q must set exactly one value, got %d", k, set)
+		}
+	}
+	return nil
+}
+
+// validateRemoveKeys checks that an update only removes metadata keys when
+// merging, and does not also set them
+func validateRemoveKeys(req *proto.UpdateRequest) error {
+	if len(req.RemoveKeys) == 0 {
+		return nil
+	}
+	if !req.Merge {
+		return fmt.Errorf("remove_keys requires merge")
+	}
+	for _, k := range req.RemoveKeys {
+		_, inMetadata := req.Metadata[k]
+		_, inTyped := req.TypedMetadata[k]
+		if inMetadata || inTyped {
+			return fmt.Errorf("metadata key %q is both set and removed", k)
+		}
+	}
+	return nil
+}
+
+// typedMetadataValues converts validated typed metadata to stored values
+func typedMetadataValues(typed map[string]*proto.MetadataValue) map[string]interface{} {
+	if len(typed) == 0 {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(typed))
+	for k, v := range typed {
+		switch {
+		case v.StringValue != nil:
+			values[k] = *v.StringValue
+		case v.IntValue != nil:
+			values[k] = *v.IntValue
+		case v.DoubleValue != nil:
+			values[k] = *v.DoubleValue
+		case v.BoolValue != nil:
+			values[k] = *v.BoolValue
+		}
+	}
+	return values
+}
+
+// typedMetadataProto converts stored values back to typed metadata
+func typedMetadataProto(values map[string]interface{}) map[string]*proto.MetadataValue {
+	if len(values) == 0 {
+		return nil
+	}
+
+	typed := make(map[string]*proto.MetadataValue, len(values))
+	for k, v := range values {
+		switch v := v.(type) {
+		case string:
+			typed[k] = &proto.MetadataValue{StringValue: &v}
+		case int64:
+			typed[k] = &proto.MetadataValue{IntValue: &v}
+		case float64:
+			typed[k] = &proto.MetadataValue{DoubleValue: &v}
+		case bool:
+			typed[k] = &proto.MetadataValue{BoolValue: &v}
+		}
+	}
+	return typed
+}
+
+// documentMetadata merges string and typed metadata into the stored form;
+// a typed value replaces a string value with the same key
+func documentMetadata(metadata map[string]string, typed map[string]interface{}) map[string]interface{} {
+	meta := make(map[string]interface{}, len(metadata)+len(typed))
+	for k, v := range metadata {
+		meta[k] = v
+	}
+	for k, v := range typed {
+		meta[k] = v
+	}
+	return meta
+}
+
+// splitMetadata separates stored metadata into its string values and its
+// typed (non-string) values, as logged in the WAL
+func splitMetadata(meta map[string]interface{}) (map[string]string, map[string]interface{}) {
+	strs := make(map[string]string, len(meta))
+	var typed map[string]interface{}
+	for k, v := range meta {
+		if str, ok := v.(string); ok {
+			strs[k] = str
+			continue
+		}
+		if typed == nil {
+			typed = make(map[string]interface{})
+		}
+		typed[k] = v
+	}
+	return strs, typed
+}
+
+// metadataToProto returns stored metadata as every value formatted as a
+// string, plus the non-string values typed
+func metadataToProto(meta map[string]interface{}) (map[string]string, map[string]*proto.MetadataValue) {
+	metadataProto := make(map[string]string, len(meta))
+	for k, v := range meta {
+		metadataProto[k] = fmt.Sprintf("%v", v)
+	}
+	_, typed := splitMetadata(meta)
+	return metadataProto, typedMetadataProto(typed)
+}
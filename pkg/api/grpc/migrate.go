@@ -0,0 +1,202 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/migrate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// migrationJournal holds the IDs of the vectors written to a namespace
+// while Migrate builds its new index
+type migrationJournal struct {
+	mu  sync.Mutex
+	ids map[uint64]struct{}
+}
+
+// add records a write to the vector stored under id
+func (j *migrationJournal) add(id uint64) {
+	j.mu.Lock()
+	j.ids[id] = struct{}{}
+	j.mu.Unlock()
+}
+
+// Migrate rebuilds a namespace's index as another index type and swaps it
+// in. HNSW and flat namespaces are served from an HNSW graph or a flat
+// HNSW index. IVF-PQ and SCANN namespaces keep their vectors in a flat
+// HNSW index and are searched through a quantized index trained on them,
+// whose candidates are rescored exactly; they need at least
+// quantizedMinVectors vectors. The quantized codes are held in addition
+// to the full-precision vectors, so IVF-PQ and SCANN make searches faster
+// at the cost of more memory, never less.
+//
+// The new index is read and trained while writes and searches continue
+// against the old one. The vectors written in the meantime are recorded,
+// and once it is built, writes to every namespace are held off while they
+// are applied to it and it is swapped in. Vector IDs are kept, so
+// metadata, external IDs and the WAL are unaffected. A namespace is
+// migrated by one call at a time.
+//
+// Cancelling the call abandons the migration and leaves the namespace as it
+// was. With the WAL enabled the new index type is logged, and recovery
+// rebuilds the namespace as that type once its vectors are replayed.
+// Snapshots record the index type too, and Restore rebuilds a restored
+// namespace as that type once it is published.
+func (s *Server) Migrate(req *proto.MigrateRequest, stream proto.VectorDB_MigrateServer) error {
+	if req.Namespace == "" {
+		return status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	switch req.IndexType {
+	case migrate.TypeHNSW, migrate.TypeFlat, migrate.TypeIVFPQ, migrate.TypeSCANN:
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid index_type %q: expected %q, %q, %q or %q",
+			req.IndexType, migrate.TypeHNSW, migrate.TypeFlat, migrate.TypeIVFPQ, migrate.TypeSCANN)
+	}
+
+	start := time.Now()
+	var sendErr error
+	store, migrated, memoryBefore, err := s.migrateNamespace(stream.Context(), req.Namespace, req.IndexType, func(p migrate.Progress) {
+		if sendErr == nil {
+			sendErr = stream.Send(&proto.MigrateProgress{
+				Phase:        p.Phase,
+				VectorsDone:  int64(p.Done),
+				VectorsTotal: int64(p.Total),
+			})
+		}
+	})
+	if ctxErr := stream.Context().Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	migrateTime := time.Since(start)
+	memoryAfter := store.MemoryUsage() + migrated.memoryUsage()
+	log.Printf("Migrated namespace %s to %s: %d vectors, memory %d -> %d bytes (took %v)",
+		req.Namespace, req.IndexType, store.Size(), memoryBefore, memoryAfter, migrateTime)
+
+	return stream.Send(&proto.MigrateProgress{
+		Phase:             migrate.PhaseBuild,
+		VectorsDone:       store.Size(),
+		VectorsTotal:      store.Size(),
+		Done:              true,
+		IndexType:         req.IndexType,
+		MemoryBeforeBytes: memoryBefore,
+		MemoryAfterBytes:  memoryAfter,
+		MigrateTimeMs:     float32(migrateTime.Seconds() * 1000),
+	})
+}
+
+// migrateNamespace rebuilds a namespace as target and swaps the new index
+// in, returning it and the memory the old one used. The vectors written
+// while it is built are journaled and applied to it under s.writeMu just
+// before the swap, so writes are only held off for that catch-up.
+func (s *Server) migrateNamespace(ctx context.Context, namespace, target string, progress func(migrate.Progress)) (*hnsw.Index, *namespaceANN, int64, error) {
+	s.mu.Lock()
+	index := s.indexes[namespace]
+	ann := s.anns[namespace]
+	if index == nil {
+		s.mu.Unlock()
+		return nil, nil, 0, status.Errorf(codes.NotFound, "namespace %q not found", namespace)
+	}
+	if s.migrations[namespace] != nil {
+		s.mu.Unlock()
+		return nil, nil, 0, status.Errorf(codes.Aborted, "namespace %q is already being migrated", namespace)
+	}
+	journal := &migrationJournal{ids: make(map[uint64]struct{})}
+	s.migrations[namespace] = journal
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.migrations, namespace)
+		s.mu.Unlock()
+	}()
+
+	memoryBefore := index.MemoryUsage() + ann.memoryUsage()
+	store, migrated, err := s.buildIndexType(ctx, namespace, index, target, progress)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	// The namespace may have been dropped, restored or reconfigured since
+	s.mu.RLock()
+	current := s.indexes[namespace]
+	config := s.indexConfigs[namespace]
+	s.mu.RUnlock()
+	if current != index {
+		return nil, nil, 0, status.Errorf(codes.Aborted, "namespace %q was replaced during the migration; retry", namespace)
+	}
+
+	// No write is in flight, so the journal is complete
+	if err := catchUpMigration(index, store, migrated, journal.ids); err != nil {
+		return nil, nil, 0, status.Errorf(codes.Internal, "failed to apply writes made during the migration: %v", err)
+	}
+
+	// Log the index type among the declared parameters before swapping in,
+	// unless it is declared already
+	if config.IndexType != target {
+		config.IndexType = target
+		if err := s.appendWAL(namespace, config.walRecord()); err != nil {
+			return nil, nil, 0, status.Errorf(codes.Internal, "failed to log index type of namespace %s: %v", namespace, err)
+		}
+	}
+	s.installIndex(namespace, target, store, migrated)
+	return store, migrated, memoryBefore, nil
+}
+
+// catchUpMigration applies the writes made to the vectors under ids while
+// store and ann were built from index, so they hold what it holds now.
+// The caller holds s.writeMu.
+func catchUpMigration(index, store *hnsw.Index, ann *namespaceANN, ids map[uint64]struct{}) error {
+	for id := range ids {
+		vector, err := index.GetVector(id)
+		stored := err == nil
+
+		if store != index {
+			switch {
+			case stored:
+				if err := store.Restore(id, vector); err != nil {
+					return fmt.Errorf("vector %d: %w", id, err)
+				}
+			case store.GetNode(id) != nil:
+				if err := store.Delete(id); err != nil {
+					return fmt.Errorf("vector %d: %w", id, err)
+				}
+			}
+		}
+
+		if ann != nil {
+			_ = ann.index.Remove(int(id)) // Missing if it was inserted since
+			if stored {
+				if err := ann.index.Insert(vector, int(id), nil); err != nil {
+					return fmt.Errorf("vector %d: %w", id, err)
+				}
+			}
+		}
+	}
+
+	// Never reuse the IDs of vectors deleted since
+	store.AdvanceNextID(index.NextID())
+	return nil
+}
@@ -0,0 +1,428 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/migrate"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/wal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NamespaceIndexConfig declares the index parameters of a namespace. Zero
+// values take the server configuration.
+type NamespaceIndexConfig struct {
+	M              int    // HNSW links per node (2-100)
+	EfConstruction int    // HNSW candidate list size during insertion (>= 10)
+	Dimensions     int    // Dimension every vector must have (0 = fixed by the first insert)
+	Metric         string // Retrieval metric ("" = unchanged, cosine by default)
+
+	// IndexType is migrate.TypeHNSW to always build the graph, or
+	// migrate.TypeFlat to never build it and search every vector exactly
+	// ("" = HNSW, kept flat up to the configured flat threshold). Migrate
+	// also records migrate.TypeIVFPQ and migrate.TypeSCANN, whose vectors
+	// are kept flat and searched through a quantized index.
+	IndexType string
+
+	// QuantizationMin and QuantizationMax set the float range quantized
+	// vectors map back to, as SetQuantizationRange does (both 0 = unchanged)
+	QuantizationMin float32
+	QuantizationMax float32
+}
+
+// validate checks the declared parameters against the same bounds as the
+// server configuration
+func (c NamespaceIndexConfig) validate(maxDimensions int) error {
+	if c.M != 0 && (c.M < 2 || c.M > 100) {
+		return fmt.Errorf("invalid M: %d (must be between 2 and 100)", c.M)
+	}
+	if c.EfConstruction != 0 && c.EfConstruction < 10 {
+		return fmt.Errorf("invalid efConstruction: %d (must be >= 10)", c.EfConstruction)
+	}
+	if c.Dimensions < 0 || (maxDimensions > 0 && c.Dimensions > maxDimensions) {
+		return fmt.Errorf("invalid dimensions: %d (must be between 1 and %d)", c.Dimensions, maxDimensions)
+	}
+	if c.Metric != "" {
+		if _, err := distanceFuncForMetric(c.Metric); err != nil {
+			return err
+		}
+	}
+	switch c.IndexType {
+	case "", migrate.TypeHNSW, migrate.TypeFlat:
+	case migrate.TypeIVFPQ, migrate.TypeSCANN:
+		return fmt.Errorf("index type %q is trained on a namespace's vectors: create the namespace as %q or %q and migrate it once loaded",
+			c.IndexType, migrate.TypeHNSW, migrate.TypeFlat)
+	default:
+		return fmt.Errorf("invalid index type %q: expected %q or %q", c.IndexType, migrate.TypeHNSW, migrate.TypeFlat)
+	}
+	if c.quantized() && !(c.QuantizationMax > c.QuantizationMin) {
+		return fmt.Errorf("invalid quantization range: [%v, %v] (max must be > min)", c.QuantizationMin, c.QuantizationMax)
+	}
+	return nil
+}
+
+// quantized reports whether the config sets a quantization range
+func (c NamespaceIndexConfig) quantized() bool {
+	return c.QuantizationMin != 0 || c.QuantizationMax != 0
+}
+
+// indexType returns the index type an HNSW config builds
+func indexType(config hnsw.IndexConfig) string {
+	if config.FlatThreshold == math.MaxInt {
+		return migrate.TypeFlat
+	}
+	return migrate.TypeHNSW
+}
+
+// namespaceInfoLocked returns the parameters a namespace's index is built
+// with. Dimensions is 0 while any dimension is accepted. The caller holds
+// s.mu.
+func (s *Server) namespaceInfoLocked(namespace string, index *hnsw.Index) NamespaceIndexConfig {
+	config := index.Config()
+	info := NamespaceIndexConfig{
+		M:              config.M,
+		EfConstruction: config.EfConstruction(),
+		Dimensions:     s.dimensionsLocked(namespace, index),
+		Metric:         s.retrievalMetricLocked(namespace),
+		IndexType:      indexType(config),
+	}
+	if ann := s.anns[namespace]; ann != nil {
+		info.IndexType = ann.indexType
+	}
+	return info
+}
+
+// SetNamespaceIndexConfig declares the index parameters for a namespace and
+// creates it. M and efConstruction shape the graph, so like the metrics
+// they must be set before the namespace holds any vectors; an empty
+// namespace is rebuilt under the new parameters. Unlike other requests,
+// this creates the namespace on a server with strict namespaces. With the
+// WAL enabled the declaration is logged ahead of the namespace's writes,
+// so a restart recreates the namespace under the same parameters.
+func (s *Server) SetNamespaceIndexConfig(namespace string, config NamespaceIndexConfig) error {
+	if err := config.validate(s.config.HNSW.MaxDimensions); err != nil {
+		return err
+	}
+
+	// Hold off writes so none lands in the index being replaced
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.applyIndexConfig(namespace, config); err != nil {
+		return err
+	}
+	if err := s.appendWAL(namespace, config.walRecord()); err != nil {
+		return status.Errorf(codes.Internal, "failed to log index parameters of namespace %s: %v", namespace, err)
+	}
+	return nil
+}
+
+// applyIndexConfig rebuilds a namespace, which must be empty, under the
+// declared parameters. The caller holds s.writeMu or is replaying the WAL.
+func (s *Server) applyIndexConfig(namespace string, config NamespaceIndexConfig) error {
+	s.mu.Lock()
+	if index, exists := s.indexes[namespace]; exists {
+		if index.Size() > 0 {
+			s.mu.Unlock()
+			return fmt.Errorf("namespace %q already has vectors; index parameters must be set before the first insert", namespace)
+		}
+
+		// Drop the empty namespace so it is rebuilt under the new parameters
+		delete(s.indexes, namespace)
+		delete(s.textIndexes, namespace)
+		delete(s.hybridSearch, namespace)
+		delete(s.anns, namespace)
+		delete(s.metadata, namespace)
+		delete(s.externalIDs, namespace)
+	}
+	if config.Metric != "" {
+		metrics := s.namespaceMetrics[namespace]
+		metrics.Retrieval = config.Metric
+		s.namespaceMetrics[namespace] = metrics
+	}
+	if config.quantized() {
+		s.quantizers[namespace] = newRangeQuantizer(config.QuantizationMin, config.QuantizationMax)
+	}
+	s.indexConfigs[namespace] = config
+	s.mu.Unlock()
+
+	s.invalidateResultCache(namespace)
+	return s.initNamespace(namespace)
+}
+
+// WAL metadata keys of a logged NamespaceIndexConfig
+const (
+	configKeyM               = "m"
+	configKeyEfConstruction  = "ef_construction"
+	configKeyDimensions      = "dimensions"
+	configKeyMetric          = "metric"
+	configKeyIndexType       = "index_type"
+	configKeyQuantizationMin = "quantization_min"
+	configKeyQuantizationMax = "quantization_max"
+)
+
+// walRecord returns the WAL record declaring the parameters. Zero values
+// are left out, as they take the server configuration.
+func (c NamespaceIndexConfig) walRecord() *wal.Record {
+	params := make(map[string]string)
+	if c.M != 0 {
+		params[configKeyM] = strconv.Itoa(c.M)
+	}
+	if c.EfConstruction != 0 {
+		params[configKeyEfConstruction] = strconv.Itoa(c.EfConstruction)
+	}
+	if c.Dimensions != 0 {
+		params[configKeyDimensions] = strconv.Itoa(c.Dimensions)
+	}
+	if c.Metric != "" {
+		params[configKeyMetric] = c.Metric
+	}
+	if c.IndexType != "" {
+		params[configKeyIndexType] = c.IndexType
+	}
+	if c.quantized() {
+		params[configKeyQuantizationMin] = strconv.FormatFloat(float64(c.QuantizationMin), 'g', -1, 32)
+		params[configKeyQuantizationMax] = strconv.FormatFloat(float64(c.QuantizationMax), 'g', -1, 32)
+	}
+	return &wal.Record{Op: wal.OpConfig, Metadata: params}
+}
+
+// indexConfigFromRecord parses parameters logged by walRecord
+func indexConfigFromRecord(rec *wal.Record) (NamespaceIndexConfig, error) {
+	var config NamespaceIndexConfig
+	for key, value := range rec.Metadata {
+		var err error
+		switch key {
+		case configKeyM:
+			config.M, err = strconv.Atoi(value)
+		case configKeyEfConstruction:
+			config.EfConstruction, err = strconv.Atoi(value)
+		case configKeyDimensions:
+			config.Dimensions, err = strconv.Atoi(value)
+		case configKeyMetric:
+			config.Metric = value
+		case configKeyIndexType:
+			config.IndexType = value
+		case configKeyQuantizationMin:
+			config.QuantizationMin, err = parseFloat32(value)
+		case configKeyQuantizationMax:
+			config.QuantizationMax, err = parseFloat32(value)
+		default:
+			err = fmt.Errorf("unknown parameter")
+		}
+		if err != nil {
+			return NamespaceIndexConfig{}, fmt.Errorf("invalid index parameter %s=%q: %w", key, value, err)
+		}
+	}
+	return config, nil
+}
+
+// retrievalMetricLocked returns the metric a namespace's graph is built
+// under; the caller holds s.mu
+func (s *Server) retrievalMetricLocked(namespace string) string {
+	if metrics, ok := s.namespaceMetrics[namespace]; ok && metrics.Retrieval != "" {
+		return metrics.Retrieval
+	}
+	return MetricCosine
+}
+
+// parseFloat32 parses a float32 logged with strconv.FormatFloat
+func parseFloat32(value string) (float32, error) {
+	f, err := strconv.ParseFloat(value, 32)
+	return float32(f), err
+}
+
+// CreateNamespace implements the CreateNamespace RPC. Creating a namespace
+// that exists but is empty replaces its parameters; one that holds vectors
+// is refused with FailedPrecondition. IVF-PQ and SCANN indexes are trained
+// on the namespace's vectors, so those index types are refused with
+// InvalidArgument; Migrate a loaded namespace to them instead.
+func (s *Server) CreateNamespace(ctx context.Context, req *proto.CreateNamespaceRequest) (*proto.CreateNamespaceResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	config := NamespaceIndexConfig{
+		M:               int(req.M),
+		EfConstruction:  int(req.EfConstruction),
+		Dimensions:      int(req.Dimensions),
+		Metric:          req.Metric,
+		IndexType:       req.IndexType,
+		QuantizationMin: req.QuantizationMin,
+		QuantizationMax: req.QuantizationMax,
+	}
+	if err := config.validate(s.config.HNSW.MaxDimensions); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.SetNamespaceIndexConfig(req.Namespace, config); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	index := s.indexes[req.Namespace]
+	if index == nil {
+		return nil, status.Errorf(codes.NotFound, "namespace %q was dropped", req.Namespace)
+	}
+	info := s.namespaceInfoLocked(req.Namespace, index)
+	qmin, qmax := s.quantizationRangeLocked(req.Namespace)
+	return &proto.CreateNamespaceResponse{
+		Namespace:       req.Namespace,
+		M:               int32(info.M),
+		EfConstruction:  int32(info.EfConstruction),
+		Dimensions:      int32(info.Dimensions),
+		Metric:          info.Metric,
+		IndexType:       info.IndexType,
+		QuantizationMin: qmin,
+		QuantizationMax: qmax,
+	}, nil
+}
+
+// DescribeNamespace implements the DescribeNamespace RPC, returning
+// NotFound rather than creating a namespace that does not exist
+func (s *Server) DescribeNamespace(ctx context.Context, req *proto.DescribeNamespaceRequest) (*proto.DescribeNamespaceResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	index, exists := s.indexes[req.Namespace]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "namespace %q not found", req.Namespace)
+	}
+	info := s.namespaceInfoLocked(req.Namespace, index)
+	limits := s.searchLimits(req.Namespace)
+	qmin, qmax := s.quantizationRangeLocked(req.Namespace)
+	return &proto.DescribeNamespaceResponse{
+		Namespace:       req.Namespace,
+		IndexType:       info.IndexType,
+		M:               int32(info.M),
+		EfConstruction:  int32(info.EfConstruction),
+		Dimensions:      int32(info.Dimensions),
+		Metric:          info.Metric,
+		VectorCount:     index.Size(),
+		MemoryBytes:     s.namespaceMemoryUsage(req.Namespace),
+		DefaultEfSearch: int32(limits.DefaultEfSearch),
+		MaxK:            int32(limits.MaxK),
+		MaxEfSearch:     int32(limits.MaxEfSearch),
+		QuantizationMin: qmin,
+		QuantizationMax: qmax,
+	}, nil
+}
+
+// DeleteNamespace removes a namespace and everything held for it: its
+// indexes, metadata, external IDs, settings overrides and, with the WAL
+// enabled, its log file, so it is not recovered on restart. Writes are held
+// off while it runs. It returns the number of vectors dropped, or a
+// NotFound status if the namespace does not exist. Unless namespaces are
+// strict, a later request naming the namespace creates it again, empty and
+// with default settings.
+func (s *Server) DeleteNamespace(namespace string) (int64, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	index, exists := s.indexes[namespace]
+	if !exists {
+		s.mu.Unlock()
+		return 0, status.Errorf(codes.NotFound, "namespace %q not found", namespace)
+	}
+
+	if l := s.wals[namespace]; l != nil {
+		if err := l.Close(); err != nil {
+			log.Printf("Warning: failed to close WAL for namespace %s: %v", namespace, err)
+		}
+		delete(s.wals, namespace)
+	}
+	if s.config.WAL.Enabled {
+		if err := os.Remove(s.walPath(namespace)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.mu.Unlock()
+			return 0, status.Errorf(codes.Internal, "failed to remove WAL for namespace %s: %v", namespace, err)
+		}
+	}
+
+	delete(s.indexes, namespace)
+	delete(s.textIndexes, namespace)
+	delete(s.hybridSearch, namespace)
+	delete(s.anns, namespace)
+	delete(s.metadata, namespace)
+	delete(s.externalIDs, namespace)
+	delete(s.dimensionPolicies, namespace)
+	delete(s.namespaceMetrics, namespace)
+	delete(s.indexConfigs, namespace)
+	delete(s.efSearchMultipliers, namespace)
+	delete(s.normalizeOnInsert, namespace)
+	delete(s.quantizers, namespace)
+	s.mu.Unlock()
+	s.normChecks.Delete(namespace)
+
+	s.invalidateResultCache(namespace)
+	if s.metrics != nil {
+		s.metrics.RemoveNamespace(namespace)
+	}
+	s.forgetQuota(namespace)
+
+	dropped := index.Size()
+	log.Printf("Dropped namespace %s (%d vectors)", namespace, dropped)
+	return dropped, nil
+}
+
+// DropNamespace implements the DropNamespace RPC
+func (s *Server) DropNamespace(ctx context.Context, req *proto.DropNamespaceRequest) (*proto.DropNamespaceResponse, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+
+	dropped, err := s.DeleteNamespace(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DropNamespaceResponse{VectorsDropped: dropped}, nil
+}
+
+// Count implements the Count RPC. A namespace that does not exist counts
+// zero vectors and is not created.
+func (s *Server) Count(ctx context.Context, req *proto.CountRequest) (*proto.CountResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if req.Namespace != "" {
+		var count int64
+		if index, exists := s.indexes[req.Namespace]; exists {
+			count = index.Size()
+		}
+		return &proto.CountResponse{Counts: map[string]int64{req.Namespace: count}}, nil
+	}
+
+	counts := make(map[string]int64, len(s.indexes))
+	for namespace, index := range s.indexes {
+		counts[namespace] = index.Size()
+	}
+	return &proto.CountResponse{Counts: counts}, nil
+}
+
+// ListNamespaces implements the ListNamespaces RPC, returning namespace
+// names in sorted order
+func (s *Server) ListNamespaces(ctx context.Context, req *proto.ListNamespacesRequest) (*proto.ListNamespacesResponse, error) {
+	s.mu.RLock()
+	namespaces := make([]string, 0, len(s.indexes))
+	for namespace := range s.indexes {
+		namespaces = append(namespaces, namespace)
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(namespaces)
+	return &proto.ListNamespacesResponse{Namespaces: namespaces}, nil
+}
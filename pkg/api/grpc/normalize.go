@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+)
+
+// NormMetadataKey is the metadata key holding a vector's L2 norm from
+// before normalize-on-insert scaled it to unit length
+const NormMetadataKey = "_original_norm"
+
+// SetNormalizeOnInsert overrides normalize-on-insert for a namespace. When
+// enabled and the namespace's retrieval metric is cosine, inserted, updated
+// and query vectors are L2-normalized, and each stored vector keeps its
+// original norm in metadata under NormMetadataKey.
+func (s *Server) SetNormalizeOnInsert(namespace string, enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.normalizeOnInsert[namespace] = enabled
+}
+
+// shouldNormalize reports whether a namespace normalizes its vectors,
+// falling back to the configured default
+func (s *Server) shouldNormalize(namespace string) bool {
+	s.mu.RLock()
+	enabled, ok := s.normalizeOnInsert[namespace]
+	metrics, hasMetrics := s.namespaceMetrics[namespace]
+	s.mu.RUnlock()
+
+	if !ok {
+		enabled = s.config.HNSW.NormalizeOnInsert
+	}
+	// Namespaces without declared metrics use cosine
+	return enabled && (!hasMetrics || metrics.Retrieval == MetricCosine)
+}
+
+// usesCosine reports whether a namespace retrieves by cosine distance
+func (s *Server) usesCosine(namespace string) bool {
+	metrics, ok := s.metricsFor(namespace)
+	return !ok || metrics.Retrieval == MetricCosine
+}
+
+// normalizeVector returns v scaled to unit L2 length and its original norm
+func normalizeVector(v []float32) ([]float32, float32, error) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return nil, 0, fmt.Errorf("cannot normalize a zero vector")
+	}
+
+	norm := math.Sqrt(sum)
+	normalized := make([]float32, len(v))
+	for i, x := range v {
+		normalized[i] = float32(float64(x) / norm)
+	}
+	return normalized, float32(norm), nil
+}
+
+// normalizeInsertVector normalizes an insert request's vector in place and
+// records its original norm in the request metadata
+func (s *Server) normalizeInsertVector(req *proto.InsertRequest) error {
+	if !s.shouldNormalize(req.Namespace) {
+		s.sampleNorm(req.Namespace, req.Vector)
+		return nil
+	}
+
+	vector, norm, err := normalizeVector(req.Vector)
+	if err != nil {
+		return err
+	}
+
+	metadata := make(map[string]string, len(req.Metadata)+1)
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+	metadata[NormMetadataKey] = formatNorm(norm)
+
+	req.Vector = vector
+	req.Metadata = metadata
+	return nil
+}
+
+// normalizeUpdateVector normalizes an update request's vector in place and
+// records its new norm. An update that leaves metadata unchanged keeps the
+// stored metadata, typed values included, with the norm replaced.
+func (s *Server) normalizeUpdateVector(req *proto.UpdateRequest, id uint64) error {
+	if !s.shouldNormalize(req.Namespace) {
+		return nil
+	}
+
+	vector, norm, err := normalizeVector(req.Vector)
+	if err != nil {
+		return err
+	}
+
+	metadata := make(map[string]string)
+	if len(req.Metadata) > 0 || len(req.TypedMetadata) > 0 {
+		for k, v := range req.Metadata {
+			metadata[k] = v
+		}
+	} else {
+		s.mu.RLock()
+		strs, typed := splitMetadata(s.metadata[req.Namespace][id])
+		s.mu.RUnlock()
+		for k, v := range strs {
+			metadata[k] = v
+		}
+		req.TypedMetadata = typedMetadataProto(typed)
+	}
+	metadata[NormMetadataKey] = formatNorm(norm)
+
+	req.Vector = vector
+	req.Metadata = metadata
+	return nil
+}
+
+// normalizeQuery returns the query scaled to unit length when the
+// namespace normalizes its vectors
+func (s *Server) normalizeQuery(namespace string, query []float32) ([]float32, error) {
+	if !s.shouldNormalize(namespace) {
+		return query, nil
+	}
+
+	normalized, _, err := normalizeVector(query)
+	if err != nil {
+		return nil, fmt.Errorf("query vector: %w", err)
+	}
+	return normalized, nil
+}
+
+// Cosine namespaces are checked for vectors far from unit length, the sign
+// of an embedding pipeline that skipped normalization. Every
+// NormCheckInterval-th insert is sampled, along with a few vectors of each
+// namespace recovered at startup, and a warning is logged once per
+// namespace when most samples are off.
+const (
+	normTolerance           = 0.1 // Largest |norm - 1| counted as unit length
+	normCheckMinSamples     = 10  // Samples taken before warning
+	normCheckStartupSamples = 100 // Vectors sampled from each recovered namespace
+)
+
+// normCheck tracks a namespace's sampled norms
+type normCheck struct {
+	inserts atomic.Int64 // Inserts seen, sampled or not
+	sampled atomic.Int64
+	off     atomic.Int64 // Samples not of unit length
+	warned  atomic.Bool
+}
+
+// normCheckFor returns a namespace's norm check, creating it on first use
+func (s *Server) normCheckFor(namespace string) *normCheck {
+	check, _ := s.normChecks.LoadOrStore(namespace, &normCheck{})
+	return check.(*normCheck)
+}
+
+// sampleNorm counts every NormCheckInterval-th vector inserted into a
+// cosine namespace toward its unit-length check
+func (s *Server) sampleNorm(namespace string, vector []float32) {
+	interval := int64(s.config.HNSW.NormCheckInterval)
+	if interval <= 0 || !s.usesCosine(namespace) {
+		return
+	}
+	check := s.normCheckFor(namespace)
+	if check.warned.Load() || (check.inserts.Add(1)-1)%interval != 0 {
+		return
+	}
+	s.recordNorm(namespace, check, vector)
+}
+
+// checkRecoveredNorms samples the vectors of each namespace recovered at
+// startup that neither normalizes them nor uses another metric
+func (s *Server) checkRecoveredNorms() {
+	if s.config.HNSW.NormCheckInterval <= 0 {
+		return
+	}
+	for namespace, index := range s.indexes {
+		if !s.usesCosine(namespace) || s.shouldNormalize(namespace) {
+			continue
+		}
+		check := s.normCheckFor(namespace)
+		for _, id := range index.IDsFrom(0, normCheckStartupSamples) {
+			if vector, err := index.GetVector(id); err == nil {
+				s.recordNorm(namespace, check, vector)
+			}
+		}
+	}
+}
+
+// recordNorm counts one sampled vector and logs the warning once most
+// samples are off unit length
+func (s *Server) recordNorm(namespace string, check *normCheck, vector []float32) {
+	var sum float64
+	for _, x := range vector {
+		sum += float64(x) * float64(x)
+	}
+
+	sampled := check.sampled.Add(1)
+	off := check.off.Load()
+	if math.Abs(math.Sqrt(sum)-1) > normTolerance {
+		off = check.off.Add(1)
+	}
+	if sampled < normCheckMinSamples || 2*off <= sampled || !check.warned.CompareAndSwap(false, true) {
+		return
+	}
+	log.Printf("Warning: namespace %s uses cosine distance, but %d of %d sampled vectors are not unit length "+
+		"(norm outside 1 ± %g). Normalize embeddings before inserting them, or enable normalize-on-insert "+
+		"(VECTOR_NORMALIZE_ON_INSERT=true), to avoid poor recall.", namespace, off, sampled, normTolerance)
+}
+
+func formatNorm(norm float32) string {
+	return strconv.FormatFloat(float64(norm), 'g', -1, 32)
+}
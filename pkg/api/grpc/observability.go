@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metricsUnaryInterceptor records each unary RPC's count, latency and
+// status code
+func (s *Server) metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.recordRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+// metricsStreamInterceptor records each streaming RPC's count, latency and
+// status code
+func (s *Server) metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	s.recordRequest(info.FullMethod, start, err)
+	return err
+}
+
+// recordRequest records an RPC under its short method name, e.g. "Search"
+func (s *Server) recordRequest(fullMethod string, start time.Time, err error) {
+	method := path.Base(fullMethod)
+	code := status.Code(err).String()
+	s.metrics.RecordRequest(method, code, time.Since(start))
+	if err != nil {
+		s.metrics.RecordError(method, code)
+	}
+}
+
+// recordInsert counts inserted vectors and refreshes the namespace's gauges
+func (s *Server) recordInsert(namespace string, index *hnsw.Index, count int) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.RecordInsert(namespace, count)
+	s.updateIndexMetrics(namespace, index)
+}
+
+// recordDelete counts deleted vectors and refreshes the namespace's gauges
+// and quota usage
+func (s *Server) recordDelete(namespace string, index *hnsw.Index, count int) {
+	s.syncQuota(namespace, index)
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.RecordDelete(namespace, count)
+	s.updateIndexMetrics(namespace, index)
+}
+
+// recordUpdate counts updated vectors and refreshes the namespace's gauges
+func (s *Server) recordUpdate(namespace string, index *hnsw.Index, count int) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.RecordUpdate(namespace, count)
+	s.updateIndexMetrics(namespace, index)
+}
+
+// recordSearch records a completed k-NN query's latency and result count
+func (s *Server) recordSearch(duration time.Duration, resultSize int) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.RecordSearch(duration, resultSize)
+}
+
+// updateIndexMetrics sets a namespace's index size and max layer gauges.
+// Memory usage walks the whole graph, so it is only refreshed by GetStats.
+func (s *Server) updateIndexMetrics(namespace string, index *hnsw.Index) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.UpdateIndexSize(namespace, int(index.Size()))
+	s.metrics.UpdateIndexMaxLayer(namespace, index.MaxLayer())
+}
@@ -0,0 +1,346 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pointInTime is a snapshot loaded to serve as_of searches. It is never
+// written to, so searches share it without locking.
+type pointInTime struct {
+	path       string
+	modTime    time.Time // Of the file when loaded; a replaced file is reloaded
+	captured   time.Time
+	namespaces map[string]*pointInTimeNamespace
+}
+
+// pointInTimeNamespace is one namespace of a loaded snapshot
+type pointInTimeNamespace struct {
+	*restoredNamespace
+	externalIDs map[uint64]string
+}
+
+// snapshotAt returns the path and capture time of the newest snapshot in
+// the snapshot directory captured at or before asOf, to the millisecond.
+// Only files named by Snapshot are considered.
+func (s *Server) snapshotAt(asOf time.Time) (string, time.Time, error) {
+	dir, err := filepath.Abs(s.snapshotDir())
+	if err != nil {
+		return "", time.Time{}, status.Error(codes.Internal, err.Error())
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", time.Time{}, status.Errorf(codes.Internal, "failed to list snapshots: %v", err)
+	}
+
+	var path string
+	var captured time.Time
+	for _, entry := range entries {
+		stamp, ok := strings.CutPrefix(entry.Name(), snapshotPrefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		stamp, ok = strings.CutSuffix(stamp, snapshotExt)
+		if !ok {
+			continue
+		}
+		t, err := time.ParseInLocation(snapshotTimeFormat, stamp, time.UTC)
+		if err != nil || t.UnixMilli() > asOf.UnixMilli() || (path != "" && !t.After(captured)) {
+			continue
+		}
+		path, captured = filepath.Join(dir, entry.Name()), t
+	}
+
+	if path == "" {
+		return "", time.Time{}, status.Errorf(codes.NotFound, "no snapshot in %s was taken at or before %s",
+			dir, asOf.UTC().Format(time.RFC3339Nano))
+	}
+	return path, captured, nil
+}
+
+// pointInTimeAt returns the snapshot an as_of search is served from,
+// loading it unless it is the one loaded last. Only that one is kept, so
+// searches alternating between snapshots reload them.
+func (s *Server) pointInTimeAt(asOf time.Time) (*pointInTime, error) {
+	path, captured, err := s.snapshotAt(asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	s.asOfMu.Lock()
+	defer s.asOfMu.Unlock()
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, status.Errorf(codes.NotFound, "snapshot %s not found", path)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if loaded := s.asOf; loaded != nil && loaded.path == path && loaded.modTime.Equal(info.ModTime()) {
+		return loaded, nil
+	}
+
+	start := time.Now()
+	namespaces, err := s.readSnapshot(bufio.NewReader(file), "")
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "failed to read snapshot %s: %v", path, err)
+	}
+
+	pit := &pointInTime{
+		path:       path,
+		modTime:    info.ModTime(),
+		captured:   captured,
+		namespaces: make(map[string]*pointInTimeNamespace, len(namespaces)),
+	}
+	for _, ns := range namespaces {
+		externalIDs := make(map[uint64]string)
+		for _, doc := range ns.documents {
+			if doc.externalID != "" {
+				externalIDs[doc.id] = doc.externalID
+			}
+		}
+		pit.namespaces[ns.name] = &pointInTimeNamespace{restoredNamespace: ns, externalIDs: externalIDs}
+	}
+	s.asOf = pit
+
+	log.Printf("Loaded snapshot %s for as_of searches (%d namespaces, took %v)", path, len(namespaces), time.Since(start))
+	return pit, nil
+}
+
+// searchAsOf serves a search with as_of from the newest snapshot taken at
+// or before it, as the namespace stood then: no write logged after the
+// snapshot is applied. The query is validated against the snapshot's
+// dimension and searched under its metrics, normalization and efSearch
+// multiplier.
+func (s *Server) searchAsOf(ctx context.Context, req *proto.SearchRequest, fields *resultFields, start time.Time) (*proto.SearchResponse, error) {
+	if req.AsOf < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "as_of must be a Unix time in milliseconds, got %d", req.AsOf)
+	}
+	if req.Reranker != "" {
+		return nil, status.Error(codes.InvalidArgument, "reranker is not supported with as_of")
+	}
+
+	var filter search.Filter
+	if req.Filter != nil {
+		var err error
+		filter, err = protoFilterToFilter(req.Filter)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		}
+	}
+
+	pit, err := s.pointInTimeAt(time.UnixMilli(req.AsOf))
+	if err != nil {
+		return nil, err
+	}
+	ns, ok := pit.namespaces[req.Namespace]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "namespace %q is not in snapshot %s", req.Namespace, pit.path)
+	}
+	index := ns.index
+
+	query := req.QueryVector
+	if dim := index.Dimension(); dim > 0 && len(query) != dim {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"vector dimension mismatch: expected %d, got %d", dim, len(query))
+	}
+	if ns.normalizes(s.config.HNSW.NormalizeOnInsert) {
+		query, _, err = normalizeVector(query)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "query vector: %v", err)
+		}
+	}
+
+	k := int(req.K)
+	offset := int(req.Offset)
+	depth := offset + k
+
+	multiplier := s.config.HNSW.EfSearchMultiplier
+	if ns.settings.efSearchMultiplier != nil {
+		multiplier = *ns.settings.efSearchMultiplier
+	}
+	efSearch := scaleEfSearch(s.searchLimits(req.Namespace).efSearch(int(req.EfSearch)), depth, multiplier)
+
+	fetchK := depth
+	metrics := ns.metrics()
+	var rerankFunc hnsw.DistanceFunc
+	if metrics.Rerank != "" && metrics.Rerank != metrics.Retrieval {
+		rerankFunc, _ = distanceFuncForMetric(metrics.Rerank)
+		fetchK = metrics.rerankDepth(depth, efSearch)
+	}
+	maxDistance := searchMaxDistance(req, metrics)
+	graphMaxDistance := float32(math.Inf(1))
+	if rerankFunc == nil {
+		graphMaxDistance = maxDistance
+	}
+
+	// A filtered search ranks the whole snapshot, so up to k matches are
+	// found whenever they exist
+	prof := newSearchProfile(req.Profile)
+	exact := filter != nil || s.useExactSearch(index)
+	var results []hnsw.Result
+	if index.Size() > 0 {
+		var searchResult *hnsw.SearchResult
+		if exact {
+			fetch := fetchK
+			if filter != nil {
+				fetch = int(index.Size())
+			}
+			searchResult, err = index.ExactSearchWithProfile(query, fetch, prof.hnswProfile())
+		} else {
+			searchResult, err = index.SearchWithinCtx(ctx, query, fetchK, efSearch, graphMaxDistance, prof.hnswProfile())
+		}
+		if err != nil {
+			return nil, searchStatus(err)
+		}
+		results = ns.filterResults(searchResult.Results, filter)
+		if len(results) > fetchK {
+			results = results[:fetchK]
+		}
+	}
+
+	if rerankFunc != nil {
+		results = rerankResults(index, query, results, depth, rerankFunc)
+	}
+	results = withinMaxDistance(results, maxDistance)
+	if offset >= len(results) {
+		results = nil
+	} else {
+		results = results[offset:]
+	}
+	if len(results) > k {
+		results = results[:k]
+	}
+
+	var totalMatches int64
+	if req.CountTotal {
+		totalMatches = ns.countMatches(filter)
+	}
+
+	protoResults := make([]*proto.SearchResult, 0, len(results))
+	for _, r := range results {
+		result := ns.resultToProto(r, fields)
+		if req.ScoreMode == ScoreModeSimilarity {
+			result.Score = floatPtr(similarityScore(scoreMetric(metrics), r.Distance))
+		}
+		protoResults = append(protoResults, result)
+	}
+
+	searchTime := time.Since(start)
+	s.recordSearch(searchTime, len(protoResults))
+
+	return &proto.SearchResponse{
+		Results:           protoResults,
+		TotalResults:      int32(len(protoResults)),
+		SearchTimeMs:      float32(searchTime.Milliseconds()),
+		Profile:           prof.toProto(),
+		EffectiveEfSearch: int32(efSearch),
+		Exact:             exact,
+		TotalMatches:      totalMatches,
+		AsOf:              pit.captured.UnixMilli(),
+	}, nil
+}
+
+// metrics returns the metrics the namespace declared when snapshotted
+func (ns *pointInTimeNamespace) metrics() NamespaceMetrics {
+	if ns.settings.metrics == nil {
+		return NamespaceMetrics{}
+	}
+	return *ns.settings.metrics
+}
+
+// normalizes reports whether the namespace normalized its vectors when
+// snapshotted, given the configured default
+func (ns *pointInTimeNamespace) normalizes(defaultEnabled bool) bool {
+	enabled := defaultEnabled
+	if ns.settings.normalize != snapshotNormalizeUnset {
+		enabled = ns.settings.normalize == snapshotNormalizeOn
+	}
+	metrics := ns.metrics()
+	return enabled && (metrics.Retrieval == "" || metrics.Retrieval == MetricCosine)
+}
+
+// filterResults keeps the results whose snapshotted metadata passes filter
+func (ns *pointInTimeNamespace) filterResults(results []hnsw.Result, filter search.Filter) []hnsw.Result {
+	if filter == nil {
+		return results
+	}
+	filtered := make([]hnsw.Result, 0, len(results))
+	for _, r := range results {
+		if metadata, ok := ns.metadata[r.ID]; ok && filter.Match(metadata) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// countMatches counts the snapshotted vectors that pass filter, or all of
+// them without one
+func (ns *pointInTimeNamespace) countMatches(filter search.Filter) int64 {
+	if filter == nil {
+		return ns.index.Size()
+	}
+	var count int64
+	for _, metadata := range ns.metadata {
+		if filter.Match(metadata) {
+			count++
+		}
+	}
+	return count
+}
+
+// resultToProto converts a result from the snapshot, returning only the
+// requested fields
+func (ns *pointInTimeNamespace) resultToProto(r hnsw.Result, fields *resultFields) *proto.SearchResult {
+	metadataProto, typedProto := metadataToProto(fields.projectMetadata(ns.metadata[r.ID]))
+
+	var vector []float32
+	if fields.includeVector() {
+		if node := ns.index.GetNode(r.ID); node != nil {
+			vector = node.Vector()
+		}
+	}
+
+	var text *string
+	if fields.includeText() {
+		if doc := ns.textIndex.GetDocument(r.ID); doc != nil {
+			text = &doc.Text
+		}
+	}
+
+	var externalID *string
+	if id, ok := ns.externalIDs[r.ID]; ok {
+		externalID = stringPtr(id)
+	}
+
+	return &proto.SearchResult{
+		Id:            strconv.FormatUint(r.ID, 10),
+		Distance:      r.Distance,
+		Vector:        vector,
+		Metadata:      metadataProto,
+		TypedMetadata: typedProto,
+		Text:          text,
+		ExternalId:    externalID,
+	}
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+)
+
+// searchProfile accumulates a per-request profile across index searches and
+// filtering. A nil profile disables profiling.
+type searchProfile struct {
+	start             time.Time
+	index             hnsw.SearchProfile
+	filterEvaluations int
+	filterNanos       int64
+}
+
+// newSearchProfile returns a profile if enabled, or nil
+func newSearchProfile(enabled bool) *searchProfile {
+	if !enabled {
+		return nil
+	}
+	return &searchProfile{start: time.Now()}
+}
+
+// hnswProfile returns the index-level profile to pass to hnsw searches
+func (p *searchProfile) hnswProfile() *hnsw.SearchProfile {
+	if p == nil {
+		return nil
+	}
+	return &p.index
+}
+
+// filterResults applies a filter, counting and timing evaluations when profiling
+func (s *Server) filterResults(namespace string, results []hnsw.Result, filter search.Filter, prof *searchProfile) []hnsw.Result {
+	if prof == nil || filter == nil {
+		return s.applyFilterToResults(namespace, results, filter)
+	}
+
+	start := time.Now()
+	filtered := s.applyFilterToResults(namespace, results, filter)
+	prof.filterNanos += time.Since(start).Nanoseconds()
+	prof.filterEvaluations += len(results)
+	return filtered
+}
+
+// filterPredicate returns a predicate that evaluates filter against a
+// vector's metadata during index traversal, counting and timing evaluations
+// when profiling
+func (s *Server) filterPredicate(namespace string, filter search.Filter, prof *searchProfile) func(id uint64) bool {
+	s.mu.RLock()
+	metadataStore := s.metadata[namespace]
+	s.mu.RUnlock()
+
+	match := func(id uint64) bool {
+		metadata, ok := metadataStore[id]
+		return ok && filter.Match(metadata)
+	}
+	if prof == nil {
+		return match
+	}
+	return func(id uint64) bool {
+		start := time.Now()
+		matched := match(id)
+		prof.filterNanos += time.Since(start).Nanoseconds()
+		prof.filterEvaluations++
+		return matched
+	}
+}
+
+// toProto converts the profile to its wire form. Span names use the folded
+// stack format so they can be fed straight into flame graph tooling.
+func (p *searchProfile) toProto() *proto.SearchProfile {
+	if p == nil {
+		return nil
+	}
+
+	return &proto.SearchProfile{
+		Spans: []*proto.ProfileSpan{
+			{Name: "search", DurationNs: time.Since(p.start).Nanoseconds()},
+			{Name: "search;hnsw;greedy_descent", DurationNs: p.index.GreedyNanos},
+			{Name: "search;hnsw;base_layer", DurationNs: p.index.BaseLayerNanos},
+			{Name: "search;hnsw;exact_scan", DurationNs: p.index.ScanNanos},
+			{Name: "search;filter", DurationNs: p.filterNanos},
+		},
+		DistanceComputations: int64(p.index.DistanceComputations),
+		DistanceNs:           p.index.DistanceNanos,
+		NodesVisited:         int64(p.index.NodesVisited),
+		HeapOperations:       int64(p.index.HeapOperations),
+		FilterEvaluations:    int64(p.filterEvaluations),
+	}
+}
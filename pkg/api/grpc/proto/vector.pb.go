@@ -23,14 +23,21 @@ const (
 
 // InsertRequest contains a vector and its metadata
 type InsertRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                         // Namespace for multi-tenancy
-	Vector        []float32              `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                      // Vector embedding
-	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Metadata key-value pairs
-	Id            *string                `protobuf:"bytes,4,opt,name=id,proto3,oneof" json:"id,omitempty"`                                                                                 // Optional custom ID (auto-generated if not provided)
-	Text          *string                `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`                                                                             // Optional text content for full-text search
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState    `protogen:"open.v1"`
+	Namespace       string                    `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                                                        // Namespace for multi-tenancy
+	Vector          []float32                 `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                                                     // Vector embedding
+	Metadata        map[string]string         `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                // Metadata key-value pairs
+	Id              *string                   `protobuf:"bytes,4,opt,name=id,proto3,oneof" json:"id,omitempty"`                                                                                                                // With upsert and no external_id, the vector (internal or external ID) to replace
+	Text            *string                   `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`                                                                                                            // Optional text content for full-text search
+	QuantizedVector []byte                    `protobuf:"bytes,6,opt,name=quantized_vector,json=quantizedVector,proto3" json:"quantized_vector,omitempty"`                                                                     // Quantized embedding; when set, vector is ignored
+	Quantization    string                    `protobuf:"bytes,7,opt,name=quantization,proto3" json:"quantization,omitempty"`                                                                                                  // Encoding of quantized_vector: "int8" or "uint8"
+	EfConstruction  int32                     `protobuf:"varint,8,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`                                                                       // HNSW construction ef override (0 = index default); in BatchInsert, read from the first message for the whole batch
+	TypedMetadata   map[string]*MetadataValue `protobuf:"bytes,9,rep,name=typed_metadata,json=typedMetadata,proto3" json:"typed_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Typed metadata; replaces a metadata entry with the same key
+	ExternalId      *string                   `protobuf:"bytes,10,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`                                                                             // Client-supplied ID, unique per namespace; accepted wherever a vector ID is
+	Upsert          bool                      `protobuf:"varint,11,opt,name=upsert,proto3" json:"upsert,omitempty"`                                                                                                            // Replace the vector stored under external_id (or id) in place, keeping its ID; insert if there is none
+	OnError         string                    `protobuf:"bytes,12,opt,name=on_error,json=onError,proto3" json:"on_error,omitempty"`                                                                                            // BatchInsert failure handling, read from the first message: "continue" (default) skips failed items; "abort" stops at the first and rolls back the batch
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *InsertRequest) Reset() {
@@ -98,6 +105,125 @@ func (x *InsertRequest) GetText() string {
 	return ""
 }
 
+func (x *InsertRequest) GetQuantizedVector() []byte {
+	if x != nil {
+		return x.QuantizedVector
+	}
+	return nil
+}
+
+func (x *InsertRequest) GetQuantization() string {
+	if x != nil {
+		return x.Quantization
+	}
+	return ""
+}
+
+func (x *InsertRequest) GetEfConstruction() int32 {
+	if x != nil {
+		return x.EfConstruction
+	}
+	return 0
+}
+
+func (x *InsertRequest) GetTypedMetadata() map[string]*MetadataValue {
+	if x != nil {
+		return x.TypedMetadata
+	}
+	return nil
+}
+
+func (x *InsertRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *InsertRequest) GetUpsert() bool {
+	if x != nil {
+		return x.Upsert
+	}
+	return false
+}
+
+func (x *InsertRequest) GetOnError() string {
+	if x != nil {
+		return x.OnError
+	}
+	return ""
+}
+
+// MetadataValue is a typed metadata value; exactly one field must be set.
+// Optional fields rather than a oneof keep it decodable from REST JSON.
+type MetadataValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StringValue   *string                `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof" json:"string_value,omitempty"`
+	IntValue      *int64                 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof" json:"int_value,omitempty"`
+	DoubleValue   *float64               `protobuf:"fixed64,3,opt,name=double_value,json=doubleValue,proto3,oneof" json:"double_value,omitempty"`
+	BoolValue     *bool                  `protobuf:"varint,4,opt,name=bool_value,json=boolValue,proto3,oneof" json:"bool_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataValue) Reset() {
+	*x = MetadataValue{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataValue) ProtoMessage() {}
+
+func (x *MetadataValue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataValue.ProtoReflect.Descriptor instead.
+func (*MetadataValue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MetadataValue) GetStringValue() string {
+	if x != nil && x.StringValue != nil {
+		return *x.StringValue
+	}
+	return ""
+}
+
+func (x *MetadataValue) GetIntValue() int64 {
+	if x != nil && x.IntValue != nil {
+		return *x.IntValue
+	}
+	return 0
+}
+
+func (x *MetadataValue) GetDoubleValue() float64 {
+	if x != nil && x.DoubleValue != nil {
+		return *x.DoubleValue
+	}
+	return 0
+}
+
+func (x *MetadataValue) GetBoolValue() bool {
+	if x != nil && x.BoolValue != nil {
+		return *x.BoolValue
+	}
+	return false
+}
+
 // InsertResponse returns the ID of the inserted vector
 type InsertResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -110,7 +236,7 @@ type InsertResponse struct {
 
 func (x *InsertResponse) Reset() {
 	*x = InsertResponse{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[1]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -122,7 +248,7 @@ func (x *InsertResponse) String() string {
 func (*InsertResponse) ProtoMessage() {}
 
 func (x *InsertResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[1]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -135,7 +261,7 @@ func (x *InsertResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InsertResponse.ProtoReflect.Descriptor instead.
 func (*InsertResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{1}
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *InsertResponse) GetId() string {
@@ -161,20 +287,33 @@ func (x *InsertResponse) GetError() string {
 
 // SearchRequest specifies vector search parameters
 type SearchRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Namespace      string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                       // Namespace to search in
-	QueryVector    []float32              `protobuf:"fixed32,2,rep,packed,name=query_vector,json=queryVector,proto3" json:"query_vector,omitempty"`       // Query vector
-	K              int32                  `protobuf:"varint,3,opt,name=k,proto3" json:"k,omitempty"`                                                      // Number of results to return
-	EfSearch       int32                  `protobuf:"varint,4,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`                        // HNSW ef_search parameter (accuracy vs speed)
-	Filter         *Filter                `protobuf:"bytes,5,opt,name=filter,proto3,oneof" json:"filter,omitempty"`                                       // Optional metadata filter
-	DistanceMetric *string                `protobuf:"bytes,6,opt,name=distance_metric,json=distanceMetric,proto3,oneof" json:"distance_metric,omitempty"` // "cosine", "euclidean", or "dot_product"
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Namespace            string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                     // Namespace to search in
+	QueryVector          []float32              `protobuf:"fixed32,2,rep,packed,name=query_vector,json=queryVector,proto3" json:"query_vector,omitempty"`                     // Query vector
+	K                    int32                  `protobuf:"varint,3,opt,name=k,proto3" json:"k,omitempty"`                                                                    // Number of results to return
+	EfSearch             int32                  `protobuf:"varint,4,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`                                      // HNSW ef_search parameter (accuracy vs speed)
+	Filter               *Filter                `protobuf:"bytes,5,opt,name=filter,proto3,oneof" json:"filter,omitempty"`                                                     // Optional metadata filter
+	DistanceMetric       *string                `protobuf:"bytes,6,opt,name=distance_metric,json=distanceMetric,proto3,oneof" json:"distance_metric,omitempty"`               // "cosine", "euclidean", or "dot_product"
+	GuaranteeK           bool                   `protobuf:"varint,7,opt,name=guarantee_k,json=guaranteeK,proto3" json:"guarantee_k,omitempty"`                                // Keep searching deeper until k results pass the filter (up to a work cap)
+	Profile              bool                   `protobuf:"varint,8,opt,name=profile,proto3" json:"profile,omitempty"`                                                        // Attach a search profile to the response
+	QuantizedQueryVector []byte                 `protobuf:"bytes,9,opt,name=quantized_query_vector,json=quantizedQueryVector,proto3" json:"quantized_query_vector,omitempty"` // Quantized query; when set, query_vector is ignored
+	Quantization         string                 `protobuf:"bytes,10,opt,name=quantization,proto3" json:"quantization,omitempty"`                                              // Encoding of quantized_query_vector: "int8" or "uint8"
+	CountTotal           bool                   `protobuf:"varint,11,opt,name=count_total,json=countTotal,proto3" json:"count_total,omitempty"`                               // Also count every vector matching the filter (O(N) metadata scan)
+	Offset               int32                  `protobuf:"varint,12,opt,name=offset,proto3" json:"offset,omitempty"`                                                         // Results to skip before the returned page of k (default 0)
+	ScoreMode            string                 `protobuf:"bytes,13,opt,name=score_mode,json=scoreMode,proto3" json:"score_mode,omitempty"`                                   // "distance" (default) or "similarity" to also fill SearchResult.score
+	Reranker             string                 `protobuf:"bytes,14,opt,name=reranker,proto3" json:"reranker,omitempty"`                                                      // Registered reranker applied to the top candidates before cutting to k ("" = none)
+	RerankDepth          int32                  `protobuf:"varint,15,opt,name=rerank_depth,json=rerankDepth,proto3" json:"rerank_depth,omitempty"`                            // Candidates handed to the reranker (0 = max(4*(offset+k), ef_search))
+	Fields               []string               `protobuf:"bytes,16,rep,name=fields,proto3" json:"fields,omitempty"`                                                          // Result fields to return: "vector", "text", "metadata" or "metadata.<key>" (empty = vector and all metadata)
+	MaxDistance          *float32               `protobuf:"fixed32,17,opt,name=max_distance,json=maxDistance,proto3,oneof" json:"max_distance,omitempty"`                     // Drop results farther than this; still at most k, so possibly fewer
+	MinScore             *float32               `protobuf:"fixed32,18,opt,name=min_score,json=minScore,proto3,oneof" json:"min_score,omitempty"`                              // Drop results whose similarity score (see score_mode) is below this, in [0,1]
+	AsOf                 int64                  `protobuf:"varint,19,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`                                                 // Unix milliseconds: search the newest snapshot taken at or before this time instead of the live namespace (0 = live)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *SearchRequest) Reset() {
 	*x = SearchRequest{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[2]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -186,7 +325,7 @@ func (x *SearchRequest) String() string {
 func (*SearchRequest) ProtoMessage() {}
 
 func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[2]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -199,7 +338,7 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
 func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{2}
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SearchRequest) GetNamespace() string {
@@ -244,124 +383,124 @@ func (x *SearchRequest) GetDistanceMetric() string {
 	return ""
 }
 
-// HybridSearchRequest combines vector and text search
-type HybridSearchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                 // Namespace to search in
-	QueryVector   []float32              `protobuf:"fixed32,2,rep,packed,name=query_vector,json=queryVector,proto3" json:"query_vector,omitempty"` // Query vector
-	QueryText     string                 `protobuf:"bytes,3,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`                // Query text for full-text search
-	K             int32                  `protobuf:"varint,4,opt,name=k,proto3" json:"k,omitempty"`                                                // Number of results to return
-	EfSearch      int32                  `protobuf:"varint,5,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`                  // HNSW ef_search parameter
-	Filter        *Filter                `protobuf:"bytes,6,opt,name=filter,proto3,oneof" json:"filter,omitempty"`                                 // Optional metadata filter
-	Config        *HybridSearchConfig    `protobuf:"bytes,7,opt,name=config,proto3,oneof" json:"config,omitempty"`                                 // Hybrid search configuration
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SearchRequest) GetGuaranteeK() bool {
+	if x != nil {
+		return x.GuaranteeK
+	}
+	return false
 }
 
-func (x *HybridSearchRequest) Reset() {
-	*x = HybridSearchRequest{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SearchRequest) GetProfile() bool {
+	if x != nil {
+		return x.Profile
+	}
+	return false
 }
 
-func (x *HybridSearchRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SearchRequest) GetQuantizedQueryVector() []byte {
+	if x != nil {
+		return x.QuantizedQueryVector
+	}
+	return nil
 }
 
-func (*HybridSearchRequest) ProtoMessage() {}
-
-func (x *HybridSearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[3]
+func (x *SearchRequest) GetQuantization() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Quantization
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use HybridSearchRequest.ProtoReflect.Descriptor instead.
-func (*HybridSearchRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{3}
+func (x *SearchRequest) GetCountTotal() bool {
+	if x != nil {
+		return x.CountTotal
+	}
+	return false
 }
 
-func (x *HybridSearchRequest) GetNamespace() string {
+func (x *SearchRequest) GetOffset() int32 {
 	if x != nil {
-		return x.Namespace
+		return x.Offset
 	}
-	return ""
+	return 0
 }
 
-func (x *HybridSearchRequest) GetQueryVector() []float32 {
+func (x *SearchRequest) GetScoreMode() string {
 	if x != nil {
-		return x.QueryVector
+		return x.ScoreMode
 	}
-	return nil
+	return ""
 }
 
-func (x *HybridSearchRequest) GetQueryText() string {
+func (x *SearchRequest) GetReranker() string {
 	if x != nil {
-		return x.QueryText
+		return x.Reranker
 	}
 	return ""
 }
 
-func (x *HybridSearchRequest) GetK() int32 {
+func (x *SearchRequest) GetRerankDepth() int32 {
 	if x != nil {
-		return x.K
+		return x.RerankDepth
 	}
 	return 0
 }
 
-func (x *HybridSearchRequest) GetEfSearch() int32 {
+func (x *SearchRequest) GetFields() []string {
 	if x != nil {
-		return x.EfSearch
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetMaxDistance() float32 {
+	if x != nil && x.MaxDistance != nil {
+		return *x.MaxDistance
 	}
 	return 0
 }
 
-func (x *HybridSearchRequest) GetFilter() *Filter {
-	if x != nil {
-		return x.Filter
+func (x *SearchRequest) GetMinScore() float32 {
+	if x != nil && x.MinScore != nil {
+		return *x.MinScore
 	}
-	return nil
+	return 0
 }
 
-func (x *HybridSearchRequest) GetConfig() *HybridSearchConfig {
+func (x *SearchRequest) GetAsOf() int64 {
 	if x != nil {
-		return x.Config
+		return x.AsOf
 	}
-	return nil
+	return 0
 }
 
-// HybridSearchConfig configures hybrid search fusion
-type HybridSearchConfig struct {
+// HybridSearchRequest combines vector and text search
+// RangeSearchRequest asks for all vectors within radius of the query
+type RangeSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FusionMethod  string                 `protobuf:"bytes,1,opt,name=fusion_method,json=fusionMethod,proto3" json:"fusion_method,omitempty"`   // "rrf" or "weighted"
-	VectorWeight  float32                `protobuf:"fixed32,2,opt,name=vector_weight,json=vectorWeight,proto3" json:"vector_weight,omitempty"` // Weight for vector results (alpha)
-	TextWeight    float32                `protobuf:"fixed32,3,opt,name=text_weight,json=textWeight,proto3" json:"text_weight,omitempty"`       // Weight for text results (beta)
-	RrfK          int32                  `protobuf:"varint,4,opt,name=rrf_k,json=rrfK,proto3" json:"rrf_k,omitempty"`                          // RRF k parameter (default 60)
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                 // Namespace to search in
+	QueryVector   []float32              `protobuf:"fixed32,2,rep,packed,name=query_vector,json=queryVector,proto3" json:"query_vector,omitempty"` // Query vector
+	Radius        float32                `protobuf:"fixed32,3,opt,name=radius,proto3" json:"radius,omitempty"`                                     // Maximum distance of returned vectors
+	MaxResults    int32                  `protobuf:"varint,4,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`            // Cap on returned vectors (0 = server maximum)
+	EfSearch      int32                  `protobuf:"varint,5,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`                  // Initial HNSW beam width, widened as needed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HybridSearchConfig) Reset() {
-	*x = HybridSearchConfig{}
+func (x *RangeSearchRequest) Reset() {
+	*x = RangeSearchRequest{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HybridSearchConfig) String() string {
+func (x *RangeSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HybridSearchConfig) ProtoMessage() {}
+func (*RangeSearchRequest) ProtoMessage() {}
 
-func (x *HybridSearchConfig) ProtoReflect() protoreflect.Message {
+func (x *RangeSearchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,64 +512,72 @@ func (x *HybridSearchConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HybridSearchConfig.ProtoReflect.Descriptor instead.
-func (*HybridSearchConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use RangeSearchRequest.ProtoReflect.Descriptor instead.
+func (*RangeSearchRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *HybridSearchConfig) GetFusionMethod() string {
+func (x *RangeSearchRequest) GetNamespace() string {
 	if x != nil {
-		return x.FusionMethod
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *HybridSearchConfig) GetVectorWeight() float32 {
+func (x *RangeSearchRequest) GetQueryVector() []float32 {
 	if x != nil {
-		return x.VectorWeight
+		return x.QueryVector
+	}
+	return nil
+}
+
+func (x *RangeSearchRequest) GetRadius() float32 {
+	if x != nil {
+		return x.Radius
 	}
 	return 0
 }
 
-func (x *HybridSearchConfig) GetTextWeight() float32 {
+func (x *RangeSearchRequest) GetMaxResults() int32 {
 	if x != nil {
-		return x.TextWeight
+		return x.MaxResults
 	}
 	return 0
 }
 
-func (x *HybridSearchConfig) GetRrfK() int32 {
+func (x *RangeSearchRequest) GetEfSearch() int32 {
 	if x != nil {
-		return x.RrfK
+		return x.EfSearch
 	}
 	return 0
 }
 
-// SearchResponse returns search results
-type SearchResponse struct {
+// BatchSearchRequest runs several queries with shared search parameters
+type BatchSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                                   // List of results
-	TotalResults  int32                  `protobuf:"varint,2,opt,name=total_results,json=totalResults,proto3" json:"total_results,omitempty"`    // Total number of results found
-	SearchTimeMs  float32                `protobuf:"fixed32,3,opt,name=search_time_ms,json=searchTimeMs,proto3" json:"search_time_ms,omitempty"` // Search time in milliseconds
-	Error         *string                `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`                                 // Error message if failed
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                // Namespace to search in
+	Queries       []*QueryVector         `protobuf:"bytes,2,rep,name=queries,proto3" json:"queries,omitempty"`                    // Query vectors, answered in order
+	K             int32                  `protobuf:"varint,3,opt,name=k,proto3" json:"k,omitempty"`                               // Number of results per query
+	EfSearch      int32                  `protobuf:"varint,4,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"` // HNSW ef_search parameter
+	Filter        *Filter                `protobuf:"bytes,5,opt,name=filter,proto3,oneof" json:"filter,omitempty"`                // Optional metadata filter applied to every query
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResponse) Reset() {
-	*x = SearchResponse{}
+func (x *BatchSearchRequest) Reset() {
+	*x = BatchSearchRequest{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResponse) String() string {
+func (x *BatchSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResponse) ProtoMessage() {}
+func (*BatchSearchRequest) ProtoMessage() {}
 
-func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchSearchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -442,67 +589,68 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
-func (*SearchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchSearchRequest.ProtoReflect.Descriptor instead.
+func (*BatchSearchRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SearchResponse) GetResults() []*SearchResult {
+func (x *BatchSearchRequest) GetNamespace() string {
 	if x != nil {
-		return x.Results
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *BatchSearchRequest) GetQueries() []*QueryVector {
+	if x != nil {
+		return x.Queries
 	}
 	return nil
 }
 
-func (x *SearchResponse) GetTotalResults() int32 {
+func (x *BatchSearchRequest) GetK() int32 {
 	if x != nil {
-		return x.TotalResults
+		return x.K
 	}
 	return 0
 }
 
-func (x *SearchResponse) GetSearchTimeMs() float32 {
+func (x *BatchSearchRequest) GetEfSearch() int32 {
 	if x != nil {
-		return x.SearchTimeMs
+		return x.EfSearch
 	}
 	return 0
 }
 
-func (x *SearchResponse) GetError() string {
-	if x != nil && x.Error != nil {
-		return *x.Error
+func (x *BatchSearchRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
 	}
-	return ""
+	return nil
 }
 
-// SearchResult represents a single search result
-type SearchResult struct {
+// QueryVector is one query of a BatchSearchRequest
+type QueryVector struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // Vector ID
-	Distance      float32                `protobuf:"fixed32,2,opt,name=distance,proto3" json:"distance,omitempty"`                                                                         // Distance/similarity score
-	Vector        []float32              `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                      // Original vector (optional)
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Metadata
-	Text          *string                `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`                                                                             // Text content if available
-	VectorScore   *float32               `protobuf:"fixed32,6,opt,name=vector_score,json=vectorScore,proto3,oneof" json:"vector_score,omitempty"`                                          // Individual vector similarity score
-	TextScore     *float32               `protobuf:"fixed32,7,opt,name=text_score,json=textScore,proto3,oneof" json:"text_score,omitempty"`                                                // Individual text relevance score
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"` // Query vector
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResult) Reset() {
-	*x = SearchResult{}
+func (x *QueryVector) Reset() {
+	*x = QueryVector{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResult) String() string {
+func (x *QueryVector) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResult) ProtoMessage() {}
+func (*QueryVector) ProtoMessage() {}
 
-func (x *SearchResult) ProtoReflect() protoreflect.Message {
+func (x *QueryVector) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -514,87 +662,48 @@ func (x *SearchResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
-func (*SearchResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueryVector.ProtoReflect.Descriptor instead.
+func (*QueryVector) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *SearchResult) GetId() string {
+func (x *QueryVector) GetValues() []float32 {
 	if x != nil {
-		return x.Id
+		return x.Values
 	}
-	return ""
+	return nil
 }
 
-func (x *SearchResult) GetDistance() float32 {
-	if x != nil {
-		return x.Distance
-	}
-	return 0
+// MultiVectorSearchRequest scores documents against a multi-vector query
+// (e.g. token embeddings). A document scores the sum over query vectors of
+// its best similarity, s(q, d) = -distance(q, d); results carry the negated
+// score as their distance.
+type MultiVectorSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // Namespace to search in
+	QueryVectors  []*QueryVector         `protobuf:"bytes,2,rep,name=query_vectors,json=queryVectors,proto3" json:"query_vectors,omitempty"` // Query vectors
+	K             int32                  `protobuf:"varint,3,opt,name=k,proto3" json:"k,omitempty"`                                          // Number of documents to return
+	EfSearch      int32                  `protobuf:"varint,4,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`            // HNSW ef_search parameter for each query vector
+	Aggregation   string                 `protobuf:"bytes,5,opt,name=aggregation,proto3" json:"aggregation,omitempty"`                       // "sum" (default) or "mean" over query vectors
+	GroupBy       *string                `protobuf:"bytes,6,opt,name=group_by,json=groupBy,proto3,oneof" json:"group_by,omitempty"`          // Metadata field naming the document a vector belongs to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResult) GetVector() []float32 {
-	if x != nil {
-		return x.Vector
-	}
-	return nil
-}
-
-func (x *SearchResult) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-func (x *SearchResult) GetText() string {
-	if x != nil && x.Text != nil {
-		return *x.Text
-	}
-	return ""
-}
-
-func (x *SearchResult) GetVectorScore() float32 {
-	if x != nil && x.VectorScore != nil {
-		return *x.VectorScore
-	}
-	return 0
-}
-
-func (x *SearchResult) GetTextScore() float32 {
-	if x != nil && x.TextScore != nil {
-		return *x.TextScore
-	}
-	return 0
-}
-
-// DeleteRequest specifies vector(s) to delete
-type DeleteRequest struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace
-	// Types that are valid to be assigned to Selector:
-	//
-	//	*DeleteRequest_Id
-	//	*DeleteRequest_Filter
-	Selector      isDeleteRequest_Selector `protobuf_oneof:"selector"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
+func (x *MultiVectorSearchRequest) Reset() {
+	*x = MultiVectorSearchRequest{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRequest) String() string {
+func (x *MultiVectorSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*MultiVectorSearchRequest) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+func (x *MultiVectorSearchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -606,83 +715,77 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultiVectorSearchRequest.ProtoReflect.Descriptor instead.
+func (*MultiVectorSearchRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DeleteRequest) GetNamespace() string {
+func (x *MultiVectorSearchRequest) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *DeleteRequest) GetSelector() isDeleteRequest_Selector {
+func (x *MultiVectorSearchRequest) GetQueryVectors() []*QueryVector {
 	if x != nil {
-		return x.Selector
+		return x.QueryVectors
 	}
 	return nil
 }
 
-func (x *DeleteRequest) GetId() string {
+func (x *MultiVectorSearchRequest) GetK() int32 {
 	if x != nil {
-		if x, ok := x.Selector.(*DeleteRequest_Id); ok {
-			return x.Id
-		}
+		return x.K
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteRequest) GetFilter() *Filter {
+func (x *MultiVectorSearchRequest) GetEfSearch() int32 {
 	if x != nil {
-		if x, ok := x.Selector.(*DeleteRequest_Filter); ok {
-			return x.Filter
-		}
+		return x.EfSearch
 	}
-	return nil
-}
-
-type isDeleteRequest_Selector interface {
-	isDeleteRequest_Selector()
+	return 0
 }
 
-type DeleteRequest_Id struct {
-	Id string `protobuf:"bytes,2,opt,name=id,proto3,oneof"` // Delete by ID
+func (x *MultiVectorSearchRequest) GetAggregation() string {
+	if x != nil {
+		return x.Aggregation
+	}
+	return ""
 }
 
-type DeleteRequest_Filter struct {
-	Filter *Filter `protobuf:"bytes,3,opt,name=filter,proto3,oneof"` // Delete by filter
+func (x *MultiVectorSearchRequest) GetGroupBy() string {
+	if x != nil && x.GroupBy != nil {
+		return *x.GroupBy
+	}
+	return ""
 }
 
-func (*DeleteRequest_Id) isDeleteRequest_Selector() {}
-
-func (*DeleteRequest_Filter) isDeleteRequest_Selector() {}
-
-// DeleteResponse confirms deletion
-type DeleteResponse struct {
+// BatchSearchResponse holds one result set per query, in request order
+type BatchSearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeletedCount  int32                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"` // Number of vectors deleted
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`                               // Operation success status
-	Error         *string                `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`                              // Error message if failed
+	Responses     []*SearchResponse      `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`                               // Per-query results; a failed query has error set
+	FailedCount   int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`       // Number of queries that failed
+	SearchTimeMs  float32                `protobuf:"fixed32,3,opt,name=search_time_ms,json=searchTimeMs,proto3" json:"search_time_ms,omitempty"` // Wall time for the whole batch
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteResponse) Reset() {
-	*x = DeleteResponse{}
+func (x *BatchSearchResponse) Reset() {
+	*x = BatchSearchResponse{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteResponse) String() string {
+func (x *BatchSearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteResponse) ProtoMessage() {}
+func (*BatchSearchResponse) ProtoMessage() {}
 
-func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchSearchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -694,58 +797,61 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchSearchResponse.ProtoReflect.Descriptor instead.
+func (*BatchSearchResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *DeleteResponse) GetDeletedCount() int32 {
+func (x *BatchSearchResponse) GetResponses() []*SearchResponse {
 	if x != nil {
-		return x.DeletedCount
+		return x.Responses
 	}
-	return 0
+	return nil
 }
 
-func (x *DeleteResponse) GetSuccess() bool {
+func (x *BatchSearchResponse) GetFailedCount() int32 {
 	if x != nil {
-		return x.Success
+		return x.FailedCount
 	}
-	return false
+	return 0
 }
 
-func (x *DeleteResponse) GetError() string {
-	if x != nil && x.Error != nil {
-		return *x.Error
+func (x *BatchSearchResponse) GetSearchTimeMs() float32 {
+	if x != nil {
+		return x.SearchTimeMs
 	}
-	return ""
+	return 0
 }
 
-// UpdateRequest updates a vector
-type UpdateRequest struct {
+type HybridSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                         // Namespace
-	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // Vector ID to update
-	Vector        []float32              `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                      // New vector (if updating vector, empty if not)
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // New metadata (if updating metadata, empty if not)
-	Text          *string                `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`                                                                             // New text content
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                 // Namespace to search in
+	QueryVector   []float32              `protobuf:"fixed32,2,rep,packed,name=query_vector,json=queryVector,proto3" json:"query_vector,omitempty"` // Query vector
+	QueryText     string                 `protobuf:"bytes,3,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`                // Query text for full-text search
+	K             int32                  `protobuf:"varint,4,opt,name=k,proto3" json:"k,omitempty"`                                                // Number of results to return
+	EfSearch      int32                  `protobuf:"varint,5,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`                  // HNSW ef_search parameter
+	Filter        *Filter                `protobuf:"bytes,6,opt,name=filter,proto3,oneof" json:"filter,omitempty"`                                 // Optional metadata filter
+	Config        *HybridSearchConfig    `protobuf:"bytes,7,opt,name=config,proto3,oneof" json:"config,omitempty"`                                 // Hybrid search configuration
+	Reranker      string                 `protobuf:"bytes,8,opt,name=reranker,proto3" json:"reranker,omitempty"`                                   // Registered reranker applied to the top candidates before cutting to k ("" = none)
+	RerankDepth   int32                  `protobuf:"varint,9,opt,name=rerank_depth,json=rerankDepth,proto3" json:"rerank_depth,omitempty"`         // Candidates handed to the reranker (0 = max(4*k, ef_search))
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateRequest) Reset() {
-	*x = UpdateRequest{}
+func (x *HybridSearchRequest) Reset() {
+	*x = HybridSearchRequest{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRequest) String() string {
+func (x *HybridSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRequest) ProtoMessage() {}
+func (*HybridSearchRequest) ProtoMessage() {}
 
-func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+func (x *HybridSearchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -757,69 +863,99 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use HybridSearchRequest.ProtoReflect.Descriptor instead.
+func (*HybridSearchRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateRequest) GetNamespace() string {
+func (x *HybridSearchRequest) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *UpdateRequest) GetId() string {
+func (x *HybridSearchRequest) GetQueryVector() []float32 {
 	if x != nil {
-		return x.Id
+		return x.QueryVector
+	}
+	return nil
+}
+
+func (x *HybridSearchRequest) GetQueryText() string {
+	if x != nil {
+		return x.QueryText
 	}
 	return ""
 }
 
-func (x *UpdateRequest) GetVector() []float32 {
+func (x *HybridSearchRequest) GetK() int32 {
 	if x != nil {
-		return x.Vector
+		return x.K
+	}
+	return 0
+}
+
+func (x *HybridSearchRequest) GetEfSearch() int32 {
+	if x != nil {
+		return x.EfSearch
+	}
+	return 0
+}
+
+func (x *HybridSearchRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *UpdateRequest) GetMetadata() map[string]string {
+func (x *HybridSearchRequest) GetConfig() *HybridSearchConfig {
 	if x != nil {
-		return x.Metadata
+		return x.Config
 	}
 	return nil
 }
 
-func (x *UpdateRequest) GetText() string {
-	if x != nil && x.Text != nil {
-		return *x.Text
+func (x *HybridSearchRequest) GetReranker() string {
+	if x != nil {
+		return x.Reranker
 	}
 	return ""
 }
 
-// UpdateResponse confirms update
-type UpdateResponse struct {
+func (x *HybridSearchRequest) GetRerankDepth() int32 {
+	if x != nil {
+		return x.RerankDepth
+	}
+	return 0
+}
+
+// HybridSearchConfig configures hybrid search fusion
+type HybridSearchConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`  // Operation success status
-	Error         *string                `protobuf:"bytes,2,opt,name=error,proto3,oneof" json:"error,omitempty"` // Error message if failed
+	FusionMethod  string                 `protobuf:"bytes,1,opt,name=fusion_method,json=fusionMethod,proto3" json:"fusion_method,omitempty"`   // "rrf" or "weighted"
+	VectorWeight  float32                `protobuf:"fixed32,2,opt,name=vector_weight,json=vectorWeight,proto3" json:"vector_weight,omitempty"` // Weight for vector results (alpha)
+	TextWeight    float32                `protobuf:"fixed32,3,opt,name=text_weight,json=textWeight,proto3" json:"text_weight,omitempty"`       // Weight for text results (beta)
+	RrfK          int32                  `protobuf:"varint,4,opt,name=rrf_k,json=rrfK,proto3" json:"rrf_k,omitempty"`                          // RRF k parameter (default 60)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateResponse) Reset() {
-	*x = UpdateResponse{}
+func (x *HybridSearchConfig) Reset() {
+	*x = HybridSearchConfig{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateResponse) String() string {
+func (x *HybridSearchConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateResponse) ProtoMessage() {}
+func (*HybridSearchConfig) ProtoMessage() {}
 
-func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+func (x *HybridSearchConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -831,51 +967,70 @@ func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
-func (*UpdateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use HybridSearchConfig.ProtoReflect.Descriptor instead.
+func (*HybridSearchConfig) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateResponse) GetSuccess() bool {
+func (x *HybridSearchConfig) GetFusionMethod() string {
 	if x != nil {
-		return x.Success
+		return x.FusionMethod
 	}
-	return false
+	return ""
 }
 
-func (x *UpdateResponse) GetError() string {
-	if x != nil && x.Error != nil {
-		return *x.Error
+func (x *HybridSearchConfig) GetVectorWeight() float32 {
+	if x != nil {
+		return x.VectorWeight
 	}
-	return ""
+	return 0
 }
 
-// BatchInsertResponse summarizes batch insertion
-type BatchInsertResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	InsertedCount int32                  `protobuf:"varint,1,opt,name=inserted_count,json=insertedCount,proto3" json:"inserted_count,omitempty"` // Number of vectors inserted
-	FailedCount   int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`       // Number of failed insertions
-	InsertedIds   []string               `protobuf:"bytes,3,rep,name=inserted_ids,json=insertedIds,proto3" json:"inserted_ids,omitempty"`        // IDs of inserted vectors
-	Errors        []string               `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`                                     // Error messages for failed insertions
-	TotalTimeMs   float32                `protobuf:"fixed32,5,opt,name=total_time_ms,json=totalTimeMs,proto3" json:"total_time_ms,omitempty"`    // Total time in milliseconds
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *HybridSearchConfig) GetTextWeight() float32 {
+	if x != nil {
+		return x.TextWeight
+	}
+	return 0
 }
 
-func (x *BatchInsertResponse) Reset() {
-	*x = BatchInsertResponse{}
+func (x *HybridSearchConfig) GetRrfK() int32 {
+	if x != nil {
+		return x.RrfK
+	}
+	return 0
+}
+
+// SearchResponse returns search results
+type SearchResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Results           []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                                                 // List of results
+	TotalResults      int32                  `protobuf:"varint,2,opt,name=total_results,json=totalResults,proto3" json:"total_results,omitempty"`                  // Total number of results found
+	SearchTimeMs      float32                `protobuf:"fixed32,3,opt,name=search_time_ms,json=searchTimeMs,proto3" json:"search_time_ms,omitempty"`               // Search time in milliseconds
+	Error             *string                `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`                                               // Error message if failed
+	Truncated         bool                   `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`                                            // a filtered search hit its work cap before finding k results
+	Profile           *SearchProfile         `protobuf:"bytes,6,opt,name=profile,proto3,oneof" json:"profile,omitempty"`                                           // Counters and timings, when requested
+	EffectiveEfSearch int32                  `protobuf:"varint,7,opt,name=effective_ef_search,json=effectiveEfSearch,proto3" json:"effective_ef_search,omitempty"` // efSearch actually used, after scaling with k
+	Exact             bool                   `protobuf:"varint,8,opt,name=exact,proto3" json:"exact,omitempty"`                                                    // Results came from a brute-force scan rather than the approximate index
+	TotalMatches      int64                  `protobuf:"varint,9,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`                  // Vectors in the namespace matching the filter (count_total only)
+	AsOf              int64                  `protobuf:"varint,10,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`                                         // Capture time, Unix milliseconds, of the snapshot an as_of search was served from
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchInsertResponse) String() string {
+func (x *SearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchInsertResponse) ProtoMessage() {}
+func (*SearchResponse) ProtoMessage() {}
 
-func (x *BatchInsertResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -887,76 +1042,108 @@ func (x *BatchInsertResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchInsertResponse.ProtoReflect.Descriptor instead.
-func (*BatchInsertResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *BatchInsertResponse) GetInsertedCount() int32 {
+func (x *SearchResponse) GetResults() []*SearchResult {
 	if x != nil {
-		return x.InsertedCount
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTotalResults() int32 {
+	if x != nil {
+		return x.TotalResults
 	}
 	return 0
 }
 
-func (x *BatchInsertResponse) GetFailedCount() int32 {
+func (x *SearchResponse) GetSearchTimeMs() float32 {
 	if x != nil {
-		return x.FailedCount
+		return x.SearchTimeMs
 	}
 	return 0
 }
 
-func (x *BatchInsertResponse) GetInsertedIds() []string {
+func (x *SearchResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetTruncated() bool {
 	if x != nil {
-		return x.InsertedIds
+		return x.Truncated
 	}
-	return nil
+	return false
 }
 
-func (x *BatchInsertResponse) GetErrors() []string {
+func (x *SearchResponse) GetProfile() *SearchProfile {
 	if x != nil {
-		return x.Errors
+		return x.Profile
 	}
 	return nil
 }
 
-func (x *BatchInsertResponse) GetTotalTimeMs() float32 {
+func (x *SearchResponse) GetEffectiveEfSearch() int32 {
 	if x != nil {
-		return x.TotalTimeMs
+		return x.EffectiveEfSearch
 	}
 	return 0
 }
 
-// Filter represents metadata filtering options
-type Filter struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to FilterType:
-	//
-	//	*Filter_Comparison
-	//	*Filter_Range
-	//	*Filter_List
-	//	*Filter_GeoRadius
-	//	*Filter_Exists
-	//	*Filter_Composite
-	FilterType    isFilter_FilterType `protobuf_oneof:"filter_type"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SearchResponse) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
 }
 
-func (x *Filter) Reset() {
-	*x = Filter{}
+func (x *SearchResponse) GetTotalMatches() int64 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+// SearchProfile is a lightweight per-request profile for latency debugging
+type SearchProfile struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Spans                []*ProfileSpan         `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`                                                            // Timed phases
+	DistanceComputations int64                  `protobuf:"varint,2,opt,name=distance_computations,json=distanceComputations,proto3" json:"distance_computations,omitempty"` // Distance function calls
+	DistanceNs           int64                  `protobuf:"varint,3,opt,name=distance_ns,json=distanceNs,proto3" json:"distance_ns,omitempty"`                               // Time spent computing distances
+	NodesVisited         int64                  `protobuf:"varint,4,opt,name=nodes_visited,json=nodesVisited,proto3" json:"nodes_visited,omitempty"`                         // Graph nodes touched
+	HeapOperations       int64                  `protobuf:"varint,5,opt,name=heap_operations,json=heapOperations,proto3" json:"heap_operations,omitempty"`                   // Candidate and result heap pushes and pops
+	FilterEvaluations    int64                  `protobuf:"varint,6,opt,name=filter_evaluations,json=filterEvaluations,proto3" json:"filter_evaluations,omitempty"`          // Metadata filter checks
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SearchProfile) Reset() {
+	*x = SearchProfile{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filter) String() string {
+func (x *SearchProfile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filter) ProtoMessage() {}
+func (*SearchProfile) ProtoMessage() {}
 
-func (x *Filter) ProtoReflect() protoreflect.Message {
+func (x *SearchProfile) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -968,136 +1155,76 @@ func (x *Filter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
-func (*Filter) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchProfile.ProtoReflect.Descriptor instead.
+func (*SearchProfile) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Filter) GetFilterType() isFilter_FilterType {
-	if x != nil {
-		return x.FilterType
-	}
-	return nil
-}
-
-func (x *Filter) GetComparison() *ComparisonFilter {
+func (x *SearchProfile) GetSpans() []*ProfileSpan {
 	if x != nil {
-		if x, ok := x.FilterType.(*Filter_Comparison); ok {
-			return x.Comparison
-		}
+		return x.Spans
 	}
 	return nil
 }
 
-func (x *Filter) GetRange() *RangeFilter {
+func (x *SearchProfile) GetDistanceComputations() int64 {
 	if x != nil {
-		if x, ok := x.FilterType.(*Filter_Range); ok {
-			return x.Range
-		}
+		return x.DistanceComputations
 	}
-	return nil
+	return 0
 }
 
-func (x *Filter) GetList() *ListFilter {
+func (x *SearchProfile) GetDistanceNs() int64 {
 	if x != nil {
-		if x, ok := x.FilterType.(*Filter_List); ok {
-			return x.List
-		}
+		return x.DistanceNs
 	}
-	return nil
+	return 0
 }
 
-func (x *Filter) GetGeoRadius() *GeoRadiusFilter {
+func (x *SearchProfile) GetNodesVisited() int64 {
 	if x != nil {
-		if x, ok := x.FilterType.(*Filter_GeoRadius); ok {
-			return x.GeoRadius
-		}
+		return x.NodesVisited
 	}
-	return nil
+	return 0
 }
 
-func (x *Filter) GetExists() *ExistsFilter {
+func (x *SearchProfile) GetHeapOperations() int64 {
 	if x != nil {
-		if x, ok := x.FilterType.(*Filter_Exists); ok {
-			return x.Exists
-		}
+		return x.HeapOperations
 	}
-	return nil
+	return 0
 }
 
-func (x *Filter) GetComposite() *CompositeFilter {
+func (x *SearchProfile) GetFilterEvaluations() int64 {
 	if x != nil {
-		if x, ok := x.FilterType.(*Filter_Composite); ok {
-			return x.Composite
-		}
+		return x.FilterEvaluations
 	}
-	return nil
-}
-
-type isFilter_FilterType interface {
-	isFilter_FilterType()
-}
-
-type Filter_Comparison struct {
-	Comparison *ComparisonFilter `protobuf:"bytes,1,opt,name=comparison,proto3,oneof"`
-}
-
-type Filter_Range struct {
-	Range *RangeFilter `protobuf:"bytes,2,opt,name=range,proto3,oneof"`
-}
-
-type Filter_List struct {
-	List *ListFilter `protobuf:"bytes,3,opt,name=list,proto3,oneof"`
-}
-
-type Filter_GeoRadius struct {
-	GeoRadius *GeoRadiusFilter `protobuf:"bytes,4,opt,name=geo_radius,json=geoRadius,proto3,oneof"`
-}
-
-type Filter_Exists struct {
-	Exists *ExistsFilter `protobuf:"bytes,5,opt,name=exists,proto3,oneof"`
-}
-
-type Filter_Composite struct {
-	Composite *CompositeFilter `protobuf:"bytes,6,opt,name=composite,proto3,oneof"`
+	return 0
 }
 
-func (*Filter_Comparison) isFilter_FilterType() {}
-
-func (*Filter_Range) isFilter_FilterType() {}
-
-func (*Filter_List) isFilter_FilterType() {}
-
-func (*Filter_GeoRadius) isFilter_FilterType() {}
-
-func (*Filter_Exists) isFilter_FilterType() {}
-
-func (*Filter_Composite) isFilter_FilterType() {}
-
-// ComparisonFilter for equality/inequality checks
-type ComparisonFilter struct {
+// ProfileSpan is a timed phase; names are ';'-separated stacks (flame graph folded format)
+type ProfileSpan struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`       // Field name
-	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "eq", "ne", "gt", "lt", "gte", "lte"
-	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`       // Value to compare (string representation)
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                // e.g. "search;hnsw;base_layer"
+	DurationNs    int64                  `protobuf:"varint,2,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"` // Wall time in nanoseconds
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ComparisonFilter) Reset() {
-	*x = ComparisonFilter{}
+func (x *ProfileSpan) Reset() {
+	*x = ProfileSpan{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ComparisonFilter) String() string {
+func (x *ProfileSpan) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ComparisonFilter) ProtoMessage() {}
+func (*ProfileSpan) ProtoMessage() {}
 
-func (x *ComparisonFilter) ProtoReflect() protoreflect.Message {
+func (x *ProfileSpan) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1109,58 +1236,59 @@ func (x *ComparisonFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ComparisonFilter.ProtoReflect.Descriptor instead.
-func (*ComparisonFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProfileSpan.ProtoReflect.Descriptor instead.
+func (*ProfileSpan) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ComparisonFilter) GetField() string {
-	if x != nil {
-		return x.Field
-	}
-	return ""
-}
-
-func (x *ComparisonFilter) GetOperator() string {
+func (x *ProfileSpan) GetName() string {
 	if x != nil {
-		return x.Operator
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ComparisonFilter) GetValue() string {
+func (x *ProfileSpan) GetDurationNs() int64 {
 	if x != nil {
-		return x.Value
+		return x.DurationNs
 	}
-	return ""
+	return 0
 }
 
-// RangeFilter for numeric ranges
-type RangeFilter struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`   // Field name
-	Gte           *string                `protobuf:"bytes,2,opt,name=gte,proto3,oneof" json:"gte,omitempty"` // Greater than or equal
-	Lte           *string                `protobuf:"bytes,3,opt,name=lte,proto3,oneof" json:"lte,omitempty"` // Less than or equal
-	Gt            *string                `protobuf:"bytes,4,opt,name=gt,proto3,oneof" json:"gt,omitempty"`   // Greater than
-	Lt            *string                `protobuf:"bytes,5,opt,name=lt,proto3,oneof" json:"lt,omitempty"`   // Less than
+// SearchResult represents a single search result
+type SearchResult struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Id            string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                                      // Vector ID
+	Distance      float32                   `protobuf:"fixed32,2,opt,name=distance,proto3" json:"distance,omitempty"`                                                                                                        // Distance/similarity score
+	Vector        []float32                 `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                                                     // Original vector (optional)
+	Metadata      map[string]string         `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                // Metadata
+	Text          *string                   `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`                                                                                                            // Text content if available
+	VectorScore   *float32                  `protobuf:"fixed32,6,opt,name=vector_score,json=vectorScore,proto3,oneof" json:"vector_score,omitempty"`                                                                         // Individual vector similarity score
+	TextScore     *float32                  `protobuf:"fixed32,7,opt,name=text_score,json=textScore,proto3,oneof" json:"text_score,omitempty"`                                                                               // Individual text relevance score
+	Snippet       *string                   `protobuf:"bytes,8,opt,name=snippet,proto3,oneof" json:"snippet,omitempty"`                                                                                                      // Text window around the best query match, terms wrapped in ** (hybrid search)
+	TypedMetadata map[string]*MetadataValue `protobuf:"bytes,9,rep,name=typed_metadata,json=typedMetadata,proto3" json:"typed_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Non-string metadata values; metadata also holds them formatted
+	Score         *float32                  `protobuf:"fixed32,10,opt,name=score,proto3,oneof" json:"score,omitempty"`                                                                                                       // Similarity in [0,1] derived from distance per metric (score_mode "similarity")
+	ExternalId    *string                   `protobuf:"bytes,11,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`                                                                             // External ID the vector was inserted with, if any
+	VectorRank    *int32                    `protobuf:"varint,12,opt,name=vector_rank,json=vectorRank,proto3,oneof" json:"vector_rank,omitempty"`                                                                            // 1-based rank among the vector matches fused by hybrid search, -1 if absent
+	TextRank      *int32                    `protobuf:"varint,13,opt,name=text_rank,json=textRank,proto3,oneof" json:"text_rank,omitempty"`                                                                                  // 1-based rank among the text matches fused by hybrid search, -1 if absent
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RangeFilter) Reset() {
-	*x = RangeFilter{}
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RangeFilter) String() string {
+func (x *SearchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RangeFilter) ProtoMessage() {}
+func (*SearchResult) ProtoMessage() {}
 
-func (x *RangeFilter) ProtoReflect() protoreflect.Message {
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1172,70 +1300,125 @@ func (x *RangeFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RangeFilter.ProtoReflect.Descriptor instead.
-func (*RangeFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *RangeFilter) GetField() string {
+func (x *SearchResult) GetId() string {
 	if x != nil {
-		return x.Field
+		return x.Id
 	}
 	return ""
 }
 
-func (x *RangeFilter) GetGte() string {
-	if x != nil && x.Gte != nil {
-		return *x.Gte
+func (x *SearchResult) GetDistance() float32 {
+	if x != nil {
+		return x.Distance
 	}
-	return ""
+	return 0
 }
 
-func (x *RangeFilter) GetLte() string {
-	if x != nil && x.Lte != nil {
-		return *x.Lte
+func (x *SearchResult) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
 	}
-	return ""
+	return nil
 }
 
-func (x *RangeFilter) GetGt() string {
-	if x != nil && x.Gt != nil {
-		return *x.Gt
+func (x *SearchResult) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
 	}
-	return ""
+	return nil
 }
 
-func (x *RangeFilter) GetLt() string {
-	if x != nil && x.Lt != nil {
-		return *x.Lt
+func (x *SearchResult) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
 	}
 	return ""
 }
 
-// ListFilter for IN/NOT IN operations
-type ListFilter struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`       // Field name
-	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "in" or "not_in"
-	Values        []string               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`     // List of values
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SearchResult) GetVectorScore() float32 {
+	if x != nil && x.VectorScore != nil {
+		return *x.VectorScore
+	}
+	return 0
 }
 
-func (x *ListFilter) Reset() {
-	*x = ListFilter{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SearchResult) GetTextScore() float32 {
+	if x != nil && x.TextScore != nil {
+		return *x.TextScore
+	}
+	return 0
 }
 
-func (x *ListFilter) String() string {
+func (x *SearchResult) GetSnippet() string {
+	if x != nil && x.Snippet != nil {
+		return *x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTypedMetadata() map[string]*MetadataValue {
+	if x != nil {
+		return x.TypedMetadata
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float32 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetVectorRank() int32 {
+	if x != nil && x.VectorRank != nil {
+		return *x.VectorRank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetTextRank() int32 {
+	if x != nil && x.TextRank != nil {
+		return *x.TextRank
+	}
+	return 0
+}
+
+// FetchRequest specifies the vectors to read back
+type FetchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`             // Vector IDs to fetch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRequest) Reset() {
+	*x = FetchRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilter) ProtoMessage() {}
+func (*FetchRequest) ProtoMessage() {}
 
-func (x *ListFilter) ProtoReflect() protoreflect.Message {
+func (x *FetchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1247,125 +1430,3083 @@ func (x *ListFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilter.ProtoReflect.Descriptor instead.
-func (*ListFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use FetchRequest.ProtoReflect.Descriptor instead.
+func (*FetchRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListFilter) GetField() string {
+func (x *FetchRequest) GetNamespace() string {
 	if x != nil {
-		return x.Field
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *ListFilter) GetOperator() string {
+func (x *FetchRequest) GetIds() []string {
 	if x != nil {
-		return x.Operator
+		return x.Ids
+	}
+	return nil
+}
+
+// FetchResult holds one stored vector, or a not-found marker
+type FetchResult struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Id            string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                                      // Requested vector ID
+	Found         bool                      `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`                                                                                                               // False if the ID is malformed or not stored
+	Vector        []float32                 `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                                                     // Stored vector
+	Metadata      map[string]string         `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                // Stored metadata
+	Text          *string                   `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`                                                                                                            // Stored text content if any
+	Error         *string                   `protobuf:"bytes,6,opt,name=error,proto3,oneof" json:"error,omitempty"`                                                                                                          // Why the ID was not found
+	TypedMetadata map[string]*MetadataValue `protobuf:"bytes,7,rep,name=typed_metadata,json=typedMetadata,proto3" json:"typed_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Non-string metadata values; metadata also holds them formatted
+	ExternalId    *string                   `protobuf:"bytes,8,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`                                                                              // External ID the vector was inserted with, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchResult) Reset() {
+	*x = FetchResult{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchResult) ProtoMessage() {}
+
+func (x *FetchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchResult.ProtoReflect.Descriptor instead.
+func (*FetchResult) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FetchResult) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ListFilter) GetValues() []string {
+func (x *FetchResult) GetFound() bool {
 	if x != nil {
-		return x.Values
+		return x.Found
+	}
+	return false
+}
+
+func (x *FetchResult) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
 	}
 	return nil
 }
 
-// GeoRadiusFilter for geographic radius queries
-type GeoRadiusFilter struct {
+func (x *FetchResult) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *FetchResult) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
+	}
+	return ""
+}
+
+func (x *FetchResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *FetchResult) GetTypedMetadata() map[string]*MetadataValue {
+	if x != nil {
+		return x.TypedMetadata
+	}
+	return nil
+}
+
+func (x *FetchResult) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+// FetchResponse returns one result per requested ID, in request order
+type FetchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`                         // Field name (should contain geo coordinates)
-	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`                 // Center latitude
-	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`               // Center longitude
-	RadiusKm      float64                `protobuf:"fixed64,4,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"` // Radius in kilometers
+	Results       []*FetchResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                                     // Per-ID results
+	FoundCount    int32                  `protobuf:"varint,2,opt,name=found_count,json=foundCount,proto3" json:"found_count,omitempty"`            // Number of IDs found
+	NotFoundCount int32                  `protobuf:"varint,3,opt,name=not_found_count,json=notFoundCount,proto3" json:"not_found_count,omitempty"` // Number of IDs missing or malformed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GeoRadiusFilter) Reset() {
-	*x = GeoRadiusFilter{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[16]
+func (x *FetchResponse) Reset() {
+	*x = FetchResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GeoRadiusFilter) String() string {
+func (x *FetchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GeoRadiusFilter) ProtoMessage() {}
+func (*FetchResponse) ProtoMessage() {}
 
-func (x *GeoRadiusFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[16]
+func (x *FetchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchResponse.ProtoReflect.Descriptor instead.
+func (*FetchResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FetchResponse) GetResults() []*FetchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *FetchResponse) GetFoundCount() int32 {
+	if x != nil {
+		return x.FoundCount
+	}
+	return 0
+}
+
+func (x *FetchResponse) GetNotFoundCount() int32 {
+	if x != nil {
+		return x.NotFoundCount
+	}
+	return 0
+}
+
+// ExportRequest selects the vectors an Export streams
+type ExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                   // Namespace to export
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`                         // Resume after the batch that returned this next_cursor (empty = from the start)
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                          // Maximum vectors to export (0 = all)
+	BatchSize     int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // Vectors per streamed batch (0 = 1000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ExportRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// ExportBatch holds the next vectors of an Export
+type ExportBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vectors       []*FetchResult         `protobuf:"bytes,1,rep,name=vectors,proto3" json:"vectors,omitempty"`                         // Vectors in ascending ID order; id is the internal ID
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Pass as cursor to resume after this batch
+	Done          bool                   `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`                              // True on the final batch
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                            // Vectors the export will send in all, counted when it started
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBatch) Reset() {
+	*x = ExportBatch{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBatch) ProtoMessage() {}
+
+func (x *ExportBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBatch.ProtoReflect.Descriptor instead.
+func (*ExportBatch) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExportBatch) GetVectors() []*FetchResult {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+func (x *ExportBatch) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ExportBatch) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ExportBatch) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// ScanRequest selects a page of a namespace's vectors
+type ScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                               // Namespace to scan
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                // Vectors per page (0 = 100, at most 10000)
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`                                     // next_cursor of the previous page (empty = first page)
+	IncludeVector bool                   `protobuf:"varint,4,opt,name=include_vector,json=includeVector,proto3" json:"include_vector,omitempty"` // Return each vector's values; by default only IDs, metadata and text
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanRequest) Reset() {
+	*x = ScanRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanRequest) ProtoMessage() {}
+
+func (x *ScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanRequest.ProtoReflect.Descriptor instead.
+func (*ScanRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ScanRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetIncludeVector() bool {
+	if x != nil {
+		return x.IncludeVector
+	}
+	return false
+}
+
+// ScanResponse holds one page of a scan
+type ScanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*FetchResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                         // Vectors in ascending ID order; id is the internal ID
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Cursor of the next page, empty when the scan is complete
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanResponse) Reset() {
+	*x = ScanResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResponse) ProtoMessage() {}
+
+func (x *ScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResponse.ProtoReflect.Descriptor instead.
+func (*ScanResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ScanResponse) GetResults() []*FetchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ScanResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// DeleteRequest specifies vector(s) to delete
+type DeleteRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace
+	// Types that are valid to be assigned to Selector:
+	//
+	//	*DeleteRequest_Id
+	//	*DeleteRequest_Filter
+	Selector      isDeleteRequest_Selector `protobuf_oneof:"selector"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetSelector() isDeleteRequest_Selector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		if x, ok := x.Selector.(*DeleteRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetFilter() *Filter {
+	if x != nil {
+		if x, ok := x.Selector.(*DeleteRequest_Filter); ok {
+			return x.Filter
+		}
+	}
+	return nil
+}
+
+type isDeleteRequest_Selector interface {
+	isDeleteRequest_Selector()
+}
+
+type DeleteRequest_Id struct {
+	Id string `protobuf:"bytes,2,opt,name=id,proto3,oneof"` // Delete by ID
+}
+
+type DeleteRequest_Filter struct {
+	Filter *Filter `protobuf:"bytes,3,opt,name=filter,proto3,oneof"` // Delete by filter
+}
+
+func (*DeleteRequest_Id) isDeleteRequest_Selector() {}
+
+func (*DeleteRequest_Filter) isDeleteRequest_Selector() {}
+
+// DeleteResponse confirms deletion
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedCount  int32                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"` // Number of vectors deleted
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`                               // Operation success status
+	Error         *string                `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`                              // Error message if failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteResponse) GetDeletedCount() int32 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+func (x *DeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// UpdateRequest updates a vector
+type UpdateRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Namespace     string                    `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                                                        // Namespace
+	Id            string                    `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                                                                                                                      // Vector ID to update
+	Vector        []float32                 `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                                                     // New vector (if updating vector, empty if not)
+	Metadata      map[string]string         `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                // New metadata (if updating metadata, empty if not)
+	Text          *string                   `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`                                                                                                            // New text content
+	TypedMetadata map[string]*MetadataValue `protobuf:"bytes,6,rep,name=typed_metadata,json=typedMetadata,proto3" json:"typed_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // New typed metadata; replaces the stored metadata together with metadata
+	Merge         bool                      `protobuf:"varint,7,opt,name=merge,proto3" json:"merge,omitempty"`                                                                                                               // Merge metadata and typed_metadata into the stored metadata instead of replacing it
+	RemoveKeys    []string                  `protobuf:"bytes,8,rep,name=remove_keys,json=removeKeys,proto3" json:"remove_keys,omitempty"`                                                                                    // Metadata keys to delete; requires merge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetTypedMetadata() map[string]*MetadataValue {
+	if x != nil {
+		return x.TypedMetadata
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetMerge() bool {
+	if x != nil {
+		return x.Merge
+	}
+	return false
+}
+
+func (x *UpdateRequest) GetRemoveKeys() []string {
+	if x != nil {
+		return x.RemoveKeys
+	}
+	return nil
+}
+
+// UpdateResponse confirms update
+type UpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`  // Operation success status
+	Error         *string                `protobuf:"bytes,2,opt,name=error,proto3,oneof" json:"error,omitempty"` // Error message if failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateResponse) Reset() {
+	*x = UpdateResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateResponse) ProtoMessage() {}
+
+func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// BatchInsertResponse summarizes batch insertion
+type BatchInsertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InsertedCount int32                  `protobuf:"varint,1,opt,name=inserted_count,json=insertedCount,proto3" json:"inserted_count,omitempty"` // Number of vectors inserted
+	FailedCount   int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`       // Number of failed insertions
+	InsertedIds   []string               `protobuf:"bytes,3,rep,name=inserted_ids,json=insertedIds,proto3" json:"inserted_ids,omitempty"`        // IDs of inserted vectors
+	Errors        []string               `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`                                     // Error messages for failed insertions
+	TotalTimeMs   float32                `protobuf:"fixed32,5,opt,name=total_time_ms,json=totalTimeMs,proto3" json:"total_time_ms,omitempty"`    // Total time in milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchInsertResponse) Reset() {
+	*x = BatchInsertResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchInsertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchInsertResponse) ProtoMessage() {}
+
+func (x *BatchInsertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchInsertResponse.ProtoReflect.Descriptor instead.
+func (*BatchInsertResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BatchInsertResponse) GetInsertedCount() int32 {
+	if x != nil {
+		return x.InsertedCount
+	}
+	return 0
+}
+
+func (x *BatchInsertResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *BatchInsertResponse) GetInsertedIds() []string {
+	if x != nil {
+		return x.InsertedIds
+	}
+	return nil
+}
+
+func (x *BatchInsertResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *BatchInsertResponse) GetTotalTimeMs() float32 {
+	if x != nil {
+		return x.TotalTimeMs
+	}
+	return 0
+}
+
+// AsyncBatchInsertRequest holds the vectors for one background insert job.
+// Items are validated and inserted as in BatchInsert; the first item's
+// ef_construction applies to the whole job.
+type AsyncBatchInsertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InsertRequest       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // Vectors to insert, in order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AsyncBatchInsertRequest) Reset() {
+	*x = AsyncBatchInsertRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AsyncBatchInsertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AsyncBatchInsertRequest) ProtoMessage() {}
+
+func (x *AsyncBatchInsertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AsyncBatchInsertRequest.ProtoReflect.Descriptor instead.
+func (*AsyncBatchInsertRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AsyncBatchInsertRequest) GetItems() []*InsertRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// AsyncBatchInsertResponse identifies the queued job
+type AsyncBatchInsertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Handle for GetJobStatus
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`             // Number of items queued
+	Error         *string                `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`        // Error message if the job was not queued
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AsyncBatchInsertResponse) Reset() {
+	*x = AsyncBatchInsertResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AsyncBatchInsertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AsyncBatchInsertResponse) ProtoMessage() {}
+
+func (x *AsyncBatchInsertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AsyncBatchInsertResponse.ProtoReflect.Descriptor instead.
+func (*AsyncBatchInsertResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AsyncBatchInsertResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *AsyncBatchInsertResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AsyncBatchInsertResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// JobStatusRequest selects the job to report
+type JobStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // ID returned by AsyncBatchInsert
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobStatusRequest) Reset() {
+	*x = JobStatusRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStatusRequest) ProtoMessage() {}
+
+func (x *JobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStatusRequest.ProtoReflect.Descriptor instead.
+func (*JobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *JobStatusRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// JobStatusResponse reports a job's progress. Finished jobs are kept for the
+// server's job TTL, after which their status is NotFound.
+type JobStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                                // "running", "completed" or "cancelled" (server shut down)
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`                               // Items in the job
+	Inserted      int32                  `protobuf:"varint,4,opt,name=inserted,proto3" json:"inserted,omitempty"`                         // Items inserted so far
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`                             // Items that failed so far
+	Remaining     int32                  `protobuf:"varint,6,opt,name=remaining,proto3" json:"remaining,omitempty"`                       // Items not yet processed
+	InsertedIds   []string               `protobuf:"bytes,7,rep,name=inserted_ids,json=insertedIds,proto3" json:"inserted_ids,omitempty"` // IDs of inserted items, in item order
+	Errors        []string               `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty"`                              // Error messages for failed items ("item N: ...")
+	ElapsedMs     float32                `protobuf:"fixed32,9,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`     // Time since the job started, or its total run time once finished
+	Error         *string                `protobuf:"bytes,10,opt,name=error,proto3,oneof" json:"error,omitempty"`                         // Error message if the status could not be read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobStatusResponse) Reset() {
+	*x = JobStatusResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStatusResponse) ProtoMessage() {}
+
+func (x *JobStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStatusResponse.ProtoReflect.Descriptor instead.
+func (*JobStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *JobStatusResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *JobStatusResponse) GetInserted() int32 {
+	if x != nil {
+		return x.Inserted
+	}
+	return 0
+}
+
+func (x *JobStatusResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *JobStatusResponse) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *JobStatusResponse) GetInsertedIds() []string {
+	if x != nil {
+		return x.InsertedIds
+	}
+	return nil
+}
+
+func (x *JobStatusResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *JobStatusResponse) GetElapsedMs() float32 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *JobStatusResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// Filter represents metadata filtering options
+type Filter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to FilterType:
+	//
+	//	*Filter_Comparison
+	//	*Filter_Range
+	//	*Filter_List
+	//	*Filter_GeoRadius
+	//	*Filter_Exists
+	//	*Filter_Composite
+	//	*Filter_GeoBoundingBox
+	FilterType    isFilter_FilterType `protobuf_oneof:"filter_type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Filter) GetFilterType() isFilter_FilterType {
+	if x != nil {
+		return x.FilterType
+	}
+	return nil
+}
+
+func (x *Filter) GetComparison() *ComparisonFilter {
+	if x != nil {
+		if x, ok := x.FilterType.(*Filter_Comparison); ok {
+			return x.Comparison
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetRange() *RangeFilter {
+	if x != nil {
+		if x, ok := x.FilterType.(*Filter_Range); ok {
+			return x.Range
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetList() *ListFilter {
+	if x != nil {
+		if x, ok := x.FilterType.(*Filter_List); ok {
+			return x.List
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetGeoRadius() *GeoRadiusFilter {
+	if x != nil {
+		if x, ok := x.FilterType.(*Filter_GeoRadius); ok {
+			return x.GeoRadius
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetExists() *ExistsFilter {
+	if x != nil {
+		if x, ok := x.FilterType.(*Filter_Exists); ok {
+			return x.Exists
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetComposite() *CompositeFilter {
+	if x != nil {
+		if x, ok := x.FilterType.(*Filter_Composite); ok {
+			return x.Composite
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetGeoBoundingBox() *GeoBoundingBoxFilter {
+	if x != nil {
+		if x, ok := x.FilterType.(*Filter_GeoBoundingBox); ok {
+			return x.GeoBoundingBox
+		}
+	}
+	return nil
+}
+
+type isFilter_FilterType interface {
+	isFilter_FilterType()
+}
+
+type Filter_Comparison struct {
+	Comparison *ComparisonFilter `protobuf:"bytes,1,opt,name=comparison,proto3,oneof"`
+}
+
+type Filter_Range struct {
+	Range *RangeFilter `protobuf:"bytes,2,opt,name=range,proto3,oneof"`
+}
+
+type Filter_List struct {
+	List *ListFilter `protobuf:"bytes,3,opt,name=list,proto3,oneof"`
+}
+
+type Filter_GeoRadius struct {
+	GeoRadius *GeoRadiusFilter `protobuf:"bytes,4,opt,name=geo_radius,json=geoRadius,proto3,oneof"`
+}
+
+type Filter_Exists struct {
+	Exists *ExistsFilter `protobuf:"bytes,5,opt,name=exists,proto3,oneof"`
+}
+
+type Filter_Composite struct {
+	Composite *CompositeFilter `protobuf:"bytes,6,opt,name=composite,proto3,oneof"`
+}
+
+type Filter_GeoBoundingBox struct {
+	GeoBoundingBox *GeoBoundingBoxFilter `protobuf:"bytes,7,opt,name=geo_bounding_box,json=geoBoundingBox,proto3,oneof"`
+}
+
+func (*Filter_Comparison) isFilter_FilterType() {}
+
+func (*Filter_Range) isFilter_FilterType() {}
+
+func (*Filter_List) isFilter_FilterType() {}
+
+func (*Filter_GeoRadius) isFilter_FilterType() {}
+
+func (*Filter_Exists) isFilter_FilterType() {}
+
+func (*Filter_Composite) isFilter_FilterType() {}
+
+func (*Filter_GeoBoundingBox) isFilter_FilterType() {}
+
+// ComparisonFilter for equality/inequality checks
+type ComparisonFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`       // Field name
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "eq", "ne", "gt", "lt", "gte", "lte", "ieq", "contains", "starts_with"
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`       // Value to compare (string representation)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparisonFilter) Reset() {
+	*x = ComparisonFilter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparisonFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparisonFilter) ProtoMessage() {}
+
+func (x *ComparisonFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparisonFilter.ProtoReflect.Descriptor instead.
+func (*ComparisonFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ComparisonFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ComparisonFilter) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ComparisonFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// RangeFilter for numeric ranges
+type RangeFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`   // Field name
+	Gte           *string                `protobuf:"bytes,2,opt,name=gte,proto3,oneof" json:"gte,omitempty"` // Greater than or equal
+	Lte           *string                `protobuf:"bytes,3,opt,name=lte,proto3,oneof" json:"lte,omitempty"` // Less than or equal
+	Gt            *string                `protobuf:"bytes,4,opt,name=gt,proto3,oneof" json:"gt,omitempty"`   // Greater than
+	Lt            *string                `protobuf:"bytes,5,opt,name=lt,proto3,oneof" json:"lt,omitempty"`   // Less than
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeFilter) Reset() {
+	*x = RangeFilter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeFilter) ProtoMessage() {}
+
+func (x *RangeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeFilter.ProtoReflect.Descriptor instead.
+func (*RangeFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RangeFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *RangeFilter) GetGte() string {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return ""
+}
+
+func (x *RangeFilter) GetLte() string {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return ""
+}
+
+func (x *RangeFilter) GetGt() string {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return ""
+}
+
+func (x *RangeFilter) GetLt() string {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return ""
+}
+
+// ListFilter for IN/NOT IN operations
+type ListFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`       // Field name
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "in" or "not_in"
+	Values        []string               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`     // List of values
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilter) Reset() {
+	*x = ListFilter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilter) ProtoMessage() {}
+
+func (x *ListFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilter.ProtoReflect.Descriptor instead.
+func (*ListFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ListFilter) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ListFilter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// GeoRadiusFilter for geographic radius queries
+type GeoRadiusFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`                         // Field name (should contain geo coordinates)
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`                 // Center latitude
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`               // Center longitude
+	RadiusKm      float64                `protobuf:"fixed64,4,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"` // Radius in kilometers
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoRadiusFilter) Reset() {
+	*x = GeoRadiusFilter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoRadiusFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoRadiusFilter) ProtoMessage() {}
+
+func (x *GeoRadiusFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoRadiusFilter.ProtoReflect.Descriptor instead.
+func (*GeoRadiusFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GeoRadiusFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GeoRadiusFilter) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoRadiusFilter) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoRadiusFilter) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+// GeoBoundingBoxFilter matches points inside a latitude/longitude box.
+// A box with min_longitude > max_longitude crosses the 180° meridian.
+type GeoBoundingBoxFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`                                     // Field name (should contain geo coordinates)
+	MinLatitude   float64                `protobuf:"fixed64,2,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`    // Southern edge
+	MinLongitude  float64                `protobuf:"fixed64,3,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"` // Western edge
+	MaxLatitude   float64                `protobuf:"fixed64,4,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`    // Northern edge
+	MaxLongitude  float64                `protobuf:"fixed64,5,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"` // Eastern edge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoBoundingBoxFilter) Reset() {
+	*x = GeoBoundingBoxFilter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoBoundingBoxFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoBoundingBoxFilter) ProtoMessage() {}
+
+func (x *GeoBoundingBoxFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoBoundingBoxFilter.ProtoReflect.Descriptor instead.
+func (*GeoBoundingBoxFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GeoBoundingBoxFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GeoBoundingBoxFilter) GetMinLatitude() float64 {
+	if x != nil {
+		return x.MinLatitude
+	}
+	return 0
+}
+
+func (x *GeoBoundingBoxFilter) GetMinLongitude() float64 {
+	if x != nil {
+		return x.MinLongitude
+	}
+	return 0
+}
+
+func (x *GeoBoundingBoxFilter) GetMaxLatitude() float64 {
+	if x != nil {
+		return x.MaxLatitude
+	}
+	return 0
+}
+
+func (x *GeoBoundingBoxFilter) GetMaxLongitude() float64 {
+	if x != nil {
+		return x.MaxLongitude
+	}
+	return 0
+}
+
+// ExistsFilter checks field existence
+type ExistsFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`    // Field name
+	Exists        bool                   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"` // true to check existence, false for non-existence
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExistsFilter) Reset() {
+	*x = ExistsFilter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExistsFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsFilter) ProtoMessage() {}
+
+func (x *ExistsFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExistsFilter.ProtoReflect.Descriptor instead.
+func (*ExistsFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ExistsFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ExistsFilter) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+// CompositeFilter combines multiple filters
+type CompositeFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      string                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"` // "and", "or", "not"
+	Filters       []*Filter              `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`   // Sub-filters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompositeFilter) Reset() {
+	*x = CompositeFilter{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompositeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompositeFilter) ProtoMessage() {}
+
+func (x *CompositeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompositeFilter.ProtoReflect.Descriptor instead.
+func (*CompositeFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CompositeFilter) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *CompositeFilter) GetFilters() []*Filter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+// StatsRequest requests database statistics
+type StatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     *string                `protobuf:"bytes,1,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"` // Optional namespace (all if not specified)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StatsRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+// StatsResponse returns database statistics
+type StatsResponse struct {
+	state            protoimpl.MessageState     `protogen:"open.v1"`
+	TotalVectors     int64                      `protobuf:"varint,1,opt,name=total_vectors,json=totalVectors,proto3" json:"total_vectors,omitempty"`                                                                                // Total number of vectors
+	TotalNamespaces  int64                      `protobuf:"varint,2,opt,name=total_namespaces,json=totalNamespaces,proto3" json:"total_namespaces,omitempty"`                                                                       // Total number of namespaces
+	MemoryUsageBytes int64                      `protobuf:"varint,3,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3" json:"memory_usage_bytes,omitempty"`                                                                  // Approximate memory usage
+	NamespaceStats   map[string]*NamespaceStats `protobuf:"bytes,4,rep,name=namespace_stats,json=namespaceStats,proto3" json:"namespace_stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Per-namespace statistics
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StatsResponse) GetTotalVectors() int64 {
+	if x != nil {
+		return x.TotalVectors
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetTotalNamespaces() int64 {
+	if x != nil {
+		return x.TotalNamespaces
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetMemoryUsageBytes() int64 {
+	if x != nil {
+		return x.MemoryUsageBytes
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetNamespaceStats() map[string]*NamespaceStats {
+	if x != nil {
+		return x.NamespaceStats
+	}
+	return nil
+}
+
+// NamespaceStats contains statistics for a single namespace
+type NamespaceStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VectorCount    int64                  `protobuf:"varint,1,opt,name=vector_count,json=vectorCount,proto3" json:"vector_count,omitempty"`          // Number of vectors in namespace
+	MemoryBytes    int64                  `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`          // Memory usage for namespace
+	Dimensions     int32                  `protobuf:"varint,3,opt,name=dimensions,proto3" json:"dimensions,omitempty"`                               // Vector dimensions
+	MaxLayer       int32                  `protobuf:"varint,4,opt,name=max_layer,json=maxLayer,proto3" json:"max_layer,omitempty"`                   // Highest HNSW layer (-1 when empty)
+	Layers         []*LayerStats          `protobuf:"bytes,5,rep,name=layers,proto3" json:"layers,omitempty"`                                        // Per-layer graph shape, base layer first
+	M              int32                  `protobuf:"varint,6,opt,name=m,proto3" json:"m,omitempty"`                                                 // HNSW links per node
+	EfConstruction int32                  `protobuf:"varint,7,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"` // HNSW candidate list size during insertion
+	Metric         string                 `protobuf:"bytes,8,opt,name=metric,proto3" json:"metric,omitempty"`                                        // Retrieval metric
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NamespaceStats) Reset() {
+	*x = NamespaceStats{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceStats) ProtoMessage() {}
+
+func (x *NamespaceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceStats.ProtoReflect.Descriptor instead.
+func (*NamespaceStats) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *NamespaceStats) GetVectorCount() int64 {
+	if x != nil {
+		return x.VectorCount
+	}
+	return 0
+}
+
+func (x *NamespaceStats) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *NamespaceStats) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *NamespaceStats) GetMaxLayer() int32 {
+	if x != nil {
+		return x.MaxLayer
+	}
+	return 0
+}
+
+func (x *NamespaceStats) GetLayers() []*LayerStats {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+func (x *NamespaceStats) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *NamespaceStats) GetEfConstruction() int32 {
+	if x != nil {
+		return x.EfConstruction
+	}
+	return 0
+}
+
+func (x *NamespaceStats) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+// LayerStats describes one layer of a namespace's HNSW graph
+type LayerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Layer         int32                  `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`                           // Layer number (0 is the base layer)
+	Nodes         int64                  `protobuf:"varint,2,opt,name=nodes,proto3" json:"nodes,omitempty"`                           // Nodes present in the layer
+	AvgDegree     float32                `protobuf:"fixed32,3,opt,name=avg_degree,json=avgDegree,proto3" json:"avg_degree,omitempty"` // Mean links per node in the layer
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LayerStats) Reset() {
+	*x = LayerStats{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayerStats) ProtoMessage() {}
+
+func (x *LayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayerStats.ProtoReflect.Descriptor instead.
+func (*LayerStats) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *LayerStats) GetLayer() int32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+func (x *LayerStats) GetNodes() int64 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *LayerStats) GetAvgDegree() float32 {
+	if x != nil {
+		return x.AvgDegree
+	}
+	return 0
+}
+
+// HealthCheckRequest requests health status
+// ValidateRequest selects the namespace whose graph to check
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace to validate
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ValidateRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ValidateResponse reports graph invariant violations
+type ValidateResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Valid          bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`                                            // True if no violations were found
+	ViolationCount int32                  `protobuf:"varint,2,opt,name=violation_count,json=violationCount,proto3" json:"violation_count,omitempty"`    // Total number of violations
+	Violations     []string               `protobuf:"bytes,3,rep,name=violations,proto3" json:"violations,omitempty"`                                   // Descriptions of the first violations (up to 10)
+	NodesChecked   int64                  `protobuf:"varint,4,opt,name=nodes_checked,json=nodesChecked,proto3" json:"nodes_checked,omitempty"`          // Number of nodes in the graph
+	ValidateTimeMs float32                `protobuf:"fixed32,5,opt,name=validate_time_ms,json=validateTimeMs,proto3" json:"validate_time_ms,omitempty"` // Time spent validating in milliseconds
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetViolationCount() int32 {
+	if x != nil {
+		return x.ViolationCount
+	}
+	return 0
+}
+
+func (x *ValidateResponse) GetViolations() []string {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ValidateResponse) GetNodesChecked() int64 {
+	if x != nil {
+		return x.NodesChecked
+	}
+	return 0
+}
+
+func (x *ValidateResponse) GetValidateTimeMs() float32 {
+	if x != nil {
+		return x.ValidateTimeMs
+	}
+	return 0
+}
+
+// SnapshotRequest starts a snapshot of all namespaces
+type SnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{45}
+}
+
+// SnapshotProgress reports a snapshot in progress. One message is sent per
+// namespace written; the last has done set and describes the file.
+type SnapshotProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Namespace       string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                     // Namespace just written
+	NamespacesDone  int32                  `protobuf:"varint,2,opt,name=namespaces_done,json=namespacesDone,proto3" json:"namespaces_done,omitempty"`    // Namespaces written so far
+	NamespacesTotal int32                  `protobuf:"varint,3,opt,name=namespaces_total,json=namespacesTotal,proto3" json:"namespaces_total,omitempty"` // Namespaces in the snapshot
+	VectorsWritten  int64                  `protobuf:"varint,4,opt,name=vectors_written,json=vectorsWritten,proto3" json:"vectors_written,omitempty"`    // Vectors written so far
+	Done            bool                   `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`                                              // True on the final message
+	Path            string                 `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`                                               // Snapshot file (final message only)
+	Checksum        string                 `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`                                       // Hex SHA-256 of the file (final message only)
+	SizeBytes       int64                  `protobuf:"varint,8,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`                   // File size (final message only)
+	SnapshotTimeMs  float32                `protobuf:"fixed32,9,opt,name=snapshot_time_ms,json=snapshotTimeMs,proto3" json:"snapshot_time_ms,omitempty"` // Total time in milliseconds (final message only)
+	CapturedAt      int64                  `protobuf:"varint,10,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`               // Capture time, Unix milliseconds, to search the snapshot with as_of (final message only)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SnapshotProgress) Reset() {
+	*x = SnapshotProgress{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotProgress) ProtoMessage() {}
+
+func (x *SnapshotProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotProgress.ProtoReflect.Descriptor instead.
+func (*SnapshotProgress) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SnapshotProgress) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SnapshotProgress) GetNamespacesDone() int32 {
+	if x != nil {
+		return x.NamespacesDone
+	}
+	return 0
+}
+
+func (x *SnapshotProgress) GetNamespacesTotal() int32 {
+	if x != nil {
+		return x.NamespacesTotal
+	}
+	return 0
+}
+
+func (x *SnapshotProgress) GetVectorsWritten() int64 {
+	if x != nil {
+		return x.VectorsWritten
+	}
+	return 0
+}
+
+func (x *SnapshotProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *SnapshotProgress) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SnapshotProgress) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *SnapshotProgress) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *SnapshotProgress) GetSnapshotTimeMs() float32 {
+	if x != nil {
+		return x.SnapshotTimeMs
+	}
+	return 0
+}
+
+func (x *SnapshotProgress) GetCapturedAt() int64 {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return 0
+}
+
+// RestoreRequest selects a snapshot file to restore
+type RestoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`         // Snapshot file inside the snapshot directory
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`     // Prepended to every restored namespace name
+	Checksum      string                 `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"` // Expected hex SHA-256 (verified when set)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRequest) ProtoMessage() {}
+
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RestoreRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RestoreRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *RestoreRequest) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+// RestoreResponse lists the restored namespaces
+type RestoreResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces      []string               `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`                                   // Names the namespaces were restored under
+	VectorsRestored int64                  `protobuf:"varint,2,opt,name=vectors_restored,json=vectorsRestored,proto3" json:"vectors_restored,omitempty"` // Total vectors restored
+	Checksum        string                 `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`                                       // Hex SHA-256 of the file
+	RestoreTimeMs   float32                `protobuf:"fixed32,4,opt,name=restore_time_ms,json=restoreTimeMs,proto3" json:"restore_time_ms,omitempty"`    // Time spent restoring in milliseconds
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreResponse) Reset() {
+	*x = RestoreResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreResponse) ProtoMessage() {}
+
+func (x *RestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreResponse.ProtoReflect.Descriptor instead.
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RestoreResponse) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *RestoreResponse) GetVectorsRestored() int64 {
+	if x != nil {
+		return x.VectorsRestored
+	}
+	return 0
+}
+
+func (x *RestoreResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *RestoreResponse) GetRestoreTimeMs() float32 {
+	if x != nil {
+		return x.RestoreTimeMs
+	}
+	return 0
+}
+
+// CompactRequest selects the namespace to compact
+type CompactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace to compact
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompactRequest) Reset() {
+	*x = CompactRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactRequest) ProtoMessage() {}
+
+func (x *CompactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactRequest.ProtoReflect.Descriptor instead.
+func (*CompactRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CompactRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// CompactResponse reports the graph before and after compaction
+type CompactResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	NodesBefore       int64                  `protobuf:"varint,1,opt,name=nodes_before,json=nodesBefore,proto3" json:"nodes_before,omitempty"`                     // Live nodes before compaction
+	NodesAfter        int64                  `protobuf:"varint,2,opt,name=nodes_after,json=nodesAfter,proto3" json:"nodes_after,omitempty"`                        // Nodes in the rebuilt graph
+	DeletedNodes      int64                  `protobuf:"varint,3,opt,name=deleted_nodes,json=deletedNodes,proto3" json:"deleted_nodes,omitempty"`                  // Deletes since the graph was built or last compacted
+	MemoryBeforeBytes int64                  `protobuf:"varint,4,opt,name=memory_before_bytes,json=memoryBeforeBytes,proto3" json:"memory_before_bytes,omitempty"` // Estimated index memory before compaction
+	MemoryAfterBytes  int64                  `protobuf:"varint,5,opt,name=memory_after_bytes,json=memoryAfterBytes,proto3" json:"memory_after_bytes,omitempty"`    // Estimated index memory after compaction
+	CompactTimeMs     float32                `protobuf:"fixed32,6,opt,name=compact_time_ms,json=compactTimeMs,proto3" json:"compact_time_ms,omitempty"`            // Time spent compacting in milliseconds
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CompactResponse) Reset() {
+	*x = CompactResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactResponse) ProtoMessage() {}
+
+func (x *CompactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactResponse.ProtoReflect.Descriptor instead.
+func (*CompactResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CompactResponse) GetNodesBefore() int64 {
+	if x != nil {
+		return x.NodesBefore
+	}
+	return 0
+}
+
+func (x *CompactResponse) GetNodesAfter() int64 {
+	if x != nil {
+		return x.NodesAfter
+	}
+	return 0
+}
+
+func (x *CompactResponse) GetDeletedNodes() int64 {
+	if x != nil {
+		return x.DeletedNodes
+	}
+	return 0
+}
+
+func (x *CompactResponse) GetMemoryBeforeBytes() int64 {
+	if x != nil {
+		return x.MemoryBeforeBytes
+	}
+	return 0
+}
+
+func (x *CompactResponse) GetMemoryAfterBytes() int64 {
+	if x != nil {
+		return x.MemoryAfterBytes
+	}
+	return 0
+}
+
+func (x *CompactResponse) GetCompactTimeMs() float32 {
+	if x != nil {
+		return x.CompactTimeMs
+	}
+	return 0
+}
+
+// MigrateRequest selects the namespace to migrate and its new index type
+type MigrateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                  // Namespace to migrate
+	IndexType     string                 `protobuf:"bytes,2,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"` // Target index type: "hnsw", "flat", "ivf_pq" or "scann"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateRequest) Reset() {
+	*x = MigrateRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateRequest) ProtoMessage() {}
+
+func (x *MigrateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateRequest.ProtoReflect.Descriptor instead.
+func (*MigrateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *MigrateRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *MigrateRequest) GetIndexType() string {
+	if x != nil {
+		return x.IndexType
+	}
+	return ""
+}
+
+// MigrateProgress reports a migration in progress. Messages are sent as
+// each phase advances; the last has done set and describes the new index.
+type MigrateProgress struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Phase             string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`                                                     // "read", "train" (ivf_pq and scann only) or "build"
+	VectorsDone       int64                  `protobuf:"varint,2,opt,name=vectors_done,json=vectorsDone,proto3" json:"vectors_done,omitempty"`                     // Vectors done in this phase
+	VectorsTotal      int64                  `protobuf:"varint,3,opt,name=vectors_total,json=vectorsTotal,proto3" json:"vectors_total,omitempty"`                  // Vectors in this phase
+	Done              bool                   `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`                                                      // True on the final message
+	IndexType         string                 `protobuf:"bytes,5,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`                            // Index type now serving the namespace (final message only)
+	MemoryBeforeBytes int64                  `protobuf:"varint,6,opt,name=memory_before_bytes,json=memoryBeforeBytes,proto3" json:"memory_before_bytes,omitempty"` // Estimated index memory before migrating (final message only)
+	MemoryAfterBytes  int64                  `protobuf:"varint,7,opt,name=memory_after_bytes,json=memoryAfterBytes,proto3" json:"memory_after_bytes,omitempty"`    // Estimated index memory after migrating (final message only)
+	MigrateTimeMs     float32                `protobuf:"fixed32,8,opt,name=migrate_time_ms,json=migrateTimeMs,proto3" json:"migrate_time_ms,omitempty"`            // Total time in milliseconds (final message only)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MigrateProgress) Reset() {
+	*x = MigrateProgress{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateProgress) ProtoMessage() {}
+
+func (x *MigrateProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateProgress.ProtoReflect.Descriptor instead.
+func (*MigrateProgress) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MigrateProgress) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *MigrateProgress) GetVectorsDone() int64 {
+	if x != nil {
+		return x.VectorsDone
+	}
+	return 0
+}
+
+func (x *MigrateProgress) GetVectorsTotal() int64 {
+	if x != nil {
+		return x.VectorsTotal
+	}
+	return 0
+}
+
+func (x *MigrateProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *MigrateProgress) GetIndexType() string {
+	if x != nil {
+		return x.IndexType
+	}
+	return ""
+}
+
+func (x *MigrateProgress) GetMemoryBeforeBytes() int64 {
+	if x != nil {
+		return x.MemoryBeforeBytes
+	}
+	return 0
+}
+
+func (x *MigrateProgress) GetMemoryAfterBytes() int64 {
+	if x != nil {
+		return x.MemoryAfterBytes
+	}
+	return 0
+}
+
+func (x *MigrateProgress) GetMigrateTimeMs() float32 {
+	if x != nil {
+		return x.MigrateTimeMs
+	}
+	return 0
+}
+
+// CountRequest selects the namespace to count
+type CountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace to count (default: every namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountRequest) Reset() {
+	*x = CountRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountRequest) ProtoMessage() {}
+
+func (x *CountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
+func (*CountRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CountRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// CountResponse holds vector counts by namespace
+type CountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        map[string]int64       `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Vectors per namespace
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResponse) Reset() {
+	*x = CountResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResponse) ProtoMessage() {}
+
+func (x *CountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResponse.ProtoReflect.Descriptor instead.
+func (*CountResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CountResponse) GetCounts() map[string]int64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+// ListNamespacesRequest requests the namespace names
+type ListNamespacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesRequest) ProtoMessage() {}
+
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{55}
+}
+
+// ListNamespacesResponse lists the namespaces in sorted order
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces    []string               `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"` // Namespace names
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+// CreateNamespaceRequest declares a namespace's index parameters. Zero
+// values take the server configuration.
+type CreateNamespaceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Namespace       string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                      // Namespace to create
+	M               int32                  `protobuf:"varint,2,opt,name=m,proto3" json:"m,omitempty"`                                                     // HNSW links per node (2-100)
+	EfConstruction  int32                  `protobuf:"varint,3,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`     // HNSW candidate list size during insertion (>= 10)
+	Dimensions      int32                  `protobuf:"varint,4,opt,name=dimensions,proto3" json:"dimensions,omitempty"`                                   // Vector dimension every insert must match (default: fixed by the first insert)
+	Metric          string                 `protobuf:"bytes,5,opt,name=metric,proto3" json:"metric,omitempty"`                                            // Retrieval metric: cosine, euclidean or dot_product
+	IndexType       string                 `protobuf:"bytes,6,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`                     // Index type: hnsw, or flat to always search exactly
+	QuantizationMin float32                `protobuf:"fixed32,7,opt,name=quantization_min,json=quantizationMin,proto3" json:"quantization_min,omitempty"` // Float value int8 -127 and uint8 0 codes map to
+	QuantizationMax float32                `protobuf:"fixed32,8,opt,name=quantization_max,json=quantizationMax,proto3" json:"quantization_max,omitempty"` // Float value int8 127 and uint8 255 codes map to (both 0 = codes are used as integers)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceRequest) ProtoMessage() {}
+
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CreateNamespaceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *CreateNamespaceRequest) GetEfConstruction() int32 {
+	if x != nil {
+		return x.EfConstruction
+	}
+	return 0
+}
+
+func (x *CreateNamespaceRequest) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *CreateNamespaceRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetIndexType() string {
+	if x != nil {
+		return x.IndexType
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetQuantizationMin() float32 {
+	if x != nil {
+		return x.QuantizationMin
+	}
+	return 0
+}
+
+func (x *CreateNamespaceRequest) GetQuantizationMax() float32 {
+	if x != nil {
+		return x.QuantizationMax
+	}
+	return 0
+}
+
+// CreateNamespaceResponse reports the parameters the namespace uses
+type CreateNamespaceResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Namespace       string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                      // Namespace created
+	M               int32                  `protobuf:"varint,2,opt,name=m,proto3" json:"m,omitempty"`                                                     // Effective HNSW links per node
+	EfConstruction  int32                  `protobuf:"varint,3,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`     // Effective HNSW construction candidate list size
+	Dimensions      int32                  `protobuf:"varint,4,opt,name=dimensions,proto3" json:"dimensions,omitempty"`                                   // Declared dimension (0 when the first insert fixes it)
+	Metric          string                 `protobuf:"bytes,5,opt,name=metric,proto3" json:"metric,omitempty"`                                            // Effective retrieval metric
+	IndexType       string                 `protobuf:"bytes,6,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`                     // Effective index type
+	QuantizationMin float32                `protobuf:"fixed32,7,opt,name=quantization_min,json=quantizationMin,proto3" json:"quantization_min,omitempty"` // Low end of the quantized vector range
+	QuantizationMax float32                `protobuf:"fixed32,8,opt,name=quantization_max,json=quantizationMax,proto3" json:"quantization_max,omitempty"` // High end of the quantized vector range (both 0 when unset)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceResponse) Reset() {
+	*x = CreateNamespaceResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceResponse) ProtoMessage() {}
+
+func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CreateNamespaceResponse) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CreateNamespaceResponse) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *CreateNamespaceResponse) GetEfConstruction() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EfConstruction
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GeoRadiusFilter.ProtoReflect.Descriptor instead.
-func (*GeoRadiusFilter) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{16}
+func (x *CreateNamespaceResponse) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
 }
 
-func (x *GeoRadiusFilter) GetField() string {
+func (x *CreateNamespaceResponse) GetMetric() string {
 	if x != nil {
-		return x.Field
+		return x.Metric
 	}
 	return ""
 }
 
-func (x *GeoRadiusFilter) GetLatitude() float64 {
+func (x *CreateNamespaceResponse) GetIndexType() string {
 	if x != nil {
-		return x.Latitude
+		return x.IndexType
 	}
-	return 0
+	return ""
 }
 
-func (x *GeoRadiusFilter) GetLongitude() float64 {
+func (x *CreateNamespaceResponse) GetQuantizationMin() float32 {
 	if x != nil {
-		return x.Longitude
+		return x.QuantizationMin
 	}
 	return 0
 }
 
-func (x *GeoRadiusFilter) GetRadiusKm() float64 {
+func (x *CreateNamespaceResponse) GetQuantizationMax() float32 {
 	if x != nil {
-		return x.RadiusKm
+		return x.QuantizationMax
 	}
 	return 0
 }
 
-// ExistsFilter checks field existence
-type ExistsFilter struct {
+// DescribeNamespaceRequest selects the namespace to describe
+type DescribeNamespaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`    // Field name
-	Exists        bool                   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"` // true to check existence, false for non-existence
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace to describe
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExistsFilter) Reset() {
-	*x = ExistsFilter{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[17]
+func (x *DescribeNamespaceRequest) Reset() {
+	*x = DescribeNamespaceRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExistsFilter) String() string {
+func (x *DescribeNamespaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExistsFilter) ProtoMessage() {}
+func (*DescribeNamespaceRequest) ProtoMessage() {}
 
-func (x *ExistsFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[17]
+func (x *DescribeNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1376,49 +4517,53 @@ func (x *ExistsFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExistsFilter.ProtoReflect.Descriptor instead.
-func (*ExistsFilter) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use DescribeNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*DescribeNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ExistsFilter) GetField() string {
+func (x *DescribeNamespaceRequest) GetNamespace() string {
 	if x != nil {
-		return x.Field
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *ExistsFilter) GetExists() bool {
-	if x != nil {
-		return x.Exists
-	}
-	return false
-}
-
-// CompositeFilter combines multiple filters
-type CompositeFilter struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Operator      string                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"` // "and", "or", "not"
-	Filters       []*Filter              `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`   // Sub-filters
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CompositeFilter) Reset() {
-	*x = CompositeFilter{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[18]
+// DescribeNamespaceResponse reports a namespace's parameters and size
+type DescribeNamespaceResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Namespace       string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                       // Namespace described
+	IndexType       string                 `protobuf:"bytes,2,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`                      // Index type: hnsw or flat
+	M               int32                  `protobuf:"varint,3,opt,name=m,proto3" json:"m,omitempty"`                                                      // HNSW links per node
+	EfConstruction  int32                  `protobuf:"varint,4,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`      // HNSW candidate list size during insertion
+	Dimensions      int32                  `protobuf:"varint,5,opt,name=dimensions,proto3" json:"dimensions,omitempty"`                                    // Vector dimension (0 until declared or fixed by the first insert)
+	Metric          string                 `protobuf:"bytes,6,opt,name=metric,proto3" json:"metric,omitempty"`                                             // Retrieval metric
+	VectorCount     int64                  `protobuf:"varint,7,opt,name=vector_count,json=vectorCount,proto3" json:"vector_count,omitempty"`               // Number of vectors
+	MemoryBytes     int64                  `protobuf:"varint,8,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`               // Estimated memory usage
+	DefaultEfSearch int32                  `protobuf:"varint,9,opt,name=default_ef_search,json=defaultEfSearch,proto3" json:"default_ef_search,omitempty"` // efSearch used when a request omits it
+	MaxK            int32                  `protobuf:"varint,10,opt,name=max_k,json=maxK,proto3" json:"max_k,omitempty"`                                   // Largest k a search may request (0 = unlimited)
+	MaxEfSearch     int32                  `protobuf:"varint,11,opt,name=max_ef_search,json=maxEfSearch,proto3" json:"max_ef_search,omitempty"`            // Largest ef_search a search may request (0 = unlimited)
+	QuantizationMin float32                `protobuf:"fixed32,12,opt,name=quantization_min,json=quantizationMin,proto3" json:"quantization_min,omitempty"` // Low end of the quantized vector range
+	QuantizationMax float32                `protobuf:"fixed32,13,opt,name=quantization_max,json=quantizationMax,proto3" json:"quantization_max,omitempty"` // High end of the quantized vector range (both 0 when unset)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DescribeNamespaceResponse) Reset() {
+	*x = DescribeNamespaceResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompositeFilter) String() string {
+func (x *DescribeNamespaceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompositeFilter) ProtoMessage() {}
+func (*DescribeNamespaceResponse) ProtoMessage() {}
 
-func (x *CompositeFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[18]
+func (x *DescribeNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1429,164 +4574,125 @@ func (x *CompositeFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompositeFilter.ProtoReflect.Descriptor instead.
-func (*CompositeFilter) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use DescribeNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*DescribeNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *CompositeFilter) GetOperator() string {
+func (x *DescribeNamespaceResponse) GetNamespace() string {
 	if x != nil {
-		return x.Operator
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *CompositeFilter) GetFilters() []*Filter {
+func (x *DescribeNamespaceResponse) GetIndexType() string {
 	if x != nil {
-		return x.Filters
+		return x.IndexType
 	}
-	return nil
-}
-
-// StatsRequest requests database statistics
-type StatsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     *string                `protobuf:"bytes,1,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"` // Optional namespace (all if not specified)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *StatsRequest) Reset() {
-	*x = StatsRequest{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[19]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *StatsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DescribeNamespaceResponse) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
 }
 
-func (*StatsRequest) ProtoMessage() {}
-
-func (x *StatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[19]
+func (x *DescribeNamespaceResponse) GetEfConstruction() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EfConstruction
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
-func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{19}
+func (x *DescribeNamespaceResponse) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
 }
 
-func (x *StatsRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *DescribeNamespaceResponse) GetMetric() string {
+	if x != nil {
+		return x.Metric
 	}
 	return ""
 }
 
-// StatsResponse returns database statistics
-type StatsResponse struct {
-	state            protoimpl.MessageState     `protogen:"open.v1"`
-	TotalVectors     int64                      `protobuf:"varint,1,opt,name=total_vectors,json=totalVectors,proto3" json:"total_vectors,omitempty"`                                                                                // Total number of vectors
-	TotalNamespaces  int64                      `protobuf:"varint,2,opt,name=total_namespaces,json=totalNamespaces,proto3" json:"total_namespaces,omitempty"`                                                                       // Total number of namespaces
-	MemoryUsageBytes int64                      `protobuf:"varint,3,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3" json:"memory_usage_bytes,omitempty"`                                                                  // Approximate memory usage
-	NamespaceStats   map[string]*NamespaceStats `protobuf:"bytes,4,rep,name=namespace_stats,json=namespaceStats,proto3" json:"namespace_stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Per-namespace statistics
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
-
-func (x *StatsResponse) Reset() {
-	*x = StatsResponse{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *StatsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DescribeNamespaceResponse) GetVectorCount() int64 {
+	if x != nil {
+		return x.VectorCount
+	}
+	return 0
 }
 
-func (*StatsResponse) ProtoMessage() {}
-
-func (x *StatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[20]
+func (x *DescribeNamespaceResponse) GetMemoryBytes() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MemoryBytes
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
-func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{20}
+func (x *DescribeNamespaceResponse) GetDefaultEfSearch() int32 {
+	if x != nil {
+		return x.DefaultEfSearch
+	}
+	return 0
 }
 
-func (x *StatsResponse) GetTotalVectors() int64 {
+func (x *DescribeNamespaceResponse) GetMaxK() int32 {
 	if x != nil {
-		return x.TotalVectors
+		return x.MaxK
 	}
 	return 0
 }
 
-func (x *StatsResponse) GetTotalNamespaces() int64 {
+func (x *DescribeNamespaceResponse) GetMaxEfSearch() int32 {
 	if x != nil {
-		return x.TotalNamespaces
+		return x.MaxEfSearch
 	}
 	return 0
 }
 
-func (x *StatsResponse) GetMemoryUsageBytes() int64 {
+func (x *DescribeNamespaceResponse) GetQuantizationMin() float32 {
 	if x != nil {
-		return x.MemoryUsageBytes
+		return x.QuantizationMin
 	}
 	return 0
 }
 
-func (x *StatsResponse) GetNamespaceStats() map[string]*NamespaceStats {
+func (x *DescribeNamespaceResponse) GetQuantizationMax() float32 {
 	if x != nil {
-		return x.NamespaceStats
+		return x.QuantizationMax
 	}
-	return nil
+	return 0
 }
 
-// NamespaceStats contains statistics for a single namespace
-type NamespaceStats struct {
+// DropNamespaceRequest selects the namespace to delete
+type DropNamespaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VectorCount   int64                  `protobuf:"varint,1,opt,name=vector_count,json=vectorCount,proto3" json:"vector_count,omitempty"` // Number of vectors in namespace
-	MemoryBytes   int64                  `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"` // Memory usage for namespace
-	Dimensions    int32                  `protobuf:"varint,3,opt,name=dimensions,proto3" json:"dimensions,omitempty"`                      // Vector dimensions
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // Namespace to delete
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NamespaceStats) Reset() {
-	*x = NamespaceStats{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[21]
+func (x *DropNamespaceRequest) Reset() {
+	*x = DropNamespaceRequest{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NamespaceStats) String() string {
+func (x *DropNamespaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NamespaceStats) ProtoMessage() {}
+func (*DropNamespaceRequest) ProtoMessage() {}
 
-func (x *NamespaceStats) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[21]
+func (x *DropNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1597,33 +4703,63 @@ func (x *NamespaceStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NamespaceStats.ProtoReflect.Descriptor instead.
-func (*NamespaceStats) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use DropNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*DropNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *NamespaceStats) GetVectorCount() int64 {
+func (x *DropNamespaceRequest) GetNamespace() string {
 	if x != nil {
-		return x.VectorCount
+		return x.Namespace
 	}
-	return 0
+	return ""
 }
 
-func (x *NamespaceStats) GetMemoryBytes() int64 {
+// DropNamespaceResponse reports what was deleted
+type DropNamespaceResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VectorsDropped int64                  `protobuf:"varint,1,opt,name=vectors_dropped,json=vectorsDropped,proto3" json:"vectors_dropped,omitempty"` // Vectors the namespace held
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DropNamespaceResponse) Reset() {
+	*x = DropNamespaceResponse{}
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DropNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DropNamespaceResponse) ProtoMessage() {}
+
+func (x *DropNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[62]
 	if x != nil {
-		return x.MemoryBytes
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *NamespaceStats) GetDimensions() int32 {
+// Deprecated: Use DropNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*DropNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DropNamespaceResponse) GetVectorsDropped() int64 {
 	if x != nil {
-		return x.Dimensions
+		return x.VectorsDropped
 	}
 	return 0
 }
 
-// HealthCheckRequest requests health status
 type HealthCheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1632,7 +4768,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[22]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1644,7 +4780,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[22]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1657,7 +4793,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{22}
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{63}
 }
 
 // HealthCheckResponse returns health status
@@ -1673,7 +4809,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[23]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1685,7 +4821,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[23]
+	mi := &file_pkg_api_grpc_proto_vector_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1698,7 +4834,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{23}
+	return file_pkg_api_grpc_proto_vector_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *HealthCheckResponse) GetStatus() string {
@@ -1733,32 +4869,104 @@ var File_pkg_api_grpc_proto_vector_proto protoreflect.FileDescriptor
 
 const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\n" +
-	"\x1fpkg/api/grpc/proto/vector.proto\x12\x06vector\"\x81\x02\n" +
+	"\x1fpkg/api/grpc/proto/vector.proto\x12\x06vector\"\x8c\x05\n" +
 	"\rInsertRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
 	"\x06vector\x18\x02 \x03(\x02R\x06vector\x12?\n" +
 	"\bmetadata\x18\x03 \x03(\v2#.vector.InsertRequest.MetadataEntryR\bmetadata\x12\x13\n" +
 	"\x02id\x18\x04 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x17\n" +
-	"\x04text\x18\x05 \x01(\tH\x01R\x04text\x88\x01\x01\x1a;\n" +
+	"\x04text\x18\x05 \x01(\tH\x01R\x04text\x88\x01\x01\x12)\n" +
+	"\x10quantized_vector\x18\x06 \x01(\fR\x0fquantizedVector\x12\"\n" +
+	"\fquantization\x18\a \x01(\tR\fquantization\x12'\n" +
+	"\x0fef_construction\x18\b \x01(\x05R\x0eefConstruction\x12O\n" +
+	"\x0etyped_metadata\x18\t \x03(\v2(.vector.InsertRequest.TypedMetadataEntryR\rtypedMetadata\x12$\n" +
+	"\vexternal_id\x18\n" +
+	" \x01(\tH\x02R\n" +
+	"externalId\x88\x01\x01\x12\x16\n" +
+	"\x06upsert\x18\v \x01(\bR\x06upsert\x12\x19\n" +
+	"\bon_error\x18\f \x01(\tR\aonError\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x05\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aW\n" +
+	"\x12TypedMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.vector.MetadataValueR\x05value:\x028\x01B\x05\n" +
 	"\x03_idB\a\n" +
-	"\x05_text\"_\n" +
+	"\x05_textB\x0e\n" +
+	"\f_external_id\"\xe4\x01\n" +
+	"\rMetadataValue\x12&\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x88\x01\x01\x12 \n" +
+	"\tint_value\x18\x02 \x01(\x03H\x01R\bintValue\x88\x01\x01\x12&\n" +
+	"\fdouble_value\x18\x03 \x01(\x01H\x02R\vdoubleValue\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"bool_value\x18\x04 \x01(\bH\x03R\tboolValue\x88\x01\x01B\x0f\n" +
+	"\r_string_valueB\f\n" +
+	"\n" +
+	"_int_valueB\x0f\n" +
+	"\r_double_valueB\r\n" +
+	"\v_bool_value\"_\n" +
 	"\x0eInsertResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x19\n" +
 	"\x05error\x18\x03 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"\xf5\x01\n" +
+	"\x06_error\"\xb7\x05\n" +
 	"\rSearchRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12!\n" +
 	"\fquery_vector\x18\x02 \x03(\x02R\vqueryVector\x12\f\n" +
 	"\x01k\x18\x03 \x01(\x05R\x01k\x12\x1b\n" +
 	"\tef_search\x18\x04 \x01(\x05R\befSearch\x12+\n" +
 	"\x06filter\x18\x05 \x01(\v2\x0e.vector.FilterH\x00R\x06filter\x88\x01\x01\x12,\n" +
-	"\x0fdistance_metric\x18\x06 \x01(\tH\x01R\x0edistanceMetric\x88\x01\x01B\t\n" +
+	"\x0fdistance_metric\x18\x06 \x01(\tH\x01R\x0edistanceMetric\x88\x01\x01\x12\x1f\n" +
+	"\vguarantee_k\x18\a \x01(\bR\n" +
+	"guaranteeK\x12\x18\n" +
+	"\aprofile\x18\b \x01(\bR\aprofile\x124\n" +
+	"\x16quantized_query_vector\x18\t \x01(\fR\x14quantizedQueryVector\x12\"\n" +
+	"\fquantization\x18\n" +
+	" \x01(\tR\fquantization\x12\x1f\n" +
+	"\vcount_total\x18\v \x01(\bR\n" +
+	"countTotal\x12\x16\n" +
+	"\x06offset\x18\f \x01(\x05R\x06offset\x12\x1d\n" +
+	"\n" +
+	"score_mode\x18\r \x01(\tR\tscoreMode\x12\x1a\n" +
+	"\breranker\x18\x0e \x01(\tR\breranker\x12!\n" +
+	"\frerank_depth\x18\x0f \x01(\x05R\vrerankDepth\x12\x16\n" +
+	"\x06fields\x18\x10 \x03(\tR\x06fields\x12&\n" +
+	"\fmax_distance\x18\x11 \x01(\x02H\x02R\vmaxDistance\x88\x01\x01\x12 \n" +
+	"\tmin_score\x18\x12 \x01(\x02H\x03R\bminScore\x88\x01\x01\x12\x13\n" +
+	"\x05as_of\x18\x13 \x01(\x03R\x04asOfB\t\n" +
 	"\a_filterB\x12\n" +
-	"\x10_distance_metric\"\x9c\x02\n" +
+	"\x10_distance_metricB\x0f\n" +
+	"\r_max_distanceB\f\n" +
+	"\n" +
+	"_min_score\"\xab\x01\n" +
+	"\x12RangeSearchRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12!\n" +
+	"\fquery_vector\x18\x02 \x03(\x02R\vqueryVector\x12\x16\n" +
+	"\x06radius\x18\x03 \x01(\x02R\x06radius\x12\x1f\n" +
+	"\vmax_results\x18\x04 \x01(\x05R\n" +
+	"maxResults\x12\x1b\n" +
+	"\tef_search\x18\x05 \x01(\x05R\befSearch\"\xc4\x01\n" +
+	"\x12BatchSearchRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12-\n" +
+	"\aqueries\x18\x02 \x03(\v2\x13.vector.QueryVectorR\aqueries\x12\f\n" +
+	"\x01k\x18\x03 \x01(\x05R\x01k\x12\x1b\n" +
+	"\tef_search\x18\x04 \x01(\x05R\befSearch\x12+\n" +
+	"\x06filter\x18\x05 \x01(\v2\x0e.vector.FilterH\x00R\x06filter\x88\x01\x01B\t\n" +
+	"\a_filter\"%\n" +
+	"\vQueryVector\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"\xec\x01\n" +
+	"\x18MultiVectorSearchRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x128\n" +
+	"\rquery_vectors\x18\x02 \x03(\v2\x13.vector.QueryVectorR\fqueryVectors\x12\f\n" +
+	"\x01k\x18\x03 \x01(\x05R\x01k\x12\x1b\n" +
+	"\tef_search\x18\x04 \x01(\x05R\befSearch\x12 \n" +
+	"\vaggregation\x18\x05 \x01(\tR\vaggregation\x12\x1e\n" +
+	"\bgroup_by\x18\x06 \x01(\tH\x00R\agroupBy\x88\x01\x01B\v\n" +
+	"\t_group_by\"\x94\x01\n" +
+	"\x13BatchSearchResponse\x124\n" +
+	"\tresponses\x18\x01 \x03(\v2\x16.vector.SearchResponseR\tresponses\x12!\n" +
+	"\ffailed_count\x18\x02 \x01(\x05R\vfailedCount\x12$\n" +
+	"\x0esearch_time_ms\x18\x03 \x01(\x02R\fsearchTimeMs\"\xdb\x02\n" +
 	"\x13HybridSearchRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12!\n" +
 	"\fquery_vector\x18\x02 \x03(\x02R\vqueryVector\x12\x1d\n" +
@@ -1767,7 +4975,9 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\x01k\x18\x04 \x01(\x05R\x01k\x12\x1b\n" +
 	"\tef_search\x18\x05 \x01(\x05R\befSearch\x12+\n" +
 	"\x06filter\x18\x06 \x01(\v2\x0e.vector.FilterH\x00R\x06filter\x88\x01\x01\x127\n" +
-	"\x06config\x18\a \x01(\v2\x1a.vector.HybridSearchConfigH\x01R\x06config\x88\x01\x01B\t\n" +
+	"\x06config\x18\a \x01(\v2\x1a.vector.HybridSearchConfigH\x01R\x06config\x88\x01\x01\x12\x1a\n" +
+	"\breranker\x18\b \x01(\tR\breranker\x12!\n" +
+	"\frerank_depth\x18\t \x01(\x05R\vrerankDepthB\t\n" +
 	"\a_filterB\t\n" +
 	"\a_config\"\x94\x01\n" +
 	"\x12HybridSearchConfig\x12#\n" +
@@ -1775,13 +4985,34 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\rvector_weight\x18\x02 \x01(\x02R\fvectorWeight\x12\x1f\n" +
 	"\vtext_weight\x18\x03 \x01(\x02R\n" +
 	"textWeight\x12\x13\n" +
-	"\x05rrf_k\x18\x04 \x01(\x05R\x04rrfK\"\xb0\x01\n" +
+	"\x05rrf_k\x18\x04 \x01(\x05R\x04rrfK\"\x90\x03\n" +
 	"\x0eSearchResponse\x12.\n" +
 	"\aresults\x18\x01 \x03(\v2\x14.vector.SearchResultR\aresults\x12#\n" +
 	"\rtotal_results\x18\x02 \x01(\x05R\ftotalResults\x12$\n" +
 	"\x0esearch_time_ms\x18\x03 \x01(\x02R\fsearchTimeMs\x12\x19\n" +
-	"\x05error\x18\x04 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"\xdd\x02\n" +
+	"\x05error\x18\x04 \x01(\tH\x00R\x05error\x88\x01\x01\x12\x1c\n" +
+	"\ttruncated\x18\x05 \x01(\bR\ttruncated\x124\n" +
+	"\aprofile\x18\x06 \x01(\v2\x15.vector.SearchProfileH\x01R\aprofile\x88\x01\x01\x12.\n" +
+	"\x13effective_ef_search\x18\a \x01(\x05R\x11effectiveEfSearch\x12\x14\n" +
+	"\x05exact\x18\b \x01(\bR\x05exact\x12#\n" +
+	"\rtotal_matches\x18\t \x01(\x03R\ftotalMatches\x12\x13\n" +
+	"\x05as_of\x18\n" +
+	" \x01(\x03R\x04asOfB\b\n" +
+	"\x06_errorB\n" +
+	"\n" +
+	"\b_profile\"\x8d\x02\n" +
+	"\rSearchProfile\x12)\n" +
+	"\x05spans\x18\x01 \x03(\v2\x13.vector.ProfileSpanR\x05spans\x123\n" +
+	"\x15distance_computations\x18\x02 \x01(\x03R\x14distanceComputations\x12\x1f\n" +
+	"\vdistance_ns\x18\x03 \x01(\x03R\n" +
+	"distanceNs\x12#\n" +
+	"\rnodes_visited\x18\x04 \x01(\x03R\fnodesVisited\x12'\n" +
+	"\x0fheap_operations\x18\x05 \x01(\x03R\x0eheapOperations\x12-\n" +
+	"\x12filter_evaluations\x18\x06 \x01(\x03R\x11filterEvaluations\"B\n" +
+	"\vProfileSpan\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vduration_ns\x18\x02 \x01(\x03R\n" +
+	"durationNs\"\xf2\x05\n" +
 	"\fSearchResult\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\bdistance\x18\x02 \x01(\x02R\bdistance\x12\x16\n" +
@@ -1790,13 +5021,80 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\x04text\x18\x05 \x01(\tH\x00R\x04text\x88\x01\x01\x12&\n" +
 	"\fvector_score\x18\x06 \x01(\x02H\x01R\vvectorScore\x88\x01\x01\x12\"\n" +
 	"\n" +
-	"text_score\x18\a \x01(\x02H\x02R\ttextScore\x88\x01\x01\x1a;\n" +
+	"text_score\x18\a \x01(\x02H\x02R\ttextScore\x88\x01\x01\x12\x1d\n" +
+	"\asnippet\x18\b \x01(\tH\x03R\asnippet\x88\x01\x01\x12N\n" +
+	"\x0etyped_metadata\x18\t \x03(\v2'.vector.SearchResult.TypedMetadataEntryR\rtypedMetadata\x12\x19\n" +
+	"\x05score\x18\n" +
+	" \x01(\x02H\x04R\x05score\x88\x01\x01\x12$\n" +
+	"\vexternal_id\x18\v \x01(\tH\x05R\n" +
+	"externalId\x88\x01\x01\x12$\n" +
+	"\vvector_rank\x18\f \x01(\x05H\x06R\n" +
+	"vectorRank\x88\x01\x01\x12 \n" +
+	"\ttext_rank\x18\r \x01(\x05H\aR\btextRank\x88\x01\x01\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aW\n" +
+	"\x12TypedMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.vector.MetadataValueR\x05value:\x028\x01B\a\n" +
 	"\x05_textB\x0f\n" +
 	"\r_vector_scoreB\r\n" +
-	"\v_text_score\"u\n" +
+	"\v_text_scoreB\n" +
+	"\n" +
+	"\b_snippetB\b\n" +
+	"\x06_scoreB\x0e\n" +
+	"\f_external_idB\x0e\n" +
+	"\f_vector_rankB\f\n" +
+	"\n" +
+	"_text_rank\">\n" +
+	"\fFetchRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\"\xec\x03\n" +
+	"\vFetchResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12\x16\n" +
+	"\x06vector\x18\x03 \x03(\x02R\x06vector\x12=\n" +
+	"\bmetadata\x18\x04 \x03(\v2!.vector.FetchResult.MetadataEntryR\bmetadata\x12\x17\n" +
+	"\x04text\x18\x05 \x01(\tH\x00R\x04text\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\x06 \x01(\tH\x01R\x05error\x88\x01\x01\x12M\n" +
+	"\x0etyped_metadata\x18\a \x03(\v2&.vector.FetchResult.TypedMetadataEntryR\rtypedMetadata\x12$\n" +
+	"\vexternal_id\x18\b \x01(\tH\x02R\n" +
+	"externalId\x88\x01\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aW\n" +
+	"\x12TypedMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.vector.MetadataValueR\x05value:\x028\x01B\a\n" +
+	"\x05_textB\b\n" +
+	"\x06_errorB\x0e\n" +
+	"\f_external_id\"\x87\x01\n" +
+	"\rFetchResponse\x12-\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.vector.FetchResultR\aresults\x12\x1f\n" +
+	"\vfound_count\x18\x02 \x01(\x05R\n" +
+	"foundCount\x12&\n" +
+	"\x0fnot_found_count\x18\x03 \x01(\x05R\rnotFoundCount\"z\n" +
+	"\rExportRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\"\x87\x01\n" +
+	"\vExportBatch\x12-\n" +
+	"\avectors\x18\x01 \x03(\v2\x13.vector.FetchResultR\avectors\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"\x87\x01\n" +
+	"\vScanRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12%\n" +
+	"\x0einclude_vector\x18\x04 \x01(\bR\rincludeVector\"^\n" +
+	"\fScanResponse\x12-\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.vector.FetchResultR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"u\n" +
 	"\rDeleteRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x10\n" +
 	"\x02id\x18\x02 \x01(\tH\x00R\x02id\x12(\n" +
@@ -1807,16 +5105,23 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\rdeleted_count\x18\x01 \x01(\x05R\fdeletedCount\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x19\n" +
 	"\x05error\x18\x03 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"\xf5\x01\n" +
+	"\x06_error\"\xd6\x03\n" +
 	"\rUpdateRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\tR\x02id\x12\x16\n" +
 	"\x06vector\x18\x03 \x03(\x02R\x06vector\x12?\n" +
 	"\bmetadata\x18\x04 \x03(\v2#.vector.UpdateRequest.MetadataEntryR\bmetadata\x12\x17\n" +
-	"\x04text\x18\x05 \x01(\tH\x00R\x04text\x88\x01\x01\x1a;\n" +
+	"\x04text\x18\x05 \x01(\tH\x00R\x04text\x88\x01\x01\x12O\n" +
+	"\x0etyped_metadata\x18\x06 \x03(\v2(.vector.UpdateRequest.TypedMetadataEntryR\rtypedMetadata\x12\x14\n" +
+	"\x05merge\x18\a \x01(\bR\x05merge\x12\x1f\n" +
+	"\vremove_keys\x18\b \x03(\tR\n" +
+	"removeKeys\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aW\n" +
+	"\x12TypedMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.vector.MetadataValueR\x05value:\x028\x01B\a\n" +
 	"\x05_text\"O\n" +
 	"\x0eUpdateResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
@@ -1827,7 +5132,30 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\ffailed_count\x18\x02 \x01(\x05R\vfailedCount\x12!\n" +
 	"\finserted_ids\x18\x03 \x03(\tR\vinsertedIds\x12\x16\n" +
 	"\x06errors\x18\x04 \x03(\tR\x06errors\x12\"\n" +
-	"\rtotal_time_ms\x18\x05 \x01(\x02R\vtotalTimeMs\"\xcd\x02\n" +
+	"\rtotal_time_ms\x18\x05 \x01(\x02R\vtotalTimeMs\"F\n" +
+	"\x17AsyncBatchInsertRequest\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.vector.InsertRequestR\x05items\"l\n" +
+	"\x18AsyncBatchInsertResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x19\n" +
+	"\x05error\x18\x03 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\")\n" +
+	"\x10JobStatusRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xa7\x02\n" +
+	"\x11JobStatusResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\binserted\x18\x04 \x01(\x05R\binserted\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12\x1c\n" +
+	"\tremaining\x18\x06 \x01(\x05R\tremaining\x12!\n" +
+	"\finserted_ids\x18\a \x03(\tR\vinsertedIds\x12\x16\n" +
+	"\x06errors\x18\b \x03(\tR\x06errors\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\t \x01(\x02R\telapsedMs\x12\x19\n" +
+	"\x05error\x18\n" +
+	" \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\x97\x03\n" +
 	"\x06Filter\x12:\n" +
 	"\n" +
 	"comparison\x18\x01 \x01(\v2\x18.vector.ComparisonFilterH\x00R\n" +
@@ -1837,7 +5165,8 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\n" +
 	"geo_radius\x18\x04 \x01(\v2\x17.vector.GeoRadiusFilterH\x00R\tgeoRadius\x12.\n" +
 	"\x06exists\x18\x05 \x01(\v2\x14.vector.ExistsFilterH\x00R\x06exists\x127\n" +
-	"\tcomposite\x18\x06 \x01(\v2\x17.vector.CompositeFilterH\x00R\tcompositeB\r\n" +
+	"\tcomposite\x18\x06 \x01(\v2\x17.vector.CompositeFilterH\x00R\tcomposite\x12H\n" +
+	"\x10geo_bounding_box\x18\a \x01(\v2\x1c.vector.GeoBoundingBoxFilterH\x00R\x0egeoBoundingBoxB\r\n" +
 	"\vfilter_type\"Z\n" +
 	"\x10ComparisonFilter\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
@@ -1862,7 +5191,13 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
 	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
 	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x1b\n" +
-	"\tradius_km\x18\x04 \x01(\x01R\bradiusKm\"<\n" +
+	"\tradius_km\x18\x04 \x01(\x01R\bradiusKm\"\xbc\x01\n" +
+	"\x14GeoBoundingBoxFilter\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12!\n" +
+	"\fmin_latitude\x18\x02 \x01(\x01R\vminLatitude\x12#\n" +
+	"\rmin_longitude\x18\x03 \x01(\x01R\fminLongitude\x12!\n" +
+	"\fmax_latitude\x18\x04 \x01(\x01R\vmaxLatitude\x12#\n" +
+	"\rmax_longitude\x18\x05 \x01(\x01R\fmaxLongitude\"<\n" +
 	"\fExistsFilter\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
 	"\x06exists\x18\x02 \x01(\bR\x06exists\"W\n" +
@@ -1880,13 +5215,144 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\x0fnamespace_stats\x18\x04 \x03(\v2).vector.StatsResponse.NamespaceStatsEntryR\x0enamespaceStats\x1aY\n" +
 	"\x13NamespaceStatsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
-	"\x05value\x18\x02 \x01(\v2\x16.vector.NamespaceStatsR\x05value:\x028\x01\"v\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.vector.NamespaceStatsR\x05value:\x028\x01\"\x8e\x02\n" +
 	"\x0eNamespaceStats\x12!\n" +
 	"\fvector_count\x18\x01 \x01(\x03R\vvectorCount\x12!\n" +
 	"\fmemory_bytes\x18\x02 \x01(\x03R\vmemoryBytes\x12\x1e\n" +
 	"\n" +
 	"dimensions\x18\x03 \x01(\x05R\n" +
-	"dimensions\"\x14\n" +
+	"dimensions\x12\x1b\n" +
+	"\tmax_layer\x18\x04 \x01(\x05R\bmaxLayer\x12*\n" +
+	"\x06layers\x18\x05 \x03(\v2\x12.vector.LayerStatsR\x06layers\x12\f\n" +
+	"\x01m\x18\x06 \x01(\x05R\x01m\x12'\n" +
+	"\x0fef_construction\x18\a \x01(\x05R\x0eefConstruction\x12\x16\n" +
+	"\x06metric\x18\b \x01(\tR\x06metric\"W\n" +
+	"\n" +
+	"LayerStats\x12\x14\n" +
+	"\x05layer\x18\x01 \x01(\x05R\x05layer\x12\x14\n" +
+	"\x05nodes\x18\x02 \x01(\x03R\x05nodes\x12\x1d\n" +
+	"\n" +
+	"avg_degree\x18\x03 \x01(\x02R\tavgDegree\"/\n" +
+	"\x0fValidateRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\xc0\x01\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12'\n" +
+	"\x0fviolation_count\x18\x02 \x01(\x05R\x0eviolationCount\x12\x1e\n" +
+	"\n" +
+	"violations\x18\x03 \x03(\tR\n" +
+	"violations\x12#\n" +
+	"\rnodes_checked\x18\x04 \x01(\x03R\fnodesChecked\x12(\n" +
+	"\x10validate_time_ms\x18\x05 \x01(\x02R\x0evalidateTimeMs\"\x11\n" +
+	"\x0fSnapshotRequest\"\xdb\x02\n" +
+	"\x10SnapshotProgress\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12'\n" +
+	"\x0fnamespaces_done\x18\x02 \x01(\x05R\x0enamespacesDone\x12)\n" +
+	"\x10namespaces_total\x18\x03 \x01(\x05R\x0fnamespacesTotal\x12'\n" +
+	"\x0fvectors_written\x18\x04 \x01(\x03R\x0evectorsWritten\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\x12\x12\n" +
+	"\x04path\x18\x06 \x01(\tR\x04path\x12\x1a\n" +
+	"\bchecksum\x18\a \x01(\tR\bchecksum\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\b \x01(\x03R\tsizeBytes\x12(\n" +
+	"\x10snapshot_time_ms\x18\t \x01(\x02R\x0esnapshotTimeMs\x12\x1f\n" +
+	"\vcaptured_at\x18\n" +
+	" \x01(\x03R\n" +
+	"capturedAt\"X\n" +
+	"\x0eRestoreRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x1a\n" +
+	"\bchecksum\x18\x03 \x01(\tR\bchecksum\"\xa0\x01\n" +
+	"\x0fRestoreResponse\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x01 \x03(\tR\n" +
+	"namespaces\x12)\n" +
+	"\x10vectors_restored\x18\x02 \x01(\x03R\x0fvectorsRestored\x12\x1a\n" +
+	"\bchecksum\x18\x03 \x01(\tR\bchecksum\x12&\n" +
+	"\x0frestore_time_ms\x18\x04 \x01(\x02R\rrestoreTimeMs\".\n" +
+	"\x0eCompactRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x80\x02\n" +
+	"\x0fCompactResponse\x12!\n" +
+	"\fnodes_before\x18\x01 \x01(\x03R\vnodesBefore\x12\x1f\n" +
+	"\vnodes_after\x18\x02 \x01(\x03R\n" +
+	"nodesAfter\x12#\n" +
+	"\rdeleted_nodes\x18\x03 \x01(\x03R\fdeletedNodes\x12.\n" +
+	"\x13memory_before_bytes\x18\x04 \x01(\x03R\x11memoryBeforeBytes\x12,\n" +
+	"\x12memory_after_bytes\x18\x05 \x01(\x03R\x10memoryAfterBytes\x12&\n" +
+	"\x0fcompact_time_ms\x18\x06 \x01(\x02R\rcompactTimeMs\"M\n" +
+	"\x0eMigrateRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"index_type\x18\x02 \x01(\tR\tindexType\"\xa8\x02\n" +
+	"\x0fMigrateProgress\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12!\n" +
+	"\fvectors_done\x18\x02 \x01(\x03R\vvectorsDone\x12#\n" +
+	"\rvectors_total\x18\x03 \x01(\x03R\fvectorsTotal\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\x12\x1d\n" +
+	"\n" +
+	"index_type\x18\x05 \x01(\tR\tindexType\x12.\n" +
+	"\x13memory_before_bytes\x18\x06 \x01(\x03R\x11memoryBeforeBytes\x12,\n" +
+	"\x12memory_after_bytes\x18\a \x01(\x03R\x10memoryAfterBytes\x12&\n" +
+	"\x0fmigrate_time_ms\x18\b \x01(\x02R\rmigrateTimeMs\",\n" +
+	"\fCountRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x85\x01\n" +
+	"\rCountResponse\x129\n" +
+	"\x06counts\x18\x01 \x03(\v2!.vector.CountResponse.CountsEntryR\x06counts\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x17\n" +
+	"\x15ListNamespacesRequest\"8\n" +
+	"\x16ListNamespacesResponse\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x01 \x03(\tR\n" +
+	"namespaces\"\x9a\x02\n" +
+	"\x16CreateNamespaceRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\f\n" +
+	"\x01m\x18\x02 \x01(\x05R\x01m\x12'\n" +
+	"\x0fef_construction\x18\x03 \x01(\x05R\x0eefConstruction\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x04 \x01(\x05R\n" +
+	"dimensions\x12\x16\n" +
+	"\x06metric\x18\x05 \x01(\tR\x06metric\x12\x1d\n" +
+	"\n" +
+	"index_type\x18\x06 \x01(\tR\tindexType\x12)\n" +
+	"\x10quantization_min\x18\a \x01(\x02R\x0fquantizationMin\x12)\n" +
+	"\x10quantization_max\x18\b \x01(\x02R\x0fquantizationMax\"\x9b\x02\n" +
+	"\x17CreateNamespaceResponse\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\f\n" +
+	"\x01m\x18\x02 \x01(\x05R\x01m\x12'\n" +
+	"\x0fef_construction\x18\x03 \x01(\x05R\x0eefConstruction\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x04 \x01(\x05R\n" +
+	"dimensions\x12\x16\n" +
+	"\x06metric\x18\x05 \x01(\tR\x06metric\x12\x1d\n" +
+	"\n" +
+	"index_type\x18\x06 \x01(\tR\tindexType\x12)\n" +
+	"\x10quantization_min\x18\a \x01(\x02R\x0fquantizationMin\x12)\n" +
+	"\x10quantization_max\x18\b \x01(\x02R\x0fquantizationMax\"8\n" +
+	"\x18DescribeNamespaceRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\xc8\x03\n" +
+	"\x19DescribeNamespaceResponse\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"index_type\x18\x02 \x01(\tR\tindexType\x12\f\n" +
+	"\x01m\x18\x03 \x01(\x05R\x01m\x12'\n" +
+	"\x0fef_construction\x18\x04 \x01(\x05R\x0eefConstruction\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x05 \x01(\x05R\n" +
+	"dimensions\x12\x16\n" +
+	"\x06metric\x18\x06 \x01(\tR\x06metric\x12!\n" +
+	"\fvector_count\x18\a \x01(\x03R\vvectorCount\x12!\n" +
+	"\fmemory_bytes\x18\b \x01(\x03R\vmemoryBytes\x12*\n" +
+	"\x11default_ef_search\x18\t \x01(\x05R\x0fdefaultEfSearch\x12\x13\n" +
+	"\x05max_k\x18\n" +
+	" \x01(\x05R\x04maxK\x12\"\n" +
+	"\rmax_ef_search\x18\v \x01(\x05R\vmaxEfSearch\x12)\n" +
+	"\x10quantization_min\x18\f \x01(\x02R\x0fquantizationMin\x12)\n" +
+	"\x10quantization_max\x18\r \x01(\x02R\x0fquantizationMax\"4\n" +
+	"\x14DropNamespaceRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"@\n" +
+	"\x15DropNamespaceResponse\x12'\n" +
+	"\x0fvectors_dropped\x18\x01 \x01(\x03R\x0evectorsDropped\"\x14\n" +
 	"\x12HealthCheckRequest\"\xee\x01\n" +
 	"\x13HealthCheckResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
@@ -1895,15 +5361,33 @@ const file_pkg_api_grpc_proto_vector_proto_rawDesc = "" +
 	"\adetails\x18\x04 \x03(\v2(.vector.HealthCheckResponse.DetailsEntryR\adetails\x1a:\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xf9\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xc9\r\n" +
 	"\bVectorDB\x127\n" +
 	"\x06Insert\x12\x15.vector.InsertRequest\x1a\x16.vector.InsertResponse\x127\n" +
 	"\x06Search\x12\x15.vector.SearchRequest\x1a\x16.vector.SearchResponse\x12C\n" +
-	"\fHybridSearch\x12\x1b.vector.HybridSearchRequest\x1a\x16.vector.SearchResponse\x127\n" +
+	"\fHybridSearch\x12\x1b.vector.HybridSearchRequest\x1a\x16.vector.SearchResponse\x12A\n" +
+	"\vRangeSearch\x12\x1a.vector.RangeSearchRequest\x1a\x16.vector.SearchResponse\x12F\n" +
+	"\vBatchSearch\x12\x1a.vector.BatchSearchRequest\x1a\x1b.vector.BatchSearchResponse\x12M\n" +
+	"\x11MultiVectorSearch\x12 .vector.MultiVectorSearchRequest\x1a\x16.vector.SearchResponse\x124\n" +
+	"\x05Fetch\x12\x14.vector.FetchRequest\x1a\x15.vector.FetchResponse\x126\n" +
+	"\x06Export\x12\x15.vector.ExportRequest\x1a\x13.vector.ExportBatch0\x01\x121\n" +
+	"\x04Scan\x12\x13.vector.ScanRequest\x1a\x14.vector.ScanResponse\x127\n" +
 	"\x06Delete\x12\x15.vector.DeleteRequest\x1a\x16.vector.DeleteResponse\x127\n" +
 	"\x06Update\x12\x15.vector.UpdateRequest\x1a\x16.vector.UpdateResponse\x12C\n" +
-	"\vBatchInsert\x12\x15.vector.InsertRequest\x1a\x1b.vector.BatchInsertResponse(\x01\x127\n" +
-	"\bGetStats\x12\x14.vector.StatsRequest\x1a\x15.vector.StatsResponse\x12F\n" +
+	"\vBatchInsert\x12\x15.vector.InsertRequest\x1a\x1b.vector.BatchInsertResponse(\x01\x12U\n" +
+	"\x10AsyncBatchInsert\x12\x1f.vector.AsyncBatchInsertRequest\x1a .vector.AsyncBatchInsertResponse\x12C\n" +
+	"\fGetJobStatus\x12\x18.vector.JobStatusRequest\x1a\x19.vector.JobStatusResponse\x127\n" +
+	"\bGetStats\x12\x14.vector.StatsRequest\x1a\x15.vector.StatsResponse\x12=\n" +
+	"\bValidate\x12\x17.vector.ValidateRequest\x1a\x18.vector.ValidateResponse\x12?\n" +
+	"\bSnapshot\x12\x17.vector.SnapshotRequest\x1a\x18.vector.SnapshotProgress0\x01\x12:\n" +
+	"\aRestore\x12\x16.vector.RestoreRequest\x1a\x17.vector.RestoreResponse\x12:\n" +
+	"\aCompact\x12\x16.vector.CompactRequest\x1a\x17.vector.CompactResponse\x12<\n" +
+	"\aMigrate\x12\x16.vector.MigrateRequest\x1a\x17.vector.MigrateProgress0\x01\x124\n" +
+	"\x05Count\x12\x14.vector.CountRequest\x1a\x15.vector.CountResponse\x12O\n" +
+	"\x0eListNamespaces\x12\x1d.vector.ListNamespacesRequest\x1a\x1e.vector.ListNamespacesResponse\x12R\n" +
+	"\x0fCreateNamespace\x12\x1e.vector.CreateNamespaceRequest\x1a\x1f.vector.CreateNamespaceResponse\x12X\n" +
+	"\x11DescribeNamespace\x12 .vector.DescribeNamespaceRequest\x1a!.vector.DescribeNamespaceResponse\x12L\n" +
+	"\rDropNamespace\x12\x1c.vector.DropNamespaceRequest\x1a\x1d.vector.DropNamespaceResponse\x12F\n" +
 	"\vHealthCheck\x12\x1a.vector.HealthCheckRequest\x1a\x1b.vector.HealthCheckResponseB@Z>github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/protob\x06proto3"
 
 var (
@@ -1918,78 +5402,183 @@ func file_pkg_api_grpc_proto_vector_proto_rawDescGZIP() []byte {
 	return file_pkg_api_grpc_proto_vector_proto_rawDescData
 }
 
-var file_pkg_api_grpc_proto_vector_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_pkg_api_grpc_proto_vector_proto_msgTypes = make([]protoimpl.MessageInfo, 76)
 var file_pkg_api_grpc_proto_vector_proto_goTypes = []any{
-	(*InsertRequest)(nil),       // 0: vector.InsertRequest
-	(*InsertResponse)(nil),      // 1: vector.InsertResponse
-	(*SearchRequest)(nil),       // 2: vector.SearchRequest
-	(*HybridSearchRequest)(nil), // 3: vector.HybridSearchRequest
-	(*HybridSearchConfig)(nil),  // 4: vector.HybridSearchConfig
-	(*SearchResponse)(nil),      // 5: vector.SearchResponse
-	(*SearchResult)(nil),        // 6: vector.SearchResult
-	(*DeleteRequest)(nil),       // 7: vector.DeleteRequest
-	(*DeleteResponse)(nil),      // 8: vector.DeleteResponse
-	(*UpdateRequest)(nil),       // 9: vector.UpdateRequest
-	(*UpdateResponse)(nil),      // 10: vector.UpdateResponse
-	(*BatchInsertResponse)(nil), // 11: vector.BatchInsertResponse
-	(*Filter)(nil),              // 12: vector.Filter
-	(*ComparisonFilter)(nil),    // 13: vector.ComparisonFilter
-	(*RangeFilter)(nil),         // 14: vector.RangeFilter
-	(*ListFilter)(nil),          // 15: vector.ListFilter
-	(*GeoRadiusFilter)(nil),     // 16: vector.GeoRadiusFilter
-	(*ExistsFilter)(nil),        // 17: vector.ExistsFilter
-	(*CompositeFilter)(nil),     // 18: vector.CompositeFilter
-	(*StatsRequest)(nil),        // 19: vector.StatsRequest
-	(*StatsResponse)(nil),       // 20: vector.StatsResponse
-	(*NamespaceStats)(nil),      // 21: vector.NamespaceStats
-	(*HealthCheckRequest)(nil),  // 22: vector.HealthCheckRequest
-	(*HealthCheckResponse)(nil), // 23: vector.HealthCheckResponse
-	nil,                         // 24: vector.InsertRequest.MetadataEntry
-	nil,                         // 25: vector.SearchResult.MetadataEntry
-	nil,                         // 26: vector.UpdateRequest.MetadataEntry
-	nil,                         // 27: vector.StatsResponse.NamespaceStatsEntry
-	nil,                         // 28: vector.HealthCheckResponse.DetailsEntry
+	(*InsertRequest)(nil),             // 0: vector.InsertRequest
+	(*MetadataValue)(nil),             // 1: vector.MetadataValue
+	(*InsertResponse)(nil),            // 2: vector.InsertResponse
+	(*SearchRequest)(nil),             // 3: vector.SearchRequest
+	(*RangeSearchRequest)(nil),        // 4: vector.RangeSearchRequest
+	(*BatchSearchRequest)(nil),        // 5: vector.BatchSearchRequest
+	(*QueryVector)(nil),               // 6: vector.QueryVector
+	(*MultiVectorSearchRequest)(nil),  // 7: vector.MultiVectorSearchRequest
+	(*BatchSearchResponse)(nil),       // 8: vector.BatchSearchResponse
+	(*HybridSearchRequest)(nil),       // 9: vector.HybridSearchRequest
+	(*HybridSearchConfig)(nil),        // 10: vector.HybridSearchConfig
+	(*SearchResponse)(nil),            // 11: vector.SearchResponse
+	(*SearchProfile)(nil),             // 12: vector.SearchProfile
+	(*ProfileSpan)(nil),               // 13: vector.ProfileSpan
+	(*SearchResult)(nil),              // 14: vector.SearchResult
+	(*FetchRequest)(nil),              // 15: vector.FetchRequest
+	(*FetchResult)(nil),               // 16: vector.FetchResult
+	(*FetchResponse)(nil),             // 17: vector.FetchResponse
+	(*ExportRequest)(nil),             // 18: vector.ExportRequest
+	(*ExportBatch)(nil),               // 19: vector.ExportBatch
+	(*ScanRequest)(nil),               // 20: vector.ScanRequest
+	(*ScanResponse)(nil),              // 21: vector.ScanResponse
+	(*DeleteRequest)(nil),             // 22: vector.DeleteRequest
+	(*DeleteResponse)(nil),            // 23: vector.DeleteResponse
+	(*UpdateRequest)(nil),             // 24: vector.UpdateRequest
+	(*UpdateResponse)(nil),            // 25: vector.UpdateResponse
+	(*BatchInsertResponse)(nil),       // 26: vector.BatchInsertResponse
+	(*AsyncBatchInsertRequest)(nil),   // 27: vector.AsyncBatchInsertRequest
+	(*AsyncBatchInsertResponse)(nil),  // 28: vector.AsyncBatchInsertResponse
+	(*JobStatusRequest)(nil),          // 29: vector.JobStatusRequest
+	(*JobStatusResponse)(nil),         // 30: vector.JobStatusResponse
+	(*Filter)(nil),                    // 31: vector.Filter
+	(*ComparisonFilter)(nil),          // 32: vector.ComparisonFilter
+	(*RangeFilter)(nil),               // 33: vector.RangeFilter
+	(*ListFilter)(nil),                // 34: vector.ListFilter
+	(*GeoRadiusFilter)(nil),           // 35: vector.GeoRadiusFilter
+	(*GeoBoundingBoxFilter)(nil),      // 36: vector.GeoBoundingBoxFilter
+	(*ExistsFilter)(nil),              // 37: vector.ExistsFilter
+	(*CompositeFilter)(nil),           // 38: vector.CompositeFilter
+	(*StatsRequest)(nil),              // 39: vector.StatsRequest
+	(*StatsResponse)(nil),             // 40: vector.StatsResponse
+	(*NamespaceStats)(nil),            // 41: vector.NamespaceStats
+	(*LayerStats)(nil),                // 42: vector.LayerStats
+	(*ValidateRequest)(nil),           // 43: vector.ValidateRequest
+	(*ValidateResponse)(nil),          // 44: vector.ValidateResponse
+	(*SnapshotRequest)(nil),           // 45: vector.SnapshotRequest
+	(*SnapshotProgress)(nil),          // 46: vector.SnapshotProgress
+	(*RestoreRequest)(nil),            // 47: vector.RestoreRequest
+	(*RestoreResponse)(nil),           // 48: vector.RestoreResponse
+	(*CompactRequest)(nil),            // 49: vector.CompactRequest
+	(*CompactResponse)(nil),           // 50: vector.CompactResponse
+	(*MigrateRequest)(nil),            // 51: vector.MigrateRequest
+	(*MigrateProgress)(nil),           // 52: vector.MigrateProgress
+	(*CountRequest)(nil),              // 53: vector.CountRequest
+	(*CountResponse)(nil),             // 54: vector.CountResponse
+	(*ListNamespacesRequest)(nil),     // 55: vector.ListNamespacesRequest
+	(*ListNamespacesResponse)(nil),    // 56: vector.ListNamespacesResponse
+	(*CreateNamespaceRequest)(nil),    // 57: vector.CreateNamespaceRequest
+	(*CreateNamespaceResponse)(nil),   // 58: vector.CreateNamespaceResponse
+	(*DescribeNamespaceRequest)(nil),  // 59: vector.DescribeNamespaceRequest
+	(*DescribeNamespaceResponse)(nil), // 60: vector.DescribeNamespaceResponse
+	(*DropNamespaceRequest)(nil),      // 61: vector.DropNamespaceRequest
+	(*DropNamespaceResponse)(nil),     // 62: vector.DropNamespaceResponse
+	(*HealthCheckRequest)(nil),        // 63: vector.HealthCheckRequest
+	(*HealthCheckResponse)(nil),       // 64: vector.HealthCheckResponse
+	nil,                               // 65: vector.InsertRequest.MetadataEntry
+	nil,                               // 66: vector.InsertRequest.TypedMetadataEntry
+	nil,                               // 67: vector.SearchResult.MetadataEntry
+	nil,                               // 68: vector.SearchResult.TypedMetadataEntry
+	nil,                               // 69: vector.FetchResult.MetadataEntry
+	nil,                               // 70: vector.FetchResult.TypedMetadataEntry
+	nil,                               // 71: vector.UpdateRequest.MetadataEntry
+	nil,                               // 72: vector.UpdateRequest.TypedMetadataEntry
+	nil,                               // 73: vector.StatsResponse.NamespaceStatsEntry
+	nil,                               // 74: vector.CountResponse.CountsEntry
+	nil,                               // 75: vector.HealthCheckResponse.DetailsEntry
 }
 var file_pkg_api_grpc_proto_vector_proto_depIdxs = []int32{
-	24, // 0: vector.InsertRequest.metadata:type_name -> vector.InsertRequest.MetadataEntry
-	12, // 1: vector.SearchRequest.filter:type_name -> vector.Filter
-	12, // 2: vector.HybridSearchRequest.filter:type_name -> vector.Filter
-	4,  // 3: vector.HybridSearchRequest.config:type_name -> vector.HybridSearchConfig
-	6,  // 4: vector.SearchResponse.results:type_name -> vector.SearchResult
-	25, // 5: vector.SearchResult.metadata:type_name -> vector.SearchResult.MetadataEntry
-	12, // 6: vector.DeleteRequest.filter:type_name -> vector.Filter
-	26, // 7: vector.UpdateRequest.metadata:type_name -> vector.UpdateRequest.MetadataEntry
-	13, // 8: vector.Filter.comparison:type_name -> vector.ComparisonFilter
-	14, // 9: vector.Filter.range:type_name -> vector.RangeFilter
-	15, // 10: vector.Filter.list:type_name -> vector.ListFilter
-	16, // 11: vector.Filter.geo_radius:type_name -> vector.GeoRadiusFilter
-	17, // 12: vector.Filter.exists:type_name -> vector.ExistsFilter
-	18, // 13: vector.Filter.composite:type_name -> vector.CompositeFilter
-	12, // 14: vector.CompositeFilter.filters:type_name -> vector.Filter
-	27, // 15: vector.StatsResponse.namespace_stats:type_name -> vector.StatsResponse.NamespaceStatsEntry
-	28, // 16: vector.HealthCheckResponse.details:type_name -> vector.HealthCheckResponse.DetailsEntry
-	21, // 17: vector.StatsResponse.NamespaceStatsEntry.value:type_name -> vector.NamespaceStats
-	0,  // 18: vector.VectorDB.Insert:input_type -> vector.InsertRequest
-	2,  // 19: vector.VectorDB.Search:input_type -> vector.SearchRequest
-	3,  // 20: vector.VectorDB.HybridSearch:input_type -> vector.HybridSearchRequest
-	7,  // 21: vector.VectorDB.Delete:input_type -> vector.DeleteRequest
-	9,  // 22: vector.VectorDB.Update:input_type -> vector.UpdateRequest
-	0,  // 23: vector.VectorDB.BatchInsert:input_type -> vector.InsertRequest
-	19, // 24: vector.VectorDB.GetStats:input_type -> vector.StatsRequest
-	22, // 25: vector.VectorDB.HealthCheck:input_type -> vector.HealthCheckRequest
-	1,  // 26: vector.VectorDB.Insert:output_type -> vector.InsertResponse
-	5,  // 27: vector.VectorDB.Search:output_type -> vector.SearchResponse
-	5,  // 28: vector.VectorDB.HybridSearch:output_type -> vector.SearchResponse
-	8,  // 29: vector.VectorDB.Delete:output_type -> vector.DeleteResponse
-	10, // 30: vector.VectorDB.Update:output_type -> vector.UpdateResponse
-	11, // 31: vector.VectorDB.BatchInsert:output_type -> vector.BatchInsertResponse
-	20, // 32: vector.VectorDB.GetStats:output_type -> vector.StatsResponse
-	23, // 33: vector.VectorDB.HealthCheck:output_type -> vector.HealthCheckResponse
-	26, // [26:34] is the sub-list for method output_type
-	18, // [18:26] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	65, // 0: vector.InsertRequest.metadata:type_name -> vector.InsertRequest.MetadataEntry
+	66, // 1: vector.InsertRequest.typed_metadata:type_name -> vector.InsertRequest.TypedMetadataEntry
+	31, // 2: vector.SearchRequest.filter:type_name -> vector.Filter
+	6,  // 3: vector.BatchSearchRequest.queries:type_name -> vector.QueryVector
+	31, // 4: vector.BatchSearchRequest.filter:type_name -> vector.Filter
+	6,  // 5: vector.MultiVectorSearchRequest.query_vectors:type_name -> vector.QueryVector
+	11, // 6: vector.BatchSearchResponse.responses:type_name -> vector.SearchResponse
+	31, // 7: vector.HybridSearchRequest.filter:type_name -> vector.Filter
+	10, // 8: vector.HybridSearchRequest.config:type_name -> vector.HybridSearchConfig
+	14, // 9: vector.SearchResponse.results:type_name -> vector.SearchResult
+	12, // 10: vector.SearchResponse.profile:type_name -> vector.SearchProfile
+	13, // 11: vector.SearchProfile.spans:type_name -> vector.ProfileSpan
+	67, // 12: vector.SearchResult.metadata:type_name -> vector.SearchResult.MetadataEntry
+	68, // 13: vector.SearchResult.typed_metadata:type_name -> vector.SearchResult.TypedMetadataEntry
+	69, // 14: vector.FetchResult.metadata:type_name -> vector.FetchResult.MetadataEntry
+	70, // 15: vector.FetchResult.typed_metadata:type_name -> vector.FetchResult.TypedMetadataEntry
+	16, // 16: vector.FetchResponse.results:type_name -> vector.FetchResult
+	16, // 17: vector.ExportBatch.vectors:type_name -> vector.FetchResult
+	16, // 18: vector.ScanResponse.results:type_name -> vector.FetchResult
+	31, // 19: vector.DeleteRequest.filter:type_name -> vector.Filter
+	71, // 20: vector.UpdateRequest.metadata:type_name -> vector.UpdateRequest.MetadataEntry
+	72, // 21: vector.UpdateRequest.typed_metadata:type_name -> vector.UpdateRequest.TypedMetadataEntry
+	0,  // 22: vector.AsyncBatchInsertRequest.items:type_name -> vector.InsertRequest
+	32, // 23: vector.Filter.comparison:type_name -> vector.ComparisonFilter
+	33, // 24: vector.Filter.range:type_name -> vector.RangeFilter
+	34, // 25: vector.Filter.list:type_name -> vector.ListFilter
+	35, // 26: vector.Filter.geo_radius:type_name -> vector.GeoRadiusFilter
+	37, // 27: vector.Filter.exists:type_name -> vector.ExistsFilter
+	38, // 28: vector.Filter.composite:type_name -> vector.CompositeFilter
+	36, // 29: vector.Filter.geo_bounding_box:type_name -> vector.GeoBoundingBoxFilter
+	31, // 30: vector.CompositeFilter.filters:type_name -> vector.Filter
+	73, // 31: vector.StatsResponse.namespace_stats:type_name -> vector.StatsResponse.NamespaceStatsEntry
+	42, // 32: vector.NamespaceStats.layers:type_name -> vector.LayerStats
+	74, // 33: vector.CountResponse.counts:type_name -> vector.CountResponse.CountsEntry
+	75, // 34: vector.HealthCheckResponse.details:type_name -> vector.HealthCheckResponse.DetailsEntry
+	1,  // 35: vector.InsertRequest.TypedMetadataEntry.value:type_name -> vector.MetadataValue
+	1,  // 36: vector.SearchResult.TypedMetadataEntry.value:type_name -> vector.MetadataValue
+	1,  // 37: vector.FetchResult.TypedMetadataEntry.value:type_name -> vector.MetadataValue
+	1,  // 38: vector.UpdateRequest.TypedMetadataEntry.value:type_name -> vector.MetadataValue
+	41, // 39: vector.StatsResponse.NamespaceStatsEntry.value:type_name -> vector.NamespaceStats
+	0,  // 40: vector.VectorDB.Insert:input_type -> vector.InsertRequest
+	3,  // 41: vector.VectorDB.Search:input_type -> vector.SearchRequest
+	9,  // 42: vector.VectorDB.HybridSearch:input_type -> vector.HybridSearchRequest
+	4,  // 43: vector.VectorDB.RangeSearch:input_type -> vector.RangeSearchRequest
+	5,  // 44: vector.VectorDB.BatchSearch:input_type -> vector.BatchSearchRequest
+	7,  // 45: vector.VectorDB.MultiVectorSearch:input_type -> vector.MultiVectorSearchRequest
+	15, // 46: vector.VectorDB.Fetch:input_type -> vector.FetchRequest
+	18, // 47: vector.VectorDB.Export:input_type -> vector.ExportRequest
+	20, // 48: vector.VectorDB.Scan:input_type -> vector.ScanRequest
+	22, // 49: vector.VectorDB.Delete:input_type -> vector.DeleteRequest
+	24, // 50: vector.VectorDB.Update:input_type -> vector.UpdateRequest
+	0,  // 51: vector.VectorDB.BatchInsert:input_type -> vector.InsertRequest
+	27, // 52: vector.VectorDB.AsyncBatchInsert:input_type -> vector.AsyncBatchInsertRequest
+	29, // 53: vector.VectorDB.GetJobStatus:input_type -> vector.JobStatusRequest
+	39, // 54: vector.VectorDB.GetStats:input_type -> vector.StatsRequest
+	43, // 55: vector.VectorDB.Validate:input_type -> vector.ValidateRequest
+	45, // 56: vector.VectorDB.Snapshot:input_type -> vector.SnapshotRequest
+	47, // 57: vector.VectorDB.Restore:input_type -> vector.RestoreRequest
+	49, // 58: vector.VectorDB.Compact:input_type -> vector.CompactRequest
+	51, // 59: vector.VectorDB.Migrate:input_type -> vector.MigrateRequest
+	53, // 60: vector.VectorDB.Count:input_type -> vector.CountRequest
+	55, // 61: vector.VectorDB.ListNamespaces:input_type -> vector.ListNamespacesRequest
+	57, // 62: vector.VectorDB.CreateNamespace:input_type -> vector.CreateNamespaceRequest
+	59, // 63: vector.VectorDB.DescribeNamespace:input_type -> vector.DescribeNamespaceRequest
+	61, // 64: vector.VectorDB.DropNamespace:input_type -> vector.DropNamespaceRequest
+	63, // 65: vector.VectorDB.HealthCheck:input_type -> vector.HealthCheckRequest
+	2,  // 66: vector.VectorDB.Insert:output_type -> vector.InsertResponse
+	11, // 67: vector.VectorDB.Search:output_type -> vector.SearchResponse
+	11, // 68: vector.VectorDB.HybridSearch:output_type -> vector.SearchResponse
+	11, // 69: vector.VectorDB.RangeSearch:output_type -> vector.SearchResponse
+	8,  // 70: vector.VectorDB.BatchSearch:output_type -> vector.BatchSearchResponse
+	11, // 71: vector.VectorDB.MultiVectorSearch:output_type -> vector.SearchResponse
+	17, // 72: vector.VectorDB.Fetch:output_type -> vector.FetchResponse
+	19, // 73: vector.VectorDB.Export:output_type -> vector.ExportBatch
+	21, // 74: vector.VectorDB.Scan:output_type -> vector.ScanResponse
+	23, // 75: vector.VectorDB.Delete:output_type -> vector.DeleteResponse
+	25, // 76: vector.VectorDB.Update:output_type -> vector.UpdateResponse
+	26, // 77: vector.VectorDB.BatchInsert:output_type -> vector.BatchInsertResponse
+	28, // 78: vector.VectorDB.AsyncBatchInsert:output_type -> vector.AsyncBatchInsertResponse
+	30, // 79: vector.VectorDB.GetJobStatus:output_type -> vector.JobStatusResponse
+	40, // 80: vector.VectorDB.GetStats:output_type -> vector.StatsResponse
+	44, // 81: vector.VectorDB.Validate:output_type -> vector.ValidateResponse
+	46, // 82: vector.VectorDB.Snapshot:output_type -> vector.SnapshotProgress
+	48, // 83: vector.VectorDB.Restore:output_type -> vector.RestoreResponse
+	50, // 84: vector.VectorDB.Compact:output_type -> vector.CompactResponse
+	52, // 85: vector.VectorDB.Migrate:output_type -> vector.MigrateProgress
+	54, // 86: vector.VectorDB.Count:output_type -> vector.CountResponse
+	56, // 87: vector.VectorDB.ListNamespaces:output_type -> vector.ListNamespacesResponse
+	58, // 88: vector.VectorDB.CreateNamespace:output_type -> vector.CreateNamespaceResponse
+	60, // 89: vector.VectorDB.DescribeNamespace:output_type -> vector.DescribeNamespaceResponse
+	62, // 90: vector.VectorDB.DropNamespace:output_type -> vector.DropNamespaceResponse
+	64, // 91: vector.VectorDB.HealthCheck:output_type -> vector.HealthCheckResponse
+	66, // [66:92] is the sub-list for method output_type
+	40, // [40:66] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_grpc_proto_vector_proto_init() }
@@ -2002,31 +5591,38 @@ func file_pkg_api_grpc_proto_vector_proto_init() {
 	file_pkg_api_grpc_proto_vector_proto_msgTypes[2].OneofWrappers = []any{}
 	file_pkg_api_grpc_proto_vector_proto_msgTypes[3].OneofWrappers = []any{}
 	file_pkg_api_grpc_proto_vector_proto_msgTypes[5].OneofWrappers = []any{}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[6].OneofWrappers = []any{}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[7].OneofWrappers = []any{
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[7].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[9].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[11].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[14].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[16].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[22].OneofWrappers = []any{
 		(*DeleteRequest_Id)(nil),
 		(*DeleteRequest_Filter)(nil),
 	}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[8].OneofWrappers = []any{}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[9].OneofWrappers = []any{}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[10].OneofWrappers = []any{}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[12].OneofWrappers = []any{
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[23].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[24].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[25].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[28].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[30].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[31].OneofWrappers = []any{
 		(*Filter_Comparison)(nil),
 		(*Filter_Range)(nil),
 		(*Filter_List)(nil),
 		(*Filter_GeoRadius)(nil),
 		(*Filter_Exists)(nil),
 		(*Filter_Composite)(nil),
+		(*Filter_GeoBoundingBox)(nil),
 	}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[14].OneofWrappers = []any{}
-	file_pkg_api_grpc_proto_vector_proto_msgTypes[19].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[33].OneofWrappers = []any{}
+	file_pkg_api_grpc_proto_vector_proto_msgTypes[39].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_grpc_proto_vector_proto_rawDesc), len(file_pkg_api_grpc_proto_vector_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   76,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
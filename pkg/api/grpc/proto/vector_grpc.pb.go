@@ -19,14 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VectorDB_Insert_FullMethodName       = "/vector.VectorDB/Insert"
-	VectorDB_Search_FullMethodName       = "/vector.VectorDB/Search"
-	VectorDB_HybridSearch_FullMethodName = "/vector.VectorDB/HybridSearch"
-	VectorDB_Delete_FullMethodName       = "/vector.VectorDB/Delete"
-	VectorDB_Update_FullMethodName       = "/vector.VectorDB/Update"
-	VectorDB_BatchInsert_FullMethodName  = "/vector.VectorDB/BatchInsert"
-	VectorDB_GetStats_FullMethodName     = "/vector.VectorDB/GetStats"
-	VectorDB_HealthCheck_FullMethodName  = "/vector.VectorDB/HealthCheck"
+	VectorDB_Insert_FullMethodName            = "/vector.VectorDB/Insert"
+	VectorDB_Search_FullMethodName            = "/vector.VectorDB/Search"
+	VectorDB_HybridSearch_FullMethodName      = "/vector.VectorDB/HybridSearch"
+	VectorDB_RangeSearch_FullMethodName       = "/vector.VectorDB/RangeSearch"
+	VectorDB_BatchSearch_FullMethodName       = "/vector.VectorDB/BatchSearch"
+	VectorDB_MultiVectorSearch_FullMethodName = "/vector.VectorDB/MultiVectorSearch"
+	VectorDB_Fetch_FullMethodName             = "/vector.VectorDB/Fetch"
+	VectorDB_Export_FullMethodName            = "/vector.VectorDB/Export"
+	VectorDB_Scan_FullMethodName              = "/vector.VectorDB/Scan"
+	VectorDB_Delete_FullMethodName            = "/vector.VectorDB/Delete"
+	VectorDB_Update_FullMethodName            = "/vector.VectorDB/Update"
+	VectorDB_BatchInsert_FullMethodName       = "/vector.VectorDB/BatchInsert"
+	VectorDB_AsyncBatchInsert_FullMethodName  = "/vector.VectorDB/AsyncBatchInsert"
+	VectorDB_GetJobStatus_FullMethodName      = "/vector.VectorDB/GetJobStatus"
+	VectorDB_GetStats_FullMethodName          = "/vector.VectorDB/GetStats"
+	VectorDB_Validate_FullMethodName          = "/vector.VectorDB/Validate"
+	VectorDB_Snapshot_FullMethodName          = "/vector.VectorDB/Snapshot"
+	VectorDB_Restore_FullMethodName           = "/vector.VectorDB/Restore"
+	VectorDB_Compact_FullMethodName           = "/vector.VectorDB/Compact"
+	VectorDB_Migrate_FullMethodName           = "/vector.VectorDB/Migrate"
+	VectorDB_Count_FullMethodName             = "/vector.VectorDB/Count"
+	VectorDB_ListNamespaces_FullMethodName    = "/vector.VectorDB/ListNamespaces"
+	VectorDB_CreateNamespace_FullMethodName   = "/vector.VectorDB/CreateNamespace"
+	VectorDB_DescribeNamespace_FullMethodName = "/vector.VectorDB/DescribeNamespace"
+	VectorDB_DropNamespace_FullMethodName     = "/vector.VectorDB/DropNamespace"
+	VectorDB_HealthCheck_FullMethodName       = "/vector.VectorDB/HealthCheck"
 )
 
 // VectorDBClient is the client API for VectorDB service.
@@ -41,14 +59,61 @@ type VectorDBClient interface {
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	// HybridSearch combines vector similarity and full-text search
 	HybridSearch(ctx context.Context, in *HybridSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// RangeSearch returns every vector within a distance threshold
+	RangeSearch(ctx context.Context, in *RangeSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// BatchSearch runs many k-NN queries against one namespace in a single call
+	BatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (*BatchSearchResponse, error)
+	// MultiVectorSearch scores documents against several query vectors by max-sim (late interaction)
+	MultiVectorSearch(ctx context.Context, in *MultiVectorSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Fetch returns the stored vector, metadata and text for one or more IDs
+	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
+	// Export streams every vector in a namespace with its metadata and text,
+	// in ascending ID order, a batch per message. Each batch carries a cursor
+	// from which an interrupted export resumes.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBatch], error)
+	// Scan returns one page of a namespace's vectors in ascending ID order,
+	// with a cursor for the next page
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error)
 	// Delete a vector by ID
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	// Update an existing vector
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	// BatchInsert inserts multiple vectors efficiently
 	BatchInsert(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[InsertRequest, BatchInsertResponse], error)
+	// AsyncBatchInsert queues vectors for insertion by a background job and
+	// returns its ID immediately
+	AsyncBatchInsert(ctx context.Context, in *AsyncBatchInsertRequest, opts ...grpc.CallOption) (*AsyncBatchInsertResponse, error)
+	// GetJobStatus reports the progress of an AsyncBatchInsert job
+	GetJobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusResponse, error)
 	// GetStats returns database statistics
 	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// Validate checks a namespace's HNSW graph for structural problems (admin)
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Snapshot writes every namespace to a single file under the data
+	// directory, streaming progress as namespaces are written (admin)
+	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotProgress], error)
+	// Restore loads a snapshot file into new namespaces (admin)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	// Compact rebuilds a namespace's graph from its live vectors, reclaiming
+	// the memory and link quality lost to deletes (admin)
+	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
+	// Migrate rebuilds a namespace's index as another index type and swaps
+	// it in, streaming progress; cancelling the call cancels the migration
+	// (admin)
+	Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MigrateProgress], error)
+	// Count returns the number of vectors in one namespace or in each
+	// namespace, without the cost of GetStats
+	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error)
+	// ListNamespaces returns the names of all namespaces
+	ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error)
+	// CreateNamespace creates a namespace with its own HNSW parameters,
+	// dimension and metric; it fails once the namespace holds vectors
+	CreateNamespace(ctx context.Context, in *CreateNamespaceRequest, opts ...grpc.CallOption) (*CreateNamespaceResponse, error)
+	// DescribeNamespace returns a namespace's parameters and size without
+	// creating it
+	DescribeNamespace(ctx context.Context, in *DescribeNamespaceRequest, opts ...grpc.CallOption) (*DescribeNamespaceResponse, error)
+	// DropNamespace deletes a namespace and all of its vectors (admin)
+	DropNamespace(ctx context.Context, in *DropNamespaceRequest, opts ...grpc.CallOption) (*DropNamespaceResponse, error)
 	// HealthCheck returns server health status
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
@@ -91,6 +156,75 @@ func (c *vectorDBClient) HybridSearch(ctx context.Context, in *HybridSearchReque
 	return out, nil
 }
 
+func (c *vectorDBClient) RangeSearch(ctx context.Context, in *RangeSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, VectorDB_RangeSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) BatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (*BatchSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchSearchResponse)
+	err := c.cc.Invoke(ctx, VectorDB_BatchSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) MultiVectorSearch(ctx context.Context, in *MultiVectorSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, VectorDB_MultiVectorSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FetchResponse)
+	err := c.cc.Invoke(ctx, VectorDB_Fetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBatch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[0], VectorDB_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, ExportBatch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorDB_ExportClient = grpc.ServerStreamingClient[ExportBatch]
+
+func (c *vectorDBClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanResponse)
+	err := c.cc.Invoke(ctx, VectorDB_Scan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vectorDBClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteResponse)
@@ -113,7 +247,7 @@ func (c *vectorDBClient) Update(ctx context.Context, in *UpdateRequest, opts ...
 
 func (c *vectorDBClient) BatchInsert(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[InsertRequest, BatchInsertResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[0], VectorDB_BatchInsert_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[1], VectorDB_BatchInsert_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +258,26 @@ func (c *vectorDBClient) BatchInsert(ctx context.Context, opts ...grpc.CallOptio
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type VectorDB_BatchInsertClient = grpc.ClientStreamingClient[InsertRequest, BatchInsertResponse]
 
+func (c *vectorDBClient) AsyncBatchInsert(ctx context.Context, in *AsyncBatchInsertRequest, opts ...grpc.CallOption) (*AsyncBatchInsertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AsyncBatchInsertResponse)
+	err := c.cc.Invoke(ctx, VectorDB_AsyncBatchInsert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) GetJobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobStatusResponse)
+	err := c.cc.Invoke(ctx, VectorDB_GetJobStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vectorDBClient) GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StatsResponse)
@@ -134,6 +288,124 @@ func (c *vectorDBClient) GetStats(ctx context.Context, in *StatsRequest, opts ..
 	return out, nil
 }
 
+func (c *vectorDBClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, VectorDB_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[2], VectorDB_Snapshot_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SnapshotRequest, SnapshotProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorDB_SnapshotClient = grpc.ServerStreamingClient[SnapshotProgress]
+
+func (c *vectorDBClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, VectorDB_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompactResponse)
+	err := c.cc.Invoke(ctx, VectorDB_Compact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MigrateProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorDB_ServiceDesc.Streams[3], VectorDB_Migrate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MigrateRequest, MigrateProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorDB_MigrateClient = grpc.ServerStreamingClient[MigrateProgress]
+
+func (c *vectorDBClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountResponse)
+	err := c.cc.Invoke(ctx, VectorDB_Count_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNamespacesResponse)
+	err := c.cc.Invoke(ctx, VectorDB_ListNamespaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) CreateNamespace(ctx context.Context, in *CreateNamespaceRequest, opts ...grpc.CallOption) (*CreateNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateNamespaceResponse)
+	err := c.cc.Invoke(ctx, VectorDB_CreateNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) DescribeNamespace(ctx context.Context, in *DescribeNamespaceRequest, opts ...grpc.CallOption) (*DescribeNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeNamespaceResponse)
+	err := c.cc.Invoke(ctx, VectorDB_DescribeNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorDBClient) DropNamespace(ctx context.Context, in *DropNamespaceRequest, opts ...grpc.CallOption) (*DropNamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DropNamespaceResponse)
+	err := c.cc.Invoke(ctx, VectorDB_DropNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vectorDBClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthCheckResponse)
@@ -156,14 +428,61 @@ type VectorDBServer interface {
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
 	// HybridSearch combines vector similarity and full-text search
 	HybridSearch(context.Context, *HybridSearchRequest) (*SearchResponse, error)
+	// RangeSearch returns every vector within a distance threshold
+	RangeSearch(context.Context, *RangeSearchRequest) (*SearchResponse, error)
+	// BatchSearch runs many k-NN queries against one namespace in a single call
+	BatchSearch(context.Context, *BatchSearchRequest) (*BatchSearchResponse, error)
+	// MultiVectorSearch scores documents against several query vectors by max-sim (late interaction)
+	MultiVectorSearch(context.Context, *MultiVectorSearchRequest) (*SearchResponse, error)
+	// Fetch returns the stored vector, metadata and text for one or more IDs
+	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
+	// Export streams every vector in a namespace with its metadata and text,
+	// in ascending ID order, a batch per message. Each batch carries a cursor
+	// from which an interrupted export resumes.
+	Export(*ExportRequest, grpc.ServerStreamingServer[ExportBatch]) error
+	// Scan returns one page of a namespace's vectors in ascending ID order,
+	// with a cursor for the next page
+	Scan(context.Context, *ScanRequest) (*ScanResponse, error)
 	// Delete a vector by ID
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	// Update an existing vector
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	// BatchInsert inserts multiple vectors efficiently
 	BatchInsert(grpc.ClientStreamingServer[InsertRequest, BatchInsertResponse]) error
+	// AsyncBatchInsert queues vectors for insertion by a background job and
+	// returns its ID immediately
+	AsyncBatchInsert(context.Context, *AsyncBatchInsertRequest) (*AsyncBatchInsertResponse, error)
+	// GetJobStatus reports the progress of an AsyncBatchInsert job
+	GetJobStatus(context.Context, *JobStatusRequest) (*JobStatusResponse, error)
 	// GetStats returns database statistics
 	GetStats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// Validate checks a namespace's HNSW graph for structural problems (admin)
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Snapshot writes every namespace to a single file under the data
+	// directory, streaming progress as namespaces are written (admin)
+	Snapshot(*SnapshotRequest, grpc.ServerStreamingServer[SnapshotProgress]) error
+	// Restore loads a snapshot file into new namespaces (admin)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	// Compact rebuilds a namespace's graph from its live vectors, reclaiming
+	// the memory and link quality lost to deletes (admin)
+	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
+	// Migrate rebuilds a namespace's index as another index type and swaps
+	// it in, streaming progress; cancelling the call cancels the migration
+	// (admin)
+	Migrate(*MigrateRequest, grpc.ServerStreamingServer[MigrateProgress]) error
+	// Count returns the number of vectors in one namespace or in each
+	// namespace, without the cost of GetStats
+	Count(context.Context, *CountRequest) (*CountResponse, error)
+	// ListNamespaces returns the names of all namespaces
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	// CreateNamespace creates a namespace with its own HNSW parameters,
+	// dimension and metric; it fails once the namespace holds vectors
+	CreateNamespace(context.Context, *CreateNamespaceRequest) (*CreateNamespaceResponse, error)
+	// DescribeNamespace returns a namespace's parameters and size without
+	// creating it
+	DescribeNamespace(context.Context, *DescribeNamespaceRequest) (*DescribeNamespaceResponse, error)
+	// DropNamespace deletes a namespace and all of its vectors (admin)
+	DropNamespace(context.Context, *DropNamespaceRequest) (*DropNamespaceResponse, error)
 	// HealthCheck returns server health status
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	mustEmbedUnimplementedVectorDBServer()
@@ -185,6 +504,24 @@ func (UnimplementedVectorDBServer) Search(context.Context, *SearchRequest) (*Sea
 func (UnimplementedVectorDBServer) HybridSearch(context.Context, *HybridSearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HybridSearch not implemented")
 }
+func (UnimplementedVectorDBServer) RangeSearch(context.Context, *RangeSearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RangeSearch not implemented")
+}
+func (UnimplementedVectorDBServer) BatchSearch(context.Context, *BatchSearchRequest) (*BatchSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSearch not implemented")
+}
+func (UnimplementedVectorDBServer) MultiVectorSearch(context.Context, *MultiVectorSearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MultiVectorSearch not implemented")
+}
+func (UnimplementedVectorDBServer) Fetch(context.Context, *FetchRequest) (*FetchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+func (UnimplementedVectorDBServer) Export(*ExportRequest, grpc.ServerStreamingServer[ExportBatch]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedVectorDBServer) Scan(context.Context, *ScanRequest) (*ScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
 func (UnimplementedVectorDBServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
@@ -194,9 +531,45 @@ func (UnimplementedVectorDBServer) Update(context.Context, *UpdateRequest) (*Upd
 func (UnimplementedVectorDBServer) BatchInsert(grpc.ClientStreamingServer[InsertRequest, BatchInsertResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method BatchInsert not implemented")
 }
+func (UnimplementedVectorDBServer) AsyncBatchInsert(context.Context, *AsyncBatchInsertRequest) (*AsyncBatchInsertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AsyncBatchInsert not implemented")
+}
+func (UnimplementedVectorDBServer) GetJobStatus(context.Context, *JobStatusRequest) (*JobStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobStatus not implemented")
+}
 func (UnimplementedVectorDBServer) GetStats(context.Context, *StatsRequest) (*StatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedVectorDBServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedVectorDBServer) Snapshot(*SnapshotRequest, grpc.ServerStreamingServer[SnapshotProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedVectorDBServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedVectorDBServer) Compact(context.Context, *CompactRequest) (*CompactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
+func (UnimplementedVectorDBServer) Migrate(*MigrateRequest, grpc.ServerStreamingServer[MigrateProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method Migrate not implemented")
+}
+func (UnimplementedVectorDBServer) Count(context.Context, *CountRequest) (*CountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedVectorDBServer) ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNamespaces not implemented")
+}
+func (UnimplementedVectorDBServer) CreateNamespace(context.Context, *CreateNamespaceRequest) (*CreateNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNamespace not implemented")
+}
+func (UnimplementedVectorDBServer) DescribeNamespace(context.Context, *DescribeNamespaceRequest) (*DescribeNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeNamespace not implemented")
+}
+func (UnimplementedVectorDBServer) DropNamespace(context.Context, *DropNamespaceRequest) (*DropNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropNamespace not implemented")
+}
 func (UnimplementedVectorDBServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -275,6 +648,107 @@ func _VectorDB_HybridSearch_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VectorDB_RangeSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RangeSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).RangeSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_RangeSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).RangeSearch(ctx, req.(*RangeSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_BatchSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).BatchSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_BatchSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).BatchSearch(ctx, req.(*BatchSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_MultiVectorSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiVectorSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).MultiVectorSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_MultiVectorSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).MultiVectorSearch(ctx, req.(*MultiVectorSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_Fetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).Fetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_Fetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).Fetch(ctx, req.(*FetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VectorDBServer).Export(m, &grpc.GenericServerStream[ExportRequest, ExportBatch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorDB_ExportServer = grpc.ServerStreamingServer[ExportBatch]
+
+func _VectorDB_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).Scan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_Scan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).Scan(ctx, req.(*ScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VectorDB_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -318,6 +792,42 @@ func _VectorDB_BatchInsert_Handler(srv interface{}, stream grpc.ServerStream) er
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type VectorDB_BatchInsertServer = grpc.ClientStreamingServer[InsertRequest, BatchInsertResponse]
 
+func _VectorDB_AsyncBatchInsert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AsyncBatchInsertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).AsyncBatchInsert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_AsyncBatchInsert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).AsyncBatchInsert(ctx, req.(*AsyncBatchInsertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_GetJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).GetJobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_GetJobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).GetJobStatus(ctx, req.(*JobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VectorDB_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatsRequest)
 	if err := dec(in); err != nil {
@@ -336,6 +846,172 @@ func _VectorDB_GetStats_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VectorDB_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_Snapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VectorDBServer).Snapshot(m, &grpc.GenericServerStream[SnapshotRequest, SnapshotProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorDB_SnapshotServer = grpc.ServerStreamingServer[SnapshotProgress]
+
+func _VectorDB_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_Compact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).Compact(ctx, req.(*CompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_Migrate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MigrateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VectorDBServer).Migrate(m, &grpc.GenericServerStream[MigrateRequest, MigrateProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorDB_MigrateServer = grpc.ServerStreamingServer[MigrateProgress]
+
+func _VectorDB_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).Count(ctx, req.(*CountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).ListNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_ListNamespaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_CreateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).CreateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_CreateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).CreateNamespace(ctx, req.(*CreateNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_DescribeNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).DescribeNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_DescribeNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).DescribeNamespace(ctx, req.(*DescribeNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorDB_DropNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorDBServer).DropNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorDB_DropNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorDBServer).DropNamespace(ctx, req.(*DropNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VectorDB_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -373,6 +1049,26 @@ var VectorDB_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HybridSearch",
 			Handler:    _VectorDB_HybridSearch_Handler,
 		},
+		{
+			MethodName: "RangeSearch",
+			Handler:    _VectorDB_RangeSearch_Handler,
+		},
+		{
+			MethodName: "BatchSearch",
+			Handler:    _VectorDB_BatchSearch_Handler,
+		},
+		{
+			MethodName: "MultiVectorSearch",
+			Handler:    _VectorDB_MultiVectorSearch_Handler,
+		},
+		{
+			MethodName: "Fetch",
+			Handler:    _VectorDB_Fetch_Handler,
+		},
+		{
+			MethodName: "Scan",
+			Handler:    _VectorDB_Scan_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _VectorDB_Delete_Handler,
@@ -381,21 +1077,76 @@ var VectorDB_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _VectorDB_Update_Handler,
 		},
+		{
+			MethodName: "AsyncBatchInsert",
+			Handler:    _VectorDB_AsyncBatchInsert_Handler,
+		},
+		{
+			MethodName: "GetJobStatus",
+			Handler:    _VectorDB_GetJobStatus_Handler,
+		},
 		{
 			MethodName: "GetStats",
 			Handler:    _VectorDB_GetStats_Handler,
 		},
+		{
+			MethodName: "Validate",
+			Handler:    _VectorDB_Validate_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _VectorDB_Restore_Handler,
+		},
+		{
+			MethodName: "Compact",
+			Handler:    _VectorDB_Compact_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _VectorDB_Count_Handler,
+		},
+		{
+			MethodName: "ListNamespaces",
+			Handler:    _VectorDB_ListNamespaces_Handler,
+		},
+		{
+			MethodName: "CreateNamespace",
+			Handler:    _VectorDB_CreateNamespace_Handler,
+		},
+		{
+			MethodName: "DescribeNamespace",
+			Handler:    _VectorDB_DescribeNamespace_Handler,
+		},
+		{
+			MethodName: "DropNamespace",
+			Handler:    _VectorDB_DropNamespace_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _VectorDB_HealthCheck_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _VectorDB_Export_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "BatchInsert",
 			Handler:       _VectorDB_BatchInsert_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Snapshot",
+			Handler:       _VectorDB_Snapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Migrate",
+			Handler:       _VectorDB_Migrate_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pkg/api/grpc/proto/vector.proto",
 }
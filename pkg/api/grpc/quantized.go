@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+)
+
+// Quantized vector encodings accepted on Insert and Search
+const (
+	QuantizationInt8  = "int8"  // One signed byte per dimension
+	QuantizationUint8 = "uint8" // One unsigned byte per dimension
+)
+
+// SetQuantizationRange sets the float range quantized vectors in a namespace
+// map back to: int8 codes [-127, 127] and uint8 codes [0, 255] both span
+// [min, max]. Without a range, codes are used as their integer values.
+// Servers set ranges from HNSW.Quantization in the config, and clients
+// through CreateNamespace, which logs the range in the WAL.
+func (s *Server) SetQuantizationRange(namespace string, min, max float32) error {
+	if !(max > min) {
+		return fmt.Errorf("invalid quantization range: [%v, %v]", min, max)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quantizers[namespace] = newRangeQuantizer(min, max)
+	return nil
+}
+
+// newRangeQuantizer returns a quantizer mapping codes onto [min, max]
+func newRangeQuantizer(min, max float32) *quantization.ScalarQuantizer {
+	// Same parameters ScalarQuantizer.Train derives from data
+	scale := 254.0 / (max - min)
+	offset := -127.0 - min*scale
+
+	q := quantization.NewScalarQuantizer()
+	q.SetParameters(min, max, scale, offset)
+	return q
+}
+
+// quantizationRangeLocked returns the float range set for a namespace's
+// quantized vectors, or zeros when none is set; the caller holds s.mu
+func (s *Server) quantizationRangeLocked(namespace string) (float32, float32) {
+	q := s.quantizers[namespace]
+	if q == nil {
+		return 0, 0
+	}
+	min, max, _, _ := q.GetParameters()
+	return min, max
+}
+
+// dequantize decodes a quantized vector into float32 values
+func (s *Server) dequantize(namespace, encoding string, data []byte) ([]float32, error) {
+	s.mu.RLock()
+	q := s.quantizers[namespace]
+	s.mu.RUnlock()
+
+	switch encoding {
+	case QuantizationInt8:
+		codes := make([]int8, len(data))
+		for i, b := range data {
+			codes[i] = int8(b)
+		}
+		if q != nil {
+			return q.Dequantize(codes), nil
+		}
+		vector := make([]float32, len(codes))
+		for i, c := range codes {
+			vector[i] = float32(c)
+		}
+		return vector, nil
+
+	case QuantizationUint8:
+		vector := make([]float32, len(data))
+		if q != nil {
+			min, max, _, _ := q.GetParameters()
+			step := (max - min) / 255
+			for i, b := range data {
+				vector[i] = min + float32(b)*step
+			}
+			return vector, nil
+		}
+		for i, b := range data {
+			vector[i] = float32(b)
+		}
+		return vector, nil
+
+	case "":
+		return nil, fmt.Errorf("quantization is required with a quantized vector (int8 or uint8)")
+	default:
+		return nil, fmt.Errorf("unsupported quantization %q (expected int8 or uint8)", encoding)
+	}
+}
+
+// resolveInsertVector replaces the float vector with the dequantized one
+// when the request carries a quantized vector
+func (s *Server) resolveInsertVector(req *proto.InsertRequest) error {
+	if len(req.QuantizedVector) == 0 {
+		return nil
+	}
+
+	vector, err := s.dequantize(req.Namespace, req.Quantization, req.QuantizedVector)
+	if err != nil {
+		return err
+	}
+	req.Vector = vector
+	return nil
+}
+
+// resolveQueryVector replaces the float query with the dequantized one
+// when the request carries a quantized query
+func (s *Server) resolveQueryVector(req *proto.SearchRequest) error {
+	if len(req.QuantizedQueryVector) == 0 {
+		return nil
+	}
+
+	vector, err := s.dequantize(req.Namespace, req.Quantization, req.QuantizedQueryVector)
+	if err != nil {
+		return err
+	}
+	req.QueryVector = vector
+	return nil
+}
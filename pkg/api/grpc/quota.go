@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/config"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/tenant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newQuotaManager builds the insert quota manager, or returns nil when no
+// quota is configured
+func newQuotaManager(cfg config.QuotaConfig) *tenant.QuotaManager {
+	if cfg.MaxVectors == 0 && cfg.MaxMemoryBytes == 0 && len(cfg.Namespaces) == 0 {
+		return nil
+	}
+
+	defaults := tenant.Quota{
+		MaxVectors:      int64(cfg.MaxVectors),
+		MaxStorageBytes: int64(cfg.MaxMemoryBytes),
+	}
+	overrides := make(map[string]tenant.Quota, len(cfg.Namespaces))
+	for namespace, override := range cfg.Namespaces {
+		// Zero keeps the default; a negative limit is never enforced
+		quota := defaults
+		if override.MaxVectors != 0 {
+			quota.MaxVectors = int64(override.MaxVectors)
+		}
+		if override.MaxMemoryBytes != 0 {
+			quota.MaxStorageBytes = int64(override.MaxMemoryBytes)
+		}
+		overrides[namespace] = quota
+	}
+	return tenant.NewQuotaManager(defaults, overrides)
+}
+
+// admitInsert counts a new vector against its namespace's quotas. It
+// returns the bytes admitted, which a failed insert passes to
+// releaseInsert, or ResourceExhausted when a quota is full.
+func (s *Server) admitInsert(req *proto.InsertRequest) (int64, error) {
+	if s.quotas == nil {
+		return 0, nil
+	}
+
+	bytes := s.insertMemoryEstimate(req)
+	if err := s.quotas.Admit(req.Namespace, 1, bytes); err != nil {
+		return 0, status.Errorf(codes.ResourceExhausted, "namespace %s: %v", req.Namespace, err)
+	}
+	s.updateQuotaMetrics(req.Namespace)
+	return bytes, nil
+}
+
+// releaseInsert returns the quota admitted for an insert that failed
+func (s *Server) releaseInsert(namespace string, bytes int64) {
+	if s.quotas == nil {
+		return
+	}
+	s.quotas.Release(namespace, 1, bytes)
+	s.updateQuotaMetrics(namespace)
+}
+
+// syncQuota measures a namespace's size and memory for its quotas. The
+// memory estimate walks the whole graph, so this only runs after loads
+// and operations that free space.
+func (s *Server) syncQuota(namespace string, index *hnsw.Index) {
+	if s.quotas == nil {
+		return
+	}
+
+	s.mu.RLock()
+	bytes := s.namespaceMemoryUsage(namespace)
+	s.mu.RUnlock()
+
+	s.quotas.Sync(namespace, index.Size(), bytes)
+	s.updateQuotaMetrics(namespace)
+}
+
+// forgetQuota drops a deleted namespace's quota usage
+func (s *Server) forgetQuota(namespace string) {
+	if s.quotas == nil {
+		return
+	}
+	s.quotas.Forget(namespace)
+	if s.metrics != nil {
+		s.metrics.UpdateTenantCount(s.quotas.Count())
+	}
+}
+
+// updateQuotaMetrics sets a namespace's quota usage gauges
+func (s *Server) updateQuotaMetrics(namespace string) {
+	if s.metrics == nil {
+		return
+	}
+	for resource, percent := range s.quotas.UsagePercentage(namespace) {
+		s.metrics.UpdateTenantQuota(namespace, resource, percent)
+	}
+	s.metrics.UpdateTenantCount(s.quotas.Count())
+}
@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+)
+
+// Distance metric names
+const (
+	MetricCosine     = "cosine"
+	MetricEuclidean  = "euclidean"
+	MetricDotProduct = "dot_product"
+)
+
+// NamespaceMetrics configures the distance metrics of a namespace. The HNSW
+// graph is built and searched under Retrieval; when Rerank is set, the top
+// RerankDepth candidates are re-scored under Rerank using stored vectors.
+type NamespaceMetrics struct {
+	Retrieval   string // Metric the graph is built under (default: cosine)
+	Rerank      string // Metric for final ordering ("" = order by retrieval metric)
+	RerankDepth int    // Candidates gathered for reranking (0 = max(4*k, efSearch))
+}
+
+// distanceFuncForMetric resolves a metric name to its distance function
+func distanceFuncForMetric(metric string) (hnsw.DistanceFunc, error) {
+	switch metric {
+	case MetricCosine:
+		return hnsw.CosineSimilarity, nil
+	case MetricEuclidean:
+		return hnsw.EuclideanDistance, nil
+	case MetricDotProduct:
+		return hnsw.DotProduct, nil
+	default:
+		return nil, fmt.Errorf("unknown distance metric: %q", metric)
+	}
+}
+
+// SetNamespaceMetrics declares the retrieval and rerank metrics for a namespace.
+// The graph depends on the retrieval metric, so this must happen before the
+// namespace holds any vectors.
+func (s *Server) SetNamespaceMetrics(namespace string, metrics NamespaceMetrics) error {
+	if metrics.Retrieval == "" {
+		metrics.Retrieval = MetricCosine
+	}
+	if _, err := distanceFuncForMetric(metrics.Retrieval); err != nil {
+		return err
+	}
+	if metrics.Rerank != "" {
+		if _, err := distanceFuncForMetric(metrics.Rerank); err != nil {
+			return err
+		}
+	}
+	if metrics.RerankDepth < 0 {
+		return fmt.Errorf("invalid rerank depth: %d (must be >= 0)", metrics.RerankDepth)
+	}
+
+	s.mu.Lock()
+	if index, exists := s.indexes[namespace]; exists {
+		if index.Size() > 0 {
+			s.mu.Unlock()
+			return fmt.Errorf("namespace %q already has vectors; metrics must be set before the first insert", namespace)
+		}
+
+		// Drop the empty namespace so it is rebuilt under the new metric
+		delete(s.indexes, namespace)
+		delete(s.textIndexes, namespace)
+		delete(s.hybridSearch, namespace)
+		delete(s.metadata, namespace)
+		delete(s.externalIDs, namespace)
+	}
+	s.namespaceMetrics[namespace] = metrics
+	s.mu.Unlock()
+
+	s.invalidateResultCache(namespace)
+	return s.initNamespace(namespace)
+}
+
+// metricsFor returns the metrics declared for a namespace
+func (s *Server) metricsFor(namespace string) (NamespaceMetrics, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	metrics, ok := s.namespaceMetrics[namespace]
+	return metrics, ok
+}
+
+// rerankDepth returns how many candidates to gather before reranking to k
+func (m NamespaceMetrics) rerankDepth(k, efSearch int) int {
+	return candidateDepth(m.RerankDepth, k, efSearch)
+}
+
+// candidateDepth returns how many candidates to gather before reranking to
+// k: the requested depth, at least k, or by default max(4*k, efSearch)
+func candidateDepth(requested, k, efSearch int) int {
+	if requested > 0 {
+		if requested < k {
+			return k
+		}
+		return requested
+	}
+	if efSearch > 4*k {
+		return efSearch
+	}
+	return 4 * k
+}
+
+// rerankResults re-scores candidates under the rerank metric using their
+// stored vectors and returns the best k
+func rerankResults(index *hnsw.Index, query []float32, candidates []hnsw.Result, k int, distanceFunc hnsw.DistanceFunc) []hnsw.Result {
+	reranked := make([]hnsw.Result, 0, len(candidates))
+	for _, c := range candidates {
+		vector, err := index.GetVector(c.ID)
+		if err != nil {
+			continue // Deleted since retrieval
+		}
+		reranked = append(reranked, hnsw.Result{
+			ID:       c.ID,
+			Distance: distanceFunc(query, vector),
+		})
+	}
+
+	sort.Slice(reranked, func(i, j int) bool {
+		if reranked[i].Distance != reranked[j].Distance {
+			return reranked[i].Distance < reranked[j].Distance
+		}
+		return reranked[i].ID < reranked[j].ID
+	})
+
+	if len(reranked) > k {
+		reranked = reranked[:k]
+	}
+	return reranked
+}
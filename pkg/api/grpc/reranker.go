@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+)
+
+// RerankerNoop is the built-in reranker that keeps the retrieval order
+const RerankerNoop = "noop"
+
+// RegisterReranker makes a reranker available to Search and HybridSearch
+// requests that name it. Registering a name again replaces the reranker.
+func (s *Server) RegisterReranker(name string, reranker search.Reranker) error {
+	if name == "" {
+		return fmt.Errorf("reranker name is required")
+	}
+	if reranker == nil {
+		return fmt.Errorf("reranker %q is nil", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rerankers[name] = reranker
+	return nil
+}
+
+// rerankerFor looks up the reranker a request names; "" selects none
+func (s *Server) rerankerFor(name string) (search.Reranker, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	s.mu.RLock()
+	reranker, ok := s.rerankers[name]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown reranker: %q", name)
+	}
+	return reranker, nil
+}
+
+// rerankCandidate returns a result with its stored vector, text and metadata
+func (s *Server) rerankCandidate(namespace string, index *hnsw.Index, id uint64, distance float32) search.Result {
+	result := search.Result{ID: id, Distance: distance}
+	if vector, err := index.GetVector(id); err == nil {
+		result.Vector = vector
+	}
+
+	s.mu.RLock()
+	result.Metadata = s.metadata[namespace][id]
+	textIndex := s.textIndexes[namespace]
+	s.mu.RUnlock()
+
+	if textIndex != nil {
+		if doc := textIndex.GetDocument(id); doc != nil {
+			result.Text = doc.Text
+		}
+	}
+	return result
+}
+
+// applyReranker reranks search candidates and returns the best k
+func (s *Server) applyReranker(namespace string, index *hnsw.Index, reranker search.Reranker, query []float32, candidates []hnsw.Result, k int) []hnsw.Result {
+	inputs := make([]search.Result, len(candidates))
+	for i, c := range candidates {
+		inputs[i] = s.rerankCandidate(namespace, index, c.ID, c.Distance)
+	}
+
+	reranked := search.ApplyReranker(reranker, query, "", inputs, k)
+	results := make([]hnsw.Result, len(reranked))
+	for i, r := range reranked {
+		results[i] = hnsw.Result{ID: r.ID, Distance: r.Distance}
+	}
+	return results
+}
+
+// applyHybridReranker reranks hybrid candidates and returns the best k in
+// the reranked order. Results keep their fusion scores.
+func (s *Server) applyHybridReranker(namespace string, index *hnsw.Index, reranker search.Reranker, query []float32, queryText string, candidates []*search.HybridSearchResult, k int) []*search.HybridSearchResult {
+	inputs := make([]search.Result, len(candidates))
+	byID := make(map[uint64]*search.HybridSearchResult, len(candidates))
+	for i, c := range candidates {
+		inputs[i] = s.rerankCandidate(namespace, index, c.ID, c.VectorScore)
+		byID[c.ID] = c
+	}
+
+	reranked := search.ApplyReranker(reranker, query, queryText, inputs, k)
+	results := make([]*search.HybridSearchResult, len(reranked))
+	for i, r := range reranked {
+		results[i] = byID[r.ID]
+	}
+	return results
+}
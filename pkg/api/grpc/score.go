@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+)
+
+// Search score modes
+const (
+	ScoreModeDistance   = "distance"   // Only distance is returned (default)
+	ScoreModeSimilarity = "similarity" // Results also carry a [0,1] similarity score
+)
+
+// validateScoreMode checks a search request's score mode
+func validateScoreMode(mode string) error {
+	switch mode {
+	case "", ScoreModeDistance, ScoreModeSimilarity:
+		return nil
+	default:
+		return fmt.Errorf("unknown score_mode %q (expected %q or %q)", mode, ScoreModeDistance, ScoreModeSimilarity)
+	}
+}
+
+// similarityScore converts a distance under metric to a similarity in
+// [0,1], higher meaning more similar:
+//
+//	cosine:      max(0, 1 - d), the cosine similarity with opposed vectors at 0
+//	euclidean:   1 / (1 + d)
+//	dot_product: 1 / (1 + e^d), the logistic of the dot product (d = -a·b)
+func similarityScore(metric string, distance float32) float32 {
+	d := float64(distance)
+	switch metric {
+	case MetricEuclidean:
+		return float32(1 / (1 + d))
+	case MetricDotProduct:
+		return float32(1 / (1 + math.Exp(d)))
+	default:
+		return float32(math.Max(0, 1-d))
+	}
+}
+
+// scoreMetric returns the metric a namespace's result distances are under:
+// the rerank metric when it reorders results, otherwise the retrieval metric
+func scoreMetric(metrics NamespaceMetrics) string {
+	if metrics.Rerank != "" {
+		return metrics.Rerank
+	}
+	if metrics.Retrieval != "" {
+		return metrics.Retrieval
+	}
+	return MetricCosine
+}
+
+// scoreDistance inverts similarityScore: the largest distance under metric
+// whose similarity is at least score. The score falls as the distance
+// grows, so a minimum score is a maximum distance.
+func scoreDistance(metric string, score float32) float32 {
+	s := float64(score)
+	if s <= 0 {
+		return float32(math.Inf(1))
+	}
+	switch metric {
+	case MetricEuclidean:
+		return float32(1/s - 1)
+	case MetricDotProduct:
+		return float32(math.Log(1/s - 1))
+	default:
+		return float32(1 - s)
+	}
+}
+
+// searchMaxDistance returns the distance threshold of a search: the lower of
+// max_distance and the distance min_score corresponds to under the
+// namespace's score metric (+Inf when neither is set)
+func searchMaxDistance(req *proto.SearchRequest, metrics NamespaceMetrics) float32 {
+	maxDistance := float32(math.Inf(1))
+	if req.MaxDistance != nil {
+		maxDistance = *req.MaxDistance
+	}
+	if req.MinScore != nil {
+		if d := scoreDistance(scoreMetric(metrics), *req.MinScore); d < maxDistance {
+			maxDistance = d
+		}
+	}
+	return maxDistance
+}
+
+// withinMaxDistance drops results farther than maxDistance, keeping the
+// order of the rest
+func withinMaxDistance(results []hnsw.Result, maxDistance float32) []hnsw.Result {
+	if math.IsInf(float64(maxDistance), 1) {
+		return results
+	}
+	kept := results[:0]
+	for _, r := range results {
+		if r.Distance <= maxDistance {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
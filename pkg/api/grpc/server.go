@@ -5,36 +5,79 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"math"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/zstd"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/rest/middleware"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/cache"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/config"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/migrate"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/observability"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/tenant"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/wal"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 // Server represents the gRPC server
 type Server struct {
 	proto.UnimplementedVectorDBServer
-	config      *config.Config
-	grpcServer  *grpc.Server
-	listener    net.Listener
-	startTime   time.Time
-	shutdownMu  sync.Mutex
-	isShutdown  bool
+	config     *config.Config
+	grpcServer *grpc.Server
+	listener   net.Listener
+	connLimit  *connLimitListener    // Enforces Server.MaxConnections (nil until Start)
+	auth       middleware.AuthConfig // API keys and JWT secret checked when Server.AuthEnabled
+	tlsConfig  *tls.Config           // Loaded certificates (nil when TLS is disabled)
+	startTime  time.Time
+	shutdownMu sync.Mutex
+	isShutdown bool
+	warming    atomic.Bool // Startup warmup still running
 
 	// Database components
-	indexes      map[string]*hnsw.Index       // namespace -> HNSW index
-	textIndexes  map[string]*search.FullTextIndex // namespace -> text index
-	hybridSearch map[string]*search.CachedHybridSearch // namespace -> cached hybrid search
-	metadata     map[string]map[uint64]map[string]interface{} // namespace -> id -> metadata
-	mu           sync.RWMutex                 // Protects indexes maps
+	indexes             map[string]*hnsw.Index                       // namespace -> HNSW index
+	textIndexes         map[string]*search.FullTextIndex             // namespace -> text index
+	hybridSearch        map[string]*search.CachedHybridSearch        // namespace -> cached hybrid search
+	anns                map[string]*namespaceANN                     // namespace -> IVF-PQ or SCANN index searched ahead of its flat HNSW index
+	migrations          map[string]*migrationJournal                 // namespace -> writes made while Migrate builds its new index
+	metadata            map[string]map[uint64]map[string]interface{} // namespace -> id -> metadata
+	externalIDs         map[string]*idMap                            // namespace -> external ID map
+	dimensionPolicies   map[string]string                            // namespace -> dimension mismatch policy override
+	namespaceMetrics    map[string]NamespaceMetrics                  // namespace -> retrieval/rerank metrics
+	indexConfigs        map[string]NamespaceIndexConfig              // namespace -> declared index parameters
+	efSearchMultipliers map[string]float64                           // namespace -> efSearch multiplier override
+	normalizeOnInsert   map[string]bool                              // namespace -> normalize-on-insert override
+	normChecks          sync.Map                                     // namespace -> *normCheck of sampled vector norms
+	quantizers          map[string]*quantization.ScalarQuantizer     // namespace -> range for quantized vectors
+	rerankers           map[string]search.Reranker                   // name -> reranker requests can select
+	wals                map[string]*wal.Log                          // namespace -> write-ahead log (when enabled)
+	metrics             *observability.Metrics                       // Prometheus metrics (nil when disabled)
+	resultCache         *cache.ResultCache                           // Search result cache (nil when disabled)
+	quotas              *tenant.QuotaManager                         // Per-namespace insert quotas (nil when none are configured)
+	mu                  sync.RWMutex                                 // Protects indexes maps
+
+	// AsyncBatchInsert jobs
+	jobs       map[string]*insertJob // job ID -> running or recently finished job
+	jobsMu     sync.Mutex            // Protects jobs
+	jobsCtx    context.Context       // Cancelled by Stop to halt running jobs
+	cancelJobs context.CancelFunc
+	jobsWG     sync.WaitGroup              // Running jobs
+	writeMu    sync.RWMutex                // Held shared by writes, exclusively by Snapshot, Restore, Compact and Migrate's swap
+	walOrder   [walOrderStripes]sync.Mutex // Held by a write to a stored vector while it is logged and applied
+	asOf       *pointInTime                // Snapshot loaded last for as_of searches (nil until one runs)
+	asOfMu     sync.Mutex                  // Protects asOf and serializes snapshot loads
 }
 
 // NewServer creates a new gRPC server
@@ -44,12 +87,54 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	}
 
 	s := &Server{
-		config:       cfg,
-		indexes:      make(map[string]*hnsw.Index),
-		textIndexes:  make(map[string]*search.FullTextIndex),
-		hybridSearch: make(map[string]*search.CachedHybridSearch),
-		metadata:     make(map[string]map[uint64]map[string]interface{}),
-		startTime:    time.Now(),
+		config:              cfg,
+		indexes:             make(map[string]*hnsw.Index),
+		textIndexes:         make(map[string]*search.FullTextIndex),
+		hybridSearch:        make(map[string]*search.CachedHybridSearch),
+		anns:                make(map[string]*namespaceANN),
+		migrations:          make(map[string]*migrationJournal),
+		metadata:            make(map[string]map[uint64]map[string]interface{}),
+		externalIDs:         make(map[string]*idMap),
+		dimensionPolicies:   make(map[string]string),
+		namespaceMetrics:    make(map[string]NamespaceMetrics),
+		indexConfigs:        make(map[string]NamespaceIndexConfig),
+		efSearchMultipliers: make(map[string]float64),
+		normalizeOnInsert:   make(map[string]bool),
+		quantizers:          make(map[string]*quantization.ScalarQuantizer),
+		rerankers:           map[string]search.Reranker{RerankerNoop: search.NoopReranker{}},
+		wals:                make(map[string]*wal.Log),
+		jobs:                make(map[string]*insertJob),
+		startTime:           time.Now(),
+		quotas:              newQuotaManager(cfg.Quota),
+		auth:                newAuthConfig(cfg),
+	}
+	s.jobsCtx, s.cancelJobs = context.WithCancel(context.Background())
+
+	// Load certificates now so a bad TLS setup fails startup
+	tlsConfig, err := loadTLSConfig(cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+	s.tlsConfig = tlsConfig
+
+	if cfg.Metrics.Enabled {
+		s.metrics = observability.DefaultMetrics()
+	}
+	if cfg.Cache.Enabled {
+		s.resultCache = cache.New(cfg.Cache.Capacity, cfg.Cache.TTL)
+	}
+	for ns, r := range cfg.HNSW.Quantization {
+		if err := s.SetQuantizationRange(ns, float32(r.Min), float32(r.Max)); err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", ns, err)
+		}
+	}
+
+	// Rebuild namespaces from their write-ahead logs
+	if cfg.WAL.Enabled {
+		if err := s.recoverWAL(); err != nil {
+			return nil, fmt.Errorf("failed to recover from WAL: %w", err)
+		}
+		s.checkRecoveredNorms()
 	}
 
 	// Initialize default namespace
@@ -57,6 +142,12 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to initialize default namespace: %w", err)
 	}
 
+	// Start the index gauges and quota usage from the recovered sizes
+	for ns, index := range s.indexes {
+		s.updateIndexMetrics(ns, index)
+		s.syncQuota(ns, index)
+	}
+
 	return s, nil
 }
 
@@ -70,41 +161,99 @@ func (s *Server) initNamespace(namespace string) error {
 		return nil
 	}
 
-	// Create HNSW index with default config
-	indexConfig := hnsw.DefaultConfig()
-	indexConfig.M = s.config.HNSW.M
+	// Create HNSW index with the configured and declared parameters
+	var metrics *NamespaceMetrics
+	if declared, ok := s.namespaceMetrics[namespace]; ok {
+		metrics = &declared
+	}
+	indexConfig, err := s.hnswConfig(s.indexConfigs[namespace], metrics)
+	if err != nil {
+		return err
+	}
 	index := hnsw.New(indexConfig)
+
+	// Open the write-ahead log before publishing the namespace
+	if err := s.openWALLocked(namespace); err != nil {
+		return fmt.Errorf("failed to open WAL for namespace %s: %w", namespace, err)
+	}
+
 	s.indexes[namespace] = index
 
 	// Create metadata store for this namespace
 	s.metadata[namespace] = make(map[uint64]map[string]interface{})
 
+	// Create bounded external ID map for this namespace
+	s.externalIDs[namespace] = newIDMap(s.config.Database.MaxExternalIDs, s.config.Database.ExternalIDOverflow)
+
 	// Create full-text index
-	textIndex := search.NewFullTextIndex()
+	textIndex := s.newTextIndex()
 	s.textIndexes[namespace] = textIndex
 
 	// Create cached hybrid search
-	var cachedSearch *search.CachedHybridSearch
+	s.hybridSearch[namespace] = s.newHybridSearch(index, textIndex)
+
+	log.Printf("Initialized namespace: %s (M=%d, efConstruction=%d, dimensions=%d)",
+		namespace, indexConfig.M, indexConfig.EfConstruction(), s.dimensionsLocked(namespace, index))
+
+	return nil
+}
+
+// hnswConfig returns the HNSW config for a namespace: the server
+// configuration with the declared index parameters and retrieval metric
+// applied over it
+func (s *Server) hnswConfig(declared NamespaceIndexConfig, metrics *NamespaceMetrics) (hnsw.IndexConfig, error) {
+	indexConfig := hnsw.DefaultConfig()
+	indexConfig.M = s.config.HNSW.M
+	indexConfig.SetEfConstruction(s.config.HNSW.EfConstruction)
+	indexConfig.Storage = s.config.HNSW.StorageDType
+	indexConfig.FlatThreshold = s.config.HNSW.FlatThreshold
+	if declared.M > 0 {
+		indexConfig.M = declared.M
+	}
+	if declared.EfConstruction > 0 {
+		indexConfig.SetEfConstruction(declared.EfConstruction)
+	}
+	switch declared.IndexType {
+	case migrate.TypeHNSW:
+		indexConfig.FlatThreshold = 0
+	case migrate.TypeFlat, migrate.TypeIVFPQ, migrate.TypeSCANN:
+		indexConfig.FlatThreshold = math.MaxInt // Never build the graph
+	}
+	if metrics != nil {
+		distanceFunc, err := distanceFuncForMetric(metrics.Retrieval)
+		if err != nil {
+			return hnsw.IndexConfig{}, err
+		}
+		indexConfig.DistanceFunc = distanceFunc
+	}
+	return indexConfig, nil
+}
+
+// newHybridSearch creates a namespace's cached hybrid search
+func (s *Server) newHybridSearch(index *hnsw.Index, textIndex *search.FullTextIndex) *search.CachedHybridSearch {
 	if s.config.Cache.Enabled {
-		cachedSearch = search.NewCachedHybridSearch(
+		return search.NewCachedHybridSearch(
 			index,
 			textIndex,
 			s.config.Cache.Capacity,
 			s.config.Cache.TTL,
 		)
-	} else {
-		// Create with zero capacity cache (effectively disabled)
-		cachedSearch = search.NewCachedHybridSearch(index, textIndex, 0, 0)
 	}
-	s.hybridSearch[namespace] = cachedSearch
-
-	log.Printf("Initialized namespace: %s (M=%d, efConstruction=%d, dimensions=%d)",
-		namespace, s.config.HNSW.M, s.config.HNSW.EfConstruction, s.config.HNSW.Dimensions)
+	// Create with zero capacity cache (effectively disabled)
+	return search.NewCachedHybridSearch(index, textIndex, 0, 0)
+}
 
-	return nil
+// newTextIndex creates a namespace's full-text index with the configured
+// tokenizer
+func (s *Server) newTextIndex() *search.FullTextIndex {
+	if s.config.Database.TextTokenizer == search.TokenizerNgram {
+		return search.NewFullTextIndexWithConfig(search.TokenizerConfig{Mode: search.TokenizerNgram, MinLength: 2})
+	}
+	return search.NewFullTextIndex()
 }
 
-// getNamespaceIndexes returns indexes for a namespace (creates if not exists)
+// getNamespaceIndexes returns indexes for a namespace, creating it unless
+// namespaces are strict
 func (s *Server) getNamespaceIndexes(namespace string) (*hnsw.Index, *search.FullTextIndex, *search.CachedHybridSearch, error) {
 	s.mu.RLock()
 	index, indexExists := s.indexes[namespace]
@@ -113,6 +262,12 @@ func (s *Server) getNamespaceIndexes(namespace string) (*hnsw.Index, *search.Ful
 	s.mu.RUnlock()
 
 	if !indexExists || !textExists || !hybridExists {
+		// A strict server only serves namespaces created explicitly
+		if s.config.Database.StrictNamespaces {
+			return nil, nil, nil, status.Errorf(codes.NotFound,
+				"namespace %q not found; create it with CreateNamespace", namespace)
+		}
+
 		// Initialize namespace if it doesn't exist
 		if err := s.initNamespace(namespace); err != nil {
 			return nil, nil, nil, err
@@ -134,18 +289,13 @@ func (s *Server) Start() error {
 	var opts []grpc.ServerOption
 
 	// Configure TLS if enabled
-	if s.config.Server.EnableTLS {
-		cert, err := tls.LoadX509KeyPair(s.config.Server.CertFile, s.config.Server.KeyFile)
-		if err != nil {
-			return fmt.Errorf("failed to load TLS certificates: %w", err)
-		}
-		tlsConfig := &tls.Config{
-			Certificates: []tls.Certificate{cert},
-			MinVersion:   tls.VersionTLS12,
+	if s.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+		if s.tlsConfig.ClientCAs != nil {
+			log.Println("TLS enabled, client certificates required")
+		} else {
+			log.Println("TLS enabled")
 		}
-		creds := credentials.NewTLS(tlsConfig)
-		opts = append(opts, grpc.Creds(creds))
-		log.Println("TLS enabled")
 	}
 
 	// Configure keepalive
@@ -157,15 +307,55 @@ func (s *Server) Start() error {
 	}
 	opts = append(opts, grpc.KeepaliveParams(kaParams))
 
-	// Configure max connections
+	// Cap concurrent streams per connection; the listener caps connections
 	opts = append(opts, grpc.MaxConcurrentStreams(uint32(s.config.Server.MaxConnections)))
 
+	// Configure response compression
+	if s.config.Server.EnableCompression {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(compressionUnaryInterceptor),
+			grpc.ChainStreamInterceptor(compressionStreamInterceptor),
+		)
+		log.Println("gzip response compression enabled")
+	}
+
+	// Continue traces started by callers
+	if s.config.Tracing.Enabled {
+		opts = append(opts, grpc.ChainUnaryInterceptor(tracingUnaryInterceptor))
+	}
+
+	// Record request counts and latencies
+	if s.metrics != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.metricsUnaryInterceptor),
+			grpc.ChainStreamInterceptor(s.metricsStreamInterceptor),
+		)
+	}
+
+	// Fail RPCs on connections beyond MaxConnections
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(s.connLimitUnaryInterceptor),
+		grpc.ChainStreamInterceptor(s.connLimitStreamInterceptor),
+	)
+
+	// Require credentials on every RPC but the public ones
+	if s.auth.Enabled {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.authUnaryInterceptor),
+			grpc.ChainStreamInterceptor(s.authStreamInterceptor),
+		)
+		log.Println("gRPC authentication enabled")
+	}
+
 	// Create gRPC server
 	s.grpcServer = grpc.NewServer(opts...)
 	proto.RegisterVectorDBServer(s.grpcServer, s)
 
-	// Enable reflection for debugging (e.g., with grpcurl)
-	reflection.Register(s.grpcServer)
+	// Let grpcurl, Postman and similar tools discover the API without the .proto
+	if s.config.Server.EnableReflection {
+		reflection.Register(s.grpcServer)
+		log.Println("gRPC reflection enabled")
+	}
 
 	// Create listener
 	addr := s.config.Server.Address()
@@ -173,13 +363,20 @@ func (s *Server) Start() error {
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
-	s.listener = listener
+	s.connLimit = newConnLimitListener(listener, s.config.Server.MaxConnections)
+	s.listener = s.connLimit
 
 	log.Printf("Vector Database gRPC server listening on %s", addr)
 
+	// Page loaded indexes in while HealthCheck reports "warming"
+	if s.config.Warmup.Enabled {
+		s.warming.Store(true)
+		go s.warmup()
+	}
+
 	// Serve in a goroutine
 	go func() {
-		if err := s.grpcServer.Serve(listener); err != nil {
+		if err := s.grpcServer.Serve(s.connLimit); err != nil {
 			log.Printf("gRPC server error: %v", err)
 		}
 	}()
@@ -187,6 +384,36 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// negotiateCompression compresses responses with zstd or gzip if the client advertised support
+// for one, preferring zstd. Importing the gzip and zstd packages registers the compressors, so
+// compressed requests are always accepted and uncompressed clients are unaffected.
+func negotiateCompression(ctx context.Context) {
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil {
+		return
+	}
+	for _, preferred := range []string{zstd.Name, gzip.Name} {
+		for _, name := range supported {
+			if name == preferred {
+				grpc.SetSendCompressor(ctx, name)
+				return
+			}
+		}
+	}
+}
+
+// compressionUnaryInterceptor enables response compression for unary RPCs
+func compressionUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	negotiateCompression(ctx)
+	return handler(ctx, req)
+}
+
+// compressionStreamInterceptor enables response compression for streaming RPCs
+func compressionStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	negotiateCompression(ss.Context())
+	return handler(srv, ss)
+}
+
 // Stop gracefully shuts down the server
 func (s *Server) Stop() error {
 	s.shutdownMu.Lock()
@@ -203,20 +430,28 @@ func (s *Server) Stop() error {
 	defer cancel()
 
 	// Graceful stop with timeout
-	stopped := make(chan struct{})
-	go func() {
-		s.grpcServer.GracefulStop()
-		close(stopped)
-	}()
-
-	select {
-	case <-stopped:
-		log.Println("Server stopped gracefully")
-	case <-ctx.Done():
-		log.Println("Shutdown timeout exceeded, forcing stop")
-		s.grpcServer.Stop()
+	if s.grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			s.grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			log.Println("Server stopped gracefully")
+		case <-ctx.Done():
+			log.Println("Shutdown timeout exceeded, forcing stop")
+			s.grpcServer.Stop()
+		}
 	}
 
+	// Let background inserts finish their in-flight items before the WALs close
+	s.stopJobs()
+
+	// Flush writes that are still only in the OS page cache
+	s.closeWALs()
+
 	s.isShutdown = true
 	return nil
 }
@@ -249,13 +484,31 @@ func (s *Server) Stats() map[string]interface{} {
 	// Collect per-namespace stats
 	for ns, idx := range s.indexes {
 		nodeCount := 0
+		maxLayer := -1
+		var layers []hnsw.LayerStats
+		dimensions := s.config.HNSW.Dimensions
+		var info NamespaceIndexConfig
 		if idx != nil {
 			nodeCount = int(idx.Size())
+			maxLayer = idx.MaxLayer()
+			layers = idx.LayerStats()
+			info = s.namespaceInfoLocked(ns, idx)
+			// A declared dimension, or else the first insert, fixes the
+			// dimension actually in use
+			if info.Dimensions > 0 {
+				dimensions = info.Dimensions
+			}
 		}
 
 		nsStats := map[string]interface{}{
-			"vector_count": nodeCount,
-			"dimensions":   s.config.HNSW.Dimensions,
+			"vector_count":    nodeCount,
+			"dimensions":      dimensions,
+			"memory_bytes":    s.namespaceMemoryUsage(ns),
+			"max_layer":       maxLayer,
+			"layers":          layers,
+			"m":               info.M,
+			"ef_construction": info.EfConstruction,
+			"metric":          info.Metric,
 		}
 
 		// Add cache stats if available
@@ -266,6 +519,15 @@ func (s *Server) Stats() map[string]interface{} {
 			nsStats["cache_hit_rate"] = cacheStats.HitRate
 		}
 
+		// Add external ID map stats
+		if ids, ok := s.externalIDs[ns]; ok {
+			idStats := ids.Stats()
+			nsStats["external_ids"] = idStats.Size
+			nsStats["external_id_capacity"] = idStats.Capacity
+			nsStats["external_id_evictions"] = idStats.Evictions
+			nsStats["external_id_rejections"] = idStats.Rejections
+		}
+
 		stats["namespace_stats"].(map[string]map[string]interface{})[ns] = nsStats
 	}
 
@@ -0,0 +1,821 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/wal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Snapshot file identification
+var snapshotMagic = [4]byte{'V', 'S', 'N', 'P'}
+
+// snapshotVersion is bumped whenever the snapshot layout changes.
+// Restore reads this version and the older ones below and rejects any
+// other.
+const snapshotVersion byte = 4
+
+// snapshotVersionNoIndexType is the layout before each namespace's index
+// type was stored with its settings
+const snapshotVersionNoIndexType byte = 3
+
+// snapshotVersionNoExternalIDs is the layout before external IDs were
+// stored with each vector
+const snapshotVersionNoExternalIDs byte = 2
+
+// snapshotVersionStringMetadata is the layout before typed metadata, with
+// every metadata value stored as a plain string
+const snapshotVersionStringMetadata byte = 1
+
+// Snapshot metadata value tags
+const (
+	snapshotValueString byte = iota
+	snapshotValueInt64
+	snapshotValueFloat64
+	snapshotValueBool
+)
+
+// snapshotExt is the file extension of snapshots
+const snapshotExt = ".snap"
+
+// Snapshot writes snapshot-<capture time><snapshotExt>, the time in UTC and
+// snapshotTimeFormat, which as_of searches use to pick a snapshot
+const (
+	snapshotPrefix     = "snapshot-"
+	snapshotTimeFormat = "20060102-150405.000000000"
+)
+
+// maxSnapshotString bounds a single string so a corrupt length cannot
+// trigger a huge allocation during restore
+const maxSnapshotString = 1 << 30
+
+// Normalize-on-insert override as stored in a snapshot
+const (
+	snapshotNormalizeUnset byte = iota
+	snapshotNormalizeOff
+	snapshotNormalizeOn
+)
+
+// namespaceSnapshot is one namespace captured for a snapshot
+type namespaceSnapshot struct {
+	name        string
+	settings    namespaceSettings
+	indexConfig NamespaceIndexConfig // Declared parameters, for the WAL checkpoint
+	index       []byte               // Output of hnsw.Index.Save
+	documents   []snapshotDocument
+	log         *wal.Log // nil without a WAL
+	logOffset   int64    // Size of log when captured
+}
+
+// namespaceSettings holds a namespace's per-namespace overrides
+type namespaceSettings struct {
+	metrics            *NamespaceMetrics // nil = not declared
+	dimensionPolicy    string            // "" = configured default
+	efSearchMultiplier *float64          // nil = configured default
+	normalize          byte              // snapshotNormalize*
+	indexType          string            // "" = not migrated
+}
+
+// snapshotDocument is the metadata and text stored for one vector
+type snapshotDocument struct {
+	id         uint64
+	metadata   map[string]interface{}
+	text       string
+	externalID string
+}
+
+// restoredNamespace is a namespace read from a snapshot, ready to publish
+type restoredNamespace struct {
+	name      string
+	settings  namespaceSettings
+	index     *hnsw.Index
+	textIndex *search.FullTextIndex
+	metadata  map[uint64]map[string]interface{}
+	documents []snapshotDocument
+}
+
+// snapshotDir returns the directory holding snapshots
+func (s *Server) snapshotDir() string {
+	return filepath.Join(s.config.Database.DataDir, "snapshots")
+}
+
+// snapshotPath resolves a Restore path. Relative paths are taken from the
+// snapshot directory, and only files directly inside it may be restored.
+func (s *Server) snapshotPath(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("path is required")
+	}
+
+	dir, err := filepath.Abs(s.snapshotDir())
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+
+	if filepath.Dir(path) != dir {
+		return "", fmt.Errorf("snapshot must be a file in %s", dir)
+	}
+	return path, nil
+}
+
+// Snapshot implements the Snapshot RPC. State is captured in memory while
+// writes are held off, then written to a temporary file that is renamed
+// into place once complete, so a failed snapshot never leaves a partial file.
+func (s *Server) Snapshot(req *proto.SnapshotRequest, stream proto.VectorDB_SnapshotServer) error {
+	start := time.Now()
+
+	namespaces, captured, err := s.captureSnapshot()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	dir, err := filepath.Abs(s.snapshotDir())
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return status.Errorf(codes.Internal, "failed to create snapshot directory: %v", err)
+	}
+
+	file, err := os.CreateTemp(dir, "snapshot-*.tmp")
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create snapshot file: %v", err)
+	}
+	tmpPath := file.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			file.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(file, hash)}
+	w := &snapshotWriter{w: bufio.NewWriter(counter)}
+
+	w.write(snapshotMagic[:])
+	w.byte(snapshotVersion)
+	w.uint32(uint32(len(namespaces)))
+
+	var vectors int64
+	for i, ns := range namespaces {
+		w.namespace(ns)
+		if w.err != nil {
+			return status.Errorf(codes.Internal, "failed to write namespace %s: %v", ns.name, w.err)
+		}
+		vectors += int64(len(ns.documents))
+
+		if err := stream.Send(&proto.SnapshotProgress{
+			Namespace:       ns.name,
+			NamespacesDone:  int32(i + 1),
+			NamespacesTotal: int32(len(namespaces)),
+			VectorsWritten:  vectors,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := w.flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to write snapshot: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		return status.Errorf(codes.Internal, "failed to sync snapshot: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return status.Errorf(codes.Internal, "failed to close snapshot: %v", err)
+	}
+
+	path := filepath.Join(dir, snapshotPrefix+captured.UTC().Format(snapshotTimeFormat)+snapshotExt)
+	if err := os.Rename(tmpPath, path); err != nil {
+		return status.Errorf(codes.Internal, "failed to publish snapshot: %v", err)
+	}
+	committed = true
+	if err := syncDir(dir); err != nil {
+		return status.Errorf(codes.Internal, "failed to sync snapshot directory: %v", err)
+	}
+
+	// The snapshot is durable, so the logs can drop what it holds
+	s.checkpointWALs(namespaces, filepath.Base(path))
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	totalTime := time.Since(start)
+	log.Printf("Wrote snapshot %s (%d namespaces, %d vectors, %d bytes, took %v)",
+		path, len(namespaces), vectors, counter.n, totalTime)
+
+	return stream.Send(&proto.SnapshotProgress{
+		NamespacesDone:  int32(len(namespaces)),
+		NamespacesTotal: int32(len(namespaces)),
+		VectorsWritten:  vectors,
+		Done:            true,
+		Path:            path,
+		Checksum:        checksum,
+		SizeBytes:       counter.n,
+		SnapshotTimeMs:  float32(totalTime.Seconds() * 1000),
+		CapturedAt:      captured.UnixMilli(),
+	})
+}
+
+// captureSnapshot copies every namespace's state. Writes are held off for
+// the duration, and the namespace maps are read under the read lock, so
+// every namespace is captured at the same consistent point, which it
+// returns: every write before that time is included, and none after.
+func (s *Server) captureSnapshot() ([]*namespaceSnapshot, time.Time, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	captured := time.Now()
+
+	names := make([]string, 0, len(s.indexes))
+	for name := range s.indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	namespaces := make([]*namespaceSnapshot, 0, len(names))
+	for _, name := range names {
+		var index bytes.Buffer
+		if err := s.indexes[name].Save(&index); err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to save index of namespace %s: %w", name, err)
+		}
+
+		ns := &namespaceSnapshot{
+			name:        name,
+			settings:    s.namespaceSettingsLocked(name),
+			indexConfig: s.indexConfigs[name],
+			index:       index.Bytes(),
+		}
+		if l := s.wals[name]; l != nil {
+			ns.log, ns.logOffset = l, l.Size()
+		}
+
+		textIndex := s.textIndexes[name]
+		ids := s.externalIDs[name]
+		for id, meta := range s.metadata[name] {
+			doc := snapshotDocument{id: id, metadata: make(map[string]interface{}, len(meta))}
+			for k, v := range meta {
+				doc.metadata[k] = v
+			}
+			if stored := textIndex.GetDocument(id); stored != nil {
+				doc.text = stored.Text
+			}
+			if ids != nil {
+				doc.externalID, _ = ids.ExternalID(id)
+			}
+			ns.documents = append(ns.documents, doc)
+		}
+		sort.Slice(ns.documents, func(i, j int) bool { return ns.documents[i].id < ns.documents[j].id })
+
+		namespaces = append(namespaces, ns)
+	}
+
+	return namespaces, captured, nil
+}
+
+// checkpointWALs starts each captured namespace's log at the snapshot
+// named file, dropping the records it holds. A namespace deleted or
+// recreated since it was captured is skipped. A failed checkpoint only
+// leaves a longer log to replay, so it is logged.
+func (s *Server) checkpointWALs(namespaces []*namespaceSnapshot, file string) {
+	// Held so the logs are not closed or replaced underneath
+	s.writeMu.RLock()
+	defer s.writeMu.RUnlock()
+
+	for _, ns := range namespaces {
+		s.mu.RLock()
+		current := s.wals[ns.name]
+		s.mu.RUnlock()
+		if ns.log == nil || current != ns.log {
+			continue
+		}
+
+		rec := ns.indexConfig.walRecord()
+		rec.Op, rec.Text = wal.OpCheckpoint, file
+		if err := ns.log.Checkpoint(ns.logOffset, rec); err != nil {
+			log.Printf("Warning: failed to checkpoint WAL for namespace %s: %v", ns.name, err)
+		}
+	}
+}
+
+// syncDir fsyncs a directory so a rename in it survives a machine crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// namespaceSettingsLocked returns a namespace's overrides; the caller holds s.mu
+func (s *Server) namespaceSettingsLocked(namespace string) namespaceSettings {
+	var settings namespaceSettings
+	if metrics, ok := s.namespaceMetrics[namespace]; ok {
+		settings.metrics = &metrics
+	}
+	settings.dimensionPolicy = s.dimensionPolicies[namespace]
+	if multiplier, ok := s.efSearchMultipliers[namespace]; ok {
+		settings.efSearchMultiplier = &multiplier
+	}
+	if enabled, ok := s.normalizeOnInsert[namespace]; ok {
+		settings.normalize = snapshotNormalizeOff
+		if enabled {
+			settings.normalize = snapshotNormalizeOn
+		}
+	}
+	settings.indexType = s.indexConfigs[namespace].IndexType
+	return settings
+}
+
+// Restore implements the Restore RPC. Every namespace in the file is
+// rebuilt off to the side and the checksum verified before any of them is
+// published, so a bad snapshot leaves the server untouched. Existing
+// namespaces are never overwritten unless they are empty; restore under a
+// prefix to keep both copies. A namespace whose snapshot records an index
+// type its saved graph does not serve, such as IVF-PQ or SCANN, is rebuilt
+// as that type once published.
+func (s *Server) Restore(ctx context.Context, req *proto.RestoreRequest) (*proto.RestoreResponse, error) {
+	start := time.Now()
+
+	path, err := s.snapshotPath(req.Path)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, status.Errorf(codes.NotFound, "snapshot %s not found", path)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	defer file.Close()
+
+	// Hash everything read, including what is left after the last namespace
+	hash := sha256.New()
+	br := bufio.NewReader(io.TeeReader(file, hash))
+	namespaces, err := s.readSnapshot(br, req.Prefix)
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "failed to read snapshot %s: %v", path, err)
+	}
+	if _, err := io.Copy(io.Discard, br); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if req.Checksum != "" && !strings.EqualFold(req.Checksum, checksum) {
+		return nil, status.Errorf(codes.DataLoss, "snapshot checksum mismatch: expected %s, got %s", req.Checksum, checksum)
+	}
+
+	if err := s.publishRestored(namespaces); err != nil {
+		return nil, err
+	}
+
+	resp := &proto.RestoreResponse{Checksum: checksum}
+	for _, ns := range namespaces {
+		resp.Namespaces = append(resp.Namespaces, ns.name)
+		resp.VectorsRestored += ns.index.Size()
+		s.invalidateResultCache(ns.name)
+		s.updateIndexMetrics(ns.name, ns.index)
+		s.syncQuota(ns.name, ns.index)
+		s.restoreIndexType(ns.name)
+	}
+	totalTime := time.Since(start)
+	resp.RestoreTimeMs = float32(totalTime.Seconds() * 1000)
+
+	log.Printf("Restored snapshot %s into %d namespaces (%d vectors, took %v)",
+		path, len(namespaces), resp.VectorsRestored, totalTime)
+
+	return resp, nil
+}
+
+// readSnapshot parses a snapshot and rebuilds its namespaces, renamed with prefix
+func (s *Server) readSnapshot(br *bufio.Reader, prefix string) ([]*restoredNamespace, error) {
+	r := &snapshotReader{r: br}
+
+	var magic [4]byte
+	r.read(magic[:])
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", r.err)
+	}
+	if magic != snapshotMagic {
+		return nil, fmt.Errorf("not a snapshot file (bad magic %q)", magic[:])
+	}
+	version := r.byte()
+	if r.err == nil && version != snapshotVersion && version != snapshotVersionNoIndexType &&
+		version != snapshotVersionNoExternalIDs && version != snapshotVersionStringMetadata {
+		return nil, fmt.Errorf("unsupported snapshot format version %d (expected %d)", version, snapshotVersion)
+	}
+
+	count := r.uint32()
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", r.err)
+	}
+
+	var namespaces []*restoredNamespace
+	for i := uint32(0); i < count; i++ {
+		ns, err := s.readNamespace(r, version)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %d of %d: %w", i+1, count, err)
+		}
+		ns.name = prefix + ns.name
+		namespaces = append(namespaces, ns)
+	}
+
+	return namespaces, nil
+}
+
+// readNamespace reads one namespace written by snapshotWriter.namespace
+// in the given snapshot format version
+func (s *Server) readNamespace(r *snapshotReader, version byte) (*restoredNamespace, error) {
+	ns := &restoredNamespace{
+		name:      r.string(),
+		textIndex: s.newTextIndex(),
+		metadata:  make(map[uint64]map[string]interface{}),
+	}
+
+	if r.byte() == 1 {
+		ns.settings.metrics = &NamespaceMetrics{
+			Retrieval:   r.string(),
+			Rerank:      r.string(),
+			RerankDepth: int(r.uint32()),
+		}
+	}
+	ns.settings.dimensionPolicy = r.string()
+	if r.byte() == 1 {
+		multiplier := math.Float64frombits(r.uint64())
+		ns.settings.efSearchMultiplier = &multiplier
+	}
+	ns.settings.normalize = r.byte()
+	if version > snapshotVersionNoIndexType {
+		ns.settings.indexType = r.string()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// Build under the declared metric; a built-in metric saved in the
+	// index replaces it on load anyway
+	indexConfig, err := s.hnswConfig(NamespaceIndexConfig{}, ns.settings.metrics)
+	if err != nil {
+		return nil, err
+	}
+	ns.index = hnsw.New(indexConfig)
+
+	size := r.uint64()
+	if r.err != nil {
+		return nil, r.err
+	}
+	indexReader := io.LimitReader(r.r, int64(size))
+	if err := ns.index.Load(indexReader); err != nil {
+		return nil, fmt.Errorf("failed to load index: %w", err)
+	}
+	if _, err := io.Copy(io.Discard, indexReader); err != nil {
+		return nil, err
+	}
+
+	count := r.uint64()
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		doc := snapshotDocument{id: r.uint64()}
+		pairs := r.uint32()
+		doc.metadata = make(map[string]interface{})
+		for j := uint32(0); j < pairs && r.err == nil; j++ {
+			k := r.string()
+			if version == snapshotVersionStringMetadata {
+				doc.metadata[k] = r.string()
+			} else {
+				doc.metadata[k] = r.value()
+			}
+		}
+		doc.text = r.string()
+		if version > snapshotVersionNoExternalIDs {
+			doc.externalID = r.string()
+		}
+		if r.err != nil {
+			break
+		}
+
+		metaMap := make(map[string]interface{}, len(doc.metadata))
+		for k, v := range doc.metadata {
+			metaMap[k] = v
+		}
+		ns.metadata[doc.id] = metaMap
+		if doc.text != "" {
+			if err := ns.textIndex.Index(&search.Document{ID: doc.id, Text: doc.text, Metadata: metaMap}); err != nil {
+				return nil, fmt.Errorf("failed to index text of vector %d: %w", doc.id, err)
+			}
+		}
+		ns.documents = append(ns.documents, doc)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return ns, nil
+}
+
+// publishRestored swaps restored namespaces in. Writes are held off so no
+// insert lands in an empty namespace while it is being replaced. With the
+// WAL enabled, each restored vector is logged so it survives a restart.
+func (s *Server) publishRestored(namespaces []*restoredNamespace) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ns := range namespaces {
+		if existing, ok := s.indexes[ns.name]; ok && existing.Size() > 0 {
+			return status.Errorf(codes.AlreadyExists,
+				"namespace %q already exists; restore under a prefix", ns.name)
+		}
+	}
+
+	for _, ns := range namespaces {
+		if err := s.openWALLocked(ns.name); err != nil {
+			return status.Errorf(codes.Internal, "failed to open WAL for namespace %s: %v", ns.name, err)
+		}
+		if l := s.wals[ns.name]; l != nil {
+			// Parameters go first, so recovery rebuilds the namespace
+			// under them before replaying its vectors
+			if err := l.Append(restoredIndexConfig(ns).walRecord()); err != nil {
+				return status.Errorf(codes.Internal, "failed to log namespace %s: %v", ns.name, err)
+			}
+			for _, doc := range ns.documents {
+				vector, err := ns.index.GetVector(doc.id)
+				if err != nil {
+					continue // Metadata of a vector the index no longer holds
+				}
+				metadata, typed := splitMetadata(doc.metadata)
+				if err := l.Append(&wal.Record{
+					Op:            wal.OpInsert,
+					ID:            doc.id,
+					Vector:        vector,
+					Metadata:      metadata,
+					TypedMetadata: typed,
+					Text:          doc.text,
+					ExternalID:    doc.externalID,
+				}); err != nil {
+					return status.Errorf(codes.Internal, "failed to log namespace %s: %v", ns.name, err)
+				}
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		s.installRestoredLocked(ns)
+	}
+
+	return nil
+}
+
+// installRestoredLocked replaces a namespace with a restored one, with
+// exactly the snapshot's overrides; the caller holds s.mu
+func (s *Server) installRestoredLocked(ns *restoredNamespace) {
+	s.indexes[ns.name] = ns.index
+	s.textIndexes[ns.name] = ns.textIndex
+	s.hybridSearch[ns.name] = s.newHybridSearch(ns.index, ns.textIndex)
+	delete(s.anns, ns.name)
+	s.metadata[ns.name] = ns.metadata
+	ids := newIDMap(s.config.Database.MaxExternalIDs, s.config.Database.ExternalIDOverflow)
+	for _, doc := range ns.documents {
+		if doc.externalID == "" {
+			continue
+		}
+		if _, err := ids.Put(doc.externalID, doc.id); err != nil {
+			log.Printf("Warning: dropping external ID %q of vector %d in namespace %s: %v", doc.externalID, doc.id, ns.name, err)
+		}
+	}
+	s.externalIDs[ns.name] = ids
+
+	// The restored namespace takes exactly the snapshot's overrides;
+	// its graph carries its own M and efConstruction
+	s.indexConfigs[ns.name] = restoredIndexConfig(ns)
+	delete(s.namespaceMetrics, ns.name)
+	if ns.settings.metrics != nil {
+		s.namespaceMetrics[ns.name] = *ns.settings.metrics
+	}
+	delete(s.dimensionPolicies, ns.name)
+	if ns.settings.dimensionPolicy != "" {
+		s.dimensionPolicies[ns.name] = ns.settings.dimensionPolicy
+	}
+	delete(s.efSearchMultipliers, ns.name)
+	if ns.settings.efSearchMultiplier != nil {
+		s.efSearchMultipliers[ns.name] = *ns.settings.efSearchMultiplier
+	}
+	delete(s.normalizeOnInsert, ns.name)
+	if ns.settings.normalize != snapshotNormalizeUnset {
+		s.normalizeOnInsert[ns.name] = ns.settings.normalize == snapshotNormalizeOn
+	}
+}
+
+// restoredIndexConfig returns the parameters a restored namespace's graph
+// was built with
+func restoredIndexConfig(ns *restoredNamespace) NamespaceIndexConfig {
+	indexConfig := ns.index.Config()
+	config := NamespaceIndexConfig{
+		M:              indexConfig.M,
+		EfConstruction: indexConfig.EfConstruction(),
+		IndexType:      ns.settings.indexType,
+	}
+	if ns.settings.metrics != nil {
+		config.Metric = ns.settings.metrics.Retrieval
+	}
+	return config
+}
+
+// snapshotWriter writes little-endian fields, recording the first error
+type snapshotWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (w *snapshotWriter) write(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+func (w *snapshotWriter) byte(b byte) {
+	w.write([]byte{b})
+}
+
+func (w *snapshotWriter) uint32(v uint32) {
+	w.write(binary.LittleEndian.AppendUint32(nil, v))
+}
+
+func (w *snapshotWriter) uint64(v uint64) {
+	w.write(binary.LittleEndian.AppendUint64(nil, v))
+}
+
+func (w *snapshotWriter) string(s string) {
+	w.uint32(uint32(len(s)))
+	w.write([]byte(s))
+}
+
+// value writes a metadata value as a tag then its string or 8 value bytes
+func (w *snapshotWriter) value(v interface{}) {
+	switch v := v.(type) {
+	case int64:
+		w.byte(snapshotValueInt64)
+		w.uint64(uint64(v))
+	case float64:
+		w.byte(snapshotValueFloat64)
+		w.uint64(math.Float64bits(v))
+	case bool:
+		w.byte(snapshotValueBool)
+		if v {
+			w.uint64(1)
+		} else {
+			w.uint64(0)
+		}
+	default:
+		w.byte(snapshotValueString)
+		w.string(fmt.Sprint(v))
+	}
+}
+
+func (w *snapshotWriter) flush() error {
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	return w.err
+}
+
+// namespace writes a namespace as [name][settings][index size][index]
+// [document count][per document: id, metadata pairs (key, value), text]
+func (w *snapshotWriter) namespace(ns *namespaceSnapshot) {
+	w.string(ns.name)
+
+	if m := ns.settings.metrics; m != nil {
+		w.byte(1)
+		w.string(m.Retrieval)
+		w.string(m.Rerank)
+		w.uint32(uint32(m.RerankDepth))
+	} else {
+		w.byte(0)
+	}
+	w.string(ns.settings.dimensionPolicy)
+	if multiplier := ns.settings.efSearchMultiplier; multiplier != nil {
+		w.byte(1)
+		w.uint64(math.Float64bits(*multiplier))
+	} else {
+		w.byte(0)
+	}
+	w.byte(ns.settings.normalize)
+	w.string(ns.settings.indexType)
+
+	w.uint64(uint64(len(ns.index)))
+	w.write(ns.index)
+
+	w.uint64(uint64(len(ns.documents)))
+	for _, doc := range ns.documents {
+		w.uint64(doc.id)
+		w.uint32(uint32(len(doc.metadata)))
+		for k, v := range doc.metadata {
+			w.string(k)
+			w.value(v)
+		}
+		w.string(doc.text)
+		w.string(doc.externalID)
+	}
+}
+
+// snapshotReader reads little-endian fields, recording the first error
+type snapshotReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (r *snapshotReader) read(b []byte) {
+	if r.err == nil {
+		_, r.err = io.ReadFull(r.r, b)
+	}
+}
+
+func (r *snapshotReader) byte() byte {
+	var b [1]byte
+	r.read(b[:])
+	return b[0]
+}
+
+func (r *snapshotReader) uint32() uint32 {
+	var b [4]byte
+	r.read(b[:])
+	return binary.LittleEndian.Uint32(b[:])
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	var b [8]byte
+	r.read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (r *snapshotReader) string() string {
+	n := r.uint32()
+	if r.err != nil {
+		return ""
+	}
+	if n > maxSnapshotString {
+		r.err = fmt.Errorf("corrupt string length %d", n)
+		return ""
+	}
+	b := make([]byte, n)
+	r.read(b)
+	return string(b)
+}
+
+// value reads a metadata value written by snapshotWriter.value
+func (r *snapshotReader) value() interface{} {
+	switch tag := r.byte(); tag {
+	case snapshotValueString:
+		return r.string()
+	case snapshotValueInt64:
+		return int64(r.uint64())
+	case snapshotValueFloat64:
+		return math.Float64frombits(r.uint64())
+	case snapshotValueBool:
+		return r.uint64() != 0
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("unknown metadata value tag %d", tag)
+		}
+		return nil
+	}
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
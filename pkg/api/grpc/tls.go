@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/config"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// loadTLSConfig loads the server certificate and, when a client CA file is
+// set, the CAs that client certificates must chain to. It returns nil when
+// TLS is disabled.
+func loadTLSConfig(cfg config.ServerConfig) (*tls.Config, error) {
+	if !cfg.EnableTLS {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificates: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in TLS client CA file %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// GatewayCredentials returns the transport credentials for an in-process
+// client such as the REST gateway. Without TLS they are insecure. With TLS
+// the client accepts only this server's own certificate, whatever address
+// it dials, and presents that certificate when client certificates are
+// required, so under mutual TLS it must also chain to the client CA.
+func (s *Server) GatewayCredentials() credentials.TransportCredentials {
+	if s.tlsConfig == nil {
+		return insecure.NewCredentials()
+	}
+
+	own := s.tlsConfig.Certificates[0]
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{own},
+		MinVersion:   tls.VersionTLS12,
+		// The peer is pinned to our certificate below instead of being
+		// verified against the address, which may be 0.0.0.0
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 || !bytes.Equal(state.PeerCertificates[0].Raw, own.Certificate[0]) {
+				return fmt.Errorf("server certificate does not match the local server's")
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier adapts incoming gRPC metadata for trace context extraction
+type metadataCarrier metadata.MD
+
+// Get returns the first value for a key
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set replaces the values for a key
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the metadata keys
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// tracingUnaryInterceptor continues the caller's trace, so handler spans
+// become children of the client span that sent the request
+func tracingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+	return handler(ctx, req)
+}
+
+// startSpan starts a child span of ctx. With tracing disabled the global
+// provider is a no-op and the span is not recorded.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return observability.Tracer().Start(ctx, name)
+}
+
+// startSearchSpan starts a child span of ctx tagged with a search's
+// query parameters
+func startSearchSpan(ctx context.Context, name, namespace string, k, efSearch int) (context.Context, trace.Span) {
+	ctx, span := startSpan(ctx, name)
+	setSearchAttributes(span, namespace, k, efSearch)
+	return ctx, span
+}
+
+// setSearchAttributes tags a search span with its query parameters
+func setSearchAttributes(span trace.Span, namespace string, k, efSearch int) {
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(
+		attribute.String("vector.namespace", namespace),
+		attribute.Int("vector.k", k),
+		attribute.Int("vector.ef_search", efSearch),
+	)
+}
+
+// endSpan records the result count or error and ends the span
+func endSpan(span trace.Span, resultCount int, err error) {
+	if span.IsRecording() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			span.SetAttributes(attribute.Int("vector.result_count", resultCount))
+		}
+	}
+	span.End()
+}
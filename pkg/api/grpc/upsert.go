@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+)
+
+// upsertTarget returns the stored vector an upsert replaces in place: the
+// one its external_id resolves to or, without an external_id, the one its
+// id (external or internal) resolves to. It reports false when there is
+// none, in which case the upsert inserts a new vector.
+func (s *Server) upsertTarget(req *proto.InsertRequest, index *hnsw.Index) (uint64, bool) {
+	if !req.Upsert {
+		return 0, false
+	}
+
+	var id uint64
+	var ok bool
+	if req.ExternalId != nil {
+		if ids := s.externalIDMap(req.Namespace); ids != nil {
+			id, ok = ids.Get(*req.ExternalId)
+		}
+	} else {
+		id, ok = s.resolveID(req.Namespace, req.GetId())
+	}
+	return id, ok && index.GetNode(id) != nil
+}
+
+// replaceInPlace overwrites a stored vector, its metadata and its text with
+// an upsert's, keeping the ID. The node is relinked under the same ID, so
+// neighbors that pointed at it are reconnected instead of losing the edge.
+// The write is logged as an insert under that ID, which replays as a full
+// replacement, before it is applied and under the vector's lock.
+func (s *Server) replaceInPlace(req *proto.InsertRequest, index *hnsw.Index, textIndex *search.FullTextIndex, id uint64) error {
+	defer s.lockVector(req.Namespace, id)()
+
+	// Deleted since upsertTarget found it
+	if index.GetNode(id) == nil {
+		return fmt.Errorf("node with ID %d not found", id)
+	}
+	if err := s.appendWAL(req.Namespace, insertRecord(req, id)); err != nil {
+		return err
+	}
+	if err := index.Update(id, req.Vector); err != nil {
+		return err
+	}
+	s.annUpdate(req.Namespace, id, req.Vector)
+
+	// Drop the old text so an upsert without text leaves none behind
+	s.removeDocument(req.Namespace, textIndex, id)
+	s.storeDocument(req, id, textIndex)
+	return nil
+}
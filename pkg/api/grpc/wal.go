@@ -0,0 +1,291 @@
+package grpc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/wal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// walExt is the file extension of namespace write-ahead logs
+const walExt = ".log"
+
+// walDir returns the directory holding the write-ahead logs
+func (s *Server) walDir() string {
+	return filepath.Join(s.config.Database.DataDir, "wal")
+}
+
+// walPath returns a namespace's log file. Namespaces are path-escaped so
+// any name maps to a single file in the WAL directory.
+func (s *Server) walPath(namespace string) string {
+	return filepath.Join(s.walDir(), url.PathEscape(namespace)+walExt)
+}
+
+// openWALLocked opens a namespace's write-ahead log if the WAL is enabled
+// and it is not already open; the caller holds s.mu
+func (s *Server) openWALLocked(namespace string) error {
+	if !s.config.WAL.Enabled || s.wals[namespace] != nil {
+		return nil
+	}
+
+	opts := wal.Options{
+		SyncInterval: s.config.WAL.SyncInterval,
+		SyncEvery:    s.config.WAL.SyncEvery,
+	}
+	if s.config.Database.SyncWrites {
+		opts.SyncEvery = 1
+	}
+
+	l, err := wal.Open(s.walPath(namespace), opts)
+	if err != nil {
+		return err
+	}
+	s.wals[namespace] = l
+	return nil
+}
+
+// appendWAL logs a write to the namespace's write-ahead log. It is a no-op
+// when the WAL is disabled.
+func (s *Server) appendWAL(namespace string, rec *wal.Record) error {
+	s.mu.RLock()
+	l := s.wals[namespace]
+	s.mu.RUnlock()
+
+	if l == nil {
+		return nil
+	}
+	return l.Append(rec)
+}
+
+// walOrderStripes is the number of locks keeping the WAL records of each
+// vector in the order its writes are applied
+const walOrderStripes = 256
+
+// lockVector locks a stored vector while a write to it is logged and
+// applied, so its records are replayed in the order they took effect.
+// Vectors sharing its stripe wait too. It returns the unlock function.
+func (s *Server) lockVector(namespace string, id uint64) func() {
+	h := fnv.New64a()
+	h.Write([]byte(namespace))
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], id)
+	h.Write(b[:])
+
+	mu := &s.walOrder[h.Sum64()%walOrderStripes]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// closeWALs syncs and closes every open write-ahead log
+func (s *Server) closeWALs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for namespace, l := range s.wals {
+		if err := l.Close(); err != nil {
+			log.Printf("Warning: failed to close WAL for namespace %s: %v", namespace, err)
+		}
+		delete(s.wals, namespace)
+	}
+}
+
+// recoverWAL rebuilds every namespace that has a write-ahead log by
+// replaying its records into fresh indexes, or into the snapshot a
+// checkpointed log continues from
+func (s *Server) recoverWAL() error {
+	entries, err := os.ReadDir(s.walDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read WAL directory: %w", err)
+	}
+
+	// Snapshots read for checkpoints, by file name; each holds every
+	// namespace, so it is read once
+	snapshots := make(map[string][]*restoredNamespace)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != walExt {
+			continue
+		}
+		namespace, err := url.PathUnescape(strings.TrimSuffix(name, walExt))
+		if err != nil {
+			log.Printf("Warning: skipping WAL file %s: %v", name, err)
+			continue
+		}
+
+		// Logged namespaces are recreated even on a strict server
+		if err := s.initNamespace(namespace); err != nil {
+			return fmt.Errorf("failed to initialize namespace %s: %w", namespace, err)
+		}
+		index, textIndex, _, err := s.getNamespaceIndexes(namespace)
+		if err != nil {
+			return fmt.Errorf("failed to initialize namespace %s: %w", namespace, err)
+		}
+
+		count, err := wal.Replay(filepath.Join(s.walDir(), name), func(rec *wal.Record) error {
+			switch rec.Op {
+			case wal.OpConfig:
+				// Declared parameters rebuild the namespace, so later
+				// records go to the new indexes
+				s.replayConfig(namespace, rec)
+				index, textIndex, _, err = s.getNamespaceIndexes(namespace)
+				return err
+			case wal.OpCheckpoint:
+				if err := s.replayCheckpoint(namespace, rec, snapshots); err != nil {
+					return err
+				}
+				index, textIndex, _, err = s.getNamespaceIndexes(namespace)
+				return err
+			}
+			s.replayRecord(namespace, index, textIndex, rec)
+			return nil
+		})
+		if status.Code(err) == codes.DataLoss {
+			return err
+		}
+		if errors.Is(err, wal.ErrCorrupt) {
+			// Replaying past the damage would drop every later write
+			return status.Errorf(codes.DataLoss, "WAL for namespace %s is damaged: %v", namespace, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to replay WAL for namespace %s: %w", namespace, err)
+		}
+
+		log.Printf("Replayed %d WAL records into namespace %s (%d vectors)", count, namespace, index.Size())
+		s.recoverIndexType(namespace)
+	}
+
+	return nil
+}
+
+// replayCheckpoint loads a namespace from the snapshot its log was
+// checkpointed at, with the parameters it had declared then; the records
+// after the checkpoint apply on top. Without that snapshot the writes
+// before the checkpoint are lost, so recovery fails with DataLoss.
+func (s *Server) replayCheckpoint(namespace string, rec *wal.Record, snapshots map[string][]*restoredNamespace) error {
+	path := filepath.Join(s.snapshotDir(), rec.Text)
+	namespaces, ok := snapshots[rec.Text]
+	if !ok {
+		file, err := os.Open(path)
+		if err != nil {
+			return status.Errorf(codes.DataLoss, "WAL for namespace %s continues from snapshot %s: %v", namespace, path, err)
+		}
+		defer file.Close()
+
+		namespaces, err = s.readSnapshot(bufio.NewReader(file), "")
+		if err != nil {
+			return status.Errorf(codes.DataLoss, "failed to read snapshot %s: %v", path, err)
+		}
+		snapshots[rec.Text] = namespaces
+	}
+
+	for _, ns := range namespaces {
+		if ns.name != namespace {
+			continue
+		}
+		config, err := indexConfigFromRecord(rec)
+		if err != nil {
+			return status.Errorf(codes.DataLoss, "WAL checkpoint of namespace %s: %v", namespace, err)
+		}
+
+		s.mu.Lock()
+		s.installRestoredLocked(ns)
+		s.indexConfigs[namespace] = config
+		s.mu.Unlock()
+		return nil
+	}
+	return status.Errorf(codes.DataLoss, "WAL for namespace %s continues from snapshot %s, which does not hold it", namespace, path)
+}
+
+// replayConfig applies logged index parameters. Parameters logged while
+// the namespace was empty rebuild it, as SetNamespaceIndexConfig does;
+// once it holds vectors they were logged by Migrate, and only the index
+// type is recorded, to be rebuilt after the rest of the log is replayed.
+func (s *Server) replayConfig(namespace string, rec *wal.Record) {
+	config, err := indexConfigFromRecord(rec)
+	if err == nil {
+		s.mu.Lock()
+		if index := s.indexes[namespace]; index != nil && index.Size() > 0 {
+			declared := s.indexConfigs[namespace]
+			declared.IndexType = config.IndexType
+			s.indexConfigs[namespace] = declared
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+		err = s.applyIndexConfig(namespace, config)
+	}
+	if err != nil {
+		log.Printf("Warning: skipping WAL index parameters in namespace %s: %v", namespace, err)
+	}
+}
+
+// replayRecord applies a logged write without logging it again. Records
+// are applied by their recorded IDs, and ones that no longer apply (such
+// as deleting a missing ID) are skipped, so replaying a log twice leaves
+// the same state.
+func (s *Server) replayRecord(namespace string, index *hnsw.Index, textIndex *search.FullTextIndex, rec *wal.Record) {
+	var text *string
+	if rec.Text != "" {
+		text = stringPtr(rec.Text)
+	}
+
+	switch rec.Op {
+	case wal.OpInsert:
+		if err := index.Restore(rec.ID, rec.Vector); err != nil {
+			log.Printf("Warning: skipping WAL insert of %d in namespace %s: %v", rec.ID, namespace, err)
+			return
+		}
+		// An upsert in place is logged as an insert of the stored ID and
+		// replaces the whole document
+		s.removeDocument(namespace, textIndex, rec.ID)
+		s.storeDocument(&proto.InsertRequest{
+			Namespace:     namespace,
+			Metadata:      rec.Metadata,
+			TypedMetadata: typedMetadataProto(rec.TypedMetadata),
+			Text:          text,
+		}, rec.ID, textIndex)
+		if rec.ExternalID != "" {
+			s.restoreExternalID(namespace, rec.ExternalID, rec.ID)
+		}
+
+	case wal.OpUpdate, wal.OpMergeUpdate:
+		// An update logged after the vector's delete must not bring it back
+		if index.GetNode(rec.ID) == nil {
+			return
+		}
+		if len(rec.Vector) > 0 {
+			if err := index.Update(rec.ID, rec.Vector); err != nil {
+				log.Printf("Warning: skipping WAL update of %d in namespace %s: %v", rec.ID, namespace, err)
+				return
+			}
+		}
+		if rec.Op == wal.OpMergeUpdate {
+			s.mergeDocument(namespace, textIndex, rec.ID, rec.Metadata, rec.TypedMetadata, rec.RemoveKeys, text)
+		} else {
+			s.updateDocument(namespace, textIndex, rec.ID, rec.Metadata, rec.TypedMetadata, text)
+		}
+
+	case wal.OpDelete:
+		// Already absent when the log is replayed twice
+		_ = index.Delete(rec.ID)
+		s.removeDocument(namespace, textIndex, rec.ID)
+		s.removeExternalID(namespace, rec.ID)
+	}
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+)
+
+// warmup runs random searches in every loaded namespace so its graph is
+// paged into memory, reporting "warming" from HealthCheck until it is done
+func (s *Server) warmup() {
+	defer s.warming.Store(false)
+	start := time.Now()
+
+	s.mu.RLock()
+	indexes := make(map[string]*hnsw.Index, len(s.indexes))
+	namespaces := make([]string, 0, len(s.indexes))
+	for ns, index := range s.indexes {
+		if index.Size() == 0 {
+			continue
+		}
+		indexes[ns] = index
+		namespaces = append(namespaces, ns)
+	}
+	s.mu.RUnlock()
+	sort.Strings(namespaces)
+
+	queries := s.config.Warmup.Queries
+	log.Printf("Warming up %d namespaces with %d queries each", len(namespaces), queries)
+
+	for i, ns := range namespaces {
+		nsStart := time.Now()
+		index := indexes[ns]
+
+		visited, err := index.Warmup(queries, s.searchLimits(ns).DefaultEfSearch)
+		if err != nil {
+			log.Printf("Warning: warmup of namespace %s failed: %v", ns, err)
+			continue
+		}
+		log.Printf("Warmed namespace %s (%d/%d): %d vectors, %d nodes visited (took %v)",
+			ns, i+1, len(namespaces), index.Size(), visited, time.Since(nsStart))
+	}
+
+	log.Printf("Warmup completed (took %v)", time.Since(start))
+}
@@ -0,0 +1,96 @@
+// Package zstd registers a zstd compressor with gRPC. Importing it, on the
+// server or a client, lets that side send and accept zstd-compressed
+// messages, in the same way google.golang.org/grpc/encoding/gzip does for
+// gzip:
+//
+//	import _ "github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/zstd"
+//
+//	stream, err := client.BatchInsert(ctx, grpc.UseCompressor(zstd.Name))
+//
+// zstd compresses about as well as gzip at a fraction of the CPU cost, which
+// matters for bulk loads that are bandwidth-bound.
+package zstd
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name registered for the zstd compressor
+const Name = "zstd"
+
+func init() {
+	c := &compressor{}
+	c.poolCompressor.New = func() interface{} {
+		// Encoders are reset onto each stream; NewWriter(nil) cannot fail
+		// with valid options
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return &writer{Encoder: enc, pool: &c.poolCompressor}
+	}
+	encoding.RegisterCompressor(c)
+}
+
+type compressor struct {
+	poolCompressor   sync.Pool
+	poolDecompressor sync.Pool
+}
+
+// writer returns its encoder to the pool when the message is written
+type writer struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+// reader returns its decoder to the pool once the message is read
+type reader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+// Compress returns a writer that compresses a message into w
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	z := c.poolCompressor.Get().(*writer)
+	z.Encoder.Reset(w)
+	return z, nil
+}
+
+// Close flushes the message and releases the encoder
+func (z *writer) Close() error {
+	defer z.pool.Put(z)
+	return z.Encoder.Close()
+}
+
+// Decompress returns a reader that decompresses a message from r
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	z, inPool := c.poolDecompressor.Get().(*reader)
+	if !inPool {
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &reader{Decoder: dec, pool: &c.poolDecompressor}, nil
+	}
+	if err := z.Decoder.Reset(r); err != nil {
+		c.poolDecompressor.Put(z)
+		return nil, err
+	}
+	return z, nil
+}
+
+// Read reads decompressed bytes, releasing the decoder at the end of the
+// message
+func (z *reader) Read(p []byte) (n int, err error) {
+	n, err = z.Decoder.Read(p)
+	if err == io.EOF {
+		z.pool.Put(z)
+	}
+	return n, err
+}
+
+// Name returns the compressor's registered name
+func (c *compressor) Name() string {
+	return Name
+}
@@ -3,11 +3,12 @@ package rest
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 
+	apidocs "github.com/therealutkarshpriyadarshi/vector/docs/api"
 	pb "github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
 )
 
@@ -64,6 +65,208 @@ func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp, http.StatusOK)
 }
 
+// Validate handles GET /v1/admin/validate/{namespace}
+func (h *Handler) Validate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace := strings.TrimPrefix(r.URL.Path, "/v1/admin/validate/")
+	if namespace == "" || strings.Contains(namespace, "/") {
+		writeError(w, "Invalid URL format, expected /v1/admin/validate/{namespace}", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.Validate(r.Context(), &pb.ValidateRequest{Namespace: namespace})
+	if err != nil {
+		writeError(w, fmt.Sprintf("Validation failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// Compact handles POST /v1/admin/compact/{namespace}
+func (h *Handler) Compact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace := strings.TrimPrefix(r.URL.Path, "/v1/admin/compact/")
+	if namespace == "" || strings.Contains(namespace, "/") {
+		writeError(w, "Invalid URL format, expected /v1/admin/compact/{namespace}", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.Compact(r.Context(), &pb.CompactRequest{Namespace: namespace})
+	if err != nil {
+		writeError(w, fmt.Sprintf("Compaction failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// ListNamespaces handles GET /v1/namespaces
+func (h *Handler) ListNamespaces(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.ListNamespaces(r.Context(), &pb.ListNamespacesRequest{})
+	if err != nil {
+		writeError(w, fmt.Sprintf("Failed to list namespaces: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// CreateNamespace handles POST /v1/namespaces
+func (h *Handler) CreateNamespace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req pb.CreateNamespaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.CreateNamespace(r.Context(), &req)
+	if err != nil {
+		writeError(w, fmt.Sprintf("Failed to create namespace: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusCreated)
+}
+
+// DescribeNamespace handles GET /v1/namespaces/{namespace}
+func (h *Handler) DescribeNamespace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace := strings.TrimPrefix(r.URL.Path, "/v1/namespaces/")
+	if namespace == "" || strings.Contains(namespace, "/") {
+		writeError(w, "Invalid URL format, expected /v1/namespaces/{namespace}", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.DescribeNamespace(r.Context(), &pb.DescribeNamespaceRequest{Namespace: namespace})
+	if err != nil {
+		writeError(w, fmt.Sprintf("Failed to describe namespace: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// Count handles GET /v1/vectors/count. With ?namespace=foo it returns
+// {"namespace":"foo","count":N}; without, {"counts":{"foo":N,...}}.
+func (h *Handler) Count(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	resp, err := h.client.Count(r.Context(), &pb.CountRequest{Namespace: namespace})
+	if err != nil {
+		writeError(w, fmt.Sprintf("Failed to count vectors: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Written by hand so zero counts are not dropped as empty fields
+	if namespace != "" {
+		writeJSON(w, map[string]interface{}{
+			"namespace": namespace,
+			"count":     resp.Counts[namespace],
+		}, http.StatusOK)
+		return
+	}
+	counts := resp.Counts
+	if counts == nil {
+		counts = map[string]int64{}
+	}
+	writeJSON(w, map[string]interface{}{"counts": counts}, http.StatusOK)
+}
+
+// Scan handles GET /v1/vectors/scan?namespace=foo&page_size=N&cursor=C&include_vector=true
+func (h *Handler) Scan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	req := &pb.ScanRequest{
+		Namespace: query.Get("namespace"),
+		Cursor:    query.Get("cursor"),
+	}
+	if value := query.Get("page_size"); value != "" {
+		pageSize, err := strconv.Atoi(value)
+		if err != nil {
+			writeError(w, fmt.Sprintf("Invalid page_size: %v", err), http.StatusBadRequest)
+			return
+		}
+		req.PageSize = int32(pageSize)
+	}
+	if value := query.Get("include_vector"); value != "" {
+		include, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, fmt.Sprintf("Invalid include_vector: %v", err), http.StatusBadRequest)
+			return
+		}
+		req.IncludeVector = include
+	}
+
+	resp, err := h.client.Scan(r.Context(), req)
+	if err != nil {
+		writeError(w, fmt.Sprintf("Scan failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Written by hand so an empty page and the final empty cursor are kept
+	results := resp.Results
+	if results == nil {
+		results = []*pb.FetchResult{}
+	}
+	writeJSON(w, map[string]interface{}{
+		"results":     results,
+		"next_cursor": resp.NextCursor,
+	}, http.StatusOK)
+}
+
+// DropNamespace handles DELETE /v1/admin/namespaces/{namespace}
+func (h *Handler) DropNamespace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace := strings.TrimPrefix(r.URL.Path, "/v1/admin/namespaces/")
+	if namespace == "" || strings.Contains(namespace, "/") {
+		writeError(w, "Invalid URL format, expected /v1/admin/namespaces/{namespace}", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.DropNamespace(r.Context(), &pb.DropNamespaceRequest{Namespace: namespace})
+	if err != nil {
+		writeError(w, fmt.Sprintf("Failed to drop namespace: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
 // Insert handles POST /v1/vectors
 func (h *Handler) Insert(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -150,6 +353,122 @@ func (h *Handler) HybridSearch(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp, http.StatusOK)
 }
 
+// RangeSearch handles POST /v1/vectors/range-search
+func (h *Handler) RangeSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req pb.RangeSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.RangeSearch(r.Context(), &req)
+	if err != nil {
+		writeError(w, fmt.Sprintf("Range search failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if resp.Error != nil && *resp.Error != "" {
+		writeError(w, *resp.Error, http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// BatchSearch handles POST /v1/vectors/batch-search
+func (h *Handler) BatchSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req pb.BatchSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.BatchSearch(r.Context(), &req)
+	if err != nil {
+		writeError(w, fmt.Sprintf("Batch search failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// MultiVectorSearch handles POST /v1/vectors/multi-vector-search
+func (h *Handler) MultiVectorSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req pb.MultiVectorSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.MultiVectorSearch(r.Context(), &req)
+	if err != nil {
+		writeError(w, fmt.Sprintf("Multi-vector search failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// Fetch handles POST /v1/vectors/fetch and GET /v1/vectors/{namespace}/{id}
+func (h *Handler) Fetch(w http.ResponseWriter, r *http.Request) {
+	var req pb.FetchRequest
+
+	switch r.Method {
+	case http.MethodGet:
+		// URL format: /v1/vectors/{namespace}/{id}
+		path := strings.TrimPrefix(r.URL.Path, "/v1/vectors/")
+		parts := strings.SplitN(path, "/", 2)
+		if len(parts) != 2 {
+			writeError(w, "Invalid URL format, expected /v1/vectors/{namespace}/{id}", http.StatusBadRequest)
+			return
+		}
+		req.Namespace = parts[0]
+		req.Ids = []string{parts[1]}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.client.Fetch(r.Context(), &req)
+	if err != nil {
+		writeError(w, fmt.Sprintf("Fetch failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// A single GET returns the item itself
+	if r.Method == http.MethodGet {
+		result := resp.Results[0]
+		if !result.Found {
+			writeError(w, fmt.Sprintf("Vector %s not found", result.Id), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, result, http.StatusOK)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
 // Delete handles DELETE /v1/vectors/{namespace}/{id} and POST /v1/vectors/delete
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	var req pb.DeleteRequest
@@ -251,7 +570,7 @@ func (h *Handler) BatchInsert(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Read request body as array of InsertRequest
-	var requests []pb.InsertRequest
+	var requests []*pb.InsertRequest
 	if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
 		writeError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
 		return
@@ -264,9 +583,13 @@ func (h *Handler) BatchInsert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Send all requests
+	// Send all requests. Send fails with io.EOF once the server ends the
+	// stream, as an aborted batch does; CloseAndRecv then returns its error.
 	for _, req := range requests {
-		if err := stream.Send(&req); err != nil {
+		if err := stream.Send(req); err != nil {
+			if err == io.EOF {
+				break
+			}
 			writeError(w, fmt.Sprintf("Failed to send batch request: %v", err), http.StatusInternalServerError)
 			return
 		}
@@ -282,6 +605,50 @@ func (h *Handler) BatchInsert(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp, http.StatusCreated)
 }
 
+// AsyncBatchInsert handles POST /v1/jobs/batch-insert
+func (h *Handler) AsyncBatchInsert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req pb.AsyncBatchInsertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.AsyncBatchInsert(r.Context(), &req)
+	if err != nil {
+		writeError(w, fmt.Sprintf("Failed to start batch insert job: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusAccepted)
+}
+
+// GetJobStatus handles GET /v1/jobs/{job_id}
+func (h *Handler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jobID := strings.TrimPrefix(r.URL.Path, "/v1/jobs/")
+	if jobID == "" || strings.Contains(jobID, "/") {
+		writeError(w, "Invalid URL format, expected /v1/jobs/{job_id}", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.GetJobStatus(r.Context(), &pb.JobStatusRequest{JobId: jobID})
+	if err != nil {
+		writeError(w, fmt.Sprintf("Failed to get job status: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
 // writeJSON writes a JSON response
 func writeJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
@@ -302,23 +669,16 @@ func writeError(w http.ResponseWriter, message string, statusCode int) {
 	})
 }
 
-// ServeDocs serves the OpenAPI/Swagger documentation
+// ServeDocs serves the OpenAPI/Swagger documentation embedded in the binary
 func ServeDocs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Read and serve the OpenAPI spec file
-	content, err := os.ReadFile("docs/api/openapi.yaml")
-	if err != nil {
-		writeError(w, "OpenAPI spec not found", http.StatusNotFound)
-		return
-	}
-
 	w.Header().Set("Content-Type", "application/x-yaml")
 	w.WriteHeader(http.StatusOK)
-	w.Write(content)
+	w.Write(apidocs.OpenAPISpec)
 }
 
 // ServeSwaggerUI serves the Swagger UI HTML page
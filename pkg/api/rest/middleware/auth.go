@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 	"strings"
@@ -9,15 +10,21 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// AuthConfig holds authentication configuration
+// AuthConfig holds authentication configuration. The gRPC server shares
+// it with REST; there, PublicPaths and AdminPaths hold method names such
+// as HealthCheck.
 type AuthConfig struct {
 	JWTSecret     string
 	Enabled       bool
 	PublicPaths   []string
 	AdminPaths    []string
 	RequireAdmin  bool
+	APIKeys       []string // Static keys accepted in place of a JWT; they grant the admin role
 }
 
+// APIKeyHeader carries an API key, over HTTP and as gRPC metadata
+const APIKeyHeader = "X-API-Key"
+
 // Claims represents JWT claims
 type Claims struct {
 	UserID    string   `json:"user_id"`
@@ -53,40 +60,34 @@ func AuthMiddleware(config AuthConfig) func(http.Handler) http.Handler {
 				}
 			}
 
-			// Extract token from Authorization header
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				writeJSONError(w, "Missing authorization header", http.StatusUnauthorized)
-				return
-			}
-
-			// Parse Bearer token
-			parts := strings.SplitN(authHeader, " ", 2)
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				writeJSONError(w, "Invalid authorization header format", http.StatusUnauthorized)
-				return
-			}
-
-			tokenString := parts[1]
-
-			// Parse and validate JWT token
-			token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-				// Validate signing method
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			// An API key stands in for a token
+			var claims *Claims
+			if key := r.Header.Get(APIKeyHeader); key != "" {
+				var ok bool
+				if claims, ok = config.CheckAPIKey(key); !ok {
+					writeJSONError(w, "Invalid API key", http.StatusUnauthorized)
+					return
+				}
+			} else {
+				// Extract token from Authorization header
+				authHeader := r.Header.Get("Authorization")
+				if authHeader == "" {
+					writeJSONError(w, "Missing authorization header", http.StatusUnauthorized)
+					return
 				}
-				return []byte(config.JWTSecret), nil
-			})
 
-			if err != nil {
-				writeJSONError(w, fmt.Sprintf("Invalid token: %v", err), http.StatusUnauthorized)
-				return
-			}
+				// Parse Bearer token
+				parts := strings.SplitN(authHeader, " ", 2)
+				if len(parts) != 2 || parts[0] != "Bearer" {
+					writeJSONError(w, "Invalid authorization header format", http.StatusUnauthorized)
+					return
+				}
 
-			claims, ok := token.Claims.(*Claims)
-			if !ok || !token.Valid {
-				writeJSONError(w, "Invalid token claims", http.StatusUnauthorized)
-				return
+				var err error
+				if claims, err = config.ParseToken(parts[1]); err != nil {
+					writeJSONError(w, fmt.Sprintf("Invalid token: %v", err), http.StatusUnauthorized)
+					return
+				}
 			}
 
 			// Check if admin role is required for certain paths
@@ -98,7 +99,7 @@ func AuthMiddleware(config AuthConfig) func(http.Handler) http.Handler {
 				}
 			}
 
-			if isAdminPath && !hasRole(claims.Roles, "admin") {
+			if isAdminPath && !claims.IsAdmin() {
 				writeJSONError(w, "Admin privileges required", http.StatusForbidden)
 				return
 			}
@@ -110,6 +111,43 @@ func AuthMiddleware(config AuthConfig) func(http.Handler) http.Handler {
 	}
 }
 
+// ParseToken validates a JWT signed with the configured secret and returns
+// its claims
+func (config AuthConfig) ParseToken(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		// Validate signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(config.JWTSecret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid claims")
+	}
+	return claims, nil
+}
+
+// CheckAPIKey reports whether key is one of the configured API keys and,
+// if so, returns the admin claims it grants
+func (config AuthConfig) CheckAPIKey(key string) (*Claims, bool) {
+	for _, allowed := range config.APIKeys {
+		if allowed != "" && subtle.ConstantTimeCompare([]byte(key), []byte(allowed)) == 1 {
+			return &Claims{UserID: "api-key", Roles: []string{"admin"}}, true
+		}
+	}
+	return nil, false
+}
+
+// IsAdmin reports whether the claims carry the admin role
+func (c *Claims) IsAdmin() bool {
+	return hasRole(c.Roles, "admin")
+}
+
 // GetClaimsFromContext retrieves user claims from request context
 func GetClaimsFromContext(ctx context.Context) (*Claims, bool) {
 	claims, ok := ctx.Value(UserContextKey).(*Claims)
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompressionConfig holds response compression configuration
+type CompressionConfig struct {
+	Enabled  bool
+	MinBytes int // Responses smaller than this are sent uncompressed
+}
+
+// gzipResponseWriter buffers the response body so the compression decision
+// can be made once the full size is known. A handler that flushes streams
+// the response instead.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	buf        bytes.Buffer
+	statusCode int
+	streaming  bool         // Flushed: writes go straight to the client
+	zw         *gzip.Writer // Compresses a streamed response (nil when sent as is)
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	w.statusCode = code
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.streaming {
+		return w.buf.Write(b)
+	}
+	if w.zw != nil {
+		return w.zw.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher. The first flush ends buffering: the
+// headers are sent, gzipped unless the handler set its own
+// Content-Encoding, and the rest of the response is streamed, each flush
+// pushing what was written so far to the client.
+func (w *gzipResponseWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		if w.Header().Get("Content-Encoding") == "" {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Del("Content-Length")
+			w.zw = gzip.NewWriter(w.ResponseWriter)
+		}
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		w.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	if w.zw != nil {
+		w.zw.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// CompressionMiddleware gzips responses of at least MinBytes when the client
+// sends Accept-Encoding: gzip
+func CompressionMiddleware(config CompressionConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !config.Enabled || !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			gw := &gzipResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(gw, r)
+
+			if gw.streaming {
+				if gw.zw != nil {
+					gw.zw.Close()
+				}
+				return
+			}
+
+			body := gw.buf.Bytes()
+			if len(body) < config.MinBytes || w.Header().Get("Content-Encoding") != "" {
+				w.WriteHeader(gw.statusCode)
+				w.Write(body)
+				return
+			}
+
+			var compressed bytes.Buffer
+			zw := gzip.NewWriter(&compressed)
+			if _, err := zw.Write(body); err != nil {
+				w.WriteHeader(gw.statusCode)
+				w.Write(body)
+				return
+			}
+			if err := zw.Close(); err != nil {
+				w.WriteHeader(gw.statusCode)
+				w.Write(body)
+				return
+			}
+
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Set("Content-Length", strconv.Itoa(compressed.Len()))
+			w.WriteHeader(gw.statusCode)
+			w.Write(compressed.Bytes())
+		})
+	}
+}
+
+// acceptsGzip reports whether the request advertises gzip support
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if encoding == "gzip" || encoding == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func largeResultHandler(n int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results := make([]map[string]interface{}, n)
+		for i := range results {
+			results[i] = map[string]interface{}{
+				"id":       i,
+				"distance": float32(i) * 0.01,
+				"metadata": map[string]string{"title": fmt.Sprintf("document %d", i)},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	})
+}
+
+func TestCompressionMiddlewareLargeResponse(t *testing.T) {
+	handler := largeResultHandler(500)
+
+	// Uncompressed reference response
+	plainReq := httptest.NewRequest(http.MethodGet, "/v1/vectors/search", nil)
+	plainRec := httptest.NewRecorder()
+	CompressionMiddleware(CompressionConfig{Enabled: true, MinBytes: 1024})(handler).ServeHTTP(plainRec, plainReq)
+
+	if enc := plainRec.Header().Get("Content-Encoding"); enc != "" {
+		t.Fatalf("Expected no encoding without Accept-Encoding, got %q", enc)
+	}
+	plain := plainRec.Body.Bytes()
+
+	// Compressed response
+	req := httptest.NewRequest(http.MethodGet, "/v1/vectors/search", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	rec := httptest.NewRecorder()
+	CompressionMiddleware(CompressionConfig{Enabled: true, MinBytes: 1024})(handler).ServeHTTP(rec, req)
+
+	if enc := rec.Header().Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("Expected Content-Encoding gzip, got %q", enc)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+
+	compressed := rec.Body.Bytes()
+	if len(compressed) >= len(plain) {
+		t.Errorf("Compressed size %d should be smaller than plain size %d", len(compressed), len(plain))
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("Failed to open gzip body: %v", err)
+	}
+	decoded, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("Failed to decode gzip body: %v", err)
+	}
+	if !bytes.Equal(decoded, plain) {
+		t.Error("Decoded body does not match uncompressed response")
+	}
+}
+
+func TestCompressionMiddlewareSmallResponse(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/vectors", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	CompressionMiddleware(CompressionConfig{Enabled: true, MinBytes: 1024})(handler).ServeHTTP(rec, req)
+
+	if enc := rec.Header().Get("Content-Encoding"); enc != "" {
+		t.Errorf("Small response should not be compressed, got encoding %q", enc)
+	}
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", rec.Code)
+	}
+	if rec.Body.String() != `{"success":true}` {
+		t.Errorf("Unexpected body: %s", rec.Body.String())
+	}
+}
+
+func TestCompressionMiddlewareDisabled(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/vectors/search", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	CompressionMiddleware(CompressionConfig{Enabled: false, MinBytes: 1024})(largeResultHandler(500)).ServeHTTP(rec, req)
+
+	if enc := rec.Header().Get("Content-Encoding"); enc != "" {
+		t.Errorf("Expected no compression when disabled, got %q", enc)
+	}
+}
+
+func TestCompressionMiddlewareFlush(t *testing.T) {
+	next := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first\n"))
+		w.(http.Flusher).Flush()
+		<-next
+		w.Write([]byte("second\n"))
+	})
+
+	server := httptest.NewServer(CompressionMiddleware(CompressionConfig{Enabled: true, MinBytes: 1024})(handler))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if enc := resp.Header.Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("Expected a streamed response to be gzipped, got %q", enc)
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to open gzip body: %v", err)
+	}
+
+	// The flushed chunk arrives while the handler is still blocked
+	line := make([]byte, len("first\n"))
+	if _, err := io.ReadFull(zr, line); err != nil || string(line) != "first\n" {
+		t.Fatalf("Expected the flushed chunk before the handler finished, got %q (%v)", line, err)
+	}
+	close(next)
+
+	rest, err := io.ReadAll(zr)
+	if err != nil || string(rest) != "second\n" {
+		t.Errorf("Expected the rest of the stream, got %q (%v)", rest, err)
+	}
+}
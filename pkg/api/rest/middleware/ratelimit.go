@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ type RateLimitConfig struct {
 	PerIP           bool    // Rate limit per IP address
 	PerUser         bool    // Rate limit per user (requires auth)
 	GlobalLimit     bool    // Global rate limit across all clients
+	SkipPaths       []string // Path prefixes never counted against limits (e.g. health checks)
 }
 
 // RateLimiter manages rate limiting for clients
@@ -96,6 +98,14 @@ func RateLimitMiddleware(limiter *RateLimiter) func(http.Handler) http.Handler {
 				return
 			}
 
+			// Skipped paths don't consume tokens
+			for _, path := range limiter.config.SkipPaths {
+				if strings.HasPrefix(r.URL.Path, path) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
 			// Check global rate limit first
 			if limiter.config.GlobalLimit && limiter.global != nil {
 				if !limiter.global.Allow() {
@@ -130,7 +140,7 @@ func RateLimitMiddleware(limiter *RateLimiter) func(http.Handler) http.Handler {
 
 			// Set rate limit headers
 			w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", limiter.config.Burst))
-			w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", clientLimiter.Tokens()))
+			w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", int(clientLimiter.Tokens())))
 
 			next.ServeHTTP(w, r)
 		})
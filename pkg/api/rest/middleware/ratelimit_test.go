@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimitMiddlewareSkipPaths(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{
+		Enabled:        true,
+		RequestsPerSec: 1,
+		Burst:          2,
+		PerIP:          true,
+		GlobalLimit:    true,
+		SkipPaths:      []string{"/v1/health", "/metrics"},
+	})
+	handler := RateLimitMiddleware(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Health checks far beyond the burst are never limited
+	for i := 0; i < 100; i++ {
+		for _, path := range []string{"/v1/health", "/metrics"} {
+			if code := serve(path); code != http.StatusOK {
+				t.Fatalf("Request %d to %s: expected 200, got %d", i, path, code)
+			}
+		}
+	}
+
+	// ...and leave the budget for real traffic untouched
+	for i := 0; i < 2; i++ {
+		if code := serve("/v1/vectors/search"); code != http.StatusOK {
+			t.Fatalf("Request %d within burst: expected 200, got %d", i, code)
+		}
+	}
+	if code := serve("/v1/vectors/search"); code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 once the burst is used, got %d", code)
+	}
+}
@@ -10,8 +10,11 @@ import (
 
 	pb "github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/api/rest/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 // Config holds the REST server configuration
@@ -19,10 +22,13 @@ type Config struct {
 	Host         string
 	Port         int
 	GRPCAddress  string
+	GRPCCredentials credentials.TransportCredentials // Credentials for the gRPC connection (default: insecure)
 	CORSEnabled  bool
 	CORSOrigins  []string
 	Auth         middleware.AuthConfig
 	RateLimit    middleware.RateLimitConfig
+	Compression  middleware.CompressionConfig
+	MetricsPath  string // Serve Prometheus metrics at this path ("" = disabled)
 }
 
 // Server represents the REST API server
@@ -32,14 +38,19 @@ type Server struct {
 	httpServer *http.Server
 	grpcConn   *grpc.ClientConn
 	mux        *http.ServeMux
+	routes     []string // Registered route patterns, each documented in the OpenAPI spec
 }
 
 // NewServer creates a new REST API server
 func NewServer(config Config) (*Server, error) {
 	// Connect to gRPC server
+	creds := config.GRPCCredentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
 	conn, err := grpc.NewClient(
 		config.GRPCAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
@@ -77,21 +88,54 @@ func NewServer(config Config) (*Server, error) {
 // setupRoutes configures all HTTP routes
 func (s *Server) setupRoutes() {
 	// Health and stats endpoints
-	s.mux.HandleFunc("/v1/health", s.handler.HealthCheck)
-	s.mux.HandleFunc("/v1/stats", s.handler.GetStats)
-	s.mux.HandleFunc("/v1/stats/", s.handler.GetStats)
+	s.handleFunc("/v1/health", s.handler.HealthCheck)
+	s.handleFunc("/v1/stats", s.handler.GetStats)
+	s.handleFunc("/v1/stats/", s.handler.GetStats)
+	s.handleFunc("/v1/namespaces", s.routeNamespaces)
+	s.handleFunc("/v1/namespaces/", s.handler.DescribeNamespace)
+
+	// Admin endpoints
+	s.handleFunc("/v1/admin/validate/", s.handler.Validate)
+	s.handleFunc("/v1/admin/compact/", s.handler.Compact)
+	s.handleFunc("/v1/admin/namespaces/", s.handler.DropNamespace)
 
 	// Vector operations
-	s.mux.HandleFunc("/v1/vectors", s.routeVectors)
-	s.mux.HandleFunc("/v1/vectors/", s.routeVectorsWithPath)
-	s.mux.HandleFunc("/v1/vectors/search", s.handler.Search)
-	s.mux.HandleFunc("/v1/vectors/hybrid-search", s.handler.HybridSearch)
-	s.mux.HandleFunc("/v1/vectors/delete", s.handler.Delete)
-	s.mux.HandleFunc("/v1/vectors/batch", s.handler.BatchInsert)
+	s.handleFunc("/v1/vectors", s.routeVectors)
+	s.handleFunc("/v1/vectors/", s.routeVectorsWithPath)
+	s.handleFunc("/v1/vectors/count", s.handler.Count)
+	s.handleFunc("/v1/vectors/scan", s.handler.Scan)
+	s.handleFunc("/v1/vectors/search", s.handler.Search)
+	s.handleFunc("/v1/vectors/hybrid-search", s.handler.HybridSearch)
+	s.handleFunc("/v1/vectors/range-search", s.handler.RangeSearch)
+	s.handleFunc("/v1/vectors/delete", s.handler.Delete)
+	s.handleFunc("/v1/vectors/batch", s.handler.BatchInsert)
+	s.handleFunc("/v1/vectors/batch-search", s.handler.BatchSearch)
+	s.handleFunc("/v1/vectors/fetch", s.handler.Fetch)
+	s.handleFunc("/v1/vectors/multi-vector-search", s.handler.MultiVectorSearch)
+
+	// Background jobs
+	s.handleFunc("/v1/jobs/batch-insert", s.handler.AsyncBatchInsert)
+	s.handleFunc("/v1/jobs/", s.handler.GetJobStatus)
 
 	// Documentation endpoints
-	s.mux.HandleFunc("/docs", ServeSwaggerUI)
-	s.mux.HandleFunc("/docs/openapi.yaml", ServeDocs)
+	s.handleFunc("/docs", ServeSwaggerUI)
+	s.handleFunc("/docs/openapi.yaml", ServeDocs)
+
+	// Prometheus metrics recorded by the gRPC server in this process
+	if s.config.MetricsPath != "" {
+		s.handle(s.config.MetricsPath, promhttp.Handler())
+	}
+}
+
+// handle registers a route, recording its pattern
+func (s *Server) handle(pattern string, handler http.Handler) {
+	s.routes = append(s.routes, pattern)
+	s.mux.Handle(pattern, handler)
+}
+
+// handleFunc registers a handler function as a route
+func (s *Server) handleFunc(pattern string, handler http.HandlerFunc) {
+	s.handle(pattern, handler)
 }
 
 // routeVectors handles /v1/vectors endpoint
@@ -103,13 +147,26 @@ func (s *Server) routeVectors(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// routeNamespaces handles /v1/namespaces
+func (s *Server) routeNamespaces(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handler.ListNamespaces(w, r)
+	case http.MethodPost:
+		s.handler.CreateNamespace(w, r)
+	default:
+		writeError(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 // routeVectorsWithPath handles /v1/vectors/{namespace}/{id}
 func (s *Server) routeVectorsWithPath(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/v1/vectors/")
 
 	// Check for specific sub-paths
 	if strings.HasPrefix(path, "search") || strings.HasPrefix(path, "hybrid-search") ||
-		strings.HasPrefix(path, "delete") || strings.HasPrefix(path, "batch") {
+		strings.HasPrefix(path, "range-search") || strings.HasPrefix(path, "delete") || strings.HasPrefix(path, "batch") ||
+		strings.HasPrefix(path, "fetch") || strings.HasPrefix(path, "multi-vector-search") {
 		http.NotFound(w, r)
 		return
 	}
@@ -121,7 +178,9 @@ func (s *Server) routeVectorsWithPath(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if r.Method == http.MethodDelete {
+	if r.Method == http.MethodGet {
+		s.handler.Fetch(w, r)
+	} else if r.Method == http.MethodDelete {
 		s.handler.Delete(w, r)
 	} else if r.Method == http.MethodPut || r.Method == http.MethodPatch {
 		s.handler.Update(w, r)
@@ -137,21 +196,45 @@ func (s *Server) withMiddleware(handler http.Handler) http.Handler {
 	// 1. Logging middleware (outermost)
 	handler = loggingMiddleware(handler)
 
-	// 2. CORS middleware
+	// 2. Response compression
+	handler = middleware.CompressionMiddleware(s.config.Compression)(handler)
+
+	// 3. CORS middleware
 	if s.config.CORSEnabled {
 		handler = corsMiddleware(s.config.CORSOrigins)(handler)
 	}
 
-	// 3. Rate limiting
+	// 4. Rate limiting
 	rateLimiter := middleware.NewRateLimiter(s.config.RateLimit)
 	handler = middleware.RateLimitMiddleware(rateLimiter)(handler)
 
-	// 4. Authentication (innermost, runs last)
+	// 5. Authentication
 	handler = middleware.AuthMiddleware(s.config.Auth)(handler)
 
+	// 6. Credential forwarding (innermost, runs last)
+	handler = forwardCredentials(handler)
+
 	return handler
 }
 
+// forwardCredentials passes the caller's API key or bearer token on to the
+// gRPC server as metadata, for when it requires authentication too
+func forwardCredentials(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pairs []string
+		if key := r.Header.Get(middleware.APIKeyHeader); key != "" {
+			pairs = append(pairs, middleware.APIKeyHeader, key)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			pairs = append(pairs, "authorization", auth)
+		}
+		if len(pairs) > 0 {
+			r = r.WithContext(metadata.AppendToOutgoingContext(r.Context(), pairs...))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Start starts the REST API server
 func (s *Server) Start() error {
 	log.Printf("Starting REST API server on %s:%d", s.config.Host, s.config.Port)
@@ -205,6 +288,13 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Flush passes flushes through so streaming handlers keep streaming
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // corsMiddleware adds CORS headers
 func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apidocs "github.com/therealutkarshpriyadarshi/vector/docs/api"
+	"go.yaml.in/yaml/v2"
+)
+
+// documentedPaths returns the paths in the embedded OpenAPI spec
+func documentedPaths(t *testing.T) []string {
+	t.Helper()
+	var spec struct {
+		Paths map[string]interface{} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(apidocs.OpenAPISpec, &spec); err != nil {
+		t.Fatalf("Failed to parse the OpenAPI spec: %v", err)
+	}
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// routeServes reports whether a mux pattern serves a documented path. A
+// pattern ending in "/" serves the paths below it, which the spec writes
+// with parameters such as /v1/jobs/{job_id}.
+func routeServes(pattern, path string) bool {
+	if pattern == path {
+		return true
+	}
+	rest, ok := strings.CutPrefix(path, pattern)
+	return ok && strings.HasSuffix(pattern, "/") && strings.HasPrefix(rest, "{")
+}
+
+func TestRoutesDocumented(t *testing.T) {
+	server, err := NewServer(Config{Host: "localhost", Port: 0, GRPCAddress: "localhost:0", MetricsPath: "/metrics"})
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	defer server.grpcConn.Close()
+
+	paths := documentedPaths(t)
+	for _, pattern := range server.routes {
+		// The metrics path is configurable and not part of the API
+		if pattern == server.config.MetricsPath {
+			continue
+		}
+		documented := false
+		for _, path := range paths {
+			if routeServes(pattern, path) {
+				documented = true
+				break
+			}
+		}
+		if !documented {
+			t.Errorf("Route %s has no path in docs/api/openapi.yaml", pattern)
+		}
+	}
+
+	for _, path := range paths {
+		served := false
+		for _, pattern := range server.routes {
+			if routeServes(pattern, path) {
+				served = true
+				break
+			}
+		}
+		if !served {
+			t.Errorf("Documented path %s is not served by any route", path)
+		}
+	}
+}
+
+func TestServeDocs(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ServeDocs(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	if string(body) != string(apidocs.OpenAPISpec) || !strings.HasPrefix(string(body), "openapi:") {
+		t.Errorf("Expected the embedded spec, got %.40q", body)
+	}
+}
@@ -0,0 +1,218 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+)
+
+// roundingScale rounds query components to six decimal places before
+// hashing, so the same query serialized by different clients shares an entry
+const roundingScale = 1e6
+
+// Query holds the parameters that determine a search's results
+type Query struct {
+	Namespace  string
+	Vector     []float32
+	K          int
+	Offset     int
+	EfSearch   int
+	Filter     string // Canonical filter, search.Filter.String ("" for none)
+	GuaranteeK bool
+	CountTotal bool
+	ScoreMode  string
+	Fields     []string // Result field projection (nil for every field)
+
+	// MaxDistance is the search's distance threshold; nil for none
+	MaxDistance *float32
+}
+
+// Key identifies a cached search
+type Key struct {
+	namespace string
+	sum       [16]byte
+}
+
+// Key hashes the query's parameters
+func (q Query) Key() Key {
+	h := sha256.New()
+
+	var buf [8]byte
+	writeInt := func(v int64) {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		h.Write(buf[:])
+	}
+
+	writeInt(int64(len(q.Vector)))
+	for _, v := range q.Vector {
+		writeInt(int64(math.Round(float64(v) * roundingScale)))
+	}
+	writeInt(int64(q.K))
+	writeInt(int64(q.Offset))
+	writeInt(int64(q.EfSearch))
+	writeBool := func(v bool) {
+		if v {
+			writeInt(1)
+		} else {
+			writeInt(0)
+		}
+	}
+	writeBool(q.GuaranteeK)
+	writeBool(q.CountTotal)
+	writeInt(int64(len(q.ScoreMode)))
+	h.Write([]byte(q.ScoreMode))
+	writeInt(int64(len(q.Filter)))
+	h.Write([]byte(q.Filter))
+	writeInt(int64(len(q.Fields)))
+	for _, field := range q.Fields {
+		writeInt(int64(len(field)))
+		h.Write([]byte(field))
+	}
+	writeBool(q.MaxDistance != nil)
+	if q.MaxDistance != nil {
+		writeInt(int64(math.Float32bits(*q.MaxDistance)))
+	}
+
+	key := Key{namespace: q.Namespace}
+	copy(key.sum[:], h.Sum(nil))
+	return key
+}
+
+// ResultCache is a thread-safe LRU cache of search results shared by all
+// namespaces. Entries are indexed by namespace so a write drops only its
+// own namespace's results. Each namespace also has a generation that
+// invalidation bumps: a search reads it before running, and its result is
+// only stored if no write happened in the meantime.
+type ResultCache struct {
+	capacity int
+	ttl      time.Duration // Time-to-live for entries (0 = no expiration)
+
+	mu          sync.Mutex
+	entries     map[Key]*list.Element
+	lru         *list.List
+	namespaces  map[string]map[*list.Element]struct{} // namespace -> its entries
+	generations map[string]uint64                     // namespace -> invalidation count
+}
+
+// entry is a single cached result
+type entry struct {
+	key       Key
+	value     interface{}
+	expiresAt time.Time
+}
+
+// New creates a result cache holding up to capacity entries, each expiring
+// ttl after it is stored (0 = never)
+func New(capacity int, ttl time.Duration) *ResultCache {
+	return &ResultCache{
+		capacity:    capacity,
+		ttl:         ttl,
+		entries:     make(map[Key]*list.Element),
+		lru:         list.New(),
+		namespaces:  make(map[string]map[*list.Element]struct{}),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Get returns the cached result for key, or false if it is missing or expired
+func (c *ResultCache) Get(key Key) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if c.ttl > 0 && time.Now().After(e.expiresAt) {
+		c.removeElement(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return e.value, true
+}
+
+// Generation returns the namespace's current generation; pass it to Put
+// with the result of a search started afterwards
+func (c *ResultCache) Generation(namespace string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[namespace]
+}
+
+// Put stores a result computed when the key's namespace was at generation.
+// The result is dropped if the namespace has been invalidated since.
+func (c *ResultCache) Put(key Key, generation uint64, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.capacity <= 0 || c.generations[key.namespace] != generation {
+		return
+	}
+
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = time.Now().Add(c.ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	elem := c.lru.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	c.entries[key] = elem
+	members := c.namespaces[key.namespace]
+	if members == nil {
+		members = make(map[*list.Element]struct{})
+		c.namespaces[key.namespace] = members
+	}
+	members[elem] = struct{}{}
+
+	if c.lru.Len() > c.capacity {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// Invalidate drops every entry of a namespace and discards results of
+// searches in it that are still running
+func (c *ResultCache) Invalidate(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[namespace]++
+	for elem := range c.namespaces[namespace] {
+		c.lru.Remove(elem)
+		delete(c.entries, elem.Value.(*entry).key)
+	}
+	delete(c.namespaces, namespace)
+}
+
+// Len returns the number of cached results
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// removeElement drops one entry
+func (c *ResultCache) removeElement(elem *list.Element) {
+	e := elem.Value.(*entry)
+	c.lru.Remove(elem)
+	delete(c.entries, e.key)
+
+	if members := c.namespaces[e.key.namespace]; members != nil {
+		delete(members, elem)
+		if len(members) == 0 {
+			delete(c.namespaces, e.key.namespace)
+		}
+	}
+}
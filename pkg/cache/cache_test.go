@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func key(namespace string, x float32) Key {
+	return Query{Namespace: namespace, Vector: []float32{x, 1}, K: 10, EfSearch: 50}.Key()
+}
+
+func TestQueryKey(t *testing.T) {
+	base := Query{Namespace: "default", Vector: []float32{0.1, 0.2}, K: 10, EfSearch: 50}
+	if base.Key() != base.Key() {
+		t.Fatal("Expected equal queries to share a key")
+	}
+
+	// Components are rounded to six decimal places
+	rounded := base
+	rounded.Vector = []float32{0.1 + 1e-8, 0.2}
+	if rounded.Key() != base.Key() {
+		t.Error("Expected float noise below the rounding precision to share a key")
+	}
+
+	variants := map[string]func(q *Query){
+		"namespace":   func(q *Query) { q.Namespace = "other" },
+		"vector":      func(q *Query) { q.Vector = []float32{0.1, 0.3} },
+		"dimension":   func(q *Query) { q.Vector = []float32{0.1, 0.2, 0} },
+		"k":           func(q *Query) { q.K = 5 },
+		"offset":      func(q *Query) { q.Offset = 10 },
+		"efSearch":    func(q *Query) { q.EfSearch = 100 },
+		"filter":      func(q *Query) { q.Filter = `eq("category","tech")` },
+		"guarantee k": func(q *Query) { q.GuaranteeK = true },
+		"count total": func(q *Query) { q.CountTotal = true },
+		"score mode":  func(q *Query) { q.ScoreMode = "similarity" },
+		"fields":      func(q *Query) { q.Fields = []string{"metadata.title"} },
+		"max distance": func(q *Query) {
+			maxDistance := float32(0.2)
+			q.MaxDistance = &maxDistance
+		},
+	}
+	for name, change := range variants {
+		q := base
+		change(&q)
+		if q.Key() == base.Key() {
+			t.Errorf("Expected a different key when %s changes", name)
+		}
+	}
+}
+
+func TestResultCache(t *testing.T) {
+	c := New(2, 0)
+
+	c.Put(key("a", 1), 0, "one")
+	c.Put(key("a", 2), 0, "two")
+	if value, ok := c.Get(key("a", 1)); !ok || value != "one" {
+		t.Fatalf("Expected cached value one, got %v (found %v)", value, ok)
+	}
+
+	// The least recently used entry is evicted
+	c.Put(key("a", 3), 0, "three")
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+	if _, ok := c.Get(key("a", 2)); ok {
+		t.Error("Expected key 2 to be evicted")
+	}
+	if _, ok := c.Get(key("a", 1)); !ok {
+		t.Error("Expected key 1 to be kept")
+	}
+}
+
+func TestResultCacheInvalidate(t *testing.T) {
+	c := New(10, 0)
+
+	c.Put(key("a", 1), 0, "a1")
+	c.Put(key("a", 2), 0, "a2")
+	c.Put(key("b", 1), 0, "b1")
+
+	c.Invalidate("a")
+	if _, ok := c.Get(key("a", 1)); ok {
+		t.Error("Expected namespace a to be invalidated")
+	}
+	if _, ok := c.Get(key("b", 1)); !ok {
+		t.Error("Expected namespace b to be kept")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", c.Len())
+	}
+
+	// A search that started before the invalidation is not stored
+	if c.Generation("a") != 1 {
+		t.Fatalf("Expected generation 1, got %d", c.Generation("a"))
+	}
+	c.Put(key("a", 1), 0, "stale")
+	if _, ok := c.Get(key("a", 1)); ok {
+		t.Error("Expected result of a stale generation to be dropped")
+	}
+	c.Put(key("a", 1), 1, "fresh")
+	if value, _ := c.Get(key("a", 1)); value != "fresh" {
+		t.Errorf("Expected fresh result, got %v", value)
+	}
+}
+
+func TestResultCacheTTL(t *testing.T) {
+	c := New(10, 10*time.Millisecond)
+
+	c.Put(key("a", 1), 0, "one")
+	if _, ok := c.Get(key("a", 1)); !ok {
+		t.Fatal("Expected entry before it expires")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get(key("a", 1)); ok {
+		t.Error("Expected entry to expire")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected expired entry removed, got %d entries", c.Len())
+	}
+}
+
+func TestResultCacheZeroCapacity(t *testing.T) {
+	c := New(0, 0)
+	c.Put(key("a", 1), 0, "one")
+	if _, ok := c.Get(key("a", 1)); ok || c.Len() != 0 {
+		t.Error("Expected a zero-capacity cache to store nothing")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,11 @@ type Config struct {
 	HNSW     HNSWConfig
 	Cache    CacheConfig
 	Database DatabaseConfig
+	WAL      WALConfig
+	Metrics  MetricsConfig
+	Tracing  TracingConfig
+	Warmup   WarmupConfig
+	Quota    QuotaConfig
 }
 
 // ServerConfig holds gRPC server configuration
@@ -26,6 +32,13 @@ type ServerConfig struct {
 	EnableTLS       bool          // Enable TLS
 	CertFile        string        // TLS certificate file
 	KeyFile         string        // TLS key file
+	ClientCAFile    string        // CA certificates that client certificates must chain to; setting it requires mutual TLS
+	EnableCompression bool        // Negotiate response compression (gzip, or zstd over gRPC) with clients
+	EnableReflection bool         // Serve gRPC reflection for grpcurl and Postman (default: true, false once TLS is enabled)
+	AuthEnabled     bool          // Require an API key or JWT (REST.JWTSecret) on every RPC (default: false)
+	APIKeys         []string      // Keys accepted in the x-api-key header by gRPC and REST
+	PublicMethods   []string      // RPCs that skip authentication (default: ["HealthCheck"])
+	AdminMethods    []string      // RPCs that require the admin role (default: GetStats and maintenance RPCs)
 }
 
 // RESTConfig holds REST API server configuration
@@ -45,6 +58,9 @@ type RESTConfig struct {
 	RateLimitPerIP     bool     // Rate limit per IP (default: true)
 	RateLimitPerUser   bool     // Rate limit per user (default: false)
 	RateLimitGlobal    bool     // Global rate limit (default: false)
+	RateLimitSkipPaths []string // Paths exempt from rate limiting (default: ["/v1/health", "/metrics"])
+	CompressionEnabled  bool    // Gzip large responses when the client accepts it (default: true)
+	CompressionMinBytes int     // Minimum response size to compress (default: 1024)
 }
 
 // HNSWConfig holds HNSW index configuration
@@ -53,6 +69,40 @@ type HNSWConfig struct {
 	EfConstruction int // Construction time accuracy (default: 200)
 	DefaultEfSearch int // Default search time accuracy (default: 50)
 	Dimensions     int // Vector dimensions (default: 768)
+	DimensionPolicy string // Mismatched dimension handling: "strict" or "reject-with-detail" (default: strict)
+	MaxDimensions  int // Largest vector accepted on insert (default: 4096)
+	GuaranteeKMaxCandidates int // Work cap for guarantee_k filtered searches (default: 10000)
+	FilteredSearchMaxVisited int // Most graph nodes a filtered search visits looking for k matches (default: 10000)
+	EfSearchMultiplier float64 // Scale efSearch to at least k * multiplier (default: 0 = disabled)
+	ExactSearchThreshold int // Namespaces with at most this many vectors are searched by brute force (default: 256, 0 = disabled)
+	FlatThreshold  int // Namespaces with at most this many vectors skip building the HNSW graph (default: 256, 0 = always build)
+	RangeSearchMaxResults int // Most vectors a RangeSearch may return (default: 10000)
+	SearchMaxWindow int // Largest offset + k a paginated Search may request (default: 10000)
+	NormalizeOnInsert bool // L2-normalize vectors and queries in cosine namespaces (default: false)
+	NormCheckInterval int // Sample every Nth vector inserted into a cosine namespace and warn once if most are not unit length (default: 100, 0 = disabled)
+	StorageDType   string // Vector storage type: "float32" or "float16", which halves vector memory (default: float32)
+	MaxK           int // Largest k a search may request (default: 0 = unlimited)
+	MaxEfSearch    int // Largest ef_search a search may request (default: 0 = unlimited)
+
+	Namespaces   map[string]NamespaceSearch   // Per-namespace overrides of DefaultEfSearch, MaxK and MaxEfSearch
+	Quantization map[string]QuantizationRange // Per-namespace float range int8 and uint8 vectors map back to
+}
+
+// NamespaceSearch overrides the search defaults and limits for one
+// namespace. A zero field keeps the global setting and a negative MaxK or
+// MaxEfSearch lifts the limit.
+type NamespaceSearch struct {
+	DefaultEfSearch int
+	MaxK            int
+	MaxEfSearch     int
+}
+
+// QuantizationRange is the float range the codes of quantized vectors in
+// one namespace span: int8 codes [-127, 127] and uint8 codes [0, 255] both
+// map onto [Min, Max]
+type QuantizationRange struct {
+	Min float64
+	Max float64
 }
 
 // CacheConfig holds query cache configuration
@@ -65,9 +115,65 @@ type CacheConfig struct {
 // DatabaseConfig holds storage configuration
 type DatabaseConfig struct {
 	DataDir      string // Data directory path
-	EnableWAL    bool   // Enable write-ahead log
-	SyncWrites   bool   // Sync writes to disk
+	EnableWAL    bool   // Deprecated: use WAL.Enabled. A config file or VECTOR_ENABLE_WAL setting it sets WAL.Enabled too (default: true)
+	SyncWrites   bool   // Fsync the write-ahead log after every write (overrides WAL.SyncEvery)
 	MaxNamespaces int   // Max number of namespaces
+
+	MaxExternalIDs     int    // Max external ID mappings per namespace (0 = unlimited)
+	ExternalIDOverflow string // Behavior when the ID map is full: "reject" or "evict"
+
+	BatchInsertWorkers int // Concurrent workers indexing a BatchInsert stream (default: 4, 1 = sequential)
+
+	JobTTL time.Duration // How long a finished AsyncBatchInsert job stays queryable (default: 1h)
+
+	TextTokenizer string // Full-text tokenizer: "whitespace", or "ngram" to index CJK text as character bigrams (default: whitespace)
+
+	// StrictNamespaces refuses requests naming a namespace that was never
+	// created with NotFound, instead of creating it (default: false)
+	StrictNamespaces bool
+}
+
+// WALConfig holds write-ahead log configuration
+type WALConfig struct {
+	Enabled      bool          // Log writes to <data_dir>/wal and replay them on startup (default: false)
+	SyncInterval time.Duration // Background fsync interval (default: 100ms, 0 = disabled)
+	SyncEvery    int           // Fsync after this many writes (default: 0 = disabled)
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Enabled bool   // Record metrics and serve them over REST (default: true)
+	Path    string // REST path serving metrics (default: "/metrics")
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration
+type TracingConfig struct {
+	Enabled     bool    // Export search spans over OTLP (default: false)
+	Endpoint    string  // OTLP/gRPC collector address (default: "localhost:4317")
+	Insecure    bool    // Connect to the collector without TLS (default: true)
+	ServiceName string  // service.name attached to spans (default: "vector")
+	SampleRatio float64 // Fraction of new traces sampled, 0-1 (default: 1)
+}
+
+// WarmupConfig holds startup index warmup configuration
+type WarmupConfig struct {
+	Enabled bool // Search loaded indexes before reporting healthy (default: false)
+	Queries int  // Random searches per namespace (default: 100)
+}
+
+// QuotaConfig holds per-namespace insert quotas
+type QuotaConfig struct {
+	MaxVectors     int // Most vectors a namespace may hold (default: 0 = unlimited)
+	MaxMemoryBytes int // Most estimated memory a namespace may use (default: 0 = unlimited)
+
+	Namespaces map[string]NamespaceQuota // Per-namespace overrides of the limits above
+}
+
+// NamespaceQuota overrides the default quotas for one namespace. A zero
+// field keeps the default and a negative one lifts the limit.
+type NamespaceQuota struct {
+	MaxVectors     int
+	MaxMemoryBytes int
 }
 
 // Default returns default configuration
@@ -80,6 +186,11 @@ func Default() *Config {
 			RequestTimeout:  30 * time.Second,
 			ShutdownTimeout: 10 * time.Second,
 			EnableTLS:       false,
+			EnableCompression: true,
+			EnableReflection: true,
+			AuthEnabled:     false,
+			PublicMethods:   []string{"HealthCheck"},
+			AdminMethods:    []string{"GetStats", "Validate", "Snapshot", "Restore", "Compact", "Migrate", "DropNamespace"},
 		},
 		REST: RESTConfig{
 			Enabled:          true,
@@ -90,19 +201,32 @@ func Default() *Config {
 			AuthEnabled:      false,
 			JWTSecret:        "change-this-secret-in-production",
 			PublicPaths:      []string{"/v1/health", "/docs"},
-			AdminPaths:       []string{"/v1/stats"},
+			AdminPaths:       []string{"/v1/stats", "/v1/admin"},
 			RateLimitEnabled: true,
 			RateLimitPerSec:  10.0,
 			RateLimitBurst:   20,
 			RateLimitPerIP:   true,
 			RateLimitPerUser: false,
 			RateLimitGlobal:  false,
+			RateLimitSkipPaths: []string{"/v1/health", "/metrics"},
+			CompressionEnabled:  true,
+			CompressionMinBytes: 1024,
 		},
 		HNSW: HNSWConfig{
 			M:              16,
 			EfConstruction: 200,
 			DefaultEfSearch: 50,
 			Dimensions:     768,
+			DimensionPolicy: "strict",
+			MaxDimensions:  4096,
+			GuaranteeKMaxCandidates: 10000,
+			FilteredSearchMaxVisited: 10000,
+			ExactSearchThreshold: 256,
+			FlatThreshold:  256,
+			RangeSearchMaxResults: 10000,
+			SearchMaxWindow: 10000,
+			NormCheckInterval: 100,
+			StorageDType:   "float32",
 		},
 		Cache: CacheConfig{
 			Enabled:  true,
@@ -114,6 +238,35 @@ func Default() *Config {
 			EnableWAL:    true,
 			SyncWrites:   false,
 			MaxNamespaces: 100,
+
+			MaxExternalIDs:     1000000,
+			ExternalIDOverflow: "reject",
+
+			BatchInsertWorkers: 4,
+
+			JobTTL: time.Hour,
+
+			TextTokenizer: "whitespace",
+		},
+		WAL: WALConfig{
+			Enabled:      false,
+			SyncInterval: 100 * time.Millisecond,
+			SyncEvery:    0,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Enabled:     false,
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			ServiceName: "vector",
+			SampleRatio: 1.0,
+		},
+		Warmup: WarmupConfig{
+			Enabled: false,
+			Queries: 100,
 		},
 	}
 }
@@ -121,7 +274,12 @@ func Default() *Config {
 // LoadFromEnv loads configuration from environment variables
 func LoadFromEnv() *Config {
 	cfg := Default()
+	applyEnv(cfg)
+	return cfg
+}
 
+// applyEnv overrides cfg with any configuration environment variables that are set
+func applyEnv(cfg *Config) {
 	// Server configuration
 	if host := os.Getenv("VECTOR_HOST"); host != "" {
 		cfg.Server.Host = host
@@ -142,9 +300,39 @@ func LoadFromEnv() *Config {
 		}
 	}
 	if enableTLS := os.Getenv("VECTOR_ENABLE_TLS"); enableTLS == "true" {
+		// TLS marks a production deployment, which keeps its API unlisted
+		// unless reflection is asked for below
+		if !cfg.Server.EnableTLS {
+			cfg.Server.EnableReflection = false
+		}
 		cfg.Server.EnableTLS = true
-		cfg.Server.CertFile = os.Getenv("VECTOR_TLS_CERT")
-		cfg.Server.KeyFile = os.Getenv("VECTOR_TLS_KEY")
+	}
+	if reflection := os.Getenv("VECTOR_ENABLE_REFLECTION"); reflection != "" {
+		cfg.Server.EnableReflection = reflection == "true"
+	}
+	if certFile := os.Getenv("VECTOR_TLS_CERT"); certFile != "" {
+		cfg.Server.CertFile = certFile
+	}
+	if keyFile := os.Getenv("VECTOR_TLS_KEY"); keyFile != "" {
+		cfg.Server.KeyFile = keyFile
+	}
+	if clientCA := os.Getenv("VECTOR_TLS_CLIENT_CA"); clientCA != "" {
+		cfg.Server.ClientCAFile = clientCA
+	}
+	if auth := os.Getenv("VECTOR_GRPC_AUTH_ENABLED"); auth != "" {
+		cfg.Server.AuthEnabled = auth == "true"
+	}
+	if keys := os.Getenv("VECTOR_API_KEYS"); keys != "" {
+		cfg.Server.APIKeys = nil
+		for _, key := range strings.Split(keys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				cfg.Server.APIKeys = append(cfg.Server.APIKeys, key)
+			}
+		}
+	}
+	if compression := os.Getenv("VECTOR_ENABLE_COMPRESSION"); compression == "false" {
+		cfg.Server.EnableCompression = false
+		cfg.REST.CompressionEnabled = false
 	}
 
 	// HNSW configuration
@@ -163,6 +351,70 @@ func LoadFromEnv() *Config {
 			cfg.HNSW.Dimensions = d
 		}
 	}
+	if policy := os.Getenv("VECTOR_DIMENSION_POLICY"); policy != "" {
+		cfg.HNSW.DimensionPolicy = policy
+	}
+	if maxDims := os.Getenv("VECTOR_MAX_DIMENSIONS"); maxDims != "" {
+		if m, err := strconv.Atoi(maxDims); err == nil {
+			cfg.HNSW.MaxDimensions = m
+		}
+	}
+	if mult := os.Getenv("VECTOR_EF_SEARCH_MULTIPLIER"); mult != "" {
+		if m, err := strconv.ParseFloat(mult, 64); err == nil {
+			cfg.HNSW.EfSearchMultiplier = m
+		}
+	}
+	if maxCandidates := os.Getenv("VECTOR_GUARANTEE_K_MAX_CANDIDATES"); maxCandidates != "" {
+		if m, err := strconv.Atoi(maxCandidates); err == nil {
+			cfg.HNSW.GuaranteeKMaxCandidates = m
+		}
+	}
+	if maxVisited := os.Getenv("VECTOR_FILTERED_SEARCH_MAX_VISITED"); maxVisited != "" {
+		if m, err := strconv.Atoi(maxVisited); err == nil {
+			cfg.HNSW.FilteredSearchMaxVisited = m
+		}
+	}
+	if maxResults := os.Getenv("VECTOR_RANGE_SEARCH_MAX_RESULTS"); maxResults != "" {
+		if m, err := strconv.Atoi(maxResults); err == nil {
+			cfg.HNSW.RangeSearchMaxResults = m
+		}
+	}
+	if window := os.Getenv("VECTOR_SEARCH_MAX_WINDOW"); window != "" {
+		if w, err := strconv.Atoi(window); err == nil {
+			cfg.HNSW.SearchMaxWindow = w
+		}
+	}
+	if maxK := os.Getenv("VECTOR_SEARCH_MAX_K"); maxK != "" {
+		if k, err := strconv.Atoi(maxK); err == nil {
+			cfg.HNSW.MaxK = k
+		}
+	}
+	if maxEf := os.Getenv("VECTOR_SEARCH_MAX_EF"); maxEf != "" {
+		if ef, err := strconv.Atoi(maxEf); err == nil {
+			cfg.HNSW.MaxEfSearch = ef
+		}
+	}
+	if threshold := os.Getenv("VECTOR_EXACT_SEARCH_THRESHOLD"); threshold != "" {
+		if t, err := strconv.Atoi(threshold); err == nil {
+			cfg.HNSW.ExactSearchThreshold = t
+		}
+	}
+	if threshold := os.Getenv("VECTOR_FLAT_THRESHOLD"); threshold != "" {
+		if t, err := strconv.Atoi(threshold); err == nil {
+			cfg.HNSW.FlatThreshold = t
+		}
+	}
+	if normalize := os.Getenv("VECTOR_NORMALIZE_ON_INSERT"); normalize != "" {
+		cfg.HNSW.NormalizeOnInsert = normalize == "true"
+	}
+	if interval := os.Getenv("VECTOR_NORM_CHECK_INTERVAL"); interval != "" {
+		if i, err := strconv.Atoi(interval); err == nil {
+			cfg.HNSW.NormCheckInterval = i
+		}
+	}
+	if dtype := os.Getenv("VECTOR_STORAGE_DTYPE"); dtype != "" {
+		cfg.HNSW.StorageDType = dtype
+	}
 
 	// Cache configuration
 	if cacheEnabled := os.Getenv("VECTOR_CACHE_ENABLED"); cacheEnabled == "false" {
@@ -183,12 +435,98 @@ func LoadFromEnv() *Config {
 	if dataDir := os.Getenv("VECTOR_DATA_DIR"); dataDir != "" {
 		cfg.Database.DataDir = dataDir
 	}
-	if wal := os.Getenv("VECTOR_ENABLE_WAL"); wal == "false" {
-		cfg.Database.EnableWAL = false
-	}
 	if sync := os.Getenv("VECTOR_SYNC_WRITES"); sync == "true" {
 		cfg.Database.SyncWrites = true
 	}
+	if maxIDs := os.Getenv("VECTOR_MAX_EXTERNAL_IDS"); maxIDs != "" {
+		if m, err := strconv.Atoi(maxIDs); err == nil {
+			cfg.Database.MaxExternalIDs = m
+		}
+	}
+	if overflow := os.Getenv("VECTOR_EXTERNAL_ID_OVERFLOW"); overflow != "" {
+		cfg.Database.ExternalIDOverflow = overflow
+	}
+	if workers := os.Getenv("VECTOR_BATCH_INSERT_WORKERS"); workers != "" {
+		if w, err := strconv.Atoi(workers); err == nil {
+			cfg.Database.BatchInsertWorkers = w
+		}
+	}
+	if ttl := os.Getenv("VECTOR_JOB_TTL"); ttl != "" {
+		if t, err := time.ParseDuration(ttl); err == nil {
+			cfg.Database.JobTTL = t
+		}
+	}
+	if tokenizer := os.Getenv("VECTOR_TEXT_TOKENIZER"); tokenizer != "" {
+		cfg.Database.TextTokenizer = tokenizer
+	}
+	if strict := os.Getenv("VECTOR_STRICT_NAMESPACES"); strict == "true" {
+		cfg.Database.StrictNamespaces = true
+	}
+
+	// WAL configuration
+	if wal := os.Getenv("VECTOR_ENABLE_WAL"); wal != "" {
+		cfg.WAL.Enabled = wal == "true"
+		cfg.Database.EnableWAL = cfg.WAL.Enabled
+	}
+	if interval := os.Getenv("VECTOR_WAL_SYNC_INTERVAL"); interval != "" {
+		if i, err := time.ParseDuration(interval); err == nil {
+			cfg.WAL.SyncInterval = i
+		}
+	}
+	if every := os.Getenv("VECTOR_WAL_SYNC_EVERY"); every != "" {
+		if e, err := strconv.Atoi(every); err == nil {
+			cfg.WAL.SyncEvery = e
+		}
+	}
+
+	// Metrics configuration
+	if metrics := os.Getenv("VECTOR_METRICS_ENABLED"); metrics != "" {
+		cfg.Metrics.Enabled = metrics == "true"
+	}
+	if path := os.Getenv("VECTOR_METRICS_PATH"); path != "" {
+		cfg.Metrics.Path = path
+	}
+
+	// Tracing configuration
+	if tracing := os.Getenv("VECTOR_TRACING_ENABLED"); tracing != "" {
+		cfg.Tracing.Enabled = tracing == "true"
+	}
+	if endpoint := os.Getenv("VECTOR_TRACING_ENDPOINT"); endpoint != "" {
+		cfg.Tracing.Endpoint = endpoint
+	}
+	if insecure := os.Getenv("VECTOR_TRACING_INSECURE"); insecure != "" {
+		cfg.Tracing.Insecure = insecure == "true"
+	}
+	if name := os.Getenv("VECTOR_TRACING_SERVICE_NAME"); name != "" {
+		cfg.Tracing.ServiceName = name
+	}
+	if ratio := os.Getenv("VECTOR_TRACING_SAMPLE_RATIO"); ratio != "" {
+		if r, err := strconv.ParseFloat(ratio, 64); err == nil {
+			cfg.Tracing.SampleRatio = r
+		}
+	}
+
+	// Warmup configuration
+	if warmup := os.Getenv("VECTOR_WARMUP_ENABLED"); warmup != "" {
+		cfg.Warmup.Enabled = warmup == "true"
+	}
+	if queries := os.Getenv("VECTOR_WARMUP_QUERIES"); queries != "" {
+		if q, err := strconv.Atoi(queries); err == nil {
+			cfg.Warmup.Queries = q
+		}
+	}
+
+	// Quota configuration
+	if maxVectors := os.Getenv("VECTOR_QUOTA_MAX_VECTORS"); maxVectors != "" {
+		if n, err := strconv.Atoi(maxVectors); err == nil {
+			cfg.Quota.MaxVectors = n
+		}
+	}
+	if maxMemory := os.Getenv("VECTOR_QUOTA_MAX_MEMORY_BYTES"); maxMemory != "" {
+		if n, err := strconv.Atoi(maxMemory); err == nil {
+			cfg.Quota.MaxMemoryBytes = n
+		}
+	}
 
 	// REST API configuration
 	if restEnabled := os.Getenv("VECTOR_REST_ENABLED"); restEnabled == "false" {
@@ -224,8 +562,11 @@ func LoadFromEnv() *Config {
 			cfg.REST.RateLimitBurst = b
 		}
 	}
-
-	return cfg
+	if minBytes := os.Getenv("VECTOR_REST_COMPRESSION_MIN_BYTES"); minBytes != "" {
+		if m, err := strconv.Atoi(minBytes); err == nil {
+			cfg.REST.CompressionMinBytes = m
+		}
+	}
 }
 
 // Validate checks if the configuration is valid
@@ -241,6 +582,11 @@ func (c *Config) Validate() error {
 		if c.Server.CertFile == "" || c.Server.KeyFile == "" {
 			return fmt.Errorf("TLS enabled but cert or key file not specified")
 		}
+	} else if c.Server.ClientCAFile != "" {
+		return fmt.Errorf("TLS client CA file specified but TLS is not enabled")
+	}
+	if c.Server.AuthEnabled && len(c.Server.APIKeys) == 0 && c.REST.JWTSecret == "" {
+		return fmt.Errorf("gRPC auth enabled but neither API keys nor a JWT secret specified")
 	}
 
 	// HNSW validation
@@ -253,6 +599,65 @@ func (c *Config) Validate() error {
 	if c.HNSW.Dimensions < 1 {
 		return fmt.Errorf("invalid dimensions: %d (must be > 0)", c.HNSW.Dimensions)
 	}
+	if c.HNSW.MaxDimensions < c.HNSW.Dimensions {
+		return fmt.Errorf("invalid max dimensions: %d (must be >= dimensions %d)", c.HNSW.MaxDimensions, c.HNSW.Dimensions)
+	}
+	if c.HNSW.EfSearchMultiplier < 0 {
+		return fmt.Errorf("invalid efSearch multiplier: %v (must be >= 0)", c.HNSW.EfSearchMultiplier)
+	}
+	if c.HNSW.GuaranteeKMaxCandidates < 0 {
+		return fmt.Errorf("invalid guarantee_k max candidates: %d (must be >= 0)", c.HNSW.GuaranteeKMaxCandidates)
+	}
+	if c.HNSW.FilteredSearchMaxVisited < 0 {
+		return fmt.Errorf("invalid filtered search max visited: %d (must be >= 0)", c.HNSW.FilteredSearchMaxVisited)
+	}
+	if c.HNSW.NormCheckInterval < 0 {
+		return fmt.Errorf("invalid norm check interval: %d (must be >= 0)", c.HNSW.NormCheckInterval)
+	}
+	if c.HNSW.RangeSearchMaxResults < 1 {
+		return fmt.Errorf("invalid range search max results: %d (must be > 0)", c.HNSW.RangeSearchMaxResults)
+	}
+	if c.HNSW.SearchMaxWindow < 1 {
+		return fmt.Errorf("invalid search max window: %d (must be > 0)", c.HNSW.SearchMaxWindow)
+	}
+	if c.HNSW.MaxK < 0 {
+		return fmt.Errorf("invalid search max k: %d (must be >= 0)", c.HNSW.MaxK)
+	}
+	if c.HNSW.MaxEfSearch < 0 {
+		return fmt.Errorf("invalid search max ef_search: %d (must be >= 0)", c.HNSW.MaxEfSearch)
+	}
+	for namespace, override := range c.HNSW.Namespaces {
+		if namespace == "" {
+			return fmt.Errorf("invalid search override: namespace name is empty")
+		}
+		if override.DefaultEfSearch < 0 {
+			return fmt.Errorf("invalid default ef_search for namespace %s: %d (must be >= 0)", namespace, override.DefaultEfSearch)
+		}
+	}
+	for namespace, r := range c.HNSW.Quantization {
+		if namespace == "" {
+			return fmt.Errorf("invalid quantization range: namespace name is empty")
+		}
+		if !(r.Max > r.Min) {
+			return fmt.Errorf("invalid quantization range for namespace %s: [%v, %v] (max must be > min)", namespace, r.Min, r.Max)
+		}
+	}
+	if c.HNSW.ExactSearchThreshold < 0 {
+		return fmt.Errorf("invalid exact search threshold: %d (must be >= 0)", c.HNSW.ExactSearchThreshold)
+	}
+	if c.HNSW.FlatThreshold < 0 {
+		return fmt.Errorf("invalid flat threshold: %d (must be >= 0)", c.HNSW.FlatThreshold)
+	}
+	switch c.HNSW.DimensionPolicy {
+	case "", "strict", "reject-with-detail":
+	default:
+		return fmt.Errorf("invalid dimension policy: %q (must be strict or reject-with-detail)", c.HNSW.DimensionPolicy)
+	}
+	switch c.HNSW.StorageDType {
+	case "", "float32", "float16":
+	default:
+		return fmt.Errorf("invalid storage dtype: %q (must be float32 or float16)", c.HNSW.StorageDType)
+	}
 
 	// Cache validation
 	if c.Cache.Enabled && c.Cache.Capacity < 1 {
@@ -263,6 +668,64 @@ func (c *Config) Validate() error {
 	if c.Database.DataDir == "" {
 		return fmt.Errorf("data directory not specified")
 	}
+	if c.Database.MaxExternalIDs < 0 {
+		return fmt.Errorf("invalid max external IDs: %d (must be >= 0)", c.Database.MaxExternalIDs)
+	}
+	switch c.Database.ExternalIDOverflow {
+	case "", "reject", "evict":
+	default:
+		return fmt.Errorf("invalid external ID overflow policy: %q (must be reject or evict)", c.Database.ExternalIDOverflow)
+	}
+	if c.Database.BatchInsertWorkers < 0 {
+		return fmt.Errorf("invalid batch insert workers: %d (must be >= 0)", c.Database.BatchInsertWorkers)
+	}
+	if c.Database.JobTTL <= 0 {
+		return fmt.Errorf("invalid job TTL: %v (must be > 0)", c.Database.JobTTL)
+	}
+	switch c.Database.TextTokenizer {
+	case "", "whitespace", "ngram":
+	default:
+		return fmt.Errorf("invalid text tokenizer: %q (must be whitespace or ngram)", c.Database.TextTokenizer)
+	}
+
+	// WAL validation
+	if c.WAL.SyncInterval < 0 {
+		return fmt.Errorf("invalid WAL sync interval: %v (must be >= 0)", c.WAL.SyncInterval)
+	}
+	if c.WAL.SyncEvery < 0 {
+		return fmt.Errorf("invalid WAL sync every: %d (must be >= 0)", c.WAL.SyncEvery)
+	}
+
+	// Metrics validation
+	if c.Metrics.Enabled && !strings.HasPrefix(c.Metrics.Path, "/") {
+		return fmt.Errorf("invalid metrics path: %q (must start with /)", c.Metrics.Path)
+	}
+
+	// Tracing validation
+	if c.Tracing.Enabled && c.Tracing.Endpoint == "" {
+		return fmt.Errorf("tracing enabled but OTLP endpoint not specified")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("invalid tracing sample ratio: %v (must be 0-1)", c.Tracing.SampleRatio)
+	}
+
+	// Warmup validation
+	if c.Warmup.Queries < 0 {
+		return fmt.Errorf("invalid warmup queries: %d (must be >= 0)", c.Warmup.Queries)
+	}
+
+	// Quota validation
+	if c.Quota.MaxVectors < 0 {
+		return fmt.Errorf("invalid quota max vectors: %d (must be >= 0)", c.Quota.MaxVectors)
+	}
+	if c.Quota.MaxMemoryBytes < 0 {
+		return fmt.Errorf("invalid quota max memory bytes: %d (must be >= 0)", c.Quota.MaxMemoryBytes)
+	}
+	for namespace := range c.Quota.Namespaces {
+		if namespace == "" {
+			return fmt.Errorf("invalid quota override: namespace name is empty")
+		}
+	}
 
 	return nil
 }
@@ -32,6 +32,9 @@ func TestDefault(t *testing.T) {
 	if cfg.Server.EnableTLS {
 		t.Error("Expected TLS disabled by default")
 	}
+	if !cfg.Server.EnableReflection {
+		t.Error("Expected reflection enabled by default")
+	}
 
 	// Test HNSW defaults
 	if cfg.HNSW.M != 16 {
@@ -65,12 +68,47 @@ func TestDefault(t *testing.T) {
 	if !cfg.Database.EnableWAL {
 		t.Error("Expected WAL enabled by default")
 	}
+	if cfg.WAL.Enabled {
+		t.Error("Expected WAL disabled by default")
+	}
+	if cfg.WAL.SyncInterval != 100*time.Millisecond {
+		t.Errorf("Expected WAL sync interval 100ms, got %v", cfg.WAL.SyncInterval)
+	}
 	if cfg.Database.SyncWrites {
 		t.Error("Expected sync writes disabled by default")
 	}
 	if cfg.Database.MaxNamespaces != 100 {
 		t.Errorf("Expected max namespaces 100, got %d", cfg.Database.MaxNamespaces)
 	}
+
+	// Test Metrics defaults
+	if !cfg.Metrics.Enabled || cfg.Metrics.Path != "/metrics" {
+		t.Errorf("Expected metrics enabled at /metrics, got %v at %q", cfg.Metrics.Enabled, cfg.Metrics.Path)
+	}
+
+	// Test pagination window default
+	if cfg.HNSW.SearchMaxWindow != 10000 {
+		t.Errorf("Expected search max window 10000, got %d", cfg.HNSW.SearchMaxWindow)
+	}
+
+	// Test storage default
+	if cfg.HNSW.StorageDType != "float32" {
+		t.Errorf("Expected float32 storage, got %q", cfg.HNSW.StorageDType)
+	}
+
+	// Test Warmup defaults
+	if cfg.Warmup.Enabled || cfg.Warmup.Queries != 100 {
+		t.Errorf("Expected warmup disabled with 100 queries, got %v with %d", cfg.Warmup.Enabled, cfg.Warmup.Queries)
+	}
+
+	// Test Tracing defaults
+	if cfg.Tracing.Enabled {
+		t.Error("Expected tracing disabled by default")
+	}
+	if cfg.Tracing.Endpoint != "localhost:4317" || cfg.Tracing.SampleRatio != 1.0 {
+		t.Errorf("Expected OTLP endpoint localhost:4317 sampling everything, got %q at %v",
+			cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio)
+	}
 }
 
 func TestLoadFromEnv(t *testing.T) {
@@ -82,6 +120,7 @@ func TestLoadFromEnv(t *testing.T) {
 		"VECTOR_HNSW_M", "VECTOR_HNSW_EF_CONSTRUCTION", "VECTOR_DIMENSIONS",
 		"VECTOR_CACHE_ENABLED", "VECTOR_CACHE_CAPACITY", "VECTOR_CACHE_TTL",
 		"VECTOR_DATA_DIR", "VECTOR_ENABLE_WAL", "VECTOR_SYNC_WRITES",
+		"VECTOR_STRICT_NAMESPACES",
 	}
 
 	for _, key := range envVars {
@@ -120,6 +159,7 @@ func TestLoadFromEnv(t *testing.T) {
 	os.Setenv("VECTOR_DATA_DIR", "/var/lib/vectordb")
 	os.Setenv("VECTOR_ENABLE_WAL", "false")
 	os.Setenv("VECTOR_SYNC_WRITES", "true")
+	os.Setenv("VECTOR_STRICT_NAMESPACES", "true")
 
 	cfg := LoadFromEnv()
 
@@ -173,6 +213,52 @@ func TestLoadFromEnv(t *testing.T) {
 	if !cfg.Database.SyncWrites {
 		t.Error("Expected sync writes enabled")
 	}
+	if !cfg.Database.StrictNamespaces {
+		t.Error("Expected strict namespaces enabled")
+	}
+}
+
+func TestLoadFromEnvAPIKeys(t *testing.T) {
+	t.Setenv("VECTOR_GRPC_AUTH_ENABLED", "true")
+	t.Setenv("VECTOR_API_KEYS", "key-a, key-b,,")
+
+	cfg := LoadFromEnv()
+	if !cfg.Server.AuthEnabled {
+		t.Error("Expected gRPC auth enabled")
+	}
+	if len(cfg.Server.APIKeys) != 2 || cfg.Server.APIKeys[0] != "key-a" || cfg.Server.APIKeys[1] != "key-b" {
+		t.Errorf("Expected API keys [key-a key-b], got %q", cfg.Server.APIKeys)
+	}
+
+	// Without keys, the JWT secret alone is enough
+	cfg.Server.APIKeys = nil
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected JWT-only auth to be valid, got %v", err)
+	}
+	cfg.REST.JWTSecret = ""
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected auth without keys or secret to be invalid")
+	}
+}
+
+func TestLoadFromEnvReflection(t *testing.T) {
+	t.Setenv("VECTOR_ENABLE_TLS", "true")
+	t.Setenv("VECTOR_ENABLE_REFLECTION", "")
+
+	if cfg := LoadFromEnv(); cfg.Server.EnableReflection {
+		t.Error("Expected TLS to disable reflection by default")
+	}
+
+	t.Setenv("VECTOR_ENABLE_REFLECTION", "true")
+	if cfg := LoadFromEnv(); !cfg.Server.EnableReflection {
+		t.Error("Expected VECTOR_ENABLE_REFLECTION to enable reflection with TLS")
+	}
+
+	t.Setenv("VECTOR_ENABLE_TLS", "")
+	t.Setenv("VECTOR_ENABLE_REFLECTION", "false")
+	if cfg := LoadFromEnv(); cfg.Server.EnableReflection {
+		t.Error("Expected VECTOR_ENABLE_REFLECTION=false to disable reflection")
+	}
 }
 
 func TestLoadFromEnv_InvalidValues(t *testing.T) {
@@ -284,6 +370,180 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Max dimensions below dimensions",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.MaxDimensions = 512
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Client CA without TLS",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Server.ClientCAFile = "ca.pem"
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Negative WAL sync every",
+			config: func() *Config {
+				cfg := Default()
+				cfg.WAL.SyncEvery = -1
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Relative metrics path",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Metrics.Path = "metrics"
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Tracing without endpoint",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Tracing.Enabled = true
+				cfg.Tracing.Endpoint = ""
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Negative warmup queries",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Warmup.Queries = -1
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Unknown storage dtype",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.StorageDType = "int8"
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Zero search max window",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.SearchMaxWindow = 0
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Negative search max k",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.MaxK = -1
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Negative namespace default ef_search",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.Namespaces = map[string]NamespaceSearch{"docs": {DefaultEfSearch: -1}}
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Namespace lifting search limits",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.MaxK = 100
+				cfg.HNSW.Namespaces = map[string]NamespaceSearch{"docs": {MaxK: -1, MaxEfSearch: -1}}
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "Empty quantization range",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.Quantization = map[string]QuantizationRange{"images": {Min: 1, Max: 1}}
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Namespace quantization range",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.Quantization = map[string]QuantizationRange{"images": {Min: -1, Max: 1}}
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "Float16 storage",
+			config: func() *Config {
+				cfg := Default()
+				cfg.HNSW.StorageDType = "float16"
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "Negative quota max vectors",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Quota.MaxVectors = -1
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Negative quota override lifts the limit",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Quota.MaxVectors = 100
+				cfg.Quota.Namespaces = map[string]NamespaceQuota{"bulk": {MaxVectors: -1}}
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "Ngram text tokenizer",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Database.TextTokenizer = "ngram"
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "Unknown text tokenizer",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Database.TextTokenizer = "stemmer"
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "Tracing sample ratio above one",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Tracing.SampleRatio = 1.5
+				return cfg
+			}(),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -318,3 +578,22 @@ func TestServerConfig_Address(t *testing.T) {
 		t.Errorf("Expected default address %s, got %s", expected, addr)
 	}
 }
+
+func TestValidateExternalIDOverflow(t *testing.T) {
+	cfg := Default()
+	cfg.Database.ExternalIDOverflow = "evict"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected evict policy to be valid, got %v", err)
+	}
+
+	cfg.Database.ExternalIDOverflow = "drop"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for unknown overflow policy")
+	}
+
+	cfg = Default()
+	cfg.Database.MaxExternalIDs = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for negative max external IDs")
+	}
+}
@@ -0,0 +1,309 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v2"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// LoadFromFile loads configuration from a YAML (.yaml, .yml) or JSON (.json)
+// file on top of the defaults, then applies environment variable overrides.
+//
+// Keys match field names case-insensitively, ignoring underscores and
+// dashes, so max_connections, maxConnections and MaxConnections are the
+// same key. Durations are strings such as "30s". Unknown keys are logged
+// as warnings and ignored. The result is not validated; callers apply any
+// flag overrides and then call Validate.
+func LoadFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values, err := parseConfigFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	var unknown []string
+	if err := applyValues(reflect.ValueOf(cfg).Elem(), values, "", &unknown); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	for _, key := range unknown {
+		log.Printf("Warning: unknown config key %q in %s (ignored)", key, path)
+	}
+
+	// Reflection defaults to off with TLS unless the file turns it on
+	if cfg.Server.EnableTLS && !hasKey(values, "server", "enable_reflection") {
+		cfg.Server.EnableReflection = false
+	}
+
+	// database.enable_wal predates the wal section and still turns the
+	// WAL on or off, unless wal.enabled is set as well
+	if hasKey(values, "database", "enable_wal") {
+		log.Printf("Warning: database.enable_wal in %s is deprecated; use wal.enabled", path)
+		if !hasKey(values, "wal", "enabled") {
+			cfg.WAL.Enabled = cfg.Database.EnableWAL
+		}
+	}
+
+	applyEnv(cfg)
+	return cfg, nil
+}
+
+// parseConfigFile decodes a config file into nested string-keyed maps,
+// choosing the format from the file extension
+func parseConfigFile(path string, data []byte) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON config %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML config %s: %w", path, err)
+		}
+		if doc == nil {
+			return values, nil
+		}
+		m, ok := stringKeys(doc).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config file %s must contain a mapping at the top level", path)
+		}
+		values = m
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (expected .yaml, .yml or .json)", ext)
+	}
+
+	return values, nil
+}
+
+// stringKeys converts the map[interface{}]interface{} values produced by
+// the YAML decoder into map[string]interface{}, recursively
+func stringKeys(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = stringKeys(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range val {
+			val[i] = stringKeys(item)
+		}
+	}
+	return v
+}
+
+// normalizeKey folds case and drops separators so snake_case, kebab-case
+// and CamelCase keys all match the Go field name
+func normalizeKey(key string) string {
+	key = strings.ReplaceAll(key, "_", "")
+	key = strings.ReplaceAll(key, "-", "")
+	return strings.ToLower(key)
+}
+
+// hasKey reports whether the nested sections of values set the key at path,
+// matching keys as applyValues does
+func hasKey(values map[string]interface{}, path ...string) bool {
+	for i, name := range path {
+		var value interface{}
+		found := false
+		for key, v := range values {
+			if normalizeKey(key) == normalizeKey(name) {
+				value, found = v, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+		if i == len(path)-1 {
+			return true
+		}
+		if values, found = value.(map[string]interface{}); !found {
+			return false
+		}
+	}
+	return false
+}
+
+// applyValues sets struct fields from a decoded section. Keys without a
+// matching field are appended to unknown with their dotted path.
+func applyValues(v reflect.Value, values map[string]interface{}, prefix string, unknown *[]string) error {
+	fields := make(map[string]int, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fields[normalizeKey(v.Type().Field(i).Name)] = i
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := prefix + key
+		i, ok := fields[normalizeKey(key)]
+		if !ok {
+			*unknown = append(*unknown, path)
+			continue
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			section, ok := values[key].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: expected a section, got %T", path, values[key])
+			}
+			if err := applyValues(field, section, path+".", unknown); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.Struct {
+			if err := applyMap(field, values[key], path, unknown); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := setValue(field, values[key]); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMap fills a map of sections, such as per-namespace overrides, from a
+// decoded mapping. Map keys are used as written.
+func applyMap(field reflect.Value, value interface{}, path string, unknown *[]string) error {
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: expected a mapping, got %T", path, value)
+	}
+	if field.IsNil() {
+		field.Set(reflect.MakeMapWithSize(field.Type(), len(entries)))
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		section, ok := entries[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s.%s: expected a section, got %T", path, name, entries[name])
+		}
+		entry := reflect.New(field.Type().Elem()).Elem()
+		if existing := field.MapIndex(reflect.ValueOf(name)); existing.IsValid() {
+			entry.Set(existing)
+		}
+		if err := applyValues(entry, section, path+"."+name+".", unknown); err != nil {
+			return err
+		}
+		field.SetMapIndex(reflect.ValueOf(name), entry)
+	}
+	return nil
+}
+
+// setValue assigns a decoded value to a field, converting numbers,
+// duration strings and string lists
+func setValue(field reflect.Value, value interface{}) error {
+	if field.Type() == durationType {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a duration string such as \"30s\", got %v", value)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %v", value)
+		}
+		field.SetString(s)
+
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected true or false, got %v", value)
+		}
+		field.SetBool(b)
+
+	case reflect.Int:
+		n, ok := toFloat(value)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("expected an integer, got %v", value)
+		}
+		field.SetInt(int64(n))
+
+	case reflect.Float64:
+		n, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("expected a number, got %v", value)
+		}
+		field.SetFloat(n)
+
+	case reflect.Slice:
+		items, ok := value.([]interface{})
+		if !ok || field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("expected a list of strings, got %v", value)
+		}
+		list := make([]string, len(items))
+		for i, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("expected a list of strings, got %v", item)
+			}
+			list[i] = s
+		}
+		field.Set(reflect.ValueOf(list))
+
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+// toFloat widens the numeric types produced by the YAML and JSON decoders
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,271 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFileYAML(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+server:
+  host: "127.0.0.1"
+  port: 6000
+  request_timeout: 45s
+hnsw:
+  m: 24
+  ef_construction: 300
+  ef_search_multiplier: 1.5
+cache:
+  ttl: 10m
+rest:
+  cors_origins: ["https://a.example", "https://b.example"]
+database:
+  data_dir: /var/lib/vector
+  sync_writes: true
+`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if cfg.Server.Host != "127.0.0.1" || cfg.Server.Port != 6000 {
+		t.Errorf("Expected 127.0.0.1:6000, got %s", cfg.Server.Address())
+	}
+	if cfg.Server.RequestTimeout != 45*time.Second {
+		t.Errorf("Expected request timeout 45s, got %v", cfg.Server.RequestTimeout)
+	}
+	if cfg.HNSW.M != 24 || cfg.HNSW.EfConstruction != 300 {
+		t.Errorf("Expected M=24 efConstruction=300, got M=%d efConstruction=%d", cfg.HNSW.M, cfg.HNSW.EfConstruction)
+	}
+	if cfg.HNSW.EfSearchMultiplier != 1.5 {
+		t.Errorf("Expected efSearch multiplier 1.5, got %v", cfg.HNSW.EfSearchMultiplier)
+	}
+	if cfg.Cache.TTL != 10*time.Minute {
+		t.Errorf("Expected cache TTL 10m, got %v", cfg.Cache.TTL)
+	}
+	if len(cfg.REST.CORSOrigins) != 2 || cfg.REST.CORSOrigins[1] != "https://b.example" {
+		t.Errorf("Unexpected CORS origins: %v", cfg.REST.CORSOrigins)
+	}
+	if cfg.Database.DataDir != "/var/lib/vector" || !cfg.Database.SyncWrites {
+		t.Errorf("Unexpected database config: %+v", cfg.Database)
+	}
+
+	// Keys not in the file keep their defaults
+	if cfg.HNSW.DefaultEfSearch != 50 {
+		t.Errorf("Expected default efSearch 50, got %d", cfg.HNSW.DefaultEfSearch)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected loaded config to be valid, got %v", err)
+	}
+}
+
+func TestLoadFromFileJSON(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{
+  "server": {"port": 7000, "shutdownTimeout": "5s"},
+  "HNSW": {"DefaultEfSearch": 80},
+  "cache": {"enabled": false}
+}`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if cfg.Server.Port != 7000 {
+		t.Errorf("Expected port 7000, got %d", cfg.Server.Port)
+	}
+	if cfg.Server.ShutdownTimeout != 5*time.Second {
+		t.Errorf("Expected shutdown timeout 5s, got %v", cfg.Server.ShutdownTimeout)
+	}
+	if cfg.HNSW.DefaultEfSearch != 80 {
+		t.Errorf("Expected efSearch 80, got %d", cfg.HNSW.DefaultEfSearch)
+	}
+	if cfg.Cache.Enabled {
+		t.Error("Expected cache disabled")
+	}
+}
+
+func TestLoadFromFileEnvOverrides(t *testing.T) {
+	t.Setenv("VECTOR_PORT", "9000")
+	t.Setenv("VECTOR_HNSW_M", "")
+
+	path := writeConfigFile(t, "config.yml", "server:\n  port: 6000\nhnsw:\n  m: 24\n")
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if cfg.Server.Port != 9000 {
+		t.Errorf("Expected env port 9000 to override file, got %d", cfg.Server.Port)
+	}
+	if cfg.HNSW.M != 24 {
+		t.Errorf("Expected file M=24 when env is unset, got %d", cfg.HNSW.M)
+	}
+}
+
+func TestLoadFromFileReflection(t *testing.T) {
+	t.Setenv("VECTOR_ENABLE_TLS", "")
+	t.Setenv("VECTOR_ENABLE_REFLECTION", "")
+
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"server:\n  port: 6000\n", true},
+		{"server:\n  enable_tls: true\n", false},
+		{"server:\n  enable_tls: true\n  enable_reflection: true\n", true},
+		{"server:\n  enableReflection: false\n", false},
+	}
+	for _, tt := range tests {
+		cfg, err := LoadFromFile(writeConfigFile(t, "config.yaml", tt.content))
+		if err != nil {
+			t.Fatalf("LoadFromFile failed: %v", err)
+		}
+		if cfg.Server.EnableReflection != tt.want {
+			t.Errorf("%q: expected reflection %v, got %v", tt.content, tt.want, cfg.Server.EnableReflection)
+		}
+	}
+}
+
+func TestLoadFromFileDeprecatedEnableWAL(t *testing.T) {
+	t.Setenv("VECTOR_ENABLE_WAL", "")
+
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"database:\n  data_dir: /tmp\n", false},
+		{"database:\n  enable_wal: true\n", true},
+		{"database:\n  enable_wal: true\nwal:\n  enabled: false\n", false},
+		{"database:\n  enable_wal: false\nwal:\n  enabled: true\n", true},
+	}
+	for _, tt := range tests {
+		cfg, err := LoadFromFile(writeConfigFile(t, "config.yaml", tt.content))
+		if err != nil {
+			t.Fatalf("LoadFromFile failed: %v", err)
+		}
+		if cfg.WAL.Enabled != tt.want {
+			t.Errorf("%q: expected WAL enabled %v, got %v", tt.content, tt.want, cfg.WAL.Enabled)
+		}
+	}
+}
+
+func TestLoadFromFileUnknownKeys(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "server:\n  port: 6000\n  colour: blue\nplugins:\n  enabled: true\n")
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("Expected unknown keys to be ignored, got %v", err)
+	}
+	if cfg.Server.Port != 6000 {
+		t.Errorf("Expected port 6000, got %d", cfg.Server.Port)
+	}
+}
+
+func TestLoadFromFileQuotaOverrides(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+quota:
+  max_vectors: 1000
+  namespaces:
+    Team_A:
+      max_vectors: 50
+    team-b:
+      max_memory_bytes: 1048576
+`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if cfg.Quota.MaxVectors != 1000 {
+		t.Errorf("Expected default max vectors 1000, got %d", cfg.Quota.MaxVectors)
+	}
+	// Namespace names are kept as written, not normalized like keys
+	if got := cfg.Quota.Namespaces["Team_A"]; got.MaxVectors != 50 {
+		t.Errorf("Expected Team_A max vectors 50, got %+v", got)
+	}
+	if got := cfg.Quota.Namespaces["team-b"]; got.MaxMemoryBytes != 1048576 || got.MaxVectors != 0 {
+		t.Errorf("Unexpected team-b override: %+v", got)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected loaded config to be valid, got %v", err)
+	}
+}
+
+func TestLoadFromFileSearchOverrides(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+hnsw:
+  max_k: 100
+  max_ef_search: 500
+  namespaces:
+    tuned:
+      default_ef_search: 200
+      max_ef_search: -1
+  quantization:
+    images:
+      min: -0.5
+      max: 2
+`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if cfg.HNSW.MaxK != 100 || cfg.HNSW.MaxEfSearch != 500 {
+		t.Errorf("Expected max k 100 and max ef_search 500, got %d and %d", cfg.HNSW.MaxK, cfg.HNSW.MaxEfSearch)
+	}
+	want := NamespaceSearch{DefaultEfSearch: 200, MaxEfSearch: -1}
+	if got := cfg.HNSW.Namespaces["tuned"]; got != want {
+		t.Errorf("Expected tuned override %+v, got %+v", want, got)
+	}
+	if got := cfg.HNSW.Quantization["images"]; got != (QuantizationRange{Min: -0.5, Max: 2}) {
+		t.Errorf("Expected images quantization range [-0.5, 2], got %+v", got)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected loaded config to be valid, got %v", err)
+	}
+}
+
+func TestLoadFromFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"unsupported extension", "config.toml", "port = 1"},
+		{"malformed YAML", "config.yaml", "server: [unclosed"},
+		{"malformed JSON", "config.json", "{"},
+		{"wrong type", "config.yaml", "server:\n  port: fast\n"},
+		{"fractional integer", "config.json", `{"server": {"port": 1.5}}`},
+		{"bad duration", "config.yaml", "server:\n  request_timeout: soon\n"},
+		{"scalar section", "config.yaml", "server: 1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.file, tt.content)
+			if _, err := LoadFromFile(path); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+
+	if _, err := LoadFromFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
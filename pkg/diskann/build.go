@@ -130,10 +130,11 @@ func (idx *Index) findMedoid() uint64 {
 	}
 
 	// Sample random points for efficiency
+	rng := rand.New(rand.NewSource(idx.seed))
 	sampleSize := min(1000, len(idx.buildVectors))
 	samples := make([]int, sampleSize)
 	for i := 0; i < sampleSize; i++ {
-		samples[i] = rand.Intn(len(idx.buildVectors))
+		samples[i] = rng.Intn(len(idx.buildVectors))
 	}
 
 	// Find point with minimum average distance to samples
@@ -158,9 +159,12 @@ func (idx *Index) findMedoid() uint64 {
 }
 
 // greedySearch performs greedy search to find candidates
+// Keeps the L closest nodes seen so far, sorted by distance, and expands
+// the closest unexpanded one until every kept candidate has been expanded
 func (idx *Index) greedySearch(query []float32, L int, entryID uint64) []Candidate {
 	visited := make(map[uint64]bool)
-	candidates := make([]Candidate, 0, L)
+	expanded := make(map[uint64]bool)
+	candidates := make([]Candidate, 0, L+1)
 
 	// Start from entry point
 	entryNode := idx.nodes[entryID]
@@ -169,48 +173,51 @@ func (idx *Index) greedySearch(query []float32, L int, entryID uint64) []Candida
 	candidates = append(candidates, Candidate{ID: entryID, Distance: entryDist})
 	visited[entryID] = true
 
-	// Greedy search
-	for len(candidates) < L {
-		// Find best unvisited neighbor
-		bestDist := float32(math.Inf(1))
-		var bestID uint64
-		found := false
-
-		for _, candidate := range candidates {
-			node := idx.nodes[candidate.ID]
-			for _, neighborID := range node.Neighbors {
-				if visited[neighborID] {
-					continue
-				}
-
-				neighborNode := idx.nodes[neighborID]
-				dist := idx.distanceFunc(query, neighborNode.Vector)
-
-				if dist < bestDist {
-					bestDist = dist
-					bestID = neighborID
-					found = true
-				}
+	for {
+		// Find closest unexpanded candidate
+		next := -1
+		for i, candidate := range candidates {
+			if !expanded[candidate.ID] {
+				next = i
+				break
 			}
 		}
-
-		if !found {
+		if next < 0 {
 			break
 		}
 
-		candidates = append(candidates, Candidate{ID: bestID, Distance: bestDist})
-		visited[bestID] = true
-	}
+		current := candidates[next].ID
+		expanded[current] = true
 
-	// Sort by distance
-	sort.Slice(candidates, func(i, j int) bool {
-		return candidates[i].Distance < candidates[j].Distance
-	})
+		for _, neighborID := range idx.nodes[current].Neighbors {
+			if visited[neighborID] {
+				continue
+			}
+			visited[neighborID] = true
+
+			dist := idx.distanceFunc(query, idx.nodes[neighborID].Vector)
+			if len(candidates) >= L && dist >= candidates[len(candidates)-1].Distance {
+				continue
+			}
+
+			// Insert in sorted position and drop the farthest beyond L
+			pos := sort.Search(len(candidates), func(i int) bool {
+				return candidates[i].Distance > dist
+			})
+			candidates = append(candidates, Candidate{})
+			copy(candidates[pos+1:], candidates[pos:])
+			candidates[pos] = Candidate{ID: neighborID, Distance: dist}
+			if len(candidates) > L {
+				candidates = candidates[:L]
+			}
+		}
+	}
 
 	return candidates
 }
 
 // selectNeighbors selects the best R neighbors using RNG heuristic
+// Candidates must be sorted by distance (closest first)
 func (idx *Index) selectNeighbors(candidates []Candidate, R int) []uint64 {
 	if len(candidates) <= R {
 		neighbors := make([]uint64, len(candidates))
@@ -236,10 +243,10 @@ func (idx *Index) selectNeighbors(candidates []Candidate, R int) []uint64 {
 		for _, selectedID := range selected {
 			selectedVec := idx.nodes[selectedID].Vector
 
-			// If distance to candidate is greater than distance to existing neighbor,
-			// the candidate might be occluded
+			// A closer kept neighbor occludes the candidate when the candidate
+			// lies alpha times nearer to it than to the node
 			distToCandidate := idx.distanceFunc(candidateVec, selectedVec)
-			if distToCandidate < candidate.Distance * float32(idx.alpha) {
+			if float32(idx.alpha)*distToCandidate <= candidate.Distance {
 				useful = false
 				break
 			}
@@ -288,7 +295,16 @@ func (idx *Index) pruneNeighbors(nodeID uint64) {
 		candidates[i] = Candidate{ID: neighborID, Distance: dist}
 	}
 
-	// Select best R neighbors
+	// Occlusion pruning visits candidates closest first, so sort them;
+	// the neighbor list is in insertion order
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Distance != candidates[j].Distance {
+			return candidates[i].Distance < candidates[j].Distance
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	// Select the most diverse R neighbors
 	pruned := idx.selectNeighbors(candidates, idx.R)
 	node.SetNeighbors(pruned)
 }
@@ -311,9 +327,12 @@ func (idx *Index) selectMemoryNodes() error {
 		distances = append(distances, nodeDistance{id: id, dist: dist})
 	}
 
-	// Sort by distance
+	// Sort by distance, ties by ID, so the selection does not depend on map order
 	sort.Slice(distances, func(i, j int) bool {
-		return distances[i].dist < distances[j].dist
+		if distances[i].dist != distances[j].dist {
+			return distances[i].dist < distances[j].dist
+		}
+		return distances[i].id < distances[j].id
 	})
 
 	// Select closest nodes for memory graph
@@ -222,7 +222,93 @@ func TestDiskANN_EmptyIndex(t *testing.T) {
 	}
 }
 
+// TestDiskANN_Warmup tests pre-reading the search entry records
+func TestDiskANN_Warmup(t *testing.T) {
+	tmpDir := "/tmp/diskann_warmup_test"
+	os.RemoveAll(tmpDir)
+	defer os.RemoveAll(tmpDir)
+
+	config := DefaultConfig()
+	config.DataPath = tmpDir
+	config.R = 8
+	config.L = 20
+	config.NumSubvectors = 4
+	config.MemoryGraphSize = 50
+
+	idx, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer idx.Close()
+
+	if _, err := idx.Warmup(); err == nil {
+		t.Error("Expected error when warming an unbuilt index")
+	}
+
+	for _, vec := range generateRandomVectors(300, 16) {
+		if _, err := idx.AddVector(vec, nil); err != nil {
+			t.Fatalf("Failed to add vector: %v", err)
+		}
+	}
+	if err := idx.Build(); err != nil {
+		t.Fatalf("Failed to build index: %v", err)
+	}
+
+	read, err := idx.Warmup()
+	if err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+	// Every memory graph node is read, plus medoid neighbors outside it
+	if read < idx.memoryGraph.Size() || read > idx.memoryGraph.Size()+config.R {
+		t.Errorf("Expected %d-%d records read, got %d", idx.memoryGraph.Size(), idx.memoryGraph.Size()+config.R, read)
+	}
+}
+
 // TestDiskANN_DimensionMismatch tests dimension mismatch handling
+func TestDiskANN_SeededBuildIsReproducible(t *testing.T) {
+	vectors := generateRandomVectors(300, 16)
+
+	build := func(seed int64) *Index {
+		config := DefaultConfig()
+		config.DataPath = t.TempDir()
+		config.R = 8
+		config.L = 20
+		config.NumSubvectors = 4
+		config.MemoryGraphSize = 50
+		config.Seed = seed
+
+		idx, err := New(config)
+		if err != nil {
+			t.Fatalf("Failed to create index: %v", err)
+		}
+		t.Cleanup(func() { idx.Close() })
+
+		for _, vec := range vectors {
+			if _, err := idx.AddVector(vec, nil); err != nil {
+				t.Fatalf("Failed to add vector: %v", err)
+			}
+		}
+		if err := idx.Build(); err != nil {
+			t.Fatalf("Failed to build index: %v", err)
+		}
+		return idx
+	}
+
+	a, b := build(7), build(7)
+
+	if a.memoryGraph.GetEntryPoint() != b.memoryGraph.GetEntryPoint() {
+		t.Errorf("Medoids differ: %d vs %d", a.memoryGraph.GetEntryPoint(), b.memoryGraph.GetEntryPoint())
+	}
+	if fmt.Sprint(a.pqCodebook.GetCodebooks()) != fmt.Sprint(b.pqCodebook.GetCodebooks()) {
+		t.Error("PQ codebooks differ between builds with the same seed")
+	}
+	for id, node := range a.nodes {
+		if fmt.Sprint(node.Neighbors) != fmt.Sprint(b.nodes[id].Neighbors) {
+			t.Fatalf("Node %d neighbors differ: %v vs %v", id, node.Neighbors, b.nodes[id].Neighbors)
+		}
+	}
+}
+
 func TestDiskANN_DimensionMismatch(t *testing.T) {
 	tmpDir := "/tmp/diskann_dim_test"
 	os.RemoveAll(tmpDir)
@@ -311,6 +397,72 @@ func calculateRecall(results []SearchResult, groundTruth []uint64) float64 {
 	return float64(matches) / float64(len(groundTruth))
 }
 
+// TestDiskANN_ReverseEdgeOverflowPrune tests that reverse-edge overflow keeps diverse neighbors
+func TestDiskANN_ReverseEdgeOverflowPrune(t *testing.T) {
+	tmpDir := "/tmp/diskann_prune_test"
+	os.RemoveAll(tmpDir)
+	defer os.RemoveAll(tmpDir)
+
+	idx, err := New(IndexConfig{
+		R:            4,
+		Alpha:        1.2,
+		DistanceFunc: EuclideanDistance,
+		DataPath:     tmpDir,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer idx.Close()
+
+	const center = uint64(0)
+	idx.nodes[center] = NewNode(center, []float32{0, 0}, nil)
+
+	// Tight clusters in four directions, added in an order that puts the
+	// nearest cluster last so naive truncation would pick the wrong set
+	rng := rand.New(rand.NewSource(42))
+	directions := [][2]float32{{0, -1.3}, {-1.2, 0}, {0, 1.1}, {1, 0}}
+	id := uint64(1)
+	for _, dir := range directions {
+		for i := 0; i < 8; i++ {
+			idx.nodes[id] = NewNode(id, []float32{
+				dir[0] + float32(rng.NormFloat64())*0.01,
+				dir[1] + float32(rng.NormFloat64())*0.01,
+			}, nil)
+			idx.addReverseEdge(center, id)
+			id++
+		}
+	}
+
+	node := idx.nodes[center]
+	kept := node.Neighbors
+	if len(kept) > idx.R {
+		t.Fatalf("Degree %d exceeds R=%d after overflow", len(kept), idx.R)
+	}
+
+	clusters := make(map[uint64]bool)
+	for _, n := range kept {
+		clusters[(n-1)/8] = true
+	}
+	if len(clusters) != len(directions) {
+		t.Errorf("Kept neighbors %v cover %d of %d clusters", kept, len(clusters), len(directions))
+	}
+
+	// No kept neighbor is occluded by a closer kept neighbor
+	for _, a := range kept {
+		for _, b := range kept {
+			if a == b {
+				continue
+			}
+			vecA, vecB := idx.nodes[a].Vector, idx.nodes[b].Vector
+			distA := idx.distanceFunc(node.Vector, vecA)
+			distB := idx.distanceFunc(node.Vector, vecB)
+			if distA <= distB && float32(idx.alpha)*idx.distanceFunc(vecA, vecB) <= distB {
+				t.Errorf("Neighbor %d is occluded by kept neighbor %d", b, a)
+			}
+		}
+	}
+}
+
 // TestDiskANN_LargeScale tests DiskANN on larger dataset
 func TestDiskANN_LargeScale(t *testing.T) {
 	if testing.Short() {
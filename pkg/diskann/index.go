@@ -20,6 +20,7 @@ type Index struct {
 	beamWidth      int          // Beam width for beam search
 	alpha          float64      // Distance threshold multiplier
 	distanceFunc   DistanceFunc // Distance metric function
+	seed           int64        // Seeds medoid sampling during Build
 
 	// Memory-resident components (small)
 	memoryGraph    *MemoryGraph        // Small in-memory graph for fast routing
@@ -64,6 +65,10 @@ type IndexConfig struct {
 
 	// Memory budget
 	MemoryGraphSize int         // Max nodes in memory graph (typical: 100k-1M)
+
+	// Seed for medoid sampling and PQ training; building the same vectors
+	// with the same seed yields an identical index
+	Seed           int64
 }
 
 // DefaultConfig returns a configuration with recommended default values
@@ -78,6 +83,7 @@ func DefaultConfig() IndexConfig {
 		NumSubvectors:   16,
 		BitsPerCode:     8,
 		MemoryGraphSize: 100000, // 100k nodes in memory
+		Seed:            42,
 	}
 }
 
@@ -122,7 +128,9 @@ func New(config IndexConfig) (*Index, error) {
 	memoryGraph := NewMemoryGraph(config.MemoryGraphSize, config.R)
 
 	// Create product quantizer (will be trained during Build)
-	pq := quantization.NewProductQuantizer(config.NumSubvectors, config.BitsPerCode)
+	trainConfig := quantization.DefaultConfig()
+	trainConfig.RandomSeed = config.Seed
+	pq := quantization.NewProductQuantizerWithConfig(config.NumSubvectors, config.BitsPerCode, trainConfig)
 
 	return &Index{
 		R:             config.R,
@@ -130,6 +138,7 @@ func New(config IndexConfig) (*Index, error) {
 		beamWidth:     config.BeamWidth,
 		alpha:         config.Alpha,
 		distanceFunc:  config.DistanceFunc,
+		seed:          config.Seed,
 		memoryGraph:   memoryGraph,
 		diskGraph:     diskGraph,
 		pqCodebook:    pq,
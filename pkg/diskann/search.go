@@ -264,7 +264,10 @@ func (idx *Index) rerank(query []float32, candidates []Candidate, k int) []Candi
 
 	// Sort by distance
 	sort.Slice(reranked, func(i, j int) bool {
-		return reranked[i].Distance < reranked[j].Distance
+		if reranked[i].Distance != reranked[j].Distance {
+			return reranked[i].Distance < reranked[j].Distance
+		}
+		return reranked[i].ID < reranked[j].ID
 	})
 
 	// Return top k
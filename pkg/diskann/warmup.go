@@ -0,0 +1,63 @@
+package diskann
+
+import (
+	"fmt"
+)
+
+// Warmup pre-reads the disk records every search starts from: the medoid,
+// its disk neighbors and the nodes of the memory graph. Run it after
+// opening an index so the first queries do not pay for cold page-cache
+// reads. It returns the number of records read.
+func (idx *Index) Warmup() (int, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if !idx.isBuilt {
+		return 0, fmt.Errorf("index not built yet - call Build() first")
+	}
+
+	medoidID := idx.memoryGraph.GetEntryPoint()
+	seen := map[uint64]bool{medoidID: true}
+	ids := []uint64{medoidID}
+
+	read := 0
+	readNode := func(id uint64) (*DiskNode, error) {
+		if !idx.diskGraph.Contains(id) {
+			return nil, nil
+		}
+		node, err := idx.diskGraph.ReadNode(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read node %d: %w", id, err)
+		}
+		read++
+		return node, nil
+	}
+
+	// The medoid's neighborhood is where every beam search begins
+	medoid, err := readNode(medoidID)
+	if err != nil {
+		return read, err
+	}
+	if medoid != nil {
+		for _, id := range medoid.Neighbors {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	for _, id := range idx.memoryGraph.GetAllNodes() {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range ids[1:] {
+		if _, err := readNode(id); err != nil {
+			return read, err
+		}
+	}
+
+	return read, nil
+}
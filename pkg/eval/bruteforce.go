@@ -0,0 +1,218 @@
+package eval
+
+import (
+	"container/heap"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Neighbor is an exact nearest neighbor of a query
+type Neighbor struct {
+	ID       int     // Index of the vector in the database
+	Distance float32 // Squared Euclidean distance to the query
+}
+
+// Options configures brute-force ground truth computation
+type Options struct {
+	Workers   int  // Goroutines to use (0 = runtime.NumCPU())
+	EarlyExit bool // Abandon a candidate once its partial distance exceeds the current k-th best
+}
+
+// DefaultOptions returns options that use all cores with early exit enabled
+func DefaultOptions() Options {
+	return Options{
+		Workers:   runtime.NumCPU(),
+		EarlyExit: true,
+	}
+}
+
+// BruteForceKNN computes the exact k nearest neighbors of every query by
+// squared Euclidean distance. Queries are spread across a worker pool and each
+// keeps a bounded max-heap of the best k candidates.
+//
+// With EarlyExit, the distance loop stops as soon as the accumulated squared
+// distance reaches the current k-th best. Partial sums only grow, so the
+// abandoned candidate could never have entered the top k and results are
+// identical to NaiveKNN.
+func BruteForceKNN(queries, database [][]float32, k int, opts Options) [][]Neighbor {
+	results := make([][]Neighbor, len(queries))
+	if len(queries) == 0 {
+		return results
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(queries) {
+		workers = len(queries)
+	}
+
+	jobs := make(chan int, len(queries))
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for qi := range jobs {
+				results[qi] = searchOne(queries[qi], database, k, opts.EarlyExit)
+			}
+		}()
+	}
+
+	for qi := range queries {
+		jobs <- qi
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return results
+}
+
+// NaiveKNN computes exact k nearest neighbors by scoring every vector and sorting.
+// It is the reference implementation BruteForceKNN is checked against.
+func NaiveKNN(query []float32, database [][]float32, k int) []Neighbor {
+	all := make([]Neighbor, len(database))
+	for i, vec := range database {
+		all[i] = Neighbor{ID: i, Distance: squaredL2(query, vec)}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[j].worse(all[i])
+	})
+
+	if len(all) > k {
+		all = all[:k]
+	}
+	return all
+}
+
+// IDs extracts neighbor IDs, e.g. for recall computation
+func IDs(neighbors [][]Neighbor) [][]int {
+	ids := make([][]int, len(neighbors))
+	for i, row := range neighbors {
+		ids[i] = make([]int, len(row))
+		for j, n := range row {
+			ids[i][j] = n.ID
+		}
+	}
+	return ids
+}
+
+// searchOne scans the database for a single query
+func searchOne(query []float32, database [][]float32, k int, earlyExit bool) []Neighbor {
+	if k <= 0 {
+		return []Neighbor{}
+	}
+
+	top := make(neighborHeap, 0, k)
+
+	for id, vec := range database {
+		if len(top) < k {
+			heap.Push(&top, Neighbor{ID: id, Distance: squaredL2(query, vec)})
+			continue
+		}
+
+		// Database is scanned in ID order, so a tie with the k-th best
+		// always loses on ID and can be abandoned too
+		bound := top[0].Distance
+		var dist float32
+		if earlyExit {
+			var ok bool
+			dist, ok = squaredL2Bounded(query, vec, bound)
+			if !ok {
+				continue
+			}
+		} else {
+			dist = squaredL2(query, vec)
+		}
+
+		if dist < bound {
+			top[0] = Neighbor{ID: id, Distance: dist}
+			heap.Fix(&top, 0)
+		}
+	}
+
+	result := make([]Neighbor, len(top))
+	for i := len(top) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(&top).(Neighbor)
+	}
+	return result
+}
+
+// squaredL2 computes the squared Euclidean distance using four accumulators
+func squaredL2(a, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		d0 := a[i] - b[i]
+		d1 := a[i+1] - b[i+1]
+		d2 := a[i+2] - b[i+2]
+		d3 := a[i+3] - b[i+3]
+		s0 += d0 * d0
+		s1 += d1 * d1
+		s2 += d2 * d2
+		s3 += d3 * d3
+	}
+	for ; i < len(a); i++ {
+		d := a[i] - b[i]
+		s0 += d * d
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+// squaredL2Bounded computes the squared Euclidean distance, giving up once the
+// partial sum reaches bound. Accumulation matches squaredL2 exactly, and since
+// every accumulator only grows (and float addition is monotonic), a partial
+// total at or above bound guarantees the final distance is too.
+func squaredL2Bounded(a, b []float32, bound float32) (float32, bool) {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		d0 := a[i] - b[i]
+		d1 := a[i+1] - b[i+1]
+		d2 := a[i+2] - b[i+2]
+		d3 := a[i+3] - b[i+3]
+		s0 += d0 * d0
+		s1 += d1 * d1
+		s2 += d2 * d2
+		s3 += d3 * d3
+		if i&15 == 12 && (s0+s1)+(s2+s3) >= bound {
+			return 0, false
+		}
+	}
+	for ; i < len(a); i++ {
+		d := a[i] - b[i]
+		s0 += d * d
+	}
+	sum := (s0 + s1) + (s2 + s3)
+	return sum, sum < bound
+}
+
+// worse reports whether n ranks after other (larger distance, ties broken by larger ID)
+func (n Neighbor) worse(other Neighbor) bool {
+	if n.Distance != other.Distance {
+		return n.Distance > other.Distance
+	}
+	return n.ID > other.ID
+}
+
+// neighborHeap is a max-heap keeping the worst of the current top k at the root
+type neighborHeap []Neighbor
+
+func (h neighborHeap) Len() int            { return len(h) }
+func (h neighborHeap) Less(i, j int) bool  { return h[i].worse(h[j]) }
+func (h neighborHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x interface{}) { *h = append(*h, x.(Neighbor)) }
+func (h *neighborHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
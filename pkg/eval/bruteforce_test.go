@@ -0,0 +1,148 @@
+package eval
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomVectors(rng *rand.Rand, n, dim int) [][]float32 {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+	}
+	return vectors
+}
+
+func TestBruteForceKNNMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	database := randomVectors(rng, 2000, 37) // Odd dimension exercises the tail loop
+	queries := randomVectors(rng, 50, 37)
+
+	// Duplicate vectors create distance ties that must break the same way
+	for i := 0; i < 20; i++ {
+		database[100+i] = database[i]
+	}
+	queries[0] = database[5]
+
+	for _, opts := range []Options{
+		{Workers: 1, EarlyExit: false},
+		{Workers: 1, EarlyExit: true},
+		{Workers: 4, EarlyExit: true},
+		DefaultOptions(),
+	} {
+		for _, k := range []int{1, 10, 100} {
+			got := BruteForceKNN(queries, database, k, opts)
+
+			if len(got) != len(queries) {
+				t.Fatalf("Expected %d result rows, got %d", len(queries), len(got))
+			}
+
+			for qi, query := range queries {
+				want := NaiveKNN(query, database, k)
+				if len(got[qi]) != len(want) {
+					t.Fatalf("opts=%+v k=%d query %d: expected %d neighbors, got %d",
+						opts, k, qi, len(want), len(got[qi]))
+				}
+				for i := range want {
+					if got[qi][i] != want[i] {
+						t.Errorf("opts=%+v k=%d query %d rank %d: got %+v, want %+v",
+							opts, k, qi, i, got[qi][i], want[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBruteForceKNNClustered(t *testing.T) {
+	// Clustered data makes early exit trigger for most candidates
+	rng := rand.New(rand.NewSource(7))
+	centers := randomVectors(rng, 20, 64)
+	database := clusteredVectors(rng, centers, 3000)
+	queries := clusteredVectors(rng, centers, 30)
+
+	got := BruteForceKNN(queries, database, 10, DefaultOptions())
+	for qi, query := range queries {
+		want := NaiveKNN(query, database, 10)
+		for i := range want {
+			if got[qi][i] != want[i] {
+				t.Errorf("Query %d rank %d: got %+v, want %+v", qi, i, got[qi][i], want[i])
+			}
+		}
+	}
+}
+
+func TestBruteForceKNNSmallDatabase(t *testing.T) {
+	database := [][]float32{{0, 0}, {1, 1}, {2, 2}}
+	queries := [][]float32{{0.9, 0.9}}
+
+	got := BruteForceKNN(queries, database, 10, DefaultOptions())
+	if len(got[0]) != 3 {
+		t.Fatalf("Expected all 3 vectors when k exceeds database size, got %d", len(got[0]))
+	}
+	if got[0][0].ID != 1 {
+		t.Errorf("Expected nearest ID 1, got %d", got[0][0].ID)
+	}
+
+	ids := IDs(got)
+	if len(ids[0]) != 3 || ids[0][0] != 1 {
+		t.Errorf("Unexpected IDs: %v", ids)
+	}
+
+	if empty := BruteForceKNN(nil, database, 10, DefaultOptions()); len(empty) != 0 {
+		t.Errorf("Expected no rows for no queries, got %d", len(empty))
+	}
+}
+
+// clusteredVectors draws vectors around random centers, like real embeddings
+func clusteredVectors(rng *rand.Rand, centers [][]float32, n int) [][]float32 {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		center := centers[rng.Intn(len(centers))]
+		vectors[i] = make([]float32, len(center))
+		for j := range vectors[i] {
+			vectors[i][j] = center[j] + float32(rng.NormFloat64())*0.1
+		}
+	}
+	return vectors
+}
+
+func BenchmarkGroundTruth(b *testing.B) {
+	rng := rand.New(rand.NewSource(42))
+	centers := randomVectors(rng, 100, 128)
+	database := clusteredVectors(rng, centers, 20000)
+	queries := clusteredVectors(rng, centers, 50)
+	const k = 10
+
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, q := range queries {
+				NaiveKNN(q, database, k)
+			}
+		}
+	})
+
+	b.Run("heap", func(b *testing.B) {
+		opts := Options{Workers: 1, EarlyExit: false}
+		for i := 0; i < b.N; i++ {
+			BruteForceKNN(queries, database, k, opts)
+		}
+	})
+
+	b.Run("heap+early-exit", func(b *testing.B) {
+		opts := Options{Workers: 1, EarlyExit: true}
+		for i := 0; i < b.N; i++ {
+			BruteForceKNN(queries, database, k, opts)
+		}
+	})
+
+	b.Run("parallel+early-exit", func(b *testing.B) {
+		opts := DefaultOptions()
+		for i := 0; i < b.N; i++ {
+			BruteForceKNN(queries, database, k, opts)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package hnsw
 
 import (
 	"fmt"
+	"runtime"
+	"sort"
 	"sync"
 	"sync/atomic"
 )
@@ -96,6 +98,128 @@ func (idx *Index) BatchInsert(vectors [][]float32, progressCb ProgressCallback)
 	return result
 }
 
+// BuildBatch inserts vectors as one bulk load and returns their IDs in
+// input order. It is much faster than calling Insert in a loop: the vectors
+// are validated, given consecutive IDs and assigned layers under a single
+// lock, the few nodes above layer 0 are linked first, highest layer first,
+// so the entry point settles early, and the layer-0 nodes are then linked
+// in parallel on every CPU. Recall matches that of incremental inserts.
+// A vector of the wrong dimension fails the whole batch before anything is
+// inserted. Vectors that fit within FlatThreshold are stored unlinked, as
+// with Insert.
+func (idx *Index) BuildBatch(vectors [][]float32) ([]uint64, error) {
+	ids := make([]uint64, len(vectors))
+	if len(vectors) == 0 {
+		return ids, nil
+	}
+
+	// A flat index stores vectors unlinked; insert one at a time until it
+	// builds its graph, then bulk load the rest
+	start := 0
+	for ; start < len(vectors) && idx.Flat(); start++ {
+		id, err := idx.Insert(vectors[start])
+		if err != nil {
+			return ids[:start], fmt.Errorf("vector %d: %w", start, err)
+		}
+		ids[start] = id
+	}
+	if start == len(vectors) {
+		return ids, nil
+	}
+	batch := vectors[start:]
+
+	idx.mu.Lock()
+	dimension := idx.dimension
+	if dimension == 0 {
+		dimension = len(batch[0])
+	}
+	for i, vector := range batch {
+		if len(vector) == 0 {
+			idx.mu.Unlock()
+			return ids[:start], fmt.Errorf("vector %d: cannot insert empty vector", start+i)
+		}
+		if len(vector) != dimension {
+			idx.mu.Unlock()
+			return ids[:start], fmt.Errorf("vector %d: vector dimension mismatch: expected %d, got %d",
+				start+i, dimension, len(vector))
+		}
+	}
+
+	// Fix the dimension before unlocking, as Insert does, so a concurrent
+	// insert cannot set a different one while the batch is linked
+	idx.dimension = dimension
+
+	levels := make([]int, len(batch))
+	for i := range batch {
+		ids[start+i] = idx.nodeCounter
+		idx.nodeCounter++
+		levels[i] = idx.randomLevel()
+	}
+	idx.mu.Unlock()
+
+	// Link upper-layer nodes first, highest layer first
+	order := make([]int, len(batch))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return levels[order[a]] > levels[order[b]] })
+
+	link := func(i int) error {
+		_, err := idx.insertAtLevel(batch[i], ids[start+i], true, 0, levels[i])
+		if err != nil {
+			return fmt.Errorf("vector %d: %w", start+i, err)
+		}
+		return nil
+	}
+
+	// Linking the upper layers, and the first node of an empty index, moves
+	// the entry point, so it stays sequential
+	sequential := 1
+	for sequential < len(order) && levels[order[sequential]] > 0 {
+		sequential++
+	}
+	for _, i := range order[:sequential] {
+		if err := link(i); err != nil {
+			return ids, err
+		}
+	}
+
+	// With one CPU, goroutines would only interleave the inserts
+	workers := runtime.GOMAXPROCS(0)
+	if workers <= 1 {
+		for _, i := range order[sequential:] {
+			if err := link(i); err != nil {
+				return ids, err
+			}
+		}
+		return ids, nil
+	}
+
+	jobs := make(chan int, len(order)-sequential)
+	for _, i := range order[sequential:] {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := link(i); err != nil {
+					errOnce.Do(func() { firstErr = err })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return ids, firstErr
+}
+
 // BatchInsertSequential inserts vectors sequentially (for when order matters)
 func (idx *Index) BatchInsertSequential(vectors [][]float32, progressCb ProgressCallback) *BatchInsertResult {
 	result := &BatchInsertResult{
@@ -299,9 +423,9 @@ func (idx *Index) GetBatchStats() map[string]interface{} {
 	defer idx.mu.RUnlock()
 
 	return map[string]interface{}{
-		"total_vectors":    idx.size,
-		"max_layer":        idx.maxLayer,
-		"entry_point_id":   func() interface{} {
+		"total_vectors": idx.size,
+		"max_layer":     idx.maxLayer,
+		"entry_point_id": func() interface{} {
 			if idx.entryPoint != nil {
 				return idx.entryPoint.id
 			}
@@ -315,6 +315,124 @@ func TestGetBatchStats(t *testing.T) {
 	}
 }
 
+// batchRecall returns the mean recall@k of idx over queries
+func batchRecall(t *testing.T, idx *Index, vectors, queries [][]float32, k int) float64 {
+	t.Helper()
+	total := 0.0
+	for _, query := range queries {
+		result, err := idx.Search(query, k, 100)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		total += calculateRecall(result.Results, bruteForceKNN(query, vectors, k, EuclideanDistance), k)
+	}
+	return total / float64(len(queries))
+}
+
+func TestBuildBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	vectors := make([][]float32, 2000)
+	for i := range vectors {
+		vectors[i] = make([]float32, 32)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+	}
+	queries := make([][]float32, 50)
+	for i := range queries {
+		queries[i] = make([]float32, 32)
+		for j := range queries[i] {
+			queries[i][j] = rng.Float32()
+		}
+	}
+
+	config := DefaultConfig()
+	config.DistanceFunc = EuclideanDistance
+
+	incremental := New(config)
+	for _, vector := range vectors {
+		if _, err := incremental.Insert(vector); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	idx := New(config)
+	ids, err := idx.BuildBatch(vectors)
+	if err != nil {
+		t.Fatalf("BuildBatch failed: %v", err)
+	}
+	for i, id := range ids {
+		if id != uint64(i) {
+			t.Fatalf("Expected vector %d to get ID %d, got %d", i, i, id)
+		}
+	}
+	if idx.Size() != int64(len(vectors)) {
+		t.Errorf("Expected size %d, got %d", len(vectors), idx.Size())
+	}
+	if err := idx.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+
+	bulk, loop := batchRecall(t, idx, vectors, queries, 10), batchRecall(t, incremental, vectors, queries, 10)
+	t.Logf("Recall@10: BuildBatch %.3f, Insert loop %.3f", bulk, loop)
+	if bulk < loop-0.05 {
+		t.Errorf("BuildBatch recall %.3f is well below the Insert loop's %.3f", bulk, loop)
+	}
+
+	// A second batch continues the ID sequence of a populated index
+	more, err := idx.BuildBatch(vectors[:10])
+	if err != nil {
+		t.Fatalf("BuildBatch failed: %v", err)
+	}
+	if more[0] != uint64(len(vectors)) || more[9] != uint64(len(vectors)+9) {
+		t.Errorf("Expected IDs %d-%d, got %v", len(vectors), len(vectors)+9, more)
+	}
+}
+
+func TestBuildBatchDimensionMismatch(t *testing.T) {
+	idx := New(DefaultConfig())
+	if _, err := idx.Insert(randomVector(16)); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	vectors := [][]float32{randomVector(16), randomVector(8), randomVector(16)}
+	if _, err := idx.BuildBatch(vectors); err == nil {
+		t.Fatal("Expected a dimension mismatch error")
+	}
+	if idx.Size() != 1 || idx.NextID() != 1 {
+		t.Errorf("Expected a rejected batch to leave the index unchanged, got size %d next ID %d", idx.Size(), idx.NextID())
+	}
+}
+
+func TestBuildBatchFlatThreshold(t *testing.T) {
+	config := DefaultConfig()
+	config.FlatThreshold = 50
+	idx := New(config)
+
+	vectors := make([][]float32, 200)
+	for i := range vectors {
+		vectors[i] = randomVector(16)
+	}
+
+	if _, err := idx.BuildBatch(vectors[:40]); err != nil {
+		t.Fatalf("BuildBatch failed: %v", err)
+	}
+	if !idx.Flat() {
+		t.Fatal("Expected the index to stay flat within its threshold")
+	}
+
+	ids, err := idx.BuildBatch(vectors[40:])
+	if err != nil {
+		t.Fatalf("BuildBatch failed: %v", err)
+	}
+	if idx.Flat() || idx.Size() != 200 || len(ids) != 160 || ids[159] != 199 {
+		t.Errorf("Expected a 200 vector graph, got flat=%v size %d, %d IDs", idx.Flat(), idx.Size(), len(ids))
+	}
+	if err := idx.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
+
 func BenchmarkBatchInsert(b *testing.B) {
 	idx := New(IndexConfig{
 		M:              16,
@@ -350,3 +468,43 @@ func BenchmarkBatchInsertSequential(b *testing.B) {
 		idx.BatchInsertSequential(vectors, nil)
 	}
 }
+
+// buildBenchVectors returns the 100k vector data set BuildBatch is
+// benchmarked on
+func buildBenchVectors() [][]float32 {
+	rng := rand.New(rand.NewSource(1))
+	vectors := make([][]float32, 100000)
+	for i := range vectors {
+		vectors[i] = make([]float32, 64)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+	}
+	return vectors
+}
+
+func BenchmarkBuildBatch(b *testing.B) {
+	vectors := buildBenchVectors()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := New(DefaultConfig())
+		if _, err := idx.BuildBatch(vectors); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildBatchInsertLoop(b *testing.B) {
+	vectors := buildBenchVectors()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := New(DefaultConfig())
+		for _, vector := range vectors {
+			if _, err := idx.Insert(vector); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
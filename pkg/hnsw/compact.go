@@ -0,0 +1,73 @@
+package hnsw
+
+import "fmt"
+
+// CompactStats reports what a Compact call changed
+type CompactStats struct {
+	NodesBefore  int64 // Live nodes when compaction started
+	NodesAfter   int64 // Nodes in the rebuilt graph
+	Deleted      int64 // Deletes (including the delete half of updates) since the graph was built
+	MemoryBefore int64 // MemoryUsage before compaction
+	MemoryAfter  int64 // MemoryUsage after compaction
+}
+
+// Compact rebuilds the graph from the live nodes, keeping their IDs. Each
+// delete unlinks the node from its neighbors without relinking them, so
+// under heavy churn neighborhoods thin out and recall drops; Go maps also
+// never shrink, so the node map keeps the space of every vector ever
+// stored. Compact re-inserts every live vector into a fresh graph and node
+// map, then swaps them in under the index lock.
+//
+// Searches keep running against the old graph while the new one is built.
+// Writes must be held off by the caller for the duration: Compact fails
+// without changing the index if any write lands while it runs.
+func (idx *Index) Compact() (CompactStats, error) {
+	idx.mu.RLock()
+	nodes := make([]*Node, 0, len(idx.nodes))
+	for _, node := range idx.nodes {
+		nodes = append(nodes, node)
+	}
+	config := idx.configLocked()
+	M0, ml := idx.M0, idx.ml
+	nodeCounter := idx.nodeCounter // InsertWithID only accepts IDs below the counter
+	size, deleted := idx.size, idx.deleted
+	idx.mu.RUnlock()
+
+	stats := CompactStats{
+		NodesBefore:  int64(len(nodes)),
+		Deleted:      deleted,
+		MemoryBefore: idx.MemoryUsage(),
+	}
+
+	// An index within its FlatThreshold comes back flat
+	rebuilt, err := buildGraph(config, M0, ml, nodeCounter, nodes)
+	if err != nil {
+		return stats, err
+	}
+
+	idx.mu.Lock()
+	if idx.size != size || idx.deleted != deleted {
+		idx.mu.Unlock()
+		return stats, fmt.Errorf("index was modified during compaction; hold off writes and retry")
+	}
+	idx.nodes = rebuilt.nodes
+	idx.entryPoint = rebuilt.entryPoint
+	idx.maxLayer = rebuilt.maxLayer
+	idx.flat = rebuilt.flat
+	idx.unlinked = nil
+	idx.size = rebuilt.size
+	idx.deleted = 0
+	idx.mu.Unlock()
+
+	stats.NodesAfter = rebuilt.size
+	stats.MemoryAfter = idx.MemoryUsage()
+	return stats, nil
+}
+
+// DeletedSinceBuild returns the number of deletes, including the delete
+// half of each update, since the graph was built or last compacted
+func (idx *Index) DeletedSinceBuild() int64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.deleted
+}
@@ -0,0 +1,125 @@
+package hnsw
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	idx := New(DefaultConfig())
+	rng := rand.New(rand.NewSource(42))
+
+	vectors := make(map[uint64][]float32)
+	for i := 0; i < 1000; i++ {
+		vector := make([]float32, 32)
+		for j := range vector {
+			vector[j] = rng.Float32()
+		}
+		id, err := idx.Insert(vector)
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		vectors[id] = vector
+	}
+
+	// Churn: delete most of the index
+	for id := uint64(0); id < 1000; id++ {
+		if id%5 != 0 {
+			if err := idx.Delete(id); err != nil {
+				t.Fatalf("Delete %d failed: %v", id, err)
+			}
+			delete(vectors, id)
+		}
+	}
+	if idx.DeletedSinceBuild() != 800 {
+		t.Fatalf("Expected 800 deletes, got %d", idx.DeletedSinceBuild())
+	}
+
+	stats, err := idx.Compact()
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if stats.NodesBefore != 200 || stats.NodesAfter != 200 || stats.Deleted != 800 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.MemoryBefore == 0 || stats.MemoryAfter == 0 {
+		t.Errorf("Expected memory to be reported, got %+v", stats)
+	}
+	if idx.DeletedSinceBuild() != 0 {
+		t.Errorf("Expected the delete count reset, got %d", idx.DeletedSinceBuild())
+	}
+	if err := idx.Validate(); err != nil {
+		t.Fatalf("Expected valid graph after compaction, got %v", err)
+	}
+
+	// IDs and vectors are preserved
+	for id, vector := range vectors {
+		got, err := idx.GetVector(id)
+		if err != nil {
+			t.Fatalf("GetVector %d failed: %v", id, err)
+		}
+		for j := range vector {
+			if got[j] != vector[j] {
+				t.Fatalf("Vector %d changed at component %d", id, j)
+			}
+		}
+	}
+
+	// New IDs continue after the old ones
+	id, err := idx.Insert(vectors[0])
+	if err != nil {
+		t.Fatalf("Insert after compaction failed: %v", err)
+	}
+	if id != 1000 {
+		t.Errorf("Expected next ID 1000, got %d", id)
+	}
+
+	// The rebuilt graph finds every live vector
+	for id, vector := range vectors {
+		result, err := idx.Search(vector, 1, 50)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(result.Results) == 0 || result.Results[0].Distance > 1e-5 {
+			t.Errorf("Expected vector %d as its own nearest neighbor, got %+v", id, result.Results)
+		}
+	}
+}
+
+func TestCompactFloat16(t *testing.T) {
+	config := DefaultConfig()
+	config.Storage = StorageFloat16
+	idx := New(config)
+
+	for i := 0; i < 100; i++ {
+		idx.Insert(randomVector(16))
+	}
+	for id := uint64(0); id < 100; id += 2 {
+		idx.Delete(id)
+	}
+	before, _ := idx.GetVector(1)
+
+	if _, err := idx.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if n := idx.GetNode(1); n.vector != nil || n.half == nil {
+		t.Errorf("Expected compacted nodes to keep float16 storage")
+	}
+	after, _ := idx.GetVector(1)
+	for j := range before {
+		if before[j] != after[j] {
+			t.Fatalf("Vector changed at component %d: %v -> %v", j, before[j], after[j])
+		}
+	}
+}
+
+func TestCompactEmpty(t *testing.T) {
+	idx := New(DefaultConfig())
+	stats, err := idx.Compact()
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if stats.NodesAfter != 0 || idx.EntryPoint() != nil {
+		t.Errorf("Expected an empty index to stay empty, got %+v", stats)
+	}
+}
@@ -55,8 +55,11 @@ func EuclideanDistance(a, b []float32) float32 {
 }
 
 // DotProduct calculates the negative dot product between two vectors
-// Returns negative value because HNSW minimizes distance (lower = better)
+// Returns negative value because HNSW minimizes distance (lower = better),
+// so search results come back by descending inner product
 // Formula: -(a·b)
+// Not a metric: recall can fall short of cosine or Euclidean indexes; see
+// MIPSTransform for the metric alternative
 func DotProduct(a, b []float32) float32 {
 	if len(a) != len(b) {
 		panic("vectors must have the same dimension")
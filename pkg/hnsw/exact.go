@@ -0,0 +1,74 @@
+package hnsw
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ExactSearch finds the k nearest neighbors by scanning every node.
+// Results are exact, and on small indexes the scan is cheaper than
+// walking the graph.
+func (idx *Index) ExactSearch(query []float32, k int) (*SearchResult, error) {
+	return idx.ExactSearchWithProfile(query, k, nil)
+}
+
+// ExactSearchWithProfile performs an exact search like ExactSearch, recording
+// distance computations, visited nodes and scan time into prof.
+// A nil prof disables profiling.
+func (idx *Index) ExactSearchWithProfile(query []float32, k int, prof *SearchProfile) (*SearchResult, error) {
+	if len(query) == 0 {
+		return nil, fmt.Errorf("query vector cannot be empty")
+	}
+
+	var start time.Time
+	if prof != nil {
+		start = time.Now()
+	}
+
+	idx.mu.RLock()
+
+	if idx.dimension == 0 {
+		idx.mu.RUnlock()
+		return nil, fmt.Errorf("index is empty")
+	}
+
+	if len(query) != idx.dimension {
+		idx.mu.RUnlock()
+		return nil, fmt.Errorf("query dimension mismatch: expected %d, got %d",
+			idx.dimension, len(query))
+	}
+
+	results := make([]Result, 0, len(idx.nodes))
+	for id, node := range idx.nodes {
+		results = append(results, Result{
+			ID:       id,
+			Distance: idx.profiledDistance(query, node, prof),
+		})
+	}
+
+	idx.mu.RUnlock()
+
+	// Break distance ties by ID so results are deterministic
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	visited := len(results)
+	if len(results) > k {
+		results = results[:k]
+	}
+
+	if prof != nil {
+		prof.NodesVisited += visited
+		prof.ScanNanos += time.Since(start).Nanoseconds()
+	}
+
+	return &SearchResult{
+		Results: results,
+		Visited: visited,
+	}, nil
+}
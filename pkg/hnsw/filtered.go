@@ -0,0 +1,221 @@
+package hnsw
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultFilteredMaxVisited caps a filtered search's traversal when the
+// caller sets no cap
+const DefaultFilteredMaxVisited = 10000
+
+// SearchFiltered performs k-NN search over only the vectors for which
+// predicate returns true. Unlike filtering the results of Search, the
+// predicate is evaluated during graph traversal: nodes that fail it are
+// still expanded, to reach matches behind them, but take no result slots,
+// so a selective filter still yields k results when enough matches are
+// reachable.
+func (idx *Index) SearchFiltered(query []float32, k int, efSearch int, predicate func(id uint64) bool) (*SearchResult, error) {
+	return idx.SearchFilteredCtx(context.Background(), query, k, efSearch, predicate, 0, nil)
+}
+
+// SearchFilteredCtx performs a filtered search like SearchFiltered, with
+// the cancellation of SearchCtx and the profiling of SearchWithProfile.
+// The base layer traversal visits at most maxVisited nodes (0 means
+// DefaultFilteredMaxVisited); a search that reaches the cap before finding
+// k matches returns those it has with Truncated set.
+func (idx *Index) SearchFilteredCtx(ctx context.Context, query []float32, k int, efSearch int, predicate func(id uint64) bool, maxVisited int, prof *SearchProfile) (*SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(query) == 0 {
+		return nil, fmt.Errorf("query vector cannot be empty")
+	}
+	if maxVisited <= 0 {
+		maxVisited = DefaultFilteredMaxVisited
+	}
+
+	idx.mu.RLock()
+
+	if idx.dimension == 0 {
+		idx.mu.RUnlock()
+		return nil, fmt.Errorf("index is empty")
+	}
+
+	if len(query) != idx.dimension {
+		idx.mu.RUnlock()
+		return nil, fmt.Errorf("query dimension mismatch: expected %d, got %d",
+			idx.dimension, len(query))
+	}
+
+	if idx.entryPoint == nil {
+		idx.mu.RUnlock()
+		return nil, fmt.Errorf("index has no entry point")
+	}
+
+	if idx.flat {
+		size := len(idx.nodes)
+		idx.mu.RUnlock()
+		return idx.exactSearchFiltered(query, k, size, predicate, prof)
+	}
+
+	// Ensure efSearch is at least k
+	if efSearch < k {
+		efSearch = k
+	}
+
+	entryPoint := idx.entryPoint
+	maxLayer := idx.maxLayer
+
+	idx.mu.RUnlock()
+
+	// The upper layers only route to a good starting point, so they are
+	// walked without the predicate
+	ep, visited, err := idx.greedyDescent(ctx, query, entryPoint, maxLayer, prof)
+	if err != nil {
+		return nil, err
+	}
+
+	var phaseStart time.Time
+	if prof != nil {
+		phaseStart = time.Now()
+	}
+
+	candidates, truncated, err := idx.searchLayerFiltered(ctx, query, ep, efSearch, predicate, maxVisited, &visited, prof)
+	if err != nil {
+		return nil, err
+	}
+
+	if prof != nil {
+		prof.BaseLayerNanos += time.Since(phaseStart).Nanoseconds()
+		prof.NodesVisited += visited
+	}
+
+	results := make([]Result, 0, k)
+	for i := 0; i < len(candidates) && i < k; i++ {
+		results = append(results, Result{
+			ID:       candidates[i].id,
+			Distance: candidates[i].distance,
+		})
+	}
+
+	return &SearchResult{
+		Results:   results,
+		Visited:   visited,
+		Truncated: truncated && len(results) < k,
+	}, nil
+}
+
+// searchLayerFiltered is searchLayerForQuery with only matching nodes in
+// the result set. Until ef matches are held every neighbor is a candidate,
+// so the beam widens past runs of non-matching nodes; after that it
+// narrows as in an unfiltered search. It reports whether maxVisited cut
+// the traversal short.
+func (idx *Index) searchLayerFiltered(ctx context.Context, query []float32, entryPoint *Node, ef int, predicate func(id uint64) bool, maxVisited int, visited *int, prof *SearchProfile) ([]heapItem, bool, error) {
+	visitedSet := make(map[uint64]bool)
+	candidates := &minHeap{}
+	results := &maxHeap{}
+	heapOps := 0
+
+	dist := idx.profiledDistance(query, entryPoint, prof)
+	heap.Push(candidates, heapItem{id: entryPoint.ID(), distance: dist})
+	heapOps++
+	if predicate(entryPoint.ID()) {
+		heap.Push(results, heapItem{id: entryPoint.ID(), distance: dist})
+		heapOps++
+	}
+	visitedSet[entryPoint.ID()] = true
+	*visited++
+
+	truncated := false
+	for expanded := 0; candidates.Len() > 0 && !truncated; expanded++ {
+		if expanded%ctxCheckInterval == ctxCheckInterval-1 {
+			if err := ctx.Err(); err != nil {
+				return nil, false, err
+			}
+		}
+
+		current := heap.Pop(candidates).(heapItem)
+		heapOps++
+
+		// Stop once ef matches are held and no candidate can displace one
+		if results.Len() >= ef && current.distance > results.Peek().(heapItem).distance {
+			break
+		}
+
+		currentNode := idx.GetNode(current.id)
+		if currentNode == nil {
+			continue
+		}
+
+		for _, neighborID := range currentNode.GetNeighbors(0) {
+			if visitedSet[neighborID] {
+				continue
+			}
+			if len(visitedSet) >= maxVisited {
+				truncated = true
+				break
+			}
+			visitedSet[neighborID] = true
+			*visited++
+
+			neighborNode := idx.GetNode(neighborID)
+			if neighborNode == nil {
+				continue
+			}
+
+			neighborDist := idx.profiledDistance(query, neighborNode, prof)
+			if results.Len() < ef || neighborDist < results.Peek().(heapItem).distance {
+				heap.Push(candidates, heapItem{id: neighborID, distance: neighborDist})
+				heapOps++
+
+				if predicate(neighborID) {
+					heap.Push(results, heapItem{id: neighborID, distance: neighborDist})
+					heapOps++
+					if results.Len() > ef {
+						heap.Pop(results)
+						heapOps++
+					}
+				}
+			}
+		}
+	}
+
+	// Convert max heap to sorted slice (closest first)
+	resultSlice := make([]heapItem, results.Len())
+	for i := len(resultSlice) - 1; i >= 0; i-- {
+		resultSlice[i] = heap.Pop(results).(heapItem)
+	}
+	heapOps += len(resultSlice)
+
+	if prof != nil {
+		prof.HeapOperations += heapOps
+	}
+
+	return resultSlice, truncated, nil
+}
+
+// exactSearchFiltered scans every node of an index without a graph and
+// keeps the k closest that pass predicate
+func (idx *Index) exactSearchFiltered(query []float32, k, size int, predicate func(id uint64) bool, prof *SearchProfile) (*SearchResult, error) {
+	all, err := idx.ExactSearchWithProfile(query, size, prof)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, k)
+	for _, r := range all.Results {
+		if len(results) == k {
+			break
+		}
+		if predicate(r.ID) {
+			results = append(results, r)
+		}
+	}
+	return &SearchResult{
+		Results: results,
+		Visited: all.Visited,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package hnsw
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// newFilteredTestIndex builds a graph over n random vectors
+func newFilteredTestIndex(t *testing.T, n, dim int) (*Index, [][]float32) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(7))
+	idx := New(DefaultConfig())
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+		if _, err := idx.Insert(vectors[i]); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	return idx, vectors
+}
+
+func TestSearchFiltered(t *testing.T) {
+	idx, vectors := newFilteredTestIndex(t, 2000, 8)
+
+	// 1% of vectors match
+	rare := func(id uint64) bool { return id%100 == 0 }
+	query := vectors[1]
+	k := 10
+
+	// Filtering the results of a plain search keeps only ~1% of them
+	plain, err := idx.Search(query, k, k)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	postFiltered := 0
+	for _, r := range plain.Results {
+		if rare(r.ID) {
+			postFiltered++
+		}
+	}
+	if postFiltered >= k {
+		t.Fatalf("Expected post-filtering to find fewer than %d results, got %d", k, postFiltered)
+	}
+
+	result, err := idx.SearchFiltered(query, k, k, rare)
+	if err != nil {
+		t.Fatalf("SearchFiltered failed: %v", err)
+	}
+	if len(result.Results) != k {
+		t.Fatalf("Expected %d results from in-traversal filtering (post-filtering found %d), got %d",
+			k, postFiltered, len(result.Results))
+	}
+	if result.Truncated {
+		t.Error("Expected truncated=false when k matches were found")
+	}
+	for i, r := range result.Results {
+		if !rare(r.ID) {
+			t.Errorf("Result %d (ID %d) does not match the predicate", i, r.ID)
+		}
+		if i > 0 && r.Distance < result.Results[i-1].Distance {
+			t.Errorf("Results not sorted at %d", i)
+		}
+	}
+
+	// Compare against the true nearest matches
+	var truth []Result
+	for id, v := range vectors {
+		if rare(uint64(id)) {
+			truth = append(truth, Result{ID: uint64(id), Distance: EuclideanDistance(query, v)})
+		}
+	}
+	sort.Slice(truth, func(i, j int) bool { return truth[i].Distance < truth[j].Distance })
+	want := make(map[uint64]bool)
+	for _, r := range truth[:k] {
+		want[r.ID] = true
+	}
+	hits := 0
+	for _, r := range result.Results {
+		if want[r.ID] {
+			hits++
+		}
+	}
+	if recall := float64(hits) / float64(k); recall < 0.8 {
+		t.Errorf("Expected filtered recall >= 0.8, got %.2f", recall)
+	}
+}
+
+func TestSearchFilteredMaxVisited(t *testing.T) {
+	idx, vectors := newFilteredTestIndex(t, 2000, 8)
+	rare := func(id uint64) bool { return id%100 == 0 }
+
+	result, err := idx.SearchFilteredCtx(context.Background(), vectors[1], 10, 10, rare, 50, nil)
+	if err != nil {
+		t.Fatalf("SearchFilteredCtx failed: %v", err)
+	}
+	if !result.Truncated {
+		t.Errorf("Expected truncated=true at a 50-node cap, got %d results", len(result.Results))
+	}
+	if len(result.Results) >= 10 {
+		t.Errorf("Expected fewer than 10 matches within the cap, got %d", len(result.Results))
+	}
+
+	// No matches at all explores up to the cap and returns nothing
+	result, err = idx.SearchFiltered(vectors[1], 10, 10, func(uint64) bool { return false })
+	if err != nil {
+		t.Fatalf("SearchFiltered failed: %v", err)
+	}
+	if len(result.Results) != 0 {
+		t.Errorf("Expected no results, got %d", len(result.Results))
+	}
+}
+
+func TestSearchFilteredFlat(t *testing.T) {
+	config := DefaultConfig()
+	config.FlatThreshold = 100
+	idx := New(config)
+	ids := make([]uint64, 50)
+	for i := range ids {
+		id, err := idx.Insert([]float32{float32(i), 1})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids[i] = id
+	}
+
+	even := func(id uint64) bool { return id%2 == 0 }
+	result, err := idx.SearchFiltered([]float32{11, 1}, 3, 10, even)
+	if err != nil {
+		t.Fatalf("SearchFiltered failed: %v", err)
+	}
+	if len(result.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(result.Results))
+	}
+	for _, r := range result.Results {
+		if !even(r.ID) {
+			t.Errorf("Result ID %d does not match the predicate", r.ID)
+		}
+	}
+	if result.Results[0].ID != ids[10] && result.Results[0].ID != ids[12] {
+		t.Errorf("Expected the nearest match to be vector 10 or 12, got ID %d", result.Results[0].ID)
+	}
+}
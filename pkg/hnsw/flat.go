@@ -0,0 +1,77 @@
+package hnsw
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Flat reports whether the index is still within its FlatThreshold: its
+// vectors are stored without graph links and searches scan all of them
+func (idx *Index) Flat() bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.flat
+}
+
+// Config returns the configuration the index was created with, for
+// building another index like it
+func (idx *Index) Config() IndexConfig {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.configLocked()
+}
+
+// configLocked returns the configuration the index was created with.
+// Callers hold idx.mu.
+func (idx *Index) configLocked() IndexConfig {
+	return IndexConfig{
+		M:              idx.M,
+		efConstruction: idx.efConstruction,
+		DistanceFunc:   idx.distanceFunc,
+		PruneAlpha:     idx.pruneAlpha,
+		Storage:        idx.storage,
+		FlatThreshold:  idx.flatThreshold,
+	}
+}
+
+// buildGraph inserts nodes into a new index with the given configuration,
+// keeping their IDs. Nodes are inserted in ID order so the graph does not
+// depend on map order.
+func buildGraph(config IndexConfig, M0 int, ml float64, nodeCounter uint64, nodes []*Node) (*Index, error) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].id < nodes[j].id })
+
+	rebuilt := New(config)
+	rebuilt.M0 = M0
+	rebuilt.ml = ml
+	rebuilt.nodeCounter = nodeCounter // InsertWithID only accepts IDs below the counter
+	for _, node := range nodes {
+		// Half floats decode to float32 and encode back exactly
+		if err := rebuilt.InsertWithID(node.id, node.Vector()); err != nil {
+			return nil, fmt.Errorf("failed to rebuild node %d: %w", node.id, err)
+		}
+	}
+	return rebuilt, nil
+}
+
+// buildGraphLocked links the stored vectors of a flat index into a graph
+// and leaves flat mode for good. Callers hold idx.mu for writing.
+func (idx *Index) buildGraphLocked() error {
+	nodes := make([]*Node, 0, len(idx.nodes))
+	for _, node := range idx.nodes {
+		nodes = append(nodes, node)
+	}
+
+	config := idx.configLocked()
+	config.FlatThreshold = 0
+	rebuilt, err := buildGraph(config, idx.M0, idx.ml, idx.nodeCounter, nodes)
+	if err != nil {
+		return err
+	}
+
+	idx.nodes = rebuilt.nodes
+	idx.entryPoint = rebuilt.entryPoint
+	idx.maxLayer = rebuilt.maxLayer
+	idx.flat = false
+	idx.unlinked = nil
+	return nil
+}
@@ -0,0 +1,205 @@
+package hnsw
+
+import (
+	"bytes"
+	"testing"
+)
+
+// assertUnlinked fails if any node has a graph link
+func assertUnlinked(t *testing.T, idx *Index) {
+	t.Helper()
+	for id, node := range idx.nodes {
+		for layer, neighbors := range node.GetAllNeighbors() {
+			if len(neighbors) > 0 {
+				t.Fatalf("Node %d has %d links at layer %d in a flat index", id, len(neighbors), layer)
+			}
+		}
+	}
+}
+
+// assertSameResults fails unless Search and ExactSearch agree on query
+func assertSameResults(t *testing.T, idx *Index, query []float32, k int) {
+	t.Helper()
+	got, err := idx.Search(query, k, 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	want, err := idx.ExactSearch(query, k)
+	if err != nil {
+		t.Fatalf("ExactSearch failed: %v", err)
+	}
+	if len(got.Results) != len(want.Results) {
+		t.Fatalf("Expected %d results, got %d", len(want.Results), len(got.Results))
+	}
+	for i := range want.Results {
+		if got.Results[i] != want.Results[i] {
+			t.Errorf("Rank %d: got %+v, expected %+v", i, got.Results[i], want.Results[i])
+		}
+	}
+}
+
+func TestFlatIndex(t *testing.T) {
+	config := DefaultConfig()
+	config.FlatThreshold = 100
+	idx := New(config)
+
+	vectors := make(map[uint64][]float32)
+	for i := 0; i < 100; i++ {
+		vector := randomVector(16)
+		id, err := idx.Insert(vector)
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		vectors[id] = vector
+	}
+
+	if !idx.Flat() {
+		t.Fatal("Expected the index to stay flat at its threshold")
+	}
+	assertUnlinked(t, idx)
+	if err := idx.Validate(); err != nil {
+		t.Fatalf("Expected a valid flat index, got %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		assertSameResults(t, idx, randomVector(16), 10)
+	}
+
+	// Deletes and updates work without a graph
+	if err := idx.Delete(3); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	delete(vectors, 3)
+	vectors[5] = randomVector(16)
+	if err := idx.Update(5, vectors[5]); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	assertSameResults(t, idx, vectors[5], 5)
+
+	// Growing past the threshold builds the graph over every stored vector
+	for i := 0; i < 2; i++ {
+		vector := randomVector(16)
+		id, err := idx.Insert(vector)
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		vectors[id] = vector
+	}
+	if idx.Flat() {
+		t.Fatal("Expected the graph to be built past the threshold")
+	}
+	if err := idx.Validate(); err != nil {
+		t.Fatalf("Expected a valid graph, got %v", err)
+	}
+	if idx.Size() != int64(len(vectors)) {
+		t.Errorf("Expected %d vectors, got %d", len(vectors), idx.Size())
+	}
+	for id, vector := range vectors {
+		got, err := idx.GetVector(id)
+		if err != nil {
+			t.Fatalf("GetVector %d failed: %v", id, err)
+		}
+		for j := range vector {
+			if got[j] != vector[j] {
+				t.Fatalf("Vector %d changed at component %d", id, j)
+			}
+		}
+
+		result, err := idx.Search(vector, 1, 50)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(result.Results) == 0 || result.Results[0].ID != id {
+			t.Errorf("Expected vector %d as its own nearest neighbor, got %+v", id, result.Results)
+		}
+	}
+
+	// Shrinking keeps the graph
+	for id := range vectors {
+		if id%2 == 0 {
+			idx.Delete(id)
+		}
+	}
+	if idx.Flat() {
+		t.Error("Expected deletes to keep the graph")
+	}
+}
+
+func TestFlatIndexSaveLoad(t *testing.T) {
+	config := DefaultConfig()
+	config.FlatThreshold = 50
+	original := New(config)
+	for i := 0; i < 40; i++ {
+		original.Insert(randomVector(8))
+	}
+
+	var buf bytes.Buffer
+	if err := original.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	// Within the threshold the index loads flat
+	flat := New(config)
+	if err := flat.Load(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !flat.Flat() {
+		t.Error("Expected the loaded index to be flat")
+	}
+	query := randomVector(8)
+	assertSameResults(t, flat, query, 10)
+
+	// An index without a flat threshold builds the graph on load
+	graph := New(DefaultConfig())
+	if err := graph.Load(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if graph.Flat() {
+		t.Error("Expected the graph to be built on load")
+	}
+	if err := graph.Validate(); err != nil {
+		t.Fatalf("Expected a valid graph, got %v", err)
+	}
+	result, err := graph.Search(query, 40, 100)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(result.Results) != 40 {
+		t.Errorf("Expected all 40 vectors from the built graph, got %d", len(result.Results))
+	}
+
+	// A saved graph loads as a graph even within the threshold
+	buf.Reset()
+	if err := graph.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reloaded := New(config)
+	if err := reloaded.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if reloaded.Flat() {
+		t.Error("Expected a saved graph to load as a graph")
+	}
+}
+
+func TestFlatIndexCompact(t *testing.T) {
+	config := DefaultConfig()
+	config.FlatThreshold = 20
+	idx := New(config)
+	for i := 0; i < 30; i++ {
+		idx.Insert(randomVector(8))
+	}
+	for id := uint64(0); id < 20; id++ {
+		idx.Delete(id)
+	}
+
+	// Compaction rebuilds a shrunken index flat, like a fresh one
+	if _, err := idx.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if !idx.Flat() {
+		t.Error("Expected a compacted index within the threshold to be flat")
+	}
+	assertUnlinked(t, idx)
+	assertSameResults(t, idx, randomVector(8), 5)
+}
@@ -0,0 +1,133 @@
+package hnsw
+
+import (
+	"math"
+	"sync"
+)
+
+// Vector storage types
+const (
+	StorageFloat32 = "float32" // Store vectors as float32 (default)
+	StorageFloat16 = "float16" // Store vectors as IEEE half floats, halving vector memory
+)
+
+// float32ToFloat16 converts f to the nearest IEEE 754 half-precision value,
+// rounding ties to even. Values beyond the half range (±65504) become
+// infinities and values below half the smallest subnormal become zero.
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int((bits>>23)&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case (bits>>23)&0xff == 0xff:
+		// Infinity or NaN (kept quiet)
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+
+	case exp >= 0x1f:
+		// Too large for half precision
+		return sign | 0x7c00
+
+	case exp <= 0:
+		// Subnormal in half precision, or too small and rounded to zero
+		if exp < -10 {
+			return sign
+		}
+		full := mant | 0x800000
+		shift := uint32(14 - exp)
+		half := full >> shift
+		rem := full & (1<<shift - 1)
+		halfway := uint32(1) << (shift - 1)
+		if rem > halfway || (rem == halfway && half&1 == 1) {
+			half++
+		}
+		return sign | uint16(half)
+	}
+
+	// A rounding carry may overflow the mantissa into the exponent, which
+	// is still the correctly rounded result (up to infinity)
+	half := uint32(exp)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+		half++
+	}
+	return sign | uint16(half)
+}
+
+// float16ToFloat32 converts an IEEE 754 half-precision value to float32.
+// Every half value is exactly representable as a float32.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0x1f:
+		// Infinity or NaN
+		return math.Float32frombits(sign | 0xff<<23 | mant<<13)
+
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// Subnormal: shift the mantissa up until it has a leading one
+		e := uint32(127 - 15 + 1)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		mant &= 0x3ff
+		return math.Float32frombits(sign | e<<23 | mant<<13)
+	}
+
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// encodeFloat16 converts a vector to half precision
+func encodeFloat16(vector []float32) []uint16 {
+	half := make([]uint16, len(vector))
+	for i, v := range vector {
+		half[i] = float32ToFloat16(v)
+	}
+	return half
+}
+
+// float16Table maps every half-precision bit pattern to its float32 value.
+// At 256KB it stays cache resident and makes decoding a single load per
+// component, which matters since every distance to a float16 node decodes.
+var float16Table = func() *[1 << 16]float32 {
+	var table [1 << 16]float32
+	for h := range table {
+		table[h] = float16ToFloat32(uint16(h))
+	}
+	return &table
+}()
+
+// decodeFloat16 converts a half-precision vector into dst, which must have
+// the same length
+func decodeFloat16(dst []float32, half []uint16) {
+	dst = dst[:len(half)]
+	for i, h := range half {
+		dst[i] = float16Table[h]
+	}
+}
+
+// decodeBuffers holds float32 scratch vectors for distance computations
+// against float16 nodes, so decoding does not allocate per comparison
+var decodeBuffers = sync.Pool{
+	New: func() interface{} { return new([]float32) },
+}
+
+// getDecodeBuffer returns a pooled scratch vector of length n
+func getDecodeBuffer(n int) *[]float32 {
+	buf := decodeBuffers.Get().(*[]float32)
+	if cap(*buf) < n {
+		*buf = make([]float32, n)
+	}
+	*buf = (*buf)[:n]
+	return buf
+}
@@ -0,0 +1,260 @@
+package hnsw
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFloat16Conversion(t *testing.T) {
+	// Values exactly representable in half precision round-trip unchanged
+	exact := []float32{0, 1, -2, 0.5, 0.1328125, 65504, -65504, 6.1035156e-05, 5.9604645e-08}
+	for _, f := range exact {
+		if got := float16ToFloat32(float32ToFloat16(f)); got != f {
+			t.Errorf("Round trip of %v gave %v", f, got)
+		}
+	}
+
+	// Negative zero keeps its sign
+	if h := float32ToFloat16(float32(math.Copysign(0, -1))); h != 0x8000 {
+		t.Errorf("Expected -0 as 0x8000, got %#x", h)
+	}
+
+	// Out of range values saturate to infinity or flush to zero
+	if got := float16ToFloat32(float32ToFloat16(1e6)); !math.IsInf(float64(got), 1) {
+		t.Errorf("Expected +Inf for 1e6, got %v", got)
+	}
+	if got := float16ToFloat32(float32ToFloat16(-1e6)); !math.IsInf(float64(got), -1) {
+		t.Errorf("Expected -Inf for -1e6, got %v", got)
+	}
+	if got := float16ToFloat32(float32ToFloat16(1e-9)); got != 0 {
+		t.Errorf("Expected 0 for 1e-9, got %v", got)
+	}
+	if got := float16ToFloat32(float32ToFloat16(float32(math.NaN()))); !math.IsNaN(float64(got)) {
+		t.Errorf("Expected NaN, got %v", got)
+	}
+
+	// Ties round to even: 1 + 2^-11 lies halfway between 1 and 1 + 2^-10
+	if got := float16ToFloat32(float32ToFloat16(1 + 1.0/2048)); got != 1 {
+		t.Errorf("Expected tie to round to 1, got %v", got)
+	}
+	if got := float16ToFloat32(float32ToFloat16(1 + 3.0/2048)); got != 1+2.0/1024 {
+		t.Errorf("Expected tie to round to 1+2^-9, got %v", got)
+	}
+
+	// Normal values are within half a unit in the last place (2^-11 relative)
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 100000; i++ {
+		f := float32((1 + rng.Float64()) * math.Pow(2, float64(rng.Intn(29)-14)))
+		if rng.Intn(2) == 0 {
+			f = -f
+		}
+		got := float16ToFloat32(float32ToFloat16(f))
+		if relErr := math.Abs(float64(got-f)) / math.Abs(float64(f)); relErr > 1.0/2048 {
+			t.Fatalf("Round trip of %v gave %v (relative error %v)", f, got, relErr)
+		}
+	}
+
+	// Every half value survives a round trip through float32
+	for h := 0; h < 1<<16; h++ {
+		f := float16ToFloat32(uint16(h))
+		if math.IsNaN(float64(f)) {
+			continue
+		}
+		if back := float32ToFloat16(f); back != uint16(h) {
+			t.Fatalf("Half %#x decoded to %v and encoded back as %#x", h, f, back)
+		}
+	}
+}
+
+func TestFloat16Storage(t *testing.T) {
+	config := DefaultConfig()
+	config.Storage = StorageFloat16
+	idx := New(config)
+
+	if idx.Storage() != StorageFloat16 {
+		t.Fatalf("Expected float16 storage, got %q", idx.Storage())
+	}
+
+	vector := []float32{0.25, -1.5, 3.0009766}
+	id, err := idx.Insert(vector)
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	node := idx.GetNode(id)
+	if node.vector != nil || len(node.half) != 3 {
+		t.Fatalf("Expected the vector stored as float16 only, got %v / %v", node.vector, node.half)
+	}
+
+	// Stored vectors come back rounded to half precision
+	got, err := idx.GetVector(id)
+	if err != nil {
+		t.Fatalf("GetVector failed: %v", err)
+	}
+	for i, v := range []float32{0.25, -1.5, 3} {
+		if got[i] != v {
+			t.Errorf("Component %d: expected %v, got %v", i, v, got[i])
+		}
+	}
+
+	// Save writes float32 vectors, and Load keeps the loading index's storage
+	var buf bytes.Buffer
+	if err := idx.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded := New(config)
+	if err := loaded.Load(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if n := loaded.GetNode(id); n.vector != nil || len(n.half) != 3 {
+		t.Errorf("Expected the loaded node stored as float16")
+	}
+
+	plain := New(DefaultConfig())
+	if err := plain.Load(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if n := plain.GetNode(id); n.half != nil || n.vector[2] != 3 {
+		t.Errorf("Expected a float32 index to load the rounded float32 vector, got %v", n.vector)
+	}
+
+	if err := idx.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
+
+// TestRecallFloat16 compares recall of float32 and float16 storage on the
+// same data; half precision should cost at most a couple of points
+func TestRecallFloat16(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping recall comparison in short mode")
+	}
+
+	const (
+		numVectors = 3000
+		dim        = 128
+		numQueries = 100
+		k          = 10
+	)
+
+	rng := rand.New(rand.NewSource(42))
+	vectors := make([][]float32, numVectors)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = float32(rng.NormFloat64())
+		}
+	}
+	queries := make([][]float32, numQueries)
+	for i := range queries {
+		queries[i] = make([]float32, dim)
+		for j := range queries[i] {
+			queries[i][j] = float32(rng.NormFloat64())
+		}
+	}
+
+	recall := func(storage string) float64 {
+		config := DefaultConfig()
+		config.Storage = storage
+		idx := New(config)
+		idx.rand = rand.New(rand.NewSource(7))
+		for _, v := range vectors {
+			if _, err := idx.Insert(v); err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+		}
+
+		total := 0.0
+		for _, q := range queries {
+			result, err := idx.Search(q, k, 100)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			truth := bruteForceKNN(q, vectors, k, config.DistanceFunc)
+			total += calculateRecall(result.Results, truth, k)
+		}
+		return total / numQueries
+	}
+
+	full := recall(StorageFloat32)
+	half := recall(StorageFloat16)
+	t.Logf("Recall@%d: float32 %.3f, float16 %.3f", k, full, half)
+
+	if half < 0.9 {
+		t.Errorf("Expected float16 recall >= 0.9, got %.3f", half)
+	}
+	if full-half > 0.02 {
+		t.Errorf("Expected float16 recall within 0.02 of float32 (%.3f), got %.3f", full, half)
+	}
+}
+
+func TestMemoryUsageFloat16(t *testing.T) {
+	const dim = 768
+
+	usage := func(storage string) int64 {
+		config := DefaultConfig()
+		config.Storage = storage
+		idx := New(config)
+		rng := rand.New(rand.NewSource(42))
+		for i := 0; i < 500; i++ {
+			vector := make([]float32, dim)
+			for j := range vector {
+				vector[j] = rng.Float32()
+			}
+			if _, err := idx.Insert(vector); err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+		}
+		return idx.MemoryUsage()
+	}
+
+	full := usage(StorageFloat32)
+	half := usage(StorageFloat16)
+
+	// Vectors dominate at this dimension, so the total nearly halves
+	saved := full - half
+	if want := int64(500 * dim * 2); saved < want*9/10 {
+		t.Errorf("Expected float16 to save about %d bytes, saved %d (%d -> %d)", want, saved, full, half)
+	}
+}
+
+// BenchmarkMemoryFloat16 reports index memory per vector for each storage
+// type, timing searches to show the cost of decoding during distance computation
+func BenchmarkMemoryFloat16(b *testing.B) {
+	for _, storage := range []string{StorageFloat32, StorageFloat16} {
+		b.Run(storage, func(b *testing.B) {
+			const (
+				numVectors = 2000
+				dim        = 768
+			)
+			rng := rand.New(rand.NewSource(42))
+			vectors := make([][]float32, numVectors)
+			for i := range vectors {
+				vectors[i] = make([]float32, dim)
+				for j := range vectors[i] {
+					vectors[i][j] = rng.Float32()
+				}
+			}
+
+			config := DefaultConfig()
+			config.Storage = storage
+			idx := New(config)
+			for _, v := range vectors {
+				if _, err := idx.Insert(v); err != nil {
+					b.Fatalf("Insert failed: %v", err)
+				}
+			}
+
+			query := vectors[0]
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := idx.Search(query, 10, 100); err != nil {
+					b.Fatalf("Search failed: %v", err)
+				}
+			}
+			b.ReportMetric(float64(idx.MemoryUsage())/numVectors, "bytes/vector")
+		})
+	}
+}
@@ -3,6 +3,7 @@ package hnsw
 import (
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 )
@@ -15,6 +16,9 @@ type Index struct {
 	efConstruction int          // Size of dynamic candidate list during construction
 	ml             float64      // Normalization factor for level generation
 	distanceFunc   DistanceFunc // Distance metric function
+	pruneAlpha     float64      // Occlusion slack used when repairing overflowing neighborhoods
+	storage        string       // Vector storage type (StorageFloat32 or StorageFloat16)
+	flatThreshold  int          // Largest size kept without a graph (0 = always build the graph)
 
 	// Index state
 	nodes       map[uint64]*Node // All nodes in the index
@@ -22,20 +26,31 @@ type Index struct {
 	maxLayer    int              // Maximum layer in the index
 	nodeCounter uint64           // Counter for generating unique node IDs
 	dimension   int              // Vector dimension (set on first insert)
+	flat        bool             // Vectors are unlinked and searched by a full scan
+	unlinked    map[uint64]bool  // Deleted IDs that nodes may still link to, until the next sweep
 
 	// Concurrency control
 	mu   sync.RWMutex // Protects index-level operations
 	rand *rand.Rand   // Random number generator for level assignment
 
 	// Statistics
-	size int64 // Number of vectors in the index
+	size    int64 // Number of vectors in the index
+	deleted int64 // Deletes since the graph was built or compacted
 }
 
 // IndexConfig holds configuration for creating a new Index
 type IndexConfig struct {
 	M              int          // Bi-directional links per node (typical: 16-32)
 	efConstruction int          // Size of candidate list during insertion (typical: 200)
-	DistanceFunc   DistanceFunc // Distance metric (default: CosineSimilarity)
+	DistanceFunc   DistanceFunc // Distance metric: CosineSimilarity (default), EuclideanDistance or DotProduct
+	PruneAlpha     float64      // Occlusion slack when pruning overflowing links; >1 keeps more long edges (default: 1.0)
+	Storage        string       // Vector storage: StorageFloat32 (default) or StorageFloat16, which halves vector memory at a small recall cost
+
+	// FlatThreshold keeps indexes of at most this many vectors flat:
+	// inserts skip graph construction and searches scan every vector, which
+	// on small indexes is both cheaper and exact. The graph is built once,
+	// when the index grows past the threshold (default: 0 = always build).
+	FlatThreshold int
 }
 
 // DefaultConfig returns a configuration with recommended default values
@@ -44,9 +59,22 @@ func DefaultConfig() IndexConfig {
 		M:              16,
 		efConstruction: 200,
 		DistanceFunc:   CosineSimilarity,
+		PruneAlpha:     1.0,
+		Storage:        StorageFloat32,
 	}
 }
 
+// EfConstruction returns the size of the candidate list used during insertion
+func (c IndexConfig) EfConstruction() int {
+	return c.efConstruction
+}
+
+// SetEfConstruction sets the size of the candidate list used during
+// insertion (0 = the default of 200)
+func (c *IndexConfig) SetEfConstruction(efConstruction int) {
+	c.efConstruction = efConstruction
+}
+
 // New creates a new HNSW index with the given configuration
 func New(config IndexConfig) *Index {
 	// Apply defaults if not set
@@ -59,6 +87,12 @@ func New(config IndexConfig) *Index {
 	if config.DistanceFunc == nil {
 		config.DistanceFunc = CosineSimilarity
 	}
+	if config.PruneAlpha < 1 {
+		config.PruneAlpha = 1.0
+	}
+	if config.Storage != StorageFloat16 {
+		config.Storage = StorageFloat32
+	}
 
 	// M0 is typically 2*M for the base layer
 	M0 := config.M * 2
@@ -73,6 +107,10 @@ func New(config IndexConfig) *Index {
 		efConstruction: config.efConstruction,
 		ml:             ml,
 		distanceFunc:   config.DistanceFunc,
+		pruneAlpha:     config.PruneAlpha,
+		storage:        config.Storage,
+		flatThreshold:  config.FlatThreshold,
+		flat:           config.FlatThreshold > 0,
 		nodes:          make(map[uint64]*Node),
 		maxLayer:       -1,
 		nodeCounter:    0,
@@ -122,6 +160,38 @@ func (idx *Index) GetNode(id uint64) *Node {
 	return idx.nodes[id]
 }
 
+// IDs returns the IDs of every vector in the index in ascending order
+func (idx *Index) IDs() []uint64 {
+	idx.mu.RLock()
+	ids := make([]uint64, 0, len(idx.nodes))
+	for id := range idx.nodes {
+		ids = append(ids, id)
+	}
+	idx.mu.RUnlock()
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// IDsFrom returns up to limit IDs of at least from, in ascending order. It
+// scans every node, so paging through an index this way costs O(n) a page.
+func (idx *Index) IDsFrom(from uint64, limit int) []uint64 {
+	idx.mu.RLock()
+	var ids []uint64
+	for id := range idx.nodes {
+		if id >= from {
+			ids = append(ids, id)
+		}
+	}
+	idx.mu.RUnlock()
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
 // EntryPoint returns the current entry point node
 func (idx *Index) EntryPoint() *Node {
 	idx.mu.RLock()
@@ -164,17 +234,89 @@ func (idx *Index) GetStats() IndexStats {
 	}
 }
 
+// LayerStats describes one layer of the graph
+type LayerStats struct {
+	Layer     int     // Layer number (0 is the base layer)
+	Nodes     int     // Nodes present in the layer
+	AvgDegree float64 // Mean number of links per node in the layer
+}
+
+// LayerStats returns the node count and average degree of every layer,
+// base layer first. Comparing the degrees with M (M0 at layer 0) shows
+// whether neighborhoods fill up for the data size. A flat index reports
+// one unlinked layer.
+func (idx *Index) LayerStats() []LayerStats {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if idx.maxLayer < 0 {
+		return nil
+	}
+
+	layers := make([]LayerStats, idx.maxLayer+1)
+	links := make([]int, idx.maxLayer+1)
+	for _, node := range idx.nodes {
+		node.mu.RLock()
+		for layer := 0; layer <= node.level && layer < len(layers); layer++ {
+			layers[layer].Nodes++
+			if layer < len(node.neighbors) {
+				links[layer] += len(node.neighbors[layer])
+			}
+		}
+		node.mu.RUnlock()
+	}
+
+	for layer := range layers {
+		layers[layer].Layer = layer
+		if layers[layer].Nodes > 0 {
+			layers[layer].AvgDegree = float64(links[layer]) / float64(layers[layer].Nodes)
+		}
+	}
+	return layers
+}
+
 // distance calculates the distance between two vectors
 func (idx *Index) distance(a, b []float32) float32 {
 	return idx.distanceFunc(a, b)
 }
 
-// distanceToNode calculates the distance from a vector to a node
+// distanceToNode calculates the distance from a vector to a node. A
+// float16 node is decoded into a scratch vector just for the computation.
 func (idx *Index) distanceToNode(vector []float32, node *Node) float32 {
-	return idx.distanceFunc(vector, node.vector)
+	if node.half == nil {
+		return idx.distanceFunc(vector, node.vector)
+	}
+
+	buf := getDecodeBuffer(len(node.half))
+	decodeFloat16(*buf, node.half)
+	dist := idx.distanceFunc(vector, *buf)
+	decodeBuffers.Put(buf)
+	return dist
 }
 
 // distanceBetweenNodes calculates the distance between two nodes
 func (idx *Index) distanceBetweenNodes(a, b *Node) float32 {
-	return idx.distanceFunc(a.vector, b.vector)
+	if a.half == nil {
+		return idx.distanceToNode(a.vector, b)
+	}
+
+	buf := getDecodeBuffer(len(a.half))
+	decodeFloat16(*buf, a.half)
+	dist := idx.distanceToNode(*buf, b)
+	decodeBuffers.Put(buf)
+	return dist
+}
+
+// newNode creates a node in the index's storage type
+func (idx *Index) newNode(id uint64, vector []float32, level int) *Node {
+	node := NewNode(id, vector, level)
+	if idx.storage == StorageFloat16 {
+		node.storeFloat16()
+	}
+	return node
+}
+
+// Storage returns the vector storage type (StorageFloat32 or StorageFloat16)
+func (idx *Index) Storage() string {
+	return idx.storage
 }
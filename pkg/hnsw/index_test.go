@@ -283,6 +283,90 @@ func TestIndexStats(t *testing.T) {
 	}
 }
 
+func TestIndexLayerStats(t *testing.T) {
+	idx := New(DefaultConfig())
+	if layers := idx.LayerStats(); layers != nil {
+		t.Errorf("Expected no layers for an empty index, got %v", layers)
+	}
+
+	for i := 0; i < 500; i++ {
+		if _, err := idx.Insert([]float32{float32(i), float32(i % 7), 1}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	layers := idx.LayerStats()
+	if len(layers) != idx.MaxLayer()+1 {
+		t.Fatalf("Expected %d layers, got %d", idx.MaxLayer()+1, len(layers))
+	}
+	if layers[0].Nodes != 500 {
+		t.Errorf("Expected all 500 nodes in the base layer, got %d", layers[0].Nodes)
+	}
+	for i, layer := range layers {
+		if layer.Layer != i {
+			t.Errorf("Layer %d reported as layer %d", i, layer.Layer)
+		}
+		if i > 0 && layer.Nodes > layers[i-1].Nodes {
+			t.Errorf("Layer %d has more nodes (%d) than the layer below (%d)", i, layer.Nodes, layers[i-1].Nodes)
+		}
+	}
+	if layers[0].AvgDegree <= 0 {
+		t.Errorf("Expected a linked base layer, got degree %.2f", layers[0].AvgDegree)
+	}
+}
+
+func TestIndexIDs(t *testing.T) {
+	idx := New(DefaultConfig())
+	if ids := idx.IDs(); len(ids) != 0 {
+		t.Errorf("Expected no IDs for an empty index, got %v", ids)
+	}
+
+	for i := 0; i < 100; i++ {
+		if _, err := idx.Insert([]float32{float32(i), 1}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	if err := idx.Delete(42); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	ids := idx.IDs()
+	if len(ids) != 99 {
+		t.Fatalf("Expected 99 IDs, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if id == 42 {
+			t.Error("Deleted ID 42 still listed")
+		}
+		if i > 0 && id <= ids[i-1] {
+			t.Fatalf("IDs not ascending at %d: %d after %d", i, id, ids[i-1])
+		}
+	}
+}
+
+func TestIndexIDsFrom(t *testing.T) {
+	idx := New(DefaultConfig())
+	for i := 0; i < 50; i++ {
+		if _, err := idx.Insert([]float32{float32(i), 1}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	if err := idx.Delete(11); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	page := idx.IDsFrom(10, 3)
+	if len(page) != 3 || page[0] != 10 || page[1] != 12 || page[2] != 13 {
+		t.Errorf("Expected IDs [10 12 13], got %v", page)
+	}
+	if page := idx.IDsFrom(48, 10); len(page) != 2 || page[0] != 48 || page[1] != 49 {
+		t.Errorf("Expected the last IDs [48 49], got %v", page)
+	}
+	if page := idx.IDsFrom(50, 10); len(page) != 0 {
+		t.Errorf("Expected no IDs past the end, got %v", page)
+	}
+}
+
 // BenchmarkRandomLevel benchmarks level generation
 func BenchmarkRandomLevel(b *testing.B) {
 	config := DefaultConfig()
@@ -318,3 +402,41 @@ func BenchmarkNodeGetNeighbors(b *testing.B) {
 		node.GetNeighbors(0)
 	}
 }
+
+// TestMemoryUsage checks the estimate covers vector storage and grows
+// linearly with the number of vectors
+func TestMemoryUsage(t *testing.T) {
+	const dim = 32
+	idx := New(DefaultConfig())
+
+	empty := idx.MemoryUsage()
+	if empty <= 0 {
+		t.Fatalf("Expected positive memory usage for an empty index, got %d", empty)
+	}
+
+	insert := func(n int) {
+		for i := 0; i < n; i++ {
+			vec := make([]float32, dim)
+			for j := range vec {
+				vec[j] = float32((i*31+j*7)%97) / 97
+			}
+			if _, err := idx.Insert(vec); err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+		}
+	}
+
+	insert(1000)
+	at1000 := idx.MemoryUsage() - empty
+	if at1000 < 1000*dim*4 {
+		t.Errorf("Expected at least %d bytes for 1000 vectors, got %d", 1000*dim*4, at1000)
+	}
+
+	insert(1000)
+	at2000 := idx.MemoryUsage() - empty
+	ratio := float64(at2000) / float64(at1000)
+	if ratio < 1.7 || ratio > 2.3 {
+		t.Errorf("Expected roughly linear growth, got %d bytes at 1000 and %d at 2000 (ratio %.2f)",
+			at1000, at2000, ratio)
+	}
+}
@@ -3,14 +3,102 @@ package hnsw
 import (
 	"container/heap"
 	"fmt"
+	"sort"
 )
 
 // Insert adds a vector to the HNSW index
 // Returns the ID of the inserted node
 func (idx *Index) Insert(vector []float32) (uint64, error) {
+	return idx.insert(vector, 0, false, 0)
+}
+
+// InsertWithEf adds a vector using efConstruction as the construction-time
+// candidate list size instead of the index default (used when zero).
+// A higher value only improves the links of the newly inserted node;
+// edges already in the graph are not rebuilt.
+func (idx *Index) InsertWithEf(vector []float32, efConstruction int) (uint64, error) {
+	return idx.insert(vector, 0, false, efConstruction)
+}
+
+// ReserveID allocates a node ID for a later InsertWithID call.
+// Reserving IDs up front lets callers fix the ID order of a batch and
+// then insert the vectors concurrently.
+func (idx *Index) ReserveID() uint64 {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	id := idx.nodeCounter
+	idx.nodeCounter++
+	return id
+}
+
+// NextID returns the ID the next Insert or ReserveID will assign
+func (idx *Index) NextID() uint64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.nodeCounter
+}
+
+// AdvanceNextID raises the ID counter to next, so IDs below it are never
+// assigned. It never lowers the counter. An index rebuilt from another
+// uses it to avoid reusing the IDs of vectors deleted from the original.
+func (idx *Index) AdvanceNextID(next uint64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if next > idx.nodeCounter {
+		idx.nodeCounter = next
+	}
+}
+
+// InsertWithID adds a vector under an ID obtained from ReserveID
+func (idx *Index) InsertWithID(id uint64, vector []float32) error {
+	_, err := idx.insert(vector, id, true, 0)
+	return err
+}
+
+// InsertWithIDAndEf combines InsertWithID and InsertWithEf
+func (idx *Index) InsertWithIDAndEf(id uint64, vector []float32, efConstruction int) error {
+	_, err := idx.insert(vector, id, true, efConstruction)
+	return err
+}
+
+// Restore inserts a vector under a specific ID, replacing any vector
+// already stored there, and advances the ID counter past it. It is meant
+// for rebuilding an index from a log of earlier writes, where replaying
+// the same record twice must leave the same state.
+func (idx *Index) Restore(id uint64, vector []float32) error {
+	idx.mu.Lock()
+	exists := idx.nodes[id] != nil
+	if id >= idx.nodeCounter {
+		idx.nodeCounter = id + 1
+	}
+	idx.mu.Unlock()
+
+	if exists {
+		return idx.Update(id, vector)
+	}
+	_, err := idx.insert(vector, id, true, 0)
+	return err
+}
+
+// insert adds a vector, assigning the next ID unless one was reserved.
+// A positive efConstruction overrides the index default for this insert.
+func (idx *Index) insert(vector []float32, reservedID uint64, reserved bool, efConstruction int) (uint64, error) {
+	return idx.insertAtLevel(vector, reservedID, reserved, efConstruction, -1)
+}
+
+// insertAtLevel is insert with the node's top layer chosen by the caller;
+// a negative level draws one at random
+func (idx *Index) insertAtLevel(vector []float32, reservedID uint64, reserved bool, efConstruction int, level int) (uint64, error) {
 	if len(vector) == 0 {
 		return 0, fmt.Errorf("cannot insert empty vector")
 	}
+	if efConstruction < 0 {
+		return 0, fmt.Errorf("efConstruction must be non-negative, got %d", efConstruction)
+	}
+	if efConstruction == 0 {
+		efConstruction = idx.efConstruction
+	}
 
 	idx.mu.Lock()
 
@@ -24,14 +112,47 @@ func (idx *Index) Insert(vector []float32) (uint64, error) {
 	}
 
 	// Generate unique ID for the new node
-	nodeID := idx.nodeCounter
-	idx.nodeCounter++
+	nodeID := reservedID
+	if !reserved {
+		nodeID = idx.nodeCounter
+		idx.nodeCounter++
+	} else if nodeID >= idx.nodeCounter || idx.nodes[nodeID] != nil {
+		idx.mu.Unlock()
+		return 0, fmt.Errorf("node ID %d was not reserved or is already in use", nodeID)
+	}
+
+	// Links left to a deleted node must not reach the new one
+	if idx.unlinked[nodeID] {
+		idx.sweepUnlinkedLocked()
+	}
+
+	// A flat index stores the vector unlinked until it outgrows the threshold
+	if idx.flat {
+		newNode := idx.newNode(nodeID, vector, 0)
+		idx.nodes[nodeID] = newNode
+		if idx.entryPoint == nil {
+			idx.entryPoint = newNode
+			idx.maxLayer = 0
+		}
+		idx.size++
+
+		var err error
+		if idx.size > int64(idx.flatThreshold) {
+			if err = idx.buildGraphLocked(); err != nil {
+				err = fmt.Errorf("vector %d stored, but building the graph failed: %w", nodeID, err)
+			}
+		}
+		idx.mu.Unlock()
+		return nodeID, err
+	}
 
 	// Assign random level for the new node
-	level := idx.randomLevel()
+	if level < 0 {
+		level = idx.randomLevel()
+	}
 
 	// Create the new node
-	newNode := NewNode(nodeID, vector, level)
+	newNode := idx.newNode(nodeID, vector, level)
 
 	// Handle first insertion (entry point initialization)
 	if idx.entryPoint == nil {
@@ -49,6 +170,31 @@ func (idx *Index) Insert(vector []float32) (uint64, error) {
 
 	idx.mu.Unlock()
 
+	idx.link(newNode, vector, level, entryPoint, currentMaxLayer, efConstruction)
+
+	// Add node to index
+	idx.mu.Lock()
+	idx.nodes[nodeID] = newNode
+
+	// Update entry point if new node has higher level
+	if level > idx.maxLayer {
+		idx.maxLayer = level
+		idx.entryPoint = newNode
+	}
+
+	idx.size++
+	idx.mu.Unlock()
+
+	return nodeID, nil
+}
+
+// link connects newNode, holding vector, into layers level and below,
+// searching down from entryPoint at currentMaxLayer. The index lock must
+// not be held. Candidates with the node's own ID are skipped, so a node
+// being replaced in place is never linked to its old version.
+func (idx *Index) link(newNode *Node, vector []float32, level int, entryPoint *Node, currentMaxLayer int, efConstruction int) {
+	nodeID := newNode.ID()
+
 	// Phase 1: Search for nearest neighbors from top layer to target layer+1
 	// We do greedy search without expanding candidates on upper layers
 	ep := entryPoint
@@ -82,7 +228,7 @@ func (idx *Index) Insert(vector []float32) (uint64, error) {
 	// and insert bidirectional links
 	for lc := min(level, currentMaxLayer); lc >= 0; lc-- {
 		// Search for efConstruction nearest neighbors at layer lc
-		candidates := idx.searchLayer(vector, ep, idx.efConstruction, lc)
+		candidates := idx.searchLayer(vector, ep, efConstruction, lc)
 
 		// Select M neighbors using heuristic
 		M := idx.M
@@ -90,7 +236,7 @@ func (idx *Index) Insert(vector []float32) (uint64, error) {
 			M = idx.M0
 		}
 
-		neighbors := idx.selectNeighbors(candidates, M, lc)
+		neighbors := idx.selectNeighbors(withoutID(candidates, nodeID), M, lc)
 
 		// Add bidirectional links
 		for _, neighbor := range neighbors {
@@ -112,21 +258,16 @@ func (idx *Index) Insert(vector []float32) (uint64, error) {
 			ep = idx.GetNode(candidates[0].id)
 		}
 	}
+}
 
-	// Add node to index
-	idx.mu.Lock()
-	idx.nodes[nodeID] = newNode
-
-	// Update entry point if new node has higher level
-	if level > idx.maxLayer {
-		idx.maxLayer = level
-		idx.entryPoint = newNode
+// withoutID returns candidates with id removed
+func withoutID(candidates []heapItem, id uint64) []heapItem {
+	for i, c := range candidates {
+		if c.id == id {
+			return append(append([]heapItem(nil), candidates[:i]...), candidates[i+1:]...)
+		}
 	}
-
-	idx.size++
-	idx.mu.Unlock()
-
-	return nodeID, nil
+	return candidates
 }
 
 // searchLayer performs a greedy search for the ef nearest neighbors at a specific layer
@@ -254,57 +395,37 @@ func (idx *Index) pruneNeighbors(node *Node, layer int) {
 	}
 
 	// Heuristic neighbor selection from HNSW paper (Algorithm 4)
-	// Select diverse neighbors that maintain good graph connectivity
-	selectedIDs := make([]uint64, 0, M)
-	remaining := candidates
-
-	// Always keep the closest neighbor
-	minIdx := 0
-	minDist := remaining[0].dist
-	for i := 1; i < len(remaining); i++ {
-		if remaining[i].dist < minDist {
-			minDist = remaining[i].dist
-			minIdx = i
+	// Visit candidates closest first and keep one only if no already kept
+	// neighbor occludes it, i.e. it is not much closer to a kept neighbor
+	// than to the node. Keeping neighbors in different directions preserves
+	// long-range connectivity that truncating to the closest M would lose.
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
 		}
-	}
-	selectedIDs = append(selectedIDs, remaining[minIdx].id)
-	remaining = append(remaining[:minIdx], remaining[minIdx+1:]...)
-
-	// Select remaining M-1 neighbors using diversity heuristic
-	for len(selectedIDs) < M && len(remaining) > 0 {
-		bestIdx := 0
-		bestScore := float32(-1.0)
-
-		// Find candidate that is closest to node but diverse from selected neighbors
-		for i, candidate := range remaining {
-			candidateNode := idx.GetNode(candidate.id)
-			if candidateNode == nil {
-				continue
-			}
+		return candidates[i].id < candidates[j].id
+	})
 
-			// Calculate minimum distance to already selected neighbors
-			minDistToSelected := float32(1e9)
-			for _, selectedID := range selectedIDs {
-				selectedNode := idx.GetNode(selectedID)
-				if selectedNode != nil {
-					distToSelected := idx.distanceBetweenNodes(candidateNode, selectedNode)
-					if distToSelected < minDistToSelected {
-						minDistToSelected = distToSelected
-					}
-				}
-			}
+	selectedIDs := make([]uint64, 0, M)
+	selectedNodes := make([]*Node, 0, M)
+	for _, candidate := range candidates {
+		if len(selectedIDs) >= M {
+			break
+		}
 
-			// Score = distance to selected (higher is better for diversity)
-			// We want neighbors that are diverse from each other
-			score := minDistToSelected
-			if score > bestScore {
-				bestScore = score
-				bestIdx = i
+		candidateNode := idx.GetNode(candidate.id)
+		occluded := false
+		for _, selectedNode := range selectedNodes {
+			if float32(idx.pruneAlpha)*idx.distanceBetweenNodes(candidateNode, selectedNode) <= candidate.dist {
+				occluded = true
+				break
 			}
 		}
 
-		selectedIDs = append(selectedIDs, remaining[bestIdx].id)
-		remaining = append(remaining[:bestIdx], remaining[bestIdx+1:]...)
+		if !occluded {
+			selectedIDs = append(selectedIDs, candidate.id)
+			selectedNodes = append(selectedNodes, candidateNode)
+		}
 	}
 
 	// Update neighbors
@@ -325,11 +446,19 @@ type heapItem struct {
 	distance float32
 }
 
+// worse reports whether a ranks after b (larger distance, ties broken by larger ID)
+func (a heapItem) worse(b heapItem) bool {
+	if a.distance != b.distance {
+		return a.distance > b.distance
+	}
+	return a.id > b.id
+}
+
 // minHeap is a min-heap of heapItem (smallest distance at top)
 type minHeap []heapItem
 
 func (h minHeap) Len() int           { return len(h) }
-func (h minHeap) Less(i, j int) bool { return h[i].distance < h[j].distance }
+func (h minHeap) Less(i, j int) bool { return h[j].worse(h[i]) }
 func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 
 func (h *minHeap) Push(x interface{}) {
@@ -355,7 +484,7 @@ func (h *minHeap) Peek() interface{} {
 type maxHeap []heapItem
 
 func (h maxHeap) Len() int           { return len(h) }
-func (h maxHeap) Less(i, j int) bool { return h[i].distance > h[j].distance }
+func (h maxHeap) Less(i, j int) bool { return h[i].worse(h[j]) }
 func (h maxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 
 func (h *maxHeap) Push(x interface{}) {
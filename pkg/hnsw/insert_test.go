@@ -2,6 +2,7 @@ package hnsw
 
 import (
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 )
@@ -112,6 +113,156 @@ func TestInsertEmpty(t *testing.T) {
 	}
 }
 
+// TestInsertWithReservedID tests inserting concurrently under reserved IDs
+func TestInsertWithReservedID(t *testing.T) {
+	idx := New(DefaultConfig())
+
+	const n = 200
+	rng := rand.New(rand.NewSource(42))
+	vectors := make([][]float32, n)
+	ids := make([]uint64, n)
+	for i := range vectors {
+		vectors[i] = []float32{rng.Float32(), rng.Float32(), rng.Float32()}
+		ids[i] = idx.ReserveID()
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := n - 1; i >= 0; i-- {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = idx.InsertWithID(ids[i], vectors[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, id := range ids {
+		if errs[i] != nil {
+			t.Fatalf("InsertWithID %d failed: %v", id, errs[i])
+		}
+		if id != uint64(i) {
+			t.Errorf("Expected reserved ID %d, got %d", i, id)
+		}
+		node := idx.GetNode(id)
+		if node == nil || node.Vector()[0] != vectors[i][0] {
+			t.Errorf("Node %d does not hold vector %d", id, i)
+		}
+	}
+	if idx.Size() != n {
+		t.Errorf("Expected size %d, got %d", n, idx.Size())
+	}
+
+	if err := idx.InsertWithID(ids[0], vectors[0]); err == nil {
+		t.Error("Expected error reusing an inserted ID")
+	}
+	if err := idx.InsertWithID(n+10, vectors[0]); err == nil {
+		t.Error("Expected error for an unreserved ID")
+	}
+
+	// Plain inserts continue after the reserved range
+	if id, err := idx.Insert(vectors[0]); err != nil || id != n {
+		t.Errorf("Expected next ID %d, got %d (err: %v)", n, id, err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	idx := New(DefaultConfig())
+
+	// Restore out of order and with gaps, as a replayed log would
+	for _, id := range []uint64{5, 2, 9} {
+		if err := idx.Restore(id, []float32{float32(id), 1, 0}); err != nil {
+			t.Fatalf("Restore %d failed: %v", id, err)
+		}
+	}
+
+	// Restoring an existing ID replaces its vector in place
+	if err := idx.Restore(2, []float32{7, 7, 7}); err != nil {
+		t.Fatalf("Restore over existing ID failed: %v", err)
+	}
+	if idx.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", idx.Size())
+	}
+	if node := idx.GetNode(2); node == nil || node.Vector()[0] != 7 {
+		t.Errorf("Expected ID 2 to hold the replacement vector")
+	}
+
+	// New inserts continue after the highest restored ID
+	if id, err := idx.Insert([]float32{1, 2, 3}); err != nil || id != 10 {
+		t.Errorf("Expected next ID 10, got %d (err: %v)", id, err)
+	}
+
+	// Update keeps the ID
+	if err := idx.Update(5, []float32{0, 0, 1}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if node := idx.GetNode(5); node == nil || node.Vector()[2] != 1 {
+		t.Errorf("Expected updated vector under ID 5")
+	}
+	if idx.Size() != 4 {
+		t.Errorf("Expected size 4 after update, got %d", idx.Size())
+	}
+}
+
+// TestInsertWithEf checks a per-insert construction ef builds a usable graph
+// on an index created with a very low default
+func TestInsertWithEf(t *testing.T) {
+	config := DefaultConfig()
+	config.efConstruction = 4
+	idx := New(config)
+
+	const n = 1000
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < n; i++ {
+		vec := make([]float32, 16)
+		for j := range vec {
+			vec[j] = rng.Float32()
+		}
+		if _, err := idx.InsertWithEf(vec, 200); err != nil {
+			t.Fatalf("InsertWithEf failed: %v", err)
+		}
+	}
+
+	// Zero falls back to the index default
+	if _, err := idx.InsertWithEf(randomVector(16), 0); err != nil {
+		t.Fatalf("InsertWithEf with default ef failed: %v", err)
+	}
+	if _, err := idx.InsertWithEf(randomVector(16), -1); err == nil {
+		t.Error("Expected error for negative efConstruction")
+	}
+	if idx.Size() != n+1 {
+		t.Errorf("Expected size %d, got %d", n+1, idx.Size())
+	}
+
+	const k = 10
+	var hits, total int
+	for q := 0; q < 50; q++ {
+		query := randomVector(16)
+		exact, err := idx.ExactSearch(query, k)
+		if err != nil {
+			t.Fatalf("ExactSearch failed: %v", err)
+		}
+		approx, err := idx.Search(query, k, 100)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		want := make(map[uint64]bool, k)
+		for _, r := range exact.Results {
+			want[r.ID] = true
+		}
+		for _, r := range approx.Results {
+			if want[r.ID] {
+				hits++
+			}
+		}
+		total += len(exact.Results)
+	}
+
+	if recall := float64(hits) / float64(total); recall < 0.9 {
+		t.Errorf("Expected recall >= 0.9 with efConstruction override, got %.3f", recall)
+	}
+}
+
 // TestInsert100 tests inserting 100 random vectors
 func TestInsert100(t *testing.T) {
 	config := DefaultConfig()
@@ -363,3 +514,71 @@ func BenchmarkInsert100(b *testing.B) {
 		}
 	}
 }
+
+// TestPruneNeighborsDiversity tests that overflow pruning keeps diverse neighbors
+func TestPruneNeighborsDiversity(t *testing.T) {
+	idx := New(IndexConfig{
+		M:              4,
+		efConstruction: 50,
+		DistanceFunc:   EuclideanDistance,
+	})
+
+	center, err := idx.Insert([]float32{0, 0})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	// Tight clusters in four directions; the closest M0 neighbors would all
+	// come from the nearest clusters
+	rng := rand.New(rand.NewSource(42))
+	directions := [][2]float32{{1, 0}, {0, 1.1}, {-1.2, 0}, {0, -1.3}}
+	var ids []uint64
+	for _, dir := range directions {
+		for i := 0; i < 10; i++ {
+			id, err := idx.Insert([]float32{
+				dir[0] + float32(rng.NormFloat64())*0.01,
+				dir[1] + float32(rng.NormFloat64())*0.01,
+			})
+			if err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	// Force an overflow well past M0
+	node := idx.GetNode(center)
+	node.SetNeighbors(0, ids)
+	idx.pruneNeighbors(node, 0)
+
+	kept := node.GetNeighbors(0)
+	if len(kept) > idx.M0 {
+		t.Fatalf("Pruned degree %d exceeds M0=%d", len(kept), idx.M0)
+	}
+	if len(kept) != len(directions) {
+		t.Errorf("Expected one neighbor per cluster (%d), kept %d", len(directions), len(kept))
+	}
+
+	clusters := make(map[int]bool)
+	for _, id := range kept {
+		clusters[int(id-ids[0])/10] = true
+	}
+	if len(clusters) != len(directions) {
+		t.Errorf("Kept neighbors cover %d of %d clusters", len(clusters), len(directions))
+	}
+
+	// No kept neighbor is occluded by a closer kept neighbor
+	for i, a := range kept {
+		for j, b := range kept {
+			if i == j {
+				continue
+			}
+			nodeA, nodeB := idx.GetNode(a), idx.GetNode(b)
+			distA := idx.distanceBetweenNodes(node, nodeA)
+			distB := idx.distanceBetweenNodes(node, nodeB)
+			if distA <= distB && idx.distanceBetweenNodes(nodeA, nodeB) <= distB {
+				t.Errorf("Neighbor %d is closer to kept neighbor %d than to the node", b, a)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package hnsw
+
+import "unsafe"
+
+// Approximate per-entry overheads used by MemoryUsage
+const (
+	mapEntryOverhead   = 48 // Key, value and bucket share of a map entry
+	sliceHeaderBytes   = 24 // Pointer, length and capacity
+	neighborIDBytes    = 8  // uint64 neighbor ID
+	vectorElementBytes = 4  // float32 component
+	halfElementBytes   = 2  // float16 component
+)
+
+// MemoryUsage estimates the bytes held by the index: node structs, vector
+// storage and per-layer neighbor lists, plus the node map. It is an
+// estimate that scales linearly with the number of vectors and links.
+func (idx *Index) MemoryUsage() int64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	total := int64(unsafe.Sizeof(*idx))
+	for _, node := range idx.nodes {
+		total += mapEntryOverhead + node.memoryUsage()
+	}
+	return total
+}
+
+// memoryUsage estimates the bytes held by a node
+func (n *Node) memoryUsage() int64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	total := int64(unsafe.Sizeof(*n))
+	total += int64(cap(n.vector)) * vectorElementBytes
+	total += int64(cap(n.half)) * halfElementBytes
+	for _, neighbors := range n.neighbors {
+		total += sliceHeaderBytes + int64(cap(neighbors))*neighborIDBytes
+	}
+	return total
+}
@@ -0,0 +1,72 @@
+package hnsw
+
+import (
+	"fmt"
+	"math"
+)
+
+// MIPSTransform reduces maximum inner product search to Euclidean nearest
+// neighbor search. DotProduct can be used as an index's DistanceFunc
+// directly, but -(a·b) is not a metric (a vector need not be its own
+// nearest neighbor and the triangle inequality fails), so the graph's
+// neighbor pruning has no guarantees and recall can drop on some data. The
+// transformation keeps HNSW on a true metric: each stored
+// vector x gets an extra component sqrt(MaxNorm² - ||x||²) and each query
+// gets 0, which makes every stored vector's norm MaxNorm and
+//
+//	||q' - x'||² = ||q||² + MaxNorm² - 2 q·x
+//
+// so ranking by Euclidean distance ranks by descending inner product. Use
+// it with EuclideanDistance; MaxNorm must bound every vector ever inserted.
+type MIPSTransform struct {
+	MaxNorm float32 // Largest norm of any stored vector
+}
+
+// NewMIPSTransform returns a transform bounding the norms of vectors
+func NewMIPSTransform(vectors [][]float32) MIPSTransform {
+	var maxNorm float32
+	for _, v := range vectors {
+		if norm := vectorNorm(v); norm > maxNorm {
+			maxNorm = norm
+		}
+	}
+	return MIPSTransform{MaxNorm: maxNorm}
+}
+
+// Vector returns the augmented form of a vector to insert
+func (t MIPSTransform) Vector(v []float32) ([]float32, error) {
+	norm := vectorNorm(v)
+	if norm > t.MaxNorm {
+		return nil, fmt.Errorf("vector norm %f exceeds MIPS max norm %f", norm, t.MaxNorm)
+	}
+
+	out := make([]float32, len(v)+1)
+	copy(out, v)
+	out[len(v)] = float32(math.Sqrt(float64(t.MaxNorm)*float64(t.MaxNorm) - float64(norm)*float64(norm)))
+	return out, nil
+}
+
+// Query returns the augmented form of a query vector
+func (t MIPSTransform) Query(q []float32) []float32 {
+	out := make([]float32, len(q)+1)
+	copy(out, q)
+	return out
+}
+
+// InnerProduct recovers q·x from the Euclidean distance between the
+// augmented query and an augmented stored vector
+func (t MIPSTransform) InnerProduct(q []float32, distance float32) float32 {
+	qNorm := float64(vectorNorm(q))
+	maxNorm := float64(t.MaxNorm)
+	d := float64(distance)
+	return float32((qNorm*qNorm + maxNorm*maxNorm - d*d) / 2)
+}
+
+// vectorNorm returns the L2 norm of v
+func vectorNorm(v []float32) float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return float32(math.Sqrt(sum))
+}
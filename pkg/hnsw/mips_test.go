@@ -0,0 +1,114 @@
+package hnsw
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// mipsDataset returns vectors whose norms vary by 4x, where raw inner
+// product ranking differs most from cosine
+func mipsDataset(rng *rand.Rand, count, dim int) [][]float32 {
+	vectors := make([][]float32, count)
+	for i := range vectors {
+		scale := 0.5 + 1.5*rng.Float32()
+		vec := make([]float32, dim)
+		for j := range vec {
+			vec[j] = float32(rng.NormFloat64()) * scale
+		}
+		vectors[i] = vec
+	}
+	return vectors
+}
+
+func TestMIPSTransform(t *testing.T) {
+	vectors := [][]float32{{3, 4}, {1, 0}, {0, -2}}
+	transform := NewMIPSTransform(vectors)
+	if transform.MaxNorm != 5 {
+		t.Fatalf("Expected max norm 5, got %f", transform.MaxNorm)
+	}
+
+	query := []float32{1, 2}
+	q := transform.Query(query)
+	for _, v := range vectors {
+		x, err := transform.Vector(v)
+		if err != nil {
+			t.Fatalf("Vector failed: %v", err)
+		}
+		if norm := vectorNorm(x); math.Abs(float64(norm-5)) > 1e-5 {
+			t.Errorf("Expected augmented norm 5, got %f", norm)
+		}
+
+		// The inner product is recovered from the Euclidean distance
+		want := -DotProduct(query, v)
+		if got := transform.InnerProduct(query, EuclideanDistance(q, x)); math.Abs(float64(got-want)) > 1e-4 {
+			t.Errorf("Expected inner product %f, got %f", want, got)
+		}
+	}
+
+	if _, err := transform.Vector([]float32{6, 0}); err == nil {
+		t.Error("Expected error for a vector above the max norm")
+	}
+}
+
+func TestRecallMIPS(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping in short mode")
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	const dim, count, queries, k = 32, 2000, 50, 10
+	vectors := mipsDataset(rng, count, dim)
+
+	// Raw inner product as the distance
+	direct := New(IndexConfig{M: 16, efConstruction: 200, DistanceFunc: DotProduct})
+	for _, v := range vectors {
+		direct.Insert(v)
+	}
+
+	// Euclidean search over augmented vectors
+	transform := NewMIPSTransform(vectors)
+	augmented := New(IndexConfig{M: 16, efConstruction: 200, DistanceFunc: EuclideanDistance})
+	for _, v := range vectors {
+		x, err := transform.Vector(v)
+		if err != nil {
+			t.Fatalf("Vector failed: %v", err)
+		}
+		augmented.Insert(x)
+	}
+
+	var directRecall, augmentedRecall float64
+	for q := 0; q < queries; q++ {
+		query := mipsDataset(rng, 1, dim)[0]
+		bruteForce := bruteForceKNN(query, vectors, k, DotProduct)
+
+		result, err := direct.Search(query, k, 100)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		directRecall += calculateRecall(result.Results, bruteForce, k)
+
+		// Results come back by descending inner product
+		for i := 1; i < len(result.Results); i++ {
+			if result.Results[i].Distance < result.Results[i-1].Distance {
+				t.Fatalf("Results not sorted by descending inner product: %v", result.Results)
+			}
+		}
+
+		result, err = augmented.Search(transform.Query(query), k, 100)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		augmentedRecall += calculateRecall(result.Results, bruteForce, k)
+	}
+	directRecall /= queries
+	augmentedRecall /= queries
+
+	t.Logf("MIPS Recall@%d: dot product %.2f%%, augmented Euclidean %.2f%%", k, directRecall*100, augmentedRecall*100)
+	if augmentedRecall < 0.9 {
+		t.Errorf("Expected augmented MIPS recall >= 90%%, got %.2f%%", augmentedRecall*100)
+	}
+	if directRecall < 0.9 {
+		t.Errorf("Expected dot product recall >= 90%%, got %.2f%%", directRecall*100)
+	}
+}
@@ -0,0 +1,138 @@
+package hnsw
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MultiVectorAggregation chooses how per-query similarities combine into a
+// document score
+type MultiVectorAggregation int
+
+const (
+	// AggregateSum adds the best similarity of every query vector (ColBERT)
+	AggregateSum MultiVectorAggregation = iota
+	// AggregateMean averages it, so scores are comparable across query lengths
+	AggregateMean
+)
+
+// MultiVectorOptions configures MultiVectorSearchWithOptions
+type MultiVectorOptions struct {
+	Aggregation MultiVectorAggregation // How per-query similarities combine (default: AggregateSum)
+
+	// DocumentOf maps a vector to the document it belongs to, so documents
+	// stored as several token vectors are scored as one. Vectors it reports
+	// no document for, and every vector when it is nil, are their own document.
+	DocumentOf func(id uint64) (string, bool)
+}
+
+// MultiVectorSearch scores documents against a multi-vector query by summed
+// max-sim, late-interaction style. See MultiVectorSearchWithOptions.
+func (idx *Index) MultiVectorSearch(queries [][]float32, k int, efSearch int) (*SearchResult, error) {
+	return idx.MultiVectorSearchWithOptions(queries, k, efSearch, MultiVectorOptions{})
+}
+
+// MultiVectorSearchWithOptions returns the top-k documents for a query made
+// of several vectors. With similarity s(q, d) = -distance(q, d), a document
+// D scores
+//
+//	score(D) = Σ_{q ∈ Q} max_{d ∈ D} s(q, d)
+//
+// or that sum divided by |Q| with AggregateMean. Each query vector runs its
+// own efSearch-wide graph search; the union of the vectors found forms the
+// candidate documents, which are then scored exactly against every query
+// vector. A document's vectors that no query found do not contribute.
+//
+// Results carry the negated score as their distance, so lower is better as
+// with Search, and the ID of the document's vector closest to any query.
+func (idx *Index) MultiVectorSearchWithOptions(queries [][]float32, k int, efSearch int, opts MultiVectorOptions) (*SearchResult, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("at least one query vector is required")
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be > 0")
+	}
+	if efSearch < k {
+		efSearch = k
+	}
+
+	// Candidate generation: the union of every query's nearest vectors
+	type docKey struct {
+		name string
+		id   uint64
+	}
+	docs := make(map[docKey][]*Node)
+	seen := make(map[uint64]bool)
+	visited := 0
+
+	for i, query := range queries {
+		searchResult, err := idx.Search(query, efSearch, efSearch)
+		if err != nil {
+			return nil, fmt.Errorf("query vector %d: %w", i, err)
+		}
+		visited += searchResult.Visited
+
+		for _, r := range searchResult.Results {
+			if seen[r.ID] {
+				continue
+			}
+			seen[r.ID] = true
+
+			node := idx.GetNode(r.ID)
+			if node == nil {
+				continue // Deleted since the search
+			}
+
+			key := docKey{id: r.ID}
+			if opts.DocumentOf != nil {
+				if name, ok := opts.DocumentOf(r.ID); ok {
+					key = docKey{name: name}
+				}
+			}
+			docs[key] = append(docs[key], node)
+		}
+	}
+
+	// Exact late-interaction scoring of each candidate document
+	results := make([]Result, 0, len(docs))
+	for _, members := range docs {
+		var total float32
+		best := members[0]
+		bestDist := idx.distanceToNode(queries[0], best)
+
+		for _, query := range queries {
+			minDist := idx.distanceToNode(query, members[0])
+			for _, node := range members {
+				dist := idx.distanceToNode(query, node)
+				if dist < minDist {
+					minDist = dist
+				}
+				if dist < bestDist || (dist == bestDist && node.id < best.id) {
+					best, bestDist = node, dist
+				}
+			}
+			total += minDist
+		}
+
+		if opts.Aggregation == AggregateMean {
+			total /= float32(len(queries))
+		}
+		results = append(results, Result{ID: best.id, Distance: total})
+	}
+
+	// Break score ties by ID so results are deterministic
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].ID < results[j].ID
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+
+	return &SearchResult{
+		Results: results,
+		Visited: visited,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package hnsw
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMultiVectorSearchSingleQuery(t *testing.T) {
+	idx := New(DefaultConfig())
+	for i := 0; i < 200; i++ {
+		idx.Insert(randomVector(16))
+	}
+
+	// One query vector scores each vector by its own distance
+	query := randomVector(16)
+	multi, err := idx.MultiVectorSearch([][]float32{query}, 10, 100)
+	if err != nil {
+		t.Fatalf("MultiVectorSearch failed: %v", err)
+	}
+	single, err := idx.Search(query, 10, 100)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(multi.Results) != len(single.Results) {
+		t.Fatalf("Expected %d results, got %d", len(single.Results), len(multi.Results))
+	}
+	for i := range single.Results {
+		if multi.Results[i].ID != single.Results[i].ID {
+			t.Errorf("Result %d: expected ID %d, got %d", i, single.Results[i].ID, multi.Results[i].ID)
+		}
+	}
+}
+
+func TestMultiVectorSearchAggregation(t *testing.T) {
+	idx := New(IndexConfig{DistanceFunc: EuclideanDistance})
+	for i := 0; i < 100; i++ {
+		idx.Insert(randomVector(8))
+	}
+
+	queries := [][]float32{randomVector(8), randomVector(8), randomVector(8)}
+	sum, err := idx.MultiVectorSearchWithOptions(queries, 5, 100, MultiVectorOptions{Aggregation: AggregateSum})
+	if err != nil {
+		t.Fatalf("Sum search failed: %v", err)
+	}
+	mean, err := idx.MultiVectorSearchWithOptions(queries, 5, 100, MultiVectorOptions{Aggregation: AggregateMean})
+	if err != nil {
+		t.Fatalf("Mean search failed: %v", err)
+	}
+
+	for i := range sum.Results {
+		if sum.Results[i].ID != mean.Results[i].ID {
+			t.Errorf("Result %d: sum and mean rank differently (%d vs %d)", i, sum.Results[i].ID, mean.Results[i].ID)
+		}
+
+		// The sum is the total distance to every query
+		node := idx.GetNode(sum.Results[i].ID)
+		var want float32
+		for _, q := range queries {
+			want += EuclideanDistance(q, node.Vector())
+		}
+		if math.Abs(float64(sum.Results[i].Distance-want)) > 1e-4 {
+			t.Errorf("Result %d: expected summed distance %f, got %f", i, want, sum.Results[i].Distance)
+		}
+		if math.Abs(float64(mean.Results[i].Distance-want/3)) > 1e-4 {
+			t.Errorf("Result %d: expected mean distance %f, got %f", i, want/3, mean.Results[i].Distance)
+		}
+	}
+}
+
+func TestMultiVectorSearchDocuments(t *testing.T) {
+	idx := New(IndexConfig{DistanceFunc: EuclideanDistance})
+
+	// Token vectors of three documents
+	docs := map[uint64]string{}
+	add := func(doc string, vec []float32) {
+		id, err := idx.Insert(vec)
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		docs[id] = doc
+	}
+	add("both", []float32{1, 0})
+	add("both", []float32{0, 1})
+	add("first", []float32{1, 0.05})
+	add("first", []float32{1, 0.1})
+	add("middle", []float32{0.5, 0.5})
+
+	queries := [][]float32{{1, 0}, {0, 1}}
+	opts := MultiVectorOptions{
+		DocumentOf: func(id uint64) (string, bool) {
+			doc, ok := docs[id]
+			return doc, ok
+		},
+	}
+
+	result, err := idx.MultiVectorSearchWithOptions(queries, 3, 10, opts)
+	if err != nil {
+		t.Fatalf("MultiVectorSearch failed: %v", err)
+	}
+	if len(result.Results) != 3 {
+		t.Fatalf("Expected one result per document, got %d", len(result.Results))
+	}
+
+	// Each query matches one of "both"'s tokens exactly
+	top := result.Results[0]
+	if docs[top.ID] != "both" || top.Distance > 1e-6 {
+		t.Errorf("Expected document \"both\" with distance 0 first, got %s (%f)", docs[top.ID], top.Distance)
+	}
+	// Summed distances: "first" 0.05 + 1.345, "middle" 0.707 + 0.707
+	if docs[result.Results[1].ID] != "first" || docs[result.Results[2].ID] != "middle" {
+		t.Errorf("Expected first then middle, got %s then %s",
+			docs[result.Results[1].ID], docs[result.Results[2].ID])
+	}
+
+	// Without documents, "both"'s tokens rank separately
+	result, err = idx.MultiVectorSearch(queries, 5, 10)
+	if err != nil {
+		t.Fatalf("MultiVectorSearch failed: %v", err)
+	}
+	if len(result.Results) != 5 {
+		t.Errorf("Expected one result per vector, got %d", len(result.Results))
+	}
+}
+
+func TestMultiVectorSearchErrors(t *testing.T) {
+	idx := New(DefaultConfig())
+	idx.Insert(randomVector(4))
+
+	if _, err := idx.MultiVectorSearch(nil, 5, 50); err == nil {
+		t.Error("Expected error for no query vectors")
+	}
+	if _, err := idx.MultiVectorSearch([][]float32{randomVector(4)}, 0, 50); err == nil {
+		t.Error("Expected error for k = 0")
+	}
+	if _, err := idx.MultiVectorSearch([][]float32{randomVector(4), randomVector(3)}, 5, 50); err == nil {
+		t.Error("Expected error for mismatched query dimension")
+	}
+}
@@ -7,7 +7,8 @@ import (
 // Node represents a vector in the HNSW graph with multi-layer connections
 type Node struct {
 	id     uint64      // Unique identifier for the node
-	vector []float32   // The vector embedding
+	vector []float32   // The vector embedding (nil with float16 storage)
+	half   []uint16    // The vector as IEEE half floats (float16 storage only)
 	level  int         // Maximum layer this node appears in
 
 	// neighbors[layer] contains the neighbor IDs at each layer
@@ -39,11 +40,31 @@ func (n *Node) ID() uint64 {
 	return n.id
 }
 
-// Vector returns the node's vector embedding
+// Vector returns the node's vector embedding. With float16 storage the
+// vector is decoded into a new slice on every call.
 func (n *Node) Vector() []float32 {
+	if n.half != nil {
+		vector := make([]float32, len(n.half))
+		decodeFloat16(vector, n.half)
+		return vector
+	}
 	return n.vector
 }
 
+// dimension returns the length of the node's vector
+func (n *Node) dimension() int {
+	if n.half != nil {
+		return len(n.half)
+	}
+	return len(n.vector)
+}
+
+// storeFloat16 replaces the node's float32 vector with its half-precision encoding
+func (n *Node) storeFloat16() {
+	n.half = encodeFloat16(n.vector)
+	n.vector = nil
+}
+
 // Level returns the maximum layer this node appears in
 func (n *Node) Level() int {
 	return n.level
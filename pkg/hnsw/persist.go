@@ -0,0 +1,293 @@
+package hnsw
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// File format identification
+var persistMagic = [4]byte{'H', 'N', 'S', 'W'}
+
+// persistVersion is bumped whenever the on-disk layout changes.
+// Load rejects any other version.
+const persistVersion byte = 1
+
+// Distance metric codes stored in the header. Custom distance functions
+// are stored as metricCustom and the loading index keeps its own.
+const (
+	metricCustom byte = iota
+	metricCosine
+	metricEuclidean
+	metricDotProduct
+	metricSquaredEuclidean
+)
+
+// persistHeader holds the index-level fields of a saved index
+type persistHeader struct {
+	M              uint32
+	M0             uint32
+	EfConstruction uint32
+	Ml             float64
+	PruneAlpha     float64
+	Metric         byte
+	Dimension      uint32
+	NodeCounter    uint64
+	MaxLayer       int32
+	HasEntryPoint  byte
+	EntryPoint     uint64
+	NumNodes       uint64
+}
+
+// Save writes the index to w in a versioned binary format: a magic header
+// and version byte, the configuration, then every node's vector and
+// per-layer neighbor lists. Nodes are written in ID order. Vectors are
+// always written as float32, so the file does not depend on the storage type.
+func (idx *Index) Save(w io.Writer) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.Write(persistMagic[:]); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	if err := bw.WriteByte(persistVersion); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	header := persistHeader{
+		M:              uint32(idx.M),
+		M0:             uint32(idx.M0),
+		EfConstruction: uint32(idx.efConstruction),
+		Ml:             idx.ml,
+		PruneAlpha:     idx.pruneAlpha,
+		Metric:         metricCode(idx.distanceFunc),
+		Dimension:      uint32(idx.dimension),
+		NodeCounter:    idx.nodeCounter,
+		MaxLayer:       int32(idx.maxLayer),
+		NumNodes:       uint64(len(idx.nodes)),
+	}
+	if idx.entryPoint != nil {
+		header.HasEntryPoint = 1
+		header.EntryPoint = idx.entryPoint.id
+	}
+	if err := binary.Write(bw, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	ids := make([]uint64, 0, len(idx.nodes))
+	for id := range idx.nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		if err := writeNode(bw, idx.nodes[id], idx.unlinked); err != nil {
+			return fmt.Errorf("failed to write node %d: %w", id, err)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeNode writes a node as [id][level][vector][per layer: count, neighbor IDs],
+// leaving out links to the deleted IDs in unlinked
+func writeNode(w io.Writer, node *Node, unlinked map[uint64]bool) error {
+	node.mu.RLock()
+	defer node.mu.RUnlock()
+
+	if err := binary.Write(w, binary.LittleEndian, node.id); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint32(node.level)); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, node.Vector()); err != nil {
+		return err
+	}
+
+	for _, neighbors := range node.neighbors {
+		if len(unlinked) > 0 {
+			live := make([]uint64, 0, len(neighbors))
+			for _, neighborID := range neighbors {
+				if !unlinked[neighborID] {
+					live = append(live, neighborID)
+				}
+			}
+			neighbors = live
+		}
+		if err := binary.Write(w, binary.LittleEndian, uint32(len(neighbors))); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, neighbors); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Load replaces the contents of the index with one written by Save. The
+// graph is restored as saved, without re-inserting any vector. A saved
+// built-in distance metric replaces the index's own; for a custom metric,
+// create the index with the same DistanceFunc before loading. Vectors are
+// kept in the loading index's own storage type.
+//
+// A flat index is saved without links. It loads flat while it fits the
+// loading index's FlatThreshold; otherwise its graph is built on load.
+func (idx *Index) Load(r io.Reader) error {
+	br := bufio.NewReader(r)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+	if magic != persistMagic {
+		return fmt.Errorf("not an HNSW index file (bad magic %q)", magic[:])
+	}
+
+	version, err := br.ReadByte()
+	if err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+	if version != persistVersion {
+		return fmt.Errorf("unsupported HNSW index format version %d (expected %d)", version, persistVersion)
+	}
+
+	var header persistHeader
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+
+	// Cap the preallocation so a corrupt count cannot exhaust memory
+	capacity := header.NumNodes
+	if capacity > 1<<20 {
+		capacity = 1 << 20
+	}
+	nodes := make(map[uint64]*Node, capacity)
+	for i := uint64(0); i < header.NumNodes; i++ {
+		node, err := readNode(br, int(header.Dimension))
+		if err != nil {
+			return fmt.Errorf("failed to read node %d of %d: %w", i, header.NumNodes, err)
+		}
+		if idx.storage == StorageFloat16 {
+			node.storeFloat16()
+		}
+		nodes[node.id] = node
+	}
+
+	linked := false
+	for _, node := range nodes {
+		for _, neighbors := range node.neighbors {
+			if len(neighbors) > 0 {
+				linked = true
+				break
+			}
+		}
+	}
+
+	var entryPoint *Node
+	if header.HasEntryPoint == 1 {
+		entryPoint = nodes[header.EntryPoint]
+		if entryPoint == nil {
+			return fmt.Errorf("entry point %d not found among saved nodes", header.EntryPoint)
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.M = int(header.M)
+	idx.M0 = int(header.M0)
+	idx.efConstruction = int(header.EfConstruction)
+	idx.ml = header.Ml
+	idx.pruneAlpha = header.PruneAlpha
+	if fn := metricFunc(header.Metric); fn != nil {
+		idx.distanceFunc = fn
+	}
+	idx.dimension = int(header.Dimension)
+	idx.nodeCounter = header.NodeCounter
+	idx.maxLayer = int(header.MaxLayer)
+	idx.entryPoint = entryPoint
+	idx.nodes = nodes
+	idx.unlinked = nil
+	idx.size = int64(len(nodes))
+	idx.deleted = 0
+	idx.flat = idx.flatThreshold > 0 && !linked
+
+	if !linked && len(nodes) > 1 && idx.size > int64(idx.flatThreshold) {
+		if err := idx.buildGraphLocked(); err != nil {
+			return fmt.Errorf("failed to build graph: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readNode reads a node written by writeNode
+func readNode(r io.Reader, dimension int) (*Node, error) {
+	var id uint64
+	var level uint32
+	if err := binary.Read(r, binary.LittleEndian, &id); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &level); err != nil {
+		return nil, err
+	}
+
+	vector := make([]float32, dimension)
+	if err := binary.Read(r, binary.LittleEndian, vector); err != nil {
+		return nil, err
+	}
+
+	node := NewNode(id, vector, int(level))
+	for layer := range node.neighbors {
+		var count uint32
+		if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+			return nil, err
+		}
+		neighbors := make([]uint64, count)
+		if err := binary.Read(r, binary.LittleEndian, neighbors); err != nil {
+			return nil, err
+		}
+		node.neighbors[layer] = neighbors
+	}
+
+	return node, nil
+}
+
+// metricCode identifies a built-in distance function for the file header
+func metricCode(fn DistanceFunc) byte {
+	ptr := reflect.ValueOf(fn).Pointer()
+	switch ptr {
+	case reflect.ValueOf(CosineSimilarity).Pointer():
+		return metricCosine
+	case reflect.ValueOf(EuclideanDistance).Pointer():
+		return metricEuclidean
+	case reflect.ValueOf(DotProduct).Pointer():
+		return metricDotProduct
+	case reflect.ValueOf(SquaredEuclideanDistance).Pointer():
+		return metricSquaredEuclidean
+	}
+	return metricCustom
+}
+
+// metricFunc returns the built-in distance function for a header code,
+// or nil for a custom metric
+func metricFunc(code byte) DistanceFunc {
+	switch code {
+	case metricCosine:
+		return CosineSimilarity
+	case metricEuclidean:
+		return EuclideanDistance
+	case metricDotProduct:
+		return DotProduct
+	case metricSquaredEuclidean:
+		return SquaredEuclideanDistance
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package hnsw
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestSaveLoadRoundTrip tests that a loaded index answers queries exactly like the original
+func TestSaveLoadRoundTrip(t *testing.T) {
+	const numVectors = 10000
+	const dim = 32
+
+	rng := rand.New(rand.NewSource(42))
+	vectors := make([][]float32, numVectors)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+	}
+
+	config := DefaultConfig()
+	config.DistanceFunc = EuclideanDistance
+	original := New(config)
+	if result := original.BatchInsert(vectors, nil); result.FailureCount > 0 {
+		t.Fatalf("BatchInsert failed for %d vectors", result.FailureCount)
+	}
+
+	// Deletions leave gaps in the ID space that must survive the round trip
+	for id := uint64(0); id < 50; id++ {
+		if err := original.Delete(id * 7); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := original.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Load into an index with a different metric; the saved one must win
+	loaded := New(DefaultConfig())
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if loaded.Size() != original.Size() || loaded.Dimension() != original.Dimension() ||
+		loaded.MaxLayer() != original.MaxLayer() {
+		t.Fatalf("Loaded stats %+v differ from original %+v", loaded.GetStats(), original.GetStats())
+	}
+	if loaded.EntryPoint().ID() != original.EntryPoint().ID() {
+		t.Errorf("Entry point %d, want %d", loaded.EntryPoint().ID(), original.EntryPoint().ID())
+	}
+
+	for q := 0; q < 100; q++ {
+		query := make([]float32, dim)
+		for j := range query {
+			query[j] = rng.Float32()
+		}
+
+		want, err := original.Search(query, 10, 50)
+		if err != nil {
+			t.Fatalf("Search on original failed: %v", err)
+		}
+		got, err := loaded.Search(query, 10, 50)
+		if err != nil {
+			t.Fatalf("Search on loaded index failed: %v", err)
+		}
+
+		if len(got.Results) != len(want.Results) {
+			t.Fatalf("Query %d: %d results, want %d", q, len(got.Results), len(want.Results))
+		}
+		for i := range want.Results {
+			if got.Results[i] != want.Results[i] {
+				t.Errorf("Query %d rank %d: got %+v, want %+v", q, i, got.Results[i], want.Results[i])
+			}
+		}
+	}
+
+	// New inserts continue after the saved IDs
+	id, err := loaded.Insert(vectors[0])
+	if err != nil {
+		t.Fatalf("Insert after load failed: %v", err)
+	}
+	if id != numVectors {
+		t.Errorf("Expected next ID %d, got %d", numVectors, id)
+	}
+}
+
+// TestLoadRejectsBadHeader tests that foreign files and unknown versions are rejected
+func TestLoadRejectsBadHeader(t *testing.T) {
+	idx := New(DefaultConfig())
+	if _, err := idx.Insert([]float32{1, 2, 3}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := idx.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	err := New(DefaultConfig()).Load(bytes.NewReader([]byte("not an index")))
+	if err == nil || !strings.Contains(err.Error(), "bad magic") {
+		t.Errorf("Expected bad magic error, got %v", err)
+	}
+
+	future := append([]byte(nil), data...)
+	future[4] = persistVersion + 1
+	err = New(DefaultConfig()).Load(bytes.NewReader(future))
+	if err == nil || !strings.Contains(err.Error(), "unsupported HNSW index format version") {
+		t.Errorf("Expected version error, got %v", err)
+	}
+
+	err = New(DefaultConfig()).Load(bytes.NewReader(data[:len(data)-4]))
+	if err == nil {
+		t.Error("Expected error for truncated file")
+	}
+
+	// A failed load leaves the index untouched
+	target := New(DefaultConfig())
+	if err := target.Load(bytes.NewReader(data[:len(data)-4])); err == nil || target.Size() != 0 {
+		t.Errorf("Expected failed load to leave the index empty, size %d", target.Size())
+	}
+}
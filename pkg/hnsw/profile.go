@@ -0,0 +1,30 @@
+package hnsw
+
+import "time"
+
+// SearchProfile collects low-level counters and timings for a search.
+// Pass a non-nil profile to SearchWithProfile or ExactSearchWithProfile to
+// populate it; counters accumulate, so one profile can span several searches.
+type SearchProfile struct {
+	DistanceComputations int   // Distance function calls
+	DistanceNanos        int64 // Time spent computing distances
+	NodesVisited         int   // Nodes touched across all layers
+	HeapOperations       int   // Candidate and result heap pushes and pops
+	GreedyNanos          int64 // Time in the greedy descent through upper layers
+	BaseLayerNanos       int64 // Time in the layer 0 beam search
+	ScanNanos            int64 // Time in exhaustive scans (ExactSearchWithProfile)
+}
+
+// profiledDistance computes a query's distance to a node, timing it when
+// profiling is enabled
+func (idx *Index) profiledDistance(query []float32, node *Node, prof *SearchProfile) float32 {
+	if prof == nil {
+		return idx.distanceToNode(query, node)
+	}
+
+	start := time.Now()
+	dist := idx.distanceToNode(query, node)
+	prof.DistanceNanos += time.Since(start).Nanoseconds()
+	prof.DistanceComputations++
+	return dist
+}
@@ -2,9 +2,16 @@ package hnsw
 
 import (
 	"container/heap"
+	"context"
 	"fmt"
+	"math"
+	"time"
 )
 
+// ctxCheckInterval is how many candidates a search expands between checks
+// of its context, keeping the check off the per-neighbor hot path
+const ctxCheckInterval = 64
+
 // Result represents a search result with ID and distance
 type Result struct {
 	ID       uint64  // Node ID
@@ -13,8 +20,9 @@ type Result struct {
 
 // SearchResult holds the results of a search operation
 type SearchResult struct {
-	Results []Result // Sorted results (closest first)
-	Visited int      // Number of nodes visited during search
+	Results   []Result // Sorted results (closest first)
+	Visited   int      // Number of nodes visited during search
+	Truncated bool     // A filtered search hit its visit cap before finding k matches
 }
 
 // Search performs k-NN search for the nearest neighbors of a query vector
@@ -23,6 +31,39 @@ type SearchResult struct {
 //           Higher values give better recall but slower search
 //           Typical values: 50-200
 func (idx *Index) Search(query []float32, k int, efSearch int) (*SearchResult, error) {
+	return idx.SearchCtx(context.Background(), query, k, efSearch)
+}
+
+// SearchCtx performs k-NN search like Search, stopping early with ctx.Err()
+// (context.DeadlineExceeded or context.Canceled) once ctx is done
+func (idx *Index) SearchCtx(ctx context.Context, query []float32, k int, efSearch int) (*SearchResult, error) {
+	return idx.SearchWithProfileCtx(ctx, query, k, efSearch, nil)
+}
+
+// SearchWithProfile performs k-NN search like Search, recording distance
+// computations, visited nodes, heap operations and phase timings into prof.
+// A nil prof disables profiling.
+func (idx *Index) SearchWithProfile(query []float32, k int, efSearch int, prof *SearchProfile) (*SearchResult, error) {
+	return idx.SearchWithProfileCtx(context.Background(), query, k, efSearch, prof)
+}
+
+// SearchWithProfileCtx combines SearchCtx and SearchWithProfile. The
+// context is checked between layers and periodically while the base layer
+// is traversed, so a search whose caller has gone away stops within a few
+// dozen node expansions.
+func (idx *Index) SearchWithProfileCtx(ctx context.Context, query []float32, k int, efSearch int, prof *SearchProfile) (*SearchResult, error) {
+	return idx.SearchWithinCtx(ctx, query, k, efSearch, float32(math.Inf(1)), prof)
+}
+
+// SearchWithinCtx performs k-NN search like SearchWithProfileCtx but returns
+// only results at most maxDistance from the query: at most k, possibly
+// fewer. The base layer traversal also stops early once the closest
+// unexpanded candidate lies beyond maxDistance and improves on nothing
+// found so far, so a tight threshold makes a far query cheap.
+func (idx *Index) SearchWithinCtx(ctx context.Context, query []float32, k int, efSearch int, maxDistance float32, prof *SearchProfile) (*SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if len(query) == 0 {
 		return nil, fmt.Errorf("query vector cannot be empty")
 	}
@@ -45,6 +86,16 @@ func (idx *Index) Search(query []float32, k int, efSearch int) (*SearchResult, e
 		return nil, fmt.Errorf("index has no entry point")
 	}
 
+	if idx.flat {
+		idx.mu.RUnlock()
+		result, err := idx.ExactSearchWithProfile(query, k, prof)
+		if err != nil {
+			return nil, err
+		}
+		result.Results = withinDistance(result.Results, maxDistance)
+		return result, nil
+	}
+
 	// Ensure efSearch is at least k
 	if efSearch < k {
 		efSearch = k
@@ -56,13 +107,60 @@ func (idx *Index) Search(query []float32, k int, efSearch int) (*SearchResult, e
 	idx.mu.RUnlock()
 
 	// Phase 1: Greedy search from top layer to layer 1
-	// Find the closest node by greedily traversing down the layers
+	ep, visited, err := idx.greedyDescent(ctx, query, entryPoint, maxLayer, prof)
+	if err != nil {
+		return nil, err
+	}
+
+	var phaseStart time.Time
+	if prof != nil {
+		phaseStart = time.Now()
+	}
+
+	// Phase 2: Search layer 0 with efSearch candidates
+	candidates, err := idx.searchLayerForQuery(ctx, query, ep, efSearch, 0, maxDistance, &visited, prof)
+	if err != nil {
+		return nil, err
+	}
+
+	if prof != nil {
+		prof.BaseLayerNanos += time.Since(phaseStart).Nanoseconds()
+		prof.NodesVisited += visited
+	}
+
+	// Select top-k results within maxDistance
+	results := make([]Result, 0, k)
+	for i := 0; i < len(candidates) && i < k && candidates[i].distance <= maxDistance; i++ {
+		results = append(results, Result{
+			ID:       candidates[i].id,
+			Distance: candidates[i].distance,
+		})
+	}
+
+	return &SearchResult{
+		Results: results,
+		Visited: visited,
+	}, nil
+}
+
+// greedyDescent finds the node closest to query on layer 1 by greedily
+// walking down from the top layer, returning it and the nodes visited
+func (idx *Index) greedyDescent(ctx context.Context, query []float32, entryPoint *Node, maxLayer int, prof *SearchProfile) (*Node, int, error) {
+	var start time.Time
+	if prof != nil {
+		start = time.Now()
+	}
+
 	ep := entryPoint
-	currentDist := idx.distance(query, ep.vector)
+	currentDist := idx.profiledDistance(query, ep, prof)
 	visited := 1
 
 	// Traverse from top layer down to layer 1
 	for lc := maxLayer; lc > 0; lc-- {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
 		changed := true
 		for changed {
 			changed = false
@@ -75,7 +173,7 @@ func (idx *Index) Search(query []float32, k int, efSearch int) (*SearchResult, e
 					continue
 				}
 
-				dist := idx.distance(query, neighborNode.vector)
+				dist := idx.profiledDistance(query, neighborNode, prof)
 				if dist < currentDist {
 					currentDist = dist
 					ep = neighborNode
@@ -85,47 +183,62 @@ func (idx *Index) Search(query []float32, k int, efSearch int) (*SearchResult, e
 		}
 	}
 
-	// Phase 2: Search layer 0 with efSearch candidates
-	candidates := idx.searchLayerForQuery(query, ep, efSearch, 0, &visited)
-
-	// Select top-k results
-	results := make([]Result, 0, k)
-	for i := 0; i < len(candidates) && i < k; i++ {
-		results = append(results, Result{
-			ID:       candidates[i].id,
-			Distance: candidates[i].distance,
-		})
+	if prof != nil {
+		prof.GreedyNanos += time.Since(start).Nanoseconds()
 	}
+	return ep, visited, nil
+}
 
-	return &SearchResult{
-		Results: results,
-		Visited: visited,
-	}, nil
+// withinDistance returns the prefix of sorted results at most maxDistance
+// from the query
+func withinDistance(results []Result, maxDistance float32) []Result {
+	for i, r := range results {
+		if r.Distance > maxDistance {
+			return results[:i]
+		}
+	}
+	return results
 }
 
 // searchLayerForQuery is similar to searchLayer but used for querying
-// It returns sorted results (closest first) and tracks visited nodes
-func (idx *Index) searchLayerForQuery(query []float32, entryPoint *Node, ef int, layer int, visited *int) []heapItem {
+// It returns sorted results (closest first) and tracks visited nodes,
+// or ctx.Err() if ctx is done before the traversal finishes. The traversal
+// stops once the closest candidate is beyond maxDistance and no closer
+// than the best result, as nothing left is likely to fall within it.
+func (idx *Index) searchLayerForQuery(ctx context.Context, query []float32, entryPoint *Node, ef int, layer int, maxDistance float32, visited *int, prof *SearchProfile) ([]heapItem, error) {
 	visitedSet := make(map[uint64]bool)
 	candidates := &minHeap{}
 	results := &maxHeap{}
+	heapOps := 0
 
 	// Start with entry point
-	dist := idx.distance(query, entryPoint.vector)
+	dist := idx.profiledDistance(query, entryPoint, prof)
 	heap.Push(candidates, heapItem{id: entryPoint.ID(), distance: dist})
 	heap.Push(results, heapItem{id: entryPoint.ID(), distance: dist})
+	heapOps += 2
 	visitedSet[entryPoint.ID()] = true
 	*visited++
+	best := dist
 
 	// Greedy search with ef candidates
-	for candidates.Len() > 0 {
+	for expanded := 0; candidates.Len() > 0; expanded++ {
+		if expanded%ctxCheckInterval == ctxCheckInterval-1 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
 		// Get closest candidate
 		current := heap.Pop(candidates).(heapItem)
+		heapOps++
 
 		// If current is farther than worst result, we can stop
 		if current.distance > results.Peek().(heapItem).distance {
 			break
 		}
+		if current.distance > maxDistance && current.distance > best {
+			break
+		}
 
 		// Explore neighbors
 		currentNode := idx.GetNode(current.id)
@@ -146,16 +259,21 @@ func (idx *Index) searchLayerForQuery(query []float32, entryPoint *Node, ef int,
 				continue
 			}
 
-			neighborDist := idx.distance(query, neighborNode.vector)
+			neighborDist := idx.profiledDistance(query, neighborNode, prof)
 
 			// If neighbor is closer than worst result, or we need more results
 			if neighborDist < results.Peek().(heapItem).distance || results.Len() < ef {
 				heap.Push(candidates, heapItem{id: neighborID, distance: neighborDist})
 				heap.Push(results, heapItem{id: neighborID, distance: neighborDist})
+				heapOps += 2
+				if neighborDist < best {
+					best = neighborDist
+				}
 
 				// Keep only ef closest results
 				if results.Len() > ef {
 					heap.Pop(results)
+					heapOps++
 				}
 			}
 		}
@@ -166,8 +284,13 @@ func (idx *Index) searchLayerForQuery(query []float32, entryPoint *Node, ef int,
 	for i := len(resultSlice) - 1; i >= 0; i-- {
 		resultSlice[i] = heap.Pop(results).(heapItem)
 	}
+	heapOps += len(resultSlice)
+
+	if prof != nil {
+		prof.HeapOperations += heapOps
+	}
 
-	return resultSlice
+	return resultSlice, nil
 }
 
 // KNNSearch is a convenience method for k-NN search with default efSearch
@@ -180,6 +303,55 @@ func (idx *Index) KNNSearch(query []float32, k int) (*SearchResult, error) {
 	return idx.Search(query, k, efSearch)
 }
 
+// RangeSearch finds every vector within radius of the query, closest first.
+// The beam starts at efSearch and doubles until it returns a node beyond the
+// radius or exhausts the index, so the result set is not limited by k.
+// At most maxResults results are returned; truncated reports whether more
+// vectors lie within the radius.
+func (idx *Index) RangeSearch(query []float32, radius float32, efSearch, maxResults int) ([]Result, bool, error) {
+	if maxResults <= 0 {
+		return nil, false, fmt.Errorf("maxResults must be > 0")
+	}
+	if efSearch <= 0 {
+		efSearch = 50
+	}
+
+	size := int(idx.Size())
+	ef := efSearch
+	var results []Result
+	for {
+		// One extra candidate tells a full cap apart from a truncated one
+		if ef > maxResults+1 {
+			ef = maxResults + 1
+		}
+
+		searchResult, err := idx.Search(query, ef, ef)
+		if err != nil {
+			return nil, false, err
+		}
+
+		within := 0
+		for within < len(searchResult.Results) && searchResult.Results[within].Distance <= radius {
+			within++
+		}
+		results = searchResult.Results[:within]
+
+		// Done once a result falls outside the radius or nothing is left to find
+		if within < len(searchResult.Results) || len(searchResult.Results) < ef || ef >= size {
+			break
+		}
+		if ef > maxResults {
+			break
+		}
+		ef *= 2
+	}
+
+	if len(results) > maxResults {
+		return results[:maxResults], true, nil
+	}
+	return results, false, nil
+}
+
 // GetVector retrieves a vector by its ID
 func (idx *Index) GetVector(id uint64) ([]float32, error) {
 	idx.mu.RLock()
@@ -191,13 +363,26 @@ func (idx *Index) GetVector(id uint64) ([]float32, error) {
 	}
 
 	// Return a copy to prevent external modification
+	if node.half != nil {
+		return node.Vector(), nil
+	}
 	vector := make([]float32, len(node.vector))
 	copy(vector, node.vector)
 	return vector, nil
 }
 
-// Delete removes a vector from the index by ID
-// This is a simplified implementation that removes the node and updates neighbor links
+// minUnlinkSweep and unlinkSweepFraction set when deleted IDs are swept
+// from the graph: once more than minUnlinkSweep of them, and more than one
+// per unlinkSweepFraction stored nodes, are pending. Each sweep visits every
+// node, so deletes pay a bounded amortized cost for it.
+const (
+	minUnlinkSweep      = 64
+	unlinkSweepFraction = 8
+)
+
+// Delete removes a vector from the index by ID. The node is unlinked from
+// its own neighbors; links that other nodes still hold to it are skipped by
+// searches and dropped in batches by sweepUnlinkedLocked.
 func (idx *Index) Delete(id uint64) error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
@@ -207,15 +392,14 @@ func (idx *Index) Delete(id uint64) error {
 		return fmt.Errorf("node with ID %d not found", id)
 	}
 
-	// Remove all bidirectional links
-	for layer := 0; layer <= node.level; layer++ {
-		neighbors := node.GetNeighbors(layer)
-		for _, neighborID := range neighbors {
-			neighborNode := idx.nodes[neighborID]
-			if neighborNode != nil {
-				neighborNode.RemoveNeighbor(layer, id)
-			}
+	// Pruning makes links one-directional, so nodes outside its own
+	// neighbor lists may still point at it
+	idx.unlinkNeighborsLocked(node, nil)
+	if !idx.flat {
+		if idx.unlinked == nil {
+			idx.unlinked = make(map[uint64]bool)
 		}
+		idx.unlinked[id] = true
 	}
 
 	// If this was the entry point, find a new one
@@ -238,32 +422,115 @@ func (idx *Index) Delete(id uint64) error {
 	// Remove the node
 	delete(idx.nodes, id)
 	idx.size--
+	idx.deleted++
 
+	if pending := len(idx.unlinked); pending > minUnlinkSweep && pending*unlinkSweepFraction > len(idx.nodes) {
+		idx.sweepUnlinkedLocked()
+	}
 	return nil
 }
 
-// Update updates a vector in the index
-// This is implemented as delete + insert
-func (idx *Index) Update(id uint64, newVector []float32) error {
-	// Check if node exists
-	idx.mu.RLock()
-	_, exists := idx.nodes[id]
-	idx.mu.RUnlock()
+// unlinkNeighborsLocked drops old's neighbors' links back to its ID,
+// except to neighbors that keep, the node taking its place (nil for none),
+// links to as well. Callers hold idx.mu.
+func (idx *Index) unlinkNeighborsLocked(old, keep *Node) {
+	id := old.ID()
+	for layer := 0; layer <= old.level; layer++ {
+		for _, neighborID := range old.GetNeighbors(layer) {
+			neighbor := idx.nodes[neighborID]
+			if neighbor == nil || (keep != nil && keep.HasNeighbor(layer, neighborID)) {
+				continue
+			}
+			neighbor.RemoveNeighbor(layer, id)
+		}
+	}
+}
 
-	if !exists {
-		return fmt.Errorf("node with ID %d not found", id)
+// sweepUnlinkedLocked drops every link to a deleted ID from the graph.
+// Callers hold idx.mu.
+func (idx *Index) sweepUnlinkedLocked() {
+	if len(idx.unlinked) == 0 {
+		return
 	}
+	for _, node := range idx.nodes {
+		node.mu.Lock()
+		for layer, neighbors := range node.neighbors {
+			kept := neighbors[:0]
+			for _, neighborID := range neighbors {
+				if !idx.unlinked[neighborID] {
+					kept = append(kept, neighborID)
+				}
+			}
+			node.neighbors[layer] = kept
+		}
+		node.mu.Unlock()
+	}
+	idx.unlinked = nil
+}
 
-	// Delete old vector
-	if err := idx.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete old vector: %w", err)
+// Update replaces the vector stored under id. The replacement node is
+// linked into the graph while the old one is still in place and the two
+// are swapped under the write lock, so concurrent searches find either
+// the old vector or the new one, never neither.
+func (idx *Index) Update(id uint64, newVector []float32) error {
+	if len(newVector) == 0 {
+		return fmt.Errorf("cannot insert empty vector")
 	}
 
-	// Insert new vector (will get a new ID)
-	_, err := idx.Insert(newVector)
-	if err != nil {
-		return fmt.Errorf("failed to insert new vector: %w", err)
+	for {
+		idx.mu.RLock()
+		old := idx.nodes[id]
+		dimension := idx.dimension
+		flat := idx.flat
+		entryPoint := idx.entryPoint
+		currentMaxLayer := idx.maxLayer
+		idx.mu.RUnlock()
+
+		if old == nil {
+			return fmt.Errorf("node with ID %d not found", id)
+		}
+		if len(newVector) != dimension {
+			return fmt.Errorf("vector dimension mismatch: expected %d, got %d", dimension, len(newVector))
+		}
+
+		// Keep the node's level so the entry point and top layer stay valid
+		replacement := idx.newNode(id, newVector, old.level)
+		if !flat {
+			idx.link(replacement, newVector, old.level, entryPoint, currentMaxLayer, idx.efConstruction)
+		}
+
+		idx.mu.Lock()
+		if idx.nodes[id] == old && idx.flat == flat {
+			idx.replaceLocked(old, replacement)
+			idx.mu.Unlock()
+			return nil
+		}
+
+		// The node was deleted or replaced, or the graph was built, while
+		// linking: start over, first dropping links to a deleted node
+		if idx.nodes[id] == nil {
+			for layer := 0; layer <= replacement.level; layer++ {
+				for _, neighborID := range replacement.GetNeighbors(layer) {
+					if neighbor := idx.nodes[neighborID]; neighbor != nil {
+						neighbor.RemoveNeighbor(layer, id)
+					}
+				}
+			}
+		}
+		idx.mu.Unlock()
 	}
+}
 
-	return nil
+// replaceLocked swaps replacement in for old, dropping the links from the
+// old vector's neighbors that relinking did not recreate. Other nodes
+// linking to the ID keep their links, now to the new vector. Callers hold
+// idx.mu.
+func (idx *Index) replaceLocked(old, replacement *Node) {
+	id := old.ID()
+	idx.unlinkNeighborsLocked(old, replacement)
+
+	idx.nodes[id] = replacement
+	if idx.entryPoint == old {
+		idx.entryPoint = replacement
+	}
 }
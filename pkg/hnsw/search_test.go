@@ -1,6 +1,9 @@
 package hnsw
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"math/rand"
 	"sort"
 	"testing"
@@ -122,6 +125,59 @@ func TestKNNSearch(t *testing.T) {
 	}
 }
 
+// TestSearchTieBreaksByID checks that vectors at equal distance come back in
+// ascending ID order, and the same order on every search
+func TestSearchTieBreaksByID(t *testing.T) {
+	idx := New(DefaultConfig())
+	rng := rand.New(rand.NewSource(42))
+
+	// Scatter 12 copies of one vector among random ones
+	duplicate := []float32{0.5, 0.5, 0.5, 0.5}
+	var duplicateIDs []uint64
+	for i := 0; i < 300; i++ {
+		if i%25 == 7 {
+			id, err := idx.Insert(duplicate)
+			if err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+			duplicateIDs = append(duplicateIDs, id)
+			continue
+		}
+		if _, err := idx.Insert([]float32{rng.Float32(), rng.Float32(), rng.Float32(), rng.Float32()}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	k := 10
+	var first []uint64
+	for run := 0; run < 5; run++ {
+		result, err := idx.Search(duplicate, k, 50)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		ids := make([]uint64, len(result.Results))
+		for i, r := range result.Results {
+			ids[i] = r.ID
+		}
+
+		if run == 0 {
+			// The k lowest of the colliding IDs, in order
+			for i := 0; i < k; i++ {
+				if ids[i] != duplicateIDs[i] || result.Results[i].Distance != 0 {
+					t.Fatalf("Expected IDs %v at distance 0, got %v", duplicateIDs[:k], ids)
+				}
+			}
+			first = ids
+			continue
+		}
+		for i := range ids {
+			if ids[i] != first[i] {
+				t.Fatalf("Run %d returned %v, first run returned %v", run, ids, first)
+			}
+		}
+	}
+}
+
 // TestSearchDimensionMismatch tests dimension validation
 func TestSearchDimensionMismatch(t *testing.T) {
 	config := DefaultConfig()
@@ -302,6 +358,140 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+// TestDeleteSweepsLinks checks that links left to deleted nodes are never
+// followed, saved or handed to a reused ID, and are swept in batches
+func TestDeleteSweepsLinks(t *testing.T) {
+	config := DefaultConfig()
+	config.FlatThreshold = 0
+	idx := New(config)
+
+	for i := 0; i < 1000; i++ {
+		if _, err := idx.Insert(randomVector(8)); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	for id := uint64(0); id < 300; id++ {
+		if err := idx.Delete(id); err != nil {
+			t.Fatalf("Delete %d failed: %v", id, err)
+		}
+		if pending := len(idx.unlinked); pending > minUnlinkSweep && pending*unlinkSweepFraction > len(idx.nodes) {
+			t.Fatalf("Expected a sweep after delete %d, %d IDs pending", id, pending)
+		}
+	}
+	if err := idx.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	result, err := idx.Search(randomVector(8), 50, 200)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	for _, r := range result.Results {
+		if r.ID < 300 {
+			t.Errorf("Search returned deleted vector %d", r.ID)
+		}
+	}
+
+	// A saved index holds no links to deleted nodes
+	var buf bytes.Buffer
+	if err := idx.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded := New(config)
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := loaded.Validate(); err != nil {
+		t.Errorf("Validate of the loaded index failed: %v", err)
+	}
+
+	// Reusing a deleted ID first sweeps the links left to it
+	reused := uint64(300)
+	for ; !idx.unlinked[reused-1]; reused++ {
+		if err := idx.Delete(reused); err != nil {
+			t.Fatalf("Delete %d failed: %v", reused, err)
+		}
+	}
+	if err := idx.InsertWithID(reused-1, randomVector(8)); err != nil {
+		t.Fatalf("InsertWithID failed: %v", err)
+	}
+	if len(idx.unlinked) != 0 {
+		t.Errorf("Expected the pending IDs swept, %d left", len(idx.unlinked))
+	}
+	if err := idx.Validate(); err != nil {
+		t.Errorf("Validate after reusing an ID failed: %v", err)
+	}
+}
+
+// TestUpdateConcurrentSearch checks that a vector being replaced is never
+// missing from concurrent searches
+func TestUpdateConcurrentSearch(t *testing.T) {
+	config := DefaultConfig()
+	config.FlatThreshold = 0
+	idx := New(config)
+
+	for i := 0; i < 100; i++ {
+		if _, err := idx.Insert(randomVector(16)); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	const target = 50
+	query, err := idx.GetVector(target)
+	if err != nil {
+		t.Fatalf("GetVector failed: %v", err)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				done <- nil
+				return
+			default:
+			}
+			vec := append([]float32(nil), query...)
+			vec[0] += float32(i%2) * 0.001
+			if err := idx.Update(target, vec); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	// efSearch above the index size makes every search exhaustive
+	for i := 0; i < 2000; i++ {
+		result, err := idx.Search(query, 100, 200)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		found := false
+		for _, r := range result.Results {
+			if r.ID == target {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("Search %d missed vector %d while it was being updated", i, target)
+		}
+	}
+	close(stop)
+	if err := <-done; err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if idx.Size() != 100 {
+		t.Errorf("Expected size 100 after updates, got %d", idx.Size())
+	}
+	if err := idx.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
+
 // TestGetVector tests vector retrieval
 func TestGetVector(t *testing.T) {
 	config := DefaultConfig()
@@ -544,3 +734,312 @@ func TestSearchPerformance(t *testing.T) {
 		t.Logf("Warning: p95 latency (%v) exceeds 10ms target", p95)
 	}
 }
+
+// TestSearchWithProfile tests that profile counters are populated and consistent
+func TestSearchWithProfile(t *testing.T) {
+	idx := New(DefaultConfig())
+	rng := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 500; i++ {
+		vector := make([]float32, 16)
+		for j := range vector {
+			vector[j] = rng.Float32()
+		}
+		if _, err := idx.Insert(vector); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	query := make([]float32, 16)
+	for j := range query {
+		query[j] = rng.Float32()
+	}
+
+	prof := &SearchProfile{}
+	result, err := idx.SearchWithProfile(query, 10, 50, prof)
+	if err != nil {
+		t.Fatalf("SearchWithProfile failed: %v", err)
+	}
+
+	if len(result.Results) != 10 {
+		t.Fatalf("Expected 10 results, got %d", len(result.Results))
+	}
+	if prof.NodesVisited != result.Visited {
+		t.Errorf("NodesVisited = %d, want %d", prof.NodesVisited, result.Visited)
+	}
+	if prof.NodesVisited < len(result.Results) {
+		t.Errorf("NodesVisited = %d, fewer than %d results", prof.NodesVisited, len(result.Results))
+	}
+	if prof.DistanceComputations < len(result.Results) || prof.DistanceComputations > prof.NodesVisited {
+		t.Errorf("DistanceComputations = %d, want between %d and NodesVisited %d",
+			prof.DistanceComputations, len(result.Results), prof.NodesVisited)
+	}
+	if prof.HeapOperations < 2*len(result.Results) {
+		t.Errorf("HeapOperations = %d, want at least a push and pop per result", prof.HeapOperations)
+	}
+	if prof.BaseLayerNanos <= 0 {
+		t.Errorf("BaseLayerNanos = %d, want > 0", prof.BaseLayerNanos)
+	}
+	if prof.DistanceNanos > prof.GreedyNanos+prof.BaseLayerNanos {
+		t.Errorf("DistanceNanos %d exceeds total phase time %d",
+			prof.DistanceNanos, prof.GreedyNanos+prof.BaseLayerNanos)
+	}
+
+	// Profiling must not change results
+	plain, err := idx.Search(query, 10, 50)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	for i := range plain.Results {
+		if plain.Results[i] != result.Results[i] {
+			t.Errorf("Result %d differs with profiling: %+v vs %+v", i, result.Results[i], plain.Results[i])
+		}
+	}
+}
+
+func TestSearchCtx(t *testing.T) {
+	idx := New(DefaultConfig())
+	rng := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 2000; i++ {
+		vector := make([]float32, 16)
+		for j := range vector {
+			vector[j] = rng.Float32()
+		}
+		if _, err := idx.Insert(vector); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	query := make([]float32, 16)
+	for j := range query {
+		query[j] = rng.Float32()
+	}
+
+	// A live context returns the same results as Search
+	result, err := idx.SearchCtx(context.Background(), query, 10, 50)
+	if err != nil {
+		t.Fatalf("SearchCtx failed: %v", err)
+	}
+	plain, err := idx.Search(query, 10, 50)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	for i := range plain.Results {
+		if plain.Results[i] != result.Results[i] {
+			t.Errorf("Result %d differs: %+v vs %+v", i, result.Results[i], plain.Results[i])
+		}
+	}
+
+	// A context that is already done fails before searching
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := idx.SearchCtx(ctx, query, 10, 50); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err := idx.SearchCtx(expired, query, 10, 50); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// A deadline passing mid-traversal stops the base layer search
+	ctx = &expiringContext{Context: context.Background(), checks: idx.MaxLayer() + 3}
+	if _, err := idx.SearchCtx(ctx, query, 1000, 1000); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded mid-search, got %v", err)
+	}
+}
+
+// expiringContext reports its deadline as exceeded after a number of checks
+type expiringContext struct {
+	context.Context
+	checks int
+}
+
+func (c *expiringContext) Err() error {
+	if c.checks <= 0 {
+		return context.DeadlineExceeded
+	}
+	c.checks--
+	return nil
+}
+
+func TestExactSearch(t *testing.T) {
+	config := DefaultConfig()
+	idx := New(config)
+	rng := rand.New(rand.NewSource(7))
+
+	vectors := make([][]float32, 200)
+	for i := range vectors {
+		vectors[i] = make([]float32, 8)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+		if _, err := idx.Insert(vectors[i]); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	query := vectors[17]
+	prof := &SearchProfile{}
+	result, err := idx.ExactSearchWithProfile(query, 10, prof)
+	if err != nil {
+		t.Fatalf("ExactSearchWithProfile failed: %v", err)
+	}
+
+	expected := bruteForceKNN(query, vectors, 10, config.DistanceFunc)
+	if len(result.Results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(result.Results))
+	}
+	for i := range expected {
+		if result.Results[i].ID != expected[i].ID {
+			t.Errorf("Rank %d: got ID %d, want %d", i, result.Results[i].ID, expected[i].ID)
+		}
+	}
+
+	if result.Visited != len(vectors) || prof.NodesVisited != len(vectors) {
+		t.Errorf("Expected every node visited, got %d (profile %d)", result.Visited, prof.NodesVisited)
+	}
+	if prof.DistanceComputations != len(vectors) {
+		t.Errorf("DistanceComputations = %d, want %d", prof.DistanceComputations, len(vectors))
+	}
+
+	if _, err := idx.ExactSearch([]float32{1, 2}, 10); err == nil {
+		t.Error("Expected error for dimension mismatch")
+	}
+}
+
+func TestRangeSearch(t *testing.T) {
+	config := DefaultConfig()
+	config.DistanceFunc = EuclideanDistance
+	idx := New(config)
+	rng := rand.New(rand.NewSource(42))
+
+	vectors := make([][]float32, 2000)
+	for i := range vectors {
+		vectors[i] = make([]float32, 4)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+		if _, err := idx.Insert(vectors[i]); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	query := []float32{0.5, 0.5, 0.5, 0.5}
+	const radius = 0.3
+
+	expected := 0
+	for _, r := range bruteForceKNN(query, vectors, len(vectors), EuclideanDistance) {
+		if r.Distance <= radius {
+			expected++
+		}
+	}
+	if expected <= 50 {
+		t.Fatalf("Test needs more than efSearch=50 vectors in range, got %d", expected)
+	}
+
+	results, truncated, err := idx.RangeSearch(query, radius, 50, 10000)
+	if err != nil {
+		t.Fatalf("RangeSearch failed: %v", err)
+	}
+	if truncated {
+		t.Error("Did not expect truncation below the cap")
+	}
+	for i, r := range results {
+		if r.Distance > radius {
+			t.Errorf("Result %d at distance %f is outside radius %f", i, r.Distance, radius)
+		}
+		if i > 0 && r.Distance < results[i-1].Distance {
+			t.Errorf("Results not sorted at %d", i)
+		}
+	}
+
+	recall := float64(len(results)) / float64(expected)
+	t.Logf("RangeSearch found %d of %d vectors in range (%.1f%%)", len(results), expected, recall*100)
+	if recall < 0.95 {
+		t.Errorf("Range recall %.2f below 0.95", recall)
+	}
+
+	results, truncated, err = idx.RangeSearch(query, radius, 50, 20)
+	if err != nil {
+		t.Fatalf("RangeSearch failed: %v", err)
+	}
+	if len(results) != 20 || !truncated {
+		t.Errorf("Expected 20 truncated results at the cap, got %d (truncated=%v)", len(results), truncated)
+	}
+
+	results, _, err = idx.RangeSearch(query, 0, 50, 100)
+	if err != nil {
+		t.Fatalf("RangeSearch failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results at radius 0, got %d", len(results))
+	}
+}
+
+func TestSearchWithin(t *testing.T) {
+	config := DefaultConfig()
+	config.DistanceFunc = EuclideanDistance
+	idx := New(config)
+	rng := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 2000; i++ {
+		vector := make([]float32, 4)
+		for j := range vector {
+			vector[j] = rng.Float32()
+		}
+		if _, err := idx.Insert(vector); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	query := []float32{0.5, 0.5, 0.5, 0.5}
+	full, err := idx.Search(query, 20, 100)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	// A threshold between the 5th and 6th results keeps exactly the first 5
+	maxDistance := (full.Results[4].Distance + full.Results[5].Distance) / 2
+	within, err := idx.SearchWithinCtx(ctx, query, 20, 100, maxDistance, nil)
+	if err != nil {
+		t.Fatalf("SearchWithinCtx failed: %v", err)
+	}
+	if len(within.Results) != 5 {
+		t.Fatalf("Expected the 5 results within %f, got %d", maxDistance, len(within.Results))
+	}
+	for i, r := range within.Results {
+		if r != full.Results[i] {
+			t.Errorf("Rank %d: got %+v, expected %+v", i, r, full.Results[i])
+		}
+	}
+
+	// k still caps the results
+	capped, err := idx.SearchWithinCtx(ctx, query, 3, 100, maxDistance, nil)
+	if err != nil {
+		t.Fatalf("SearchWithinCtx failed: %v", err)
+	}
+	if len(capped.Results) != 3 {
+		t.Errorf("Expected k=3 to cap the results, got %d", len(capped.Results))
+	}
+
+	// A query far from every vector stops early and returns nothing
+	far := []float32{10, 10, 10, 10}
+	unbounded, err := idx.Search(far, 10, 100)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	none, err := idx.SearchWithinCtx(ctx, far, 10, 100, 1, nil)
+	if err != nil {
+		t.Fatalf("SearchWithinCtx failed: %v", err)
+	}
+	if len(none.Results) != 0 {
+		t.Errorf("Expected no results within 1 of a far query, got %d", len(none.Results))
+	}
+	if none.Visited >= unbounded.Visited {
+		t.Errorf("Expected the threshold to cut the search short, visited %d vs %d", none.Visited, unbounded.Visited)
+	}
+}
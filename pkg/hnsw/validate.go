@@ -0,0 +1,117 @@
+package hnsw
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxReportedViolations caps how many violations a ValidationError lists
+const maxReportedViolations = 10
+
+// ValidationError reports graph invariant violations found by Validate
+type ValidationError struct {
+	Violations []string // The first violations found, in node ID order
+	Total      int      // Total number of violations, including unlisted ones
+}
+
+// Error lists the recorded violations and how many more were found
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("hnsw: index failed validation with %d violation(s): %s",
+		e.Total, strings.Join(e.Violations, "; "))
+	if more := e.Total - len(e.Violations); more > 0 {
+		msg += fmt.Sprintf(" (and %d more)", more)
+	}
+	return msg
+}
+
+// add records a violation, keeping only the first few descriptions
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Total++
+	if len(e.Violations) < maxReportedViolations {
+		e.Violations = append(e.Violations, fmt.Sprintf(format, args...))
+	}
+}
+
+// Validate checks the graph's structural invariants and returns a
+// *ValidationError describing the first few violations, or nil.
+//
+// It verifies that every neighbor ID refers to a stored node present at
+// that layer, or to a deleted one whose links are not yet swept, that each node has a neighbor list for every layer from 0 up
+// to its level, that vectors match the index dimension, and that the entry
+// point is a stored node at the top layer. Validate holds the index read
+// lock, but inserts link nodes outside it, so run it on a quiescent index
+// for exact results.
+func (idx *Index) Validate() error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	verr := &ValidationError{}
+
+	if int(idx.size) != len(idx.nodes) {
+		verr.add("size %d does not match %d stored nodes", idx.size, len(idx.nodes))
+	}
+
+	// Walk nodes in ID order so reports are stable
+	ids := make([]uint64, 0, len(idx.nodes))
+	for id := range idx.nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	maxLevel := -1
+	for _, id := range ids {
+		node := idx.nodes[id]
+		if node.id != id {
+			verr.add("node stored under ID %d reports ID %d", id, node.id)
+		}
+		if idx.dimension > 0 && node.dimension() != idx.dimension {
+			verr.add("node %d has dimension %d, expected %d", id, node.dimension(), idx.dimension)
+		}
+		if node.level > maxLevel {
+			maxLevel = node.level
+		}
+
+		node.mu.RLock()
+		if len(node.neighbors) != node.level+1 {
+			verr.add("node %d at level %d has neighbor lists for %d layers", id, node.level, len(node.neighbors))
+		}
+		for layer, neighbors := range node.neighbors {
+			for _, neighborID := range neighbors {
+				neighbor := idx.nodes[neighborID]
+				switch {
+				case neighborID == id:
+					verr.add("node %d links to itself at layer %d", id, layer)
+				case neighbor == nil:
+					if !idx.unlinked[neighborID] {
+						verr.add("node %d has dangling neighbor %d at layer %d", id, neighborID, layer)
+					}
+				case neighbor.level < layer:
+					verr.add("node %d links to %d at layer %d, but %d only reaches layer %d",
+						id, neighborID, layer, neighborID, neighbor.level)
+				}
+			}
+		}
+		node.mu.RUnlock()
+	}
+
+	// Entry point
+	switch {
+	case idx.entryPoint == nil:
+		if len(idx.nodes) > 0 {
+			verr.add("index has %d nodes but no entry point", len(idx.nodes))
+		}
+	case idx.nodes[idx.entryPoint.id] != idx.entryPoint:
+		verr.add("entry point %d is not a stored node", idx.entryPoint.id)
+	case idx.entryPoint.level != idx.maxLayer:
+		verr.add("entry point %d is at level %d, but the max layer is %d",
+			idx.entryPoint.id, idx.entryPoint.level, idx.maxLayer)
+	case maxLevel > idx.maxLayer:
+		verr.add("a node reaches level %d above the max layer %d", maxLevel, idx.maxLayer)
+	}
+
+	if verr.Total > 0 {
+		return verr
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package hnsw
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	idx := New(DefaultConfig())
+	if err := idx.Validate(); err != nil {
+		t.Fatalf("Expected empty index to be valid, got %v", err)
+	}
+
+	// Concurrent inserts and deletes must leave a consistent graph
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				idx.Insert(randomVector(16))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for id := uint64(0); id < 400; id += 3 {
+		if err := idx.Delete(id); err != nil {
+			t.Fatalf("Delete %d failed: %v", id, err)
+		}
+	}
+
+	if err := idx.Validate(); err != nil {
+		t.Fatalf("Expected valid graph, got %v", err)
+	}
+}
+
+func TestValidateReportsViolations(t *testing.T) {
+	idx := New(DefaultConfig())
+	for i := 0; i < 50; i++ {
+		idx.Insert(randomVector(8))
+	}
+
+	// Corrupt the graph: dangling links from every node and a bad entry point
+	for _, node := range idx.nodes {
+		node.AddNeighbor(0, 9999)
+	}
+	idx.nodes[3].AddNeighbor(0, 3)
+
+	err := idx.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if verr.Total != 51 {
+		t.Errorf("Expected 51 violations, got %d", verr.Total)
+	}
+	if len(verr.Violations) != maxReportedViolations {
+		t.Errorf("Expected %d listed violations, got %d", maxReportedViolations, len(verr.Violations))
+	}
+	if !strings.Contains(verr.Violations[0], "node 0 has dangling neighbor 9999 at layer 0") {
+		t.Errorf("Unexpected first violation: %q", verr.Violations[0])
+	}
+	if !strings.Contains(err.Error(), "(and 41 more)") {
+		t.Errorf("Expected error to count unlisted violations, got %q", err.Error())
+	}
+}
+
+func TestValidateEntryPointAndLayers(t *testing.T) {
+	idx := New(DefaultConfig())
+	for i := 0; i < 20; i++ {
+		idx.Insert(randomVector(8))
+	}
+
+	// A link above the neighbor's level breaks the layer invariant
+	var low, high *Node
+	for _, node := range idx.nodes {
+		if node.level == 0 && low == nil {
+			low = node
+		}
+		if node.level > 0 && high == nil {
+			high = node
+		}
+	}
+	if low != nil && high != nil {
+		high.AddNeighbor(1, low.id)
+		if err := idx.Validate(); err == nil || !strings.Contains(err.Error(), "only reaches layer 0") {
+			t.Errorf("Expected layer violation, got %v", err)
+		}
+		high.RemoveNeighbor(1, low.id)
+	}
+
+	entry := idx.entryPoint
+	idx.entryPoint = nil
+	if err := idx.Validate(); err == nil || !strings.Contains(err.Error(), "no entry point") {
+		t.Errorf("Expected missing entry point violation, got %v", err)
+	}
+	idx.entryPoint = entry
+
+	if err := idx.Validate(); err != nil {
+		t.Errorf("Expected repaired graph to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package hnsw
+
+import (
+	"math/rand"
+)
+
+// Warmup runs queries searches for randomly chosen stored vectors so the
+// graph's nodes and vectors are paged in before real traffic arrives. It
+// returns the total number of nodes visited.
+func (idx *Index) Warmup(queries int, efSearch int) (int, error) {
+	idx.mu.RLock()
+	ids := make([]uint64, 0, len(idx.nodes))
+	for id := range idx.nodes {
+		ids = append(ids, id)
+	}
+	idx.mu.RUnlock()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	visited := 0
+	for i := 0; i < queries; i++ {
+		node := idx.GetNode(ids[rand.Intn(len(ids))])
+		if node == nil {
+			continue // Deleted since the IDs were collected
+		}
+
+		result, err := idx.Search(node.Vector(), 1, efSearch)
+		if err != nil {
+			return visited, err
+		}
+		visited += result.Visited
+	}
+
+	return visited, nil
+}
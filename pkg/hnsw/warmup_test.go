@@ -0,0 +1,24 @@
+package hnsw
+
+import "testing"
+
+func TestWarmup(t *testing.T) {
+	idx := New(DefaultConfig())
+
+	visited, err := idx.Warmup(10, 50)
+	if err != nil || visited != 0 {
+		t.Fatalf("Expected no work on an empty index, got %d visited (err: %v)", visited, err)
+	}
+
+	for i := 0; i < 200; i++ {
+		idx.Insert(randomVector(16))
+	}
+
+	visited, err = idx.Warmup(10, 50)
+	if err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+	if visited < 10 {
+		t.Errorf("Expected every query to visit nodes, got %d visited", visited)
+	}
+}
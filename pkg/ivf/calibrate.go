@@ -0,0 +1,118 @@
+package ivf
+
+import "fmt"
+
+// Calibrate picks the smallest nprobe whose recall on a validation set
+// reaches targetRecall, and makes it the default for searches passed
+// nprobe <= 0. groundTruth[i] holds the true nearest neighbor IDs of
+// queries[i]; recall is measured at k = len(groundTruth[i]).
+//
+// nprobe is swept by doubling until the target is met, then narrowed by
+// binary search, so recall is assumed to grow with nprobe. Returns an
+// error if the target is not met even when every centroid is probed.
+func (ivfpq *IVFPQ) Calibrate(queries [][]float32, groundTruth [][]int, targetRecall float32) (int, error) {
+	if len(queries) == 0 {
+		return 0, fmt.Errorf("no validation queries provided")
+	}
+	if len(queries) != len(groundTruth) {
+		return 0, fmt.Errorf("queries and ground truth length mismatch")
+	}
+	if targetRecall <= 0 || targetRecall > 1 {
+		return 0, fmt.Errorf("target recall must be in (0, 1], got %g", targetRecall)
+	}
+
+	ivfpq.mu.RLock()
+	trained := ivfpq.trained && ivfpq.pqTrained
+	numCentroids := len(ivfpq.centroids)
+	ivfpq.mu.RUnlock()
+	if !trained {
+		return 0, fmt.Errorf("index not trained")
+	}
+
+	measured := make(map[int]float32)
+	meets := func(nprobe int) (bool, error) {
+		recall, err := ivfpq.measureRecall(queries, groundTruth, nprobe)
+		if err != nil {
+			return false, err
+		}
+		measured[nprobe] = recall
+		return recall >= targetRecall, nil
+	}
+
+	// Double until the target is met; the answer lies in (low, high]
+	low, high := 0, 1
+	for {
+		ok, err := meets(high)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			break
+		}
+		if high == numCentroids {
+			return 0, fmt.Errorf("target recall %.3f unreachable: recall is %.3f with all %d centroids probed",
+				targetRecall, measured[high], numCentroids)
+		}
+		low = high
+		high = min(high*2, numCentroids)
+	}
+
+	for high-low > 1 {
+		mid := (low + high) / 2
+		ok, err := meets(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+
+	ivfpq.mu.Lock()
+	ivfpq.defaultNprobe = high
+	ivfpq.mu.Unlock()
+
+	return high, nil
+}
+
+// DefaultNprobe returns the nprobe used for searches passed nprobe <= 0
+func (ivfpq *IVFPQ) DefaultNprobe() int {
+	ivfpq.mu.RLock()
+	defer ivfpq.mu.RUnlock()
+	return ivfpq.defaultNprobe
+}
+
+// measureRecall returns the fraction of ground truth neighbors found
+// across all queries at the given nprobe
+func (ivfpq *IVFPQ) measureRecall(queries [][]float32, groundTruth [][]int, nprobe int) (float32, error) {
+	found, total := 0, 0
+	for i, query := range queries {
+		truth := groundTruth[i]
+		if len(truth) == 0 {
+			continue
+		}
+
+		ids, _, err := ivfpq.Search(query, len(truth), nprobe)
+		if err != nil {
+			return 0, fmt.Errorf("validation query %d: %w", i, err)
+		}
+
+		returned := make(map[int]bool, len(ids))
+		for _, id := range ids {
+			returned[id] = true
+		}
+		for _, id := range truth {
+			if returned[id] {
+				found++
+			}
+		}
+		total += len(truth)
+	}
+
+	if total == 0 {
+		return 0, fmt.Errorf("ground truth is empty")
+	}
+	return float32(found) / float32(total), nil
+}
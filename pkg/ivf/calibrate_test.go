@@ -0,0 +1,128 @@
+package ivf
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+)
+
+// exactNeighbors returns the k nearest IDs (indexes into vectors) by Euclidean distance
+func exactNeighbors(vectors [][]float32, query []float32, k int) []int {
+	ids := make([]int, len(vectors))
+	dists := make([]float32, len(vectors))
+	for i, vec := range vectors {
+		ids[i] = i
+		dists[i] = quantization.EuclideanDistanceFloat32(query, vec)
+	}
+	sort.Slice(ids, func(a, b int) bool { return dists[ids[a]] < dists[ids[b]] })
+	return ids[:k]
+}
+
+func TestIVFPQ_Calibrate(t *testing.T) {
+	config := ConfigPQ{
+		NumCentroids:  32,
+		NumSubvectors: 8,
+		BitsPerCode:   8,
+		Metric:        quantization.EuclideanDistance,
+	}
+
+	ivfpq := NewIVFPQ(config)
+	vectors := generateRandomVectors(2000, 32)
+	if err := ivfpq.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	ivfpq.Add(vectors, ids, nil)
+
+	if _, _, err := ivfpq.Search(vectors[0], 10, 0); err != nil {
+		t.Fatalf("Search with the default nprobe failed: %v", err)
+	}
+
+	queries := make([][]float32, 50)
+	groundTruth := make([][]int, len(queries))
+	for i := range queries {
+		queries[i] = vectors[i*37]
+		groundTruth[i] = exactNeighbors(vectors, queries[i], 10)
+	}
+
+	const target = 0.5
+	nprobe, err := ivfpq.Calibrate(queries, groundTruth, target)
+	if err != nil {
+		t.Fatalf("Calibrate failed: %v", err)
+	}
+	if nprobe < 1 || nprobe > config.NumCentroids {
+		t.Fatalf("Calibrated nprobe %d out of range", nprobe)
+	}
+	if ivfpq.DefaultNprobe() != nprobe {
+		t.Errorf("Expected default nprobe %d, got %d", nprobe, ivfpq.DefaultNprobe())
+	}
+
+	recall, err := ivfpq.measureRecall(queries, groundTruth, nprobe)
+	if err != nil {
+		t.Fatalf("measureRecall failed: %v", err)
+	}
+	if recall < target {
+		t.Errorf("Recall %.3f at calibrated nprobe %d is below target %.2f", recall, nprobe, target)
+	}
+	if nprobe > 1 {
+		below, _ := ivfpq.measureRecall(queries, groundTruth, nprobe-1)
+		if below >= target {
+			t.Errorf("nprobe %d already meets the target (recall %.3f); expected the smallest", nprobe-1, below)
+		}
+	}
+	t.Logf("Calibrated nprobe %d for recall %.3f", nprobe, recall)
+
+	// Parameterless search uses the calibrated nprobe
+	wantIDs, _, _ := ivfpq.Search(queries[0], 10, nprobe)
+	gotIDs, _, _ := ivfpq.Search(queries[0], 10, 0)
+	if len(gotIDs) != len(wantIDs) {
+		t.Fatalf("Expected %d results, got %d", len(wantIDs), len(gotIDs))
+	}
+	for i := range wantIDs {
+		if gotIDs[i] != wantIDs[i] {
+			t.Errorf("Rank %d: default search returned %d, expected %d", i, gotIDs[i], wantIDs[i])
+		}
+	}
+}
+
+func TestIVFPQ_CalibrateErrors(t *testing.T) {
+	config := ConfigPQ{
+		NumCentroids:  8,
+		NumSubvectors: 4,
+		BitsPerCode:   4,
+		Metric:        quantization.EuclideanDistance,
+	}
+
+	ivfpq := NewIVFPQ(config)
+	vectors := generateRandomVectors(200, 16)
+	queries := vectors[:5]
+	groundTruth := [][]int{{-1}, {-1}, {-1}, {-1}, {-1}} // IDs that are never returned
+
+	if _, err := ivfpq.Calibrate(queries, groundTruth, 0.9); err == nil {
+		t.Error("Expected an untrained index to fail calibration")
+	}
+
+	ivfpq.Train(vectors)
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	ivfpq.Add(vectors, ids, nil)
+
+	if _, err := ivfpq.Calibrate(queries, groundTruth, 0.9); err == nil {
+		t.Error("Expected an unreachable target to fail")
+	}
+	if ivfpq.DefaultNprobe() != 1 {
+		t.Errorf("Expected a failed calibration to keep the default nprobe, got %d", ivfpq.DefaultNprobe())
+	}
+	if _, err := ivfpq.Calibrate(queries, groundTruth[:2], 0.9); err == nil {
+		t.Error("Expected a ground truth length mismatch to fail")
+	}
+	if _, err := ivfpq.Calibrate(queries, groundTruth, 1.5); err == nil {
+		t.Error("Expected a target recall above 1 to fail")
+	}
+}
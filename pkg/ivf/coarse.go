@@ -0,0 +1,62 @@
+package ivf
+
+import (
+	"fmt"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+)
+
+// DefaultCoarseEfSearch is the default efSearch used when querying the coarse index
+const DefaultCoarseEfSearch = 64
+
+// coarseIndex is an auxiliary HNSW graph over the IVF centroids.
+//
+// Assigning a vector to its nearest centroid is an O(numCentroids·dim) scan,
+// which dominates Add and residual computation once numCentroids reaches the
+// thousands. The coarse index turns that into a graph search at the cost of
+// approximate assignment.
+type coarseIndex struct {
+	index    *hnsw.Index
+	efSearch int
+}
+
+// newCoarseIndex builds an HNSW graph over centroids.
+// HNSW assigns sequential IDs from 0, so node IDs are centroid indices.
+func newCoarseIndex(centroids [][]float32, metric quantization.DistanceMetric, efSearch int) (*coarseIndex, error) {
+	if efSearch <= 0 {
+		efSearch = DefaultCoarseEfSearch
+	}
+
+	config := hnsw.DefaultConfig()
+	switch metric {
+	case quantization.CosineDistance:
+		config.DistanceFunc = hnsw.CosineSimilarity
+	case quantization.DotProductDistance:
+		config.DistanceFunc = hnsw.DotProduct
+	default:
+		config.DistanceFunc = hnsw.EuclideanDistance
+	}
+
+	index := hnsw.New(config)
+	for i, centroid := range centroids {
+		id, err := index.Insert(centroid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to index centroid %d: %w", i, err)
+		}
+		if id != uint64(i) {
+			return nil, fmt.Errorf("unexpected coarse index ID %d for centroid %d", id, i)
+		}
+	}
+
+	return &coarseIndex{index: index, efSearch: efSearch}, nil
+}
+
+// nearest returns the index of the (approximately) nearest centroid
+func (c *coarseIndex) nearest(vec []float32) (int, bool) {
+	result, err := c.index.Search(vec, 1, c.efSearch)
+	if err != nil || len(result.Results) == 0 {
+		return 0, false
+	}
+	return int(result.Results[0].ID), true
+}
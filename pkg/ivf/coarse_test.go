@@ -0,0 +1,182 @@
+package ivf
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+)
+
+// newIVFPQWithCentroids builds an IVFPQ with random centroids and a trained PQ,
+// skipping k-means so large centroid counts are cheap to set up
+func newIVFPQWithCentroids(tb testing.TB, centroids [][]float32, coarse bool) *IVFPQ {
+	numCentroids, dim := len(centroids), len(centroids[0])
+	ivfpq := NewIVFPQ(ConfigPQ{
+		NumCentroids:  numCentroids,
+		NumSubvectors: 8,
+		BitsPerCode:   8,
+		Metric:        quantization.EuclideanDistance,
+		CoarseIndex:   coarse,
+	})
+
+	ivfpq.dim = dim
+	ivfpq.centroids = centroids
+	ivfpq.trained = true
+
+	if err := ivfpq.pq.Train(generateRandomVectors(1000, dim)); err != nil {
+		tb.Fatalf("PQ training failed: %v", err)
+	}
+	ivfpq.pqTrained = true
+
+	if coarse {
+		if err := ivfpq.buildCoarseIndex(); err != nil {
+			tb.Fatalf("Coarse index build failed: %v", err)
+		}
+	}
+
+	return ivfpq
+}
+
+func TestIVFPQ_CoarseIndexMatchesExact(t *testing.T) {
+	ivfpq := newIVFPQWithCentroids(t, generateRandomVectors(1000, 32), true)
+	queries := generateRandomVectors(500, 32)
+
+	matches := 0
+	for _, q := range queries {
+		if ivfpq.findNearestCentroid(q) == ivfpq.findNearestCentroidExact(q) {
+			matches++
+		}
+	}
+
+	rate := float64(matches) / float64(len(queries))
+	t.Logf("Coarse assignment agreement: %.2f%%", rate*100)
+	if rate < 0.99 {
+		t.Errorf("Coarse assignment agreement %.2f%% below 99%%", rate*100)
+	}
+}
+
+func TestIVFPQ_CoarseIndexTrain(t *testing.T) {
+	vectors := generateRandomVectors(1000, 64)
+
+	exact := NewIVFPQ(ConfigPQ{
+		NumCentroids:  20,
+		NumSubvectors: 8,
+		BitsPerCode:   8,
+		Metric:        quantization.EuclideanDistance,
+	})
+	if err := exact.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	if exact.coarse != nil {
+		t.Error("Exact mode should not build a coarse index")
+	}
+
+	coarse := NewIVFPQ(ConfigPQ{
+		NumCentroids:  20,
+		NumSubvectors: 8,
+		BitsPerCode:   8,
+		Metric:        quantization.EuclideanDistance,
+		CoarseIndex:   true,
+	})
+	if err := coarse.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	if coarse.coarse == nil {
+		t.Fatal("Expected coarse index to be built")
+	}
+
+	// With few centroids the graph search is exhaustive, so assignment is exact
+	for i, vec := range vectors[:200] {
+		if got, want := coarse.findNearestCentroid(vec), coarse.findNearestCentroidExact(vec); got != want {
+			t.Errorf("Vector %d: coarse assignment %d, exact %d", i, got, want)
+		}
+	}
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	if err := coarse.Add(vectors, ids, nil); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if total := coarse.GetStats()["total_entries"].(int); total != len(vectors) {
+		t.Errorf("Expected %d entries, got %d", len(vectors), total)
+	}
+}
+
+func TestIVFFlat_CoarseIndex(t *testing.T) {
+	ivf := NewIVFFlat(Config{
+		NumCentroids: 20,
+		Metric:       quantization.EuclideanDistance,
+		CoarseIndex:  true,
+	})
+	vectors := generateRandomVectors(500, 32)
+
+	if err := ivf.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	if ivf.coarse == nil {
+		t.Fatal("Expected coarse index to be built")
+	}
+
+	for i, vec := range vectors {
+		if got, want := ivf.findNearestCentroid(vec), ivf.findNearestCentroidExact(vec); got != want {
+			t.Errorf("Vector %d: coarse assignment %d, exact %d", i, got, want)
+		}
+	}
+}
+
+func BenchmarkIVFPQ_AddCoarse(b *testing.B) {
+	const numCentroids = 10000
+	const dim = 128
+
+	// Real centroids follow the data distribution, so draw both from clusters
+	centroids := generateClusteredVectors(numCentroids, dim, 100)
+	vectors := generateClusteredVectors(1000, dim, 100)
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+
+	for _, mode := range []struct {
+		name   string
+		coarse bool
+	}{
+		{"exact", false},
+		{"coarse", true},
+	} {
+		b.Run(mode.name, func(b *testing.B) {
+			ivfpq := newIVFPQWithCentroids(b, centroids, mode.coarse)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ivfpq.invertedLists = make([][]IVFPQEntry, numCentroids)
+				if err := ivfpq.Add(vectors, ids, nil); err != nil {
+					b.Fatalf("Add failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// generateClusteredVectors draws vectors around a fixed set of cluster centers
+func generateClusteredVectors(n, dim, numClusters int) [][]float32 {
+	rng := rand.New(rand.NewSource(42))
+	centers := make([][]float32, numClusters)
+	for i := range centers {
+		centers[i] = make([]float32, dim)
+		for j := range centers[i] {
+			centers[i][j] = rng.Float32() * 10
+		}
+	}
+
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		center := centers[rng.Intn(numClusters)]
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = center[j] + float32(rng.NormFloat64())
+		}
+	}
+	return vectors
+}
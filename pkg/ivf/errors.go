@@ -0,0 +1,54 @@
+package ivf
+
+import "fmt"
+
+// Search parameters a SearchError can point at
+const (
+	ParamQuery  = "query"  // The query vector's dimension
+	ParamK      = "k"      // The number of results
+	ParamNprobe = "nprobe" // The number of partitions probed
+)
+
+// SearchError reports a search the index refused: the index is not
+// trained, the query has the wrong dimension, or k or nprobe is out of
+// range. Match it with errors.As to tell a bad request from an index that
+// is not ready.
+type SearchError struct {
+	Param    string // ParamQuery, ParamK or ParamNprobe ("" when the index is not trained)
+	Value    int    // The query's dimension, or the rejected k or nprobe
+	Expected int    // Dimension the index was trained on (0 while untrained)
+	Trained  bool   // Whether the index has been trained
+}
+
+// Error describes the refused search with the expected and actual values
+func (e *SearchError) Error() string {
+	switch {
+	case !e.Trained:
+		return fmt.Sprintf("IVF-PQ index not trained, call Train() first (query has %d dimensions)", e.Value)
+	case e.Param == ParamQuery:
+		return fmt.Sprintf("query dimension mismatch: IVF-PQ index expects %d dimensions, got %d", e.Expected, e.Value)
+	case e.Param == ParamK:
+		return fmt.Sprintf("invalid k: %d (must be > 0)", e.Value)
+	default:
+		return fmt.Sprintf("invalid nprobe: %d (must be > 0, or 0 for the default)", e.Value)
+	}
+}
+
+// checkSearch validates a search against the index; the caller holds
+// ivfpq.mu
+func (ivfpq *IVFPQ) checkSearch(query []float32, k, nprobe int) error {
+	err := &SearchError{Value: len(query), Expected: ivfpq.dim, Trained: ivfpq.trained}
+	switch {
+	case !ivfpq.trained:
+		err.Expected = 0
+	case len(query) != ivfpq.dim:
+		err.Param = ParamQuery
+	case k <= 0:
+		err.Param, err.Value = ParamK, k
+	case nprobe < 0:
+		err.Param, err.Value = ParamNprobe, nprobe
+	default:
+		return nil
+	}
+	return err
+}
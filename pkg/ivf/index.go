@@ -34,6 +34,15 @@ type IVFFlat struct {
 	metric       quantization.DistanceMetric
 	mu           sync.RWMutex
 	trained      bool
+
+	useCoarseIndex bool         // Assign vectors via the coarse index instead of an exact scan
+	coarseEfSearch int          // efSearch for coarse index queries
+	coarse         *coarseIndex // HNSW over centroids (nil = exact scan)
+
+	locations        map[int]int // Vector ID -> inverted list holding it
+	positions        map[int]int // Vector ID -> slot in vectors/ids
+	tombstones       []int       // Removed entries awaiting compaction, per inverted list
+	compactThreshold float64     // Tombstoned fraction of a list that triggers its compaction
 }
 
 // IVFEntry represents an entry in an inverted list
@@ -41,6 +50,8 @@ type IVFEntry struct {
 	ID       int       // Vector ID
 	Vector   []float32 // Original vector
 	Metadata map[string]interface{} // Metadata for filtering
+
+	deleted bool // Tombstoned by Remove; dropped when the list is compacted
 }
 
 // Config holds IVF configuration
@@ -48,6 +59,16 @@ type Config struct {
 	NumCentroids int // Number of clusters (typical: sqrt(N) to N/100)
 	Metric       quantization.DistanceMetric
 	TrainConfig  *quantization.QuantizationConfig
+
+	// CoarseIndex builds an HNSW graph over the centroids to speed up
+	// nearest-centroid assignment in Add. Assignment becomes approximate.
+	CoarseIndex    bool
+	CoarseEfSearch int // efSearch for the coarse index (default: 64)
+
+	// CompactThreshold is the fraction of an inverted list's entries that
+	// Remove may tombstone before the list is compacted. 0 compacts on every
+	// Remove; higher values batch the cost of rewriting lists.
+	CompactThreshold float64
 }
 
 // NewIVFFlat creates a new IVF-Flat index
@@ -62,6 +83,12 @@ func NewIVFFlat(config Config) *IVFFlat {
 		invertedLists: make([][]IVFEntry, config.NumCentroids),
 		vectors:      make([][]float32, 0),
 		ids:          make([]int, 0),
+		useCoarseIndex: config.CoarseIndex,
+		coarseEfSearch: config.CoarseEfSearch,
+		locations:        make(map[int]int),
+		positions:        make(map[int]int),
+		tombstones:       make([]int, config.NumCentroids),
+		compactThreshold: config.CompactThreshold,
 	}
 }
 
@@ -94,6 +121,14 @@ func (ivf *IVFFlat) Train(vectors [][]float32) error {
 	ivf.centroids = centroids
 	ivf.trained = true
 
+	if ivf.useCoarseIndex {
+		coarse, err := newCoarseIndex(ivf.centroids, ivf.metric, ivf.coarseEfSearch)
+		if err != nil {
+			return fmt.Errorf("coarse index build failed: %w", err)
+		}
+		ivf.coarse = coarse
+	}
+
 	fmt.Printf("IVF-Flat trained with %d centroids\n", ivf.numCentroids)
 	return nil
 }
@@ -119,6 +154,9 @@ func (ivf *IVFFlat) Add(vectors [][]float32, ids []int, metadata []map[string]in
 		if len(vec) != ivf.dim {
 			return fmt.Errorf("vector dimension mismatch: expected %d, got %d", ivf.dim, len(vec))
 		}
+		if _, exists := ivf.locations[ids[i]]; exists {
+			return fmt.Errorf("vector %d already exists", ids[i])
+		}
 
 		// Find nearest centroid
 		centroidIdx := ivf.findNearestCentroid(vec)
@@ -133,6 +171,8 @@ func (ivf *IVFFlat) Add(vectors [][]float32, ids []int, metadata []map[string]in
 		}
 
 		ivf.invertedLists[centroidIdx] = append(ivf.invertedLists[centroidIdx], entry)
+		ivf.locations[ids[i]] = centroidIdx
+		ivf.positions[ids[i]] = len(ivf.ids)
 		ivf.vectors = append(ivf.vectors, vec)
 		ivf.ids = append(ivf.ids, ids[i])
 	}
@@ -140,6 +180,70 @@ func (ivf *IVFFlat) Add(vectors [][]float32, ids []int, metadata []map[string]in
 	return nil
 }
 
+// Remove deletes a vector from the index. Its entry is tombstoned and
+// skipped by searches; once tombstones exceed CompactThreshold of the
+// inverted list, the list is rewritten without them.
+func (ivf *IVFFlat) Remove(id int) error {
+	ivf.mu.Lock()
+	defer ivf.mu.Unlock()
+
+	centroidIdx, ok := ivf.locations[id]
+	if !ok {
+		return fmt.Errorf("vector %d not found", id)
+	}
+
+	list := ivf.invertedLists[centroidIdx]
+	for i := range list {
+		if list[i].ID == id && !list[i].deleted {
+			list[i].deleted = true
+			break
+		}
+	}
+	delete(ivf.locations, id)
+
+	// Swap the last vector into the freed slot
+	pos := ivf.positions[id]
+	last := len(ivf.ids) - 1
+	ivf.vectors[pos] = ivf.vectors[last]
+	ivf.ids[pos] = ivf.ids[last]
+	ivf.positions[ivf.ids[pos]] = pos
+	ivf.vectors = ivf.vectors[:last]
+	ivf.ids = ivf.ids[:last]
+	delete(ivf.positions, id)
+
+	ivf.tombstones[centroidIdx]++
+	if float64(ivf.tombstones[centroidIdx]) > ivf.compactThreshold*float64(len(list)) {
+		ivf.compactList(centroidIdx)
+	}
+
+	return nil
+}
+
+// Compact drops every tombstoned entry, regardless of CompactThreshold
+func (ivf *IVFFlat) Compact() {
+	ivf.mu.Lock()
+	defer ivf.mu.Unlock()
+
+	for centroidIdx, count := range ivf.tombstones {
+		if count > 0 {
+			ivf.compactList(centroidIdx)
+		}
+	}
+}
+
+// compactList rewrites one inverted list without its tombstoned entries
+func (ivf *IVFFlat) compactList(centroidIdx int) {
+	list := ivf.invertedLists[centroidIdx]
+	live := make([]IVFEntry, 0, len(list)-ivf.tombstones[centroidIdx])
+	for _, entry := range list {
+		if !entry.deleted {
+			live = append(live, entry)
+		}
+	}
+	ivf.invertedLists[centroidIdx] = live
+	ivf.tombstones[centroidIdx] = 0
+}
+
 // Search performs nearest neighbor search
 func (ivf *IVFFlat) Search(query []float32, k int, nprobe int) ([]int, []float32, error) {
 	ivf.mu.RLock()
@@ -167,14 +271,20 @@ func (ivf *IVFFlat) Search(query []float32, k int, nprobe int) ([]int, []float32
 	for _, centroidID := range centroidIDs {
 		// Search all vectors in this inverted list
 		for _, entry := range ivf.invertedLists[centroidID] {
+			if entry.deleted {
+				continue
+			}
 			dist := ivf.computeDistance(query, entry.Vector)
 			results = append(results, result{id: entry.ID, dist: dist})
 		}
 	}
 
-	// Step 3: Sort by distance and return top-k
+	// Step 3: Sort by distance, ties by ID, and return top-k
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].dist < results[j].dist
+		if results[i].dist != results[j].dist {
+			return results[i].dist < results[j].dist
+		}
+		return results[i].id < results[j].id
 	})
 
 	if len(results) > k {
@@ -214,6 +324,10 @@ func (ivf *IVFFlat) SearchWithFilter(query []float32, k int, nprobe int, filter
 	// Search with filter
 	for _, centroidID := range centroidIDs {
 		for _, entry := range ivf.invertedLists[centroidID] {
+			if entry.deleted {
+				continue
+			}
+
 			// Apply filter
 			if filter != nil && !filter(entry.Metadata) {
 				continue
@@ -224,9 +338,12 @@ func (ivf *IVFFlat) SearchWithFilter(query []float32, k int, nprobe int, filter
 		}
 	}
 
-	// Sort and return top-k
+	// Sort, ties by ID, and return top-k
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].dist < results[j].dist
+		if results[i].dist != results[j].dist {
+			return results[i].dist < results[j].dist
+		}
+		return results[i].id < results[j].id
 	})
 
 	if len(results) > k {
@@ -244,7 +361,18 @@ func (ivf *IVFFlat) SearchWithFilter(query []float32, k int, nprobe int, filter
 }
 
 // findNearestCentroid finds the nearest centroid for a vector
+// Uses the coarse index when enabled, otherwise an exact scan
 func (ivf *IVFFlat) findNearestCentroid(vec []float32) int {
+	if ivf.coarse != nil {
+		if idx, ok := ivf.coarse.nearest(vec); ok {
+			return idx
+		}
+	}
+	return ivf.findNearestCentroidExact(vec)
+}
+
+// findNearestCentroidExact scans all centroids for the nearest one
+func (ivf *IVFFlat) findNearestCentroidExact(vec []float32) int {
 	minDist := float32(math.MaxFloat32)
 	minIdx := 0
 
@@ -275,7 +403,10 @@ func (ivf *IVFFlat) findNearestCentroids(vec []float32, nprobe int) []int {
 	}
 
 	sort.Slice(distances, func(i, j int) bool {
-		return distances[i].dist < distances[j].dist
+		if distances[i].dist != distances[j].dist {
+			return distances[i].dist < distances[j].dist
+		}
+		return distances[i].idx < distances[j].idx
 	})
 
 	if nprobe > len(distances) {
@@ -318,12 +449,15 @@ func (ivf *IVFFlat) GetStats() map[string]interface{} {
 	// Compute inverted list sizes
 	listSizes := make([]int, ivf.numCentroids)
 	totalEntries := 0
+	tombstoned := 0
 	for i, list := range ivf.invertedLists {
-		listSizes[i] = len(list)
-		totalEntries += len(list)
+		listSizes[i] = len(list) - ivf.tombstones[i]
+		totalEntries += listSizes[i]
+		tombstoned += ivf.tombstones[i]
 	}
 
 	stats["total_entries"] = totalEntries
+	stats["tombstoned_entries"] = tombstoned
 	stats["avg_list_size"] = float32(totalEntries) / float32(ivf.numCentroids)
 
 	// Find min/max list sizes
@@ -28,6 +28,18 @@ type IVFPQ struct {
 	mu            sync.RWMutex
 	trained       bool
 	pqTrained     bool
+	generation    int // Bumped whenever Train replaces the centroids and codebooks
+
+	useCoarseIndex bool         // Assign vectors via the coarse index instead of an exact scan
+	coarseEfSearch int          // efSearch for coarse index queries
+	coarse         *coarseIndex // HNSW over centroids (nil = exact scan)
+
+	searchWorkers int // Goroutines scanning probed partitions (<= 1 = sequential)
+	defaultNprobe int // nprobe for searches passed nprobe <= 0 (set by Calibrate)
+
+	locations        map[int]int // Vector ID -> inverted list holding it
+	tombstones       []int       // Removed entries awaiting compaction, per inverted list
+	compactThreshold float64     // Tombstoned fraction of a list that triggers its compaction
 }
 
 // IVFPQEntry represents a compressed entry in an inverted list
@@ -35,6 +47,8 @@ type IVFPQEntry struct {
 	ID       int                    // Vector ID
 	Code     []byte                 // PQ code
 	Metadata map[string]interface{} // Metadata for filtering
+
+	deleted bool // Tombstoned by Remove; dropped when the list is compacted
 }
 
 // ConfigPQ holds IVF-PQ configuration
@@ -44,6 +58,20 @@ type ConfigPQ struct {
 	BitsPerCode    int // PQ parameter
 	Metric         quantization.DistanceMetric
 	TrainConfig    *quantization.QuantizationConfig
+
+	// CoarseIndex builds an HNSW graph over the centroids to speed up
+	// nearest-centroid assignment when NumCentroids is large.
+	// Assignment becomes approximate; leave false for an exact scan.
+	CoarseIndex    bool
+	CoarseEfSearch int // efSearch for the coarse index (default: 64)
+
+	// SearchWorkers scans probed partitions concurrently (0 or 1 = sequential)
+	SearchWorkers int
+
+	// CompactThreshold is the fraction of an inverted list's entries that
+	// Remove may tombstone before the list is compacted. 0 compacts on every
+	// Remove; higher values batch the cost of rewriting lists.
+	CompactThreshold float64
 }
 
 // NewIVFPQ creates a new IVF-PQ index
@@ -57,6 +85,13 @@ func NewIVFPQ(config ConfigPQ) *IVFPQ {
 		metric:        config.Metric,
 		invertedLists: make([][]IVFPQEntry, config.NumCentroids),
 		pq:            quantization.NewProductQuantizerWithConfig(config.NumSubvectors, config.BitsPerCode, config.TrainConfig),
+		useCoarseIndex: config.CoarseIndex,
+		coarseEfSearch: config.CoarseEfSearch,
+		searchWorkers:  config.SearchWorkers,
+		defaultNprobe:  1,
+		locations:        make(map[int]int),
+		tombstones:       make([]int, config.NumCentroids),
+		compactThreshold: config.CompactThreshold,
 	}
 }
 
@@ -91,6 +126,12 @@ func (ivfpq *IVFPQ) Train(vectors [][]float32) error {
 
 	fmt.Printf("IVF clustering complete\n")
 
+	if ivfpq.useCoarseIndex {
+		if err := ivfpq.buildCoarseIndex(); err != nil {
+			return err
+		}
+	}
+
 	// Step 2: Compute residuals (vector - nearest centroid)
 	// Product Quantization is trained on residuals for better accuracy
 	fmt.Printf("Computing residuals for PQ training...\n")
@@ -115,6 +156,7 @@ func (ivfpq *IVFPQ) Train(vectors [][]float32) error {
 	}
 
 	ivfpq.pqTrained = true
+	ivfpq.generation++
 
 	// Print compression statistics
 	codebookMB, perVectorBytes := ivfpq.pq.GetMemoryUsage()
@@ -140,159 +182,247 @@ func (ivfpq *IVFPQ) Add(vectors [][]float32, ids []int, metadata []map[string]in
 	}
 
 	for i, vec := range vectors {
-		if len(vec) != ivfpq.dim {
-			return fmt.Errorf("vector dimension mismatch")
-		}
-
-		// Find nearest centroid
-		centroidIdx := ivfpq.findNearestCentroid(vec)
-		nearestCentroid := ivfpq.centroids[centroidIdx]
-
-		// Compute residual
-		residual := make([]float32, ivfpq.dim)
-		for d := 0; d < ivfpq.dim; d++ {
-			residual[d] = vec[d] - nearestCentroid[d]
+		var meta map[string]interface{}
+		if metadata != nil && i < len(metadata) {
+			meta = metadata[i]
 		}
 
-		// Encode residual with PQ
-		code := ivfpq.pq.Encode(residual)
-
-		// Add to inverted list
-		entry := IVFPQEntry{
-			ID:   ids[i],
-			Code: code,
+		centroidIdx, entry, err := ivfpq.encode(vec, ids[i], meta)
+		if err != nil {
+			return err
 		}
-		if metadata != nil && i < len(metadata) {
-			entry.Metadata = metadata[i]
+		if err := ivfpq.appendLocked(centroidIdx, entry); err != nil {
+			return err
 		}
-
-		ivfpq.invertedLists[centroidIdx] = append(ivfpq.invertedLists[centroidIdx], entry)
 	}
 
 	return nil
 }
 
-// Search performs approximate nearest neighbor search
-func (ivfpq *IVFPQ) Search(query []float32, k int, nprobe int) ([]int, []float32, error) {
+// Insert adds a single vector. It is safe to call from many goroutines:
+// the vector is encoded under the read lock, so concurrent inserts and
+// searches only serialize on the append to its inverted list. A vector
+// encoded before the index was retrained is encoded again.
+func (ivfpq *IVFPQ) Insert(vec []float32, id int, metadata map[string]interface{}) error {
 	ivfpq.mu.RLock()
-	defer ivfpq.mu.RUnlock()
+	centroidIdx, entry, err := ivfpq.encode(vec, id, metadata)
+	generation := ivfpq.generation
+	ivfpq.mu.RUnlock()
+	if err != nil {
+		return err
+	}
 
-	if !ivfpq.trained {
-		return nil, nil, fmt.Errorf("index not trained")
+	ivfpq.mu.Lock()
+	defer ivfpq.mu.Unlock()
+
+	if ivfpq.generation != generation {
+		if centroidIdx, entry, err = ivfpq.encode(vec, id, metadata); err != nil {
+			return err
+		}
 	}
+	return ivfpq.appendLocked(centroidIdx, entry)
+}
 
-	if len(query) != ivfpq.dim {
-		return nil, nil, fmt.Errorf("query dimension mismatch")
+// encode compresses a vector's residual with PQ and picks its inverted
+// list. It only reads the index, so the caller needs at least the read lock.
+func (ivfpq *IVFPQ) encode(vec []float32, id int, metadata map[string]interface{}) (int, IVFPQEntry, error) {
+	if !ivfpq.trained || !ivfpq.pqTrained {
+		return 0, IVFPQEntry{}, fmt.Errorf("index not trained, call Train() first")
+	}
+	if len(vec) != ivfpq.dim {
+		return 0, IVFPQEntry{}, fmt.Errorf("vector dimension mismatch")
 	}
 
-	// Step 1: Find nprobe nearest centroids
-	centroidIDs := ivfpq.findNearestCentroids(query, nprobe)
+	// Find nearest centroid
+	centroidIdx := ivfpq.findNearestCentroid(vec)
+	nearestCentroid := ivfpq.centroids[centroidIdx]
 
-	type result struct {
-		id   int
-		dist float32
+	// Compute residual
+	residual := make([]float32, ivfpq.dim)
+	for d := 0; d < ivfpq.dim; d++ {
+		residual[d] = vec[d] - nearestCentroid[d]
 	}
 
-	results := make([]result, 0, nprobe*100)
+	// Encode residual with PQ
+	return centroidIdx, IVFPQEntry{
+		ID:       id,
+		Code:     ivfpq.pq.Encode(residual),
+		Metadata: metadata,
+	}, nil
+}
+
+// appendLocked adds an encoded entry to its inverted list, rejecting IDs
+// already in the index. The caller must hold the write lock.
+func (ivfpq *IVFPQ) appendLocked(centroidIdx int, entry IVFPQEntry) error {
+	if _, exists := ivfpq.locations[entry.ID]; exists {
+		return fmt.Errorf("vector %d already exists", entry.ID)
+	}
+	ivfpq.invertedLists[centroidIdx] = append(ivfpq.invertedLists[centroidIdx], entry)
+	ivfpq.locations[entry.ID] = centroidIdx
+	return nil
+}
 
-	// Step 2: Search in each probed region using asymmetric distance
-	for _, centroidID := range centroidIDs {
-		centroid := ivfpq.centroids[centroidID]
+// Remove deletes a vector from the index. Its entry is tombstoned and
+// skipped by searches; once tombstones exceed CompactThreshold of the
+// inverted list, the list is rewritten without them.
+func (ivfpq *IVFPQ) Remove(id int) error {
+	ivfpq.mu.Lock()
+	defer ivfpq.mu.Unlock()
 
-		// Compute query residual
-		queryResidual := make([]float32, ivfpq.dim)
-		for d := 0; d < ivfpq.dim; d++ {
-			queryResidual[d] = query[d] - centroid[d]
+	centroidIdx, ok := ivfpq.locations[id]
+	if !ok {
+		return fmt.Errorf("vector %d not found", id)
+	}
+
+	list := ivfpq.invertedLists[centroidIdx]
+	for i := range list {
+		if list[i].ID == id && !list[i].deleted {
+			list[i].deleted = true
+			break
 		}
+	}
+	delete(ivfpq.locations, id)
+
+	ivfpq.tombstones[centroidIdx]++
+	if float64(ivfpq.tombstones[centroidIdx]) > ivfpq.compactThreshold*float64(len(list)) {
+		ivfpq.compactList(centroidIdx)
+	}
+
+	return nil
+}
 
-		// Precompute distance table for asymmetric distance
-		distTable := ivfpq.pq.ComputeDistanceTable(queryResidual)
+// Compact drops every tombstoned entry, regardless of CompactThreshold
+func (ivfpq *IVFPQ) Compact() {
+	ivfpq.mu.Lock()
+	defer ivfpq.mu.Unlock()
 
-		// Compute distance to all vectors in this list
-		for _, entry := range ivfpq.invertedLists[centroidID] {
-			dist := ivfpq.pq.AsymmetricDistance(distTable, entry.Code)
-			results = append(results, result{id: entry.ID, dist: dist})
+	for centroidIdx, count := range ivfpq.tombstones {
+		if count > 0 {
+			ivfpq.compactList(centroidIdx)
 		}
 	}
+}
 
-	// Step 3: Sort by distance and return top-k
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].dist < results[j].dist
-	})
+// compactList rewrites one inverted list without its tombstoned entries
+func (ivfpq *IVFPQ) compactList(centroidIdx int) {
+	list := ivfpq.invertedLists[centroidIdx]
+	live := make([]IVFPQEntry, 0, len(list)-ivfpq.tombstones[centroidIdx])
+	for _, entry := range list {
+		if !entry.deleted {
+			live = append(live, entry)
+		}
+	}
+	ivfpq.invertedLists[centroidIdx] = live
+	ivfpq.tombstones[centroidIdx] = 0
+}
 
-	if len(results) > k {
-		results = results[:k]
+// Search performs approximate nearest neighbor search. nprobe 0 uses
+// DefaultNprobe. An untrained index, a query of the wrong dimension, k <= 0
+// or a negative nprobe is reported as a *SearchError.
+func (ivfpq *IVFPQ) Search(query []float32, k int, nprobe int) ([]int, []float32, error) {
+	ivfpq.mu.RLock()
+	defer ivfpq.mu.RUnlock()
+
+	if err := ivfpq.checkSearch(query, k, nprobe); err != nil {
+		return nil, nil, err
 	}
 
-	ids := make([]int, len(results))
-	distances := make([]float32, len(results))
-	for i, r := range results {
-		ids[i] = r.id
-		distances[i] = r.dist
+	if nprobe == 0 {
+		nprobe = ivfpq.defaultNprobe
 	}
 
+	// Step 1: Find nprobe nearest centroids
+	centroidIDs := ivfpq.findNearestCentroids(query, nprobe)
+
+	// Step 2: Search in each probed region using asymmetric distance,
+	// keeping only the top-k across all regions
+	ids, distances := scanPartitions(centroidIDs, k, ivfpq.searchWorkers, func(centroidID int, top *topKHeap) {
+		ivfpq.scanList(query, centroidID, k, nil, top)
+	})
+
 	return ids, distances, nil
 }
 
-// SearchWithFilter performs filtered search
+// SearchWithFilter performs filtered search, validating its arguments like
+// Search. nprobe 0 uses DefaultNprobe.
 func (ivfpq *IVFPQ) SearchWithFilter(query []float32, k int, nprobe int, filter func(map[string]interface{}) bool) ([]int, []float32, error) {
 	ivfpq.mu.RLock()
 	defer ivfpq.mu.RUnlock()
 
-	if !ivfpq.trained {
-		return nil, nil, fmt.Errorf("index not trained")
+	if err := ivfpq.checkSearch(query, k, nprobe); err != nil {
+		return nil, nil, err
 	}
 
-	centroidIDs := ivfpq.findNearestCentroids(query, nprobe)
-
-	type result struct {
-		id   int
-		dist float32
+	if nprobe == 0 {
+		nprobe = ivfpq.defaultNprobe
 	}
+	centroidIDs := ivfpq.findNearestCentroids(query, nprobe)
 
-	results := make([]result, 0, nprobe*100)
+	ids, distances := scanPartitions(centroidIDs, k, ivfpq.searchWorkers, func(centroidID int, top *topKHeap) {
+		ivfpq.scanList(query, centroidID, k, filter, top)
+	})
 
-	for _, centroidID := range centroidIDs {
-		centroid := ivfpq.centroids[centroidID]
+	return ids, distances, nil
+}
 
-		queryResidual := make([]float32, ivfpq.dim)
-		for d := 0; d < ivfpq.dim; d++ {
-			queryResidual[d] = query[d] - centroid[d]
-		}
+// scanList scores every entry in one inverted list against the query
+func (ivfpq *IVFPQ) scanList(query []float32, centroidID, k int, filter func(map[string]interface{}) bool, top *topKHeap) {
+	centroid := ivfpq.centroids[centroidID]
 
-		distTable := ivfpq.pq.ComputeDistanceTable(queryResidual)
+	// Compute query residual
+	queryResidual := make([]float32, ivfpq.dim)
+	for d := 0; d < ivfpq.dim; d++ {
+		queryResidual[d] = query[d] - centroid[d]
+	}
 
-		for _, entry := range ivfpq.invertedLists[centroidID] {
-			// Apply filter
-			if filter != nil && !filter(entry.Metadata) {
-				continue
-			}
+	// Precompute distance table for asymmetric distance
+	distTable := ivfpq.pq.ComputeDistanceTable(queryResidual)
 
-			dist := ivfpq.pq.AsymmetricDistance(distTable, entry.Code)
-			results = append(results, result{id: entry.ID, dist: dist})
+	for _, entry := range ivfpq.invertedLists[centroidID] {
+		if entry.deleted {
+			continue
 		}
-	}
 
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].dist < results[j].dist
-	})
+		// Apply filter
+		if filter != nil && !filter(entry.Metadata) {
+			continue
+		}
 
-	if len(results) > k {
-		results = results[:k]
+		dist := ivfpq.pq.AsymmetricDistance(distTable, entry.Code)
+		top.offer(scoredID{id: entry.ID, dist: dist}, k)
 	}
+}
 
-	ids := make([]int, len(results))
-	distances := make([]float32, len(results))
-	for i, r := range results {
-		ids[i] = r.id
-		distances[i] = r.dist
-	}
+// SetSearchWorkers sets how many goroutines scan probed partitions (1 = sequential)
+func (ivfpq *IVFPQ) SetSearchWorkers(workers int) {
+	ivfpq.mu.Lock()
+	defer ivfpq.mu.Unlock()
+	ivfpq.searchWorkers = workers
+}
 
-	return ids, distances, nil
+// buildCoarseIndex builds the HNSW graph over the current centroids
+func (ivfpq *IVFPQ) buildCoarseIndex() error {
+	coarse, err := newCoarseIndex(ivfpq.centroids, ivfpq.metric, ivfpq.coarseEfSearch)
+	if err != nil {
+		return fmt.Errorf("coarse index build failed: %w", err)
+	}
+	ivfpq.coarse = coarse
+	return nil
 }
 
 // findNearestCentroid finds the nearest centroid for a vector
+// Uses the coarse index when enabled, otherwise an exact scan
 func (ivfpq *IVFPQ) findNearestCentroid(vec []float32) int {
+	if ivfpq.coarse != nil {
+		if idx, ok := ivfpq.coarse.nearest(vec); ok {
+			return idx
+		}
+	}
+	return ivfpq.findNearestCentroidExact(vec)
+}
+
+// findNearestCentroidExact scans all centroids for the nearest one
+func (ivfpq *IVFPQ) findNearestCentroidExact(vec []float32) int {
 	minDist := float32(math.MaxFloat32)
 	minIdx := 0
 
@@ -323,7 +453,10 @@ func (ivfpq *IVFPQ) findNearestCentroids(vec []float32, nprobe int) []int {
 	}
 
 	sort.Slice(distances, func(i, j int) bool {
-		return distances[i].dist < distances[j].dist
+		if distances[i].dist != distances[j].dist {
+			return distances[i].dist < distances[j].dist
+		}
+		return distances[i].idx < distances[j].idx
 	})
 
 	if nprobe > len(distances) {
@@ -352,6 +485,37 @@ func (ivfpq *IVFPQ) computeDistance(a, b []float32) float32 {
 	}
 }
 
+// Size returns the number of vectors in the index, excluding removed ones
+func (ivfpq *IVFPQ) Size() int {
+	ivfpq.mu.RLock()
+	defer ivfpq.mu.RUnlock()
+	return len(ivfpq.locations)
+}
+
+// Range calls fn for each vector in the index until fn returns false. PQ
+// keeps only compressed codes, so each vector is reconstructed from its
+// centroid and code and only approximates the vector that was added. The
+// read lock is held throughout, so fn must not modify the index.
+func (ivfpq *IVFPQ) Range(fn func(id int, vector []float32, metadata map[string]interface{}) bool) {
+	ivfpq.mu.RLock()
+	defer ivfpq.mu.RUnlock()
+
+	for centroidIdx, list := range ivfpq.invertedLists {
+		for _, entry := range list {
+			if entry.deleted {
+				continue
+			}
+			vector := ivfpq.pq.Decode(entry.Code)
+			for d, v := range ivfpq.centroids[centroidIdx] {
+				vector[d] += v
+			}
+			if !fn(entry.ID, vector, entry.Metadata) {
+				return
+			}
+		}
+	}
+}
+
 // GetStats returns index statistics
 func (ivfpq *IVFPQ) GetStats() map[string]interface{} {
 	ivfpq.mu.RLock()
@@ -361,14 +525,16 @@ func (ivfpq *IVFPQ) GetStats() map[string]interface{} {
 	stats["num_centroids"] = ivfpq.numCentroids
 	stats["dimension"] = ivfpq.dim
 	stats["trained"] = ivfpq.trained
+	stats["coarse_index"] = ivfpq.coarse != nil
 
 	// Count total entries
-	totalEntries := 0
-	for _, list := range ivfpq.invertedLists {
-		totalEntries += len(list)
+	tombstoned := 0
+	for _, count := range ivfpq.tombstones {
+		tombstoned += count
 	}
 
-	stats["total_entries"] = totalEntries
+	stats["total_entries"] = len(ivfpq.locations)
+	stats["tombstoned_entries"] = tombstoned
 	stats["compression_ratio"] = ivfpq.pq.GetCompressionRatio(ivfpq.dim)
 
 	codebookBytes, perVectorBytes := ivfpq.pq.GetMemoryUsage()
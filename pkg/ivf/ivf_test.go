@@ -1,7 +1,10 @@
 package ivf
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
@@ -99,6 +102,40 @@ func TestIVFFlat_Search(t *testing.T) {
 	}
 }
 
+func TestIVFFlat_SearchTieBreaksByID(t *testing.T) {
+	config := Config{
+		NumCentroids: 4,
+		Metric:       quantization.EuclideanDistance,
+	}
+
+	ivf := NewIVFFlat(config)
+	vectors := generateRandomVectors(200, 8)
+	ivf.Train(vectors)
+
+	// Add eight copies of one vector with IDs in descending order
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	for i := 0; i < 8; i++ {
+		vectors = append(vectors, vectors[0])
+		ids = append(ids, 1000-i)
+	}
+	ivf.Add(vectors, ids, nil)
+
+	resultIDs, distances, err := ivf.Search(vectors[0], 9, 4)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	expected := []int{0, 993, 994, 995, 996, 997, 998, 999, 1000}
+	for i, id := range expected {
+		if resultIDs[i] != id || distances[i] != 0 {
+			t.Fatalf("Expected IDs %v at distance 0, got %v (distances %v)", expected, resultIDs, distances)
+		}
+	}
+}
+
 func TestIVFFlat_SearchWithFilter(t *testing.T) {
 	config := Config{
 		NumCentroids: 20,
@@ -244,6 +281,202 @@ func TestIVFPQ_Search(t *testing.T) {
 	t.Logf("Search returned %d results, first distance: %f", len(resultIDs), distances[0])
 }
 
+func TestIVFPQ_SearchErrors(t *testing.T) {
+	ivfpq := NewIVFPQ(ConfigPQ{
+		NumCentroids:  4,
+		NumSubvectors: 4,
+		BitsPerCode:   4,
+		Metric:        quantization.EuclideanDistance,
+	})
+	vectors := generateRandomVectors(200, 16)
+
+	var searchErr *SearchError
+	_, _, err := ivfpq.Search(vectors[0], 10, 0)
+	if !errors.As(err, &searchErr) || searchErr.Trained || searchErr.Value != 16 {
+		t.Fatalf("Expected an untrained SearchError, got %v", err)
+	}
+
+	if err := ivfpq.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    []float32
+		k        int
+		nprobe   int
+		param    string
+		value    int
+		contains string
+	}{
+		{"short query", vectors[0][:8], 10, 0, ParamQuery, 8, "expects 16 dimensions, got 8"},
+		{"zero k", vectors[0], 0, 0, ParamK, 0, "invalid k: 0"},
+		{"negative nprobe", vectors[0], 10, -1, ParamNprobe, -1, "invalid nprobe: -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, search := range []func() error{
+				func() error { _, _, err := ivfpq.Search(tt.query, tt.k, tt.nprobe); return err },
+				func() error { _, _, err := ivfpq.SearchWithFilter(tt.query, tt.k, tt.nprobe, nil); return err },
+			} {
+				err := search()
+				if !errors.As(err, &searchErr) {
+					t.Fatalf("Expected a SearchError, got %v", err)
+				}
+				if searchErr.Param != tt.param || searchErr.Value != tt.value || !searchErr.Trained || searchErr.Expected != 16 {
+					t.Errorf("Unexpected SearchError: %+v", searchErr)
+				}
+				if !strings.Contains(err.Error(), tt.contains) {
+					t.Errorf("Expected %q in %q", tt.contains, err.Error())
+				}
+			}
+		})
+	}
+
+	// nprobe 0 uses the default
+	if _, _, err := ivfpq.Search(vectors[0], 10, 0); err != nil {
+		t.Errorf("Expected nprobe 0 to search, got %v", err)
+	}
+}
+
+func TestIVFPQ_ConcurrentInsert(t *testing.T) {
+	config := ConfigPQ{
+		NumCentroids:  10,
+		NumSubvectors: 8,
+		BitsPerCode:   8,
+		Metric:        quantization.EuclideanDistance,
+	}
+
+	ivfpq := NewIVFPQ(config)
+	vectors := generateRandomVectors(800, 64)
+
+	if err := ivfpq.Insert(vectors[0], 0, nil); err == nil {
+		t.Error("Expected Insert before Train to fail")
+	}
+	ivfpq.Train(vectors)
+
+	// Insert from many goroutines while others search
+	numGoroutines := 8
+	done := make(chan bool)
+	for g := 0; g < numGoroutines; g++ {
+		go func(g int) {
+			for i := g; i < len(vectors); i += numGoroutines {
+				meta := map[string]interface{}{"writer": g}
+				if err := ivfpq.Insert(vectors[i], i, meta); err != nil {
+					t.Errorf("Concurrent insert failed: %v", err)
+				}
+				if i%10 == 0 {
+					if _, _, err := ivfpq.Search(vectors[i], 5, 2); err != nil {
+						t.Errorf("Concurrent search failed: %v", err)
+					}
+				}
+			}
+			done <- true
+		}(g)
+	}
+	for g := 0; g < numGoroutines; g++ {
+		<-done
+	}
+
+	if total := ivfpq.GetStats()["total_entries"].(int); total != len(vectors) {
+		t.Fatalf("Expected %d entries, got %d", len(vectors), total)
+	}
+
+	// Every vector is searchable. PQ distances are approximate, so a vector
+	// need not rank first for its own query.
+	found := 0
+	for i, vec := range vectors {
+		ids, _, err := ivfpq.Search(vec, 10, config.NumCentroids)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		for _, id := range ids {
+			if id == i {
+				found++
+				break
+			}
+		}
+	}
+	if recall := float64(found) / float64(len(vectors)); recall < 0.9 {
+		t.Errorf("Expected inserted vectors to find themselves, recall@10 = %.2f", recall)
+	}
+
+	if err := ivfpq.Insert(vectors[0], 0, nil); err == nil {
+		t.Error("Expected Insert of an existing ID to fail")
+	}
+}
+
+func TestIVFPQ_ParallelSearchMatchesSequential(t *testing.T) {
+	config := ConfigPQ{
+		NumCentroids:  32,
+		NumSubvectors: 8,
+		BitsPerCode:   8,
+		Metric:        quantization.EuclideanDistance,
+	}
+
+	ivfpq := NewIVFPQ(config)
+	vectors := generateRandomVectors(2000, 64)
+
+	if err := ivfpq.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+
+	ids := make([]int, len(vectors))
+	metadata := make([]map[string]interface{}, len(vectors))
+	for i := range ids {
+		ids[i] = i
+		metadata[i] = map[string]interface{}{"even": i%2 == 0}
+	}
+	ivfpq.Add(vectors, ids, metadata)
+
+	evenOnly := func(m map[string]interface{}) bool { return m["even"].(bool) }
+
+	for q := 0; q < 20; q++ {
+		query := vectors[q*100]
+
+		ivfpq.SetSearchWorkers(1)
+		seqIDs, seqDists, err := ivfpq.Search(query, 10, 16)
+		if err != nil {
+			t.Fatalf("Sequential search failed: %v", err)
+		}
+		seqFilteredIDs, _, _ := ivfpq.SearchWithFilter(query, 10, 16, evenOnly)
+
+		ivfpq.SetSearchWorkers(8)
+		parIDs, parDists, err := ivfpq.Search(query, 10, 16)
+		if err != nil {
+			t.Fatalf("Parallel search failed: %v", err)
+		}
+		parFilteredIDs, _, _ := ivfpq.SearchWithFilter(query, 10, 16, evenOnly)
+
+		if len(seqIDs) != 10 || len(parIDs) != len(seqIDs) {
+			t.Fatalf("Query %d: expected 10 results, got %d sequential and %d parallel", q, len(seqIDs), len(parIDs))
+		}
+		for i := range seqIDs {
+			if seqIDs[i] != parIDs[i] || seqDists[i] != parDists[i] {
+				t.Errorf("Query %d rank %d: sequential (%d, %f) != parallel (%d, %f)",
+					q, i, seqIDs[i], seqDists[i], parIDs[i], parDists[i])
+			}
+		}
+		for i := 1; i < len(seqDists); i++ {
+			if seqDists[i] < seqDists[i-1] {
+				t.Errorf("Query %d: results not sorted by distance", q)
+			}
+		}
+
+		if len(seqFilteredIDs) != len(parFilteredIDs) {
+			t.Fatalf("Query %d: filtered result count mismatch", q)
+		}
+		for i := range seqFilteredIDs {
+			if seqFilteredIDs[i] != parFilteredIDs[i] {
+				t.Errorf("Query %d filtered rank %d: sequential %d != parallel %d", q, i, seqFilteredIDs[i], parFilteredIDs[i])
+			}
+			if seqFilteredIDs[i]%2 != 0 {
+				t.Errorf("Filtered result %d should be even", seqFilteredIDs[i])
+			}
+		}
+	}
+}
+
 func TestIVFPQ_CompressionRatio(t *testing.T) {
 	config := ConfigPQ{
 		NumCentroids:  50,
@@ -303,6 +536,166 @@ func TestIVFPQ_MemoryUsage(t *testing.T) {
 
 // Helper functions
 
+func TestIVFFlat_Remove(t *testing.T) {
+	config := Config{
+		NumCentroids: 10,
+		Metric:       quantization.EuclideanDistance,
+	}
+
+	ivf := NewIVFFlat(config)
+	vectors := generateRandomVectors(500, 32)
+	ivf.Train(vectors)
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	ivf.Add(vectors, ids, nil)
+
+	// Remove every even ID
+	for id := 0; id < len(vectors); id += 2 {
+		if err := ivf.Remove(id); err != nil {
+			t.Fatalf("Remove %d failed: %v", id, err)
+		}
+	}
+
+	if err := ivf.Remove(0); err == nil {
+		t.Error("Expected error removing a vector twice")
+	}
+	if len(ivf.vectors) != 250 || len(ivf.ids) != 250 {
+		t.Errorf("Expected 250 vectors, got %d vectors and %d ids", len(ivf.vectors), len(ivf.ids))
+	}
+
+	// Probing every list, no removed vector comes back
+	resultIDs, _, err := ivf.Search(vectors[0], 50, 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	for _, id := range resultIDs {
+		if id%2 == 0 {
+			t.Errorf("Search returned removed vector %d", id)
+		}
+	}
+
+	resultIDs, _, _ = ivf.Search(vectors[1], 1, 10)
+	if len(resultIDs) != 1 || resultIDs[0] != 1 {
+		t.Errorf("Expected remaining vector 1 to be found, got %v", resultIDs)
+	}
+
+	// Removed IDs can be added again
+	if err := ivf.Add(vectors[:1], []int{0}, nil); err != nil {
+		t.Fatalf("Re-adding removed vector failed: %v", err)
+	}
+	if err := ivf.Add(vectors[1:2], []int{1}, nil); err == nil {
+		t.Error("Expected error adding a duplicate ID")
+	}
+	resultIDs, _, _ = ivf.Search(vectors[0], 1, 10)
+	if len(resultIDs) != 1 || resultIDs[0] != 0 {
+		t.Errorf("Expected re-added vector 0 to be found, got %v", resultIDs)
+	}
+}
+
+func TestIVFFlat_RemoveTombstones(t *testing.T) {
+	config := Config{
+		NumCentroids:     10,
+		Metric:           quantization.EuclideanDistance,
+		CompactThreshold: 0.5,
+	}
+
+	ivf := NewIVFFlat(config)
+	vectors := generateRandomVectors(500, 32)
+	ivf.Train(vectors)
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	ivf.Add(vectors, ids, nil)
+
+	filter := func(map[string]interface{}) bool { return true }
+	for id := 0; id < 100; id++ {
+		ivf.Remove(id)
+	}
+
+	stats := ivf.GetStats()
+	if stats["tombstoned_entries"].(int) == 0 {
+		t.Error("Expected tombstones below the compaction threshold")
+	}
+	if stats["total_entries"].(int) != 400 {
+		t.Errorf("Expected 400 live entries, got %v", stats["total_entries"])
+	}
+
+	resultIDs, _, _ := ivf.SearchWithFilter(vectors[5], 20, 10, filter)
+	for _, id := range resultIDs {
+		if id < 100 {
+			t.Errorf("Filtered search returned tombstoned vector %d", id)
+		}
+	}
+
+	ivf.Compact()
+	if stats := ivf.GetStats(); stats["tombstoned_entries"].(int) != 0 || stats["total_entries"].(int) != 400 {
+		t.Errorf("Expected 400 entries and no tombstones after Compact, got %v and %v",
+			stats["total_entries"], stats["tombstoned_entries"])
+	}
+}
+
+func TestIVFPQ_Remove(t *testing.T) {
+	config := ConfigPQ{
+		NumCentroids:     10,
+		NumSubvectors:    8,
+		BitsPerCode:      8,
+		Metric:           quantization.EuclideanDistance,
+		CompactThreshold: 0.3,
+	}
+
+	ivfpq := NewIVFPQ(config)
+	vectors := generateRandomVectors(500, 32)
+	if err := ivfpq.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	ivfpq.Add(vectors, ids, nil)
+
+	for id := 0; id < len(vectors); id += 2 {
+		if err := ivfpq.Remove(id); err != nil {
+			t.Fatalf("Remove %d failed: %v", id, err)
+		}
+	}
+	if err := ivfpq.Remove(len(vectors)); err == nil {
+		t.Error("Expected error removing an unknown vector")
+	}
+
+	check := func(stage string) {
+		resultIDs, _, err := ivfpq.Search(vectors[0], 100, 10)
+		if err != nil {
+			t.Fatalf("%s: Search failed: %v", stage, err)
+		}
+		if len(resultIDs) != 100 {
+			t.Errorf("%s: expected 100 results, got %d", stage, len(resultIDs))
+		}
+		for _, id := range resultIDs {
+			if id%2 == 0 {
+				t.Errorf("%s: search returned removed vector %d", stage, id)
+			}
+		}
+	}
+
+	check("tombstoned")
+	if stats := ivfpq.GetStats(); stats["total_entries"].(int) != 250 {
+		t.Errorf("Expected 250 live entries, got %v", stats["total_entries"])
+	}
+
+	ivfpq.Compact()
+	check("compacted")
+	if stats := ivfpq.GetStats(); stats["tombstoned_entries"].(int) != 0 {
+		t.Errorf("Expected no tombstones after Compact, got %v", stats["tombstoned_entries"])
+	}
+}
+
 func generateRandomVectors(n, dim int) [][]float32 {
 	vectors := make([][]float32, n)
 	for i := 0; i < n; i++ {
@@ -367,3 +760,37 @@ func BenchmarkIVFPQ_Search(b *testing.B) {
 		ivfpq.Search(query, 10, 10)
 	}
 }
+
+func BenchmarkIVFPQ_SearchParallel(b *testing.B) {
+	config := ConfigPQ{
+		NumCentroids:  64,
+		NumSubvectors: 16,
+		BitsPerCode:   8,
+		Metric:        quantization.EuclideanDistance,
+	}
+
+	ivfpq := NewIVFPQ(config)
+	vectors := generateRandomVectors(50000, 128)
+
+	ivfpq.Train(vectors[:5000])
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	ivfpq.Add(vectors, ids, nil)
+
+	query := vectors[0]
+
+	for _, nprobe := range []int{8, 32, 64} {
+		for _, workers := range []int{1, 2, 4, 8} {
+			b.Run(fmt.Sprintf("nprobe=%d/workers=%d", nprobe, workers), func(b *testing.B) {
+				ivfpq.SetSearchWorkers(workers)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					ivfpq.Search(query, 10, nprobe)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package ivf
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+// scoredID is a candidate produced while scanning inverted lists
+type scoredID struct {
+	id   int
+	dist float32
+}
+
+// worse reports whether a ranks after b (larger distance, ties broken by larger ID)
+func (a scoredID) worse(b scoredID) bool {
+	if a.dist != b.dist {
+		return a.dist > b.dist
+	}
+	return a.id > b.id
+}
+
+// topKHeap is a max-heap holding the best k candidates seen so far
+type topKHeap []scoredID
+
+func (h topKHeap) Len() int            { return len(h) }
+func (h topKHeap) Less(i, j int) bool  { return h[i].worse(h[j]) }
+func (h topKHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *topKHeap) Push(x interface{}) { *h = append(*h, x.(scoredID)) }
+func (h *topKHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// offer adds a candidate if it belongs in the top k
+func (h *topKHeap) offer(c scoredID, k int) {
+	if h.Len() < k {
+		heap.Push(h, c)
+	} else if (*h)[0].worse(c) {
+		(*h)[0] = c
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the candidates ordered best first
+func (h topKHeap) sorted() ([]int, []float32) {
+	results := make([]scoredID, len(h))
+	copy(results, h)
+	sort.Slice(results, func(i, j int) bool {
+		return results[j].worse(results[i])
+	})
+
+	ids := make([]int, len(results))
+	distances := make([]float32, len(results))
+	for i, r := range results {
+		ids[i] = r.id
+		distances[i] = r.dist
+	}
+	return ids, distances
+}
+
+// scanPartitions scores the given partitions and returns the top-k candidates.
+// With more than one worker, partitions are scanned concurrently; each worker
+// keeps a local top-k and merges it into the shared heap when it finishes,
+// so the result is identical to a sequential scan.
+func scanPartitions(partitions []int, k, workers int, scan func(partition int, top *topKHeap)) ([]int, []float32) {
+	if k <= 0 || len(partitions) == 0 {
+		return []int{}, []float32{}
+	}
+
+	if workers > len(partitions) {
+		workers = len(partitions)
+	}
+
+	shared := make(topKHeap, 0, k)
+	if workers <= 1 {
+		for _, p := range partitions {
+			scan(p, &shared)
+		}
+		return shared.sorted()
+	}
+
+	jobs := make(chan int, len(partitions))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make(topKHeap, 0, k)
+			for p := range jobs {
+				scan(p, &local)
+			}
+
+			mu.Lock()
+			for _, c := range local {
+				shared.offer(c, k)
+			}
+			mu.Unlock()
+		}()
+	}
+
+	for _, p := range partitions {
+		jobs <- p
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return shared.sorted()
+}
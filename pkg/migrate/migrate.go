@@ -0,0 +1,297 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/ivf"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/scann"
+)
+
+// Index types a migration reads from or builds
+const (
+	TypeHNSW  = "hnsw"   // HNSW graph
+	TypeFlat  = "flat"   // HNSW index that never builds its graph: every search is an exact full scan
+	TypeIVFPQ = "ivf_pq" // Inverted file with product quantization
+	TypeSCANN = "scann"  // SCANN partitions with anisotropic quantization
+)
+
+// Migration phases, in order
+const (
+	PhaseRead  = "read"  // Reading vectors from the source index
+	PhaseTrain = "train" // Training the target's partitions and quantizer (IVF-PQ and SCANN only)
+	PhaseBuild = "build" // Adding vectors to the target index
+)
+
+// progressInterval is the number of vectors between progress reports
+const progressInterval = 1000
+
+// Index is the source or result of a migration. Exactly one of HNSW, IVFPQ
+// and SCANN is set.
+type Index struct {
+	HNSW  *hnsw.Index  // TypeHNSW or TypeFlat
+	IVFPQ *ivf.IVFPQ   // TypeIVFPQ
+	SCANN *scann.SCANN // TypeSCANN
+
+	// Metadata holds metadata by vector ID for an HNSW index, which stores
+	// none itself. IVF-PQ and SCANN keep metadata in their entries.
+	Metadata map[uint64]map[string]interface{}
+}
+
+// Progress reports how far a migration has got
+type Progress struct {
+	Phase string // PhaseRead, PhaseTrain or PhaseBuild
+	Done  int    // Vectors done in this phase
+	Total int    // Vectors in this phase
+}
+
+// Options configures a migration
+type Options struct {
+	Type string // Target index type: TypeHNSW, TypeFlat, TypeIVFPQ or TypeSCANN
+
+	HNSW  *hnsw.IndexConfig // TypeHNSW and TypeFlat (nil = the source's config when it is HNSW, else hnsw.DefaultConfig())
+	IVFPQ ivf.ConfigPQ      // TypeIVFPQ
+	SCANN *scann.Config     // TypeSCANN (nil = scann.DefaultConfig())
+
+	// TrainSize is the number of vectors IVF-PQ and SCANN are trained on,
+	// spread evenly over the source (0 = all)
+	TrainSize int
+
+	// Progress is called as the migration advances (nil = no reports)
+	Progress func(Progress)
+}
+
+// record is a vector read from the source
+type record struct {
+	id       uint64
+	vector   []float32
+	metadata map[string]interface{}
+}
+
+// Migrate builds an index of the requested type holding the source's
+// vectors under the same IDs, with their metadata. The source is only
+// read, so it keeps serving until the caller swaps the result in; writes
+// to it during the migration may be missed and should be held off.
+//
+// IVF-PQ, and SCANN without StoreVectors, keep only compressed codes, so
+// vectors read from them are reconstructions and an index migrated from
+// them is no more accurate than the source.
+//
+// Cancelling ctx stops the migration between vectors. Training cannot be
+// interrupted, so ctx is checked again once it finishes.
+func Migrate(ctx context.Context, source Index, opts Options) (Index, error) {
+	switch opts.Type {
+	case TypeHNSW, TypeFlat, TypeIVFPQ, TypeSCANN:
+	default:
+		return Index{}, fmt.Errorf("unknown index type %q (must be %s, %s, %s or %s)",
+			opts.Type, TypeHNSW, TypeFlat, TypeIVFPQ, TypeSCANN)
+	}
+
+	records, err := read(ctx, source, opts.Progress)
+	if err != nil {
+		return Index{}, err
+	}
+
+	switch opts.Type {
+	case TypeIVFPQ:
+		return buildIVFPQ(ctx, records, opts)
+	case TypeSCANN:
+		return buildSCANN(ctx, records, opts)
+	default:
+		return buildHNSW(ctx, source, records, opts)
+	}
+}
+
+// read copies every vector out of the source, in ascending ID order
+func read(ctx context.Context, source Index, progress func(Progress)) ([]record, error) {
+	report := reporter(PhaseRead, progress)
+
+	var records []record
+	switch {
+	case source.HNSW != nil:
+		ids := source.HNSW.IDs()
+		records = make([]record, 0, len(ids))
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			vector, err := source.HNSW.GetVector(id)
+			if err != nil {
+				continue // Deleted since the IDs were listed
+			}
+			records = append(records, record{id: id, vector: vector, metadata: source.Metadata[id]})
+			report(len(records), len(ids), false)
+		}
+
+	case source.IVFPQ != nil, source.SCANN != nil:
+		var rangeFn func(func(id int, vector []float32, metadata map[string]interface{}) bool)
+		var total int
+		if source.IVFPQ != nil {
+			rangeFn, total = source.IVFPQ.Range, source.IVFPQ.Size()
+		} else {
+			rangeFn, total = source.SCANN.Range, source.SCANN.Size()
+		}
+		records = make([]record, 0, total)
+
+		var err error
+		rangeFn(func(id int, vector []float32, metadata map[string]interface{}) bool {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+			if id < 0 {
+				err = fmt.Errorf("vector ID %d is negative", id)
+				return false
+			}
+			records = append(records, record{id: uint64(id), vector: vector, metadata: metadata})
+			report(len(records), total, false)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(records, func(i, j int) bool { return records[i].id < records[j].id })
+
+	default:
+		return nil, fmt.Errorf("no source index given")
+	}
+
+	report(len(records), len(records), true)
+	return records, nil
+}
+
+// buildHNSW builds an HNSW graph, or a flat HNSW index, from the records
+func buildHNSW(ctx context.Context, source Index, records []record, opts Options) (Index, error) {
+	config := hnsw.DefaultConfig()
+	if opts.HNSW != nil {
+		config = *opts.HNSW
+	} else if source.HNSW != nil {
+		config = source.HNSW.Config()
+	}
+	config.FlatThreshold = 0
+	if opts.Type == TypeFlat {
+		config.FlatThreshold = math.MaxInt
+	}
+
+	index := hnsw.New(config)
+	if len(records) > 0 {
+		index.AdvanceNextID(records[len(records)-1].id + 1) // InsertWithID only accepts IDs below the counter
+	}
+	if source.HNSW != nil {
+		index.AdvanceNextID(source.HNSW.NextID()) // Never reuse the IDs of deleted vectors
+	}
+
+	metadata := make(map[uint64]map[string]interface{})
+	err := build(ctx, records, opts.Progress, func(rec record) error {
+		if rec.metadata != nil {
+			metadata[rec.id] = rec.metadata
+		}
+		return index.InsertWithID(rec.id, rec.vector)
+	})
+	if err != nil {
+		return Index{}, err
+	}
+	return Index{HNSW: index, Metadata: metadata}, nil
+}
+
+// buildIVFPQ trains an IVF-PQ index on the records and adds them
+func buildIVFPQ(ctx context.Context, records []record, opts Options) (Index, error) {
+	if err := checkQuantizedIDs(records); err != nil {
+		return Index{}, err
+	}
+
+	index := ivf.NewIVFPQ(opts.IVFPQ)
+	if err := train(ctx, records, opts, index.Train); err != nil {
+		return Index{}, err
+	}
+	err := build(ctx, records, opts.Progress, func(rec record) error {
+		return index.Insert(rec.vector, int(rec.id), rec.metadata)
+	})
+	if err != nil {
+		return Index{}, err
+	}
+	return Index{IVFPQ: index}, nil
+}
+
+// buildSCANN trains a SCANN index on the records and adds them
+func buildSCANN(ctx context.Context, records []record, opts Options) (Index, error) {
+	if err := checkQuantizedIDs(records); err != nil {
+		return Index{}, err
+	}
+
+	index := scann.NewSCANN(opts.SCANN)
+	if err := train(ctx, records, opts, index.Train); err != nil {
+		return Index{}, err
+	}
+	err := build(ctx, records, opts.Progress, func(rec record) error {
+		return index.Insert(rec.vector, int(rec.id), rec.metadata)
+	})
+	if err != nil {
+		return Index{}, err
+	}
+	return Index{SCANN: index}, nil
+}
+
+// checkQuantizedIDs reports an ID too large for IVF-PQ and SCANN, which
+// use int IDs
+func checkQuantizedIDs(records []record) error {
+	if len(records) > 0 && records[len(records)-1].id > math.MaxInt {
+		return fmt.Errorf("vector ID %d is too large for a quantized index", records[len(records)-1].id)
+	}
+	return nil
+}
+
+// train trains a quantized index on an even sample of the records
+func train(ctx context.Context, records []record, opts Options, trainFn func([][]float32) error) error {
+	if len(records) == 0 {
+		return fmt.Errorf("cannot train a %s index without vectors", opts.Type)
+	}
+
+	n := opts.TrainSize
+	if n <= 0 || n > len(records) {
+		n = len(records)
+	}
+	sample := make([][]float32, n)
+	for i := range sample {
+		sample[i] = records[i*len(records)/n].vector
+	}
+
+	report := reporter(PhaseTrain, opts.Progress)
+	report(0, n, true)
+	if err := trainFn(sample); err != nil {
+		return fmt.Errorf("failed to train %s index: %w", opts.Type, err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	report(n, n, true)
+	return nil
+}
+
+// build adds every record to the target index
+func build(ctx context.Context, records []record, progress func(Progress), add func(record) error) error {
+	report := reporter(PhaseBuild, progress)
+	for i, rec := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := add(rec); err != nil {
+			return fmt.Errorf("failed to add vector %d: %w", rec.id, err)
+		}
+		report(i+1, len(records), false)
+	}
+	report(len(records), len(records), true)
+	return nil
+}
+
+// reporter returns a function reporting progress in a phase every
+// progressInterval vectors, or whenever force is set
+func reporter(phase string, progress func(Progress)) func(done, total int, force bool) {
+	return func(done, total int, force bool) {
+		if progress != nil && (force || done%progressInterval == 0) {
+			progress(Progress{Phase: phase, Done: done, Total: total})
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/ivf"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/scann"
+)
+
+func randomVectors(rng *rand.Rand, n, dim int) [][]float32 {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+	}
+	return vectors
+}
+
+// newSource builds an HNSW index with metadata on every vector and the
+// highest ID deleted
+func newSource(t *testing.T, n, dim int) (Index, [][]float32) {
+	t.Helper()
+	vectors := randomVectors(rand.New(rand.NewSource(42)), n, dim)
+	index := hnsw.New(hnsw.DefaultConfig())
+	metadata := make(map[uint64]map[string]interface{})
+	for i, vec := range vectors {
+		id, err := index.Insert(vec)
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		metadata[id] = map[string]interface{}{"position": i}
+	}
+	if err := index.Delete(uint64(n - 1)); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	delete(metadata, uint64(n-1))
+	return Index{HNSW: index, Metadata: metadata}, vectors
+}
+
+func ivfpqConfig() ivf.ConfigPQ {
+	return ivf.ConfigPQ{
+		NumCentroids:  8,
+		NumSubvectors: 4,
+		BitsPerCode:   4,
+		Metric:        quantization.EuclideanDistance,
+	}
+}
+
+func scannConfig() *scann.Config {
+	config := scann.DefaultConfig()
+	config.NumPartitions = 8
+	config.NumSubvectors = 4
+	config.BitsPerCode = 4
+	return config
+}
+
+func TestMigrateHNSWAndFlat(t *testing.T) {
+	source, vectors := newSource(t, 300, 16)
+
+	flat, err := Migrate(context.Background(), source, Options{Type: TypeFlat})
+	if err != nil {
+		t.Fatalf("Migrate to flat failed: %v", err)
+	}
+	if !flat.HNSW.Flat() {
+		t.Error("Expected the flat index to have no graph")
+	}
+
+	graph, err := Migrate(context.Background(), flat, Options{Type: TypeHNSW})
+	if err != nil {
+		t.Fatalf("Migrate to HNSW failed: %v", err)
+	}
+	if graph.HNSW.Flat() {
+		t.Error("Expected the HNSW index to build its graph")
+	}
+
+	for _, migrated := range []Index{flat, graph} {
+		if !reflect.DeepEqual(migrated.HNSW.IDs(), source.HNSW.IDs()) {
+			t.Fatalf("Expected IDs to be kept")
+		}
+		if !reflect.DeepEqual(migrated.Metadata, source.Metadata) {
+			t.Errorf("Expected metadata to be kept")
+		}
+		for _, id := range migrated.HNSW.IDs() {
+			vector, _ := migrated.HNSW.GetVector(id)
+			if !reflect.DeepEqual(vector, vectors[id]) {
+				t.Fatalf("Vector %d changed", id)
+			}
+		}
+
+		// The deleted vector's ID is not handed out again
+		if next := migrated.HNSW.NextID(); next != uint64(len(vectors)) {
+			t.Errorf("Expected next ID %d, got %d", len(vectors), next)
+		}
+	}
+
+	result, err := flat.HNSW.Search(vectors[7], 1, 10)
+	if err != nil || len(result.Results) != 1 || result.Results[0].ID != 7 {
+		t.Errorf("Expected flat search to find vector 7, got %v (%v)", result, err)
+	}
+}
+
+func TestMigrateQuantized(t *testing.T) {
+	source, vectors := newSource(t, 500, 16)
+	n := len(vectors) - 1 // The last vector was deleted
+
+	var phases []string
+	progress := func(p Progress) {
+		if len(phases) == 0 || phases[len(phases)-1] != p.Phase {
+			phases = append(phases, p.Phase)
+		}
+		if p.Done > p.Total {
+			t.Errorf("Progress %d/%d in phase %s", p.Done, p.Total, p.Phase)
+		}
+	}
+
+	pq, err := Migrate(context.Background(), source, Options{
+		Type:      TypeIVFPQ,
+		IVFPQ:     ivfpqConfig(),
+		TrainSize: 400,
+		Progress:  progress,
+	})
+	if err != nil {
+		t.Fatalf("Migrate to IVF-PQ failed: %v", err)
+	}
+	if want := []string{PhaseRead, PhaseTrain, PhaseBuild}; !reflect.DeepEqual(phases, want) {
+		t.Errorf("Expected phases %v, got %v", want, phases)
+	}
+	if pq.IVFPQ.Size() != n {
+		t.Fatalf("Expected %d vectors in IVF-PQ, got %d", n, pq.IVFPQ.Size())
+	}
+	ids, _, err := pq.IVFPQ.SearchWithFilter(vectors[3], 1, 8, func(meta map[string]interface{}) bool {
+		return meta["position"] == 3
+	})
+	if err != nil || len(ids) != 1 || ids[0] != 3 {
+		t.Errorf("Expected metadata to follow vector 3 into IVF-PQ, got %v (%v)", ids, err)
+	}
+
+	sc, err := Migrate(context.Background(), pq, Options{Type: TypeSCANN, SCANN: scannConfig()})
+	if err != nil {
+		t.Fatalf("Migrate IVF-PQ to SCANN failed: %v", err)
+	}
+	if sc.SCANN.Size() != n {
+		t.Fatalf("Expected %d vectors in SCANN, got %d", n, sc.SCANN.Size())
+	}
+
+	back, err := Migrate(context.Background(), sc, Options{Type: TypeHNSW})
+	if err != nil {
+		t.Fatalf("Migrate SCANN to HNSW failed: %v", err)
+	}
+	if !reflect.DeepEqual(back.HNSW.IDs(), source.HNSW.IDs()) {
+		t.Errorf("Expected IDs to survive IVF-PQ and SCANN")
+	}
+	if !reflect.DeepEqual(back.Metadata, source.Metadata) {
+		t.Errorf("Expected metadata to survive IVF-PQ and SCANN")
+	}
+}
+
+func TestMigrateCancel(t *testing.T) {
+	source, _ := newSource(t, 2500, 8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reports := 0
+	_, err := Migrate(ctx, source, Options{
+		Type: TypeHNSW,
+		Progress: func(p Progress) {
+			reports++
+			if p.Phase == PhaseBuild {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the migration to be cancelled, got %v", err)
+	}
+	if reports == 0 {
+		t.Error("Expected progress before the cancellation")
+	}
+}
+
+func TestMigrateInvalid(t *testing.T) {
+	source, _ := newSource(t, 10, 8)
+
+	if _, err := Migrate(context.Background(), source, Options{Type: "annoy"}); err == nil {
+		t.Error("Expected an unknown index type to fail")
+	}
+	if _, err := Migrate(context.Background(), Index{}, Options{Type: TypeHNSW}); err == nil {
+		t.Error("Expected a missing source to fail")
+	}
+
+	empty := Index{HNSW: hnsw.New(hnsw.DefaultConfig())}
+	if _, err := Migrate(context.Background(), empty, Options{Type: TypeIVFPQ, IVFPQ: ivfpqConfig()}); err == nil {
+		t.Error("Expected training on an empty source to fail")
+	}
+	if migrated, err := Migrate(context.Background(), empty, Options{Type: TypeHNSW}); err != nil || migrated.HNSW.Size() != 0 {
+		t.Errorf("Expected an empty HNSW index, got %v", err)
+	}
+}
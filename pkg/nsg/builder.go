@@ -6,7 +6,9 @@ import (
 )
 
 // findNavigatingNode finds the approximate centroid of all vectors
-// This node serves as the entry point for all searches
+// This node serves as the entry point for all searches. Like findKNN, it
+// walks nodes in build order rather than map order, so the same vectors
+// always build the same graph.
 func (idx *Index) findNavigatingNode() uint64 {
 	if len(idx.nodes) == 0 {
 		return 0
@@ -16,8 +18,8 @@ func (idx *Index) findNavigatingNode() uint64 {
 	centroid := make([]float32, idx.dimension)
 	count := float32(len(idx.nodes))
 
-	for _, node := range idx.nodes {
-		for i, val := range node.vector {
+	for _, id := range idx.buildIDs {
+		for i, val := range idx.nodes[id].vector {
 			centroid[i] += val / count
 		}
 	}
@@ -26,8 +28,8 @@ func (idx *Index) findNavigatingNode() uint64 {
 	var closestID uint64
 	minDist := float32(math.MaxFloat32)
 
-	for id, node := range idx.nodes {
-		dist := idx.distanceFunc(node.vector, centroid)
+	for _, id := range idx.buildIDs {
+		dist := idx.distanceFunc(idx.nodes[id].vector, centroid)
 		if dist < minDist {
 			minDist = dist
 			closestID = id
@@ -57,12 +59,12 @@ func (idx *Index) findKNN(query []float32, excludeID uint64, k int) []uint64 {
 	heap.Init(pq)
 
 	// Calculate distances to all nodes
-	for id, node := range idx.nodes {
+	for _, id := range idx.buildIDs {
 		if id == excludeID {
 			continue
 		}
 
-		dist := idx.distanceFunc(query, node.vector)
+		dist := idx.distanceFunc(query, idx.nodes[id].vector)
 
 		if pq.Len() < k {
 			heap.Push(pq, &item{id: id, distance: dist, maxHeap: true})
@@ -2,39 +2,40 @@ package nsg
 
 import (
 	"math"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
 )
 
 // DistanceFunc is a function type for calculating distance between two vectors
 type DistanceFunc func(a, b []float32) float32
 
-// CosineSimilarity calculates the cosine similarity between two vectors
-// Returns 1 - cosine similarity to make it a distance metric (0 = identical, 2 = opposite)
+// CosineDistance calculates the cosine distance between two vectors, the
+// default metric of the index. Lower is more similar: the range is [0, 2],
+// with 0 for vectors pointing the same way, 1 for orthogonal vectors and 2
+// for opposite ones. A zero vector is at distance 1 from everything.
+// Uses SIMD instructions when the CPU supports them.
 // Formula: 1 - (a·b) / (||a|| * ||b||)
-func CosineSimilarity(a, b []float32) float32 {
+func CosineDistance(a, b []float32) float32 {
 	if len(a) != len(b) {
 		panic("vectors must have the same dimension")
 	}
+	return quantization.CosineDistanceFloat32(a, b)
+}
 
-	var dotProduct, normA, normB float32
-
-	for i := 0; i < len(a); i++ {
-		dotProduct += a[i] * b[i]
-		normA += a[i] * a[i]
-		normB += b[i] * b[i]
-	}
-
-	// Avoid division by zero
-	if normA == 0 || normB == 0 {
-		return 1.0
-	}
-
-	normA = float32(math.Sqrt(float64(normA)))
-	normB = float32(math.Sqrt(float64(normB)))
-
-	similarity := dotProduct / (normA * normB)
+// CosineSim calculates the cosine similarity between two vectors. Higher is
+// more similar: the range is [-1, 1], with 1 for vectors pointing the same
+// way. It equals 1 - CosineDistance, so a zero vector scores 0. Use it to
+// report scores, not as an index DistanceFunc.
+// Formula: (a·b) / (||a|| * ||b||)
+func CosineSim(a, b []float32) float32 {
+	return 1.0 - CosineDistance(a, b)
+}
 
-	// Convert similarity to distance: higher distance = less similar
-	return 1.0 - similarity
+// CosineSimilarity returns the cosine distance, despite its name.
+//
+// Deprecated: use CosineDistance, or CosineSim for the similarity.
+func CosineSimilarity(a, b []float32) float32 {
+	return CosineDistance(a, b)
 }
 
 // EuclideanDistance calculates the Euclidean (L2) distance between two vectors
@@ -13,6 +13,7 @@ type Index struct {
 	L             int          // Candidate pool size for graph construction
 	C             int          // Maximum candidate pool size
 	distanceFunc  DistanceFunc // Distance metric function
+	rebuildRatio  float64      // Post-build inserts, relative to the built size, that call for a rebuild
 
 	// Index state
 	nodes        map[uint64]*Node // All nodes in the index
@@ -25,6 +26,8 @@ type Index struct {
 	buildVectors [][]float32 // Temporary storage during batch build
 	buildIDs     []uint64    // Temporary ID storage during batch build
 
+	insertedAfterBuild int // Vectors added by InsertAfterBuild since Build
+
 	// Concurrency control
 	mu sync.RWMutex // Protects index-level operations
 
@@ -37,7 +40,8 @@ type IndexConfig struct {
 	R            int          // Outgoing edges per node (typical: 16-32)
 	L            int          // Candidate pool size for construction (typical: 100)
 	C            int          // Max candidate pool size (typical: 500)
-	DistanceFunc DistanceFunc // Distance metric (default: CosineSimilarity)
+	DistanceFunc DistanceFunc // Distance metric (default: CosineDistance)
+	RebuildRatio float64      // NeedsRebuild threshold: post-build inserts / built size (default: 0.2)
 }
 
 // DefaultConfig returns a configuration with recommended default values
@@ -46,7 +50,8 @@ func DefaultConfig() IndexConfig {
 		R:            16,
 		L:            100,
 		C:            500,
-		DistanceFunc: CosineSimilarity,
+		DistanceFunc: CosineDistance,
+		RebuildRatio: 0.2,
 	}
 }
 
@@ -63,7 +68,10 @@ func New(config IndexConfig) *Index {
 		config.C = 500
 	}
 	if config.DistanceFunc == nil {
-		config.DistanceFunc = CosineSimilarity
+		config.DistanceFunc = CosineDistance
+	}
+	if config.RebuildRatio <= 0 {
+		config.RebuildRatio = 0.2
 	}
 
 	return &Index{
@@ -71,6 +79,7 @@ func New(config IndexConfig) *Index {
 		L:            config.L,
 		C:            config.C,
 		distanceFunc: config.DistanceFunc,
+		rebuildRatio: config.RebuildRatio,
 		nodes:        make(map[uint64]*Node),
 		nodeCounter:  0,
 		isBuilt:      false,
@@ -80,13 +89,13 @@ func New(config IndexConfig) *Index {
 }
 
 // AddVector adds a vector to the build queue (must call Build() after adding all vectors)
-// NSG requires batch construction - cannot insert into built index
+// Use InsertAfterBuild to add vectors to a built index
 func (idx *Index) AddVector(vector []float32) (uint64, error) {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 
 	if idx.isBuilt {
-		return 0, fmt.Errorf("cannot add vectors to built NSG index")
+		return 0, fmt.Errorf("cannot add vectors to built NSG index - use InsertAfterBuild")
 	}
 
 	// Set dimension on first vector
@@ -0,0 +1,131 @@
+package nsg
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// InsertAfterBuild adds a vector to a built index without rebuilding it.
+// A greedy search from the navigating node gathers up to L candidates, the
+// R closest become the new node's neighbors, and each of them links back,
+// pruning its own neighbors to the R closest when it is full.
+//
+// Recall drifts down as the graph departs from what Build would produce;
+// NeedsRebuild reports when enough vectors have been added this way.
+func (idx *Index) InsertAfterBuild(vector []float32) (uint64, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if !idx.isBuilt {
+		return 0, fmt.Errorf("index not built yet - use AddVector and Build")
+	}
+	if len(vector) != idx.dimension {
+		return 0, fmt.Errorf("vector dimension mismatch: expected %d, got %d", idx.dimension, len(vector))
+	}
+
+	id := idx.nodeCounter
+	idx.nodeCounter++
+
+	vecCopy := make([]float32, len(vector))
+	copy(vecCopy, vector)
+
+	candidates := idx.searchCandidates(vecCopy, idx.L)
+	neighbors := idx.selectMonotonicNeighbors(id, vecCopy, candidates, nil)
+
+	node := NewNode(id, vecCopy)
+	node.SetNeighbors(neighbors)
+	idx.nodes[id] = node
+
+	// Reverse edges make the new node reachable
+	for _, neighborID := range neighbors {
+		neighbor := idx.nodes[neighborID]
+		if neighbor.NeighborCount() < idx.R {
+			neighbor.AddNeighbor(id)
+			continue
+		}
+		pool := append(neighbor.GetNeighbors(), id)
+		neighbor.SetNeighbors(idx.selectMonotonicNeighbors(neighborID, neighbor.vector, pool, nil))
+	}
+
+	idx.size++
+	idx.insertedAfterBuild++
+
+	return id, nil
+}
+
+// NeedsRebuild reports whether vectors inserted after Build exceed
+// RebuildRatio of the built size, the point where a fresh Build is likely
+// to recover noticeable recall
+func (idx *Index) NeedsRebuild() bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if !idx.isBuilt || idx.insertedAfterBuild == 0 {
+		return false
+	}
+	builtSize := idx.size - int64(idx.insertedAfterBuild)
+	return float64(idx.insertedAfterBuild) > idx.rebuildRatio*float64(builtSize)
+}
+
+// InsertedAfterBuild returns the number of vectors added by InsertAfterBuild
+func (idx *Index) InsertedAfterBuild() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.insertedAfterBuild
+}
+
+// searchCandidates runs a greedy search from the navigating node and
+// returns up to l of the closest nodes found, closest first
+func (idx *Index) searchCandidates(query []float32, l int) []uint64 {
+	navNode, exists := idx.nodes[idx.navigatingID]
+	if !exists {
+		return nil
+	}
+
+	candidates := &priorityQueue{} // Min-heap: nodes to expand
+	results := &priorityQueue{}    // Max-heap: best l nodes found
+	visited := map[uint64]bool{idx.navigatingID: true}
+
+	navDist := idx.distanceFunc(query, navNode.vector)
+	heap.Push(candidates, &item{id: idx.navigatingID, distance: navDist})
+	heap.Push(results, &item{id: idx.navigatingID, distance: navDist, maxHeap: true})
+
+	for candidates.Len() > 0 {
+		current := heap.Pop(candidates).(*item)
+		if results.Len() >= l && current.distance > (*results)[0].distance {
+			break
+		}
+
+		for _, neighborID := range idx.nodes[current.id].GetNeighbors() {
+			if visited[neighborID] {
+				continue
+			}
+			visited[neighborID] = true
+
+			neighbor, exists := idx.nodes[neighborID]
+			if !exists {
+				continue
+			}
+
+			dist := idx.distanceFunc(query, neighbor.vector)
+			if results.Len() < l || dist < (*results)[0].distance {
+				heap.Push(candidates, &item{id: neighborID, distance: dist})
+				heap.Push(results, &item{id: neighborID, distance: dist, maxHeap: true})
+				if results.Len() > l {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	found := make([]*item, results.Len())
+	copy(found, *results)
+	sort.Slice(found, func(i, j int) bool { return found[i].distance < found[j].distance })
+
+	ids := make([]uint64, len(found))
+	for i, it := range found {
+		ids[i] = it.id
+	}
+	return ids
+}
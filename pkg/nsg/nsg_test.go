@@ -1,6 +1,7 @@
 package nsg
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 	"testing"
@@ -124,6 +125,44 @@ func TestBuildIndex(t *testing.T) {
 	}
 }
 
+func TestBuildDeterministic(t *testing.T) {
+	// Grid points put many vectors at equal distances, so any dependence on
+	// map iteration order would show up as differing neighbor lists
+	build := func() *Index {
+		config := DefaultConfig()
+		config.DistanceFunc = EuclideanDistance
+		idx := New(config)
+		for x := 0; x < 8; x++ {
+			for y := 0; y < 8; y++ {
+				if _, err := idx.AddVector([]float32{float32(x), float32(y)}); err != nil {
+					t.Fatalf("AddVector failed: %v", err)
+				}
+			}
+		}
+		if err := idx.Build(); err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		return idx
+	}
+
+	first := build()
+	for run := 0; run < 5; run++ {
+		idx := build()
+		if idx.GetNavigatingNode() != first.GetNavigatingNode() {
+			t.Fatalf("Run %d: navigating node %d, first build chose %d",
+				run, idx.GetNavigatingNode(), first.GetNavigatingNode())
+		}
+		for id := uint64(0); id < 64; id++ {
+			want, _ := first.GetNode(id)
+			got, _ := idx.GetNode(id)
+			if fmt.Sprint(got.GetNeighbors()) != fmt.Sprint(want.GetNeighbors()) {
+				t.Fatalf("Run %d: node %d neighbors %v, first build had %v",
+					run, id, got.GetNeighbors(), want.GetNeighbors())
+			}
+		}
+	}
+}
+
 func TestSearchBasic(t *testing.T) {
 	idx := New(DefaultConfig())
 
@@ -204,7 +243,7 @@ func TestSearchRecall(t *testing.T) {
 		query := vectors[i]
 
 		// Get ground truth (brute force)
-		groundTruth := bruteForceSearch(query, vectors, k, CosineSimilarity)
+		groundTruth := bruteForceSearch(query, vectors, k, CosineDistance)
 
 		// Get NSG results
 		results, err := idx.Search(query, k)
@@ -342,18 +381,97 @@ func TestAddVectorAfterBuild(t *testing.T) {
 	}
 }
 
+func TestInsertAfterBuild(t *testing.T) {
+	idx := New(DefaultConfig())
+
+	dim := 16
+	rng := rand.New(rand.NewSource(42))
+	randomVector := func() []float32 {
+		vec := make([]float32, dim)
+		for j := range vec {
+			vec[j] = rng.Float32()
+		}
+		return vec
+	}
+
+	if _, err := idx.InsertAfterBuild(randomVector()); err == nil {
+		t.Error("Expected error when inserting before build")
+	}
+
+	vectors := make([][]float32, 0, 250)
+	for i := 0; i < 200; i++ {
+		vec := randomVector()
+		vectors = append(vectors, vec)
+		idx.AddVector(vec)
+	}
+	if err := idx.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		vec := randomVector()
+		id, err := idx.InsertAfterBuild(vec)
+		if err != nil {
+			t.Fatalf("InsertAfterBuild failed: %v", err)
+		}
+		if id != uint64(len(vectors)) {
+			t.Fatalf("Expected ID %d, got %d", len(vectors), id)
+		}
+		vectors = append(vectors, vec)
+
+		// 20% of the 200 built vectors may be added before a rebuild is due
+		if want := i+1 > 40; idx.NeedsRebuild() != want {
+			t.Errorf("After %d inserts expected NeedsRebuild %v", i+1, want)
+		}
+	}
+
+	if idx.Size() != 250 || idx.InsertedAfterBuild() != 50 {
+		t.Errorf("Expected 250 vectors with 50 inserted after build, got %d and %d", idx.Size(), idx.InsertedAfterBuild())
+	}
+	for id := uint64(0); id < 250; id++ {
+		node, _ := idx.GetNode(id)
+		if node.NeighborCount() > idx.R {
+			t.Fatalf("Node %d has %d neighbors, exceeding R = %d", id, node.NeighborCount(), idx.R)
+		}
+	}
+
+	// Queries around both built and inserted vectors
+	k := 10
+	totalRecall := 0.0
+	numQueries := 0
+	for i := 180; i < 250; i++ {
+		groundTruth := bruteForceSearch(vectors[i], vectors, k, CosineDistance)
+		results, err := idx.Search(vectors[i], k)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		totalRecall += calculateRecall(results, groundTruth, k)
+		numQueries++
+	}
+
+	avgRecall := totalRecall / float64(numQueries)
+	if avgRecall < 0.70 {
+		t.Errorf("Average recall %.2f%% after post-build inserts, expected >70%%", avgRecall*100)
+	}
+	t.Logf("Average recall after 50 post-build inserts: %.2f%%", avgRecall*100)
+
+	if _, err := idx.InsertAfterBuild([]float32{1, 2}); err == nil {
+		t.Error("Expected error for dimension mismatch")
+	}
+}
+
 func TestDistanceFunctions(t *testing.T) {
 	vec1 := []float32{1.0, 0.0, 0.0, 0.0}
 	vec2 := []float32{0.0, 1.0, 0.0, 0.0}
 	vec3 := []float32{1.0, 0.0, 0.0, 0.0}
 
 	// Cosine distance
-	d1 := CosineSimilarity(vec1, vec2)
+	d1 := CosineDistance(vec1, vec2)
 	if d1 < 0.99 || d1 > 1.01 {
 		t.Errorf("Cosine distance between orthogonal vectors should be ~1.0, got %f", d1)
 	}
 
-	d2 := CosineSimilarity(vec1, vec3)
+	d2 := CosineDistance(vec1, vec3)
 	if d2 > 0.001 {
 		t.Errorf("Cosine distance between identical vectors should be ~0, got %f", d2)
 	}
@@ -371,6 +489,50 @@ func TestDistanceFunctions(t *testing.T) {
 	}
 }
 
+func TestCosineRanges(t *testing.T) {
+	x := []float32{1, 2, 0, 0}
+	cases := []struct {
+		name     string
+		b        []float32
+		distance float32
+		sim      float32
+	}{
+		{"same direction", []float32{2, 4, 0, 0}, 0, 1},
+		{"orthogonal", []float32{0, 0, 3, 0}, 1, 0},
+		{"opposite", []float32{-1, -2, 0, 0}, 2, -1},
+		{"zero vector", []float32{0, 0, 0, 0}, 1, 0},
+	}
+	for _, c := range cases {
+		if d := CosineDistance(x, c.b); math.Abs(float64(d-c.distance)) > 1e-5 {
+			t.Errorf("%s: expected CosineDistance %v, got %v", c.name, c.distance, d)
+		}
+		if s := CosineSim(x, c.b); math.Abs(float64(s-c.sim)) > 1e-5 {
+			t.Errorf("%s: expected CosineSim %v, got %v", c.name, c.sim, s)
+		}
+	}
+
+	// Every pair stays in range, and the deprecated name keeps returning the distance
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 1000; i++ {
+		a := make([]float32, 16)
+		b := make([]float32, 16)
+		for j := range a {
+			a[j] = rng.Float32()*2 - 1
+			b[j] = rng.Float32()*2 - 1
+		}
+		d, s := CosineDistance(a, b), CosineSim(a, b)
+		if d < -1e-6 || d > 2+1e-6 {
+			t.Fatalf("CosineDistance %v outside [0, 2]", d)
+		}
+		if s < -1-1e-6 || s > 1+1e-6 {
+			t.Fatalf("CosineSim %v outside [-1, 1]", s)
+		}
+		if CosineSimilarity(a, b) != d {
+			t.Fatalf("CosineSimilarity changed behavior: %v != %v", CosineSimilarity(a, b), d)
+		}
+	}
+}
+
 func TestConcurrentSearch(t *testing.T) {
 	idx := New(DefaultConfig())
 
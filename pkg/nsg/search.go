@@ -132,7 +132,10 @@ func (idx *Index) Search(query []float32, k int) ([]SearchResult, error) {
 
 	// Sort by distance (ascending)
 	sort.Slice(resultList, func(i, j int) bool {
-		return resultList[i].Distance < resultList[j].Distance
+		if resultList[i].Distance != resultList[j].Distance {
+			return resultList[i].Distance < resultList[j].Distance
+		}
+		return resultList[i].ID < resultList[j].ID
 	})
 
 	// Return top k
@@ -246,7 +249,10 @@ func (idx *Index) SearchWithFilter(query []float32, k int, filter func(uint64) b
 	}
 
 	sort.Slice(resultList, func(i, j int) bool {
-		return resultList[i].Distance < resultList[j].Distance
+		if resultList[i].Distance != resultList[j].Distance {
+			return resultList[i].Distance < resultList[j].Distance
+		}
+		return resultList[i].ID < resultList[j].ID
 	})
 
 	if len(resultList) > k {
@@ -331,7 +337,10 @@ func (idx *Index) RangeSearch(query []float32, radius float32) ([]SearchResult,
 
 	// Sort by distance
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].Distance < results[j].Distance
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].ID < results[j].ID
 	})
 
 	return results, nil
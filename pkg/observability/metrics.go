@@ -3,6 +3,7 @@ package observability
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"sync"
 	"time"
 )
 
@@ -50,6 +51,22 @@ type Metrics struct {
 	CPUUsage        prometheus.Gauge
 }
 
+var (
+	defaultMetrics     *Metrics
+	defaultMetricsOnce sync.Once
+)
+
+// DefaultMetrics returns the process-wide metrics, registering them with
+// the default Prometheus registry on first use. NewMetrics registers a new
+// set each call and panics if one is already registered, so servers share
+// this instance.
+func DefaultMetrics() *Metrics {
+	defaultMetricsOnce.Do(func() {
+		defaultMetrics = NewMetrics()
+	})
+	return defaultMetrics
+}
+
 // NewMetrics creates and registers all Prometheus metrics
 func NewMetrics() *Metrics {
 	m := &Metrics{
@@ -295,6 +312,14 @@ func (m *Metrics) UpdateIndexMaxLayer(namespace string, maxLayer int) {
 	m.IndexMaxLayer.WithLabelValues(namespace).Set(float64(maxLayer))
 }
 
+// RemoveNamespace drops a deleted namespace's index and quota series
+func (m *Metrics) RemoveNamespace(namespace string) {
+	m.IndexSize.DeleteLabelValues(namespace)
+	m.IndexMemoryBytes.DeleteLabelValues(namespace)
+	m.IndexMaxLayer.DeleteLabelValues(namespace)
+	m.TenantQuotaUsage.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+}
+
 // RecordBatchInsert records a batch insert operation
 func (m *Metrics) RecordBatchInsert(duration time.Duration, count int) {
 	m.BatchInsertTotal.Inc()
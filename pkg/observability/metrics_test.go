@@ -3,6 +3,8 @@ package observability
 import (
 	"testing"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestMetrics(t *testing.T) {
@@ -132,6 +134,16 @@ func TestMetrics(t *testing.T) {
 		m.UpdateIndexMaxLayer("staging", 3)
 	})
 
+	t.Run("RemoveNamespace", func(t *testing.T) {
+		m.UpdateIndexSize("scratch", 10)
+		m.UpdateIndexMaxLayer("scratch", 2)
+		m.RemoveNamespace("scratch")
+
+		if n := testutil.CollectAndCount(m.IndexSize, "vectordb_index_size"); n != 3 {
+			t.Errorf("Expected 3 index size series after removal, got %d", n)
+		}
+	})
+
 	t.Run("RecordCacheHit", func(t *testing.T) {
 		// Test cache hits
 		for i := 0; i < 100; i++ {
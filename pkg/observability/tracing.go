@@ -0,0 +1,62 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies spans created by the vector database
+const tracerName = "github.com/therealutkarshpriyadarshi/vector"
+
+// TracingConfig configures OpenTelemetry trace export
+type TracingConfig struct {
+	Endpoint    string  // OTLP/gRPC collector address, e.g. "localhost:4317"
+	Insecure    bool    // Connect to the collector without TLS
+	ServiceName string  // service.name resource attribute
+	SampleRatio float64 // Fraction of new traces sampled (parent decisions are honored)
+}
+
+// InitTracing installs a global tracer provider that batches spans to an
+// OTLP collector, and the W3C trace context propagator. The returned
+// function flushes pending spans and shuts the provider down.
+//
+// Until InitTracing is called the global provider is a no-op, so spans
+// started through Tracer cost almost nothing.
+func InitTracing(ctx context.Context, cfg TracingConfig) (func(context.Context) error, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer for vector database spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
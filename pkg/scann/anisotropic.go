@@ -17,7 +17,7 @@ import (
 //
 // Key innovations:
 // 1. Learned rotation: Projects data to align with principal components
-// 2. Non-uniform subvector sizes: More dimensions for important directions
+// 2. Non-uniform subvector sizes: Fewer dimensions per codebook for high-variance directions
 // 3. Optimized for maximum inner product search (MIPS)
 //
 // In practice, this often provides 10-20% better recall than standard PQ
@@ -27,7 +27,7 @@ type AnisotropicQuantizer struct {
 	numSubvectors int           // Number of subvectors
 	bitsPerCode   int           // Bits per code
 	subvectorDims []int         // Dimensions per subvector (can vary!)
-	rotation      [][]float32   // Learned rotation matrix (optional)
+	rotation      [][]float32   // Learned rotation matrix, rows are principal components (nil unless useRotation)
 	codebooks     [][][]float32 // Codebooks for each subvector
 	useRotation   bool          // Whether to use rotation
 }
@@ -38,10 +38,16 @@ func NewAnisotropicQuantizer(dim, numSubvectors, bitsPerCode int) *AnisotropicQu
 		dim:           dim,
 		numSubvectors: numSubvectors,
 		bitsPerCode:   bitsPerCode,
-		useRotation:   false, // Disabled by default for simplicity
+		useRotation:   false, // Disabled by default: training costs O(dim^3)
 	}
 }
 
+// SetUseRotation enables or disables the learned rotation. It takes effect
+// at the next Train.
+func (aq *AnisotropicQuantizer) SetUseRotation(enabled bool) {
+	aq.useRotation = enabled
+}
+
 // Train trains the anisotropic quantizer
 func (aq *AnisotropicQuantizer) Train(vectors [][]float32, config *quantization.QuantizationConfig) error {
 	if len(vectors) == 0 {
@@ -57,29 +63,53 @@ func (aq *AnisotropicQuantizer) Train(vectors [][]float32, config *quantization.
 	fmt.Printf("    Subvectors: %d\n", aq.numSubvectors)
 	fmt.Printf("    Bits per code: %d\n", aq.bitsPerCode)
 
-	// Step 1: Compute subvector dimensions
-	// For now, use equal division (future: learn based on variance)
-	aq.subvectorDims = make([]int, aq.numSubvectors)
-	baseDim := aq.dim / aq.numSubvectors
-	remainder := aq.dim % aq.numSubvectors
+	// Step 1: Compute subvector dimensions. Without rotation, consecutive
+	// dimensions are grouped so each subvector captures a similar share of
+	// the variance; the rotation balances variance itself, over equal
+	// subvectors
+	aq.rotation = nil
+	if aq.useRotation {
+		aq.subvectorDims = equalSubvectorDims(aq.dim, aq.numSubvectors)
+	} else {
+		aq.subvectorDims = balancedSubvectorDims(dimensionVariance(vectors), aq.numSubvectors)
+	}
 
-	for i := 0; i < aq.numSubvectors; i++ {
-		aq.subvectorDims[i] = baseDim
-		if i < remainder {
-			aq.subvectorDims[i]++
+	// Step 2: Optional rotation onto the principal components, balanced
+	// across subvectors; codebooks are trained on the rotated vectors
+	if aq.useRotation {
+		fmt.Printf("    Learning rotation...\n")
+		aq.rotation = learnRotation(vectors, aq.subvectorDims)
+
+		rotated := make([][]float32, len(vectors))
+		for i, vec := range vectors {
+			rotated[i] = rotate(aq.rotation, vec)
 		}
+		vectors = rotated
 	}
 
-	// Step 2: Optional rotation (PCA-like transformation)
-	// For simplicity, we skip this in the basic implementation
-	// In production SCANN, this uses SVD to find principal components
-
 	// Step 3: Train codebooks for each subvector
+	if err := aq.trainCodebooks(vectors, config); err != nil {
+		return err
+	}
+
+	fmt.Printf("  Anisotropic Quantizer training complete\n")
+	return nil
+}
+
+// trainCodebooks trains one k-means codebook per subvector of the current
+// subvectorDims
+func (aq *AnisotropicQuantizer) trainCodebooks(vectors [][]float32, config *quantization.QuantizationConfig) error {
 	aq.codebooks = make([][][]float32, aq.numSubvectors)
 	numCodes := 1 << aq.bitsPerCode
 
-	offset := 0
-	for sv := 0; sv < aq.numSubvectors; sv++ {
+	offsets := make([]int, aq.numSubvectors)
+	for sv := 1; sv < aq.numSubvectors; sv++ {
+		offsets[sv] = offsets[sv-1] + aq.subvectorDims[sv-1]
+	}
+
+	// Codebooks are independent, so they train concurrently
+	return quantization.ForEachSubvector(aq.numSubvectors, func(sv int) error {
+		offset := offsets[sv]
 		svDim := aq.subvectorDims[sv]
 		endDim := offset + svDim
 
@@ -100,11 +130,89 @@ func (aq *AnisotropicQuantizer) Train(vectors [][]float32, config *quantization.
 		}
 
 		aq.codebooks[sv] = centroids
-		offset = endDim
+		return nil
+	})
+}
+
+// equalSubvectorDims splits dim dimensions into numSubvectors near-equal
+// subvectors
+func equalSubvectorDims(dim, numSubvectors int) []int {
+	dims := make([]int, numSubvectors)
+	baseDim := dim / numSubvectors
+	remainder := dim % numSubvectors
+
+	for i := 0; i < numSubvectors; i++ {
+		dims[i] = baseDim
+		if i < remainder {
+			dims[i]++
+		}
 	}
+	return dims
+}
 
-	fmt.Printf("  Anisotropic Quantizer training complete\n")
-	return nil
+// balancedSubvectorDims splits the dimensions, in order, into numSubvectors
+// subvectors of at least one dimension each, closing each subvector where
+// the running variance is nearest its even share of the total. High-variance
+// dimensions end up in narrow subvectors, so their codebooks spend more
+// codes per dimension where quantization error matters most. Without any
+// variance, or with no more dimensions than subvectors, it splits equally.
+func balancedSubvectorDims(variance []float64, numSubvectors int) []int {
+	dim := len(variance)
+	total := 0.0
+	for _, v := range variance {
+		total += v
+	}
+	if total <= 0 || numSubvectors >= dim {
+		return equalSubvectorDims(dim, numSubvectors)
+	}
+
+	dims := make([]int, numSubvectors)
+	start := 0
+	cumulative := 0.0
+	for sv := 0; sv < numSubvectors-1; sv++ {
+		target := total * float64(sv+1) / float64(numSubvectors)
+
+		// Take at least one dimension and leave one for each later subvector
+		end := start + 1
+		cumulative += variance[start]
+		for end < dim-(numSubvectors-1-sv) &&
+			math.Abs(cumulative+variance[end]-target) <= math.Abs(cumulative-target) {
+			cumulative += variance[end]
+			end++
+		}
+
+		dims[sv] = end - start
+		start = end
+	}
+	dims[numSubvectors-1] = dim - start
+
+	return dims
+}
+
+// dimensionVariance returns the variance of each dimension of vectors
+func dimensionVariance(vectors [][]float32) []float64 {
+	dim := len(vectors[0])
+	mean := make([]float64, dim)
+	for _, vec := range vectors {
+		for d, v := range vec {
+			mean[d] += float64(v)
+		}
+	}
+	for d := range mean {
+		mean[d] /= float64(len(vectors))
+	}
+
+	variance := make([]float64, dim)
+	for _, vec := range vectors {
+		for d, v := range vec {
+			diff := float64(v) - mean[d]
+			variance[d] += diff * diff
+		}
+	}
+	for d := range variance {
+		variance[d] /= float64(len(vectors))
+	}
+	return variance
 }
 
 // Encode encodes a vector
@@ -113,6 +221,10 @@ func (aq *AnisotropicQuantizer) Encode(vec []float32) []byte {
 		return nil
 	}
 
+	if aq.rotation != nil {
+		vec = rotate(aq.rotation, vec)
+	}
+
 	codes := make([]byte, aq.numSubvectors)
 	offset := 0
 
@@ -161,6 +273,10 @@ func (aq *AnisotropicQuantizer) Decode(codes []byte) []float32 {
 		offset += svDim
 	}
 
+	if aq.rotation != nil {
+		vec = unrotate(aq.rotation, vec)
+	}
+
 	return vec
 }
 
@@ -170,6 +286,10 @@ func (aq *AnisotropicQuantizer) ComputeDistanceTable(query []float32) interface{
 		return nil
 	}
 
+	if aq.rotation != nil {
+		query = rotate(aq.rotation, query)
+	}
+
 	distTable := make([][]float32, aq.numSubvectors)
 	offset := 0
 
@@ -231,7 +351,7 @@ func (aq *AnisotropicQuantizer) GetBytesPerVector() int {
 
 // Serialize serializes the quantizer
 func (aq *AnisotropicQuantizer) Serialize() ([]byte, error) {
-	// Format: [dim][numSubvectors][bitsPerCode][subvectorDims...][codebooks...]
+	// Format: [dim][numSubvectors][bitsPerCode][subvectorDims...][codebooks...][hasRotation][rotation...]
 	numCodes := 1 << aq.bitsPerCode
 
 	// Calculate size
@@ -240,7 +360,11 @@ func (aq *AnisotropicQuantizer) Serialize() ([]byte, error) {
 	for sv := 0; sv < aq.numSubvectors; sv++ {
 		codebookSize += numCodes * aq.subvectorDims[sv] * 4
 	}
-	totalSize := headerSize + codebookSize
+	rotationSize := 4
+	if aq.rotation != nil {
+		rotationSize += aq.dim * aq.dim * 4
+	}
+	totalSize := headerSize + codebookSize + rotationSize
 
 	data := make([]byte, totalSize)
 	offset := 0
@@ -270,6 +394,20 @@ func (aq *AnisotropicQuantizer) Serialize() ([]byte, error) {
 		}
 	}
 
+	// Write rotation
+	if aq.rotation == nil {
+		binary.LittleEndian.PutUint32(data[offset:], 0)
+		return data, nil
+	}
+	binary.LittleEndian.PutUint32(data[offset:], 1)
+	offset += 4
+	for _, row := range aq.rotation {
+		for _, v := range row {
+			binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(v))
+			offset += 4
+		}
+	}
+
 	return data, nil
 }
 
@@ -315,6 +453,29 @@ func (aq *AnisotropicQuantizer) Deserialize(data []byte) error {
 		}
 	}
 
+	// Read rotation
+	if offset+4 > len(data) {
+		return fmt.Errorf("unexpected end of data")
+	}
+	hasRotation := binary.LittleEndian.Uint32(data[offset:])
+	offset += 4
+	aq.useRotation = hasRotation != 0
+	aq.rotation = nil
+	if !aq.useRotation {
+		return nil
+	}
+	if int64(aq.dim)*int64(aq.dim)*4 > int64(len(data)-offset) {
+		return fmt.Errorf("unexpected end of data")
+	}
+	aq.rotation = make([][]float32, aq.dim)
+	for i := range aq.rotation {
+		aq.rotation[i] = make([]float32, aq.dim)
+		for d := range aq.rotation[i] {
+			aq.rotation[i][d] = math.Float32frombits(binary.LittleEndian.Uint32(data[offset:]))
+			offset += 4
+		}
+	}
+
 	return nil
 }
 
@@ -38,6 +38,10 @@ type SCANN struct {
 	config  *Config                     // SCANN configuration
 	mu      sync.RWMutex
 	trained bool
+
+	generation int // Bumped whenever training or loading replaces the partitions and quantizer
+
+	searchWorkers int // Goroutines scanning probed partitions (<= 1 = sequential)
 }
 
 // SCANNEntry represents a compressed vector in SCANN
@@ -45,6 +49,7 @@ type SCANNEntry struct {
 	ID       int                    // Vector ID
 	Code     []byte                 // Anisotropic quantization code
 	Norm     float32                // Vector norm (for MIPS)
+	Vector   []float32              // Original vector for fine rescoring (nil unless StoreVectors)
 	Metadata map[string]interface{} // Metadata
 }
 
@@ -61,6 +66,15 @@ type Config struct {
 	// Search
 	ReorderTopK   int  // Number of candidates to rescore (higher = better recall)
 	UseReordering bool // Enable fine rescoring step
+	StoreVectors  bool // Keep original vectors for rescoring (costs 4*dim bytes per vector)
+
+	// UseRotation rotates vectors onto their principal components before
+	// quantizing, balancing variance across subvectors. It raises recall on
+	// correlated data such as embeddings; training costs O(dim^3) extra.
+	UseRotation bool
+
+	// SearchWorkers scans probed partitions concurrently (0 or 1 = sequential)
+	SearchWorkers int
 
 	// Training
 	TrainConfig *quantization.QuantizationConfig
@@ -78,6 +92,7 @@ func DefaultConfig() *Config {
 		BitsPerCode:   8,
 		ReorderTopK:   200,
 		UseReordering: true,
+		StoreVectors:  true,
 		TrainConfig:   quantization.DefaultConfig(),
 		Metric:        quantization.CosineDistance,
 	}
@@ -97,6 +112,7 @@ func NewSCANN(config *Config) *SCANN {
 		invertedLists: make([][]SCANNEntry, config.NumPartitions),
 		config:        config,
 		metric:        config.Metric,
+		searchWorkers: config.SearchWorkers,
 	}
 }
 
@@ -157,11 +173,13 @@ func (s *SCANN) Train(vectors [][]float32) error {
 	// Step 3: Train anisotropic quantizer on residuals
 	fmt.Printf("Training anisotropic quantizer...\n")
 	s.aq = NewAnisotropicQuantizer(s.dim, s.config.NumSubvectors, s.config.BitsPerCode)
+	s.aq.SetUseRotation(s.config.UseRotation)
 	if err := s.aq.Train(residuals, s.config.TrainConfig); err != nil {
 		return fmt.Errorf("anisotropic quantization training failed: %w", err)
 	}
 
 	s.trained = true
+	s.generation++
 
 	fmt.Printf("\nSCANN Training Complete!\n")
 	fmt.Printf("  Compression ratio: %.1fx\n", s.aq.GetCompressionRatio())
@@ -184,152 +202,185 @@ func (s *SCANN) Add(vectors [][]float32, ids []int, metadata []map[string]interf
 	}
 
 	for i, vec := range vectors {
-		if len(vec) != s.dim {
-			return fmt.Errorf("vector dimension mismatch")
+		var meta map[string]interface{}
+		if metadata != nil && i < len(metadata) {
+			meta = metadata[i]
 		}
 
-		// Find partition
-		partitionIdx := s.findNearestPartition(vec)
-		partition := s.partitions[partitionIdx]
-
-		// Compute residual
-		residual := make([]float32, s.dim)
-		for d := 0; d < s.dim; d++ {
-			residual[d] = vec[d] - partition[d]
+		partitionIdx, entry, err := s.encode(vec, ids[i], meta)
+		if err != nil {
+			return err
 		}
+		s.invertedLists[partitionIdx] = append(s.invertedLists[partitionIdx], entry)
+	}
+
+	return nil
+}
 
-		// Encode with anisotropic quantizer
-		code := s.aq.Encode(residual)
+// Insert adds a single vector. It is safe to call from many goroutines:
+// the vector is encoded under the read lock, so concurrent inserts and
+// searches only serialize on the append to its inverted list. A vector
+// encoded before the index was retrained is encoded again.
+func (s *SCANN) Insert(vec []float32, id int, metadata map[string]interface{}) error {
+	s.mu.RLock()
+	partitionIdx, entry, err := s.encode(vec, id, metadata)
+	generation := s.generation
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
 
-		// Compute norm (for MIPS)
-		norm := quantization.NormL2(vec)
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-		// Add entry
-		entry := SCANNEntry{
-			ID:   ids[i],
-			Code: code,
-			Norm: norm,
+	if s.generation != generation {
+		if partitionIdx, entry, err = s.encode(vec, id, metadata); err != nil {
+			return err
 		}
-		if metadata != nil && i < len(metadata) {
-			entry.Metadata = metadata[i]
-		}
-
-		s.invertedLists[partitionIdx] = append(s.invertedLists[partitionIdx], entry)
 	}
+	s.invertedLists[partitionIdx] = append(s.invertedLists[partitionIdx], entry)
 
 	return nil
 }
 
-// Search performs approximate nearest neighbor search with SCANN
-func (s *SCANN) Search(query []float32, k int, nprobe int) ([]int, []float32, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// Remove deletes a vector from the index. Entries are not indexed by ID,
+// so every inverted list is scanned for it.
+func (s *SCANN) Remove(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if !s.trained {
-		return nil, nil, fmt.Errorf("index not trained")
+	for partitionIdx, list := range s.invertedLists {
+		for i := range list {
+			if list[i].ID == id {
+				last := len(list) - 1
+				list[i] = list[last]
+				list[last] = SCANNEntry{}
+				s.invertedLists[partitionIdx] = list[:last]
+				return nil
+			}
+		}
 	}
 
-	if len(query) != s.dim {
-		return nil, nil, fmt.Errorf("query dimension mismatch")
+	return fmt.Errorf("vector %d not found", id)
+}
+
+// encode builds a vector's entry and picks its partition. It only reads
+// the index, so the caller needs at least the read lock.
+func (s *SCANN) encode(vec []float32, id int, metadata map[string]interface{}) (int, SCANNEntry, error) {
+	if !s.trained {
+		return 0, SCANNEntry{}, fmt.Errorf("index not trained, call Train() first")
+	}
+	if len(vec) != s.dim {
+		return 0, SCANNEntry{}, fmt.Errorf("vector dimension mismatch")
 	}
 
-	// Stage 1: Coarse search - find nearest partitions
-	partitionIDs := s.findNearestPartitions(query, nprobe)
+	// Find partition
+	partitionIdx := s.findNearestPartition(vec)
+	partition := s.partitions[partitionIdx]
 
-	// Stage 2: Mid-level scoring with anisotropic quantization
-	type candidate struct {
-		id   int
-		dist float32
+	// Compute residual
+	residual := make([]float32, s.dim)
+	for d := 0; d < s.dim; d++ {
+		residual[d] = vec[d] - partition[d]
 	}
 
-	candidates := make([]candidate, 0, nprobe*100)
-
-	for _, partitionID := range partitionIDs {
-		partition := s.partitions[partitionID]
+	entry := SCANNEntry{
+		ID:       id,
+		Code:     s.aq.Encode(residual),    // Anisotropic quantization code
+		Norm:     quantization.NormL2(vec), // Norm for MIPS
+		Metadata: metadata,
+	}
+	if s.config.StoreVectors {
+		entry.Vector = make([]float32, len(vec))
+		copy(entry.Vector, vec)
+	}
 
-		// Compute query residual
-		queryResidual := make([]float32, s.dim)
-		for d := 0; d < s.dim; d++ {
-			queryResidual[d] = query[d] - partition[d]
-		}
+	return partitionIdx, entry, nil
+}
 
-		// Precompute distance table for asymmetric distance
-		distTable := s.aq.ComputeDistanceTable(queryResidual)
+// Search performs approximate nearest neighbor search with SCANN. An
+// untrained index, a query of the wrong dimension, k <= 0 or nprobe <= 0 is
+// reported as a *SearchError.
+func (s *SCANN) Search(query []float32, k int, nprobe int) ([]int, []float32, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-		// Score all vectors in this partition
-		for _, entry := range s.invertedLists[partitionID] {
-			dist := s.aq.AsymmetricDistance(distTable, entry.Code)
-			candidates = append(candidates, candidate{id: entry.ID, dist: dist})
-		}
+	if err := s.checkSearch(query, k, nprobe); err != nil {
+		return nil, nil, err
 	}
 
-	// Sort candidates
-	sort.Slice(candidates, func(i, j int) bool {
-		return candidates[i].dist < candidates[j].dist
+	// Stage 1: Coarse search - find nearest partitions
+	partitionIDs := s.findNearestPartitions(query, nprobe)
+
+	// Stage 2: Mid-level scoring with anisotropic quantization, keeping
+	// only the candidates that fine rescoring will look at
+	candidates := scanPartitions(partitionIDs, s.candidateLimit(k), s.searchWorkers, func(partitionID int, top *candidateHeap) {
+		s.scanList(query, partitionID, k, nil, top)
 	})
 
 	// Stage 3: Fine rescoring (optional, but improves recall)
-	// In a production system, you'd recompute exact distances
-	// using stored vectors for top candidates
+	candidates = s.rescore(query, candidates, k)
 
-	// Return top-k
-	if len(candidates) > k {
-		candidates = candidates[:k]
-	}
-
-	ids := make([]int, len(candidates))
-	distances := make([]float32, len(candidates))
-	for i, c := range candidates {
-		ids[i] = c.id
-		distances[i] = c.dist
-	}
-
-	return ids, distances, nil
+	return topK(candidates, k)
 }
 
-// SearchWithFilter performs filtered search
+// SearchWithFilter performs filtered search, validating its arguments like
+// Search
 func (s *SCANN) SearchWithFilter(query []float32, k int, nprobe int, filter func(map[string]interface{}) bool) ([]int, []float32, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if !s.trained {
-		return nil, nil, fmt.Errorf("index not trained")
+	if err := s.checkSearch(query, k, nprobe); err != nil {
+		return nil, nil, err
 	}
 
 	partitionIDs := s.findNearestPartitions(query, nprobe)
 
-	type candidate struct {
-		id   int
-		dist float32
-	}
+	candidates := scanPartitions(partitionIDs, s.candidateLimit(k), s.searchWorkers, func(partitionID int, top *candidateHeap) {
+		s.scanList(query, partitionID, k, filter, top)
+	})
 
-	candidates := make([]candidate, 0)
+	candidates = s.rescore(query, candidates, k)
 
-	for _, partitionID := range partitionIDs {
-		partition := s.partitions[partitionID]
+	return topK(candidates, k)
+}
 
-		queryResidual := make([]float32, s.dim)
-		for d := 0; d < s.dim; d++ {
-			queryResidual[d] = query[d] - partition[d]
-		}
+// scanList scores every entry in one partition against the query
+func (s *SCANN) scanList(query []float32, partitionID, k int, filter func(map[string]interface{}) bool, top *candidateHeap) {
+	partition := s.partitions[partitionID]
 
-		distTable := s.aq.ComputeDistanceTable(queryResidual)
+	// Compute query residual
+	queryResidual := make([]float32, s.dim)
+	for d := 0; d < s.dim; d++ {
+		queryResidual[d] = query[d] - partition[d]
+	}
 
-		for _, entry := range s.invertedLists[partitionID] {
-			// Apply filter
-			if filter != nil && !filter(entry.Metadata) {
-				continue
-			}
+	// Precompute distance table for asymmetric distance
+	distTable := s.aq.ComputeDistanceTable(queryResidual)
 
-			dist := s.aq.AsymmetricDistance(distTable, entry.Code)
-			candidates = append(candidates, candidate{id: entry.ID, dist: dist})
+	limit := s.candidateLimit(k)
+	for _, entry := range s.invertedLists[partitionID] {
+		// Apply filter
+		if filter != nil && !filter(entry.Metadata) {
+			continue
 		}
+
+		dist := s.aq.AsymmetricDistance(distTable, entry.Code)
+		top.offer(candidate{id: entry.ID, dist: dist, vector: entry.Vector}, limit)
 	}
+}
 
-	sort.Slice(candidates, func(i, j int) bool {
-		return candidates[i].dist < candidates[j].dist
-	})
+// candidateLimit is how many quantized candidates a search keeps: the
+// ReorderTopK (at least k) that rescore looks at, or k without rescoring
+func (s *SCANN) candidateLimit(k int) int {
+	if !s.config.UseReordering || !s.config.StoreVectors || s.config.ReorderTopK < k {
+		return k
+	}
+	return s.config.ReorderTopK
+}
 
+// topK splits the first k candidates into IDs and distances
+func topK(candidates []candidate, k int) ([]int, []float32, error) {
 	if len(candidates) > k {
 		candidates = candidates[:k]
 	}
@@ -344,6 +395,65 @@ func (s *SCANN) SearchWithFilter(query []float32, k int, nprobe int, filter func
 	return ids, distances, nil
 }
 
+// SetSearchWorkers sets how many goroutines scan probed partitions (1 = sequential)
+func (s *SCANN) SetSearchWorkers(workers int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.searchWorkers = workers
+}
+
+// candidate is a search result awaiting ranking
+type candidate struct {
+	id     int
+	dist   float32
+	vector []float32 // Original vector, nil when vectors are not stored
+}
+
+// rescore recomputes exact distances for the top ReorderTopK candidates
+// (at least k) and re-sorts them. Candidates must be sorted by their
+// approximate distance; the rest are dropped. Without reordering or
+// stored vectors the candidates are returned unchanged.
+func (s *SCANN) rescore(query []float32, candidates []candidate, k int) []candidate {
+	if !s.config.UseReordering || !s.config.StoreVectors {
+		return candidates
+	}
+
+	topK := s.config.ReorderTopK
+	if topK < k {
+		topK = k
+	}
+	if len(candidates) > topK {
+		candidates = candidates[:topK]
+	}
+
+	for i := range candidates {
+		if candidates[i].vector != nil {
+			candidates[i].dist = s.exactDistance(query, candidates[i].vector)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].id < candidates[j].id
+	})
+
+	return candidates
+}
+
+// exactDistance computes the configured metric on full-precision vectors
+func (s *SCANN) exactDistance(a, b []float32) float32 {
+	switch s.metric {
+	case quantization.CosineDistance:
+		return quantization.CosineDistanceFloat32(a, b)
+	case quantization.DotProductDistance:
+		return -quantization.DotProductFloat32(a, b)
+	default:
+		return quantization.EuclideanDistanceFloat32(a, b)
+	}
+}
+
 // sphericalKMeans performs spherical k-means clustering
 // This is better for angular similarity (cosine distance)
 func (s *SCANN) sphericalKMeans(vectors [][]float32, k int) ([][]float32, error) {
@@ -479,7 +589,10 @@ func (s *SCANN) findNearestPartitions(vec []float32, nprobe int) []int {
 	}
 
 	sort.Slice(distances, func(i, j int) bool {
-		return distances[i].dist < distances[j].dist
+		if distances[i].dist != distances[j].dist {
+			return distances[i].dist < distances[j].dist
+		}
+		return distances[i].idx < distances[j].idx
 	})
 
 	if nprobe > len(distances) {
@@ -494,6 +607,66 @@ func (s *SCANN) findNearestPartitions(vec []float32, nprobe int) []int {
 	return result
 }
 
+// Size returns the number of vectors in the index
+func (s *SCANN) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	size := 0
+	for _, list := range s.invertedLists {
+		size += len(list)
+	}
+	return size
+}
+
+// Range calls fn for each vector in the index until fn returns false. With
+// StoreVectors the original vectors are passed; otherwise each vector is
+// reconstructed from its partition and code and only approximates the
+// vector that was added. The read lock is held throughout, so fn must not
+// modify the index.
+func (s *SCANN) Range(fn func(id int, vector []float32, metadata map[string]interface{}) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for partitionIdx, list := range s.invertedLists {
+		for _, entry := range list {
+			vector := entry.Vector
+			if vector != nil {
+				vector = append([]float32(nil), vector...)
+			} else {
+				vector = s.aq.Decode(entry.Code)
+				for d, v := range s.partitions[partitionIdx] {
+					vector[d] += v
+				}
+			}
+			if !fn(entry.ID, vector, entry.Metadata) {
+				return
+			}
+		}
+	}
+}
+
+// GetMemoryUsage returns memory usage in bytes: the partition centroids
+// plus each entry's code, norm and, with StoreVectors, original vector
+func (s *SCANN) GetMemoryUsage() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	total := int64(len(s.partitions) * s.dim * 4)
+
+	perEntry := int64(4) // Norm
+	if s.aq != nil {
+		perEntry += int64(s.aq.GetBytesPerVector())
+	}
+	for _, list := range s.invertedLists {
+		for _, entry := range list {
+			total += perEntry + int64(len(entry.Vector)*4)
+		}
+	}
+
+	return total
+}
+
 // GetStats returns index statistics
 func (s *SCANN) GetStats() map[string]interface{} {
 	s.mu.RLock()
@@ -504,6 +677,8 @@ func (s *SCANN) GetStats() map[string]interface{} {
 	stats["dimension"] = s.dim
 	stats["trained"] = s.trained
 	stats["spherical_kmeans"] = s.config.SphericalKM
+	stats["reordering"] = s.config.UseReordering && s.config.StoreVectors
+	stats["reorder_top_k"] = s.config.ReorderTopK
 
 	totalEntries := 0
 	for _, list := range s.invertedLists {
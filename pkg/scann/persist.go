@@ -0,0 +1,284 @@
+package scann
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+
+	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+)
+
+// Serialized index identification
+var persistMagic = [4]byte{'S', 'C', 'N', 'N'}
+
+// persistVersion is bumped whenever the serialized layout changes.
+// Deserialize rejects any other version.
+const persistVersion byte = 2
+
+// persistHeader holds the configuration of a serialized index
+type persistHeader struct {
+	Dim             uint32
+	NumPartitions   uint32
+	SphericalKM     bool
+	NumSubvectors   uint32
+	BitsPerCode     uint32
+	ReorderTopK     uint32
+	UseReordering   bool
+	StoreVectors    bool
+	Metric          int32
+	TrainIterations uint32
+	TrainMetric     int32
+	TrainVerbose    bool
+	TrainSeed       int64
+}
+
+// entryHeader is the fixed-size part of a serialized inverted list entry
+type entryHeader struct {
+	ID   int64
+	Norm float32
+}
+
+// Serialize encodes a trained index: a magic header and version byte, the
+// configuration, the partition centroids, the anisotropic quantizer, then
+// each partition's inverted list (ID, norm, code and, with StoreVectors,
+// the original vector). Entry metadata follows gob-encoded, so it may hold
+// basic Go types (strings, numbers, bools and slices or maps of them).
+func (s *SCANN) Serialize() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.trained {
+		return nil, fmt.Errorf("index not trained")
+	}
+
+	var buf bytes.Buffer
+	buf.Write(persistMagic[:])
+	buf.WriteByte(persistVersion)
+
+	header := persistHeader{
+		Dim:             uint32(s.dim),
+		NumPartitions:   uint32(s.numPartitions),
+		SphericalKM:     s.config.SphericalKM,
+		NumSubvectors:   uint32(s.config.NumSubvectors),
+		BitsPerCode:     uint32(s.config.BitsPerCode),
+		ReorderTopK:     uint32(s.config.ReorderTopK),
+		UseReordering:   s.config.UseReordering,
+		StoreVectors:    s.config.StoreVectors,
+		Metric:          int32(s.metric),
+		TrainIterations: uint32(s.config.TrainConfig.NumIterations),
+		TrainMetric:     int32(s.config.TrainConfig.DistanceMetric),
+		TrainVerbose:    s.config.TrainConfig.Verbose,
+		TrainSeed:       s.config.TrainConfig.RandomSeed,
+	}
+	binary.Write(&buf, binary.LittleEndian, &header)
+
+	for _, partition := range s.partitions {
+		binary.Write(&buf, binary.LittleEndian, partition)
+	}
+
+	aqData, err := s.aq.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize quantizer: %w", err)
+	}
+	binary.Write(&buf, binary.LittleEndian, uint32(len(aqData)))
+	buf.Write(aqData)
+
+	var metadata []map[string]interface{}
+	hasMetadata := false
+	for _, list := range s.invertedLists {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(list)))
+		for _, entry := range list {
+			binary.Write(&buf, binary.LittleEndian, &entryHeader{ID: int64(entry.ID), Norm: entry.Norm})
+			buf.Write(entry.Code)
+			if entry.Vector != nil {
+				buf.WriteByte(1)
+				binary.Write(&buf, binary.LittleEndian, entry.Vector)
+			} else {
+				buf.WriteByte(0)
+			}
+
+			metadata = append(metadata, entry.Metadata)
+			if entry.Metadata != nil {
+				hasMetadata = true
+			}
+		}
+	}
+
+	if !hasMetadata {
+		buf.WriteByte(0)
+		return buf.Bytes(), nil
+	}
+	buf.WriteByte(1)
+	if err := gob.NewEncoder(&buf).Encode(metadata); err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Deserialize replaces the index, configuration included, with one
+// written by Serialize
+func (s *SCANN) Deserialize(data []byte) error {
+	r := bytes.NewReader(data)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+	if magic != persistMagic {
+		return fmt.Errorf("not a SCANN index (bad magic %q)", magic[:])
+	}
+	version, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+	if version != persistVersion {
+		return fmt.Errorf("unsupported SCANN index format version %d (expected %d)", version, persistVersion)
+	}
+
+	var header persistHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+	dim := int(header.Dim)
+	numPartitions := int(header.NumPartitions)
+	numSubvectors := int(header.NumSubvectors)
+	if dim == 0 || numPartitions == 0 || numSubvectors == 0 {
+		return fmt.Errorf("invalid header: dimension %d, %d partitions, %d subvectors", dim, numPartitions, numSubvectors)
+	}
+	// Every partition centroid takes 4*dim bytes, so a corrupt count or
+	// dimension cannot trigger an allocation larger than the input
+	if int64(numPartitions)*int64(dim)*4 > int64(r.Len()) {
+		return fmt.Errorf("invalid header: %d partitions of dimension %d exceed the data size", numPartitions, dim)
+	}
+
+	partitions := make([][]float32, numPartitions)
+	for i := range partitions {
+		partitions[i] = make([]float32, dim)
+		if err := binary.Read(r, binary.LittleEndian, partitions[i]); err != nil {
+			return fmt.Errorf("failed to read partition %d: %w", i, err)
+		}
+	}
+
+	var aqSize uint32
+	if err := binary.Read(r, binary.LittleEndian, &aqSize); err != nil {
+		return fmt.Errorf("failed to read quantizer: %w", err)
+	}
+	if int64(aqSize) > int64(r.Len()) {
+		return fmt.Errorf("quantizer size %d exceeds the data size", aqSize)
+	}
+	aqData := make([]byte, aqSize)
+	io.ReadFull(r, aqData)
+	// Codes are one byte per subvector; check the codebook size before
+	// the quantizer allocates 2^bits centroids per subvector
+	if header.BitsPerCode == 0 || header.BitsPerCode > 8 ||
+		len(aqData) < 12 || binary.LittleEndian.Uint32(aqData[8:]) != header.BitsPerCode {
+		return fmt.Errorf("invalid quantizer: expected %d bits per code", header.BitsPerCode)
+	}
+	aq := &AnisotropicQuantizer{}
+	if err := aq.Deserialize(aqData); err != nil {
+		return fmt.Errorf("failed to read quantizer: %w", err)
+	}
+	if aq.dim != dim || aq.numSubvectors != numSubvectors {
+		return fmt.Errorf("quantizer has dimension %d and %d subvectors, index has %d and %d",
+			aq.dim, aq.numSubvectors, dim, numSubvectors)
+	}
+
+	invertedLists := make([][]SCANNEntry, numPartitions)
+	entrySize := int64(8 + 4 + numSubvectors + 1)
+	total := 0
+	for p := range invertedLists {
+		var count uint32
+		if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+			return fmt.Errorf("failed to read partition %d: %w", p, err)
+		}
+		if int64(count)*entrySize > int64(r.Len()) {
+			return fmt.Errorf("partition %d: %d entries exceed the data size", p, count)
+		}
+
+		list := make([]SCANNEntry, count)
+		for i := range list {
+			var fixed entryHeader
+			if err := binary.Read(r, binary.LittleEndian, &fixed); err != nil {
+				return fmt.Errorf("partition %d entry %d: %w", p, i, err)
+			}
+			list[i].ID = int(fixed.ID)
+			list[i].Norm = fixed.Norm
+			list[i].Code = make([]byte, numSubvectors)
+			if _, err := io.ReadFull(r, list[i].Code); err != nil {
+				return fmt.Errorf("partition %d entry %d: %w", p, i, err)
+			}
+
+			hasVector, err := r.ReadByte()
+			if err != nil {
+				return fmt.Errorf("partition %d entry %d: %w", p, i, io.ErrUnexpectedEOF)
+			}
+			if hasVector == 1 {
+				list[i].Vector = make([]float32, dim)
+				if err := binary.Read(r, binary.LittleEndian, list[i].Vector); err != nil {
+					return fmt.Errorf("partition %d entry %d: %w", p, i, err)
+				}
+			}
+		}
+		invertedLists[p] = list
+		total += len(list)
+	}
+
+	hasMetadata, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("failed to read metadata: %w", io.ErrUnexpectedEOF)
+	}
+	if hasMetadata == 1 {
+		var metadata []map[string]interface{}
+		if err := gob.NewDecoder(r).Decode(&metadata); err != nil {
+			return fmt.Errorf("failed to read metadata: %w", err)
+		}
+		if len(metadata) != total {
+			return fmt.Errorf("metadata for %d entries, index has %d", len(metadata), total)
+		}
+		// gob decodes nil maps inside a slice as empty maps
+		i := 0
+		for _, list := range invertedLists {
+			for j := range list {
+				if len(metadata[i]) > 0 {
+					list[j].Metadata = metadata[i]
+				}
+				i++
+			}
+		}
+	}
+
+	config := &Config{
+		NumPartitions: numPartitions,
+		SphericalKM:   header.SphericalKM,
+		NumSubvectors: numSubvectors,
+		BitsPerCode:   int(header.BitsPerCode),
+		ReorderTopK:   int(header.ReorderTopK),
+		UseReordering: header.UseReordering,
+		StoreVectors:  header.StoreVectors,
+		UseRotation:   aq.useRotation,
+		TrainConfig: &quantization.QuantizationConfig{
+			NumIterations:  int(header.TrainIterations),
+			DistanceMetric: quantization.DistanceMetric(header.TrainMetric),
+			Verbose:        header.TrainVerbose,
+			RandomSeed:     header.TrainSeed,
+		},
+		Metric: quantization.DistanceMetric(header.Metric),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.numPartitions = numPartitions
+	s.partitions = partitions
+	s.aq = aq
+	s.invertedLists = invertedLists
+	s.dim = dim
+	s.metric = config.Metric
+	s.config = config
+	s.trained = true
+	s.generation++
+
+	return nil
+}
@@ -0,0 +1,330 @@
+package scann
+
+import (
+	"math"
+	"sort"
+)
+
+// learnRotation learns an orthogonal rotation that decorrelates the
+// training vectors and spreads their variance evenly across subvectors.
+//
+// The rows of the returned matrix are the principal components of the
+// training data (eigenvectors of its covariance). Quantizing along the
+// principal components wastes no code bits on correlated dimensions, but
+// taken in order they would pack most of the variance into the first
+// subvectors. Instead each component, largest first, goes to the subvector
+// holding the least variance so far that still has room (the eigenvalue
+// allocation of Optimized Product Quantization), so every codebook gets a
+// similar share of the signal.
+func learnRotation(vectors [][]float32, subvectorDims []int) [][]float32 {
+	dim := len(vectors[0])
+
+	mean := make([]float64, dim)
+	for _, vec := range vectors {
+		for d, v := range vec {
+			mean[d] += float64(v)
+		}
+	}
+	for d := range mean {
+		mean[d] /= float64(len(vectors))
+	}
+
+	// Covariance, accumulated on the upper triangle and mirrored
+	cov := make([][]float64, dim)
+	for i := range cov {
+		cov[i] = make([]float64, dim)
+	}
+	centered := make([]float64, dim)
+	for _, vec := range vectors {
+		for d, v := range vec {
+			centered[d] = float64(v) - mean[d]
+		}
+		for i := 0; i < dim; i++ {
+			ci := centered[i]
+			row := cov[i]
+			for j := i; j < dim; j++ {
+				row[j] += ci * centered[j]
+			}
+		}
+	}
+	for i := 0; i < dim; i++ {
+		for j := i; j < dim; j++ {
+			cov[i][j] /= float64(len(vectors))
+			cov[j][i] = cov[i][j]
+		}
+	}
+
+	values, eigenvectors := symmetricEigen(cov)
+
+	// Largest variance first
+	order := make([]int, dim)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return values[order[a]] > values[order[b]] })
+
+	// Allocate components to subvectors, balancing their variance
+	offsets := make([]int, len(subvectorDims))
+	for sv := 1; sv < len(subvectorDims); sv++ {
+		offsets[sv] = offsets[sv-1] + subvectorDims[sv-1]
+	}
+	filled := make([]int, len(subvectorDims))
+	variance := make([]float64, len(subvectorDims))
+
+	rotation := make([][]float32, dim)
+	for _, component := range order {
+		target := -1
+		for sv := range subvectorDims {
+			if filled[sv] == subvectorDims[sv] {
+				continue
+			}
+			if target < 0 || variance[sv] < variance[target] {
+				target = sv
+			}
+		}
+
+		row := make([]float32, dim)
+		for d := 0; d < dim; d++ {
+			row[d] = float32(eigenvectors[d][component])
+		}
+		rotation[offsets[target]+filled[target]] = row
+		filled[target]++
+		variance[target] += math.Max(values[component], 0)
+	}
+
+	return rotation
+}
+
+// rotate returns rotation applied to vec
+func rotate(rotation [][]float32, vec []float32) []float32 {
+	out := make([]float32, len(rotation))
+	for i, row := range rotation {
+		var sum float32
+		for d, v := range vec {
+			sum += row[d] * v
+		}
+		out[i] = sum
+	}
+	return out
+}
+
+// unrotate applies the inverse (transpose) of rotation to vec
+func unrotate(rotation [][]float32, vec []float32) []float32 {
+	out := make([]float32, len(vec))
+	for i, row := range rotation {
+		v := vec[i]
+		for d := range out {
+			out[d] += row[d] * v
+		}
+	}
+	return out
+}
+
+// symmetricEigen returns the eigenvalues of the symmetric matrix a and
+// the matching eigenvectors as the columns of the second result, using
+// Householder tridiagonalization followed by the implicit QL algorithm
+// (the tred2 and tql2 routines of EISPACK, as ported by JAMA). a is not
+// modified.
+func symmetricEigen(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	v := make([][]float64, n)
+	for i := range v {
+		v[i] = append([]float64(nil), a[i]...)
+	}
+	d := make([]float64, n)
+	e := make([]float64, n)
+	if n == 0 {
+		return d, v
+	}
+
+	tridiagonalize(v, d, e)
+	diagonalize(v, d, e)
+
+	return d, v
+}
+
+// tridiagonalize reduces the symmetric matrix in v to tridiagonal form by
+// Householder reflections, leaving the diagonal in d, the subdiagonal in
+// e and the accumulated transformation in v
+func tridiagonalize(v [][]float64, d, e []float64) {
+	n := len(v)
+	for j := 0; j < n; j++ {
+		d[j] = v[n-1][j]
+	}
+
+	for i := n - 1; i > 0; i-- {
+		// Scale to avoid under/overflow
+		scale := 0.0
+		h := 0.0
+		for k := 0; k < i; k++ {
+			scale += math.Abs(d[k])
+		}
+
+		if scale == 0 {
+			e[i] = d[i-1]
+			for j := 0; j < i; j++ {
+				d[j] = v[i-1][j]
+				v[i][j] = 0
+				v[j][i] = 0
+			}
+		} else {
+			// Generate the Householder vector
+			for k := 0; k < i; k++ {
+				d[k] /= scale
+				h += d[k] * d[k]
+			}
+			f := d[i-1]
+			g := math.Sqrt(h)
+			if f > 0 {
+				g = -g
+			}
+			e[i] = scale * g
+			h -= f * g
+			d[i-1] = f - g
+			for j := 0; j < i; j++ {
+				e[j] = 0
+			}
+
+			// Apply the similarity transformation to the remaining columns
+			for j := 0; j < i; j++ {
+				f = d[j]
+				v[j][i] = f
+				g = e[j] + v[j][j]*f
+				for k := j + 1; k <= i-1; k++ {
+					g += v[k][j] * d[k]
+					e[k] += v[k][j] * f
+				}
+				e[j] = g
+			}
+			f = 0
+			for j := 0; j < i; j++ {
+				e[j] /= h
+				f += e[j] * d[j]
+			}
+			hh := f / (h + h)
+			for j := 0; j < i; j++ {
+				e[j] -= hh * d[j]
+			}
+			for j := 0; j < i; j++ {
+				f = d[j]
+				g = e[j]
+				for k := j; k <= i-1; k++ {
+					v[k][j] -= f*e[k] + g*d[k]
+				}
+				d[j] = v[i-1][j]
+				v[i][j] = 0
+			}
+		}
+		d[i] = h
+	}
+
+	// Accumulate the transformations
+	for i := 0; i < n-1; i++ {
+		v[n-1][i] = v[i][i]
+		v[i][i] = 1
+		h := d[i+1]
+		if h != 0 {
+			for k := 0; k <= i; k++ {
+				d[k] = v[k][i+1] / h
+			}
+			for j := 0; j <= i; j++ {
+				g := 0.0
+				for k := 0; k <= i; k++ {
+					g += v[k][i+1] * v[k][j]
+				}
+				for k := 0; k <= i; k++ {
+					v[k][j] -= g * d[k]
+				}
+			}
+		}
+		for k := 0; k <= i; k++ {
+			v[k][i+1] = 0
+		}
+	}
+	for j := 0; j < n; j++ {
+		d[j] = v[n-1][j]
+		v[n-1][j] = 0
+	}
+	v[n-1][n-1] = 1
+	e[0] = 0
+}
+
+// diagonalize runs the implicit QL algorithm on the tridiagonal matrix
+// in d and e, leaving the eigenvalues in d and the eigenvectors in the
+// columns of v
+func diagonalize(v [][]float64, d, e []float64) {
+	n := len(v)
+	for i := 1; i < n; i++ {
+		e[i-1] = e[i]
+	}
+	e[n-1] = 0
+
+	f := 0.0
+	tst1 := 0.0
+	eps := math.Pow(2, -52)
+	for l := 0; l < n; l++ {
+		// Find a small subdiagonal element
+		tst1 = math.Max(tst1, math.Abs(d[l])+math.Abs(e[l]))
+		m := l
+		for m < n-1 && math.Abs(e[m]) > eps*tst1 {
+			m++
+		}
+
+		// If m == l, d[l] is already an eigenvalue; otherwise iterate
+		if m > l {
+			for {
+				// Compute the implicit shift
+				g := d[l]
+				p := (d[l+1] - g) / (2 * e[l])
+				r := math.Hypot(p, 1)
+				if p < 0 {
+					r = -r
+				}
+				d[l] = e[l] / (p + r)
+				d[l+1] = e[l] * (p + r)
+				dl1 := d[l+1]
+				h := g - d[l]
+				for i := l + 2; i < n; i++ {
+					d[i] -= h
+				}
+				f += h
+
+				// Implicit QL transformation
+				p = d[m]
+				c, c2, c3 := 1.0, 1.0, 1.0
+				el1 := e[l+1]
+				s, s2 := 0.0, 0.0
+				for i := m - 1; i >= l; i-- {
+					c3 = c2
+					c2 = c
+					s2 = s
+					g = c * e[i]
+					h = c * p
+					r = math.Hypot(p, e[i])
+					e[i+1] = s * r
+					s = e[i] / r
+					c = p / r
+					p = c*d[i] - s*g
+					d[i+1] = h + s*(c*g+s*d[i])
+
+					// Accumulate the transformation
+					for k := 0; k < n; k++ {
+						h = v[k][i+1]
+						v[k][i+1] = s*v[k][i] + c*h
+						v[k][i] = c*v[k][i] - s*h
+					}
+				}
+				p = -s * s2 * c3 * el1 * e[l] / dl1
+				e[l] = s * p
+				d[l] = c * p
+
+				// Check for convergence
+				if math.Abs(e[l]) <= eps*tst1 {
+					break
+				}
+			}
+		}
+		d[l] += f
+		e[l] = 0
+	}
+}
@@ -0,0 +1,102 @@
+package scann
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+// worse reports whether a ranks after b (larger distance, ties broken by larger ID)
+func (a candidate) worse(b candidate) bool {
+	if a.dist != b.dist {
+		return a.dist > b.dist
+	}
+	return a.id > b.id
+}
+
+// candidateHeap is a max-heap holding the best n candidates seen so far
+type candidateHeap []candidate
+
+func (h candidateHeap) Len() int            { return len(h) }
+func (h candidateHeap) Less(i, j int) bool  { return h[i].worse(h[j]) }
+func (h candidateHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *candidateHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *candidateHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// offer adds a candidate if it belongs in the best n
+func (h *candidateHeap) offer(c candidate, n int) {
+	if h.Len() < n {
+		heap.Push(h, c)
+	} else if (*h)[0].worse(c) {
+		(*h)[0] = c
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the candidates ordered best first
+func (h candidateHeap) sorted() []candidate {
+	results := make([]candidate, len(h))
+	copy(results, h)
+	sort.Slice(results, func(i, j int) bool {
+		return results[j].worse(results[i])
+	})
+	return results
+}
+
+// scanPartitions scores the given partitions and returns the best n
+// candidates, best first. With more than one worker, partitions are scanned
+// concurrently; each worker keeps a local heap and merges it into the shared
+// one when it finishes, so the result is identical to a sequential scan.
+func scanPartitions(partitions []int, n, workers int, scan func(partition int, top *candidateHeap)) []candidate {
+	if n <= 0 || len(partitions) == 0 {
+		return []candidate{}
+	}
+
+	if workers > len(partitions) {
+		workers = len(partitions)
+	}
+
+	shared := make(candidateHeap, 0, n)
+	if workers <= 1 {
+		for _, p := range partitions {
+			scan(p, &shared)
+		}
+		return shared.sorted()
+	}
+
+	jobs := make(chan int, len(partitions))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make(candidateHeap, 0, n)
+			for p := range jobs {
+				scan(p, &local)
+			}
+
+			mu.Lock()
+			for _, c := range local {
+				shared.offer(c, n)
+			}
+			mu.Unlock()
+		}()
+	}
+
+	for _, p := range partitions {
+		jobs <- p
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return shared.sorted()
+}
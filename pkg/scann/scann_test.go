@@ -1,7 +1,13 @@
 package scann
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
@@ -84,6 +90,56 @@ func TestSCANN_Add(t *testing.T) {
 	}
 }
 
+func TestSCANN_Remove(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPartitions = 10
+	config.NumSubvectors = 8
+	config.BitsPerCode = 4
+
+	scann := NewSCANN(config)
+	vectors := generateRandomVectors(500, 64)
+	scann.Train(vectors)
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	scann.Add(vectors, ids, nil)
+	before := scann.GetMemoryUsage()
+
+	if err := scann.Remove(7); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := scann.Remove(7); err == nil {
+		t.Error("Expected removing a removed vector to fail")
+	}
+	if scann.Size() != len(vectors)-1 {
+		t.Errorf("Expected %d vectors, got %d", len(vectors)-1, scann.Size())
+	}
+	if after := scann.GetMemoryUsage(); after >= before {
+		t.Errorf("Expected memory to shrink after Remove, got %d -> %d", before, after)
+	}
+
+	resultIDs, _, err := scann.Search(vectors[7], 10, config.NumPartitions)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	for _, id := range resultIDs {
+		if id == 7 {
+			t.Fatal("Expected the removed vector to be gone from search results")
+		}
+	}
+
+	// A removed ID can be inserted again
+	if err := scann.Insert(vectors[7], 7, nil); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	resultIDs, _, _ = scann.Search(vectors[7], 1, config.NumPartitions)
+	if len(resultIDs) != 1 || resultIDs[0] != 7 {
+		t.Errorf("Expected the reinserted vector first, got %v", resultIDs)
+	}
+}
+
 func TestSCANN_Search(t *testing.T) {
 	config := DefaultConfig()
 	config.NumPartitions = 50
@@ -123,6 +179,115 @@ func TestSCANN_Search(t *testing.T) {
 	}
 }
 
+func TestSCANN_SearchErrors(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPartitions = 4
+	config.NumSubvectors = 4
+	config.BitsPerCode = 4
+
+	scann := NewSCANN(config)
+	vectors := generateRandomVectors(200, 16)
+
+	var searchErr *SearchError
+	_, _, err := scann.Search(vectors[0], 10, 2)
+	if !errors.As(err, &searchErr) || searchErr.Trained || searchErr.Value != 16 {
+		t.Fatalf("Expected an untrained SearchError, got %v", err)
+	}
+
+	if err := scann.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    []float32
+		k        int
+		nprobe   int
+		param    string
+		value    int
+		contains string
+	}{
+		{"long query", append(vectors[0][:16:16], 1), 10, 2, ParamQuery, 17, "expects 16 dimensions, got 17"},
+		{"negative k", vectors[0], -3, 2, ParamK, -3, "invalid k: -3"},
+		{"zero nprobe", vectors[0], 10, 0, ParamNprobe, 0, "invalid nprobe: 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, search := range []func() error{
+				func() error { _, _, err := scann.Search(tt.query, tt.k, tt.nprobe); return err },
+				func() error { _, _, err := scann.SearchWithFilter(tt.query, tt.k, tt.nprobe, nil); return err },
+			} {
+				err := search()
+				if !errors.As(err, &searchErr) {
+					t.Fatalf("Expected a SearchError, got %v", err)
+				}
+				if searchErr.Param != tt.param || searchErr.Value != tt.value || !searchErr.Trained || searchErr.Expected != 16 {
+					t.Errorf("Unexpected SearchError: %+v", searchErr)
+				}
+				if !strings.Contains(err.Error(), tt.contains) {
+					t.Errorf("Expected %q in %q", tt.contains, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestSCANN_ConcurrentInsert(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPartitions = 10
+	config.NumSubvectors = 8
+
+	scann := NewSCANN(config)
+	vectors := generateRandomVectors(800, 64)
+
+	if err := scann.Insert(vectors[0], 0, nil); err == nil {
+		t.Error("Expected Insert before Train to fail")
+	}
+	scann.Train(vectors)
+
+	// Insert from many goroutines while others search
+	numGoroutines := 8
+	done := make(chan bool)
+	for g := 0; g < numGoroutines; g++ {
+		go func(g int) {
+			for i := g; i < len(vectors); i += numGoroutines {
+				meta := map[string]interface{}{"writer": g}
+				if err := scann.Insert(vectors[i], i, meta); err != nil {
+					t.Errorf("Concurrent insert failed: %v", err)
+				}
+				if i%10 == 0 {
+					if _, _, err := scann.Search(vectors[i], 5, 2); err != nil {
+						t.Errorf("Concurrent search failed: %v", err)
+					}
+				}
+			}
+			done <- true
+		}(g)
+	}
+	for g := 0; g < numGoroutines; g++ {
+		<-done
+	}
+
+	if total := scann.GetStats()["total_entries"].(int); total != len(vectors) {
+		t.Fatalf("Expected %d entries, got %d", len(vectors), total)
+	}
+
+	// Every vector is found by searching for it
+	for i, vec := range vectors {
+		ids, _, err := scann.Search(vec, 1, config.NumPartitions)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(ids) == 0 || ids[0] != i {
+			t.Errorf("Search for vector %d returned %v", i, ids)
+		}
+	}
+
+	if err := scann.Insert(vectors[0][:10], 1000, nil); err == nil {
+		t.Error("Expected Insert with the wrong dimension to fail")
+	}
+}
+
 func TestSCANN_SearchWithFilter(t *testing.T) {
 	config := DefaultConfig()
 	config.NumPartitions = 30
@@ -170,6 +335,160 @@ func TestSCANN_SearchWithFilter(t *testing.T) {
 	t.Logf("Filtered search returned %d results", len(resultIDs))
 }
 
+func TestSCANN_ParallelSearchMatchesSequential(t *testing.T) {
+	for _, storeVectors := range []bool{true, false} {
+		config := DefaultConfig()
+		config.NumPartitions = 32
+		config.NumSubvectors = 8
+		config.ReorderTopK = 50
+		config.StoreVectors = storeVectors
+
+		scann := NewSCANN(config)
+		vectors := generateRandomVectors(2000, 64)
+
+		if err := scann.Train(vectors); err != nil {
+			t.Fatalf("Train failed: %v", err)
+		}
+
+		ids := make([]int, len(vectors))
+		metadata := make([]map[string]interface{}, len(vectors))
+		for i := range ids {
+			ids[i] = i
+			metadata[i] = map[string]interface{}{"even": i%2 == 0}
+		}
+		scann.Add(vectors, ids, metadata)
+
+		evenOnly := func(m map[string]interface{}) bool { return m["even"].(bool) }
+
+		for q := 0; q < 20; q++ {
+			query := vectors[q*100]
+
+			scann.SetSearchWorkers(1)
+			seqIDs, seqDists, err := scann.Search(query, 10, 16)
+			if err != nil {
+				t.Fatalf("Sequential search failed: %v", err)
+			}
+			seqFilteredIDs, _, _ := scann.SearchWithFilter(query, 10, 16, evenOnly)
+
+			scann.SetSearchWorkers(8)
+			parIDs, parDists, err := scann.Search(query, 10, 16)
+			if err != nil {
+				t.Fatalf("Parallel search failed: %v", err)
+			}
+			parFilteredIDs, _, _ := scann.SearchWithFilter(query, 10, 16, evenOnly)
+
+			if len(seqIDs) != 10 || len(parIDs) != len(seqIDs) {
+				t.Fatalf("Query %d: expected 10 results, got %d sequential and %d parallel", q, len(seqIDs), len(parIDs))
+			}
+			for i := range seqIDs {
+				if seqIDs[i] != parIDs[i] || seqDists[i] != parDists[i] {
+					t.Errorf("StoreVectors=%v query %d rank %d: sequential (%d, %f) != parallel (%d, %f)",
+						storeVectors, q, i, seqIDs[i], seqDists[i], parIDs[i], parDists[i])
+				}
+			}
+
+			if !reflect.DeepEqual(seqFilteredIDs, parFilteredIDs) {
+				t.Errorf("StoreVectors=%v query %d: filtered results differ: sequential %v, parallel %v",
+					storeVectors, q, seqFilteredIDs, parFilteredIDs)
+			}
+			for _, id := range seqFilteredIDs {
+				if id%2 != 0 {
+					t.Errorf("Filtered result %d should be even", id)
+				}
+			}
+		}
+	}
+}
+
+func TestSCANN_Reordering(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPartitions = 10
+	config.NumSubvectors = 8
+	config.BitsPerCode = 4
+
+	scann := NewSCANN(config)
+	vectors := generateRandomVectors(1000, 64)
+
+	scann.Train(vectors)
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	scann.Add(vectors, ids, nil)
+
+	k := 10
+	recall := func() float64 {
+		var hits, total int
+		for q := 0; q < 20; q++ {
+			query := vectors[q*7]
+			truth := bruteForceCosine(vectors, query, k)
+
+			resultIDs, _, err := scann.Search(query, k, config.NumPartitions)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			for _, id := range resultIDs {
+				if truth[id] {
+					hits++
+				}
+			}
+			total += k
+		}
+		return float64(hits) / float64(total)
+	}
+
+	withReorder := recall()
+	config.UseReordering = false
+	withoutReorder := recall()
+
+	t.Logf("Recall@%d: %.2f with reordering, %.2f without", k, withReorder, withoutReorder)
+	if withReorder <= withoutReorder {
+		t.Errorf("Expected reordering to raise recall: %.2f <= %.2f", withReorder, withoutReorder)
+	}
+	if withReorder < 0.75 {
+		t.Errorf("Expected recall >= 0.75 with reordering, got %.2f", withReorder)
+	}
+}
+
+func TestSCANN_StoreVectorsDisabled(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPartitions = 10
+	config.NumSubvectors = 8
+	config.BitsPerCode = 4
+	config.StoreVectors = false
+
+	scann := NewSCANN(config)
+	vectors := generateRandomVectors(500, 64)
+
+	scann.Train(vectors)
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	scann.Add(vectors, ids, nil)
+
+	for _, list := range scann.invertedLists {
+		for _, entry := range list {
+			if entry.Vector != nil {
+				t.Fatalf("Expected no stored vector for ID %d", entry.ID)
+			}
+		}
+	}
+
+	resultIDs, _, err := scann.Search(vectors[0], 10, 5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resultIDs) != 10 {
+		t.Errorf("Expected 10 results, got %d", len(resultIDs))
+	}
+	if scann.GetStats()["reordering"].(bool) {
+		t.Error("Expected reordering to be reported as disabled")
+	}
+}
+
 func TestSCANN_CompressionRatio(t *testing.T) {
 	config := DefaultConfig()
 	config.NumPartitions = 50
@@ -193,6 +512,129 @@ func TestSCANN_CompressionRatio(t *testing.T) {
 	t.Logf("Compression: %.1fx, %d bytes per vector", compressionRatio, bytesPerVector)
 }
 
+func TestSCANN_SerializeRoundTrip(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPartitions = 10
+	config.NumSubvectors = 8
+	config.BitsPerCode = 4
+	config.Metric = quantization.EuclideanDistance
+	config.UseRotation = true
+
+	scann := NewSCANN(config)
+	if _, err := scann.Serialize(); err == nil {
+		t.Error("Expected an untrained index to fail to serialize")
+	}
+
+	vectors := generateRandomVectors(500, 32)
+	if err := scann.Train(vectors); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	ids := make([]int, len(vectors))
+	metadata := make([]map[string]interface{}, len(vectors))
+	for i := range ids {
+		ids[i] = i * 3
+		if i%2 == 0 {
+			metadata[i] = map[string]interface{}{"category": i % 10, "name": "even"}
+		}
+	}
+	if err := scann.Add(vectors, ids, metadata); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	data, err := scann.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	loaded := NewSCANN(nil)
+	if err := loaded.Deserialize(data); err != nil {
+		t.Fatalf("Deserialize failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.config, scann.config) {
+		t.Errorf("Config mismatch:\ngot  %+v\nwant %+v", loaded.config, scann.config)
+	}
+	if !reflect.DeepEqual(loaded.invertedLists, scann.invertedLists) {
+		t.Error("Inverted lists differ after round trip")
+	}
+	if loaded.aq.rotation == nil || !reflect.DeepEqual(loaded.aq.rotation, scann.aq.rotation) {
+		t.Error("Rotation differs after round trip")
+	}
+
+	filter := func(meta map[string]interface{}) bool {
+		cat, ok := meta["category"].(int)
+		return ok && cat == 4
+	}
+	for q := 0; q < 10; q++ {
+		query := vectors[q*13]
+
+		wantIDs, wantDists, _ := scann.Search(query, 10, 3)
+		gotIDs, gotDists, err := loaded.Search(query, 10, 3)
+		if err != nil {
+			t.Fatalf("Search on loaded index failed: %v", err)
+		}
+		if !reflect.DeepEqual(gotIDs, wantIDs) || !reflect.DeepEqual(gotDists, wantDists) {
+			t.Errorf("Query %d: results differ after round trip: %v vs %v", q, gotIDs, wantIDs)
+		}
+
+		wantIDs, _, _ = scann.SearchWithFilter(query, 5, 10, filter)
+		gotIDs, _, _ = loaded.SearchWithFilter(query, 5, 10, filter)
+		if len(wantIDs) == 0 || !reflect.DeepEqual(gotIDs, wantIDs) {
+			t.Errorf("Query %d: filtered results differ after round trip: %v vs %v", q, gotIDs, wantIDs)
+		}
+	}
+
+	// New vectors encode the same way in the loaded index
+	if err := loaded.Add(vectors[:1], []int{9999}, nil); err != nil {
+		t.Errorf("Add to loaded index failed: %v", err)
+	}
+}
+
+func TestSCANN_DeserializeRejectsBadInput(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPartitions = 4
+	config.NumSubvectors = 4
+	config.BitsPerCode = 4
+
+	scann := NewSCANN(config)
+	vectors := generateRandomVectors(100, 16)
+	scann.Train(vectors)
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	scann.Add(vectors, ids, nil)
+
+	data, err := scann.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	badVersion := append([]byte(nil), data...)
+	badVersion[4] = 99
+	badMagic := append([]byte(nil), data...)
+	badMagic[0] = 'X'
+	// Claim one more partition than the file holds
+	badPartitions := append([]byte(nil), data...)
+	badPartitions[9]++
+
+	cases := map[string][]byte{
+		"version":    badVersion,
+		"magic":      badMagic,
+		"partitions": badPartitions,
+		"truncated":  data[:len(data)-10],
+		"empty":      nil,
+	}
+	for name, input := range cases {
+		loaded := NewSCANN(nil)
+		if err := loaded.Deserialize(input); err == nil {
+			t.Errorf("Expected %s corruption to be rejected", name)
+		}
+		if loaded.trained {
+			t.Errorf("Expected a failed load (%s) to leave the index untouched", name)
+		}
+	}
+}
+
 func TestAnisotropicQuantizer_Train(t *testing.T) {
 	aq := NewAnisotropicQuantizer(768, 16, 8)
 
@@ -296,6 +738,159 @@ func TestAnisotropicQuantizer_Serialize(t *testing.T) {
 	}
 }
 
+func TestAnisotropicQuantizer_Rotation(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	vectors := generateCorrelatedVectors(rng, 2000, 32, 16)
+	queries := generateCorrelatedVectors(rng, 50, 32, 16)
+	config := quantization.DefaultConfig()
+
+	plain := NewAnisotropicQuantizer(32, 4, 6)
+	if err := plain.Train(vectors, config); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+
+	rotated := NewAnisotropicQuantizer(32, 4, 6)
+	rotated.SetUseRotation(true)
+	if err := rotated.Train(vectors, config); err != nil {
+		t.Fatalf("Train with rotation failed: %v", err)
+	}
+
+	// The rotation is orthonormal
+	for i, row := range rotated.rotation {
+		for j, other := range rotated.rotation {
+			want := float32(0)
+			if i == j {
+				want = 1
+			}
+			if dot := quantization.DotProductFloat32(row, other); math.Abs(float64(dot-want)) > 1e-4 {
+				t.Fatalf("Rotation rows %d and %d have dot product %f, want %f", i, j, dot, want)
+			}
+		}
+	}
+
+	// Decode undoes the rotation
+	for _, vec := range vectors[:20] {
+		decoded := rotated.Decode(rotated.Encode(vec))
+		codes := rotated.Encode(decoded)
+		if !reflect.DeepEqual(codes, rotated.Encode(vec)) {
+			t.Errorf("Decoded vector encodes to %v, want %v", codes, rotated.Encode(vec))
+		}
+	}
+
+	plainRecall, rotatedRecall := adcRecall(plain, vectors, queries), adcRecall(rotated, vectors, queries)
+	t.Logf("Recall@10: %.3f without rotation, %.3f with rotation", plainRecall, rotatedRecall)
+	if rotatedRecall < plainRecall+0.1 {
+		t.Errorf("Expected rotation to raise recall by at least 0.1 on correlated data, got %.3f vs %.3f",
+			rotatedRecall, plainRecall)
+	}
+
+	data, err := rotated.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded := NewAnisotropicQuantizer(0, 0, 0)
+	if err := loaded.Deserialize(data); err != nil {
+		t.Fatalf("Deserialize failed: %v", err)
+	}
+	if !loaded.useRotation || !reflect.DeepEqual(loaded.rotation, rotated.rotation) {
+		t.Error("Rotation differs after round trip")
+	}
+	if err := loaded.Deserialize(data[:len(data)-4]); err == nil {
+		t.Error("Expected a truncated rotation to fail to deserialize")
+	}
+}
+
+func TestAnisotropicQuantizer_BalancedSubvectors(t *testing.T) {
+	if got := balancedSubvectorDims([]float64{1, 1, 1, 1, 1, 1, 1, 1}, 4); !reflect.DeepEqual(got, []int{2, 2, 2, 2}) {
+		t.Errorf("Uniform variance: got subvector dims %v, want [2 2 2 2]", got)
+	}
+	if got := balancedSubvectorDims([]float64{9, 3, 3, 1, 1, 1, 0, 0}, 3); !reflect.DeepEqual(got, []int{1, 1, 6}) {
+		t.Errorf("Skewed variance: got subvector dims %v, want [1 1 6]", got)
+	}
+	if got := balancedSubvectorDims([]float64{100, 0, 0, 0}, 4); !reflect.DeepEqual(got, []int{1, 1, 1, 1}) {
+		t.Errorf("Every subvector needs a dimension: got %v", got)
+	}
+
+	// Independent dimensions whose variance decays along the vector
+	rng := rand.New(rand.NewSource(11))
+	skewed := func(n int) [][]float32 {
+		vectors := make([][]float32, n)
+		for i := range vectors {
+			vectors[i] = make([]float32, 32)
+			for d := range vectors[i] {
+				vectors[i][d] = float32(rng.NormFloat64()) / float32(1+d)
+			}
+		}
+		return vectors
+	}
+	vectors := skewed(2000)
+	queries := skewed(50)
+	config := quantization.DefaultConfig()
+
+	balanced := NewAnisotropicQuantizer(32, 4, 6)
+	if err := balanced.Train(vectors, config); err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	if balanced.subvectorDims[0] >= balanced.subvectorDims[3] {
+		t.Errorf("Expected narrower subvectors for high-variance dimensions, got %v", balanced.subvectorDims)
+	}
+
+	equal := NewAnisotropicQuantizer(32, 4, 6)
+	equal.subvectorDims = equalSubvectorDims(32, 4)
+	if err := equal.trainCodebooks(vectors, config); err != nil {
+		t.Fatalf("Training equal subvectors failed: %v", err)
+	}
+
+	equalRecall, balancedRecall := adcRecall(equal, vectors, queries), adcRecall(balanced, vectors, queries)
+	t.Logf("Recall@10: %.3f with equal subvectors %v, %.3f with balanced subvectors %v",
+		equalRecall, equal.subvectorDims, balancedRecall, balanced.subvectorDims)
+	if balancedRecall < equalRecall+0.05 {
+		t.Errorf("Expected balanced subvectors to raise recall by at least 0.05 on skewed data, got %.3f vs %.3f",
+			balancedRecall, equalRecall)
+	}
+
+	// Serialization keeps the subvector sizes
+	data, err := balanced.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded := NewAnisotropicQuantizer(0, 0, 0)
+	if err := loaded.Deserialize(data); err != nil {
+		t.Fatalf("Deserialize failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.subvectorDims, balanced.subvectorDims) {
+		t.Errorf("Subvector dims %v after round trip, want %v", loaded.subvectorDims, balanced.subvectorDims)
+	}
+}
+
+func TestSymmetricEigen(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	n := 12
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			a[i][j] = rng.NormFloat64()
+			a[j][i] = a[i][j]
+		}
+	}
+
+	values, vectors := symmetricEigen(a)
+	for c, value := range values {
+		for i := 0; i < n; i++ {
+			var av float64
+			for j := 0; j < n; j++ {
+				av += a[i][j] * vectors[j][c]
+			}
+			if math.Abs(av-value*vectors[i][c]) > 1e-9 {
+				t.Fatalf("Eigenpair %d: (Av)[%d] = %f, want %f", c, i, av, value*vectors[i][c])
+			}
+		}
+	}
+}
+
 // Helper functions
 
 func generateRandomVectors(n, dim int) [][]float32 {
@@ -309,6 +904,94 @@ func generateRandomVectors(n, dim int) [][]float32 {
 	return vectors
 }
 
+// bruteForceCosine returns the IDs of the k nearest vectors by cosine distance
+// generateCorrelatedVectors draws vectors from a rank-dimensional subspace
+// with decaying variance plus a little isotropic noise, like embeddings
+func generateCorrelatedVectors(rng *rand.Rand, n, dim, rank int) [][]float32 {
+	basis := make([][]float32, rank)
+	for r := range basis {
+		basis[r] = make([]float32, dim)
+		for d := range basis[r] {
+			basis[r][d] = float32(rng.NormFloat64())
+		}
+	}
+
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vec := make([]float32, dim)
+		for r, direction := range basis {
+			weight := float32(rng.NormFloat64()) / float32(r+1)
+			for d := range vec {
+				vec[d] += weight * direction[d]
+			}
+		}
+		for d := range vec {
+			vec[d] += 0.05 * float32(rng.NormFloat64())
+		}
+		vectors[i] = vec
+	}
+	return vectors
+}
+
+// adcRecall returns the recall@10 of ranking vectors by their asymmetric
+// distance to each query against the exact Euclidean ranking
+func adcRecall(aq *AnisotropicQuantizer, vectors, queries [][]float32) float64 {
+	codes := make([][]byte, len(vectors))
+	for i, vec := range vectors {
+		codes[i] = aq.Encode(vec)
+	}
+
+	hits := 0
+	for _, query := range queries {
+		exact := make([]float32, len(vectors))
+		approx := make([]float32, len(vectors))
+		distTable := aq.ComputeDistanceTable(query)
+		for i, vec := range vectors {
+			exact[i] = quantization.EuclideanDistanceFloat32(query, vec)
+			approx[i] = aq.AsymmetricDistance(distTable, codes[i])
+		}
+
+		truth := make(map[int]bool, 10)
+		for _, id := range topIDs(exact, 10) {
+			truth[id] = true
+		}
+		for _, id := range topIDs(approx, 10) {
+			if truth[id] {
+				hits++
+			}
+		}
+	}
+	return float64(hits) / float64(10*len(queries))
+}
+
+// topIDs returns the indices of the k smallest distances
+func topIDs(distances []float32, k int) []int {
+	ids := make([]int, len(distances))
+	for i := range ids {
+		ids[i] = i
+	}
+	sort.Slice(ids, func(a, b int) bool { return distances[ids[a]] < distances[ids[b]] })
+	return ids[:k]
+}
+
+func bruteForceCosine(vectors [][]float32, query []float32, k int) map[int]bool {
+	ids := make([]int, len(vectors))
+	dists := make([]float32, len(vectors))
+	for i, vec := range vectors {
+		ids[i] = i
+		dists[i] = quantization.CosineDistanceFloat32(query, vec)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		return dists[ids[a]] < dists[ids[b]]
+	})
+
+	truth := make(map[int]bool, k)
+	for _, id := range ids[:k] {
+		truth[id] = true
+	}
+	return truth
+}
+
 // Benchmarks
 
 func BenchmarkSCANN_Search(b *testing.B) {
@@ -336,6 +1019,38 @@ func BenchmarkSCANN_Search(b *testing.B) {
 	}
 }
 
+func BenchmarkSCANN_SearchParallel(b *testing.B) {
+	config := DefaultConfig()
+	config.NumPartitions = 100
+	config.NumSubvectors = 16
+	config.BitsPerCode = 8
+
+	scann := NewSCANN(config)
+	vectors := generateRandomVectors(50000, 128)
+
+	scann.Train(vectors[:5000])
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	scann.Add(vectors, ids, nil)
+
+	query := vectors[0]
+
+	for _, nprobe := range []int{10, 50} {
+		for _, workers := range []int{1, 2, 4, 8} {
+			b.Run(fmt.Sprintf("nprobe=%d/workers=%d", nprobe, workers), func(b *testing.B) {
+				scann.SetSearchWorkers(workers)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					scann.Search(query, 10, nprobe)
+				}
+			})
+		}
+	}
+}
+
 func BenchmarkAnisotropicQuantizer_Encode(b *testing.B) {
 	aq := NewAnisotropicQuantizer(768, 16, 8)
 
@@ -3,6 +3,8 @@ package search
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -10,6 +12,15 @@ import (
 type Filter interface {
 	// Match returns true if the given metadata passes the filter
 	Match(metadata map[string]interface{}) bool
+
+	// String returns a canonical representation; logically equal filters
+	// (e.g. AND/OR children in any order) produce the same string
+	String() string
+
+	// Hash returns a stable hash of the canonical representation,
+	// suitable for cache keys and deduplication; the gRPC result cache
+	// keys filtered searches on it
+	Hash() uint64
 }
 
 // FilterOperator defines the type of filter operation
@@ -22,17 +33,23 @@ const (
 	OpLessThan    FilterOperator = "lt"       // Less than
 	OpGreaterOrEq FilterOperator = "gte"      // Greater than or equal
 	OpLessOrEq    FilterOperator = "lte"      // Less than or equal
+	OpIEquals     FilterOperator = "ieq"      // Equals, ignoring case (strings only)
+	OpContains    FilterOperator = "contains" // Contains substring (strings only)
+	OpStartsWith  FilterOperator = "starts_with" // Has prefix (strings only)
 	OpIn          FilterOperator = "in"       // In list
 	OpNotIn       FilterOperator = "not_in"   // Not in list
 	OpRange       FilterOperator = "range"    // Range (min, max)
 	OpGeoRadius   FilterOperator = "geo_radius" // Geographic radius
+	OpGeoBoundingBox FilterOperator = "geo_bounding_box" // Geographic bounding box
 	OpExists      FilterOperator = "exists"   // Field exists
 	OpAnd         FilterOperator = "and"      // Logical AND
 	OpOr          FilterOperator = "or"       // Logical OR
 	OpNot         FilterOperator = "not"      // Logical NOT
 )
 
-// ComparisonFilter filters based on field comparison
+// ComparisonFilter filters based on field comparison. When the field holds
+// a []interface{} or []string, eq matches if any element equals the value
+// and ne matches if none does.
 type ComparisonFilter struct {
 	Field    string
 	Operator FilterOperator
@@ -48,10 +65,10 @@ func (f *ComparisonFilter) Match(metadata map[string]interface{}) bool {
 
 	switch f.Operator {
 	case OpEquals:
-		return equals(fieldValue, f.Value)
+		return anyEquals(fieldValue, f.Value)
 
 	case OpNotEquals:
-		return !equals(fieldValue, f.Value)
+		return !anyEquals(fieldValue, f.Value)
 
 	case OpGreaterThan:
 		return compare(fieldValue, f.Value) > 0
@@ -67,6 +84,9 @@ func (f *ComparisonFilter) Match(metadata map[string]interface{}) bool {
 		cmp := compare(fieldValue, f.Value)
 		return cmp < 0 || cmp == 0
 
+	case OpIEquals, OpContains, OpStartsWith:
+		return matchString(f.Operator, fieldValue, f.Value)
+
 	case OpExists:
 		return exists
 
@@ -75,6 +95,29 @@ func (f *ComparisonFilter) Match(metadata map[string]interface{}) bool {
 	}
 }
 
+// matchString applies a string-only operator; non-string values never match
+func matchString(op FilterOperator, fieldValue, value interface{}) bool {
+	s, ok := fieldValue.(string)
+	if !ok {
+		return false
+	}
+	v, ok := value.(string)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case OpIEquals:
+		return strings.EqualFold(s, v)
+	case OpContains:
+		return strings.Contains(s, v)
+	case OpStartsWith:
+		return strings.HasPrefix(s, v)
+	default:
+		return false
+	}
+}
+
 // RangeFilter filters based on numeric range
 type RangeFilter struct {
 	Field string
@@ -101,7 +144,9 @@ func (f *RangeFilter) Match(metadata map[string]interface{}) bool {
 	return true
 }
 
-// InListFilter filters based on whether value is in a list
+// InListFilter filters based on whether value is in a list. When the field
+// holds a []interface{} or []string, IN matches if any element is in the
+// list and NOT IN matches if none is.
 type InListFilter struct {
 	Field  string
 	Values []interface{}
@@ -117,7 +162,7 @@ func (f *InListFilter) Match(metadata map[string]interface{}) bool {
 
 	found := false
 	for _, v := range f.Values {
-		if equals(fieldValue, v) {
+		if anyEquals(fieldValue, v) {
 			found = true
 			break
 		}
@@ -145,16 +190,62 @@ type GeoRadiusFilter struct {
 
 // Match implements Filter interface
 func (f *GeoRadiusFilter) Match(metadata map[string]interface{}) bool {
-	fieldValue, exists := metadata[f.Field]
-	if !exists {
+	point, ok := geoPointField(metadata, f.Field)
+	if !ok {
 		return false
 	}
 
-	// Extract GeoPoint from field
-	var point GeoPoint
+	// Calculate distance
+	distance := haversineDistance(f.Center, point)
+
+	// Check against radius
+	radius := f.RadiusMeters
+	if radius == 0 {
+		radius = f.RadiusKm * 1000 // Convert km to meters
+	}
+
+	return distance <= radius
+}
+
+// GeoBoundingBoxFilter filters on a point lying inside a latitude/longitude
+// box. A box with MinLon > MaxLon crosses the antimeridian and covers
+// MinLon..180 and -180..MaxLon.
+type GeoBoundingBoxFilter struct {
+	Field  string  // Field containing GeoPoint
+	MinLat float64 // Southern edge
+	MinLon float64 // Western edge
+	MaxLat float64 // Northern edge
+	MaxLon float64 // Eastern edge
+}
+
+// Match implements Filter interface
+func (f *GeoBoundingBoxFilter) Match(metadata map[string]interface{}) bool {
+	point, ok := geoPointField(metadata, f.Field)
+	if !ok {
+		return false
+	}
+
+	if point.Lat < f.MinLat || point.Lat > f.MaxLat {
+		return false
+	}
+	if f.MinLon <= f.MaxLon {
+		return point.Lon >= f.MinLon && point.Lon <= f.MaxLon
+	}
+	// Crosses the antimeridian
+	return point.Lon >= f.MinLon || point.Lon <= f.MaxLon
+}
+
+// geoPointField extracts a GeoPoint, or a map with "lat" and "lon" keys,
+// from a metadata field
+func geoPointField(metadata map[string]interface{}, field string) (GeoPoint, bool) {
+	fieldValue, exists := metadata[field]
+	if !exists {
+		return GeoPoint{}, false
+	}
+
 	switch v := fieldValue.(type) {
 	case GeoPoint:
-		point = v
+		return v, true
 	case map[string]interface{}:
 		lat, latOk := v["lat"].(float64)
 		lon, lonOk := v["lon"].(float64)
@@ -163,21 +254,9 @@ func (f *GeoRadiusFilter) Match(metadata map[string]interface{}) bool {
 			lat = toFloat64(v["lat"])
 			lon = toFloat64(v["lon"])
 		}
-		point = GeoPoint{Lat: lat, Lon: lon}
-	default:
-		return false
-	}
-
-	// Calculate distance
-	distance := haversineDistance(f.Center, point)
-
-	// Check against radius
-	radius := f.RadiusMeters
-	if radius == 0 {
-		radius = f.RadiusKm * 1000 // Convert km to meters
+		return GeoPoint{Lat: lat, Lon: lon}, true
 	}
-
-	return distance <= radius
+	return GeoPoint{}, false
 }
 
 // CompositeFilter combines multiple filters with logical operations
@@ -234,6 +313,29 @@ func (f *ExistsFilter) Match(metadata map[string]interface{}) bool {
 // Helper functions
 
 // equals compares two values for equality
+// anyEquals compares a field value to v, treating a slice field as a set
+// that matches if any element equals v
+func anyEquals(fieldValue, v interface{}) bool {
+	switch elems := fieldValue.(type) {
+	case []interface{}:
+		for _, e := range elems {
+			if equals(e, v) {
+				return true
+			}
+		}
+		return false
+	case []string:
+		for _, e := range elems {
+			if equals(e, v) {
+				return true
+			}
+		}
+		return false
+	default:
+		return equals(fieldValue, v)
+	}
+}
+
 func equals(a, b interface{}) bool {
 	// Handle nil cases
 	if a == nil && b == nil {
@@ -257,6 +359,12 @@ func equals(a, b interface{}) bool {
 		// Try converting b to int
 		return av == int(toFloat64(b))
 
+	case int64:
+		if bv, ok := b.(int64); ok {
+			return av == bv
+		}
+		return float64(av) == toFloat64(b)
+
 	case float64:
 		return av == toFloat64(b)
 
@@ -269,6 +377,10 @@ func equals(a, b interface{}) bool {
 		if bv, ok := b.(bool); ok {
 			return av == bv
 		}
+		// Filters from the API carry booleans as "true" or "false"
+		if bv, ok := b.(string); ok {
+			return strconv.FormatBool(av) == bv
+		}
 
 	case time.Time:
 		if bv, ok := b.(time.Time); ok {
@@ -373,6 +485,13 @@ func Ne(field string, value interface{}) Filter {
 	}
 }
 
+// ArrayContains creates a filter matching an array field that contains
+// value. It is Eq under another name: Eq already matches any element of an
+// array field.
+func ArrayContains(field string, value interface{}) Filter {
+	return Eq(field, value)
+}
+
 // Gt creates a greater-than filter
 func Gt(field string, value interface{}) Filter {
 	return &ComparisonFilter{
@@ -409,6 +528,33 @@ func Lte(field string, value interface{}) Filter {
 	}
 }
 
+// IEq creates a case-insensitive string equality filter
+func IEq(field string, value string) Filter {
+	return &ComparisonFilter{
+		Field:    field,
+		Operator: OpIEquals,
+		Value:    value,
+	}
+}
+
+// Contains creates a substring filter
+func Contains(field string, substr string) Filter {
+	return &ComparisonFilter{
+		Field:    field,
+		Operator: OpContains,
+		Value:    substr,
+	}
+}
+
+// StartsWith creates a string prefix filter
+func StartsWith(field string, prefix string) Filter {
+	return &ComparisonFilter{
+		Field:    field,
+		Operator: OpStartsWith,
+		Value:    prefix,
+	}
+}
+
 // Range creates a range filter
 func Range(field string, min, max interface{}) Filter {
 	return &RangeFilter{
@@ -454,6 +600,18 @@ func GeoRadiusMeters(field string, lat, lon, radiusMeters float64) Filter {
 	}
 }
 
+// GeoBoundingBox creates a geographic bounding box filter. Pass
+// minLon > maxLon for a box that crosses the antimeridian.
+func GeoBoundingBox(field string, minLat, minLon, maxLat, maxLon float64) Filter {
+	return &GeoBoundingBoxFilter{
+		Field:  field,
+		MinLat: minLat,
+		MinLon: minLon,
+		MaxLat: maxLat,
+		MaxLon: maxLon,
+	}
+}
+
 // Exists creates an exists filter
 func Exists(field string) Filter {
 	return &ExistsFilter{
@@ -0,0 +1,159 @@
+package search
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// String implements Filter interface
+func (f *ComparisonFilter) String() string {
+	return string(f.Operator) + "(" + strconv.Quote(f.Field) + "," + formatFilterValue(f.Value) + ")"
+}
+
+// Hash implements Filter interface
+func (f *ComparisonFilter) Hash() uint64 {
+	return hashFilterString(f.String())
+}
+
+// String implements Filter interface
+func (f *RangeFilter) String() string {
+	return string(OpRange) + "(" + strconv.Quote(f.Field) + "," +
+		formatFilterValue(f.Min) + "," + formatFilterValue(f.Max) + ")"
+}
+
+// Hash implements Filter interface
+func (f *RangeFilter) Hash() uint64 {
+	return hashFilterString(f.String())
+}
+
+// String implements Filter interface
+// List membership is a set, so values are sorted and deduplicated
+func (f *InListFilter) String() string {
+	op := OpIn
+	if f.Negate {
+		op = OpNotIn
+	}
+
+	values := make([]string, len(f.Values))
+	for i, v := range f.Values {
+		values[i] = formatFilterValue(v)
+	}
+
+	return string(op) + "(" + strconv.Quote(f.Field) + ",[" + strings.Join(sortedUnique(values), ",") + "])"
+}
+
+// Hash implements Filter interface
+func (f *InListFilter) Hash() uint64 {
+	return hashFilterString(f.String())
+}
+
+// String implements Filter interface
+// The radius is normalized to meters, matching how Match interprets it
+func (f *GeoRadiusFilter) String() string {
+	radius := f.RadiusMeters
+	if radius == 0 {
+		radius = f.RadiusKm * 1000
+	}
+
+	return string(OpGeoRadius) + "(" + strconv.Quote(f.Field) + "," +
+		formatFloat(f.Center.Lat) + "," + formatFloat(f.Center.Lon) + "," + formatFloat(radius) + ")"
+}
+
+// Hash implements Filter interface
+func (f *GeoRadiusFilter) Hash() uint64 {
+	return hashFilterString(f.String())
+}
+
+// String implements Filter interface
+func (f *GeoBoundingBoxFilter) String() string {
+	return string(OpGeoBoundingBox) + "(" + strconv.Quote(f.Field) + "," +
+		formatFloat(f.MinLat) + "," + formatFloat(f.MinLon) + "," +
+		formatFloat(f.MaxLat) + "," + formatFloat(f.MaxLon) + ")"
+}
+
+// Hash implements Filter interface
+func (f *GeoBoundingBoxFilter) Hash() uint64 {
+	return hashFilterString(f.String())
+}
+
+// String implements Filter interface
+// AND and OR are commutative, so their children are sorted
+func (f *CompositeFilter) String() string {
+	children := make([]string, len(f.Filters))
+	for i, filter := range f.Filters {
+		children[i] = filter.String()
+	}
+
+	if f.Operator == OpAnd || f.Operator == OpOr {
+		sort.Strings(children)
+	}
+
+	return string(f.Operator) + "(" + strings.Join(children, ",") + ")"
+}
+
+// Hash implements Filter interface
+func (f *CompositeFilter) Hash() uint64 {
+	return hashFilterString(f.String())
+}
+
+// String implements Filter interface
+func (f *ExistsFilter) String() string {
+	if f.Exists {
+		return string(OpExists) + "(" + strconv.Quote(f.Field) + ")"
+	}
+	return string(OpNot) + "(" + string(OpExists) + "(" + strconv.Quote(f.Field) + "))"
+}
+
+// Hash implements Filter interface
+func (f *ExistsFilter) Hash() uint64 {
+	return hashFilterString(f.String())
+}
+
+// hashFilterString hashes a canonical filter string with 64-bit FNV-1a
+func hashFilterString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// formatFilterValue formats a filter operand canonically.
+// Numeric types are normalized to float64 since comparisons treat them alike.
+func formatFilterValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case time.Time:
+		return "time:" + val.UTC().Format(time.RFC3339Nano)
+	case GeoPoint:
+		return "geo:" + formatFloat(val.Lat) + ":" + formatFloat(val.Lon)
+	case float64, float32, int, int32, int64, uint, uint32, uint64:
+		return formatFloat(toFloat64(val))
+	default:
+		return strconv.Quote(fmt.Sprintf("%T:%v", val, val))
+	}
+}
+
+// formatFloat formats a float with the shortest exact representation
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// sortedUnique sorts strings and removes duplicates
+func sortedUnique(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
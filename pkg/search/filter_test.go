@@ -52,6 +52,135 @@ func TestComparisonFilter_NotEquals(t *testing.T) {
 	}
 }
 
+func TestArrayFieldFilters(t *testing.T) {
+	tagged := map[string]interface{}{"tags": []interface{}{"go", "db", "search"}}
+	stringTagged := map[string]interface{}{"tags": []string{"go", "db", "search"}}
+	numbers := map[string]interface{}{"years": []interface{}{2020, 2022}}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		metadata map[string]interface{}
+		want     bool
+	}{
+		{"array contains", ArrayContains("tags", "db"), tagged, true},
+		{"array does not contain", ArrayContains("tags", "rust"), tagged, false},
+		{"string slice contains", ArrayContains("tags", "search"), stringTagged, true},
+		{"string slice does not contain", ArrayContains("tags", "rust"), stringTagged, false},
+		{"eq matches any element", Eq("tags", "go"), tagged, true},
+		{"ne with element present", Ne("tags", "go"), tagged, false},
+		{"ne with element absent", Ne("tags", "rust"), stringTagged, true},
+		{"in overlaps", In("tags", "rust", "db"), tagged, true},
+		{"in disjoint", In("tags", "rust", "java"), stringTagged, false},
+		{"not in overlaps", NotIn("tags", "rust", "db"), stringTagged, false},
+		{"not in disjoint", NotIn("tags", "rust", "java"), tagged, true},
+		{"numeric elements", ArrayContains("years", 2022.0), numbers, true},
+		{"empty array", ArrayContains("tags", "go"), map[string]interface{}{"tags": []string{}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.metadata); got != tt.want {
+				t.Errorf("%s.Match() = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparisonFilter_TypedValues(t *testing.T) {
+	metadata := map[string]interface{}{"year": int64(2023), "score": 0.75, "published": true}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"int64 equals float", Eq("year", 2023.0), true},
+		{"int64 equals int64", Eq("year", int64(2023)), true},
+		{"int64 not equal", Eq("year", 2024.0), false},
+		{"int64 greater than", Gt("year", 2020.0), true},
+		{"int64 less than", Lt("year", 2020.0), false},
+		{"float in range", Range("score", 0.5, 1.0), true},
+		{"bool equals bool", Eq("published", true), true},
+		{"bool equals string", Eq("published", "true"), true},
+		{"bool not equal string", Eq("published", "false"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(metadata); got != tt.want {
+				t.Errorf("%s.Match() = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparisonFilter_Strings(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   Filter
+		metadata map[string]interface{}
+		want     bool
+	}{
+		{
+			name:     "ieq matches other case",
+			filter:   IEq("category", "Tech"),
+			metadata: map[string]interface{}{"category": "tech"},
+			want:     true,
+		},
+		{
+			name:     "ieq no match",
+			filter:   IEq("category", "Tech"),
+			metadata: map[string]interface{}{"category": "technology"},
+			want:     false,
+		},
+		{
+			name:     "contains match",
+			filter:   Contains("title", "vector"),
+			metadata: map[string]interface{}{"title": "a vector database"},
+			want:     true,
+		},
+		{
+			name:     "contains is case-sensitive",
+			filter:   Contains("title", "Vector"),
+			metadata: map[string]interface{}{"title": "a vector database"},
+			want:     false,
+		},
+		{
+			name:     "starts with match",
+			filter:   StartsWith("path", "/docs/"),
+			metadata: map[string]interface{}{"path": "/docs/api.md"},
+			want:     true,
+		},
+		{
+			name:     "starts with no match",
+			filter:   StartsWith("path", "/docs/"),
+			metadata: map[string]interface{}{"path": "/src/docs/api.md"},
+			want:     false,
+		},
+		{
+			name:     "non-string field",
+			filter:   StartsWith("year", "20"),
+			metadata: map[string]interface{}{"year": 2024},
+			want:     false,
+		},
+		{
+			name:     "field missing",
+			filter:   Contains("title", ""),
+			metadata: map[string]interface{}{"type": "article"},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.metadata); got != tt.want {
+				t.Errorf("%s.Match() = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestComparisonFilter_Numeric(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -213,6 +342,76 @@ func TestGeoRadiusFilter_GeoPoint(t *testing.T) {
 	}
 }
 
+func TestGeoBoundingBoxFilter(t *testing.T) {
+	// Roughly the San Francisco Bay Area
+	filter := GeoBoundingBox("location", 37.2, -122.6, 38.0, -121.7)
+
+	tests := []struct {
+		name string
+		lat  float64
+		lon  float64
+		want bool
+	}{
+		{"inside", 37.7749, -122.4194, true},
+		{"on the edge", 37.2, -121.7, true},
+		{"north of the box", 38.5, -122.0, false},
+		{"east of the box", 37.5, -121.0, false},
+		{"west of the box", 37.5, -123.0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]interface{}{
+				"location": map[string]interface{}{
+					"lat": tt.lat,
+					"lon": tt.lon,
+				},
+			}
+			if got := filter.Match(metadata); got != tt.want {
+				t.Errorf("GeoBoundingBox().Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if filter.Match(map[string]interface{}{"location": "37.7,-122.4"}) {
+		t.Error("GeoBoundingBox() should not match a non-point field")
+	}
+	if filter.Match(map[string]interface{}{}) {
+		t.Error("GeoBoundingBox() should not match a missing field")
+	}
+}
+
+func TestGeoBoundingBoxFilter_Antimeridian(t *testing.T) {
+	// Fiji spans the 180° meridian: 170°E to 170°W
+	filter := GeoBoundingBox("location", -21, 170, -12, -170)
+
+	tests := []struct {
+		name string
+		lon  float64
+		want bool
+	}{
+		{"east of the meridian", 178.4, true},
+		{"west of the meridian", -179.9, true},
+		{"on the meridian", 180, true},
+		{"on the western edge", 170, true},
+		{"on the eastern edge", -170, true},
+		{"outside, east hemisphere", 160, false},
+		{"outside, west hemisphere", -160, false},
+		{"prime meridian", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]interface{}{
+				"location": GeoPoint{Lat: -17.7, Lon: tt.lon},
+			}
+			if got := filter.Match(metadata); got != tt.want {
+				t.Errorf("GeoBoundingBox().Match() at lon %v = %v, want %v", tt.lon, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCompositeFilter_And(t *testing.T) {
 	filter := And(
 		Eq("category", "tech"),
@@ -485,6 +684,78 @@ func TestToFloat64(t *testing.T) {
 	}
 }
 
+func TestFilterHash(t *testing.T) {
+	a := Eq("category", "tech")
+	b := Gt("year", 2020)
+	c := In("tag", "go", "rust")
+
+	equal := []struct {
+		name string
+		x, y Filter
+	}{
+		{"And is order-insensitive", And(a, b), And(b, a)},
+		{"Or is order-insensitive", Or(a, b, c), Or(c, a, b)},
+		{"nested composites", And(Or(a, b), c), And(c, Or(b, a))},
+		{"In list is a set", In("tag", "go", "rust"), In("tag", "rust", "go", "go")},
+		{"numeric types normalize", Gt("year", 2020), Gt("year", 2020.0)},
+		{"geo radius units", GeoRadius("loc", 1, 2, 3), GeoRadiusMeters("loc", 1, 2, 3000)},
+		{"separately built", Eq("category", "tech"), Eq("category", "tech")},
+	}
+	for _, tt := range equal {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.x.Hash() != tt.y.Hash() {
+				t.Errorf("Hash differs: %s vs %s", tt.x, tt.y)
+			}
+			if tt.x.String() != tt.y.String() {
+				t.Errorf("String differs: %s vs %s", tt.x, tt.y)
+			}
+		})
+	}
+
+	distinct := []Filter{
+		a,
+		b,
+		c,
+		Eq("category", "science"),
+		Ne("category", "tech"),
+		IEq("category", "tech"),
+		Contains("category", "tech"),
+		StartsWith("category", "tech"),
+		Eq("year", "2020"), // String, not number
+		NotIn("tag", "go", "rust"),
+		Range("year", 2020, 2024),
+		Range("year", 2020, nil),
+		Exists("category"),
+		NotExists("category"),
+		And(a, b),
+		Or(a, b),
+		Not(And(a, b)),
+		And(a, b, c),
+		Not(a),
+		GeoRadius("loc", 1, 2, 3),
+		GeoBoundingBox("loc", 1, 2, 3, 4),
+		GeoBoundingBox("loc", 1, 4, 3, 2),
+		Eq("a,b", "c"),
+		Eq("a", "b,c"),
+	}
+	seen := make(map[uint64]string)
+	for _, f := range distinct {
+		if prev, ok := seen[f.Hash()]; ok {
+			t.Errorf("Hash collision between %s and %s", prev, f)
+		}
+		seen[f.Hash()] = f.String()
+	}
+
+	// Hash is stable across calls
+	if And(a, b).Hash() != And(a, b).Hash() {
+		t.Error("Hash is not stable")
+	}
+
+	if got, want := And(b, a).String(), `and(eq("category","tech"),gt("year",2020))`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
+
 func BenchmarkComparisonFilter(b *testing.B) {
 	filter := Eq("category", "tech")
 	metadata := map[string]interface{}{"category": "tech"}
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"sync"
 	"unicode"
+	"unicode/utf8"
+	"unsafe"
 )
 
 // Document represents a searchable document with text content and metadata
@@ -14,12 +16,43 @@ type Document struct {
 	Metadata map[string]interface{}
 }
 
+// ScoringModel selects how FullTextIndex ranks matching documents
+type ScoringModel int
+
+const (
+	// BM25 (Best Matching 25) is a probabilistic ranking function with
+	// term frequency saturation and document length normalization
+	BM25 ScoringModel = iota
+	// TFIDF is classic term frequency * inverse document frequency
+	TFIDF
+)
+
+// String returns the name of the scoring model
+func (m ScoringModel) String() string {
+	switch m {
+	case BM25:
+		return "BM25"
+	case TFIDF:
+		return "TFIDF"
+	default:
+		return "unknown"
+	}
+}
+
 // FullTextIndex implements BM25-based full-text search
 // BM25 (Best Matching 25) is a probabilistic ranking function used by search engines
 type FullTextIndex struct {
 	// Configuration parameters
-	k1 float64 // Term frequency saturation parameter (typical: 1.2-2.0)
-	b  float64 // Length normalization parameter (typical: 0.75)
+	model ScoringModel // Score computation (postings and analyzer are shared)
+	k1    float64      // Term frequency saturation parameter (typical: 1.2-2.0)
+	b     float64      // Length normalization parameter (typical: 0.75)
+
+	tokenizer TokenizerConfig // Splits document text and queries into terms
+
+	// Snippet generation
+	highlightPre  string // Inserted before matched terms
+	highlightPost string // Inserted after matched terms
+	snippetLength int    // Snippet length in characters (0 = no snippets)
 
 	// Index structures
 	documents     map[uint64]*Document         // Document storage
@@ -27,6 +60,9 @@ type FullTextIndex struct {
 	docLengths    map[uint64]int               // Document lengths (word count)
 	avgDocLength  float64                      // Average document length
 	docCount      int                          // Total number of documents
+	vocabulary    []string                     // Sorted indexed terms, for prefix queries
+
+	maxPrefixExpansions int // Terms one prefix query term may expand to
 
 	mu sync.RWMutex
 }
@@ -36,52 +72,249 @@ type FullTextResult struct {
 	ID       uint64
 	Score    float64
 	Document *Document
+	Snippet  string // Matched text window with query terms highlighted
 }
 
 // NewFullTextIndex creates a new full-text search index with BM25 scoring
 func NewFullTextIndex() *FullTextIndex {
+	return NewFullTextIndexWithModel(BM25)
+}
+
+// NewFullTextIndexWithModel creates a new full-text search index using the given scoring model
+func NewFullTextIndexWithModel(model ScoringModel) *FullTextIndex {
+	idx := newFullTextIndex(model)
+	idx.tokenizer = legacyTokenizerConfig()
+	return idx
+}
+
+// NewFullTextIndexWithConfig creates a BM25 full-text search index that
+// tokenizes with the given configuration, e.g. DefaultTokenizerConfig()
+func NewFullTextIndexWithConfig(config TokenizerConfig) *FullTextIndex {
+	idx := newFullTextIndex(BM25)
+	idx.tokenizer = config.clone()
+	return idx
+}
+
+func newFullTextIndex(model ScoringModel) *FullTextIndex {
 	return &FullTextIndex{
+		model:         model,
 		k1:            1.5,  // Standard BM25 k1 parameter
 		b:             0.75, // Standard BM25 b parameter
+		highlightPre:  DefaultHighlightDelimiter,
+		highlightPost: DefaultHighlightDelimiter,
+		snippetLength: DefaultSnippetLength,
 		documents:     make(map[uint64]*Document),
 		invertedIndex: make(map[string]map[uint64]int),
 		docLengths:    make(map[uint64]int),
+		maxPrefixExpansions: DefaultMaxPrefixExpansions,
 	}
 }
 
-// SetParameters allows customization of BM25 parameters
-func (idx *FullTextIndex) SetParameters(k1, b float64) {
+// SetBM25Params sets the BM25 term frequency saturation (k1) and length
+// normalization (b) parameters. Larger k1 lets repeated terms keep adding
+// to the score; b ranges from 0 (ignore document length) to 1 (fully
+// normalize by it). Defaults are k1=1.5 and b=0.75. Scores are computed at
+// query time, so the new values apply to already indexed documents.
+func (idx *FullTextIndex) SetBM25Params(k1, b float64) {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 	idx.k1 = k1
 	idx.b = b
 }
 
-// tokenize splits text into lowercase words, removing punctuation
-func tokenize(text string) []string {
+// SetParameters allows customization of BM25 parameters (see SetBM25Params)
+func (idx *FullTextIndex) SetParameters(k1, b float64) {
+	idx.SetBM25Params(k1, b)
+}
+
+// DisableLengthNormalization sets b=0 so document length does not affect
+// scores, which suits short fields such as titles
+func (idx *FullTextIndex) DisableLengthNormalization() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.b = 0
+}
+
+// BM25Params returns the current k1 and b parameters
+func (idx *FullTextIndex) BM25Params() (k1, b float64) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.k1, idx.b
+}
+
+// Model returns the scoring model used by the index
+func (idx *FullTextIndex) Model() ScoringModel {
+	return idx.model
+}
+
+// Tokenizer modes
+const (
+	TokenizerWhitespace = "whitespace" // Each word is a term
+	TokenizerNgram      = "ngram"      // CJK text becomes overlapping character bigrams; other words stay whole
+)
+
+// TokenizerConfig controls how document text and queries are split into
+// terms. Text is always lowercased and split on anything that is not a
+// letter or digit; lengths count characters, not bytes.
+//
+// Chinese and Japanese are written without spaces, so in whitespace mode a
+// whole sentence is one term. Ngram mode splits each run of CJK characters
+// into overlapping bigrams ("向量数据" -> "向量", "量数", "数据"), so any
+// substring of two or more characters matches; a lone CJK character is
+// its own term. CJK terms are exempt from the length bounds.
+type TokenizerConfig struct {
+	Mode      string          // TokenizerWhitespace (or "") or TokenizerNgram
+	Stopwords map[string]bool // Lowercase terms to drop (nil = keep every term)
+	MinLength int             // Shortest term kept
+	MaxLength int             // Longest term kept (0 = unlimited)
+}
+
+// DefaultTokenizerConfig drops common English stopwords and keeps terms of
+// any length, so short terms like "ai", "go" and "c" stay searchable
+func DefaultTokenizerConfig() TokenizerConfig {
+	return TokenizerConfig{
+		Stopwords: EnglishStopwords(),
+		MinLength: 1,
+	}
+}
+
+// legacyTokenizerConfig keeps terms of two or more characters and no
+// stopword list, matching indexes created by NewFullTextIndex
+func legacyTokenizerConfig() TokenizerConfig {
+	return TokenizerConfig{MinLength: 2}
+}
+
+// EnglishStopwords returns a new set of common English stopwords
+func EnglishStopwords() map[string]bool {
+	words := []string{
+		"a", "about", "above", "after", "again", "against", "all", "am", "an", "and",
+		"any", "are", "as", "at", "be", "because", "been", "before", "being", "below",
+		"between", "both", "but", "by", "can", "could", "did", "do", "does", "doing",
+		"down", "during", "each", "few", "for", "from", "further", "had", "has", "have",
+		"having", "he", "her", "here", "hers", "herself", "him", "himself", "his", "how",
+		"i", "if", "in", "into", "is", "it", "its", "itself", "just", "me",
+		"more", "most", "my", "myself", "no", "nor", "not", "now", "of", "off",
+		"on", "once", "only", "or", "other", "our", "ours", "ourselves", "out", "over",
+		"own", "same", "she", "should", "so", "some", "such", "than", "that", "the",
+		"their", "theirs", "them", "themselves", "then", "there", "these", "they", "this", "those",
+		"through", "to", "too", "under", "until", "up", "very", "was", "we", "were",
+		"what", "when", "where", "which", "while", "who", "whom", "why", "will", "with",
+		"would", "you", "your", "yours", "yourself", "yourselves",
+	}
+
+	stopwords := make(map[string]bool, len(words))
+	for _, w := range words {
+		stopwords[w] = true
+	}
+	return stopwords
+}
+
+// clone copies the stopword set so later changes by the caller do not
+// affect an index
+func (c TokenizerConfig) clone() TokenizerConfig {
+	if c.Stopwords != nil {
+		stopwords := make(map[string]bool, len(c.Stopwords))
+		for w, ok := range c.Stopwords {
+			if ok {
+				stopwords[strings.ToLower(w)] = true
+			}
+		}
+		c.Stopwords = stopwords
+	}
+	return c
+}
+
+// tokenize splits text into lowercase words, removing punctuation, and
+// drops stopwords and terms outside the configured length bounds
+func (c TokenizerConfig) tokenize(text string) []string {
 	// Convert to lowercase and split into words
 	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
 		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
 	})
 
-	// Filter out very short tokens (< 2 chars)
 	filtered := make([]string, 0, len(words))
 	for _, word := range words {
-		if len(word) >= 2 {
-			filtered = append(filtered, word)
+		if c.Mode == TokenizerNgram && hasCJK(word) {
+			for _, span := range cjkSpans([]rune(word)) {
+				if (span.gram || c.keepLength(span.end-span.start)) && !c.Stopwords[span.term] {
+					filtered = append(filtered, span.term)
+				}
+			}
+			continue
+		}
+
+		if !c.keepLength(utf8.RuneCountInString(word)) {
+			continue
+		}
+		if c.Stopwords[word] {
+			continue
 		}
+		filtered = append(filtered, word)
 	}
 
 	return filtered
 }
 
+// keepLength reports whether a term of n characters is within the length
+// bounds
+func (c TokenizerConfig) keepLength(n int) bool {
+	return n >= c.MinLength && (c.MaxLength == 0 || n <= c.MaxLength)
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// hasCJK reports whether a word holds any CJK character
+func hasCJK(word string) bool {
+	for _, r := range word {
+		if isCJK(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// cjkSpans splits a lowercase word into ngram mode terms: each run of CJK
+// characters into overlapping bigrams (or one character, for a run of
+// one), and each other run whole. Offsets are runes into word.
+func cjkSpans(word []rune) []tokenSpan {
+	var spans []tokenSpan
+	for start := 0; start < len(word); {
+		cjk := isCJK(word[start])
+		end := start + 1
+		for end < len(word) && isCJK(word[end]) == cjk {
+			end++
+		}
+
+		switch {
+		case !cjk:
+			spans = append(spans, tokenSpan{term: string(word[start:end]), start: start, end: end})
+		case end-start == 1:
+			spans = append(spans, tokenSpan{term: string(word[start]), start: start, end: end, gram: true})
+		default:
+			for i := start; i+2 <= end; i++ {
+				spans = append(spans, tokenSpan{term: string(word[i : i+2]), start: i, end: i + 2, gram: true})
+			}
+		}
+		start = end
+	}
+	return spans
+}
+
+// tokenize splits text the way NewFullTextIndex does
+func tokenize(text string) []string {
+	return legacyTokenizerConfig().tokenize(text)
+}
+
 // Index adds or updates a document in the full-text index
 func (idx *FullTextIndex) Index(doc *Document) error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 
 	// Tokenize the document text
-	tokens := tokenize(doc.Text)
+	tokens := idx.tokenizer.tokenize(doc.Text)
 
 	// Remove old document if it exists
 	if oldDoc, exists := idx.documents[doc.ID]; exists {
@@ -103,6 +336,7 @@ func (idx *FullTextIndex) Index(doc *Document) error {
 	for term, freq := range termFreq {
 		if idx.invertedIndex[term] == nil {
 			idx.invertedIndex[term] = make(map[uint64]int)
+			idx.addTermLocked(term)
 		}
 		idx.invertedIndex[term][doc.ID] = freq
 	}
@@ -139,7 +373,7 @@ func (idx *FullTextIndex) Remove(docID uint64) error {
 
 // removeDocumentLocked removes a document (must be called with lock held)
 func (idx *FullTextIndex) removeDocumentLocked(doc *Document) {
-	tokens := tokenize(doc.Text)
+	tokens := idx.tokenizer.tokenize(doc.Text)
 	termFreq := make(map[string]int)
 	for _, token := range tokens {
 		termFreq[token]++
@@ -151,6 +385,7 @@ func (idx *FullTextIndex) removeDocumentLocked(doc *Document) {
 			delete(postings, doc.ID)
 			if len(postings) == 0 {
 				delete(idx.invertedIndex, term)
+				idx.removeTermLocked(term)
 			}
 		}
 	}
@@ -175,8 +410,52 @@ func (idx *FullTextIndex) updateAvgDocLengthLocked() {
 	idx.avgDocLength = float64(totalLength) / float64(idx.docCount)
 }
 
-// Search performs BM25-based full-text search
-// Returns top k documents ranked by BM25 score
+// idfLocked computes the inverse document frequency of a term appearing in df documents
+func (idx *FullTextIndex) idfLocked(df int) float64 {
+	N := float64(idx.docCount)
+	n := float64(df)
+
+	if idx.model == TFIDF {
+		// Smoothed IDF = log((1 + N) / (1 + df)) + 1
+		// Stays positive for terms that appear in every document
+		return math.Log((1+N)/(1+n)) + 1
+	}
+
+	// BM25 uses IDF+ (ensures positive values)
+	// IDF = log(1 + (N - df + 0.5) / (df + 0.5))
+	// where N = total docs, df = document frequency
+	return math.Log(1 + (N-n+0.5)/(n+0.5))
+}
+
+// termScoreLocked computes one term's contribution to a document's score
+func (idx *FullTextIndex) termScoreLocked(idf float64, termFreq, docLength int) float64 {
+	tf := float64(termFreq)
+
+	if idx.model == TFIDF {
+		// TF-IDF formula: score = tf * IDF
+		// No saturation or length normalization
+		return tf * idf
+	}
+
+	// BM25 formula:
+	// score = IDF * (tf * (k1 + 1)) / (tf + k1 * (1 - b + b * (dl / avgdl)))
+	// where:
+	//   tf = term frequency in document
+	//   dl = document length
+	//   avgdl = average document length
+	//   k1, b = tuning parameters
+	dl := float64(docLength)
+	avgdl := idx.avgDocLength
+
+	numerator := tf * (idx.k1 + 1)
+	denominator := tf + idx.k1*(1-idx.b+idx.b*(dl/avgdl))
+
+	return idf * (numerator / denominator)
+}
+
+// Search performs full-text search using the index's scoring model
+// Returns top k documents ranked by score. A query word ending in '*'
+// matches every indexed term with that prefix (see SetMaxPrefixExpansions).
 func (idx *FullTextIndex) Search(query string, k int) []*FullTextResult {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
@@ -185,13 +464,13 @@ func (idx *FullTextIndex) Search(query string, k int) []*FullTextResult {
 		return nil
 	}
 
-	// Tokenize query
-	queryTokens := tokenize(query)
+	// Tokenize query, expanding prefix terms
+	queryTokens := idx.queryTermsLocked(query)
 	if len(queryTokens) == 0 {
 		return nil
 	}
 
-	// Calculate BM25 scores for all documents
+	// Calculate scores for all documents
 	scores := make(map[uint64]float64)
 
 	for _, term := range queryTokens {
@@ -200,31 +479,11 @@ func (idx *FullTextIndex) Search(query string, k int) []*FullTextResult {
 			continue // Term not in index
 		}
 
-		// Calculate IDF for this term using IDF+ (ensures positive values)
-		// IDF = log(1 + (N - df + 0.5) / (df + 0.5))
-		// where N = total docs, df = document frequency
-		N := float64(idx.docCount)
-		df := float64(len(postings))
-		idf := math.Log(1 + (N-df+0.5)/(df+0.5))
+		idf := idx.idfLocked(len(postings))
 
-		// Calculate BM25 component for each document containing this term
+		// Accumulate this term's contribution for each document containing it
 		for docID, termFreq := range postings {
-			// BM25 formula:
-			// score = IDF * (tf * (k1 + 1)) / (tf + k1 * (1 - b + b * (dl / avgdl)))
-			// where:
-			//   tf = term frequency in document
-			//   dl = document length
-			//   avgdl = average document length
-			//   k1, b = tuning parameters
-
-			tf := float64(termFreq)
-			dl := float64(idx.docLengths[docID])
-			avgdl := idx.avgDocLength
-
-			numerator := tf * (idx.k1 + 1)
-			denominator := tf + idx.k1*(1-idx.b+idx.b*(dl/avgdl))
-
-			scores[docID] += idf * (numerator / denominator)
+			scores[docID] += idx.termScoreLocked(idf, termFreq, idx.docLengths[docID])
 		}
 	}
 
@@ -246,6 +505,7 @@ func (idx *FullTextIndex) Search(query string, k int) []*FullTextResult {
 		results = results[:k]
 	}
 
+	idx.addSnippetsLocked(results, query)
 	return results
 }
 
@@ -258,13 +518,13 @@ func (idx *FullTextIndex) SearchWithFilter(query string, k int, filter FilterFun
 		return nil
 	}
 
-	// Tokenize query
-	queryTokens := tokenize(query)
+	// Tokenize query, expanding prefix terms
+	queryTokens := idx.queryTermsLocked(query)
 	if len(queryTokens) == 0 {
 		return nil
 	}
 
-	// Calculate BM25 scores for all documents
+	// Calculate scores for all documents
 	scores := make(map[uint64]float64)
 
 	for _, term := range queryTokens {
@@ -273,9 +533,7 @@ func (idx *FullTextIndex) SearchWithFilter(query string, k int, filter FilterFun
 			continue
 		}
 
-		N := float64(idx.docCount)
-		df := float64(len(postings))
-		idf := math.Log(1 + (N-df+0.5)/(df+0.5))
+		idf := idx.idfLocked(len(postings))
 
 		for docID, termFreq := range postings {
 			// Apply filter
@@ -284,14 +542,7 @@ func (idx *FullTextIndex) SearchWithFilter(query string, k int, filter FilterFun
 				continue
 			}
 
-			tf := float64(termFreq)
-			dl := float64(idx.docLengths[docID])
-			avgdl := idx.avgDocLength
-
-			numerator := tf * (idx.k1 + 1)
-			denominator := tf + idx.k1*(1-idx.b+idx.b*(dl/avgdl))
-
-			scores[docID] += idf * (numerator / denominator)
+			scores[docID] += idx.termScoreLocked(idf, termFreq, idx.docLengths[docID])
 		}
 	}
 
@@ -311,6 +562,7 @@ func (idx *FullTextIndex) SearchWithFilter(query string, k int, filter FilterFun
 		results = results[:k]
 	}
 
+	idx.addSnippetsLocked(results, query)
 	return results
 }
 
@@ -328,13 +580,37 @@ func (idx *FullTextIndex) Size() int {
 	return idx.docCount
 }
 
-// sortByScore sorts results by score in descending order
+// MemoryUsage estimates the bytes held by the index: document text and
+// structs, the inverted index postings and document lengths. Document
+// metadata is owned by the caller and not counted.
+func (idx *FullTextIndex) MemoryUsage() int64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// Approximate map entry overhead (key, value and bucket share)
+	const entryOverhead = 48
+
+	var total int64
+	for _, doc := range idx.documents {
+		total += entryOverhead + int64(unsafe.Sizeof(*doc)) + int64(len(doc.Text))
+	}
+	for term, postings := range idx.invertedIndex {
+		total += entryOverhead + int64(len(term)) + int64(len(postings))*entryOverhead
+	}
+	total += int64(len(idx.vocabulary)) * int64(unsafe.Sizeof(""))
+	total += int64(len(idx.docLengths)) * entryOverhead
+
+	return total
+}
+
+// sortByScore sorts results by score in descending order, ties by ascending ID
 func sortByScore(results []*FullTextResult) {
 	// Simple insertion sort (efficient for small k)
 	for i := 1; i < len(results); i++ {
 		key := results[i]
 		j := i - 1
-		for j >= 0 && results[j].Score < key.Score {
+		for j >= 0 && (results[j].Score < key.Score ||
+			results[j].Score == key.Score && results[j].ID > key.ID) {
 			results[j+1] = results[j]
 			j--
 		}
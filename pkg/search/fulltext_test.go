@@ -7,6 +7,7 @@ import (
 func TestTokenize(t *testing.T) {
 	tests := []struct {
 		name     string
+		config   *TokenizerConfig // nil = NewFullTextIndex behavior
 		text     string
 		expected []string
 	}{
@@ -35,11 +36,64 @@ func TestTokenize(t *testing.T) {
 			text:     "a b cd ef ghi",
 			expected: []string{"cd", "ef", "ghi"},
 		},
+		{
+			name:     "default config keeps short terms and drops stopwords",
+			config:   &TokenizerConfig{Stopwords: EnglishStopwords(), MinLength: 1},
+			text:     "The AI is written in Go and C",
+			expected: []string{"ai", "written", "go", "c"},
+		},
+		{
+			name:     "custom stopwords",
+			config:   &TokenizerConfig{Stopwords: map[string]bool{"vector": true}},
+			text:     "vector DB for a vector",
+			expected: []string{"db", "for", "a"},
+		},
+		{
+			name:     "min and max length",
+			config:   &TokenizerConfig{MinLength: 3, MaxLength: 5},
+			text:     "go ml embed vectors",
+			expected: []string{"embed"},
+		},
+		{
+			name:     "length counts characters",
+			config:   &TokenizerConfig{MinLength: 2, MaxLength: 4},
+			text:     "é café naïve",
+			expected: []string{"café"},
+		},
+		{
+			name:     "ngram splits CJK into bigrams",
+			config:   &TokenizerConfig{Mode: TokenizerNgram, MinLength: 2},
+			text:     "向量数据库",
+			expected: []string{"向量", "量数", "数据", "据库"},
+		},
+		{
+			name:     "ngram keeps other words whole",
+			config:   &TokenizerConfig{Mode: TokenizerNgram, MinLength: 2},
+			text:     "Go语言 and HNSW 索引",
+			expected: []string{"go", "语言", "and", "hnsw", "索引"},
+		},
+		{
+			name:     "ngram keeps a lone CJK character",
+			config:   &TokenizerConfig{Mode: TokenizerNgram, MinLength: 2},
+			text:     "猫 a 犬",
+			expected: []string{"猫", "犬"},
+		},
+		{
+			name:     "whitespace keeps CJK runs whole",
+			config:   &TokenizerConfig{Mode: TokenizerWhitespace, MinLength: 2},
+			text:     "向量数据库",
+			expected: []string{"向量数据库"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := tokenize(tt.text)
+			var result []string
+			if tt.config != nil {
+				result = tt.config.tokenize(tt.text)
+			} else {
+				result = tokenize(tt.text)
+			}
 			if len(result) != len(tt.expected) {
 				t.Errorf("tokenize() got %d tokens, want %d", len(result), len(tt.expected))
 				return
@@ -53,6 +107,91 @@ func TestTokenize(t *testing.T) {
 	}
 }
 
+func TestFullTextIndex_TokenizerConfig(t *testing.T) {
+	docs := []*Document{
+		{ID: 1, Text: "Intro to AI and ML"},
+		{ID: 2, Text: "The Go programming language"},
+		{ID: 3, Text: "Writing a parser in C"},
+	}
+
+	legacy := NewFullTextIndex()
+	legacy.BatchIndex(docs)
+	configured := NewFullTextIndexWithConfig(DefaultTokenizerConfig())
+	configured.BatchIndex(docs)
+
+	// Single-character terms are only indexed with the new defaults
+	if got := legacy.Search("c", 3); len(got) != 0 {
+		t.Errorf("legacy Search(c) returned %d results, want 0", len(got))
+	}
+	if got := configured.Search("c", 3); len(got) != 1 || got[0].ID != 3 {
+		t.Errorf("configured Search(c) = %v, want doc 3", got)
+	}
+	if got := configured.Search("ai", 3); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("configured Search(ai) = %v, want doc 1", got)
+	}
+
+	// Stopwords neither match nor count towards document length
+	if got := configured.Search("the", 3); len(got) != 0 {
+		t.Errorf("configured Search(the) returned %d results, want 0", len(got))
+	}
+	if got := legacy.Search("the", 3); len(got) != 1 {
+		t.Errorf("legacy Search(the) returned %d results, want 1", len(got))
+	}
+	if configured.docLengths[2] != 3 {
+		t.Errorf("configured doc 2 length = %d, want 3", configured.docLengths[2])
+	}
+
+	// The index keeps its own copy of the stopword set
+	config := DefaultTokenizerConfig()
+	idx := NewFullTextIndexWithConfig(config)
+	config.Stopwords["parser"] = true
+	idx.BatchIndex(docs)
+	if got := idx.Search("parser", 3); len(got) != 1 {
+		t.Errorf("Search(parser) returned %d results, want 1", len(got))
+	}
+}
+
+func TestFullTextIndex_NgramCJK(t *testing.T) {
+	docs := []*Document{
+		{ID: 1, Text: "我喜欢向量数据库"},
+		{ID: 2, Text: "关系数据库存储表格"},
+		{ID: 3, Text: "ベクトル検索エンジン"},
+	}
+
+	idx := NewFullTextIndexWithConfig(TokenizerConfig{Mode: TokenizerNgram, MinLength: 2})
+	idx.BatchIndex(docs)
+
+	// A substring matches every document containing it
+	got := idx.Search("数据库", 3)
+	if len(got) != 2 {
+		t.Fatalf("Search(数据库) returned %d results, want 2", len(got))
+	}
+
+	// More shared bigrams rank higher
+	got = idx.Search("向量数据", 3)
+	if len(got) != 2 || got[0].ID != 1 {
+		t.Errorf("Search(向量数据) = %v, want doc 1 first", got)
+	}
+	if got := idx.Search("検索", 3); len(got) != 1 || got[0].ID != 3 {
+		t.Errorf("Search(検索) = %v, want doc 3", got)
+	}
+	if got := idx.Search("图像", 3); len(got) != 0 {
+		t.Errorf("Search(图像) returned %d results, want 0", len(got))
+	}
+
+	// Overlapping bigram matches are highlighted as one region
+	if got := idx.Highlight(1, "向量数据", 0); got != "我喜欢**向量数据**库" {
+		t.Errorf("Highlight() = %q, want %q", got, "我喜欢**向量数据**库")
+	}
+
+	// Whitespace tokenizing indexes each sentence as one term
+	whitespace := NewFullTextIndex()
+	whitespace.BatchIndex(docs)
+	if got := whitespace.Search("数据库", 3); len(got) != 0 {
+		t.Errorf("whitespace Search(数据库) returned %d results, want 0", len(got))
+	}
+}
+
 func TestFullTextIndex_Index(t *testing.T) {
 	idx := NewFullTextIndex()
 
@@ -396,6 +535,98 @@ func TestFullTextIndex_BM25Scoring(t *testing.T) {
 	}
 }
 
+func TestFullTextIndex_ScoringModels(t *testing.T) {
+	docs := []*Document{
+		{ID: 1, Text: "vector search"}, // Short, single mention
+		{ID: 2, Text: "vector databases store every vector embedding alongside rich metadata " +
+			"so that applications can filter vector results by tenant category language and freshness"}, // Long, repeated term
+		{ID: 3, Text: "approximate nearest neighbor vector indexes trade recall for latency"},
+		{ID: 4, Text: "keyword search engines"},
+	}
+
+	if got := NewFullTextIndex().Model(); got != BM25 {
+		t.Errorf("NewFullTextIndex().Model() = %v, want BM25", got)
+	}
+
+	ranked := make(map[ScoringModel][]*FullTextResult)
+	for _, model := range []ScoringModel{BM25, TFIDF} {
+		idx := NewFullTextIndexWithModel(model)
+		idx.BatchIndex(docs)
+
+		results := idx.Search("vector search", 4)
+		if len(results) != 4 {
+			t.Fatalf("%v: Search() returned %d results, want 4", model, len(results))
+		}
+
+		for i := 1; i < len(results); i++ {
+			if results[i].Score > results[i-1].Score {
+				t.Errorf("%v: results[%d].Score > results[%d].Score", model, i, i-1)
+			}
+		}
+		ranked[model] = results
+	}
+
+	// BM25 saturates and length-normalizes term frequency, so the short exact
+	// match wins; TF-IDF rewards the long document's repeated mentions
+	if ranked[BM25][0].ID != 1 {
+		t.Errorf("BM25 top result = %d, want 1", ranked[BM25][0].ID)
+	}
+	if ranked[TFIDF][0].ID != 2 {
+		t.Errorf("TFIDF top result = %d, want 2", ranked[TFIDF][0].ID)
+	}
+
+	if ranked[BM25][0].Score == ranked[TFIDF][0].Score {
+		t.Error("BM25 and TFIDF produced identical top scores")
+	}
+}
+
+func TestFullTextIndex_BM25Params(t *testing.T) {
+	docs := []*Document{
+		{ID: 1, Text: "vector"}, // Very short, single mention
+		{ID: 2, Text: "vector databases store every vector embedding alongside rich metadata so that " +
+			"applications can filter results by tenant category language freshness region owner " +
+			"and vector source"}, // Long, repeated term
+	}
+
+	idx := NewFullTextIndex()
+	idx.BatchIndex(docs)
+
+	if k1, b := idx.BM25Params(); k1 != 1.5 || b != 0.75 {
+		t.Errorf("default BM25Params() = (%v, %v), want (1.5, 0.75)", k1, b)
+	}
+
+	tests := []struct {
+		name  string
+		apply func()
+		top   uint64
+	}{
+		// Full normalization penalizes the long document's length
+		{"b=1", func() { idx.SetBM25Params(1.5, 1) }, 1},
+		{"default", func() { idx.SetBM25Params(1.5, 0.75) }, 1},
+		// Without it, the long document's three mentions win
+		{"b=0", func() { idx.SetBM25Params(1.5, 0) }, 2},
+		{"DisableLengthNormalization", func() {
+			idx.SetBM25Params(1.5, 0.75)
+			idx.DisableLengthNormalization()
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		tt.apply()
+		results := idx.Search("vector", 2)
+		if len(results) != 2 {
+			t.Fatalf("%s: Search() returned %d results, want 2", tt.name, len(results))
+		}
+		if results[0].ID != tt.top {
+			t.Errorf("%s: top result = %d, want %d", tt.name, results[0].ID, tt.top)
+		}
+	}
+
+	if k1, b := idx.BM25Params(); k1 != 1.5 || b != 0 {
+		t.Errorf("BM25Params() after DisableLengthNormalization = (%v, %v), want (1.5, 0)", k1, b)
+	}
+}
+
 func TestFullTextIndex_EmptyQuery(t *testing.T) {
 	idx := NewFullTextIndex()
 
@@ -507,3 +738,26 @@ func BenchmarkFullTextIndex_SearchWithFilter(b *testing.B) {
 		idx.SearchWithFilter("vector", 10, filter)
 	}
 }
+
+func TestFullTextIndex_MemoryUsage(t *testing.T) {
+	idx := NewFullTextIndex()
+	if got := idx.MemoryUsage(); got != 0 {
+		t.Errorf("MemoryUsage() on empty index = %d, want 0", got)
+	}
+
+	text := "Vector database with HNSW indexing and BM25 ranking"
+	if err := idx.Index(&Document{ID: 1, Text: text}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	one := idx.MemoryUsage()
+	if one < int64(len(text)) {
+		t.Errorf("MemoryUsage() = %d, want at least the text length %d", one, len(text))
+	}
+
+	if err := idx.Index(&Document{ID: 2, Text: "Another document about search"}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if two := idx.MemoryUsage(); two <= one {
+		t.Errorf("MemoryUsage() did not grow: %d after one document, %d after two", one, two)
+	}
+}
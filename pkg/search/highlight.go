@@ -0,0 +1,184 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Snippet defaults
+const (
+	DefaultHighlightDelimiter = "**" // Wraps matched terms on both sides
+	DefaultSnippetLength      = 160  // Snippet length in characters
+)
+
+// ellipsis marks text cut from either side of a snippet
+const ellipsis = "..."
+
+// tokenSpan is a token and its rune offsets in the original text
+type tokenSpan struct {
+	term       string
+	start, end int
+	gram       bool // A CJK character or bigram from ngram mode
+}
+
+// tokenSpans splits text like tokenize, keeping each token's position
+func tokenSpans(runes []rune) []tokenSpan {
+	var spans []tokenSpan
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		inWord := i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsNumber(runes[i]))
+		if inWord && start < 0 {
+			start = i
+		}
+		if !inWord && start >= 0 {
+			// Length and stopword filtering is left to query term matching
+			term := strings.ToLower(string(runes[start:i]))
+			spans = append(spans, tokenSpan{term: term, start: start, end: i})
+			start = -1
+		}
+	}
+	return spans
+}
+
+// spans splits text like the tokenizer, keeping each term's position.
+// Ngram mode bigrams overlap, so adjacent matches share characters.
+func (c TokenizerConfig) spans(runes []rune) []tokenSpan {
+	words := tokenSpans(runes)
+	if c.Mode != TokenizerNgram {
+		return words
+	}
+
+	spans := make([]tokenSpan, 0, len(words))
+	for _, word := range words {
+		if !hasCJK(word.term) {
+			spans = append(spans, word)
+			continue
+		}
+		for _, span := range cjkSpans(runes[word.start:word.end]) {
+			span.term = strings.ToLower(span.term)
+			span.start += word.start
+			span.end += word.start
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// SetHighlightDelimiters sets the strings wrapped around matched terms in
+// snippets (default "**" on both sides)
+func (idx *FullTextIndex) SetHighlightDelimiters(pre, post string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.highlightPre = pre
+	idx.highlightPost = post
+}
+
+// SetSnippetLength sets the snippet length, in characters, attached to
+// search results. Zero disables snippets.
+func (idx *FullTextIndex) SetSnippetLength(n int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.snippetLength = n
+}
+
+// Highlight returns up to maxLen characters of a document's text centered
+// on the query term that contributes most to its score, with every query
+// term in the window wrapped in the highlight delimiters. When no query
+// term occurs in the document it falls back to the leading text. Cut ends
+// are marked with "...". A maxLen of zero or less returns the whole text.
+func (idx *FullTextIndex) Highlight(docID uint64, query string, maxLen int) string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	doc := idx.documents[docID]
+	if doc == nil {
+		return ""
+	}
+	return idx.highlightLocked(doc, query, maxLen)
+}
+
+// highlightLocked builds a snippet for a document; the caller holds idx.mu
+func (idx *FullTextIndex) highlightLocked(doc *Document, query string, maxLen int) string {
+	runes := []rune(doc.Text)
+	spans := idx.tokenizer.spans(runes)
+
+	queryTerms := make(map[string]bool)
+	for _, term := range idx.queryTermsLocked(query) {
+		queryTerms[term] = true
+	}
+
+	// Anchor on the first occurrence of the highest-scoring term
+	anchor := -1
+	bestScore := -1.0
+	for i, span := range spans {
+		if !queryTerms[span.term] {
+			continue
+		}
+		postings := idx.invertedIndex[span.term]
+		score := idx.termScoreLocked(idx.idfLocked(len(postings)), postings[doc.ID], idx.docLengths[doc.ID])
+		if score > bestScore {
+			bestScore = score
+			anchor = i
+		}
+	}
+
+	// Choose the window [from, to) in runes
+	from, to := 0, len(runes)
+	if maxLen > 0 && len(runes) > maxLen {
+		if anchor >= 0 {
+			span := spans[anchor]
+			from = span.start - (maxLen-(span.end-span.start))/2
+			if from < 0 {
+				from = 0
+			}
+			if from+maxLen > len(runes) {
+				from = len(runes) - maxLen
+			}
+		}
+		to = from + maxLen
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString(ellipsis)
+	}
+	// Overlapping matches, such as adjacent bigrams, are highlighted as one
+	var matched []tokenSpan
+	for _, span := range spans {
+		if !queryTerms[span.term] || span.start < from || span.end > to {
+			continue
+		}
+		if n := len(matched); n > 0 && span.start < matched[n-1].end {
+			matched[n-1].end = span.end
+			continue
+		}
+		matched = append(matched, span)
+	}
+
+	pos := from
+	for _, span := range matched {
+		b.WriteString(string(runes[pos:span.start]))
+		b.WriteString(idx.highlightPre)
+		b.WriteString(string(runes[span.start:span.end]))
+		b.WriteString(idx.highlightPost)
+		pos = span.end
+	}
+	b.WriteString(string(runes[pos:to]))
+	if to < len(runes) {
+		b.WriteString(ellipsis)
+	}
+
+	return b.String()
+}
+
+// addSnippetsLocked attaches snippets to results; the caller holds idx.mu
+func (idx *FullTextIndex) addSnippetsLocked(results []*FullTextResult, query string) {
+	if idx.snippetLength <= 0 {
+		return
+	}
+	for _, r := range results {
+		if r.Document != nil {
+			r.Snippet = idx.highlightLocked(r.Document, query, idx.snippetLength)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	idx := NewFullTextIndex()
+	idx.Index(&Document{ID: 1, Text: "Vector search with HNSW graphs. Vector databases store embeddings."})
+	idx.Index(&Document{ID: 2, Text: "Relational databases store rows."})
+
+	tests := []struct {
+		name   string
+		docID  uint64
+		query  string
+		maxLen int
+		want   string
+	}{
+		{
+			name:   "short document is returned whole",
+			docID:  2,
+			query:  "rows",
+			maxLen: 100,
+			want:   "Relational databases store **rows**.",
+		},
+		{
+			name:   "all query terms in the window are wrapped",
+			docID:  1,
+			query:  "vector embeddings",
+			maxLen: 0,
+			want:   "**Vector** search with HNSW graphs. **Vector** databases store **embeddings**.",
+		},
+		{
+			name:   "absent terms fall back to leading text",
+			docID:  1,
+			query:  "quantization",
+			maxLen: 13,
+			want:   "Vector search...",
+		},
+		{
+			name:   "window centers on the match",
+			docID:  1,
+			query:  "hnsw",
+			maxLen: 12,
+			want:   "...ith **HNSW** gra...",
+		},
+		{
+			name:   "window at the end of the text",
+			docID:  1,
+			query:  "embeddings",
+			maxLen: 16,
+			want:   "...tore **embeddings**.",
+		},
+		{
+			name:   "unknown document",
+			docID:  99,
+			query:  "vector",
+			maxLen: 10,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idx.Highlight(tt.docID, tt.query, tt.maxLen); got != tt.want {
+				t.Errorf("Highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightBestTerm(t *testing.T) {
+	idx := NewFullTextIndex()
+	text := "common words appear first and common words repeat, " +
+		"then much later the rare term zebra appears once"
+	idx.Index(&Document{ID: 1, Text: text})
+	idx.Index(&Document{ID: 2, Text: "common words elsewhere"})
+	idx.Index(&Document{ID: 3, Text: "more common words"})
+
+	// "zebra" is rarer across the corpus, so it outscores "common"
+	got := idx.Highlight(1, "common zebra", 20)
+	if !strings.Contains(got, "**zebra**") {
+		t.Errorf("Expected snippet around zebra, got %q", got)
+	}
+}
+
+func TestHighlightDelimiters(t *testing.T) {
+	idx := NewFullTextIndex()
+	idx.SetHighlightDelimiters("<em>", "</em>")
+	idx.Index(&Document{ID: 1, Text: "Vector database"})
+
+	if got, want := idx.Highlight(1, "database", 0), "Vector <em>database</em>"; got != want {
+		t.Errorf("Highlight() = %q, want %q", got, want)
+	}
+}
+
+func TestSearchSnippets(t *testing.T) {
+	idx := NewFullTextIndex()
+	idx.Index(&Document{ID: 1, Text: "Full-text search with BM25 ranking"})
+
+	results := idx.Search("ranking", 10)
+	if len(results) != 1 || results[0].Snippet != "Full-text search with BM25 **ranking**" {
+		t.Fatalf("Unexpected search snippet: %+v", results)
+	}
+
+	results = idx.SearchWithFilter("ranking", 10, nil)
+	if len(results) != 1 || results[0].Snippet == "" {
+		t.Fatalf("Expected filtered search snippet, got %+v", results)
+	}
+
+	idx.SetSnippetLength(0)
+	if results := idx.Search("ranking", 10); results[0].Snippet != "" {
+		t.Errorf("Expected no snippet when disabled, got %q", results[0].Snippet)
+	}
+}
+
+func TestHybridSearchSnippets(t *testing.T) {
+	hs, vectors := createTestHybridSearch(t)
+
+	results := hs.Search(vectors[3], "HNSW", 5, 50)
+	if len(results) == 0 {
+		t.Fatal("Expected hybrid results")
+	}
+	for _, r := range results {
+		if r.Snippet == "" {
+			t.Errorf("Result %d has no snippet", r.ID)
+		}
+		if r.TextScore > 0 && !strings.Contains(r.Snippet, "**HNSW**") {
+			t.Errorf("Text match %d snippet %q does not highlight the query", r.ID, r.Snippet)
+		}
+	}
+}
@@ -10,7 +10,10 @@ type HybridSearchResult struct {
 	VectorScore float32                // Distance from vector search (lower is better)
 	TextScore   float64                // BM25 score from text search (higher is better)
 	FusedScore  float64                // Combined RRF score (higher is better)
+	VectorRank  int                    // 1-based rank in the vector results, 0 if not present
+	TextRank    int                    // 1-based rank in the text results, 0 if not present
 	Metadata    map[string]interface{} // Document metadata
+	Snippet     string                 // Matched text window, empty without a text query
 }
 
 // HybridSearch performs hybrid search combining vector similarity and full-text search
@@ -75,10 +78,14 @@ func (hs *HybridSearch) Search(queryVector []float32, queryText string, k int, e
 	textResults := hs.textIndex.Search(queryText, k*2)
 
 	// Merge using RRF or weighted combination
+	var results []*HybridSearchResult
 	if hs.useRRF {
-		return hs.reciprocalRankFusion(vectorResults, textResults, k)
+		results = hs.reciprocalRankFusion(vectorResults, textResults, k)
+	} else {
+		results = hs.weightedCombination(vectorResults, textResults, k)
 	}
-	return hs.weightedCombination(vectorResults, textResults, k)
+	hs.addSnippets(results, queryText)
+	return results
 }
 
 // SearchWithFilter performs hybrid search with metadata filtering
@@ -104,25 +111,37 @@ func (hs *HybridSearch) SearchWithFilter(queryVector []float32, queryText string
 	}
 
 	// Merge using RRF or weighted combination
+	var results []*HybridSearchResult
 	if hs.useRRF {
-		return hs.reciprocalRankFusion(filteredVectorResults, textResults, k)
+		results = hs.reciprocalRankFusion(filteredVectorResults, textResults, k)
+	} else {
+		results = hs.weightedCombination(filteredVectorResults, textResults, k)
+	}
+	hs.addSnippets(results, queryText)
+	return results
+}
+
+// addSnippets highlights the query in each result's text. Results found
+// only by vector similarity get their leading text.
+func (hs *HybridSearch) addSnippets(results []*HybridSearchResult, queryText string) {
+	hs.textIndex.mu.RLock()
+	defer hs.textIndex.mu.RUnlock()
+
+	if hs.textIndex.snippetLength <= 0 {
+		return
+	}
+	for _, r := range results {
+		if doc := hs.textIndex.documents[r.ID]; doc != nil {
+			r.Snippet = hs.textIndex.highlightLocked(doc, queryText, hs.textIndex.snippetLength)
+		}
 	}
-	return hs.weightedCombination(filteredVectorResults, textResults, k)
 }
 
 // reciprocalRankFusion implements the RRF algorithm
 // RRF score = Σ(α / (k + rank_vector)) + Σ(β / (k + rank_text))
 func (hs *HybridSearch) reciprocalRankFusion(vectorResults []hnsw.Result, textResults []*FullTextResult, topK int) []*HybridSearchResult {
 	// Build rank maps for efficient lookup
-	vectorRanks := make(map[uint64]int)
-	for rank, result := range vectorResults {
-		vectorRanks[result.ID] = rank + 1 // Rank starts at 1
-	}
-
-	textRanks := make(map[uint64]int)
-	for rank, result := range textResults {
-		textRanks[result.ID] = rank + 1
-	}
+	vectorRanks, textRanks := resultRanks(vectorResults, textResults)
 
 	// Collect all unique document IDs
 	allDocs := make(map[uint64]bool)
@@ -178,6 +197,8 @@ func (hs *HybridSearch) reciprocalRankFusion(vectorResults []hnsw.Result, textRe
 			VectorScore: vectorScore,
 			TextScore:   textScore,
 			FusedScore:  rrfScore,
+			VectorRank:  vectorRanks[docID],
+			TextRank:    textRanks[docID],
 			Metadata:    metadata,
 		})
 	}
@@ -193,6 +214,21 @@ func (hs *HybridSearch) reciprocalRankFusion(vectorResults []hnsw.Result, textRe
 	return results
 }
 
+// resultRanks maps each ID in the vector and text results to its 1-based
+// rank in that list
+func resultRanks(vectorResults []hnsw.Result, textResults []*FullTextResult) (map[uint64]int, map[uint64]int) {
+	vectorRanks := make(map[uint64]int, len(vectorResults))
+	for rank, result := range vectorResults {
+		vectorRanks[result.ID] = rank + 1 // Rank starts at 1
+	}
+
+	textRanks := make(map[uint64]int, len(textResults))
+	for rank, result := range textResults {
+		textRanks[result.ID] = rank + 1
+	}
+	return vectorRanks, textRanks
+}
+
 // weightedCombination uses weighted score combination instead of RRF
 // This normalizes scores and combines them with weights
 func (hs *HybridSearch) weightedCombination(vectorResults []hnsw.Result, textResults []*FullTextResult, topK int) []*HybridSearchResult {
@@ -242,6 +278,7 @@ func (hs *HybridSearch) weightedCombination(vectorResults []hnsw.Result, textRes
 	}
 
 	// Calculate combined scores
+	vectorRanks, textRanks := resultRanks(vectorResults, textResults)
 	results := make([]*HybridSearchResult, 0, len(allDocs))
 
 	for docID := range allDocs {
@@ -276,6 +313,8 @@ func (hs *HybridSearch) weightedCombination(vectorResults []hnsw.Result, textRes
 			VectorScore: vectorScore,
 			TextScore:   textScore,
 			FusedScore:  combinedScore,
+			VectorRank:  vectorRanks[docID],
+			TextRank:    textRanks[docID],
 			Metadata:    metadata,
 		})
 	}
@@ -291,13 +330,16 @@ func (hs *HybridSearch) weightedCombination(vectorResults []hnsw.Result, textRes
 	return results
 }
 
-// sortByFusedScore sorts results by fused score in descending order
+// sortByFusedScore sorts results by fused score in descending order, breaking
+// ties by ascending ID. Results are gathered from a map, so without the ID
+// their order would change from call to call.
 func sortByFusedScore(results []*HybridSearchResult) {
 	// Insertion sort (efficient for small k)
 	for i := 1; i < len(results); i++ {
 		key := results[i]
 		j := i - 1
-		for j >= 0 && results[j].FusedScore < key.FusedScore {
+		for j >= 0 && (results[j].FusedScore < key.FusedScore ||
+			results[j].FusedScore == key.FusedScore && results[j].ID > key.ID) {
 			results[j+1] = results[j]
 			j--
 		}
@@ -325,6 +367,7 @@ func (hs *HybridSearch) VectorOnlySearch(queryVector []float32, k int, efSearch
 			VectorScore: vr.Distance,
 			TextScore:   0,
 			FusedScore:  float64(-vr.Distance), // Negative distance as score
+			VectorRank:  i + 1,
 			Metadata:    metadata,
 		}
 	}
@@ -343,7 +386,9 @@ func (hs *HybridSearch) TextOnlySearch(queryText string, k int) []*HybridSearchR
 			VectorScore: 0,
 			TextScore:   tr.Score,
 			FusedScore:  tr.Score,
+			TextRank:    i + 1,
 			Metadata:    tr.Document.Metadata,
+			Snippet:     tr.Snippet,
 		}
 	}
 
@@ -194,6 +194,67 @@ func TestHybridSearch_WeightedCombination(t *testing.T) {
 	}
 }
 
+func TestHybridSearch_Ranks(t *testing.T) {
+	hs, _ := createTestHybridSearch(t)
+
+	// "hybrid" appears only in doc 3, and every doc is a vector candidate
+	for _, useRRF := range []bool{true, false} {
+		hs.SetFusionMethod(useRRF)
+		results := hs.Search([]float32{1.0, 0.0, 0.0}, "hybrid", 5, 50)
+		if len(results) != 5 {
+			t.Fatalf("useRRF=%v: expected 5 results, got %d", useRRF, len(results))
+		}
+
+		seen := make(map[int]bool)
+		for _, r := range results {
+			if r.VectorRank < 1 || r.VectorRank > 5 || seen[r.VectorRank] {
+				t.Errorf("useRRF=%v: result %d has invalid or duplicate vector rank %d", useRRF, r.ID, r.VectorRank)
+			}
+			seen[r.VectorRank] = true
+			if r.VectorRank == 1 && r.VectorScore != 0 {
+				t.Errorf("useRRF=%v: vector rank 1 should be the exact match, got distance %f", useRRF, r.VectorScore)
+			}
+
+			if r.TextScore > 0 && r.TextRank != 1 {
+				t.Errorf("useRRF=%v: the only text match should have text rank 1, got %d", useRRF, r.TextRank)
+			}
+			if r.TextScore == 0 && r.TextRank != 0 {
+				t.Errorf("useRRF=%v: result %d without a text match has text rank %d", useRRF, r.ID, r.TextRank)
+			}
+		}
+	}
+
+	for i, r := range hs.VectorOnlySearch([]float32{1.0, 0.0, 0.0}, 3, 50) {
+		if r.VectorRank != i+1 || r.TextRank != 0 {
+			t.Errorf("Vector-only result %d: expected ranks (%d, 0), got (%d, %d)", i, i+1, r.VectorRank, r.TextRank)
+		}
+	}
+	for i, r := range hs.TextOnlySearch("search", 3) {
+		if r.TextRank != i+1 || r.VectorRank != 0 {
+			t.Errorf("Text-only result %d: expected ranks (0, %d), got (%d, %d)", i, i+1, r.VectorRank, r.TextRank)
+		}
+	}
+}
+
+func TestSortByFusedScore_TieBreaksByID(t *testing.T) {
+	results := []*HybridSearchResult{
+		{ID: 7, FusedScore: 0.5},
+		{ID: 3, FusedScore: 0.5},
+		{ID: 9, FusedScore: 0.9},
+		{ID: 1, FusedScore: 0.5},
+		{ID: 4, FusedScore: 0.1},
+	}
+
+	sortByFusedScore(results)
+
+	expected := []uint64{9, 1, 3, 7, 4}
+	for i, id := range expected {
+		if results[i].ID != id {
+			t.Fatalf("Position %d: expected ID %d, got %d", i, id, results[i].ID)
+		}
+	}
+}
+
 func TestHybridSearch_SearchWithFilter(t *testing.T) {
 	hs, _ := createTestHybridSearch(t)
 
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultMaxPrefixExpansions caps how many indexed terms one prefix query
+// term expands to
+const DefaultMaxPrefixExpansions = 50
+
+// SetMaxPrefixExpansions sets how many indexed terms a prefix query term
+// ("data*") may expand to. When more terms share the prefix, the ones found
+// in the most documents are kept. Values below 1 restore the default.
+func (idx *FullTextIndex) SetMaxPrefixExpansions(n int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if n < 1 {
+		n = DefaultMaxPrefixExpansions
+	}
+	idx.maxPrefixExpansions = n
+}
+
+// addTermLocked inserts a new term into the sorted vocabulary
+func (idx *FullTextIndex) addTermLocked(term string) {
+	i := sort.SearchStrings(idx.vocabulary, term)
+	idx.vocabulary = append(idx.vocabulary, "")
+	copy(idx.vocabulary[i+1:], idx.vocabulary[i:])
+	idx.vocabulary[i] = term
+}
+
+// removeTermLocked deletes a term from the sorted vocabulary
+func (idx *FullTextIndex) removeTermLocked(term string) {
+	i := sort.SearchStrings(idx.vocabulary, term)
+	if i < len(idx.vocabulary) && idx.vocabulary[i] == term {
+		idx.vocabulary = append(idx.vocabulary[:i], idx.vocabulary[i+1:]...)
+	}
+}
+
+// queryTermsLocked tokenizes a query. A word ending in '*' is a prefix and
+// expands to the indexed terms starting with it, each scored as its own
+// query term, so a document's contributions from all of them add up.
+func (idx *FullTextIndex) queryTermsLocked(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(query) {
+		if !strings.HasSuffix(field, "*") {
+			terms = append(terms, idx.tokenizer.tokenize(field)...)
+			continue
+		}
+
+		// Only the word directly before the '*' is a prefix
+		words := strings.FieldsFunc(strings.ToLower(strings.TrimRight(field, "*")), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+		last := len(words) - 1
+		terms = append(terms, idx.tokenizer.tokenize(strings.Join(words[:last], " "))...)
+		if idx.tokenizer.Mode == TokenizerNgram && hasCJK(words[last]) {
+			// Bigrams already match any substring, so CJK is not expanded
+			terms = append(terms, idx.tokenizer.tokenize(words[last])...)
+			continue
+		}
+		terms = append(terms, idx.expandPrefixLocked(words[last])...)
+	}
+	return terms
+}
+
+// expandPrefixLocked returns the indexed terms starting with prefix, keeping
+// the maxPrefixExpansions found in the most documents
+func (idx *FullTextIndex) expandPrefixLocked(prefix string) []string {
+	start := sort.SearchStrings(idx.vocabulary, prefix)
+	end := start
+	for end < len(idx.vocabulary) && strings.HasPrefix(idx.vocabulary[end], prefix) {
+		end++
+	}
+
+	matches := make([]string, end-start)
+	copy(matches, idx.vocabulary[start:end])
+	if len(matches) > idx.maxPrefixExpansions {
+		sort.SliceStable(matches, func(i, j int) bool {
+			return len(idx.invertedIndex[matches[i]]) > len(idx.invertedIndex[matches[j]])
+		})
+		matches = matches[:idx.maxPrefixExpansions]
+	}
+	return matches
+}
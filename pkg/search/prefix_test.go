@@ -0,0 +1,75 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFullTextIndex_PrefixSearch(t *testing.T) {
+	idx := NewFullTextIndex()
+	idx.BatchIndex([]*Document{
+		{ID: 1, Text: "database and datastore internals"},
+		{ID: 2, Text: "relational database design"},
+		{ID: 3, Text: "data science notebooks"},
+		{ID: 4, Text: "graph algorithms"},
+	})
+
+	results := idx.Search("data*", 10)
+	if len(results) != 3 {
+		t.Fatalf("Search(data*) returned %d results, want 3", len(results))
+	}
+	// Document 1 matches two expansions, so their contributions add up
+	if results[0].ID != 1 {
+		t.Errorf("top result = %d, want 1", results[0].ID)
+	}
+
+	// Without the wildcard only the exact term matches
+	if results := idx.Search("data", 10); len(results) != 1 || results[0].ID != 3 {
+		t.Errorf("Search(data) = %v, want doc 3 only", results)
+	}
+
+	// Prefix and plain terms combine, and matching is case-insensitive
+	if results := idx.Search("GRAPH DATA*", 10); len(results) != 4 {
+		t.Errorf("Search(GRAPH DATA*) returned %d results, want 4", len(results))
+	}
+
+	// Snippets highlight the expanded terms
+	if results[0].Snippet != "**database** and **datastore** internals" {
+		t.Errorf("snippet = %q", results[0].Snippet)
+	}
+
+	// Unknown prefixes and a bare '*' match nothing
+	if results := idx.Search("zzz* *", 10); len(results) != 0 {
+		t.Errorf("Search(zzz* *) returned %d results, want 0", len(results))
+	}
+
+	// Removed documents' terms leave the vocabulary
+	idx.Remove(1)
+	if results := idx.Search("datas*", 10); len(results) != 0 {
+		t.Errorf("Search(datas*) after Remove returned %d results, want 0", len(results))
+	}
+}
+
+func TestFullTextIndex_PrefixExpansionCap(t *testing.T) {
+	idx := NewFullTextIndex()
+	idx.BatchIndex([]*Document{
+		{ID: 1, Text: "alpha beta"},
+		{ID: 2, Text: "alpha atlas"},
+		{ID: 3, Text: "alpha atlas apex"},
+	})
+
+	if got := idx.expandPrefixLocked("a"); !reflect.DeepEqual(got, []string{"alpha", "apex", "atlas"}) {
+		t.Errorf("expandPrefixLocked(a) = %v", got)
+	}
+
+	// The cap keeps the terms found in the most documents
+	idx.SetMaxPrefixExpansions(2)
+	if got := idx.expandPrefixLocked("a"); !reflect.DeepEqual(got, []string{"alpha", "atlas"}) {
+		t.Errorf("capped expandPrefixLocked(a) = %v, want [alpha atlas]", got)
+	}
+
+	idx.SetMaxPrefixExpansions(0)
+	if idx.maxPrefixExpansions != DefaultMaxPrefixExpansions {
+		t.Errorf("maxPrefixExpansions = %d, want default %d", idx.maxPrefixExpansions, DefaultMaxPrefixExpansions)
+	}
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+)
+
+// Result is a retrieval candidate handed to a Reranker
+type Result struct {
+	ID       uint64
+	Distance float32                // Retrieval distance (lower is better); a reranker may rewrite it
+	Vector   []float32              // Stored vector (nil if unavailable)
+	Text     string                 // Stored text ("" if none)
+	Metadata map[string]interface{} // Stored metadata
+}
+
+// Reranker reorders retrieval candidates before they are cut to k, for
+// example with a cross-encoder scoring the query text against each Text.
+//
+// Rerank receives the candidates best first and returns them in the new
+// order. It may reorder and drop candidates and rewrite Distance, but it
+// must not invent results: returned IDs that were not candidates, and
+// repeated IDs, are discarded. Rerankers are shared by concurrent searches
+// and must be safe for concurrent use.
+type Reranker interface {
+	Rerank(query []float32, queryText string, results []Result) []Result
+}
+
+// NoopReranker keeps the retrieval order
+type NoopReranker struct{}
+
+// Rerank returns results unchanged
+func (NoopReranker) Rerank(query []float32, queryText string, results []Result) []Result {
+	return results
+}
+
+// ExactDistanceReranker re-sorts candidates by the exact distance from the
+// query to their stored vectors, for example to order candidates retrieved
+// under another metric or from compressed storage. Candidates without a
+// stored vector keep their retrieval distance.
+type ExactDistanceReranker struct {
+	Distance hnsw.DistanceFunc
+}
+
+// Rerank re-scores every candidate and sorts by the new distance
+func (r ExactDistanceReranker) Rerank(query []float32, queryText string, results []Result) []Result {
+	for i := range results {
+		if results[i].Vector != nil {
+			results[i].Distance = r.Distance(query, results[i].Vector)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results
+}
+
+// ApplyReranker runs a reranker over candidates and returns at most k
+// results, dropping any that break the Reranker contract
+func ApplyReranker(r Reranker, query []float32, queryText string, candidates []Result, k int) []Result {
+	allowed := make(map[uint64]bool, len(candidates))
+	for _, c := range candidates {
+		allowed[c.ID] = true
+	}
+
+	// Hand over a copy so the reranker cannot disturb the caller's slice
+	input := make([]Result, len(candidates))
+	copy(input, candidates)
+
+	reranked := r.Rerank(query, queryText, input)
+	results := make([]Result, 0, k)
+	for _, res := range reranked {
+		if len(results) == k {
+			break
+		}
+		if !allowed[res.ID] {
+			continue
+		}
+		allowed[res.ID] = false
+		results = append(results, res)
+	}
+	return results
+}
@@ -0,0 +1,53 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/therealutkarshpriyadarshi/vector/pkg/hnsw"
+)
+
+// inventingReranker reverses the candidates, repeats the first and adds
+// an ID that was never retrieved
+type inventingReranker struct{}
+
+func (inventingReranker) Rerank(query []float32, queryText string, results []Result) []Result {
+	out := []Result{{ID: 99}}
+	for i := len(results) - 1; i >= 0; i-- {
+		out = append(out, results[i])
+	}
+	return append(out, results[0])
+}
+
+func TestApplyReranker(t *testing.T) {
+	candidates := []Result{{ID: 1, Distance: 0.1}, {ID: 2, Distance: 0.2}, {ID: 3, Distance: 0.3}}
+
+	got := ApplyReranker(NoopReranker{}, nil, "", candidates, 2)
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+		t.Errorf("Expected the noop reranker to keep the top 2, got %+v", got)
+	}
+
+	got = ApplyReranker(inventingReranker{}, nil, "", candidates, 5)
+	if len(got) != 3 || got[0].ID != 3 || got[1].ID != 2 || got[2].ID != 1 {
+		t.Errorf("Expected invented and repeated IDs dropped, got %+v", got)
+	}
+	if candidates[0].ID != 1 || candidates[2].ID != 3 {
+		t.Errorf("Reranking changed the caller's candidates: %+v", candidates)
+	}
+}
+
+func TestExactDistanceReranker(t *testing.T) {
+	query := []float32{0, 0}
+	candidates := []Result{
+		{ID: 1, Distance: 0, Vector: []float32{3, 4}},
+		{ID: 2, Distance: 1, Vector: []float32{1, 0}},
+		{ID: 3, Distance: 2}, // No stored vector
+	}
+
+	got := ApplyReranker(ExactDistanceReranker{Distance: hnsw.EuclideanDistance}, query, "", candidates, 3)
+	if got[0].ID != 2 || got[1].ID != 3 || got[2].ID != 1 {
+		t.Fatalf("Expected order 2, 3, 1 by exact distance, got %+v", got)
+	}
+	if got[0].Distance != 1 || got[2].Distance != 5 {
+		t.Errorf("Expected exact distances 1 and 5, got %v and %v", got[0].Distance, got[2].Distance)
+	}
+}
@@ -0,0 +1,125 @@
+package tenant
+
+import (
+	"fmt"
+	"sync"
+)
+
+// QuotaManager enforces per-namespace vector count and memory quotas on
+// insert. Each namespace gets a tenant on first use, with the default
+// quota or its override; memory is tracked as the tenant's storage.
+//
+// A tenant's usage is the last measurement passed to Sync plus every
+// insert admitted since, so callers Sync after anything that frees space.
+type QuotaManager struct {
+	tenants   *Manager
+	defaults  Quota
+	overrides map[string]Quota
+	mu        sync.Mutex // Makes check-and-count in Admit atomic
+}
+
+// NewQuotaManager creates a quota manager applying defaults to every
+// namespace without an entry in overrides
+func NewQuotaManager(defaults Quota, overrides map[string]Quota) *QuotaManager {
+	return &QuotaManager{
+		tenants:   NewManager(),
+		defaults:  defaults,
+		overrides: overrides,
+	}
+}
+
+// QuotaFor returns the quota applied to a namespace
+func (q *QuotaManager) QuotaFor(namespace string) Quota {
+	if quota, ok := q.overrides[namespace]; ok {
+		return quota
+	}
+	return q.defaults
+}
+
+// tenant returns a namespace's tenant, creating it on first use. The
+// caller must hold q.mu.
+func (q *QuotaManager) tenant(namespace string) *Tenant {
+	if t, err := q.tenants.GetTenant(namespace); err == nil {
+		return t
+	}
+	// Cannot fail: the tenant does not exist and q.mu is held
+	t, _ := q.tenants.CreateTenant(namespace, q.QuotaFor(namespace))
+	return t
+}
+
+// Admit checks that adding vectors and bytes keeps a namespace within its
+// quota and, if so, counts them against it
+func (q *QuotaManager) Admit(namespace string, vectors, bytes int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t := q.tenant(namespace)
+	if err := t.CheckVectorQuota(vectors); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Quota.MaxStorageBytes > 0 && t.Usage.StorageBytes+bytes > t.Quota.MaxStorageBytes {
+		return fmt.Errorf("memory quota exceeded: current=%d bytes, requested=%d, max=%d",
+			t.Usage.StorageBytes, bytes, t.Quota.MaxStorageBytes)
+	}
+	t.Usage.VectorCount += vectors
+	t.Usage.StorageBytes += bytes
+	return nil
+}
+
+// Release returns admitted vectors and bytes whose insert failed
+func (q *QuotaManager) Release(namespace string, vectors, bytes int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t := q.tenant(namespace)
+	t.DecrementVectorCount(vectors)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Usage.StorageBytes -= bytes
+	if t.Usage.StorageBytes < 0 {
+		t.Usage.StorageBytes = 0
+	}
+}
+
+// Sync replaces a namespace's usage with a fresh measurement
+func (q *QuotaManager) Sync(namespace string, vectors, bytes int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t := q.tenant(namespace)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Usage.VectorCount = vectors
+	t.Usage.StorageBytes = bytes
+}
+
+// Forget drops a deleted namespace's usage
+func (q *QuotaManager) Forget(namespace string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_ = q.tenants.DeleteTenant(namespace)
+}
+
+// UsagePercentage returns a namespace's usage as a percentage of each
+// limited resource, keyed "vectors" and "memory"
+func (q *QuotaManager) UsagePercentage(namespace string) map[string]float64 {
+	q.mu.Lock()
+	t := q.tenant(namespace)
+	q.mu.Unlock()
+
+	usage := t.GetUsagePercentage()
+	if storage, ok := usage["storage"]; ok {
+		usage["memory"] = storage
+		delete(usage, "storage")
+	}
+	return usage
+}
+
+// Count returns the number of namespaces with tracked usage
+func (q *QuotaManager) Count() int {
+	return len(q.tenants.ListTenants())
+}
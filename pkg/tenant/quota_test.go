@@ -0,0 +1,85 @@
+package tenant
+
+import "testing"
+
+func TestQuotaManager_Admit(t *testing.T) {
+	q := NewQuotaManager(Quota{MaxVectors: 3, MaxStorageBytes: 1000}, nil)
+
+	for i := 0; i < 3; i++ {
+		if err := q.Admit("test", 1, 100); err != nil {
+			t.Fatalf("Admit %d failed: %v", i, err)
+		}
+	}
+	if err := q.Admit("test", 1, 100); err == nil {
+		t.Error("Expected vector quota to reject the fourth vector")
+	}
+
+	// A failed insert gives its share back
+	q.Release("test", 1, 100)
+	if err := q.Admit("test", 1, 100); err != nil {
+		t.Errorf("Expected released vector to be admitted again, got %v", err)
+	}
+
+	// Namespaces are counted separately
+	if err := q.Admit("other", 1, 100); err != nil {
+		t.Errorf("Expected other namespace to be admitted, got %v", err)
+	}
+	if q.Count() != 2 {
+		t.Errorf("Expected 2 tracked namespaces, got %d", q.Count())
+	}
+}
+
+func TestQuotaManager_MemoryQuota(t *testing.T) {
+	q := NewQuotaManager(Quota{MaxStorageBytes: 1000}, nil)
+
+	if err := q.Admit("test", 1, 800); err != nil {
+		t.Fatalf("Admit failed: %v", err)
+	}
+	if err := q.Admit("test", 1, 300); err == nil {
+		t.Error("Expected memory quota to reject 1100 bytes")
+	}
+
+	usage := q.UsagePercentage("test")
+	if usage["memory"] != 80 {
+		t.Errorf("Expected 80%% memory usage, got %v", usage["memory"])
+	}
+	if _, ok := usage["vectors"]; ok {
+		t.Error("Expected no vector usage without a vector quota")
+	}
+}
+
+func TestQuotaManager_Overrides(t *testing.T) {
+	q := NewQuotaManager(Quota{MaxVectors: 1}, map[string]Quota{
+		"big": {MaxVectors: 10},
+	})
+
+	if q.QuotaFor("big").MaxVectors != 10 || q.QuotaFor("small").MaxVectors != 1 {
+		t.Errorf("Unexpected quotas: big=%+v small=%+v", q.QuotaFor("big"), q.QuotaFor("small"))
+	}
+	if err := q.Admit("big", 5, 0); err != nil {
+		t.Errorf("Expected override to admit 5 vectors, got %v", err)
+	}
+	if err := q.Admit("small", 2, 0); err == nil {
+		t.Error("Expected default quota to reject 2 vectors")
+	}
+}
+
+func TestQuotaManager_Sync(t *testing.T) {
+	q := NewQuotaManager(Quota{MaxVectors: 10}, nil)
+
+	q.Sync("test", 10, 0)
+	if err := q.Admit("test", 1, 0); err == nil {
+		t.Error("Expected full namespace to reject an insert")
+	}
+
+	// Measuring after deletes frees space
+	q.Sync("test", 4, 0)
+	if usage := q.UsagePercentage("test"); usage["vectors"] != 40 {
+		t.Errorf("Expected 40%% vector usage, got %v", usage["vectors"])
+	}
+
+	q.Forget("test")
+	if q.Count() != 0 {
+		t.Errorf("Expected forgotten namespace to be dropped, got %d", q.Count())
+	}
+}
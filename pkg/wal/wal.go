@@ -0,0 +1,632 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Op identifies the write a record describes
+type Op byte
+
+// Record operations
+const (
+	OpInsert      Op = iota + 1 // Vector inserted under ID
+	OpUpdate                    // Vector, metadata and/or text replaced for ID
+	OpDelete                    // ID deleted
+	OpMergeUpdate               // Vector and/or text replaced and metadata merged for ID
+	OpConfig                    // Namespace parameters declared, as Metadata key/value pairs
+	OpCheckpoint                // State up to here is in the snapshot file named by Text
+)
+
+// frameHeaderSize is the length and CRC-32 prefix written before each record
+const frameHeaderSize = 8
+
+// maxRecordSize bounds a single record so a corrupt length cannot trigger a
+// huge allocation during replay
+const maxRecordSize = 1 << 30
+
+// ErrCorrupt reports a bad record with more of the log after it. A crash
+// mid-write only tears the final record, so this is damage to the file.
+var ErrCorrupt = errors.New("corrupt WAL record")
+
+// Record is a single logged write. For OpUpdate an empty Vector, empty
+// Metadata and TypedMetadata, or empty Text means that part was left
+// unchanged. OpMergeUpdate is an OpUpdate whose Metadata and TypedMetadata
+// are merged into the stored metadata, with RemoveKeys then deleted from it.
+// OpConfig carries no ID; its Metadata holds the parameters, whose keys the
+// caller defines. OpCheckpoint carries no ID either and is only ever the
+// first record, written by Checkpoint; its Text and Metadata are the caller's.
+type Record struct {
+	Op            Op
+	ID            uint64
+	Vector        []float32
+	Metadata      map[string]string
+	TypedMetadata map[string]interface{} // int64, float64 or bool values
+	Text          string
+	ExternalID    string   // Client-supplied ID an OpInsert is keyed by, if any
+	RemoveKeys    []string // Metadata keys an OpMergeUpdate deletes
+}
+
+// Typed metadata value tags
+const (
+	typeInt64 byte = iota + 1
+	typeFloat64
+	typeBool
+)
+
+// Options controls when appended records are fsynced
+type Options struct {
+	SyncInterval time.Duration // Fsync in the background at this interval (0 = disabled)
+	SyncEvery    int           // Fsync after this many appends (0 = disabled, 1 = every append)
+}
+
+// Log is an append-only write-ahead log file. Each record is framed as a
+// little-endian uint32 payload length, a uint32 CRC-32 of the payload, then
+// the payload. Records are written with a single write call, so a process
+// crash loses nothing that Append returned; fsync policy decides what
+// survives a machine crash.
+type Log struct {
+	path string
+	opts Options
+
+	mu      sync.Mutex
+	file    *os.File
+	size    int64 // Bytes in the file
+	pending int   // Records appended since the last fsync
+	closed  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Open opens the log at path for appending, creating it and its directory
+// if needed. Replay any existing records before appending new ones.
+func Open(path string, opts Options) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create WAL directory: %w", err)
+	}
+
+	file, size, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{
+		path: path,
+		opts: opts,
+		file: file,
+		size: size,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	if opts.SyncInterval > 0 {
+		go l.syncLoop()
+	} else {
+		close(l.done)
+	}
+
+	return l, nil
+}
+
+// openAppend opens the log file for appending, returning its size
+func openAppend(path string) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open WAL: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("failed to stat WAL: %w", err)
+	}
+	return file, info.Size(), nil
+}
+
+// Path returns the file the log writes to
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Size returns the bytes appended to the log so far. A Checkpoint at this
+// offset keeps every record appended after the call.
+func (l *Log) Size() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.size
+}
+
+// Append writes a record to the log, fsyncing it if the SyncEvery policy
+// is due
+func (l *Log) Append(rec *Record) error {
+	payload, err := encodeRecord(rec)
+	if err != nil {
+		return err
+	}
+	frame := encodeFrame(payload)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return fmt.Errorf("WAL %s is closed", l.path)
+	}
+	n, err := l.file.Write(frame)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to append to WAL: %w", err)
+	}
+
+	l.pending++
+	if l.opts.SyncEvery > 0 && l.pending >= l.opts.SyncEvery {
+		return l.syncLocked()
+	}
+	return nil
+}
+
+// encodeFrame prefixes a record payload with its length and CRC-32
+func encodeFrame(payload []byte) []byte {
+	frame := make([]byte, frameHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(payload))
+	copy(frame[frameHeaderSize:], payload)
+	return frame
+}
+
+// Checkpoint drops the records before offset, whose writes a snapshot now
+// holds, and starts the log with rec, an OpCheckpoint naming it. The
+// records from offset on are copied behind it into a new file that is
+// fsynced and renamed over the log, so a crash leaves either the old log or
+// the new one. Appends wait until it is done. A closed log is left alone.
+func (l *Log) Checkpoint(offset int64, rec *Record) error {
+	if rec.Op != OpCheckpoint {
+		return fmt.Errorf("checkpoint record has op %d", rec.Op)
+	}
+	payload, err := encodeRecord(rec)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	if offset < 0 || offset > l.size {
+		return fmt.Errorf("checkpoint offset %d outside WAL of %d bytes", offset, l.size)
+	}
+
+	tmpPath := l.path + ".tmp"
+	if err := writeCheckpoint(tmpPath, l.path, offset, encodeFrame(payload)); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace WAL: %w", err)
+	}
+	if err := syncDir(filepath.Dir(l.path)); err != nil {
+		return err
+	}
+
+	// Appends go to the new file from here on
+	file, size, err := openAppend(l.path)
+	if err != nil {
+		return err
+	}
+	l.file.Close()
+	l.file = file
+	l.size = size
+	l.pending = 0
+	return nil
+}
+
+// writeCheckpoint writes frame followed by the log at path from offset on
+// to tmpPath, and fsyncs it
+func writeCheckpoint(tmpPath, path string, offset int64, frame []byte) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open WAL: %w", err)
+	}
+	defer src.Close()
+	if _, err := src.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read WAL: %w", err)
+	}
+
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create WAL: %w", err)
+	}
+	if _, err := dst.Write(frame); err != nil {
+		dst.Close()
+		return fmt.Errorf("failed to write WAL: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return fmt.Errorf("failed to copy WAL: %w", err)
+	}
+	if err := dst.Sync(); err != nil {
+		dst.Close()
+		return fmt.Errorf("failed to sync WAL: %w", err)
+	}
+	return dst.Close()
+}
+
+// syncDir fsyncs a directory so a rename in it survives a machine crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open WAL directory: %w", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync WAL directory: %w", err)
+	}
+	return nil
+}
+
+// Sync fsyncs any records appended since the last sync
+func (l *Log) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	return l.syncLocked()
+}
+
+// syncLocked fsyncs the file; the caller holds l.mu
+func (l *Log) syncLocked() error {
+	if l.pending == 0 {
+		return nil
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync WAL: %w", err)
+	}
+	l.pending = 0
+	return nil
+}
+
+// syncLoop fsyncs pending records every SyncInterval until Close
+func (l *Log) syncLoop() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.opts.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.Sync(); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+// Close stops background syncing, fsyncs pending records and closes the file
+func (l *Log) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	close(l.stop)
+	err := l.syncLocked()
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.mu.Unlock()
+
+	<-l.done
+	return err
+}
+
+// Replay reads every record in the log at path in order and passes it to
+// fn, returning the number of records read. A missing file replays nothing.
+// A torn or corrupt final record, as left by a crash mid-write, ends the
+// replay; the file is truncated to the last good record so later appends
+// are readable. A bad record with more of the log after it fails with
+// ErrCorrupt and leaves the file as it is.
+func Replay(path string, fn func(*Record) error) (int, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open WAL: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat WAL: %w", err)
+	}
+
+	r := bufio.NewReader(file)
+	var offset int64
+	count := 0
+
+	for {
+		rec, size, err := readRecord(r)
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			// The bad record's frame runs to the end of the file: torn
+			if offset+size < info.Size() {
+				return count, fmt.Errorf("%w at offset %d of %s: %v", ErrCorrupt, offset, path, err)
+			}
+			log.Printf("Warning: WAL %s has a torn final record at offset %d (%v); truncating", path, offset, err)
+			if err := file.Truncate(offset); err != nil {
+				return count, fmt.Errorf("failed to truncate WAL: %w", err)
+			}
+			return count, nil
+		}
+
+		if err := fn(rec); err != nil {
+			return count, fmt.Errorf("failed to replay WAL record %d: %w", count, err)
+		}
+		offset += size
+		count++
+	}
+}
+
+// readRecord reads one framed record, returning its size on disk. A bad
+// record comes with the size its header claims, or the bytes left for a
+// truncated header. io.EOF means the log ended cleanly between records.
+func readRecord(r *bufio.Reader) (*Record, int64, error) {
+	var header [frameHeaderSize]byte
+	if n, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return nil, 0, io.EOF
+		}
+		return nil, int64(n), fmt.Errorf("truncated record header")
+	}
+
+	length := binary.LittleEndian.Uint32(header[0:4])
+	checksum := binary.LittleEndian.Uint32(header[4:8])
+	size := int64(frameHeaderSize) + int64(length)
+	if length > maxRecordSize {
+		return nil, size, fmt.Errorf("record length %d exceeds limit", length)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, size, fmt.Errorf("truncated record")
+	}
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return nil, size, fmt.Errorf("checksum mismatch")
+	}
+
+	rec, err := decodeRecord(payload)
+	if err != nil {
+		return nil, size, err
+	}
+	return rec, size, nil
+}
+
+// encodeRecord serializes a record payload: op, ID, vector, metadata
+// pairs and text, with lengths as little-endian uint32. Typed metadata
+// follows as [count][per value: key, type tag, 8 value bytes], then the
+// external ID string. Each trailing section is written only when it or a
+// later one is present, so records written before they existed still
+// decode.
+func encodeRecord(rec *Record) ([]byte, error) {
+	size := 1 + 8 + 4 + 4*len(rec.Vector) + 4 + 4 + len(rec.Text)
+	for k, v := range rec.Metadata {
+		size += 8 + len(k) + len(v)
+	}
+	if rec.ExternalID != "" || len(rec.RemoveKeys) > 0 {
+		size += 4 + 4 + len(rec.ExternalID)
+	} else if len(rec.TypedMetadata) > 0 {
+		size += 4
+	}
+	if len(rec.RemoveKeys) > 0 {
+		size += 4
+		for _, k := range rec.RemoveKeys {
+			size += 4 + len(k)
+		}
+	}
+	if len(rec.TypedMetadata) > 0 {
+		for k := range rec.TypedMetadata {
+			size += 4 + len(k) + 1 + 8
+		}
+	}
+
+	buf := make([]byte, 0, size)
+	buf = append(buf, byte(rec.Op))
+	buf = binary.LittleEndian.AppendUint64(buf, rec.ID)
+
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(rec.Vector)))
+	for _, v := range rec.Vector {
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(rec.Metadata)))
+	for k, v := range rec.Metadata {
+		buf = appendString(buf, k)
+		buf = appendString(buf, v)
+	}
+
+	buf = appendString(buf, rec.Text)
+
+	// Trailing sections are written only when set, each implying the ones
+	// before it, so records from older versions still decode
+	if len(rec.TypedMetadata) > 0 || rec.ExternalID != "" || len(rec.RemoveKeys) > 0 {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(rec.TypedMetadata)))
+		for k, v := range rec.TypedMetadata {
+			buf = appendString(buf, k)
+			switch v := v.(type) {
+			case int64:
+				buf = append(buf, typeInt64)
+				buf = binary.LittleEndian.AppendUint64(buf, uint64(v))
+			case float64:
+				buf = append(buf, typeFloat64)
+				buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+			case bool:
+				var b uint64
+				if v {
+					b = 1
+				}
+				buf = append(buf, typeBool)
+				buf = binary.LittleEndian.AppendUint64(buf, b)
+			default:
+				return nil, fmt.Errorf("unsupported typed metadata value %T for key %q", v, k)
+			}
+		}
+	}
+
+	if rec.ExternalID != "" || len(rec.RemoveKeys) > 0 {
+		buf = appendString(buf, rec.ExternalID)
+	}
+
+	if len(rec.RemoveKeys) > 0 {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(rec.RemoveKeys)))
+		for _, k := range rec.RemoveKeys {
+			buf = appendString(buf, k)
+		}
+	}
+
+	return buf, nil
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(s)))
+	return append(buf, s...)
+}
+
+// decodeRecord parses a payload written by encodeRecord
+func decodeRecord(payload []byte) (*Record, error) {
+	d := decoder{buf: payload}
+	rec := &Record{Op: Op(d.byte()), ID: d.uint64()}
+
+	if n := d.uint32(); n > 0 && d.err == nil {
+		if int(n) > len(d.buf)/4 {
+			return nil, fmt.Errorf("corrupt vector length %d", n)
+		}
+		rec.Vector = make([]float32, n)
+		for i := range rec.Vector {
+			rec.Vector[i] = math.Float32frombits(d.uint32())
+		}
+	}
+
+	if n := d.uint32(); n > 0 && d.err == nil {
+		rec.Metadata = make(map[string]string, n)
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			k := d.string()
+			rec.Metadata[k] = d.string()
+		}
+	}
+
+	rec.Text = d.string()
+
+	if len(d.buf) > 0 && d.err == nil {
+		n := d.uint32()
+		if n > 0 {
+			rec.TypedMetadata = make(map[string]interface{}, n)
+		}
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			k := d.string()
+			tag := d.byte()
+			bits := d.uint64()
+			switch tag {
+			case typeInt64:
+				rec.TypedMetadata[k] = int64(bits)
+			case typeFloat64:
+				rec.TypedMetadata[k] = math.Float64frombits(bits)
+			case typeBool:
+				rec.TypedMetadata[k] = bits != 0
+			default:
+				if d.err == nil {
+					return nil, fmt.Errorf("unknown typed metadata tag %d", tag)
+				}
+			}
+		}
+	}
+
+	if len(d.buf) > 0 && d.err == nil {
+		rec.ExternalID = d.string()
+	}
+
+	if len(d.buf) > 0 && d.err == nil {
+		n := d.uint32()
+		if int(n) > len(d.buf)/4 {
+			return nil, fmt.Errorf("corrupt remove keys length %d", n)
+		}
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			rec.RemoveKeys = append(rec.RemoveKeys, d.string())
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	if rec.Op < OpInsert || rec.Op > OpCheckpoint {
+		return nil, fmt.Errorf("unknown record op %d", rec.Op)
+	}
+	return rec, nil
+}
+
+// decoder reads little-endian fields, recording the first short read
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.buf) < n {
+		d.err = fmt.Errorf("record payload too short")
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) byte() byte {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *decoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *decoder) uint64() uint64 {
+	if b := d.next(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (d *decoder) string() string {
+	n := d.uint32()
+	if b := d.next(int(n)); b != nil {
+		return string(b)
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+package wal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func readAll(t *testing.T, path string) []*Record {
+	t.Helper()
+	var records []*Record
+	if _, err := Replay(path, func(rec *Record) error {
+		records = append(records, rec)
+		return nil
+	}); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	return records
+}
+
+func TestAppendReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal", "default.log")
+
+	want := []*Record{
+		{Op: OpInsert, ID: 0, Vector: []float32{0.1, -2, 3.5}, Metadata: map[string]string{"category": "tech"}, Text: "hello"},
+		{Op: OpInsert, ID: 7, Vector: []float32{1, 2, 3}},
+		{Op: OpUpdate, ID: 0, Metadata: map[string]string{"category": "science", "year": "2024"}},
+		{Op: OpDelete, ID: 7},
+		{Op: OpInsert, ID: 8, Vector: []float32{4, 5, 6}, Metadata: map[string]string{"category": "tech"},
+			TypedMetadata: map[string]interface{}{"year": int64(-2023), "score": 0.25, "published": true}, Text: "typed"},
+		{Op: OpUpdate, ID: 8, TypedMetadata: map[string]interface{}{"published": false}},
+		{Op: OpInsert, ID: 9, Vector: []float32{7, 8, 9}, ExternalID: "doc-9"},
+		{Op: OpInsert, ID: 10, Vector: []float32{1, 1, 1}, TypedMetadata: map[string]interface{}{"year": int64(2024)}, ExternalID: "doc-10"},
+		{Op: OpMergeUpdate, ID: 10, Metadata: map[string]string{"category": "news"},
+			TypedMetadata: map[string]interface{}{"score": 0.5}, RemoveKeys: []string{"year"}},
+		{Op: OpMergeUpdate, ID: 0, RemoveKeys: []string{"category", "year"}},
+		{Op: OpConfig, Metadata: map[string]string{"m": "32", "metric": "euclidean"}},
+	}
+
+	l, err := Open(path, Options{SyncEvery: 1})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for _, rec := range want {
+		if err := l.Append(rec); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	got := readAll(t, path)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Replayed records differ:\ngot  %+v\nwant %+v", got, want)
+	}
+
+	// Reopening appends after the existing records
+	l, err = Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if err := l.Append(&Record{Op: OpDelete, ID: 0}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	l.Close()
+
+	if got := readAll(t, path); len(got) != len(want)+1 || got[len(want)].ID != 0 {
+		t.Errorf("Expected %d records ending with delete of 0, got %+v", len(want)+1, got)
+	}
+}
+
+func TestAppendRejectsUnsupportedTypedMetadata(t *testing.T) {
+	l, err := Open(filepath.Join(t.TempDir(), "default.log"), Options{})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer l.Close()
+
+	rec := &Record{Op: OpInsert, ID: 1, TypedMetadata: map[string]interface{}{"year": 2023}}
+	if err := l.Append(rec); err == nil {
+		t.Error("Expected an int (not int64) typed value to be rejected")
+	}
+}
+
+func TestReplayMissingFile(t *testing.T) {
+	n, err := Replay(filepath.Join(t.TempDir(), "missing.log"), func(*Record) error {
+		t.Error("Unexpected record")
+		return nil
+	})
+	if err != nil || n != 0 {
+		t.Errorf("Expected empty replay, got %d records (err: %v)", n, err)
+	}
+}
+
+func TestReplayTruncatesTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.log")
+
+	l, err := Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for id := uint64(0); id < 3; id++ {
+		l.Append(&Record{Op: OpInsert, ID: id, Vector: []float32{float32(id)}})
+	}
+	l.Close()
+
+	info, _ := os.Stat(path)
+	goodSize := info.Size()
+
+	// Simulate a crash partway through writing a fourth record
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{40, 0, 0, 0, 1, 2, 3, 4, 1})
+	f.Close()
+
+	if got := readAll(t, path); len(got) != 3 {
+		t.Fatalf("Expected 3 intact records, got %d", len(got))
+	}
+	if info, _ := os.Stat(path); info.Size() != goodSize {
+		t.Errorf("Expected log truncated to %d bytes, got %d", goodSize, info.Size())
+	}
+
+	// A corrupted payload is detected by its checksum
+	data, _ := os.ReadFile(path)
+	data[len(data)-1] ^= 0xff
+	os.WriteFile(path, data, 0644)
+
+	if got := readAll(t, path); len(got) != 2 {
+		t.Errorf("Expected 2 records before the corrupt one, got %d", len(got))
+	}
+}
+
+func TestReplayRejectsCorruptionBeforeTheTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.log")
+
+	l, err := Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for id := uint64(0); id < 3; id++ {
+		l.Append(&Record{Op: OpInsert, ID: id, Vector: []float32{float32(id)}})
+	}
+	l.Close()
+
+	// Flip a byte in the first record's payload
+	data, _ := os.ReadFile(path)
+	data[frameHeaderSize] ^= 0xff
+	os.WriteFile(path, data, 0644)
+
+	n, err := Replay(path, func(*Record) error { return nil })
+	if !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("Expected ErrCorrupt, got %d records (err: %v)", n, err)
+	}
+	if after, _ := os.ReadFile(path); len(after) != len(data) {
+		t.Errorf("Expected the log left at %d bytes, got %d", len(data), len(after))
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.log")
+
+	l, err := Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for id := uint64(0); id < 3; id++ {
+		l.Append(&Record{Op: OpInsert, ID: id, Vector: []float32{float32(id)}})
+	}
+	offset := l.Size()
+	if info, _ := os.Stat(path); info.Size() != offset {
+		t.Fatalf("Expected Size %d, got %d", info.Size(), offset)
+	}
+	l.Append(&Record{Op: OpDelete, ID: 1})
+
+	if err := l.Checkpoint(offset, &Record{Op: OpCheckpoint, Text: "snapshot.bin"}); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	if err := l.Checkpoint(0, &Record{Op: OpDelete, ID: 1}); err == nil {
+		t.Error("Expected error checkpointing with a record other than OpCheckpoint")
+	}
+
+	// Appends after the checkpoint go to the new file
+	l.Append(&Record{Op: OpInsert, ID: 3, Vector: []float32{3}})
+	l.Close()
+
+	want := []*Record{
+		{Op: OpCheckpoint, Text: "snapshot.bin"},
+		{Op: OpDelete, ID: 1},
+		{Op: OpInsert, ID: 3, Vector: []float32{3}},
+	}
+	if got := readAll(t, path); !reflect.DeepEqual(got, want) {
+		t.Errorf("Replayed records differ:\ngot  %+v\nwant %+v", got, want)
+	}
+	if _, err := os.Stat(path + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no temporary file left, got %v", err)
+	}
+}
+
+func TestSyncInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.log")
+
+	l, err := Open(path, Options{SyncInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	l.Append(&Record{Op: OpDelete, ID: 1})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		l.mu.Lock()
+		pending := l.pending
+		l.mu.Unlock()
+		if pending == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected background sync to flush pending records")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := l.Append(&Record{Op: OpDelete, ID: 2}); err == nil {
+		t.Error("Expected error appending to a closed log")
+	}
+}
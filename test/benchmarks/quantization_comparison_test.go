@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/therealutkarshpriyadarshi/vector/internal/quantization"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/eval"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/ivf"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/scann"
 )
@@ -40,7 +41,10 @@ var quantizationConfigs = []struct {
 }
 
 func TestQuantizationComparison(t *testing.T) {
-	fmt.Println("\n=== QUANTIZATION METHODS COMPARISON ===\n")
+	if testing.Short() {
+		t.Skip("Skipping quantization comparison in short mode")
+	}
+	fmt.Print("\n=== QUANTIZATION METHODS COMPARISON ===\n\n")
 
 	// Generate test data
 	database := generateRandomVectors(benchNumVectors, benchVectorDim)
@@ -101,11 +105,6 @@ func testProductQuantization(t *testing.T, name string, numSubvectors, bitsPerCo
 		distTable := pq.ComputeDistanceTable(query)
 
 		// Find k-NN using asymmetric distance
-		type candidate struct {
-			id   int
-			dist float32
-		}
-
 		candidates := make([]candidate, len(encodedDB))
 		for i, code := range encodedDB {
 			candidates[i] = candidate{
@@ -169,11 +168,6 @@ func testScalarQuantization(t *testing.T, database, queries [][]float32, groundT
 		quantizedQuery := sq.Quantize(query)
 
 		// Find k-NN using int8 distance
-		type candidate struct {
-			id   int
-			dist float32
-		}
-
 		candidates := make([]candidate, len(encodedDB))
 		for i, code := range encodedDB {
 			candidates[i] = candidate{
@@ -212,7 +206,10 @@ func testScalarQuantization(t *testing.T, database, queries [][]float32, groundT
 }
 
 func TestIndexComparison(t *testing.T) {
-	fmt.Println("\n=== INDEX METHODS COMPARISON ===\n")
+	if testing.Short() {
+		t.Skip("Skipping index comparison in short mode")
+	}
+	fmt.Print("\n=== INDEX METHODS COMPARISON ===\n\n")
 
 	database := generateRandomVectors(benchNumVectors, benchVectorDim)
 	queries := generateRandomVectors(benchNumQueries, benchVectorDim)
@@ -230,7 +227,12 @@ func TestIndexComparison(t *testing.T) {
 
 	// Test SCANN
 	t.Run("SCANN", func(t *testing.T) {
-		testSCANN(t, database, queries, groundTruth)
+		testSCANN(t, database, queries, groundTruth, false)
+	})
+
+	// Test SCANN with the learned rotation
+	t.Run("SCANN-Rotation", func(t *testing.T) {
+		testSCANN(t, database, queries, groundTruth, true)
 	})
 }
 
@@ -353,11 +355,12 @@ func testIVFPQ(t *testing.T, database, queries [][]float32, groundTruth [][]int)
 	}
 }
 
-func testSCANN(t *testing.T, database, queries [][]float32, groundTruth [][]int) {
+func testSCANN(t *testing.T, database, queries [][]float32, groundTruth [][]int, useRotation bool) {
 	config := scann.DefaultConfig()
 	config.NumPartitions = 100
 	config.NumSubvectors = 16
 	config.BitsPerCode = 8
+	config.UseRotation = useRotation
 
 	index := scann.NewSCANN(config)
 
@@ -406,6 +409,7 @@ func testSCANN(t *testing.T, database, queries [][]float32, groundTruth [][]int)
 		fmt.Printf("\nSCANN (nprobe=%d):\n", nprobe)
 		fmt.Printf("  Compression: %.1fx\n", compressionRatio)
 		fmt.Printf("  Spherical k-means: %v\n", config.SphericalKM)
+		fmt.Printf("  Learned rotation: %v\n", config.UseRotation)
 		fmt.Printf("  Training time: %v\n", trainTime)
 		fmt.Printf("  Adding time: %v\n", addTime)
 		fmt.Printf("  Recall@%d: %.2f%%\n", benchK, avgRecall*100)
@@ -428,32 +432,7 @@ func generateRandomVectors(n, dim int) [][]float32 {
 }
 
 func computeGroundTruth(queries, database [][]float32, k int) [][]int {
-	groundTruth := make([][]int, len(queries))
-
-	for qi, query := range queries {
-		type candidate struct {
-			id   int
-			dist float32
-		}
-
-		candidates := make([]candidate, len(database))
-		for i, vec := range database {
-			candidates[i] = candidate{
-				id:   i,
-				dist: quantization.EuclideanDistanceFloat32(query, vec),
-			}
-		}
-
-		// Sort by distance
-		quickSelect(candidates, k)
-
-		groundTruth[qi] = make([]int, k)
-		for i := 0; i < k; i++ {
-			groundTruth[qi][i] = candidates[i].id
-		}
-	}
-
-	return groundTruth
+	return eval.IDs(eval.BruteForceKNN(queries, database, k, eval.DefaultOptions()))
 }
 
 func computeRecall(groundTruth, results []int) float32 {
@@ -472,8 +451,14 @@ func computeRecall(groundTruth, results []int) float32 {
 	return float32(matches) / float32(len(groundTruth))
 }
 
+// candidate is a scored database entry
+type candidate struct {
+	id   int
+	dist float32
+}
+
 // Quick select for partial sorting (faster than full sort)
-func quickSelect(candidates []struct{ id int; dist float32 }, k int) {
+func quickSelect(candidates []candidate, k int) {
 	if k >= len(candidates) {
 		// Full sort
 		for i := 0; i < len(candidates)-1; i++ {
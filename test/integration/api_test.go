@@ -1,18 +1,54 @@
 package integration
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	grpcserver "github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/zstd"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/rest"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/rest/middleware"
 	"github.com/therealutkarshpriyadarshi/vector/pkg/config"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/eval"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/observability"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/search"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/wal"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
 )
 
-func setupTestServer(t *testing.T) (*grpcserver.Server, proto.VectorDBClient, func()) {
+func setupTestServer(t testing.TB) (*grpcserver.Server, proto.VectorDBClient, func()) {
 	// Create test configuration
 	cfg := config.Default()
 	cfg.Server.Port = 50052 // Use different port for testing
@@ -137,6 +173,180 @@ func TestInsertInvalidRequest(t *testing.T) {
 	}
 }
 
+func TestDimensionPolicy(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if err := server.SetDimensionPolicy("detailed", grpcserver.DimensionPolicyRejectWithDetail); err != nil {
+		t.Fatalf("SetDimensionPolicy failed: %v", err)
+	}
+	if err := server.SetDimensionPolicy("detailed", "pad"); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+
+	ctx := context.Background()
+
+	for _, ns := range []string{"strict", "detailed"} {
+		if _, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: ns,
+			Vector:    []float32{0.1, 0.2, 0.3},
+		}); err != nil {
+			t.Fatalf("Initial insert in %s failed: %v", ns, err)
+		}
+	}
+
+	// Strict (default) policy refuses with expected vs actual in the message
+	_, err := client.Insert(ctx, &proto.InsertRequest{
+		Namespace: "strict",
+		Vector:    []float32{0.1, 0.2, 0.3, 0.4},
+	})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+	if !strings.Contains(st.Message(), "expected 3, got 4") {
+		t.Errorf("Error should name expected and actual dimension, got %q", st.Message())
+	}
+	if len(st.Details()) != 0 {
+		t.Errorf("Strict policy should not attach details, got %d", len(st.Details()))
+	}
+
+	// reject-with-detail attaches structured details
+	_, err = client.Insert(ctx, &proto.InsertRequest{
+		Namespace: "detailed",
+		Vector:    []float32{0.1, 0.2},
+	})
+	st = status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+	if !strings.Contains(st.Message(), "expected 3, got 2") || !strings.Contains(st.Message(), "1 too few") {
+		t.Errorf("Unexpected detailed message: %q", st.Message())
+	}
+
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, d := range st.Details() {
+		switch detail := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = detail
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+	if info == nil || info.Metadata["expected_dimension"] != "3" || info.Metadata["actual_dimension"] != "2" {
+		t.Errorf("Expected ErrorInfo with dimensions, got %+v", info)
+	}
+	if badRequest == nil || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "vector" {
+		t.Errorf("Expected BadRequest field violation on vector, got %+v", badRequest)
+	}
+
+	// Matching inserts still succeed in both namespaces
+	for _, ns := range []string{"strict", "detailed"} {
+		resp, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: ns,
+			Vector:    []float32{0.3, 0.2, 0.1},
+		})
+		if err != nil || !resp.Success {
+			t.Errorf("Matching insert in %s failed: %v", ns, err)
+		}
+	}
+}
+
+func TestVectorValidation(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// The first insert fixes the namespace dimension
+	first, err := client.Insert(ctx, &proto.InsertRequest{Namespace: "five", Vector: []float32{1, 2, 3, 4, 5}})
+	if err != nil {
+		t.Fatalf("Initial insert failed: %v", err)
+	}
+
+	short := []float32{1, 2, 3}
+	mismatched := map[string]func() error{
+		"Insert": func() error {
+			_, err := client.Insert(ctx, &proto.InsertRequest{Namespace: "five", Vector: short})
+			return err
+		},
+		"Update": func() error {
+			_, err := client.Update(ctx, &proto.UpdateRequest{Namespace: "five", Id: first.Id, Vector: short})
+			return err
+		},
+		"Search": func() error {
+			_, err := client.Search(ctx, &proto.SearchRequest{Namespace: "five", QueryVector: short, K: 1})
+			return err
+		},
+		"RangeSearch": func() error {
+			_, err := client.RangeSearch(ctx, &proto.RangeSearchRequest{Namespace: "five", QueryVector: short, Radius: 1})
+			return err
+		},
+		"HybridSearch": func() error {
+			_, err := client.HybridSearch(ctx, &proto.HybridSearchRequest{Namespace: "five", QueryVector: short, QueryText: "x", K: 1})
+			return err
+		},
+		"MultiVectorSearch": func() error {
+			_, err := client.MultiVectorSearch(ctx, &proto.MultiVectorSearchRequest{
+				Namespace:    "five",
+				QueryVectors: []*proto.QueryVector{{Values: short}},
+				K:            1,
+			})
+			return err
+		},
+	}
+	for name, call := range mismatched {
+		st := status.Convert(call())
+		if st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), "expected 5, got 3") {
+			t.Errorf("%s: expected InvalidArgument naming both dimensions, got %v", name, st)
+		}
+	}
+
+	batch, err := client.BatchSearch(ctx, &proto.BatchSearchRequest{
+		Namespace: "five",
+		Queries:   []*proto.QueryVector{{Values: short}, {Values: []float32{1, 2, 3, 4, 5}}},
+		K:         1,
+	})
+	if err != nil {
+		t.Fatalf("BatchSearch failed: %v", err)
+	}
+	if !strings.Contains(batch.Responses[0].GetError(), "expected 5, got 3") || batch.Responses[1].GetError() != "" {
+		t.Errorf("Expected only the mismatched batch query to fail, got %v", batch.Responses)
+	}
+
+	// NaN and infinite components are rejected wherever a vector is accepted
+	for _, bad := range []float32{float32(math.NaN()), float32(math.Inf(1)), float32(math.Inf(-1))} {
+		vector := []float32{1, 2, bad, 4, 5}
+
+		_, err := client.Insert(ctx, &proto.InsertRequest{Namespace: "five", Vector: vector})
+		if st := status.Convert(err); st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), "component 2") {
+			t.Errorf("Insert of %v: expected InvalidArgument naming the component, got %v", bad, st)
+		}
+		_, err = client.Update(ctx, &proto.UpdateRequest{Namespace: "five", Id: first.Id, Vector: vector})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Update with %v: expected InvalidArgument, got %v", bad, err)
+		}
+		_, err = client.Search(ctx, &proto.SearchRequest{Namespace: "five", QueryVector: vector, K: 1})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Search with %v: expected InvalidArgument, got %v", bad, err)
+		}
+	}
+
+	// Nothing invalid reached the index
+	stats, err := client.GetStats(ctx, &proto.StatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if n := stats.NamespaceStats["five"].GetVectorCount(); n != 1 {
+		t.Errorf("Expected 1 stored vector, got %d", n)
+	}
+	fetch, err := client.Fetch(ctx, &proto.FetchRequest{Namespace: "five", Ids: []string{first.Id}})
+	if err != nil || fetch.Results[0].Vector[2] != 3 {
+		t.Errorf("Expected the stored vector to be unchanged, got %v (err: %v)", fetch, err)
+	}
+}
+
 func TestSearch(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -198,284 +408,5880 @@ func TestSearch(t *testing.T) {
 	t.Logf("Found %d results in %.2fms", len(searchResp.Results), searchResp.SearchTimeMs)
 }
 
-func TestHybridSearch(t *testing.T) {
-	_, client, cleanup := setupTestServer(t)
-	defer cleanup()
-
-	ctx := context.Background()
-
-	// Insert test vectors with text
-	testData := []struct {
-		vector []float32
-		text   string
-	}{
-		{[]float32{0.1, 0.2, 0.3}, "machine learning and artificial intelligence"},
-		{[]float32{0.2, 0.3, 0.4}, "deep neural networks for image recognition"},
-		{[]float32{0.9, 0.8, 0.7}, "cooking recipes and food preparation"},
-	}
+// rareFilter matches the 2% of vectors inserted by insertSelectiveVectors tagged "rare"
+var rareFilter = &proto.Filter{
+	FilterType: &proto.Filter_Comparison{
+		Comparison: &proto.ComparisonFilter{Field: "group", Operator: "eq", Value: "rare"},
+	},
+}
 
-	for i, data := range testData {
+// insertSelectiveVectors inserts n random vectors, tagging every 50th as rare
+func insertSelectiveVectors(t *testing.T, insert func(*proto.InsertRequest) error, n int) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < n; i++ {
+		group := "common"
+		if i%50 == 0 {
+			group = "rare"
+		}
 		req := &proto.InsertRequest{
 			Namespace: "default",
-			Vector:    data.vector,
-			Text:      &data.text,
-			Metadata: map[string]string{
-				"index": string(rune('0' + i)),
-			},
+			Vector:    []float32{rng.Float32(), rng.Float32(), rng.Float32()},
+			Metadata:  map[string]string{"group": group},
 		}
-
-		if _, err := client.Insert(ctx, req); err != nil {
+		if err := insert(req); err != nil {
 			t.Fatalf("Failed to insert vector %d: %v", i, err)
 		}
 	}
+}
 
-	// Wait for indexing
-	time.Sleep(100 * time.Millisecond)
+func TestSearchGuaranteeK(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
 
-	// Hybrid search
-	hybridReq := &proto.HybridSearchRequest{
+	ctx := context.Background()
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := client.Insert(ctx, req)
+		return err
+	}, 1000)
+
+	searchReq := &proto.SearchRequest{
 		Namespace:   "default",
-		QueryVector: []float32{0.15, 0.25, 0.35},
-		QueryText:   "machine learning neural networks",
-		K:           2,
-		EfSearch:    50,
+		QueryVector: []float32{0.5, 0.5, 0.5},
+		K:           10,
+		EfSearch:    10,
+		Filter:      rareFilter,
 	}
 
-	hybridResp, err := client.HybridSearch(ctx, hybridReq)
+	plainResp, err := client.Search(ctx, searchReq)
 	if err != nil {
-		t.Fatalf("Hybrid search failed: %v", err)
+		t.Fatalf("Search failed: %v", err)
 	}
+	t.Logf("Without guarantee_k: %d of %d results", len(plainResp.Results), searchReq.K)
 
-	if len(hybridResp.Results) == 0 {
-		t.Fatal("Hybrid search returned no results")
+	searchReq.GuaranteeK = true
+	resp, err := client.Search(ctx, searchReq)
+	if err != nil {
+		t.Fatalf("Search with guarantee_k failed: %v", err)
 	}
 
-	t.Logf("Found %d results in %.2fms", len(hybridResp.Results), hybridResp.SearchTimeMs)
+	if len(resp.Results) != int(searchReq.K) {
+		t.Fatalf("Expected a full %d results with guarantee_k, got %d", searchReq.K, len(resp.Results))
+	}
+	if resp.Truncated {
+		t.Error("Expected truncated=false when k results were found")
+	}
+	for i, r := range resp.Results {
+		if r.Metadata["group"] != "rare" {
+			t.Errorf("Result %d does not match filter: %v", i, r.Metadata)
+		}
+		if i > 0 && r.Distance < resp.Results[i-1].Distance {
+			t.Error("Results not sorted by distance")
+		}
+	}
 }
 
-func TestDelete(t *testing.T) {
-	_, client, cleanup := setupTestServer(t)
-	defer cleanup()
+func TestNamespaceRerankMetric(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
 
-	ctx := context.Background()
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
 
-	// Insert a vector
-	insertReq := &proto.InsertRequest{
-		Namespace: "default",
-		Vector:    []float32{0.1, 0.2, 0.3},
+	if err := server.SetNamespaceMetrics("docs", grpcserver.NamespaceMetrics{Retrieval: "manhattan"}); err == nil {
+		t.Error("Expected error for unknown metric")
 	}
 
-	insertResp, err := client.Insert(ctx, insertReq)
-	if err != nil {
-		t.Fatalf("Failed to insert: %v", err)
+	// Gather 2 candidates by dot product, then rank them by cosine
+	if err := server.SetNamespaceMetrics("docs", grpcserver.NamespaceMetrics{
+		Retrieval:   grpcserver.MetricDotProduct,
+		Rerank:      grpcserver.MetricCosine,
+		RerankDepth: 2,
+	}); err != nil {
+		t.Fatalf("SetNamespaceMetrics failed: %v", err)
 	}
 
-	id := insertResp.Id
+	ctx := context.Background()
+	// For query (1, 0, 0): dot product order is a, c, b, d; cosine order is d, b, c, a
+	vectors := map[string][]float32{
+		"a": {10, 10, 0},
+		"b": {1, 0.1, 0},
+		"c": {6, 0, 3},
+		"d": {0.5, 0, 0},
+	}
+	ids := make(map[string]string)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		resp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: vectors[name]})
+		if err != nil {
+			t.Fatalf("Insert %s failed: %v", name, err)
+		}
+		ids[resp.Id] = name
+	}
 
-	// Delete the vector
-	deleteReq := &proto.DeleteRequest{
-		Namespace: "default",
-		Selector:  &proto.DeleteRequest_Id{Id: id},
+	if err := server.SetNamespaceMetrics("docs", grpcserver.NamespaceMetrics{Retrieval: grpcserver.MetricCosine}); err == nil {
+		t.Error("Expected error changing metrics of a populated namespace")
 	}
 
-	deleteResp, err := client.Delete(ctx, deleteReq)
+	resp, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "docs",
+		QueryVector: []float32{1, 0, 0},
+		K:           2,
+		EfSearch:    10,
+	})
 	if err != nil {
-		t.Fatalf("Delete failed: %v", err)
+		t.Fatalf("Search failed: %v", err)
 	}
 
-	if !deleteResp.Success {
-		t.Fatalf("Delete returned success=false: %v", deleteResp.Error)
+	// Candidates are the dot product top 2 (a, c); final order is by cosine
+	var got []string
+	for _, r := range resp.Results {
+		got = append(got, ids[r.Id])
 	}
-
-	if deleteResp.DeletedCount != 1 {
-		t.Fatalf("Expected 1 deleted, got %d", deleteResp.DeletedCount)
+	if strings.Join(got, ",") != "c,a" {
+		t.Fatalf("Expected dot-product candidates reranked by cosine [c a], got %v", got)
 	}
 
-	t.Logf("Deleted vector %s", id)
+	// Reported distances are cosine distances in ascending order
+	if d := resp.Results[0].Distance; d < 0.1 || d > 0.11 {
+		t.Errorf("Expected cosine distance ~0.106 for c, got %f", d)
+	}
+	if resp.Results[1].Distance < resp.Results[0].Distance {
+		t.Error("Results not sorted by rerank distance")
+	}
 }
 
-func TestUpdate(t *testing.T) {
-	_, client, cleanup := setupTestServer(t)
-	defer cleanup()
+func TestSearchScoreMode(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 2
 
-	ctx := context.Background()
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
 
-	// Insert a vector
-	insertReq := &proto.InsertRequest{
-		Namespace: "default",
-		Vector:    []float32{0.1, 0.2, 0.3},
-		Metadata: map[string]string{
-			"status": "draft",
-		},
+	ctx := context.Background()
+	for _, metric := range []string{grpcserver.MetricEuclidean, grpcserver.MetricDotProduct} {
+		if err := server.SetNamespaceMetrics(metric, grpcserver.NamespaceMetrics{Retrieval: metric}); err != nil {
+			t.Fatalf("SetNamespaceMetrics failed: %v", err)
+		}
 	}
 
-	insertResp, err := client.Insert(ctx, insertReq)
-	if err != nil {
-		t.Fatalf("Failed to insert: %v", err)
+	tests := []struct {
+		namespace string
+		score     func(distance float64) float64
+	}{
+		{"cosine", func(d float64) float64 { return math.Max(0, 1-d) }},
+		{grpcserver.MetricEuclidean, func(d float64) float64 { return 1 / (1 + d) }},
+		{grpcserver.MetricDotProduct, func(d float64) float64 { return 1 / (1 + math.Exp(d)) }},
 	}
 
-	id := insertResp.Id
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			for _, v := range [][]float32{{1, 0}, {0.6, 0.8}, {-1, 0.1}, {3, 4}} {
+				if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: tt.namespace, Vector: v}); err != nil {
+					t.Fatalf("Insert failed: %v", err)
+				}
+			}
 
-	// Update the vector
-	updateReq := &proto.UpdateRequest{
-		Namespace: "default",
-		Id:        id,
-		Vector:    []float32{0.2, 0.3, 0.4},
-		Metadata: map[string]string{
-			"status": "published",
-		},
-	}
+			query := &proto.SearchRequest{Namespace: tt.namespace, QueryVector: []float32{1, 0}, K: 4}
+			resp, err := server.Search(ctx, query)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			for _, r := range resp.Results {
+				if r.Score != nil {
+					t.Errorf("Expected no score in distance mode, got %v", *r.Score)
+				}
+			}
 
-	updateResp, err := client.Update(ctx, updateReq)
-	if err != nil {
-		t.Fatalf("Update failed: %v", err)
+			query.ScoreMode = "similarity"
+			resp, err = server.Search(ctx, query)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(resp.Results) != 4 {
+				t.Fatalf("Expected 4 results, got %d", len(resp.Results))
+			}
+			for i, r := range resp.Results {
+				if r.Score == nil {
+					t.Fatalf("Expected a score on result %s", r.Id)
+				}
+				score := float64(*r.Score)
+				if want := tt.score(float64(r.Distance)); math.Abs(score-want) > 1e-6 {
+					t.Errorf("Result %s: score %f for distance %f, want %f", r.Id, score, r.Distance, want)
+				}
+				if score < 0 || score > 1 {
+					t.Errorf("Result %s: score %f outside [0,1]", r.Id, score)
+				}
+				if i > 0 && score > float64(*resp.Results[i-1].Score) {
+					t.Errorf("Scores not descending: %f after %f", score, *resp.Results[i-1].Score)
+				}
+			}
+		})
 	}
 
-	if !updateResp.Success {
-		t.Fatalf("Update returned success=false: %v", updateResp.Error)
+	_, err = server.Search(ctx, &proto.SearchRequest{Namespace: "cosine", QueryVector: []float32{1, 0}, K: 1, ScoreMode: "percent"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown score mode, got %v", err)
 	}
-
-	t.Logf("Updated vector %s", id)
 }
 
-func TestBatchInsert(t *testing.T) {
-	_, client, cleanup := setupTestServer(t)
-	defer cleanup()
-
-	ctx := context.Background()
+func TestSearchMaxDistance(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 2
 
-	// Create batch insert stream
-	stream, err := client.BatchInsert(ctx)
+	server, err := grpcserver.NewServer(cfg)
 	if err != nil {
-		t.Fatalf("Failed to create batch insert stream: %v", err)
+		t.Fatalf("Failed to create server: %v", err)
 	}
+	defer server.Stop()
 
-	// Send multiple vectors
-	numVectors := 10
-	for i := 0; i < numVectors; i++ {
-		req := &proto.InsertRequest{
-			Namespace: "default",
-			Vector:    []float32{float32(i) * 0.1, float32(i) * 0.2, float32(i) * 0.3},
-			Metadata: map[string]string{
-				"batch_index": string(rune('0' + i)),
-			},
-		}
-
-		if err := stream.Send(req); err != nil {
-			t.Fatalf("Failed to send vector %d: %v", i, err)
+	ctx := context.Background()
+	if err := server.SetNamespaceMetrics("points", grpcserver.NamespaceMetrics{Retrieval: grpcserver.MetricEuclidean}); err != nil {
+		t.Fatalf("SetNamespaceMetrics failed: %v", err)
+	}
+	// Points 1, 2, ..., 8 away from the origin along the x axis
+	for i := 1; i <= 8; i++ {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "points", Vector: []float32{float32(i), 0}}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
 		}
 	}
 
-	// Close stream and get response
-	resp, err := stream.CloseAndRecv()
-	if err != nil {
-		t.Fatalf("Failed to close stream: %v", err)
+	maxDistance := float32(3.5)
+	minScore := float32(0.2) // 1 / (1 + d) >= 0.2 keeps d <= 4
+	tests := []struct {
+		name    string
+		req     *proto.SearchRequest
+		results int
+	}{
+		{"no threshold", &proto.SearchRequest{K: 5}, 5},
+		{"max distance excludes part of the top k", &proto.SearchRequest{K: 5, MaxDistance: &maxDistance}, 3},
+		{"k still caps", &proto.SearchRequest{K: 2, MaxDistance: &maxDistance}, 2},
+		{"min score", &proto.SearchRequest{K: 5, MinScore: &minScore, ScoreMode: "similarity"}, 4},
+		{"tighter of both", &proto.SearchRequest{K: 5, MaxDistance: &maxDistance, MinScore: &minScore}, 3},
+		{"offset pages within the threshold", &proto.SearchRequest{K: 5, Offset: 2, MaxDistance: &maxDistance}, 1},
 	}
-
-	if resp.InsertedCount != int32(numVectors) {
-		t.Fatalf("Expected %d insertions, got %d", numVectors, resp.InsertedCount)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Namespace = "points"
+			tt.req.QueryVector = []float32{0, 0}
+			resp, err := server.Search(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(resp.Results) != tt.results {
+				t.Fatalf("Expected %d results, got %d", tt.results, len(resp.Results))
+			}
+			for _, r := range resp.Results {
+				if tt.req.MaxDistance != nil && r.Distance > *tt.req.MaxDistance {
+					t.Errorf("Result %s at distance %f beyond max_distance %f", r.Id, r.Distance, *tt.req.MaxDistance)
+				}
+				if tt.req.MinScore != nil && r.Score != nil && *r.Score < *tt.req.MinScore {
+					t.Errorf("Result %s scored %f below min_score %f", r.Id, *r.Score, *tt.req.MinScore)
+				}
+			}
+		})
 	}
 
-	if resp.FailedCount != 0 {
-		t.Fatalf("Expected 0 failures, got %d: %v", resp.FailedCount, resp.Errors)
+	badScore := float32(1.5)
+	nan := float32(math.NaN())
+	for _, req := range []*proto.SearchRequest{
+		{Namespace: "points", QueryVector: []float32{0, 0}, K: 5, MinScore: &badScore},
+		{Namespace: "points", QueryVector: []float32{0, 0}, K: 5, MaxDistance: &nan},
+	} {
+		if _, err := server.Search(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
 	}
+}
 
-	t.Logf("Batch inserted %d vectors in %.2fms", resp.InsertedCount, resp.TotalTimeMs)
-}
+func TestSearchExactSmallNamespace(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
 
-func TestGetStats(t *testing.T) {
-	_, client, cleanup := setupTestServer(t)
-	defer cleanup()
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
 
 	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "small",
+			Vector:    []float32{float32(i), float32(10 - i), 1},
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := server.Insert(ctx, req)
+		return err
+	}, 1000)
 
-	// Insert some vectors
-	for i := 0; i < 5; i++ {
+	query := []float32{0.5, 0.5, 0.5}
+	resp, err := server.Search(ctx, &proto.SearchRequest{Namespace: "small", QueryVector: query, K: 20})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if !resp.Exact {
+		t.Error("Expected exact=true for a 10-vector namespace")
+	}
+	if len(resp.Results) != 10 {
+		t.Errorf("Expected all 10 vectors, got %d", len(resp.Results))
+	}
+	for i := 1; i < len(resp.Results); i++ {
+		if resp.Results[i].Distance < resp.Results[i-1].Distance {
+			t.Errorf("Results not sorted at %d", i)
+		}
+	}
+
+	// Filters apply to the whole scan, so every match is found
+	resp, err = server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "small",
+		QueryVector: query,
+		K:           5,
+		Filter:      rareFilter,
+	})
+	if err != nil {
+		t.Fatalf("Filtered search failed: %v", err)
+	}
+	if !resp.Exact || len(resp.Results) != 0 {
+		t.Errorf("Expected exact empty result, got exact=%v with %d results", resp.Exact, len(resp.Results))
+	}
+
+	resp, err = server.Search(ctx, &proto.SearchRequest{Namespace: "default", QueryVector: query, K: 10})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.Exact {
+		t.Error("Expected exact=false for a 1000-vector namespace")
+	}
+	if len(resp.Results) != 10 {
+		t.Errorf("Expected 10 results, got %d", len(resp.Results))
+	}
+}
+
+func TestFlatNamespace(t *testing.T) {
+	flatCfg := config.Default()
+	flatCfg.HNSW.Dimensions = 4
+	flatCfg.HNSW.FlatThreshold = 20
+	flatCfg.HNSW.ExactSearchThreshold = 0
+	flat, err := grpcserver.NewServer(flatCfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer flat.Stop()
+
+	// The same data behind a graph, searched by the exact path
+	graphCfg := config.Default()
+	graphCfg.HNSW.Dimensions = 4
+	graphCfg.HNSW.FlatThreshold = 0
+	graph, err := grpcserver.NewServer(graphCfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer graph.Stop()
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(7))
+	insert := func(servers ...*grpcserver.Server) {
 		req := &proto.InsertRequest{
-			Namespace: "default",
-			Vector:    []float32{float32(i) * 0.1, float32(i) * 0.2, float32(i) * 0.3},
+			Namespace: "small",
+			Vector:    []float32{rng.Float32(), rng.Float32(), rng.Float32(), rng.Float32()},
 		}
-		if _, err := client.Insert(ctx, req); err != nil {
-			t.Fatalf("Failed to insert: %v", err)
+		for _, server := range servers {
+			if _, err := server.Insert(ctx, req); err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+		}
+	}
+	for i := 0; i < 20; i++ {
+		insert(flat, graph)
+	}
+
+	for q := 0; q < 5; q++ {
+		req := &proto.SearchRequest{
+			Namespace:   "small",
+			QueryVector: []float32{rng.Float32(), rng.Float32(), rng.Float32(), rng.Float32()},
+			K:           8,
+		}
+		flatResp, err := flat.Search(ctx, req)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		graphResp, err := graph.Search(ctx, req)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if !flatResp.Exact {
+			t.Error("Expected exact=true for a namespace without a graph")
+		}
+		if len(flatResp.Results) != len(graphResp.Results) {
+			t.Fatalf("Expected %d results, got %d", len(graphResp.Results), len(flatResp.Results))
+		}
+		for i := range graphResp.Results {
+			if flatResp.Results[i].Id != graphResp.Results[i].Id || flatResp.Results[i].Distance != graphResp.Results[i].Distance {
+				t.Errorf("Query %d rank %d: flat (%s, %f) != graph (%s, %f)", q, i,
+					flatResp.Results[i].Id, flatResp.Results[i].Distance,
+					graphResp.Results[i].Id, graphResp.Results[i].Distance)
+			}
 		}
 	}
 
-	// Get stats
-	statsReq := &proto.StatsRequest{}
-	statsResp, err := client.GetStats(ctx, statsReq)
+	// Growing past the threshold switches to the graph transparently
+	for i := 0; i < 10; i++ {
+		insert(flat)
+	}
+	resp, err := flat.Search(ctx, &proto.SearchRequest{Namespace: "small", QueryVector: []float32{0.5, 0.5, 0.5, 0.5}, K: 30})
 	if err != nil {
-		t.Fatalf("GetStats failed: %v", err)
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.Exact {
+		t.Error("Expected exact=false once the graph is built")
 	}
+	if len(resp.Results) != 30 {
+		t.Errorf("Expected all 30 vectors, got %d", len(resp.Results))
+	}
+}
 
-	if statsResp.TotalVectors < 5 {
-		t.Fatalf("Expected at least 5 vectors, got %d", statsResp.TotalVectors)
+func TestQuantizedVectors(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.HNSW.Quantization = map[string]config.QuantizationRange{"scaled": {Min: 0, Max: 2}}
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
+	ctx := context.Background()
 
-	if statsResp.TotalNamespaces < 1 {
-		t.Fatal("Expected at least 1 namespace")
+	// int8 codes are used as-is without a configured range
+	for _, codes := range [][]int8{{127, 0, 0}, {0, 127, 0}, {0, 0, -127}} {
+		data := make([]byte, len(codes))
+		for i, c := range codes {
+			data[i] = byte(c)
+		}
+		if _, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace:       "int8",
+			Vector:          []float32{9, 9, 9}, // Ignored in favor of the quantized vector
+			QuantizedVector: data,
+			Quantization:    "int8",
+		}); err != nil {
+			t.Fatalf("Quantized insert failed: %v", err)
+		}
+	}
+
+	resp, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace:            "int8",
+		QuantizedQueryVector: []byte{0, 0, 0x9c}, // int8 -100
+		Quantization:         "int8",
+		K:                    1,
+	})
+	if err != nil {
+		t.Fatalf("Quantized search failed: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Id != "2" {
+		t.Fatalf("Expected vector 2 nearest, got %v", resp.Results)
+	}
+	if got := resp.Results[0].Vector; got[2] != -127 {
+		t.Errorf("Expected dequantized vector [0 0 -127], got %v", got)
+	}
+
+	// uint8 codes span the range declared with the namespace
+	created, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{
+		Namespace:       "uint8",
+		QuantizationMin: -1,
+		QuantizationMax: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateNamespace failed: %v", err)
+	}
+	if created.QuantizationMin != -1 || created.QuantizationMax != 1 {
+		t.Errorf("Expected quantization range [-1, 1], got [%v, %v]", created.QuantizationMin, created.QuantizationMax)
+	}
+	if _, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace:       "uint8",
+		QuantizedVector: []byte{0, 255, 0},
+		Quantization:    "uint8",
+	}); err != nil {
+		t.Fatalf("Quantized insert failed: %v", err)
+	}
+	resp, err = server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "uint8",
+		QueryVector: []float32{-1, 1, -1},
+		K:           1,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Distance > 1e-5 {
+		t.Errorf("Expected an exact match for the dequantized vector, got %v", resp.Results)
+	}
+
+	// The config sets ranges for namespaces before they are created
+	if _, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace:       "scaled",
+		QuantizedVector: []byte{0x81, 0, 127}, // int8 -127, 0, 127
+		Quantization:    "int8",
+	}); err != nil {
+		t.Fatalf("Quantized insert failed: %v", err)
+	}
+	described, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "scaled"})
+	if err != nil {
+		t.Fatalf("DescribeNamespace failed: %v", err)
+	}
+	if described.QuantizationMin != 0 || described.QuantizationMax != 2 {
+		t.Errorf("Expected configured range [0, 2], got [%v, %v]", described.QuantizationMin, described.QuantizationMax)
+	}
+	resp, err = server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "scaled",
+		QueryVector: []float32{0, 1, 2},
+		K:           1,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Distance > 1e-5 {
+		t.Errorf("Expected the int8 codes to span [0, 2], got %v", resp.Results)
+	}
+
+	_, err = server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{
+		Namespace:       "inverted",
+		QuantizationMin: 1,
+		QuantizationMax: -1,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an inverted quantization range, got %v", err)
 	}
 
-	t.Logf("Stats: %d vectors, %d namespaces", statsResp.TotalVectors, statsResp.TotalNamespaces)
+	_, err = server.Insert(ctx, &proto.InsertRequest{
+		Namespace:       "int8",
+		QuantizedVector: []byte{1, 2, 3},
+		Quantization:    "int4",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unknown quantization, got %v", err)
+	}
 }
 
-func TestHealthCheck(t *testing.T) {
+func TestRangeSearch(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
 
 	ctx := context.Background()
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := client.Insert(ctx, req)
+		return err
+	}, 1000)
 
-	// Health check
-	healthReq := &proto.HealthCheckRequest{}
-	healthResp, err := client.HealthCheck(ctx, healthReq)
+	req := &proto.RangeSearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0.5, 0.5, 0.5},
+		Radius:      0.01,
+	}
+	resp, err := client.RangeSearch(ctx, req)
 	if err != nil {
-		t.Fatalf("HealthCheck failed: %v", err)
+		t.Fatalf("RangeSearch failed: %v", err)
+	}
+	if len(resp.Results) <= 10 {
+		t.Fatalf("Expected more than k=10 neighbors in range, got %d", len(resp.Results))
+	}
+	if resp.Truncated || resp.Exact {
+		t.Errorf("Unexpected truncated=%v exact=%v", resp.Truncated, resp.Exact)
+	}
+	for i, r := range resp.Results {
+		if r.Distance > req.Radius {
+			t.Errorf("Result %d at distance %f is outside radius %f", i, r.Distance, req.Radius)
+		}
 	}
 
-	if healthResp.Status != "healthy" {
-		t.Fatalf("Expected status 'healthy', got '%s'", healthResp.Status)
+	// The cap keeps a huge radius from returning the whole namespace
+	req.Radius = 10
+	req.MaxResults = 25
+	resp, err = client.RangeSearch(ctx, req)
+	if err != nil {
+		t.Fatalf("RangeSearch failed: %v", err)
+	}
+	if len(resp.Results) != 25 || !resp.Truncated {
+		t.Errorf("Expected 25 truncated results, got %d (truncated=%v)", len(resp.Results), resp.Truncated)
 	}
 
-	if healthResp.Version == "" {
-		t.Error("Version is empty")
+	req.Radius = -1
+	if _, err := client.RangeSearch(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for negative radius, got %v", err)
 	}
+}
 
-	t.Logf("Health: %s (version %s, uptime %ds)",
-		healthResp.Status, healthResp.Version, healthResp.UptimeSeconds)
+func TestSearchEfSearchScaling(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 16
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.SetNamespaceMetrics("docs", grpcserver.NamespaceMetrics{Retrieval: grpcserver.MetricEuclidean}); err != nil {
+		t.Fatalf("SetNamespaceMetrics failed: %v", err)
+	}
+	if err := server.SetEfSearchMultiplier("docs", -1); err == nil {
+		t.Error("Expected error for negative multiplier")
+	}
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(42))
+	newVector := func() []float32 {
+		v := make([]float32, 16)
+		for i := range v {
+			v[i] = rng.Float32()
+		}
+		return v
+	}
+
+	const numVectors = 2000
+	database := make([][]float32, numVectors)
+	positions := make(map[string]int)
+	for i := range database {
+		database[i] = newVector()
+		resp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: database[i]})
+		if err != nil {
+			t.Fatalf("Insert %d failed: %v", i, err)
+		}
+		positions[resp.Id] = i
+	}
+
+	const k = 100
+	queries := [][]float32{newVector(), newVector(), newVector(), newVector(), newVector()}
+	groundTruth := eval.IDs(eval.BruteForceKNN(queries, database, k, eval.DefaultOptions()))
+
+	search := func(query []float32) *proto.SearchResponse {
+		resp, err := server.Search(ctx, &proto.SearchRequest{
+			Namespace:   "docs",
+			QueryVector: query,
+			K:           k,
+			EfSearch:    50,
+		})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		return resp
+	}
+
+	// Without a multiplier efSearch is only raised to k
+	if got := search(queries[0]).EffectiveEfSearch; got != k {
+		t.Errorf("Expected effective efSearch %d without multiplier, got %d", k, got)
+	}
+
+	if err := server.SetEfSearchMultiplier("docs", 2); err != nil {
+		t.Fatalf("SetEfSearchMultiplier failed: %v", err)
+	}
+
+	hits := 0
+	for qi, query := range queries {
+		resp := search(query)
+		if resp.EffectiveEfSearch != 2*k {
+			t.Fatalf("Expected effective efSearch %d, got %d", 2*k, resp.EffectiveEfSearch)
+		}
+
+		truth := make(map[int]bool, k)
+		for _, id := range groundTruth[qi] {
+			truth[id] = true
+		}
+		for _, r := range resp.Results {
+			if truth[positions[r.Id]] {
+				hits++
+			}
+		}
+	}
+
+	recall := float64(hits) / float64(k*len(queries))
+	t.Logf("Recall@%d with efSearch=%d: %.3f", k, 2*k, recall)
+	if recall < 0.9 {
+		t.Errorf("Recall %.3f below 0.9", recall)
+	}
 }
 
-func TestMultipleNamespaces(t *testing.T) {
+func TestSearchProfile(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
 
 	ctx := context.Background()
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := client.Insert(ctx, req)
+		return err
+	}, 500)
 
-	// Insert vectors in different namespaces
-	namespaces := []string{"ns1", "ns2", "ns3"}
-	for _, ns := range namespaces {
-		req := &proto.InsertRequest{
-			Namespace: ns,
-			Vector:    []float32{0.1, 0.2, 0.3},
+	searchReq := &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0.5, 0.5, 0.5},
+		K:           10,
+		EfSearch:    50,
+	}
+
+	resp, err := client.Search(ctx, searchReq)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.Profile != nil {
+		t.Error("Profile should only be attached when requested")
+	}
+
+	searchReq.Profile = true
+	searchReq.Filter = rareFilter
+	searchReq.GuaranteeK = true
+	resp, err = client.Search(ctx, searchReq)
+	if err != nil {
+		t.Fatalf("Profiled search failed: %v", err)
+	}
+
+	prof := resp.Profile
+	if prof == nil {
+		t.Fatal("Expected a profile in the response")
+	}
+	if prof.NodesVisited < int64(len(resp.Results)) {
+		t.Errorf("NodesVisited %d < %d results", prof.NodesVisited, len(resp.Results))
+	}
+	if prof.DistanceComputations == 0 || prof.DistanceComputations > prof.NodesVisited {
+		t.Errorf("DistanceComputations %d should be in (0, NodesVisited %d]", prof.DistanceComputations, prof.NodesVisited)
+	}
+	if prof.HeapOperations < int64(len(resp.Results)) {
+		t.Errorf("HeapOperations %d < %d results", prof.HeapOperations, len(resp.Results))
+	}
+	if prof.FilterEvaluations < int64(len(resp.Results)) {
+		t.Errorf("FilterEvaluations %d < %d results", prof.FilterEvaluations, len(resp.Results))
+	}
+
+	spans := make(map[string]int64)
+	for _, span := range prof.Spans {
+		spans[span.Name] = span.DurationNs
+	}
+	total, ok := spans["search"]
+	if !ok || total <= 0 {
+		t.Fatalf("Expected a positive root span, got %v", prof.Spans)
+	}
+	children := spans["search;hnsw;greedy_descent"] + spans["search;hnsw;base_layer"] + spans["search;filter"]
+	if children > total {
+		t.Errorf("Child spans (%dns) exceed root span (%dns)", children, total)
+	}
+	if prof.DistanceNs > spans["search;hnsw;greedy_descent"]+spans["search;hnsw;base_layer"] {
+		t.Errorf("Distance time %dns exceeds HNSW phase time", prof.DistanceNs)
+	}
+}
+
+func TestSearchGuaranteeKTruncated(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.HNSW.GuaranteeKMaxCandidates = 40
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := server.Insert(ctx, req)
+		return err
+	}, 1000)
+
+	// Only ~1 rare vector is expected among 40 candidates
+	resp, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0.5, 0.5, 0.5},
+		K:           10,
+		Filter:      rareFilter,
+		GuaranteeK:  true,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if !resp.Truncated {
+		t.Errorf("Expected truncated=true at the work cap, got %d results", len(resp.Results))
+	}
+	if len(resp.Results) >= 10 {
+		t.Errorf("Expected fewer than 10 results within the cap, got %d", len(resp.Results))
+	}
+}
+
+func TestSearchFilteredTraversal(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := server.Insert(ctx, req)
+		return err
+	}, 1000)
+
+	query := []float32{0.5, 0.5, 0.5}
+
+	// Filtering the top 10 after the search finds only a few rare vectors
+	plain, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace: "default", QueryVector: query, K: 10, EfSearch: 10,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	postFiltered := 0
+	for _, r := range plain.Results {
+		if r.Metadata["group"] == "rare" {
+			postFiltered++
 		}
-		if _, err := client.Insert(ctx, req); err != nil {
-			t.Fatalf("Failed to insert in namespace %s: %v", ns, err)
+	}
+	if postFiltered >= 10 {
+		t.Fatalf("Expected post-filtering to keep fewer than 10 results, got %d", postFiltered)
+	}
+
+	// The filter applied during traversal fills k without guarantee_k
+	resp, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace: "default", QueryVector: query, K: 10, EfSearch: 10, Filter: rareFilter,
+	})
+	if err != nil {
+		t.Fatalf("Filtered search failed: %v", err)
+	}
+	if len(resp.Results) != 10 {
+		t.Fatalf("Expected 10 results (post-filtering kept %d), got %d", postFiltered, len(resp.Results))
+	}
+	if resp.Truncated {
+		t.Error("Expected truncated=false when k results were found")
+	}
+	for i, r := range resp.Results {
+		if r.Metadata["group"] != "rare" {
+			t.Errorf("Result %d does not match filter: %v", i, r.Metadata)
+		}
+		if i > 0 && r.Distance < resp.Results[i-1].Distance {
+			t.Errorf("Results not sorted at %d", i)
 		}
 	}
 
-	// Get stats to verify namespaces
-	statsReq := &proto.StatsRequest{}
-	statsResp, err := client.GetStats(ctx, statsReq)
+	// A low visit cap stops the traversal before k matches are found. The
+	// query differs from the one above so the response is not cached.
+	cfg.HNSW.FilteredSearchMaxVisited = 50
+	resp, err = server.Search(ctx, &proto.SearchRequest{
+		Namespace: "default", QueryVector: []float32{0.4, 0.5, 0.5}, K: 10, EfSearch: 10, Filter: rareFilter,
+	})
 	if err != nil {
-		t.Fatalf("GetStats failed: %v", err)
+		t.Fatalf("Filtered search failed: %v", err)
 	}
+	if !resp.Truncated || len(resp.Results) >= 10 {
+		t.Errorf("Expected truncated=true with fewer than 10 results, got truncated=%v with %d",
+			resp.Truncated, len(resp.Results))
+	}
+}
 
-	if int(statsResp.TotalNamespaces) < len(namespaces) {
-		t.Fatalf("Expected at least %d namespaces, got %d",
-			len(namespaces), statsResp.TotalNamespaces)
+func TestSearchCountTotal(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
+	defer server.Stop()
 
-	t.Logf("Created %d namespaces successfully", len(namespaces))
-}
+	ctx := context.Background()
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := server.Insert(ctx, req)
+		return err
+	}, 1000)
 
-func stringPtr(s string) *string {
-	return &s
+	query := []float32{0.5, 0.5, 0.5}
+	resp, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: query,
+		K:           5,
+		Filter:      rareFilter,
+		GuaranteeK:  true,
+		CountTotal:  true,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.TotalResults != 5 || resp.TotalMatches != 20 {
+		t.Errorf("Expected 5 of 20 rare vectors, got %d of %d", resp.TotalResults, resp.TotalMatches)
+	}
+
+	// Without a filter every vector matches
+	resp, err = server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: query,
+		K:           5,
+		CountTotal:  true,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.TotalMatches != 1000 {
+		t.Errorf("Expected 1000 matches without a filter, got %d", resp.TotalMatches)
+	}
+
+	// Counting is off by default, and cached counted results are not reused
+	resp, err = server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: query,
+		K:           5,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if resp.TotalMatches != 0 {
+		t.Errorf("Expected no count unless requested, got %d", resp.TotalMatches)
+	}
+}
+
+func TestSearchOffset(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.HNSW.SearchMaxWindow = 50
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	insertSelectiveVectors(t, func(req *proto.InsertRequest) error {
+		_, err := server.Insert(ctx, req)
+		return err
+	}, 100)
+
+	query := []float32{0.5, 0.5, 0.5}
+	full, err := server.Search(ctx, &proto.SearchRequest{Namespace: "default", QueryVector: query, K: 30})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	// Consecutive pages line up with one larger search
+	for page := 0; page < 3; page++ {
+		resp, err := server.Search(ctx, &proto.SearchRequest{
+			Namespace:   "default",
+			QueryVector: query,
+			K:           10,
+			Offset:      int32(page * 10),
+		})
+		if err != nil {
+			t.Fatalf("Search of page %d failed: %v", page, err)
+		}
+		if resp.TotalResults != 10 {
+			t.Fatalf("Expected 10 results on page %d, got %d", page, resp.TotalResults)
+		}
+		for i, r := range resp.Results {
+			if want := full.Results[page*10+i].Id; r.Id != want {
+				t.Errorf("Page %d result %d: expected %s, got %s", page, i, want, r.Id)
+			}
+		}
+	}
+
+	// A filtered page past the last match is empty
+	resp, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: query,
+		K:           10,
+		Offset:      5,
+		Filter:      rareFilter,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 0 {
+		t.Errorf("Expected an empty page past the 2 rare vectors, got %d results", len(resp.Results))
+	}
+
+	if _, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace: "default", QueryVector: query, K: 10, Offset: -1,
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a negative offset, got %v", err)
+	}
+	if _, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace: "default", QueryVector: query, K: 10, Offset: 41,
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument beyond the search window, got %v", err)
+	}
+}
+
+func TestHybridSearch(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Insert test vectors with text
+	testData := []struct {
+		vector []float32
+		text   string
+	}{
+		{[]float32{0.1, 0.2, 0.3}, "machine learning and artificial intelligence"},
+		{[]float32{0.2, 0.3, 0.4}, "deep neural networks for image recognition"},
+		{[]float32{0.9, 0.8, 0.7}, "cooking recipes and food preparation"},
+	}
+
+	for i, data := range testData {
+		req := &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    data.vector,
+			Text:      &data.text,
+			Metadata: map[string]string{
+				"index": string(rune('0' + i)),
+			},
+		}
+
+		if _, err := client.Insert(ctx, req); err != nil {
+			t.Fatalf("Failed to insert vector %d: %v", i, err)
+		}
+	}
+
+	// Wait for indexing
+	time.Sleep(100 * time.Millisecond)
+
+	// Hybrid search
+	hybridReq := &proto.HybridSearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0.15, 0.25, 0.35},
+		QueryText:   "machine learning neural networks",
+		K:           2,
+		EfSearch:    50,
+	}
+
+	hybridResp, err := client.HybridSearch(ctx, hybridReq)
+	if err != nil {
+		t.Fatalf("Hybrid search failed: %v", err)
+	}
+
+	if len(hybridResp.Results) == 0 {
+		t.Fatal("Hybrid search returned no results")
+	}
+
+	// Text matches carry a snippet with the query terms highlighted
+	for _, r := range hybridResp.Results {
+		if r.GetTextScore() > 0 && !strings.Contains(r.GetSnippet(), "**") {
+			t.Errorf("Result %s matched text but has snippet %q", r.Id, r.GetSnippet())
+		}
+	}
+
+	// Every vector is a vector candidate; only text matches have a text rank
+	for _, r := range hybridResp.Results {
+		if r.VectorRank == nil || r.GetVectorRank() < 1 {
+			t.Errorf("Result %s: expected a vector rank, got %v", r.Id, r.VectorRank)
+		}
+		if r.TextRank == nil {
+			t.Errorf("Result %s: expected a text rank", r.Id)
+		} else if matched := r.GetTextScore() > 0; matched != (r.GetTextRank() >= 1) || (!matched && r.GetTextRank() != -1) {
+			t.Errorf("Result %s: text score %f but text rank %d", r.Id, r.GetTextScore(), r.GetTextRank())
+		}
+	}
+
+	t.Logf("Found %d results in %.2fms", len(hybridResp.Results), hybridResp.SearchTimeMs)
+}
+
+// reverseReranker reverses the candidates and adds an ID that was never
+// retrieved, which the server must drop
+type reverseReranker struct{}
+
+func (reverseReranker) Rerank(query []float32, queryText string, results []search.Result) []search.Result {
+	out := []search.Result{{ID: 1 << 40}}
+	for i := len(results) - 1; i >= 0; i-- {
+		out = append(out, results[i])
+	}
+	return out
+}
+
+func TestReranker(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	if err := server.RegisterReranker("reverse", reverseReranker{}); err != nil {
+		t.Fatalf("Failed to register reranker: %v", err)
+	}
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		text := fmt.Sprintf("document number %d", i)
+		resp, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), 0, 0},
+			Text:      &text,
+		})
+		if err != nil {
+			t.Fatalf("Failed to insert vector %d: %v", i, err)
+		}
+		ids = append(ids, resp.Id)
+	}
+
+	// The reranker sees all five candidates, so the farthest come first
+	resp, err := client.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0, 0, 0},
+		K:           2,
+		Reranker:    "reverse",
+		RerankDepth: 5,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].Id != ids[4] || resp.Results[1].Id != ids[3] {
+		t.Errorf("Expected reranked results %s, %s, got %v", ids[4], ids[3], resp.Results)
+	}
+
+	// The noop reranker keeps the retrieval order
+	resp, err = client.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0, 0, 0},
+		K:           2,
+		Reranker:    grpcserver.RerankerNoop,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].Id != ids[0] || resp.Results[1].Id != ids[1] {
+		t.Errorf("Expected retrieval order %s, %s, got %v", ids[0], ids[1], resp.Results)
+	}
+
+	hybridResp, err := client.HybridSearch(ctx, &proto.HybridSearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0, 0, 0},
+		QueryText:   "document",
+		K:           2,
+		Reranker:    "reverse",
+		RerankDepth: 5,
+	})
+	if err != nil {
+		t.Fatalf("Hybrid search failed: %v", err)
+	}
+	if len(hybridResp.Results) != 2 {
+		t.Fatalf("Expected 2 hybrid results, got %d", len(hybridResp.Results))
+	}
+	for _, r := range hybridResp.Results {
+		if r.Id == ids[0] {
+			t.Errorf("Expected the best fused result reranked past k, got %v", hybridResp.Results)
+		}
+	}
+
+	_, err = client.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0, 0, 0},
+		K:           2,
+		Reranker:    "missing",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown reranker, got %v", err)
+	}
+	_, err = client.HybridSearch(ctx, &proto.HybridSearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0, 0, 0},
+		QueryText:   "document",
+		K:           2,
+		RerankDepth: -1,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a negative rerank_depth, got %v", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	insertResp, err := client.Insert(ctx, &proto.InsertRequest{
+		Namespace: "default",
+		Vector:    []float32{0.1, 0.2, 0.3},
+		Metadata:  map[string]string{"category": "tech"},
+		Text:      stringPtr("stored text"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+
+	resp, err := client.Fetch(ctx, &proto.FetchRequest{
+		Namespace: "default",
+		Ids:       []string{insertResp.Id, "999999", "not-a-number"},
+	})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	if len(resp.Results) != 3 || resp.FoundCount != 1 || resp.NotFoundCount != 2 {
+		t.Fatalf("Unexpected fetch counts: %d results, %d found, %d not found",
+			len(resp.Results), resp.FoundCount, resp.NotFoundCount)
+	}
+
+	got := resp.Results[0]
+	if !got.Found || got.Id != insertResp.Id {
+		t.Fatalf("Expected %s to be found, got %+v", insertResp.Id, got)
+	}
+	if len(got.Vector) != 3 || got.Vector[1] != 0.2 {
+		t.Errorf("Unexpected vector: %v", got.Vector)
+	}
+	if got.Metadata["category"] != "tech" {
+		t.Errorf("Unexpected metadata: %v", got.Metadata)
+	}
+	if got.Text == nil || *got.Text != "stored text" {
+		t.Errorf("Unexpected text: %v", got.Text)
+	}
+
+	for _, missing := range resp.Results[1:] {
+		if missing.Found || missing.Error == nil {
+			t.Errorf("Expected %s to be reported missing, got %+v", missing.Id, missing)
+		}
+	}
+
+	// Unknown namespaces report every ID as missing
+	resp, err = client.Fetch(ctx, &proto.FetchRequest{Namespace: "nowhere", Ids: []string{insertResp.Id}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if resp.Results[0].Found {
+		t.Error("Expected no results from an unknown namespace")
+	}
+
+	if _, err := client.Fetch(ctx, &proto.FetchRequest{Namespace: "default"}); err == nil {
+		t.Error("Expected error for fetch without IDs")
+	}
+}
+
+func TestWALRecovery(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var ids []string
+	for i, text := range []string{"first doc", "second doc", "third doc"} {
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "docs",
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata:  map[string]string{"n": strconv.Itoa(i)},
+			Text:      stringPtr(text),
+		})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+	}
+	if _, err := server.Update(ctx, &proto.UpdateRequest{
+		Namespace: "docs",
+		Id:        ids[0],
+		Vector:    []float32{9, 9, 9},
+		Metadata:  map[string]string{"n": "updated"},
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := server.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "docs",
+		Selector:  &proto.DeleteRequest_Id{Id: ids[1]},
+	}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	server.Stop()
+
+	// Replaying the same log on every restart must rebuild the same state
+	for restart := 1; restart <= 2; restart++ {
+		server, err = grpcserver.NewServer(cfg)
+		if err != nil {
+			t.Fatalf("Restart %d: failed to create server: %v", restart, err)
+		}
+
+		resp, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "docs", Ids: ids})
+		if err != nil {
+			t.Fatalf("Restart %d: fetch failed: %v", restart, err)
+		}
+
+		updated, deleted, kept := resp.Results[0], resp.Results[1], resp.Results[2]
+		if !updated.Found || updated.Vector[0] != 9 || updated.Metadata["n"] != "updated" {
+			t.Errorf("Restart %d: expected the update to be replayed, got %+v", restart, updated)
+		}
+		if updated.Text == nil || *updated.Text != "first doc" {
+			t.Errorf("Restart %d: expected text to survive the update, got %v", restart, updated.Text)
+		}
+		if deleted.Found {
+			t.Errorf("Restart %d: expected %s to stay deleted", restart, ids[1])
+		}
+		if !kept.Found || kept.Metadata["n"] != "2" || kept.Text == nil || *kept.Text != "third doc" {
+			t.Errorf("Restart %d: expected %s to be restored, got %+v", restart, ids[2], kept)
+		}
+
+		// Replayed text is searchable again
+		hybrid, err := server.HybridSearch(ctx, &proto.HybridSearchRequest{
+			Namespace:   "docs",
+			QueryVector: []float32{2, 1, 0},
+			QueryText:   "third",
+			K:           1,
+		})
+		if err != nil || len(hybrid.Results) == 0 || hybrid.Results[0].Id != ids[2] {
+			t.Errorf("Restart %d: expected hybrid search to find %s, got %v (err: %v)", restart, ids[2], hybrid, err)
+		}
+
+		server.Stop()
+	}
+
+	// New IDs continue after the replayed ones
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	resp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: []float32{1, 2, 3}})
+	if err != nil {
+		t.Fatalf("Insert after recovery failed: %v", err)
+	}
+	for _, id := range ids {
+		if resp.Id == id {
+			t.Errorf("Insert after recovery reused ID %s", id)
+		}
+	}
+}
+
+func TestWALReplaySkipsUpdatesOfDeletedVectors(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	// An update logged after the delete of its vector, as a racing Update
+	// and Delete could leave it
+	l, err := wal.Open(filepath.Join(cfg.Database.DataDir, "wal", "docs.log"), wal.Options{})
+	if err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	for _, rec := range []*wal.Record{
+		{Op: wal.OpInsert, ID: 0, Vector: []float32{1, 0, 0}},
+		{Op: wal.OpInsert, ID: 1, Vector: []float32{0, 1, 0}},
+		{Op: wal.OpDelete, ID: 0},
+		{Op: wal.OpUpdate, ID: 0, Vector: []float32{0, 0, 1}, Metadata: map[string]string{"n": "late"}},
+		{Op: wal.OpMergeUpdate, ID: 0, Metadata: map[string]string{"n": "later"}},
+	} {
+		if err := l.Append(rec); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	l.Close()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	resp, err := server.Fetch(context.Background(), &proto.FetchRequest{Namespace: "docs", Ids: []string{"0", "1"}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if resp.Results[0].Found {
+		t.Errorf("Expected the deleted vector to stay deleted, got %+v", resp.Results[0])
+	}
+	if !resp.Results[1].Found {
+		t.Error("Expected vector 1 to be recovered")
+	}
+}
+
+func TestWALRecoveryRejectsDamagedLog(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	path := filepath.Join(cfg.Database.DataDir, "wal", "docs.log")
+	l, err := wal.Open(path, wal.Options{})
+	if err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	for id := uint64(0); id < 3; id++ {
+		if err := l.Append(&wal.Record{Op: wal.OpInsert, ID: id, Vector: []float32{float32(id), 1, 0}}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	l.Close()
+
+	// Damage the first record; the two after it must not be dropped silently
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read WAL: %v", err)
+	}
+	data[10] ^= 0xff
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write WAL: %v", err)
+	}
+
+	if _, err := grpcserver.NewServer(cfg); status.Code(err) != codes.DataLoss {
+		t.Errorf("Expected recovery to fail with DataLoss, got %v", err)
+	}
+	if after, _ := os.ReadFile(path); !bytes.Equal(after, data) {
+		t.Error("Expected the damaged log to be left untouched")
+	}
+}
+
+func TestWALCheckpointAfterSnapshot(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var ids []string
+	for i, text := range []string{"first doc", "second doc", "third doc"} {
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace:  "docs",
+			Vector:     []float32{float32(i), 1, 0},
+			Metadata:   map[string]string{"n": strconv.Itoa(i)},
+			Text:       stringPtr(text),
+			ExternalId: stringPtr("doc-" + strconv.Itoa(i)),
+		})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+	}
+
+	stream := &snapshotStream{ctx: ctx}
+	if err := server.Snapshot(&proto.SnapshotRequest{}, stream); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	final := stream.progress[len(stream.progress)-1]
+
+	// The snapshot holds every logged write, so only the checkpoint is left
+	path := filepath.Join(cfg.Database.DataDir, "wal", "docs.log")
+	var records []*wal.Record
+	if _, err := wal.Replay(path, func(rec *wal.Record) error {
+		records = append(records, rec)
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to read WAL: %v", err)
+	}
+	if len(records) != 1 || records[0].Op != wal.OpCheckpoint || records[0].Text != filepath.Base(final.Path) {
+		t.Fatalf("Expected the WAL to hold only a checkpoint at %s, got %+v", filepath.Base(final.Path), records)
+	}
+
+	// Writes after the snapshot are logged behind the checkpoint
+	if _, err := server.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "docs",
+		Selector:  &proto.DeleteRequest_Id{Id: ids[0]},
+	}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := server.Update(ctx, &proto.UpdateRequest{
+		Namespace: "docs",
+		Id:        ids[1],
+		Metadata:  map[string]string{"n": "updated"},
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	resp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: []float32{5, 1, 0}})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	ids = append(ids, resp.Id)
+	server.Stop()
+
+	for restart := 1; restart <= 2; restart++ {
+		server, err = grpcserver.NewServer(cfg)
+		if err != nil {
+			t.Fatalf("Restart %d: failed to create server: %v", restart, err)
+		}
+
+		resp, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "docs", Ids: ids})
+		if err != nil {
+			t.Fatalf("Restart %d: fetch failed: %v", restart, err)
+		}
+		deleted, updated, kept, inserted := resp.Results[0], resp.Results[1], resp.Results[2], resp.Results[3]
+		if deleted.Found {
+			t.Errorf("Restart %d: expected %s to stay deleted", restart, ids[0])
+		}
+		if !updated.Found || updated.Metadata["n"] != "updated" || updated.Text == nil || *updated.Text != "second doc" {
+			t.Errorf("Restart %d: expected the update to apply to the snapshot, got %+v", restart, updated)
+		}
+		if !kept.Found || kept.Metadata["n"] != "2" || kept.GetExternalId() != "doc-2" {
+			t.Errorf("Restart %d: expected %s restored from the snapshot, got %+v", restart, ids[2], kept)
+		}
+		if !inserted.Found {
+			t.Errorf("Restart %d: expected the insert after the snapshot to be replayed", restart)
+		}
+		server.Stop()
+	}
+
+	// Without the snapshot the log cannot be replayed
+	if err := os.Remove(final.Path); err != nil {
+		t.Fatalf("Failed to remove snapshot: %v", err)
+	}
+	if _, err := grpcserver.NewServer(cfg); status.Code(err) != codes.DataLoss {
+		t.Errorf("Expected recovery without the snapshot to fail with DataLoss, got %v", err)
+	}
+}
+
+func TestTypedMetadata(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	year := func(y int64) *proto.MetadataValue { return &proto.MetadataValue{IntValue: &y} }
+	published := true
+	score := 0.5
+
+	var ids []string
+	for i, y := range []int64{2019, 2023} {
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "docs",
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata:  map[string]string{"category": "tech"},
+			TypedMetadata: map[string]*proto.MetadataValue{
+				"year":      year(y),
+				"published": {BoolValue: &published},
+				"score":     {DoubleValue: &score},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+	}
+	// A string year compares as 0 in numeric filters
+	if _, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace: "docs",
+		Vector:    []float32{2, 1, 0},
+		Metadata:  map[string]string{"year": "2024"},
+	}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	_, err = server.Insert(ctx, &proto.InsertRequest{
+		Namespace:     "docs",
+		Vector:        []float32{1, 1, 1},
+		TypedMetadata: map[string]*proto.MetadataValue{"year": {}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty typed value, got %v", err)
+	}
+
+	check := func(label string) {
+		t.Helper()
+
+		resp, err := server.Search(ctx, &proto.SearchRequest{
+			Namespace:   "docs",
+			QueryVector: []float32{1, 1, 0},
+			K:           10,
+			Filter: &proto.Filter{FilterType: &proto.Filter_Comparison{
+				Comparison: &proto.ComparisonFilter{Field: "year", Operator: "gt", Value: "2020"},
+			}},
+		})
+		if err != nil {
+			t.Fatalf("%s: search failed: %v", label, err)
+		}
+		if len(resp.Results) != 1 || resp.Results[0].Id != ids[1] {
+			t.Fatalf("%s: expected only %s to have year > 2020, got %v", label, ids[1], resp.Results)
+		}
+		if v := resp.Results[0].TypedMetadata["year"]; v == nil || v.IntValue == nil || *v.IntValue != 2023 {
+			t.Errorf("%s: expected typed year 2023 in the result, got %v", label, v)
+		}
+
+		resp, err = server.Search(ctx, &proto.SearchRequest{
+			Namespace:   "docs",
+			QueryVector: []float32{1, 1, 0},
+			K:           10,
+			Filter: &proto.Filter{FilterType: &proto.Filter_Comparison{
+				Comparison: &proto.ComparisonFilter{Field: "published", Operator: "eq", Value: "true"},
+			}},
+		})
+		if err != nil || len(resp.Results) != 2 {
+			t.Errorf("%s: expected 2 published results, got %v (err: %v)", label, resp, err)
+		}
+
+		fetch, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "docs", Ids: ids[:1]})
+		if err != nil {
+			t.Fatalf("%s: fetch failed: %v", label, err)
+		}
+		got := fetch.Results[0]
+		if got.Metadata["year"] != "2019" || got.Metadata["published"] != "true" || got.Metadata["score"] != "0.5" {
+			t.Errorf("%s: expected every value formatted in metadata, got %v", label, got.Metadata)
+		}
+		if v := got.TypedMetadata["score"]; v == nil || v.DoubleValue == nil || *v.DoubleValue != 0.5 {
+			t.Errorf("%s: expected typed score 0.5, got %v", label, v)
+		}
+		if _, ok := got.TypedMetadata["category"]; ok {
+			t.Errorf("%s: expected string values only in metadata, got %v", label, got.TypedMetadata)
+		}
+	}
+
+	check("after insert")
+
+	// Typed values survive WAL replay
+	server.Stop()
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	defer server.Stop()
+	check("after restart")
+
+	// ... and a snapshot restore
+	stream := &snapshotStream{ctx: ctx}
+	if err := server.Snapshot(&proto.SnapshotRequest{}, stream); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if _, err := server.DropNamespace(ctx, &proto.DropNamespaceRequest{Namespace: "docs"}); err != nil {
+		t.Fatalf("DropNamespace failed: %v", err)
+	}
+	final := stream.progress[len(stream.progress)-1]
+	if _, err := server.Restore(ctx, &proto.RestoreRequest{Path: final.Path}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	check("after restore")
+}
+
+func TestExternalIDs(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	insert := func(externalID string, vector []float32, upsert bool) (*proto.InsertResponse, error) {
+		return server.Insert(ctx, &proto.InsertRequest{
+			Namespace:  "docs",
+			Vector:     vector,
+			Metadata:   map[string]string{"v": fmt.Sprint(vector[0])},
+			ExternalId: stringPtr(externalID),
+			Upsert:     upsert,
+		})
+	}
+
+	first, err := insert("doc-a", []float32{1, 0, 0}, false)
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if _, err := insert("doc-b", []float32{0, 1, 0}, false); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	// External IDs are unique per namespace
+	if _, err := insert("doc-a", []float32{0, 0, 1}, false); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("Expected AlreadyExists for a duplicate external ID, got %v", err)
+	}
+	if _, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace:  "other",
+		Vector:     []float32{1, 0, 0},
+		ExternalId: stringPtr("doc-a"),
+	}); err != nil {
+		t.Fatalf("Expected the same external ID in another namespace to succeed, got %v", err)
+	}
+	if _, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace:  "docs",
+		Vector:     []float32{1, 0, 0},
+		ExternalId: stringPtr(""),
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for an empty external ID, got %v", err)
+	}
+
+	// Upsert replaces the vector stored under the external ID
+	upserted, err := insert("doc-a", []float32{0, 0, 1}, true)
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if upserted.Id != first.Id {
+		t.Fatalf("Expected the upsert to keep ID %s, got %s", first.Id, upserted.Id)
+	}
+
+	fetch, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "docs", Ids: []string{"doc-a"}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if got := fetch.Results[0]; !got.Found || got.Vector[2] != 1 || got.GetExternalId() != "doc-a" {
+		t.Errorf("Expected doc-a to fetch the upserted vector, got %+v", got)
+	}
+
+	// Search results carry the external ID
+	search, err := server.Search(ctx, &proto.SearchRequest{Namespace: "docs", QueryVector: []float32{0, 1, 0}, K: 1})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(search.Results) != 1 || search.Results[0].GetExternalId() != "doc-b" {
+		t.Fatalf("Expected doc-b as the nearest result, got %v", search.Results)
+	}
+
+	// Update and Delete accept external IDs
+	if _, err := server.Update(ctx, &proto.UpdateRequest{
+		Namespace: "docs",
+		Id:        "doc-b",
+		Metadata:  map[string]string{"v": "updated"},
+	}); err != nil {
+		t.Fatalf("Update by external ID failed: %v", err)
+	}
+	if _, err := server.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "docs",
+		Selector:  &proto.DeleteRequest_Id{Id: "doc-a"},
+	}); err != nil {
+		t.Fatalf("Delete by external ID failed: %v", err)
+	}
+	if _, err := insert("doc-a", []float32{1, 1, 0}, false); err != nil {
+		t.Fatalf("Expected a deleted external ID to be reusable, got %v", err)
+	}
+	server.Stop()
+
+	// External IDs survive a restart through the WAL
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	defer server.Stop()
+
+	fetch, err = server.Fetch(ctx, &proto.FetchRequest{Namespace: "docs", Ids: []string{"doc-a", "doc-b", upserted.Id}})
+	if err != nil {
+		t.Fatalf("Fetch after restart failed: %v", err)
+	}
+	if got := fetch.Results[0]; !got.Found || got.Vector[0] != 1 || got.Vector[1] != 1 {
+		t.Errorf("Expected doc-a to map to its reinserted vector after restart, got %+v", got)
+	}
+	if got := fetch.Results[1]; !got.Found || got.Metadata["v"] != "updated" || got.GetExternalId() != "doc-b" {
+		t.Errorf("Expected the updated doc-b after restart, got %+v", got)
+	}
+	if fetch.Results[2].Found {
+		t.Errorf("Expected the deleted vector %s to stay deleted", upserted.Id)
+	}
+	if _, err := insert("doc-b", []float32{0, 1, 1}, false); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for doc-b after restart, got %v", err)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Neighbors for the upserted vector to link to
+	for i := 0; i < 20; i++ {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "docs",
+			Vector:    []float32{float32(i), 1, 1},
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	upsert := func(vector []float32, text string) *proto.InsertResponse {
+		t.Helper()
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace:  "docs",
+			Vector:     vector,
+			Metadata:   map[string]string{"text": text},
+			Text:       stringPtr(text),
+			ExternalId: stringPtr("doc-1"),
+			Upsert:     true,
+		})
+		if err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		return resp
+	}
+
+	first := upsert([]float32{5, 1, 0}, "original draft")
+	second := upsert([]float32{5, 1, 0.5}, "revised edition")
+	if first.Id != second.Id {
+		t.Fatalf("Expected the upsert to keep ID %s, got %s", first.Id, second.Id)
+	}
+
+	check := func(when string) {
+		t.Helper()
+
+		// Only one vector carries the external ID
+		search, err := server.Search(ctx, &proto.SearchRequest{Namespace: "docs", QueryVector: []float32{5, 1, 0.5}, K: 25})
+		if err != nil {
+			t.Fatalf("%s: search failed: %v", when, err)
+		}
+		matches := 0
+		for _, r := range search.Results {
+			if r.GetExternalId() == "doc-1" {
+				matches++
+				if r.Id != first.Id || r.Metadata["text"] != "revised edition" {
+					t.Errorf("%s: expected the upserted vector, got %+v", when, r)
+				}
+			}
+		}
+		if matches != 1 || len(search.Results) != 21 {
+			t.Errorf("%s: expected doc-1 once among 21 vectors, got %d of %d", when, matches, len(search.Results))
+		}
+
+		// The replaced text is no longer indexed
+		for query, want := range map[string]int{"original": 0, "revised": 1} {
+			hybrid, err := server.HybridSearch(ctx, &proto.HybridSearchRequest{
+				Namespace:   "docs",
+				QueryVector: []float32{5, 1, 0.5},
+				QueryText:   query,
+				K:           1,
+				Config:      &proto.HybridSearchConfig{FusionMethod: "weighted", VectorWeight: 0, TextWeight: 1},
+			})
+			if err != nil {
+				t.Fatalf("%s: hybrid search failed: %v", when, err)
+			}
+			got := 0
+			for _, r := range hybrid.Results {
+				if r.TextScore != nil && *r.TextScore > 0 {
+					got++
+				}
+			}
+			if got != want {
+				t.Errorf("%s: expected %d text matches for %q, got %d", when, want, query, got)
+			}
+		}
+
+		validate, err := server.Validate(ctx, &proto.ValidateRequest{Namespace: "docs"})
+		if err != nil || !validate.Valid {
+			t.Errorf("%s: expected a valid graph, got %v (err: %v)", when, validate, err)
+		}
+	}
+	check("Before restart")
+
+	// Upserting by internal ID replaces the same vector
+	if _, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace: "docs",
+		Vector:    []float32{5, 1, 0.5},
+		Metadata:  map[string]string{"text": "revised edition"},
+		Text:      stringPtr("revised edition"),
+		Id:        stringPtr(first.Id),
+		Upsert:    true,
+	}); err != nil {
+		t.Fatalf("Upsert by ID failed: %v", err)
+	}
+	server.Stop()
+
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	defer server.Stop()
+	check("After restart")
+}
+
+func TestBatchInsertExternalIDs(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	if _, err := client.Insert(ctx, &proto.InsertRequest{
+		Namespace:  "default",
+		Vector:     []float32{1, 0, 0},
+		ExternalId: stringPtr("taken"),
+	}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+	for _, externalID := range []string{"x", "taken", "y", "x"} {
+		if err := stream.Send(&proto.InsertRequest{
+			Namespace:  "default",
+			Vector:     []float32{0, 1, 0},
+			ExternalId: stringPtr(externalID),
+		}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Failed to close stream: %v", err)
+	}
+
+	// The taken ID and the repeat within the batch are rejected
+	if resp.InsertedCount != 2 || resp.FailedCount != 2 {
+		t.Fatalf("Expected 2 inserted and 2 failed, got %+v", resp)
+	}
+	if !strings.Contains(resp.Errors[0], "item 1") || !strings.Contains(resp.Errors[1], "item 3") {
+		t.Errorf("Expected items 1 and 3 to fail, got %v", resp.Errors)
+	}
+
+	fetch, err := client.Fetch(ctx, &proto.FetchRequest{Namespace: "default", Ids: []string{"x", "y"}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if fetch.FoundCount != 2 {
+		t.Errorf("Expected x and y to be fetchable, got %+v", fetch.Results)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Insert a vector
+	insertReq := &proto.InsertRequest{
+		Namespace: "default",
+		Vector:    []float32{0.1, 0.2, 0.3},
+	}
+
+	insertResp, err := client.Insert(ctx, insertReq)
+	if err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+
+	id := insertResp.Id
+
+	// Delete the vector
+	deleteReq := &proto.DeleteRequest{
+		Namespace: "default",
+		Selector:  &proto.DeleteRequest_Id{Id: id},
+	}
+
+	deleteResp, err := client.Delete(ctx, deleteReq)
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if !deleteResp.Success {
+		t.Fatalf("Delete returned success=false: %v", deleteResp.Error)
+	}
+
+	if deleteResp.DeletedCount != 1 {
+		t.Fatalf("Expected 1 deleted, got %d", deleteResp.DeletedCount)
+	}
+
+	t.Logf("Deleted vector %s", id)
+}
+
+func TestUpdate(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Insert a vector
+	insertReq := &proto.InsertRequest{
+		Namespace: "default",
+		Vector:    []float32{0.1, 0.2, 0.3},
+		Metadata: map[string]string{
+			"status": "draft",
+		},
+	}
+
+	insertResp, err := client.Insert(ctx, insertReq)
+	if err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+
+	id := insertResp.Id
+
+	// Update the vector
+	updateReq := &proto.UpdateRequest{
+		Namespace: "default",
+		Id:        id,
+		Vector:    []float32{0.2, 0.3, 0.4},
+		Metadata: map[string]string{
+			"status": "published",
+		},
+	}
+
+	updateResp, err := client.Update(ctx, updateReq)
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if !updateResp.Success {
+		t.Fatalf("Update returned success=false: %v", updateResp.Error)
+	}
+
+	t.Logf("Updated vector %s", id)
+}
+
+func TestUpdateMergeMetadata(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	insertResp, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace: "docs",
+		Vector:    []float32{1, 0, 0},
+		Metadata:  map[string]string{"status": "draft", "author": "ann", "lang": "en"},
+		Text:      stringPtr("merge me"),
+	})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	id := insertResp.Id
+
+	fetch := func(server *grpcserver.Server) *proto.FetchResult {
+		t.Helper()
+		resp, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "docs", Ids: []string{id}})
+		if err != nil || !resp.Results[0].Found {
+			t.Fatalf("Fetch failed: %v", err)
+		}
+		return resp.Results[0]
+	}
+
+	// Merging overwrites and adds the given keys and keeps the rest
+	views := int64(3)
+	if _, err := server.Update(ctx, &proto.UpdateRequest{
+		Namespace:     "docs",
+		Id:            id,
+		Merge:         true,
+		Metadata:      map[string]string{"status": "published", "topic": "go"},
+		TypedMetadata: map[string]*proto.MetadataValue{"views": {IntValue: &views}},
+	}); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	got := fetch(server)
+	want := map[string]string{"status": "published", "author": "ann", "lang": "en", "topic": "go", "views": "3"}
+	if !reflect.DeepEqual(got.Metadata, want) {
+		t.Errorf("Expected merged metadata %v, got %v", want, got.Metadata)
+	}
+	if v := got.TypedMetadata["views"]; v == nil || v.GetIntValue() != 3 {
+		t.Errorf("Expected typed views=3 after merge, got %v", got.TypedMetadata)
+	}
+	if got.Text == nil || *got.Text != "merge me" {
+		t.Errorf("Expected text to survive the merge, got %v", got.Text)
+	}
+
+	// Removed keys are deleted, with or without keys to set
+	if _, err := server.Update(ctx, &proto.UpdateRequest{
+		Namespace:  "docs",
+		Id:         id,
+		Merge:      true,
+		Metadata:   map[string]string{"lang": "fr"},
+		RemoveKeys: []string{"author", "views", "missing"},
+	}); err != nil {
+		t.Fatalf("Merge with remove_keys failed: %v", err)
+	}
+	got = fetch(server)
+	want = map[string]string{"status": "published", "lang": "fr", "topic": "go"}
+	if !reflect.DeepEqual(got.Metadata, want) || len(got.TypedMetadata) != 0 {
+		t.Errorf("Expected metadata %v without views, got %v and %v", want, got.Metadata, got.TypedMetadata)
+	}
+
+	// Concurrent merges of different keys all apply
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := server.Update(ctx, &proto.UpdateRequest{
+				Namespace: "docs",
+				Id:        id,
+				Merge:     true,
+				Metadata:  map[string]string{fmt.Sprintf("k%d", i): strconv.Itoa(i)},
+			}); err != nil {
+				t.Errorf("Concurrent merge %d failed: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	got = fetch(server)
+	for i := 0; i < 20; i++ {
+		if got.Metadata[fmt.Sprintf("k%d", i)] != strconv.Itoa(i) {
+			t.Errorf("Expected concurrent merge of k%d to apply, got %v", i, got.Metadata)
+			break
+		}
+	}
+
+	// remove_keys requires merge and may not name a key being set
+	for _, req := range []*proto.UpdateRequest{
+		{Namespace: "docs", Id: id, RemoveKeys: []string{"lang"}},
+		{Namespace: "docs", Id: id, Merge: true, Metadata: map[string]string{"lang": "de"}, RemoveKeys: []string{"lang"}},
+	} {
+		if _, err := server.Update(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+
+	if _, err := server.Update(ctx, &proto.UpdateRequest{
+		Namespace:  "docs",
+		Id:         id,
+		Merge:      true,
+		RemoveKeys: []string{"k0"},
+	}); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	want = fetch(server).Metadata
+	server.Stop()
+
+	// Merges and removals are replayed from the WAL
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	defer server.Stop()
+	if got := fetch(server); !reflect.DeepEqual(got.Metadata, want) {
+		t.Errorf("Expected replayed metadata %v, got %v", want, got.Metadata)
+	}
+
+	// Without merge, metadata is still replaced
+	if _, err := server.Update(ctx, &proto.UpdateRequest{
+		Namespace: "docs",
+		Id:        id,
+		Metadata:  map[string]string{"status": "archived"},
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if got := fetch(server); !reflect.DeepEqual(got.Metadata, map[string]string{"status": "archived"}) {
+		t.Errorf("Expected an update without merge to replace metadata, got %v", got.Metadata)
+	}
+}
+
+func TestBatchInsert(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Create batch insert stream
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+
+	// Send multiple vectors
+	numVectors := 10
+	for i := 0; i < numVectors; i++ {
+		req := &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i) * 0.1, float32(i) * 0.2, float32(i) * 0.3},
+			Metadata: map[string]string{
+				"batch_index": string(rune('0' + i)),
+			},
+		}
+
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Failed to send vector %d: %v", i, err)
+		}
+	}
+
+	// Close stream and get response
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Failed to close stream: %v", err)
+	}
+
+	if resp.InsertedCount != int32(numVectors) {
+		t.Fatalf("Expected %d insertions, got %d", numVectors, resp.InsertedCount)
+	}
+
+	if resp.FailedCount != 0 {
+		t.Fatalf("Expected 0 failures, got %d: %v", resp.FailedCount, resp.Errors)
+	}
+
+	t.Logf("Batch inserted %d vectors in %.2fms", resp.InsertedCount, resp.TotalTimeMs)
+}
+
+func TestBatchInsertSkipsBadItems(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Fix the namespace dimension before streaming
+	if _, err := client.Insert(ctx, &proto.InsertRequest{
+		Namespace: "default",
+		Vector:    []float32{0.1, 0.2, 0.3},
+	}); err != nil {
+		t.Fatalf("Initial insert failed: %v", err)
+	}
+
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+
+	// Item 3 is far larger than the configured max, item 6 has the wrong dimension
+	oversized := make([]float32, config.Default().HNSW.MaxDimensions+1)
+	const numItems = 10
+	for i := 0; i < numItems; i++ {
+		vector := []float32{float32(i) * 0.1, float32(i) * 0.2, float32(i) * 0.3}
+		switch i {
+		case 3:
+			vector = oversized
+		case 6:
+			vector = []float32{0.1, 0.2, 0.3, 0.4}
+		}
+
+		if err := stream.Send(&proto.InsertRequest{Namespace: "default", Vector: vector}); err != nil {
+			t.Fatalf("Failed to send item %d: %v", i, err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Batch should complete despite bad items: %v", err)
+	}
+
+	if resp.InsertedCount != numItems-2 {
+		t.Errorf("Expected %d insertions, got %d", numItems-2, resp.InsertedCount)
+	}
+	if resp.FailedCount != 2 || len(resp.Errors) != 2 {
+		t.Fatalf("Expected 2 failures, got %d: %v", resp.FailedCount, resp.Errors)
+	}
+	if !strings.HasPrefix(resp.Errors[0], "item 3:") || !strings.Contains(resp.Errors[0], "exceeds maximum") {
+		t.Errorf("Unexpected error for oversized item: %q", resp.Errors[0])
+	}
+	if !strings.HasPrefix(resp.Errors[1], "item 6:") || !strings.Contains(resp.Errors[1], "expected 3, got 4") {
+		t.Errorf("Unexpected error for mismatched item: %q", resp.Errors[1])
+	}
+}
+
+func TestBatchInsertOnError(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Two stored vectors that an aborted batch must leave alone
+	for i := 0; i < 2; i++ {
+		if _, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: "txn",
+			Vector:    []float32{float32(i), 1, 0},
+		}); err != nil {
+			t.Fatalf("Initial insert failed: %v", err)
+		}
+	}
+	count := func() int64 {
+		t.Helper()
+		resp, err := client.Count(ctx, &proto.CountRequest{Namespace: "txn"})
+		if err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		return resp.Counts["txn"]
+	}
+
+	// sendBatch streams 6 items whose item 3 has the wrong dimension
+	sendBatch := func(onError string) (*proto.BatchInsertResponse, error) {
+		t.Helper()
+		stream, err := client.BatchInsert(ctx)
+		if err != nil {
+			t.Fatalf("Failed to create batch insert stream: %v", err)
+		}
+		for i := 0; i < 6; i++ {
+			req := &proto.InsertRequest{
+				Namespace:  "txn",
+				Vector:     []float32{float32(i), 2, 0},
+				Text:       stringPtr(fmt.Sprintf("batch item %d", i)),
+				ExternalId: stringPtr(fmt.Sprintf("%s-%d", onError, i)),
+				OnError:    onError,
+			}
+			if i == 3 {
+				// Let the workers insert the earlier items first
+				time.Sleep(200 * time.Millisecond)
+				req.Vector = []float32{1, 2}
+			}
+			// An aborting server may close the stream before every item is sent
+			if err := stream.Send(req); err != nil {
+				break
+			}
+		}
+		return stream.CloseAndRecv()
+	}
+
+	// "continue" skips the failed item and inserts the rest
+	resp, err := sendBatch(grpcserver.OnErrorContinue)
+	if err != nil {
+		t.Fatalf("Batch with on_error=continue failed: %v", err)
+	}
+	if resp.InsertedCount != 5 || resp.FailedCount != 1 || !strings.HasPrefix(resp.Errors[0], "item 3:") {
+		t.Fatalf("Expected 5 inserted and item 3 failed, got %+v", resp)
+	}
+	if n := count(); n != 7 {
+		t.Fatalf("Expected 7 vectors after the continued batch, got %d", n)
+	}
+
+	// "abort" fails at item 3 and deletes the items inserted before it
+	_, err = sendBatch(grpcserver.OnErrorAbort)
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("Expected Aborted, got %v", err)
+	}
+	if msg := status.Convert(err).Message(); !strings.Contains(msg, "item 3:") || !strings.Contains(msg, "rolled back 3") {
+		t.Errorf("Expected the error to name item 3 and 3 rolled-back vectors, got %q", msg)
+	}
+	if n := count(); n != 7 {
+		t.Errorf("Expected the aborted batch to leave 7 vectors, got %d", n)
+	}
+
+	// Rolled-back vectors are gone from fetches, text search and the external ID map
+	fetch, err := client.Fetch(ctx, &proto.FetchRequest{Namespace: "txn", Ids: []string{"abort-0", "abort-2"}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	for _, r := range fetch.Results {
+		if r.Found {
+			t.Errorf("Expected rolled-back vector %s to be gone", r.Id)
+		}
+	}
+	hybrid, err := client.HybridSearch(ctx, &proto.HybridSearchRequest{
+		Namespace:   "txn",
+		QueryVector: []float32{0, 2, 0},
+		QueryText:   "batch item",
+		K:           20,
+	})
+	if err != nil {
+		t.Fatalf("HybridSearch failed: %v", err)
+	}
+	if len(hybrid.Results) != 7 {
+		t.Errorf("Expected 7 hybrid results after the rollback, got %d", len(hybrid.Results))
+	}
+	if _, err := client.Insert(ctx, &proto.InsertRequest{
+		Namespace:  "txn",
+		Vector:     []float32{1, 1, 1},
+		ExternalId: stringPtr("abort-0"),
+	}); err != nil {
+		t.Errorf("Expected the rolled-back external ID to be free, got %v", err)
+	}
+
+	// Upserts and unknown modes are refused
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+	stream.Send(&proto.InsertRequest{Namespace: "txn", Vector: []float32{1, 0, 0}, OnError: grpcserver.OnErrorAbort})
+	stream.Send(&proto.InsertRequest{Namespace: "txn", Vector: []float32{1, 0, 0}, ExternalId: stringPtr("abort-0"), Upsert: true})
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.Aborted || !strings.Contains(err.Error(), "upsert") {
+		t.Errorf("Expected an upsert to abort the batch, got %v", err)
+	}
+	stream, err = client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+	stream.Send(&proto.InsertRequest{Namespace: "txn", Vector: []float32{1, 0, 0}, OnError: "retry"})
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for on_error=retry, got %v", err)
+	}
+	if n := count(); n != 8 {
+		t.Errorf("Expected 8 vectors after the refused batches, got %d", n)
+	}
+}
+
+func TestBatchInsertOrderedIDs(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+
+	// Distinct directions so each vector is its own nearest neighbor
+	const numItems = 200
+	vectors := make([][]float32, numItems)
+	for i := range vectors {
+		angle := float64(i) * 0.03
+		vectors[i] = []float32{float32(math.Cos(angle)), float32(math.Sin(angle)), 0.5}
+
+		if err := stream.Send(&proto.InsertRequest{
+			Namespace: "default",
+			Vector:    vectors[i],
+			Metadata:  map[string]string{"item": fmt.Sprint(i)},
+		}); err != nil {
+			t.Fatalf("Failed to send item %d: %v", i, err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Failed to close stream: %v", err)
+	}
+	if resp.InsertedCount != numItems || len(resp.InsertedIds) != numItems {
+		t.Fatalf("Expected %d insertions, got %d (%d IDs): %v",
+			numItems, resp.InsertedCount, len(resp.InsertedIds), resp.Errors)
+	}
+
+	// IDs are reserved in input order even though indexing is concurrent
+	for i := 1; i < numItems; i++ {
+		prev, _ := strconv.ParseUint(resp.InsertedIds[i-1], 10, 64)
+		cur, _ := strconv.ParseUint(resp.InsertedIds[i], 10, 64)
+		if cur <= prev {
+			t.Fatalf("IDs not in input order at item %d: %s after %s", i, resp.InsertedIds[i], resp.InsertedIds[i-1])
+		}
+	}
+
+	// Every stored vector must carry the ID and metadata of its input item.
+	// The search is approximate, so a few vectors may be missed.
+	searchResp, err := client.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: vectors[0],
+		K:           numItems,
+		EfSearch:    numItems,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(searchResp.Results) < numItems*9/10 {
+		t.Fatalf("Expected nearly all %d vectors back, got %d", numItems, len(searchResp.Results))
+	}
+
+	for _, r := range searchResp.Results {
+		item, err := strconv.Atoi(r.Metadata["item"])
+		if err != nil {
+			t.Fatalf("Result %s has metadata %v", r.Id, r.Metadata)
+		}
+		if r.Id != resp.InsertedIds[item] {
+			t.Errorf("Item %d: stored under ID %s, returned ID %s", item, r.Id, resp.InsertedIds[item])
+		}
+		for j := range r.Vector {
+			if r.Vector[j] != vectors[item][j] {
+				t.Errorf("ID %s holds vector %v, item %d sent %v", r.Id, r.Vector, item, vectors[item])
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkBatchInsert(b *testing.B) {
+	const numItems = 1000
+	const dim = 128
+
+	rng := rand.New(rand.NewSource(42))
+	vectors := make([][]float32, numItems)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			cfg := config.Default()
+			cfg.HNSW.Dimensions = dim
+			cfg.Database.BatchInsertWorkers = workers
+
+			server, err := grpcserver.NewServer(cfg)
+			if err != nil {
+				b.Fatalf("Failed to create server: %v", err)
+			}
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				stream := &batchInsertStream{ctx: context.Background()}
+				for i, vec := range vectors {
+					stream.reqs = append(stream.reqs, &proto.InsertRequest{
+						Namespace: fmt.Sprintf("bench-%d", n),
+						Vector:    vec,
+						Metadata:  map[string]string{"item": fmt.Sprint(i)},
+					})
+				}
+
+				if err := server.BatchInsert(stream); err != nil {
+					b.Fatalf("BatchInsert failed: %v", err)
+				}
+				if stream.resp.FailedCount != 0 {
+					b.Fatalf("Unexpected failures: %v", stream.resp.Errors)
+				}
+			}
+			b.ReportMetric(float64(numItems*b.N)/b.Elapsed().Seconds(), "vectors/s")
+		})
+	}
+}
+
+func TestBatchInsertEfConstruction(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Only the first message's ef_construction applies to the batch
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		req := &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), 1, 0.5},
+		}
+		if i == 0 {
+			req.EfConstruction = 400
+		}
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Failed to send item %d: %v", i, err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Failed to close stream: %v", err)
+	}
+	if resp.InsertedCount != 20 {
+		t.Fatalf("Expected 20 insertions, got %d: %v", resp.InsertedCount, resp.Errors)
+	}
+
+	// A negative value on the first message rejects the whole batch
+	stream, err = client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create batch insert stream: %v", err)
+	}
+	if err := stream.Send(&proto.InsertRequest{
+		Namespace:      "default",
+		Vector:         []float32{1, 2, 3},
+		EfConstruction: -1,
+	}); err != nil {
+		t.Fatalf("Failed to send: %v", err)
+	}
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for negative ef_construction, got %v", err)
+	}
+}
+
+// batchInsertStream feeds requests to BatchInsert without a network round trip
+type batchInsertStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*proto.InsertRequest
+	resp *proto.BatchInsertResponse
+}
+
+func (s *batchInsertStream) Context() context.Context { return s.ctx }
+
+func (s *batchInsertStream) Recv() (*proto.InsertRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *batchInsertStream) SendAndClose(resp *proto.BatchInsertResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// waitForJob polls a job until it leaves the running state
+func waitForJob(t *testing.T, client proto.VectorDBClient, jobID string) *proto.JobStatusResponse {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		job, err := client.GetJobStatus(context.Background(), &proto.JobStatusRequest{JobId: jobID})
+		if err != nil {
+			t.Fatalf("GetJobStatus failed: %v", err)
+		}
+		if job.State != grpcserver.JobRunning {
+			return job
+		}
+		if job.Inserted+job.Failed+job.Remaining != job.Total {
+			t.Fatalf("Running job counts do not add up: %+v", job)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Job %s did not finish: %+v", jobID, job)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAsyncBatchInsert(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var items []*proto.InsertRequest
+	for i := 0; i < 200; i++ {
+		items = append(items, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), 1, 0},
+		})
+	}
+	items[5].Vector = []float32{1, 2} // Wrong dimension
+
+	resp, err := client.AsyncBatchInsert(ctx, &proto.AsyncBatchInsertRequest{Items: items})
+	if err != nil {
+		t.Fatalf("AsyncBatchInsert failed: %v", err)
+	}
+	if resp.JobId == "" || resp.Total != 200 {
+		t.Fatalf("Expected a job ID for 200 items, got %+v", resp)
+	}
+
+	job := waitForJob(t, client, resp.JobId)
+	if job.State != grpcserver.JobCompleted {
+		t.Fatalf("Expected the job to complete, got %q", job.State)
+	}
+	if job.Inserted != 199 || job.Failed != 1 || job.Remaining != 0 {
+		t.Fatalf("Expected 199 inserted and 1 failed, got %+v", job)
+	}
+	if len(job.InsertedIds) != 199 || len(job.Errors) != 1 || !strings.HasPrefix(job.Errors[0], "item 5:") {
+		t.Errorf("Expected 199 IDs and an error for item 5, got %d IDs and %v", len(job.InsertedIds), job.Errors)
+	}
+
+	// The inserted vectors are searchable
+	fetched, err := client.Fetch(ctx, &proto.FetchRequest{Namespace: "default", Ids: job.InsertedIds[:3]})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if len(fetched.Results) != 3 {
+		t.Errorf("Expected 3 fetched vectors, got %d", len(fetched.Results))
+	}
+
+	_, err = client.GetJobStatus(ctx, &proto.JobStatusRequest{JobId: "job-missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown job, got %v", err)
+	}
+	_, err = client.AsyncBatchInsert(ctx, &proto.AsyncBatchInsertRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty job, got %v", err)
+	}
+}
+
+func TestAsyncBatchInsertJobTTL(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.JobTTL = 50 * time.Millisecond
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	resp, err := server.AsyncBatchInsert(ctx, &proto.AsyncBatchInsertRequest{
+		Items: []*proto.InsertRequest{{Namespace: "default", Vector: []float32{1, 0, 0}}},
+	})
+	if err != nil {
+		t.Fatalf("AsyncBatchInsert failed: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		job, err := server.GetJobStatus(ctx, &proto.JobStatusRequest{JobId: resp.JobId})
+		if err != nil {
+			t.Fatalf("GetJobStatus failed: %v", err)
+		}
+		if job.State == grpcserver.JobCompleted {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Job did not finish: %+v", job)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The finished job is forgotten once the TTL passes
+	time.Sleep(100 * time.Millisecond)
+	if _, err := server.GetJobStatus(ctx, &proto.JobStatusRequest{JobId: resp.JobId}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after the job TTL, got %v", err)
+	}
+}
+
+func TestBatchSearch(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for i := 0; i < 50; i++ {
+		angle := float64(i) * 0.1
+		if _, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(math.Cos(angle)), float32(math.Sin(angle)), 0.5},
+		}); err != nil {
+			t.Fatalf("Failed to insert: %v", err)
+		}
+	}
+
+	queries := []*proto.QueryVector{
+		{Values: []float32{1, 0, 0.5}},
+		{Values: []float32{1, 2}}, // Wrong dimension
+		{Values: []float32{0, 1, 0.5}},
+		{Values: []float32{-1, 0, 0.5}},
+	}
+
+	resp, err := client.BatchSearch(ctx, &proto.BatchSearchRequest{
+		Namespace: "default",
+		Queries:   queries,
+		K:         5,
+		EfSearch:  50,
+	})
+	if err != nil {
+		t.Fatalf("BatchSearch failed: %v", err)
+	}
+
+	if len(resp.Responses) != len(queries) {
+		t.Fatalf("Expected %d responses, got %d", len(queries), len(resp.Responses))
+	}
+	if resp.FailedCount != 1 || resp.Responses[1].Error == nil {
+		t.Fatalf("Expected only query 1 to fail, got %d failures: %v", resp.FailedCount, resp.Responses[1])
+	}
+
+	// Each result set matches the same query sent on its own
+	for i, query := range queries {
+		if i == 1 {
+			continue
+		}
+		single, err := client.Search(ctx, &proto.SearchRequest{
+			Namespace:   "default",
+			QueryVector: query.Values,
+			K:           5,
+			EfSearch:    50,
+		})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+
+		got := resp.Responses[i].Results
+		if len(got) != len(single.Results) {
+			t.Fatalf("Query %d: expected %d results, got %d", i, len(single.Results), len(got))
+		}
+		for j := range got {
+			if got[j].Id != single.Results[j].Id {
+				t.Errorf("Query %d rank %d: batch returned %s, single search %s", i, j, got[j].Id, single.Results[j].Id)
+			}
+		}
+	}
+
+	// Request-level problems fail the whole call
+	if _, err := client.BatchSearch(ctx, &proto.BatchSearchRequest{Namespace: "default", K: 5}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without queries, got %v", err)
+	}
+}
+
+// BenchmarkBatchSearch compares one BatchSearch call against the same
+// queries sent as sequential Search calls
+func BenchmarkBatchSearch(b *testing.B) {
+	_, client, cleanup := setupTestServer(b)
+	defer cleanup()
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(42))
+	randomVector := func() []float32 {
+		return []float32{rng.Float32(), rng.Float32(), rng.Float32()}
+	}
+
+	for i := 0; i < 2000; i++ {
+		if _, err := client.Insert(ctx, &proto.InsertRequest{Namespace: "bench-search", Vector: randomVector()}); err != nil {
+			b.Fatalf("Failed to insert: %v", err)
+		}
+	}
+
+	const numQueries = 100
+	queries := make([]*proto.QueryVector, numQueries)
+	for i := range queries {
+		queries[i] = &proto.QueryVector{Values: randomVector()}
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, q := range queries {
+				if _, err := client.Search(ctx, &proto.SearchRequest{
+					Namespace: "bench-search", QueryVector: q.Values, K: 10, EfSearch: 50,
+				}); err != nil {
+					b.Fatalf("Search failed: %v", err)
+				}
+			}
+		}
+		b.ReportMetric(float64(numQueries*b.N)/b.Elapsed().Seconds(), "queries/s")
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			if _, err := client.BatchSearch(ctx, &proto.BatchSearchRequest{
+				Namespace: "bench-search", Queries: queries, K: 10, EfSearch: 50,
+			}); err != nil {
+				b.Fatalf("BatchSearch failed: %v", err)
+			}
+		}
+		b.ReportMetric(float64(numQueries*b.N)/b.Elapsed().Seconds(), "queries/s")
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Insert some vectors
+	for i := 0; i < 5; i++ {
+		req := &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i) * 0.1, float32(i) * 0.2, float32(i) * 0.3},
+		}
+		if _, err := client.Insert(ctx, req); err != nil {
+			t.Fatalf("Failed to insert: %v", err)
+		}
+	}
+
+	// Get stats
+	statsReq := &proto.StatsRequest{}
+	statsResp, err := client.GetStats(ctx, statsReq)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+
+	if statsResp.TotalVectors < 5 {
+		t.Fatalf("Expected at least 5 vectors, got %d", statsResp.TotalVectors)
+	}
+
+	if statsResp.TotalNamespaces < 1 {
+		t.Fatal("Expected at least 1 namespace")
+	}
+
+	if statsResp.MemoryUsageBytes <= 0 {
+		t.Fatalf("Expected positive memory usage, got %d", statsResp.MemoryUsageBytes)
+	}
+	for name, ns := range statsResp.NamespaceStats {
+		if ns.VectorCount > 0 && ns.MemoryBytes <= 0 {
+			t.Errorf("Namespace %q has %d vectors but reports %d bytes", name, ns.VectorCount, ns.MemoryBytes)
+		}
+	}
+
+	// Memory grows with more vectors
+	for i := 0; i < 50; i++ {
+		req := &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i) * 0.3, float32(i) * 0.1, 1},
+			Text:      stringPtr("memory accounting document"),
+		}
+		if _, err := client.Insert(ctx, req); err != nil {
+			t.Fatalf("Failed to insert: %v", err)
+		}
+	}
+	grown, err := client.GetStats(ctx, statsReq)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if grown.MemoryUsageBytes <= statsResp.MemoryUsageBytes {
+		t.Errorf("Expected memory usage to grow after inserts: %d -> %d",
+			statsResp.MemoryUsageBytes, grown.MemoryUsageBytes)
+	}
+
+	// The graph shape is reported per layer, base layer first
+	ns := grown.NamespaceStats["default"]
+	if len(ns.Layers) != int(ns.MaxLayer)+1 {
+		t.Fatalf("Expected %d layers for max layer %d, got %d", ns.MaxLayer+1, ns.MaxLayer, len(ns.Layers))
+	}
+	if ns.Layers[0].Nodes != ns.VectorCount {
+		t.Errorf("Expected all %d vectors in the base layer, got %d", ns.VectorCount, ns.Layers[0].Nodes)
+	}
+
+	t.Logf("Stats: %d vectors, %d namespaces, %d bytes", statsResp.TotalVectors, statsResp.TotalNamespaces, statsResp.MemoryUsageBytes)
+}
+
+func TestHealthCheck(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Health check
+	healthReq := &proto.HealthCheckRequest{}
+	healthResp, err := client.HealthCheck(ctx, healthReq)
+	if err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+
+	if healthResp.Status != "healthy" {
+		t.Fatalf("Expected status 'healthy', got '%s'", healthResp.Status)
+	}
+
+	if healthResp.Version == "" {
+		t.Error("Version is empty")
+	}
+
+	t.Logf("Health: %s (version %s, uptime %ds)",
+		healthResp.Status, healthResp.Version, healthResp.UptimeSeconds)
+}
+
+func TestMaxConnections(t *testing.T) {
+	cfg := config.Default()
+	cfg.Server.Port = 50054
+	cfg.HNSW.Dimensions = 3
+	cfg.Server.MaxConnections = 2
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	dial := func() (*grpc.ClientConn, proto.VectorDBClient) {
+		conn, err := grpc.NewClient("localhost:50054",
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		return conn, proto.NewVectorDBClient(conn)
+	}
+
+	// Each client opens its own connection; the first two are admitted
+	var conns []*grpc.ClientConn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	var health *proto.HealthCheckResponse
+	for i := 0; i < 2; i++ {
+		conn, client := dial()
+		conns = append(conns, conn)
+		if health, err = client.HealthCheck(ctx, &proto.HealthCheckRequest{}); err != nil {
+			t.Fatalf("HealthCheck on connection %d failed: %v", i, err)
+		}
+	}
+	if health.Details["connections"] != "2" || health.Details["max_connections"] != "2" {
+		t.Errorf("Expected 2 of 2 connections in health details, got %v", health.Details)
+	}
+
+	conn, client := dial()
+	_, err = client.HealthCheck(ctx, &proto.HealthCheckRequest{})
+	conn.Close()
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted beyond the limit, got %v", err)
+	}
+
+	// Closing a connection frees its slot
+	conns[0].Close()
+	conns = conns[1:]
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, client := dial()
+		conns = append(conns, conn)
+		_, err := client.HealthCheck(ctx, &proto.HealthCheckRequest{})
+		if err == nil {
+			break
+		}
+		if status.Code(err) != codes.ResourceExhausted || time.Now().After(deadline) {
+			t.Fatalf("Expected a freed slot to admit a new connection, got %v", err)
+		}
+		conn.Close()
+		conns = conns[:len(conns)-1]
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestMultipleNamespaces(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Insert vectors in different namespaces
+	namespaces := []string{"ns1", "ns2", "ns3"}
+	for _, ns := range namespaces {
+		req := &proto.InsertRequest{
+			Namespace: ns,
+			Vector:    []float32{0.1, 0.2, 0.3},
+		}
+		if _, err := client.Insert(ctx, req); err != nil {
+			t.Fatalf("Failed to insert in namespace %s: %v", ns, err)
+		}
+	}
+
+	// Get stats to verify namespaces
+	statsReq := &proto.StatsRequest{}
+	statsResp, err := client.GetStats(ctx, statsReq)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+
+	if int(statsResp.TotalNamespaces) < len(namespaces) {
+		t.Fatalf("Expected at least %d namespaces, got %d",
+			len(namespaces), statsResp.TotalNamespaces)
+	}
+
+	t.Logf("Created %d namespaces successfully", len(namespaces))
+}
+
+// payloadRecorder records the wire and decoded sizes of received payloads
+// and totals the wire size of sent ones
+type payloadRecorder struct {
+	mu               sync.Mutex
+	length           int
+	compressedLength int
+	sentWireLength   int
+}
+
+func (p *payloadRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context { return ctx }
+func (p *payloadRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InPayload); ok {
+		p.mu.Lock()
+		p.length = in.Length
+		p.compressedLength = in.CompressedLength
+		p.mu.Unlock()
+	}
+	if out, ok := s.(*stats.OutPayload); ok {
+		p.mu.Lock()
+		p.sentWireLength += out.WireLength
+		p.mu.Unlock()
+	}
+}
+
+// sent returns the wire bytes sent since the last call
+func (p *payloadRecorder) sent() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := p.sentWireLength
+	p.sentWireLength = 0
+	return n
+}
+
+// streamBatchInsert sends reqs over one BatchInsert stream, compressed with
+// compressor unless it is empty
+func streamBatchInsert(ctx context.Context, client proto.VectorDBClient, compressor string, reqs []*proto.InsertRequest) (*proto.BatchInsertResponse, error) {
+	var opts []grpc.CallOption
+	if compressor != "" {
+		opts = append(opts, grpc.UseCompressor(compressor))
+	}
+	stream, err := client.BatchInsert(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+func TestBatchInsertCompressed(t *testing.T) {
+	_, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	recorder := &payloadRecorder{}
+	conn, err := grpc.NewClient("localhost:50052",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(recorder),
+	)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewVectorDBClient(conn)
+
+	// gRPC compresses each message on its own, so each item carries
+	// enough text to compress
+	text := strings.Repeat("compressed batch insert document text ", 20)
+	var reqs []*proto.InsertRequest
+	for i := 0; i < 200; i++ {
+		reqs = append(reqs, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i%4) * 0.25, float32(i%3) * 0.5, 1},
+			Text:      stringPtr(text),
+		})
+	}
+
+	wire := make(map[string]int)
+	for _, compressor := range []string{"", gzip.Name, zstd.Name} {
+		resp, err := streamBatchInsert(ctx, client, compressor, reqs)
+		if err != nil {
+			t.Fatalf("BatchInsert with compressor %q failed: %v", compressor, err)
+		}
+		if resp.InsertedCount != int32(len(reqs)) {
+			t.Fatalf("BatchInsert with compressor %q inserted %d of %d: %v", compressor, resp.InsertedCount, len(reqs), resp.Errors)
+		}
+		wire[compressor] = recorder.sent()
+	}
+
+	for _, compressor := range []string{gzip.Name, zstd.Name} {
+		if wire[compressor] >= wire[""] {
+			t.Errorf("Expected %s to shrink the batch below %d wire bytes, got %d", compressor, wire[""], wire[compressor])
+		}
+	}
+	t.Logf("BatchInsert wire bytes: none=%d gzip=%d zstd=%d", wire[""], wire[gzip.Name], wire[zstd.Name])
+}
+
+// BenchmarkBatchInsertCompression measures BatchInsert throughput and
+// bytes on the wire for 768-dimensional embeddings with each compressor
+func BenchmarkBatchInsertCompression(b *testing.B) {
+	const numItems = 500
+	const dim = 768
+
+	cfg := config.Default()
+	cfg.Server.Port = 50055
+	cfg.HNSW.Dimensions = dim
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		b.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		b.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	recorder := &payloadRecorder{}
+	conn, err := grpc.NewClient("localhost:50055",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(recorder),
+	)
+	if err != nil {
+		b.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewVectorDBClient(conn)
+
+	rng := rand.New(rand.NewSource(42))
+	vectors := make([][]float32, numItems)
+	for i := range vectors {
+		vectors[i] = make([]float32, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = float32(rng.NormFloat64() * 0.05)
+		}
+	}
+
+	ctx := context.Background()
+	for _, compressor := range []string{"none", gzip.Name, zstd.Name} {
+		b.Run("compressor="+compressor, func(b *testing.B) {
+			useCompressor := compressor
+			if compressor == "none" {
+				useCompressor = ""
+			}
+			recorder.sent()
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				reqs := make([]*proto.InsertRequest, numItems)
+				for i, vec := range vectors {
+					reqs[i] = &proto.InsertRequest{
+						Namespace: fmt.Sprintf("bench-%s-%d", compressor, n),
+						Vector:    vec,
+						Metadata:  map[string]string{"item": fmt.Sprint(i)},
+					}
+				}
+				resp, err := streamBatchInsert(ctx, client, useCompressor, reqs)
+				if err != nil {
+					b.Fatalf("BatchInsert failed: %v", err)
+				}
+				if resp.FailedCount != 0 {
+					b.Fatalf("Unexpected failures: %v", resp.Errors)
+				}
+			}
+			b.ReportMetric(float64(recorder.sent())/float64(numItems*b.N), "wire-bytes/vector")
+			b.ReportMetric(float64(numItems*b.N)/b.Elapsed().Seconds(), "vectors/s")
+		})
+	}
+}
+func (p *payloadRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+func (p *payloadRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func TestSearchCompressed(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for i := 0; i < 200; i++ {
+		req := &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i%7) * 0.1, float32(i%11) * 0.1, float32(i%13) * 0.1},
+			Metadata: map[string]string{
+				"title": fmt.Sprintf("compressible document number %d", i),
+			},
+		}
+		if _, err := client.Insert(ctx, req); err != nil {
+			t.Fatalf("Failed to insert vector %d: %v", i, err)
+		}
+	}
+
+	recorder := &payloadRecorder{}
+	conn, err := grpc.NewClient("localhost:50052",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(recorder),
+	)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	statsClient := proto.NewVectorDBClient(conn)
+
+	searchReq := &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{0.3, 0.3, 0.3},
+		K:           100,
+		EfSearch:    200,
+	}
+
+	plainResp, err := statsClient.Search(ctx, searchReq)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	compressedResp, err := statsClient.Search(ctx, searchReq, grpc.UseCompressor(gzip.Name))
+	if err != nil {
+		t.Fatalf("Compressed search failed: %v", err)
+	}
+
+	recorder.mu.Lock()
+	length, compressedLength := recorder.length, recorder.compressedLength
+	recorder.mu.Unlock()
+	if compressedLength >= length {
+		t.Errorf("Expected compressed payload (%d bytes) smaller than decoded (%d bytes)", compressedLength, length)
+	}
+
+	if len(plainResp.Results) != len(compressedResp.Results) {
+		t.Fatalf("Result count mismatch: %d vs %d", len(plainResp.Results), len(compressedResp.Results))
+	}
+	for i := range plainResp.Results {
+		if plainResp.Results[i].Id != compressedResp.Results[i].Id ||
+			plainResp.Results[i].Distance != compressedResp.Results[i].Distance {
+			t.Errorf("Result %d differs between compressed and plain responses", i)
+		}
+	}
+}
+
+func TestSearchCompressionNegotiated(t *testing.T) {
+	for i, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			port := 50073 + i
+			cfg := config.Default()
+			cfg.Server.Port = port
+			cfg.HNSW.Dimensions = 3
+			cfg.Server.EnableCompression = enabled
+
+			server, err := grpcserver.NewServer(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+			if err := server.Start(); err != nil {
+				t.Fatalf("Failed to start server: %v", err)
+			}
+			defer server.Stop()
+
+			ctx := context.Background()
+			for i := 0; i < 200; i++ {
+				if _, err := server.Insert(ctx, &proto.InsertRequest{
+					Namespace: "default",
+					Vector:    []float32{float32(i%7) * 0.1, float32(i%11) * 0.1, float32(i%13) * 0.1},
+					Metadata:  map[string]string{"title": fmt.Sprintf("compressible document number %d", i)},
+				}); err != nil {
+					t.Fatalf("Insert failed: %v", err)
+				}
+			}
+
+			recorder := &payloadRecorder{}
+			conn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", port),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithStatsHandler(recorder),
+			)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+			client := proto.NewVectorDBClient(conn)
+
+			searchReq := &proto.SearchRequest{Namespace: "default", QueryVector: []float32{0.3, 0.3, 0.3}, K: 100, EfSearch: 200}
+			received := func() (int, int) {
+				recorder.mu.Lock()
+				defer recorder.mu.Unlock()
+				return recorder.length, recorder.compressedLength
+			}
+
+			// An uncompressed request only advertises gzip, so the server
+			// decides whether to compress the response
+			if _, err := client.Search(ctx, searchReq); err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			length, compressedLength := received()
+			if compressed := compressedLength < length; compressed != enabled {
+				t.Errorf("Expected response compressed=%v, got %d wire bytes for %d decoded", enabled, compressedLength, length)
+			}
+
+			// A gzip request is accepted either way
+			resp, err := client.Search(ctx, searchReq, grpc.UseCompressor(gzip.Name))
+			if err != nil {
+				t.Fatalf("Compressed search failed: %v", err)
+			}
+			if len(resp.Results) != 100 {
+				t.Errorf("Expected 100 results, got %d", len(resp.Results))
+			}
+		})
+	}
+}
+
+func TestReflection(t *testing.T) {
+	listServices := func(port int, enabled bool) ([]string, error) {
+		cfg := config.Default()
+		cfg.Server.Port = port
+		cfg.HNSW.Dimensions = 3
+		cfg.Server.EnableReflection = enabled
+
+		server, err := grpcserver.NewServer(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create server: %v", err)
+		}
+		if err := server.Start(); err != nil {
+			t.Fatalf("Failed to start server: %v", err)
+		}
+		defer server.Stop()
+
+		conn, err := grpc.NewClient(cfg.Server.Address(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}); err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		var services []string
+		for _, service := range resp.GetListServicesResponse().GetService() {
+			services = append(services, service.GetName())
+		}
+		return services, nil
+	}
+
+	services, err := listServices(50071, true)
+	if err != nil {
+		t.Fatalf("ListServices failed: %v", err)
+	}
+	found := false
+	for _, name := range services {
+		if name == "vector.VectorDB" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected reflection to list vector.VectorDB, got %v", services)
+	}
+
+	if _, err := listServices(50072, false); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented with reflection disabled, got %v", err)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestValidate(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 50; i++ {
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), float32(i % 7), 1},
+		})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+	}
+	for _, id := range ids[:20] {
+		if _, err := server.Delete(ctx, &proto.DeleteRequest{
+			Namespace: "default",
+			Selector:  &proto.DeleteRequest_Id{Id: id},
+		}); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+
+	resp, err := server.Validate(ctx, &proto.ValidateRequest{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !resp.Valid || resp.ViolationCount != 0 {
+		t.Errorf("Expected valid graph, got %d violations: %v", resp.ViolationCount, resp.Violations)
+	}
+	if resp.NodesChecked != 30 {
+		t.Errorf("Expected 30 nodes checked, got %d", resp.NodesChecked)
+	}
+
+	if _, err := server.Validate(ctx, &proto.ValidateRequest{Namespace: "nowhere"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for unknown namespace, got %v", err)
+	}
+	if _, err := server.Validate(ctx, &proto.ValidateRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty namespace, got %v", err)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 8
+	cfg.HNSW.ExactSearchThreshold = 0 // Always search the graph
+	cfg.HNSW.FlatThreshold = 0
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(42))
+
+	vectors := make(map[string][]float32)
+	var ids []string
+	for i := 0; i < 300; i++ {
+		vector := make([]float32, 8)
+		for j := range vector {
+			vector[j] = rng.Float32()
+		}
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "churn",
+			Vector:    vector,
+			Metadata:  map[string]string{"n": strconv.Itoa(i)},
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+		vectors[resp.Id] = vector
+	}
+	for _, id := range ids[:200] {
+		if _, err := server.Delete(ctx, &proto.DeleteRequest{
+			Namespace: "churn",
+			Selector:  &proto.DeleteRequest_Id{Id: id},
+		}); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		delete(vectors, id)
+	}
+
+	// Searches keep working while the graph is rebuilt
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		query := vectors[ids[250]]
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			resp, err := server.Search(ctx, &proto.SearchRequest{Namespace: "churn", QueryVector: query, K: 5})
+			if err != nil || len(resp.Results) == 0 {
+				t.Errorf("Search during compaction failed: %v", err)
+				return
+			}
+		}
+	}()
+
+	resp, err := server.Compact(ctx, &proto.CompactRequest{Namespace: "churn"})
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if resp.NodesBefore != 100 || resp.NodesAfter != 100 || resp.DeletedNodes != 200 {
+		t.Errorf("Unexpected compaction counts: %+v", resp)
+	}
+	if resp.MemoryBeforeBytes == 0 || resp.MemoryAfterBytes == 0 {
+		t.Errorf("Expected memory to be reported, got %+v", resp)
+	}
+
+	// IDs and metadata survive compaction
+	for _, id := range ids[200:] {
+		search, err := server.Search(ctx, &proto.SearchRequest{Namespace: "churn", QueryVector: vectors[id], K: 1})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(search.Results) != 1 || search.Results[0].Id != id {
+			t.Fatalf("Expected %s as its own nearest neighbor, got %v", id, search.Results)
+		}
+	}
+	fetch, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "churn", Ids: []string{ids[250]}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if len(fetch.Results) != 1 || fetch.Results[0].Metadata["n"] != "250" {
+		t.Errorf("Expected metadata of vector 250 after compaction, got %v", fetch.Results)
+	}
+
+	// A second compaction has no deletes left to reclaim
+	resp, err = server.Compact(ctx, &proto.CompactRequest{Namespace: "churn"})
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if resp.DeletedNodes != 0 {
+		t.Errorf("Expected no deletes after compaction, got %d", resp.DeletedNodes)
+	}
+
+	if _, err := server.Compact(ctx, &proto.CompactRequest{Namespace: "nowhere"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for unknown namespace, got %v", err)
+	}
+	if _, err := server.Compact(ctx, &proto.CompactRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty namespace, got %v", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 8
+	cfg.HNSW.ExactSearchThreshold = 0 // Always search the index
+	cfg.HNSW.FlatThreshold = 0
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(42))
+
+	vectors := make(map[string][]float32)
+	var ids []string
+	for i := 0; i < 1200; i++ {
+		vector := make([]float32, 8)
+		for j := range vector {
+			vector[j] = rng.Float32()
+		}
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "docs",
+			Vector:    vector,
+			Metadata:  map[string]string{"n": strconv.Itoa(i)},
+			Text:      stringPtr("document " + strconv.Itoa(i)),
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+		vectors[resp.Id] = vector
+	}
+
+	// A cancelled migration leaves the namespace as it was
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancelled := &migrateStream{ctx: cancelCtx, onSend: cancel}
+	err = server.Migrate(&proto.MigrateRequest{Namespace: "docs", IndexType: "flat"}, cancelled)
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("Expected Canceled, got %v", err)
+	}
+
+	stream := &migrateStream{ctx: ctx}
+	if err := server.Migrate(&proto.MigrateRequest{Namespace: "docs", IndexType: "flat"}, stream); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	phases := make(map[string]bool)
+	for _, p := range stream.progress[:len(stream.progress)-1] {
+		phases[p.Phase] = true
+		if p.Done {
+			t.Errorf("Expected only the last message to be done, got %+v", p)
+		}
+	}
+	if !phases["read"] || !phases["build"] {
+		t.Errorf("Expected read and build progress, got %v", phases)
+	}
+	final := stream.progress[len(stream.progress)-1]
+	if !final.Done || final.IndexType != "flat" || final.VectorsTotal != 1200 {
+		t.Fatalf("Unexpected final progress: %+v", final)
+	}
+	if final.MemoryAfterBytes >= final.MemoryBeforeBytes {
+		t.Errorf("Expected the flat index to drop the graph's memory, got %d -> %d bytes",
+			final.MemoryBeforeBytes, final.MemoryAfterBytes)
+	}
+
+	// IDs, metadata and text survive, and the flat index searches exactly
+	for _, id := range ids[:50] {
+		search, err := server.Search(ctx, &proto.SearchRequest{Namespace: "docs", QueryVector: vectors[id], K: 1})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(search.Results) != 1 || search.Results[0].Id != id {
+			t.Fatalf("Expected %s as its own nearest neighbor, got %v", id, search.Results)
+		}
+	}
+	fetch, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "docs", Ids: []string{ids[7]}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if len(fetch.Results) != 1 || fetch.Results[0].Metadata["n"] != "7" || fetch.Results[0].GetText() != "document 7" {
+		t.Errorf("Expected metadata and text of vector 7 after migrating, got %v", fetch.Results)
+	}
+	hybrid, err := server.HybridSearch(ctx, &proto.HybridSearchRequest{
+		Namespace:   "docs",
+		QueryVector: vectors[ids[7]],
+		QueryText:   "document",
+		K:           10,
+	})
+	if err != nil {
+		t.Fatalf("HybridSearch failed: %v", err)
+	}
+	foundVector := false
+	for _, result := range hybrid.Results {
+		if result.Id == ids[7] && result.GetVectorRank() == 1 {
+			foundVector = true
+		}
+	}
+	if !foundVector {
+		t.Errorf("Expected hybrid search to rank %s first by vector, got %v", ids[7], hybrid.Results)
+	}
+
+	// Inserts after the migration get fresh IDs
+	resp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: vectors[ids[0]]})
+	if err != nil {
+		t.Fatalf("Insert after migrating failed: %v", err)
+	}
+	if _, exists := vectors[resp.Id]; exists {
+		t.Errorf("Expected a new ID, got %s again", resp.Id)
+	}
+
+	stream = &migrateStream{ctx: ctx}
+	if err := server.Migrate(&proto.MigrateRequest{Namespace: "docs", IndexType: "hnsw"}, stream); err != nil {
+		t.Fatalf("Migrate back to HNSW failed: %v", err)
+	}
+	if final := stream.progress[len(stream.progress)-1]; final.VectorsTotal != 1201 {
+		t.Errorf("Expected 1201 vectors in the rebuilt graph, got %d", final.VectorsTotal)
+	}
+
+	// Quantized indexes need enough vectors to train on
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "few", Vector: vectors[ids[0]]}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *proto.MigrateRequest
+		want codes.Code
+	}{
+		{"missing namespace", &proto.MigrateRequest{IndexType: "flat"}, codes.InvalidArgument},
+		{"unknown index type", &proto.MigrateRequest{Namespace: "docs", IndexType: "annoy"}, codes.InvalidArgument},
+		{"too few vectors to train", &proto.MigrateRequest{Namespace: "few", IndexType: "ivf_pq"}, codes.FailedPrecondition},
+		{"unknown namespace", &proto.MigrateRequest{Namespace: "nowhere", IndexType: "flat"}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := server.Migrate(tt.req, &migrateStream{ctx: ctx})
+			if status.Code(err) != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// migrateStream collects Migrate progress without a network round trip,
+// calling onSend (when set) for each message
+type migrateStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	onSend   func()
+	progress []*proto.MigrateProgress
+}
+
+func (s *migrateStream) Context() context.Context { return s.ctx }
+
+func (s *migrateStream) Send(p *proto.MigrateProgress) error {
+	s.progress = append(s.progress, p)
+	if s.onSend != nil {
+		s.onSend()
+	}
+	return nil
+}
+
+func TestMigrateQuantized(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 8
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(7))
+	randomVector := func() []float32 {
+		vector := make([]float32, 8)
+		for j := range vector {
+			vector[j] = rng.Float32()*2 - 1
+		}
+		return vector
+	}
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// nearest searches a namespace for a vector, returning the top ID or ""
+	nearest := func(server *grpcserver.Server, namespace string, vector []float32, filter *proto.Filter) string {
+		t.Helper()
+		resp, err := server.Search(ctx, &proto.SearchRequest{Namespace: namespace, QueryVector: vector, K: 1, Filter: filter})
+		if err != nil {
+			t.Fatalf("Search in %s failed: %v", namespace, err)
+		}
+		if len(resp.Results) == 0 {
+			return ""
+		}
+		return resp.Results[0].Id
+	}
+
+	type migrated struct {
+		ids      []string
+		vectors  map[string][]float32
+		inserted string // Inserted after migrating
+		updated  []float32
+		deleted  string
+	}
+	namespaces := make(map[string]*migrated)
+
+	for _, indexType := range []string{"ivf_pq", "scann"} {
+		ns := &migrated{vectors: make(map[string][]float32)}
+		namespaces[indexType] = ns
+		for i := 0; i < 400; i++ {
+			vector := randomVector()
+			resp, err := server.Insert(ctx, &proto.InsertRequest{
+				Namespace: indexType,
+				Vector:    vector,
+				Metadata:  map[string]string{"tag": "v" + strconv.Itoa(i)},
+			})
+			if err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+			ns.ids = append(ns.ids, resp.Id)
+			ns.vectors[resp.Id] = vector
+		}
+
+		stream := &migrateStream{ctx: ctx}
+		if err := server.Migrate(&proto.MigrateRequest{Namespace: indexType, IndexType: indexType}, stream); err != nil {
+			t.Fatalf("Migrate to %s failed: %v", indexType, err)
+		}
+		trained := false
+		for _, p := range stream.progress {
+			trained = trained || p.Phase == "train"
+		}
+		final := stream.progress[len(stream.progress)-1]
+		if !trained || !final.Done || final.IndexType != indexType || final.VectorsTotal != 400 {
+			t.Errorf("Unexpected %s progress: train phase %v, final %+v", indexType, trained, final)
+		}
+
+		describe, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: indexType})
+		if err != nil {
+			t.Fatalf("DescribeNamespace failed: %v", err)
+		}
+		if describe.IndexType != indexType || describe.VectorCount != 400 {
+			t.Errorf("Expected a %s namespace of 400 vectors, got %v", indexType, describe)
+		}
+
+		// Searches go through the quantized index and rescore exactly
+		for _, id := range ns.ids[:20] {
+			resp, err := server.Search(ctx, &proto.SearchRequest{Namespace: indexType, QueryVector: ns.vectors[id], K: 1})
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(resp.Results) != 1 || resp.Results[0].Id != id || resp.Results[0].Distance > 1e-5 || resp.Exact {
+				t.Fatalf("Expected %s as its own nearest neighbor in %s, got %v", id, indexType, resp)
+			}
+		}
+		filter := &proto.Filter{FilterType: &proto.Filter_Comparison{
+			Comparison: &proto.ComparisonFilter{Field: "tag", Operator: "eq", Value: "v5"},
+		}}
+		if got := nearest(server, indexType, ns.vectors[ns.ids[0]], filter); got != ns.ids[5] {
+			t.Errorf("Expected the filtered search in %s to widen to %s, got %q", indexType, ns.ids[5], got)
+		}
+
+		// Writes after the migration reach the quantized index
+		vector := randomVector()
+		resp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: indexType, Vector: vector})
+		if err != nil {
+			t.Fatalf("Insert after migrating failed: %v", err)
+		}
+		ns.inserted = resp.Id
+		ns.vectors[resp.Id] = vector
+
+		ns.updated = randomVector()
+		if _, err := server.Update(ctx, &proto.UpdateRequest{Namespace: indexType, Id: ns.ids[1], Vector: ns.updated}); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		ns.deleted = ns.ids[2]
+		if _, err := server.Delete(ctx, &proto.DeleteRequest{
+			Namespace: indexType,
+			Selector:  &proto.DeleteRequest_Id{Id: ns.deleted},
+		}); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+
+	check := func(server *grpcserver.Server, when string) {
+		t.Helper()
+		for indexType, ns := range namespaces {
+			if got := nearest(server, indexType, ns.vectors[ns.inserted], nil); got != ns.inserted {
+				t.Errorf("%s: expected the inserted vector %s in %s, got %q", when, ns.inserted, indexType, got)
+			}
+			if got := nearest(server, indexType, ns.updated, nil); got != ns.ids[1] {
+				t.Errorf("%s: expected the updated vector %s in %s, got %q", when, ns.ids[1], indexType, got)
+			}
+			if got := nearest(server, indexType, ns.vectors[ns.deleted], nil); got == ns.deleted {
+				t.Errorf("%s: expected the deleted vector %s gone from %s", when, ns.deleted, indexType)
+			}
+		}
+	}
+	check(server, "after writes")
+	server.Stop()
+
+	// The WAL records the index type, so recovery rebuilds the quantized
+	// indexes
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	defer server.Stop()
+
+	for indexType := range namespaces {
+		describe, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: indexType})
+		if err != nil {
+			t.Fatalf("DescribeNamespace failed: %v", err)
+		}
+		if describe.IndexType != indexType || describe.VectorCount != 400 {
+			t.Errorf("Expected %s to recover as a %s namespace of 400 vectors, got %v", indexType, indexType, describe)
+		}
+	}
+	check(server, "after restart")
+
+	// Snapshots record the index type, so a restored copy is retrained
+	snapshot := &snapshotStream{ctx: ctx}
+	if err := server.Snapshot(&proto.SnapshotRequest{}, snapshot); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	final := snapshot.progress[len(snapshot.progress)-1]
+	if _, err := server.Restore(ctx, &proto.RestoreRequest{Path: final.Path, Prefix: "restored-"}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	for indexType, ns := range namespaces {
+		describe, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "restored-" + indexType})
+		if err != nil {
+			t.Fatalf("DescribeNamespace failed: %v", err)
+		}
+		if describe.IndexType != indexType || describe.VectorCount != 400 {
+			t.Errorf("Expected restored-%s to restore as a %s namespace of 400 vectors, got %v", indexType, indexType, describe)
+		}
+		if got := nearest(server, "restored-"+indexType, ns.updated, nil); got != ns.ids[1] {
+			t.Errorf("Expected the updated vector %s in restored-%s, got %q", ns.ids[1], indexType, got)
+		}
+	}
+
+	// Migrating back serves the namespace from a graph again. Writes made
+	// while it is built carry over to it.
+	var wg sync.WaitGroup
+	during := make(map[string][]float32)
+	var duringMu sync.Mutex
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			duringMu.Lock()
+			vector := randomVector()
+			duringMu.Unlock()
+			resp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "scann", Vector: vector})
+			if err != nil {
+				t.Errorf("Insert during the migration failed: %v", err)
+				return
+			}
+			duringMu.Lock()
+			during[resp.Id] = vector
+			duringMu.Unlock()
+		}
+	}()
+	if err := server.Migrate(&proto.MigrateRequest{Namespace: "scann", IndexType: "hnsw"}, &migrateStream{ctx: ctx}); err != nil {
+		t.Fatalf("Migrate back to HNSW failed: %v", err)
+	}
+	wg.Wait()
+
+	describe, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "scann"})
+	if err != nil || describe.IndexType != "hnsw" || describe.VectorCount != int64(400+len(during)) {
+		t.Errorf("Expected an HNSW namespace of %d vectors after migrating back, got %v (%v)", 400+len(during), describe, err)
+	}
+	for id, vector := range during {
+		if got := nearest(server, "scann", vector, nil); got != id {
+			t.Errorf("Expected the vector %s inserted during the migration, got %q", id, got)
+		}
+	}
+}
+
+func TestCreateNamespace(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+
+	resp, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{
+		Namespace:      "images",
+		M:              8,
+		EfConstruction: 50,
+		Dimensions:     4,
+		Metric:         grpcserver.MetricEuclidean,
+	})
+	if err != nil {
+		t.Fatalf("CreateNamespace failed: %v", err)
+	}
+	if resp.M != 8 || resp.EfConstruction != 50 || resp.Dimensions != 4 || resp.Metric != grpcserver.MetricEuclidean {
+		t.Errorf("Expected the declared parameters, got %v", resp)
+	}
+
+	// Omitted parameters take the server configuration
+	resp, err = server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{Namespace: "defaults"})
+	if err != nil {
+		t.Fatalf("CreateNamespace failed: %v", err)
+	}
+	if int(resp.M) != cfg.HNSW.M || int(resp.EfConstruction) != cfg.HNSW.EfConstruction ||
+		resp.Dimensions != 0 || resp.Metric != grpcserver.MetricCosine {
+		t.Errorf("Expected the configured parameters, got %v", resp)
+	}
+
+	// The declared dimension holds before the first insert
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "images", Vector: []float32{1, 0, 0}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a 3-dimensional vector, got %v", err)
+	}
+	insertResp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "images", Vector: []float32{1, 0, 0, 0}})
+	if err != nil || !insertResp.Success {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	stats, err := server.GetStats(ctx, &proto.StatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	images := stats.NamespaceStats["images"]
+	if images.M != 8 || images.EfConstruction != 50 || images.Dimensions != 4 || images.Metric != grpcserver.MetricEuclidean {
+		t.Errorf("Expected GetStats to report the declared parameters, got %v", images)
+	}
+	if def := stats.NamespaceStats["default"]; int(def.M) != cfg.HNSW.M || int(def.EfConstruction) != cfg.HNSW.EfConstruction {
+		t.Errorf("Expected the default namespace to use the configured parameters, got %v", def)
+	}
+
+	// Parameters cannot change once the namespace holds vectors
+	_, err = server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{Namespace: "images", M: 16})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+
+	invalid := []*proto.CreateNamespaceRequest{
+		{Namespace: ""},
+		{Namespace: "bad", M: 1},
+		{Namespace: "bad", EfConstruction: 5},
+		{Namespace: "bad", Dimensions: -1},
+		{Namespace: "bad", Dimensions: int32(cfg.HNSW.MaxDimensions) + 1},
+		{Namespace: "bad", Metric: "manhattan"},
+	}
+	for _, req := range invalid {
+		if _, err := server.CreateNamespace(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+
+	// An empty namespace is rebuilt under new parameters
+	if _, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{Namespace: "defaults", M: 24}); err != nil {
+		t.Fatalf("CreateNamespace on an empty namespace failed: %v", err)
+	}
+	stats, err = server.GetStats(ctx, &proto.StatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if m := stats.NamespaceStats["defaults"].M; m != 24 {
+		t.Errorf("Expected M 24 after redeclaring, got %d", m)
+	}
+
+	// A flat namespace never builds its graph
+	resp, err = server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{Namespace: "small", IndexType: "flat"})
+	if err != nil || resp.IndexType != "flat" {
+		t.Fatalf("Expected a flat namespace, got %v (%v)", resp, err)
+	}
+	for _, indexType := range []string{"ivf_pq", "scann"} {
+		if _, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{Namespace: "small", IndexType: indexType}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for a %s namespace, got %v", indexType, err)
+		}
+	}
+	if _, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{Namespace: "small", IndexType: "btree"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown index type, got %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "small", Vector: []float32{float32(i), 1, 0}}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	stats, err = server.GetStats(ctx, &proto.StatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if layers := stats.NamespaceStats["small"].Layers; len(layers) != 1 || layers[0].AvgDegree != 0 {
+		t.Errorf("Expected a flat namespace to have no links, got %v", layers)
+	}
+}
+
+func TestNamespaceConfigRecovery(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if _, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{
+		Namespace:      "images",
+		M:              8,
+		EfConstruction: 50,
+		Dimensions:     4,
+		Metric:         grpcserver.MetricEuclidean,
+		IndexType:      "flat",
+	}); err != nil {
+		t.Fatalf("CreateNamespace failed: %v", err)
+	}
+	insertResp, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "images", Vector: []float32{3, 4, 0, 0}})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	server.Stop()
+
+	// The declared parameters are replayed ahead of the vectors
+	for restart := 1; restart <= 2; restart++ {
+		server, err = grpcserver.NewServer(cfg)
+		if err != nil {
+			t.Fatalf("Restart %d: failed to create server: %v", restart, err)
+		}
+
+		resp, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "images"})
+		if err != nil {
+			t.Fatalf("Restart %d: DescribeNamespace failed: %v", restart, err)
+		}
+		if resp.M != 8 || resp.EfConstruction != 50 || resp.Dimensions != 4 ||
+			resp.Metric != grpcserver.MetricEuclidean || resp.IndexType != "flat" || resp.VectorCount != 1 {
+			t.Errorf("Restart %d: expected the declared parameters and 1 vector, got %v", restart, resp)
+		}
+
+		search, err := server.Search(ctx, &proto.SearchRequest{Namespace: "images", QueryVector: []float32{0, 0, 0, 0}, K: 1})
+		if err != nil || len(search.Results) != 1 || search.Results[0].Id != insertResp.Id || search.Results[0].Distance != 5 {
+			t.Errorf("Restart %d: expected a euclidean distance of 5 to %s, got %v (err: %v)", restart, insertResp.Id, search, err)
+		}
+		if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "images", Vector: []float32{1, 0, 0}}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Restart %d: expected the declared dimension to hold, got %v", restart, err)
+		}
+
+		server.Stop()
+	}
+}
+
+func TestDescribeNamespace(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+
+	if _, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	list, err := server.ListNamespaces(ctx, &proto.ListNamespacesRequest{})
+	if err != nil || len(list.Namespaces) != 1 {
+		t.Errorf("Expected describing to create no namespace, got %v (%v)", list, err)
+	}
+
+	if _, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{
+		Namespace: "docs", M: 12, Metric: grpcserver.MetricDotProduct, IndexType: "flat",
+	}); err != nil {
+		t.Fatalf("CreateNamespace failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: []float32{1, float32(i), 0}}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	resp, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "docs"})
+	if err != nil {
+		t.Fatalf("DescribeNamespace failed: %v", err)
+	}
+	if resp.IndexType != "flat" || resp.M != 12 || int(resp.EfConstruction) != cfg.HNSW.EfConstruction ||
+		resp.Dimensions != 3 || resp.Metric != grpcserver.MetricDotProduct || resp.VectorCount != 3 || resp.MemoryBytes <= 0 {
+		t.Errorf("Unexpected description: %v", resp)
+	}
+}
+
+func TestSearchLimits(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.HNSW.MaxK = 5
+	cfg.HNSW.MaxEfSearch = 100
+	cfg.HNSW.Namespaces = map[string]config.NamespaceSearch{
+		"docs": {DefaultEfSearch: 80, MaxK: 20, MaxEfSearch: -1},
+	}
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	query := []float32{1, 0, 0}
+	for _, ns := range []string{"default", "docs"} {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: ns, Vector: query}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		k         int32
+		efSearch  int32
+		want      codes.Code
+	}{
+		{"within global limits", "default", 5, 100, codes.OK},
+		{"k above global limit", "default", 6, 0, codes.InvalidArgument},
+		{"ef_search above global limit", "default", 5, 101, codes.InvalidArgument},
+		{"k within namespace limit", "docs", 20, 0, codes.OK},
+		{"k above namespace limit", "docs", 21, 0, codes.InvalidArgument},
+		{"ef_search limit lifted", "docs", 5, 1000, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.Search(ctx, &proto.SearchRequest{
+				Namespace: tt.namespace, QueryVector: query, K: tt.k, EfSearch: tt.efSearch,
+			})
+			if status.Code(err) != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := server.RangeSearch(ctx, &proto.RangeSearchRequest{
+		Namespace: "default", QueryVector: query, Radius: 1, EfSearch: 101,
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for range search, got %v", err)
+	}
+
+	resp, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "docs"})
+	if err != nil {
+		t.Fatalf("DescribeNamespace failed: %v", err)
+	}
+	if resp.DefaultEfSearch != 80 || resp.MaxK != 20 || resp.MaxEfSearch != 0 {
+		t.Errorf("Unexpected docs limits: %v", resp)
+	}
+	resp, err = server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("DescribeNamespace failed: %v", err)
+	}
+	if int(resp.DefaultEfSearch) != cfg.HNSW.DefaultEfSearch || resp.MaxK != 5 || resp.MaxEfSearch != 100 {
+		t.Errorf("Unexpected default limits: %v", resp)
+	}
+}
+
+func TestStrictNamespaces(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.StrictNamespaces = true
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	vector := []float32{1, 0, 0}
+
+	// The default namespace always exists
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "default", Vector: vector}); err != nil {
+		t.Fatalf("Insert into the default namespace failed: %v", err)
+	}
+
+	// Requests naming an unknown namespace fail without creating it
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "dcos", Vector: vector}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an insert, got %v", err)
+	}
+	if _, err := server.Search(ctx, &proto.SearchRequest{Namespace: "dcos", QueryVector: vector, K: 1}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a search, got %v", err)
+	}
+	if _, err := server.Delete(ctx, &proto.DeleteRequest{Namespace: "dcos", Selector: &proto.DeleteRequest_Id{Id: "1"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a delete, got %v", err)
+	}
+	list, err := server.ListNamespaces(ctx, &proto.ListNamespacesRequest{})
+	if err != nil || len(list.Namespaces) != 1 {
+		t.Errorf("Expected only the default namespace, got %v (%v)", list, err)
+	}
+
+	// A created namespace accepts writes
+	if _, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{Namespace: "docs"}); err != nil {
+		t.Fatalf("CreateNamespace failed: %v", err)
+	}
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: vector}); err != nil {
+		t.Errorf("Insert into a created namespace failed: %v", err)
+	}
+
+	// Dropped namespaces are not recreated by later requests
+	if _, err := server.DropNamespace(ctx, &proto.DropNamespaceRequest{Namespace: "docs"}); err != nil {
+		t.Fatalf("DropNamespace failed: %v", err)
+	}
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "docs", Vector: vector}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after dropping, got %v", err)
+	}
+}
+
+func TestStrictNamespacesRecovery(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.Database.StrictNamespaces = true
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if _, err := server.CreateNamespace(ctx, &proto.CreateNamespaceRequest{
+		Namespace:       "empty",
+		M:               12,
+		Metric:          grpcserver.MetricDotProduct,
+		QuantizationMin: -2,
+		QuantizationMax: 2,
+	}); err != nil {
+		t.Fatalf("CreateNamespace failed: %v", err)
+	}
+	server.Stop()
+
+	// A namespace created without writes survives the restart, with its
+	// full configuration
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	defer server.Stop()
+
+	resp, err := server.DescribeNamespace(ctx, &proto.DescribeNamespaceRequest{Namespace: "empty"})
+	if err != nil {
+		t.Fatalf("DescribeNamespace failed: %v", err)
+	}
+	if resp.M != 12 || resp.Metric != grpcserver.MetricDotProduct || resp.QuantizationMin != -2 || resp.QuantizationMax != 2 {
+		t.Errorf("Expected the declared configuration, got %v", resp)
+	}
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "empty", Vector: []float32{1, 0, 0}}); err != nil {
+		t.Errorf("Insert into the recovered namespace failed: %v", err)
+	}
+}
+
+func TestDropNamespace(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	for _, ns := range []string{"experiment", "experiment", "experiment", "keep"} {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: ns,
+			Vector:    []float32{1, 2, 3},
+			Metadata:  map[string]string{"ns": ns},
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	list, err := server.ListNamespaces(ctx, &proto.ListNamespacesRequest{})
+	if err != nil {
+		t.Fatalf("ListNamespaces failed: %v", err)
+	}
+	if got := strings.Join(list.Namespaces, ","); got != "default,experiment,keep" {
+		t.Fatalf("Expected default,experiment,keep, got %s", got)
+	}
+
+	resp, err := server.DropNamespace(ctx, &proto.DropNamespaceRequest{Namespace: "experiment"})
+	if err != nil {
+		t.Fatalf("DropNamespace failed: %v", err)
+	}
+	if resp.VectorsDropped != 3 {
+		t.Errorf("Expected 3 vectors dropped, got %d", resp.VectorsDropped)
+	}
+
+	stats, err := server.GetStats(ctx, &proto.StatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if _, ok := stats.NamespaceStats["experiment"]; ok || stats.TotalVectors != 1 {
+		t.Errorf("Expected only namespace keep to hold vectors, got %v", stats.NamespaceStats)
+	}
+
+	if _, err := server.DropNamespace(ctx, &proto.DropNamespaceRequest{Namespace: "experiment"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound when dropping twice, got %v", err)
+	}
+	if _, err := server.DropNamespace(ctx, &proto.DropNamespaceRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty namespace, got %v", err)
+	}
+	server.Stop()
+
+	// The dropped namespace's log is gone, so a restart does not bring it back
+	server, err = grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to restart server: %v", err)
+	}
+	defer server.Stop()
+
+	list, err = server.ListNamespaces(ctx, &proto.ListNamespacesRequest{})
+	if err != nil {
+		t.Fatalf("ListNamespaces failed: %v", err)
+	}
+	if got := strings.Join(list.Namespaces, ","); got != "default,keep" {
+		t.Errorf("Expected default,keep after restart, got %s", got)
+	}
+}
+
+func TestCount(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for ns, n := range map[string]int{"default": 5, "other": 2} {
+		for i := 0; i < n; i++ {
+			if _, err := client.Insert(ctx, &proto.InsertRequest{
+				Namespace: ns,
+				Vector:    []float32{float32(i), 1, 0},
+			}); err != nil {
+				t.Fatalf("Insert failed: %v", err)
+			}
+		}
+	}
+
+	resp, err := client.Count(ctx, &proto.CountRequest{})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if resp.Counts["default"] != 5 || resp.Counts["other"] != 2 {
+		t.Errorf("Expected counts default=5 other=2, got %v", resp.Counts)
+	}
+
+	handler := rest.NewHandler(client)
+	get := func(url string) map[string]interface{} {
+		rec := httptest.NewRecorder()
+		handler.Count(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d: %s", url, rec.Code, rec.Body.String())
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("GET %s: invalid JSON: %v", url, err)
+		}
+		return body
+	}
+
+	body := get("/v1/vectors/count?namespace=default")
+	if body["namespace"] != "default" || body["count"] != float64(5) {
+		t.Errorf("Expected {namespace: default, count: 5}, got %v", body)
+	}
+
+	// An unknown namespace counts zero, with the count still present
+	body = get("/v1/vectors/count?namespace=missing")
+	if count, ok := body["count"]; !ok || count != float64(0) {
+		t.Errorf("Expected count 0 for a missing namespace, got %v", body)
+	}
+	if resp, err := client.ListNamespaces(ctx, &proto.ListNamespacesRequest{}); err != nil {
+		t.Fatalf("ListNamespaces failed: %v", err)
+	} else if len(resp.Namespaces) != 2 {
+		t.Errorf("Count should not create namespaces, got %v", resp.Namespaces)
+	}
+
+	body = get("/v1/vectors/count")
+	counts, ok := body["counts"].(map[string]interface{})
+	if !ok || counts["default"] != float64(5) || counts["other"] != float64(2) {
+		t.Errorf("Expected counts for every namespace, got %v", body)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.Count(rec, httptest.NewRequest(http.MethodPost, "/v1/vectors/count", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", rec.Code)
+	}
+}
+
+func TestExport(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ids := make([]string, 25)
+	for i := range ids {
+		n := int64(i)
+		req := &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata:  map[string]string{"title": fmt.Sprintf("doc %d", i)},
+			TypedMetadata: map[string]*proto.MetadataValue{
+				"n": {IntValue: &n},
+			},
+		}
+		if i%2 == 0 {
+			req.Text = stringPtr(fmt.Sprintf("text %d", i))
+		}
+		if i%5 == 0 {
+			req.ExternalId = stringPtr(fmt.Sprintf("ext-%d", i))
+		}
+		resp, err := client.Insert(ctx, req)
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids[i] = resp.Id
+	}
+	if _, err := client.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "default",
+		Selector:  &proto.DeleteRequest_Id{Id: ids[3]},
+	}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	export := func(req *proto.ExportRequest) []*proto.ExportBatch {
+		stream, err := client.Export(ctx, req)
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		var batches []*proto.ExportBatch
+		for {
+			batch, err := stream.Recv()
+			if err == io.EOF {
+				return batches
+			}
+			if err != nil {
+				t.Fatalf("Export stream failed: %v", err)
+			}
+			batches = append(batches, batch)
+		}
+	}
+
+	batches := export(&proto.ExportRequest{Namespace: "default", BatchSize: 10})
+	if len(batches) != 3 {
+		t.Fatalf("Expected 3 batches of at most 10 for 24 vectors, got %d", len(batches))
+	}
+	var exported []*proto.FetchResult
+	for i, batch := range batches {
+		if batch.Total != 24 {
+			t.Errorf("Batch %d: expected total 24, got %d", i, batch.Total)
+		}
+		if batch.Done != (i == len(batches)-1) {
+			t.Errorf("Batch %d: expected done only on the last batch, got %v", i, batch.Done)
+		}
+		exported = append(exported, batch.Vectors...)
+	}
+	if len(exported) != 24 {
+		t.Fatalf("Expected 24 exported vectors, got %d", len(exported))
+	}
+
+	idNum := func(id string) uint64 {
+		n, _ := strconv.ParseUint(id, 10, 64)
+		return n
+	}
+	byID := make(map[string]int)
+	for i, id := range ids {
+		byID[id] = i
+	}
+	for i, v := range exported {
+		n, ok := byID[v.Id]
+		if !ok || n == 3 {
+			t.Fatalf("Unexpected vector %s exported", v.Id)
+		}
+		if i > 0 && idNum(v.Id) <= idNum(exported[i-1].Id) {
+			t.Errorf("Vectors not in ascending ID order at %d", i)
+		}
+		if v.Vector[0] != float32(n) || v.Metadata["title"] != fmt.Sprintf("doc %d", n) {
+			t.Errorf("Vector %s: wrong vector or metadata: %v %v", v.Id, v.Vector, v.Metadata)
+		}
+		if v.TypedMetadata["n"].GetIntValue() != int64(n) {
+			t.Errorf("Vector %s: expected typed metadata n=%d, got %v", v.Id, n, v.TypedMetadata["n"])
+		}
+		if (v.Text != nil) != (n%2 == 0) {
+			t.Errorf("Vector %s: unexpected text %v", v.Id, v.Text)
+		}
+		if (v.ExternalId != nil) != (n%5 == 0) {
+			t.Errorf("Vector %s: unexpected external ID %v", v.Id, v.ExternalId)
+		}
+	}
+
+	// Resuming from the first batch's cursor continues with the next vector
+	resumed := export(&proto.ExportRequest{Namespace: "default", Cursor: batches[0].NextCursor, Limit: 5})
+	if len(resumed) != 1 || !resumed[0].Done || resumed[0].Total != 5 {
+		t.Fatalf("Expected one final batch of 5, got %v", resumed)
+	}
+	for i, v := range resumed[0].Vectors {
+		if v.Id != exported[10+i].Id {
+			t.Errorf("Resumed vector %d: expected ID %s, got %s", i, exported[10+i].Id, v.Id)
+		}
+	}
+
+	// An empty export still ends with a done batch
+	empty := export(&proto.ExportRequest{Namespace: "default", Cursor: batches[2].NextCursor})
+	if len(empty) != 1 || !empty[0].Done || len(empty[0].Vectors) != 0 {
+		t.Errorf("Expected one empty done batch past the last cursor, got %v", empty)
+	}
+
+	for _, tc := range []struct {
+		req  *proto.ExportRequest
+		code codes.Code
+	}{
+		{&proto.ExportRequest{Namespace: "missing"}, codes.NotFound},
+		{&proto.ExportRequest{Namespace: "default", Cursor: "abc"}, codes.InvalidArgument},
+		{&proto.ExportRequest{Namespace: "default", Limit: -1}, codes.InvalidArgument},
+	} {
+		stream, err := client.Export(ctx, tc.req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != tc.code {
+			t.Errorf("Export(%v): expected %v, got %v", tc.req, tc.code, err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	insert := func(i int) string {
+		resp, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata:  map[string]string{"n": strconv.Itoa(i)},
+		})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		return resp.Id
+	}
+	ids := make([]string, 25)
+	for i := range ids {
+		ids[i] = insert(i)
+	}
+
+	first, err := client.Scan(ctx, &proto.ScanRequest{Namespace: "default", PageSize: 10})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(first.Results) != 10 || first.NextCursor == "" {
+		t.Fatalf("Expected a full first page with a cursor, got %d results, cursor %q", len(first.Results), first.NextCursor)
+	}
+	for i, r := range first.Results {
+		if r.Id != ids[i] {
+			t.Errorf("Result %d: expected ID %s, got %s", i, ids[i], r.Id)
+		}
+		if len(r.Vector) != 0 {
+			t.Errorf("Result %d: vector returned without include_vector", i)
+		}
+		if r.Metadata["n"] != strconv.Itoa(i) {
+			t.Errorf("Result %d: expected metadata n=%d, got %v", i, i, r.Metadata)
+		}
+	}
+
+	// Writes between pages neither repeat nor lose vectors: the deleted one
+	// is skipped and the new one appears on a later page
+	if _, err := client.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "default",
+		Selector:  &proto.DeleteRequest_Id{Id: ids[15]},
+	}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	added := insert(25)
+
+	seen := make(map[string]bool)
+	for _, r := range first.Results {
+		seen[r.Id] = true
+	}
+	cursor := first.NextCursor
+	for pages := 1; cursor != ""; pages++ {
+		if pages > 10 {
+			t.Fatal("Scan did not finish")
+		}
+		page, err := client.Scan(ctx, &proto.ScanRequest{Namespace: "default", PageSize: 10, Cursor: cursor, IncludeVector: true})
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		for _, r := range page.Results {
+			if seen[r.Id] {
+				t.Errorf("ID %s returned twice", r.Id)
+			}
+			seen[r.Id] = true
+			if len(r.Vector) != 3 {
+				t.Errorf("ID %s: expected its vector with include_vector, got %v", r.Id, r.Vector)
+			}
+		}
+		cursor = page.NextCursor
+	}
+	if len(seen) != 25 || seen[ids[15]] || !seen[added] {
+		t.Errorf("Expected the 24 remaining and 1 added vectors, got %d (deleted seen: %v, added seen: %v)",
+			len(seen), seen[ids[15]], seen[added])
+	}
+
+	for _, tc := range []struct {
+		req  *proto.ScanRequest
+		code codes.Code
+	}{
+		{&proto.ScanRequest{Namespace: "missing"}, codes.NotFound},
+		{&proto.ScanRequest{Namespace: "default", Cursor: "abc"}, codes.InvalidArgument},
+		{&proto.ScanRequest{Namespace: "default", PageSize: 10001}, codes.InvalidArgument},
+	} {
+		if _, err := client.Scan(ctx, tc.req); status.Code(err) != tc.code {
+			t.Errorf("Scan(%v): expected %v, got %v", tc.req, tc.code, err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	rest.NewHandler(client).Scan(rec, httptest.NewRequest(http.MethodGet, "/v1/vectors/scan?namespace=default&page_size=30", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/vectors/scan: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Results    []map[string]interface{} `json:"results"`
+		NextCursor *string                  `json:"next_cursor"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(body.Results) != 25 || body.NextCursor == nil || *body.NextCursor != "" {
+		t.Errorf("Expected 25 results and an empty next_cursor, got %d results, cursor %v", len(body.Results), body.NextCursor)
+	}
+}
+
+func TestSearchFieldProjection(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		year := int64(2020 + i)
+		if _, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata: map[string]string{
+				"title":  fmt.Sprintf("Doc %d", i),
+				"author": "someone",
+				"body":   strings.Repeat("wide ", 100),
+			},
+			TypedMetadata: map[string]*proto.MetadataValue{"year": {IntValue: &year}},
+			Text:          stringPtr(fmt.Sprintf("text %d", i)),
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	search := func(fields ...string) []*proto.SearchResult {
+		t.Helper()
+		resp, err := client.Search(ctx, &proto.SearchRequest{
+			Namespace:   "default",
+			QueryVector: []float32{1, 1, 0},
+			K:           3,
+			Fields:      fields,
+		})
+		if err != nil {
+			t.Fatalf("Search(fields=%v) failed: %v", fields, err)
+		}
+		if len(resp.Results) != 3 {
+			t.Fatalf("Search(fields=%v): expected 3 results, got %d", fields, len(resp.Results))
+		}
+		return resp.Results
+	}
+
+	// No fields keeps every field but text
+	for _, r := range search() {
+		if len(r.Vector) != 3 || len(r.Metadata) != 4 || r.TypedMetadata["year"] == nil || r.Text != nil {
+			t.Errorf("Expected the vector and all metadata by default, got %v", r)
+		}
+	}
+
+	// Only the requested metadata keys, without the vector
+	for _, r := range search("metadata.title", "metadata.year", "metadata.missing") {
+		if r.Id == "" {
+			t.Error("Expected the ID to always be returned")
+		}
+		if len(r.Metadata) != 2 || r.Metadata["title"] == "" || r.Metadata["year"] == "" {
+			t.Errorf("Expected only title and year metadata, got %v", r.Metadata)
+		}
+		if len(r.TypedMetadata) != 1 || r.TypedMetadata["year"] == nil {
+			t.Errorf("Expected only year typed metadata, got %v", r.TypedMetadata)
+		}
+		if len(r.Vector) != 0 || r.Text != nil {
+			t.Errorf("Expected no vector or text, got %v and %v", r.Vector, r.Text)
+		}
+	}
+
+	for _, r := range search("vector", "text") {
+		if len(r.Vector) != 3 || r.Text == nil || len(r.Metadata) != 0 {
+			t.Errorf("Expected the vector and text without metadata, got %v", r)
+		}
+	}
+	for _, r := range search("metadata") {
+		if len(r.Metadata) != 4 || len(r.Vector) != 0 {
+			t.Errorf("Expected all metadata without the vector, got %v", r)
+		}
+	}
+
+	_, err := client.Search(ctx, &proto.SearchRequest{
+		Namespace:   "default",
+		QueryVector: []float32{1, 1, 0},
+		K:           3,
+		Fields:      []string{"title"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown field, got %v", err)
+	}
+}
+
+func TestNormCheckWarning(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.HNSW.NormCheckInterval = 1
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	ctx := context.Background()
+	insert := func(namespace string, vector []float32) {
+		t.Helper()
+		if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: namespace, Vector: vector}); err != nil {
+			t.Fatalf("Insert into %s failed: %v", namespace, err)
+		}
+	}
+
+	// Unit vectors never warn
+	for i := 0; i < 20; i++ {
+		insert("unit", []float32{0.6, 0.8, 0})
+	}
+
+	// Unnormalized vectors warn once enough have been sampled, and only once
+	for i := 0; i < 9; i++ {
+		insert("raw", []float32{float32(i + 2), 10, 10})
+	}
+	if strings.Contains(logs.String(), "Warning: namespace") {
+		t.Fatalf("Expected no warning before 10 samples, got logs:\n%s", logs.String())
+	}
+	for i := 0; i < 20; i++ {
+		insert("raw", []float32{float32(i + 2), 10, 10})
+	}
+
+	if n := strings.Count(logs.String(), "Warning: namespace raw uses cosine distance"); n != 1 {
+		t.Errorf("Expected exactly one warning for namespace raw, got %d in logs:\n%s", n, logs.String())
+	}
+	if strings.Contains(logs.String(), "namespace unit uses") {
+		t.Errorf("Expected no warning for unit vectors, got logs:\n%s", logs.String())
+	}
+
+	// Namespaces that normalize on insert are not checked
+	server.SetNormalizeOnInsert("normalized", true)
+	for i := 0; i < 20; i++ {
+		insert("normalized", []float32{float32(i + 2), 10, 10})
+	}
+	if strings.Contains(logs.String(), "namespace normalized uses") {
+		t.Errorf("Expected no warning when normalizing on insert, got logs:\n%s", logs.String())
+	}
+}
+
+func TestMultiVectorSearch(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Token vectors of three documents
+	tokens := []struct {
+		doc    string
+		vector []float32
+	}{
+		{"both", []float32{1, 0, 0}},
+		{"both", []float32{0, 1, 0}},
+		{"first", []float32{1, 0.1, 0}},
+		{"first", []float32{1, 0.2, 0}},
+		{"other", []float32{0, 0, 1}},
+	}
+	for _, tok := range tokens {
+		if _, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: "colbert",
+			Vector:    tok.vector,
+			Metadata:  map[string]string{"doc_id": tok.doc},
+		}); err != nil {
+			t.Fatalf("Failed to insert: %v", err)
+		}
+	}
+
+	queries := []*proto.QueryVector{
+		{Values: []float32{1, 0, 0}},
+		{Values: []float32{0, 1, 0}},
+	}
+	resp, err := client.MultiVectorSearch(ctx, &proto.MultiVectorSearchRequest{
+		Namespace:    "colbert",
+		QueryVectors: queries,
+		K:            3,
+		GroupBy:      stringPtr("doc_id"),
+	})
+	if err != nil {
+		t.Fatalf("MultiVectorSearch failed: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("Expected one result per document, got %d", len(resp.Results))
+	}
+	if got := resp.Results[0].Metadata["doc_id"]; got != "both" {
+		t.Errorf("Expected document \"both\" first, got %s", got)
+	}
+
+	mean, err := client.MultiVectorSearch(ctx, &proto.MultiVectorSearchRequest{
+		Namespace:    "colbert",
+		QueryVectors: queries,
+		K:            3,
+		Aggregation:  "mean",
+		GroupBy:      stringPtr("doc_id"),
+	})
+	if err != nil {
+		t.Fatalf("MultiVectorSearch failed: %v", err)
+	}
+	for i := range resp.Results {
+		if diff := mean.Results[i].Distance - resp.Results[i].Distance/2; diff > 1e-5 || diff < -1e-5 {
+			t.Errorf("Result %d: expected mean distance %f, got %f", i, resp.Results[i].Distance/2, mean.Results[i].Distance)
+		}
+	}
+
+	_, err = client.MultiVectorSearch(ctx, &proto.MultiVectorSearchRequest{
+		Namespace:    "colbert",
+		QueryVectors: queries,
+		K:            3,
+		Aggregation:  "max",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unknown aggregation, got %v", err)
+	}
+}
+
+func TestNormalizeOnInsert(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	server.SetNormalizeOnInsert("unit", true)
+	ctx := context.Background()
+
+	// The same direction, un-normalized and pre-normalized
+	raw, err := server.Insert(ctx, &proto.InsertRequest{
+		Namespace: "unit",
+		Vector:    []float32{3, 4, 0},
+		Metadata:  map[string]string{"name": "raw"},
+	})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	unit, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "unit", Vector: []float32{0.6, 0.8, 0}})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	fetched, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "unit", Ids: []string{raw.Id, unit.Id}})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	stored := fetched.Results[0]
+	if math.Abs(float64(stored.Vector[0])-0.6) > 1e-6 || math.Abs(float64(stored.Vector[1])-0.8) > 1e-6 {
+		t.Errorf("Expected stored vector [0.6 0.8 0], got %v", stored.Vector)
+	}
+	if stored.Metadata[grpcserver.NormMetadataKey] != "5" || stored.Metadata["name"] != "raw" {
+		t.Errorf("Expected original norm 5 alongside metadata, got %v", stored.Metadata)
+	}
+	if got := fetched.Results[1].Metadata[grpcserver.NormMetadataKey]; got != "1" {
+		t.Errorf("Expected norm 1 for a unit vector, got %q", got)
+	}
+
+	resp, err := server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "unit",
+		QueryVector: []float32{30, 40, 0},
+		K:           2,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(resp.Results))
+	}
+	for _, r := range resp.Results {
+		if r.Distance > 1e-6 {
+			t.Errorf("Expected distance ~0 for %s, got %f", r.Id, r.Distance)
+		}
+	}
+
+	// Updating the vector replaces the recorded norm and keeps the metadata
+	if _, err := server.Update(ctx, &proto.UpdateRequest{Namespace: "unit", Id: raw.Id, Vector: []float32{0, 0, 2}}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	fetched, _ = server.Fetch(ctx, &proto.FetchRequest{Namespace: "unit", Ids: []string{raw.Id}})
+	if meta := fetched.Results[0].Metadata; meta[grpcserver.NormMetadataKey] != "2" || meta["name"] != "raw" {
+		t.Errorf("Expected norm 2 and kept metadata after update, got %v", meta)
+	}
+
+	// Zero vectors have no direction
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "unit", Vector: []float32{0, 0, 0}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for zero vector insert, got %v", err)
+	}
+	if _, err := server.Search(ctx, &proto.SearchRequest{Namespace: "unit", QueryVector: []float32{0, 0, 0}, K: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for zero query, got %v", err)
+	}
+
+	// Only cosine namespaces normalize
+	if err := server.SetNamespaceMetrics("dot", grpcserver.NamespaceMetrics{Retrieval: grpcserver.MetricDotProduct}); err != nil {
+		t.Fatalf("SetNamespaceMetrics failed: %v", err)
+	}
+	server.SetNormalizeOnInsert("dot", true)
+	dot, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "dot", Vector: []float32{3, 4, 0}})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	fetched, _ = server.Fetch(ctx, &proto.FetchRequest{Namespace: "dot", Ids: []string{dot.Id}})
+	if got := fetched.Results[0]; got.Vector[0] != 3 || got.Metadata[grpcserver.NormMetadataKey] != "" {
+		t.Errorf("Expected dot product namespace to store the raw vector, got %v %v", got.Vector, got.Metadata)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ns := "metrics-test"
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		resp, err := client.Insert(ctx, &proto.InsertRequest{Namespace: ns, Vector: []float32{float32(i), 1, 0}})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+	}
+
+	stream, err := client.BatchInsert(ctx)
+	if err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := stream.Send(&proto.InsertRequest{Namespace: ns, Vector: []float32{0, float32(i), 1}}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatalf("CloseAndRecv failed: %v", err)
+	}
+
+	if _, err := client.Search(ctx, &proto.SearchRequest{Namespace: ns, QueryVector: []float32{1, 1, 0}, K: 2}); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if _, err := client.Search(ctx, &proto.SearchRequest{Namespace: ns, K: 2}); err == nil {
+		t.Fatal("Expected error for search without a query vector")
+	}
+	if _, err := client.Update(ctx, &proto.UpdateRequest{Namespace: ns, Id: ids[0], Metadata: map[string]string{"a": "b"}}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := client.Delete(ctx, &proto.DeleteRequest{Namespace: ns, Selector: &proto.DeleteRequest_Id{Id: ids[1]}}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// Scrape the default registry the REST server exposes at /metrics
+	rec := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`vectordb_index_size{namespace="metrics-test"} 4`,
+		`vectordb_requests_total{method="Insert",status="OK"}`,
+		`vectordb_requests_total{method="BatchInsert",status="OK"}`,
+		`vectordb_requests_total{method="Search",status="InvalidArgument"}`,
+		`vectordb_request_errors_total{error_type="InvalidArgument",method="Search"}`,
+		`vectordb_request_duration_seconds_count{method="Delete"}`,
+		"vectordb_vectors_updated_total",
+		"vectordb_batch_insert_total",
+		"vectordb_search_latency_seconds_count",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics to contain %q", want)
+		}
+	}
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ns := "tracing-test"
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: ns,
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata:  map[string]string{"parity": strconv.Itoa(i % 2)},
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	resp, err := client.Search(ctx, &proto.SearchRequest{
+		Namespace:   ns,
+		QueryVector: []float32{1, 1, 0},
+		K:           2,
+		EfSearch:    20,
+		Filter: &proto.Filter{
+			FilterType: &proto.Filter_Comparison{
+				Comparison: &proto.ComparisonFilter{Field: "parity", Operator: "eq", Value: "0"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	root, ok := spans["vector.Search"]
+	if !ok {
+		t.Fatalf("Expected a vector.Search span, got %d spans", len(spans))
+	}
+	attrs := make(map[string]string)
+	for _, kv := range root.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["vector.namespace"] != ns || attrs["vector.k"] != "2" || attrs["vector.ef_search"] != "20" {
+		t.Errorf("Unexpected search attributes: %v", attrs)
+	}
+	if attrs["vector.result_count"] != strconv.Itoa(len(resp.Results)) {
+		t.Errorf("Expected result count %d, got %s", len(resp.Results), attrs["vector.result_count"])
+	}
+
+	// Stages are children of the search span; a namespace this small is scanned exactly
+	for _, name := range []string{"vector.ExactSearch", "vector.ResultsToProto"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("Expected a %s span", name)
+			continue
+		}
+		if span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("Expected %s to be a child of vector.Search", name)
+		}
+	}
+}
+
+func TestResultCache(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ns := "cache-test"
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.Insert(ctx, &proto.InsertRequest{Namespace: ns, Vector: []float32{float32(i), 1, 0}}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	search := func() *proto.SearchResponse {
+		t.Helper()
+		resp, err := client.Search(ctx, &proto.SearchRequest{Namespace: ns, QueryVector: []float32{10, 1, 0}, K: 2})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		return resp
+	}
+
+	hits := testutil.ToFloat64(observability.DefaultMetrics().CacheHits)
+	first := search()
+	second := search()
+	if got := testutil.ToFloat64(observability.DefaultMetrics().CacheHits) - hits; got != 1 {
+		t.Errorf("Expected 1 cache hit, got %v", got)
+	}
+	if len(second.Results) != len(first.Results) || second.Results[0].Id != first.Results[0].Id {
+		t.Errorf("Expected cached results to match, got %v and %v", first.Results, second.Results)
+	}
+
+	// An insert invalidates the namespace, so the new vector is found
+	resp, err := client.Insert(ctx, &proto.InsertRequest{Namespace: ns, Vector: []float32{10, 1, 0}})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if got := search(); got.Results[0].Id != resp.Id {
+		t.Errorf("Expected new vector %s first after insert, got %s", resp.Id, got.Results[0].Id)
+	}
+
+	// So does a delete
+	if _, err := client.Delete(ctx, &proto.DeleteRequest{Namespace: ns, Selector: &proto.DeleteRequest_Id{Id: resp.Id}}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if got := search(); got.Results[0].Id == resp.Id {
+		t.Error("Expected deleted vector to leave the cached results")
+	}
+
+	// And an update
+	if _, err := client.Update(ctx, &proto.UpdateRequest{Namespace: ns, Id: first.Results[0].Id, Metadata: map[string]string{"tag": "new"}}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if got := search(); got.Results[0].Metadata["tag"] != "new" {
+		t.Errorf("Expected updated metadata after update, got %v", got.Results[0].Metadata)
+	}
+
+	// Writes elsewhere leave the namespace's entries alone
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "cache-other", Vector: []float32{10, 1, 0}}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	hits = testutil.ToFloat64(observability.DefaultMetrics().CacheHits)
+	search()
+	if got := testutil.ToFloat64(observability.DefaultMetrics().CacheHits) - hits; got != 1 {
+		t.Errorf("Expected a write to another namespace to keep the cache, got %v hits", got)
+	}
+}
+
+func TestResultCacheFilterOrder(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ns := "cache-filter"
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.Insert(ctx, &proto.InsertRequest{
+			Namespace: ns,
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata:  map[string]string{"color": "red", "size": fmt.Sprint(i % 2)},
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	eq := func(field, value string) *proto.Filter {
+		return &proto.Filter{FilterType: &proto.Filter_Comparison{
+			Comparison: &proto.ComparisonFilter{Field: field, Operator: "eq", Value: value},
+		}}
+	}
+	composite := func(op string, filters ...*proto.Filter) *proto.Filter {
+		return &proto.Filter{FilterType: &proto.Filter_Composite{
+			Composite: &proto.CompositeFilter{Operator: op, Filters: filters},
+		}}
+	}
+	search := func(filter *proto.Filter) {
+		t.Helper()
+		if _, err := client.Search(ctx, &proto.SearchRequest{
+			Namespace: ns, QueryVector: []float32{10, 1, 0}, K: 2, Filter: filter,
+		}); err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+	}
+
+	for _, op := range []string{"and", "or"} {
+		search(composite(op, eq("color", "red"), eq("size", "1")))
+
+		hits := testutil.ToFloat64(observability.DefaultMetrics().CacheHits)
+		search(composite(op, eq("size", "1"), eq("color", "red")))
+		if got := testutil.ToFloat64(observability.DefaultMetrics().CacheHits) - hits; got != 1 {
+			t.Errorf("%s: expected reordered children to hit the cache, got %v hits", op, got)
+		}
+	}
+
+	// A different filter gets its own entry
+	hits := testutil.ToFloat64(observability.DefaultMetrics().CacheHits)
+	search(composite("and", eq("color", "red"), eq("size", "0")))
+	if got := testutil.ToFloat64(observability.DefaultMetrics().CacheHits) - hits; got != 0 {
+		t.Errorf("Expected a different filter to miss the cache, got %v hits", got)
+	}
+}
+
+func BenchmarkSearchCache(b *testing.B) {
+	const numItems = 5000
+	const dim = 128
+
+	rng := rand.New(rand.NewSource(42))
+	randomVector := func() []float32 {
+		vec := make([]float32, dim)
+		for i := range vec {
+			vec[i] = rng.Float32()
+		}
+		return vec
+	}
+
+	ctx := context.Background()
+	query := randomVector()
+
+	for _, enabled := range []bool{false, true} {
+		cfg := config.Default()
+		cfg.HNSW.Dimensions = dim
+		cfg.Cache.Enabled = enabled
+
+		server, err := grpcserver.NewServer(cfg)
+		if err != nil {
+			b.Fatalf("Failed to create server: %v", err)
+		}
+
+		stream := &batchInsertStream{ctx: ctx}
+		for i := 0; i < numItems; i++ {
+			stream.reqs = append(stream.reqs, &proto.InsertRequest{Namespace: "bench", Vector: randomVector()})
+		}
+		if err := server.BatchInsert(stream); err != nil {
+			b.Fatalf("BatchInsert failed: %v", err)
+		}
+
+		// Repeats the same query, as a dashboard refreshing would
+		b.Run(fmt.Sprintf("cache=%v", enabled), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if _, err := server.Search(ctx, &proto.SearchRequest{
+					Namespace: "bench", QueryVector: query, K: 10, EfSearch: 100,
+				}); err != nil {
+					b.Fatalf("Search failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	cfg := config.Default()
+	cfg.Server.Port = 50053
+	cfg.HNSW.Dimensions = 3
+	cfg.Warmup.Enabled = true
+	cfg.Warmup.Queries = 20000
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(42))
+	stream := &batchInsertStream{ctx: ctx}
+	for i := 0; i < 1000; i++ {
+		stream.reqs = append(stream.reqs, &proto.InsertRequest{
+			Namespace: "warm",
+			Vector:    []float32{rng.Float32(), rng.Float32(), rng.Float32()},
+		})
+	}
+	if err := server.BatchInsert(stream); err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	health, err := server.HealthCheck(ctx, &proto.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+	if health.Status != "warming" {
+		t.Errorf("Expected status warming right after start, got %q", health.Status)
+	}
+
+	// Searches are served while warming
+	if _, err := server.Search(ctx, &proto.SearchRequest{Namespace: "warm", QueryVector: []float32{0.5, 0.5, 0.5}, K: 5}); err != nil {
+		t.Errorf("Search during warmup failed: %v", err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for health.Status == "warming" {
+		if time.Now().After(deadline) {
+			t.Fatal("Warmup did not complete")
+		}
+		time.Sleep(10 * time.Millisecond)
+		health, _ = server.HealthCheck(ctx, &proto.HealthCheckRequest{})
+	}
+	if health.Status != "healthy" {
+		t.Errorf("Expected healthy after warmup, got %q", health.Status)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.SetNamespaceMetrics("dot", grpcserver.NamespaceMetrics{Retrieval: grpcserver.MetricDotProduct}); err != nil {
+		t.Fatalf("SetNamespaceMetrics failed: %v", err)
+	}
+
+	var ids []string
+	for i, text := range []string{"first doc", "second doc", "third doc"} {
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace:  "docs",
+			Vector:     []float32{float32(i), 1, 0},
+			Metadata:   map[string]string{"n": strconv.Itoa(i)},
+			Text:       stringPtr(text),
+			ExternalId: stringPtr("doc-" + strconv.Itoa(i)),
+		})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		ids = append(ids, resp.Id)
+	}
+	if _, err := server.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "docs",
+		Selector:  &proto.DeleteRequest_Id{Id: ids[1]},
+	}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := server.Insert(ctx, &proto.InsertRequest{Namespace: "dot", Vector: []float32{1, 2, 3}}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	stream := &snapshotStream{ctx: ctx}
+	if err := server.Snapshot(&proto.SnapshotRequest{}, stream); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	// One message per namespace (default, docs, dot), then the result
+	if len(stream.progress) != 4 {
+		t.Fatalf("Expected 4 progress messages, got %d", len(stream.progress))
+	}
+	for i, p := range stream.progress[:3] {
+		if p.Done || p.NamespacesDone != int32(i+1) || p.NamespacesTotal != 3 {
+			t.Errorf("Unexpected progress message %d: %+v", i, p)
+		}
+	}
+	final := stream.progress[3]
+	if !final.Done || final.VectorsWritten != 3 {
+		t.Fatalf("Expected a final message covering 3 vectors, got %+v", final)
+	}
+
+	data, err := os.ReadFile(final.Path)
+	if err != nil {
+		t.Fatalf("Failed to read snapshot: %v", err)
+	}
+	sum := sha256.Sum256(data)
+	if final.Checksum != hex.EncodeToString(sum[:]) || final.SizeBytes != int64(len(data)) {
+		t.Errorf("Expected checksum and size of the written file, got %s (%d bytes)", final.Checksum, final.SizeBytes)
+	}
+
+	// Namespaces that hold vectors are never overwritten
+	_, err = server.Restore(ctx, &proto.RestoreRequest{Path: final.Path})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists restoring over existing namespaces, got %v", err)
+	}
+	_, err = server.Restore(ctx, &proto.RestoreRequest{Path: final.Path, Prefix: "bad-", Checksum: strings.Repeat("0", 64)})
+	if status.Code(err) != codes.DataLoss {
+		t.Errorf("Expected DataLoss for a checksum mismatch, got %v", err)
+	}
+	_, err = server.Restore(ctx, &proto.RestoreRequest{Path: filepath.Join(cfg.Database.DataDir, "wal", "docs.log")})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a file outside the snapshot directory, got %v", err)
+	}
+
+	restored, err := server.Restore(ctx, &proto.RestoreRequest{
+		Path:     filepath.Base(final.Path),
+		Prefix:   "copy-",
+		Checksum: final.Checksum,
+	})
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if strings.Join(restored.Namespaces, ",") != "copy-default,copy-docs,copy-dot" || restored.VectorsRestored != 3 {
+		t.Errorf("Unexpected restore result: %+v", restored)
+	}
+
+	// The failed restores published nothing
+	stats, err := server.GetStats(ctx, &proto.StatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if _, ok := stats.NamespaceStats["bad-docs"]; ok {
+		t.Error("Expected a rejected restore to leave no namespaces behind")
+	}
+
+	// Restored namespaces survive a restart through the WAL
+	for restart := 0; restart <= 1; restart++ {
+		resp, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "copy-docs", Ids: ids})
+		if err != nil {
+			t.Fatalf("Restart %d: fetch failed: %v", restart, err)
+		}
+		first, deleted, third := resp.Results[0], resp.Results[1], resp.Results[2]
+		if !first.Found || first.Metadata["n"] != "0" || first.Text == nil || *first.Text != "first doc" {
+			t.Errorf("Restart %d: expected %s to be restored, got %+v", restart, ids[0], first)
+		}
+		if deleted.Found {
+			t.Errorf("Restart %d: expected deleted %s to stay deleted", restart, ids[1])
+		}
+		if !third.Found || third.Vector[0] != 2 || third.GetExternalId() != "doc-2" {
+			t.Errorf("Restart %d: expected %s to be restored, got %+v", restart, ids[2], third)
+		}
+		if byExternal, err := server.Fetch(ctx, &proto.FetchRequest{Namespace: "copy-docs", Ids: []string{"doc-2"}}); err != nil ||
+			byExternal.FoundCount != 1 || byExternal.Results[0].Vector[0] != 2 {
+			t.Errorf("Restart %d: expected doc-2 to resolve after restore, got %v (err: %v)", restart, byExternal, err)
+		}
+
+		hybrid, err := server.HybridSearch(ctx, &proto.HybridSearchRequest{
+			Namespace:   "copy-docs",
+			QueryVector: []float32{2, 1, 0},
+			QueryText:   "third",
+			K:           1,
+		})
+		if err != nil || len(hybrid.Results) == 0 || hybrid.Results[0].Id != ids[2] {
+			t.Errorf("Restart %d: expected hybrid search to find %s, got %v (err: %v)", restart, ids[2], hybrid, err)
+		}
+
+		// The restored namespace keeps its retrieval metric. Namespace
+		// settings are not in the WAL, so this only holds before a restart.
+		if restart == 0 {
+			search, err := server.Search(ctx, &proto.SearchRequest{Namespace: "copy-dot", QueryVector: []float32{1, 1, 1}, K: 1})
+			if err != nil || len(search.Results) != 1 || search.Results[0].Distance != -6 {
+				t.Errorf("Expected a dot product distance of -6, got %v (err: %v)", search, err)
+			}
+		}
+
+		server.Stop()
+		if server, err = grpcserver.NewServer(cfg); err != nil {
+			t.Fatalf("Failed to create server: %v", err)
+		}
+	}
+	server.Stop()
+}
+
+// snapshotStream collects Snapshot progress without a network round trip
+type snapshotStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	progress []*proto.SnapshotProgress
+}
+
+func (s *snapshotStream) Context() context.Context { return s.ctx }
+
+func (s *snapshotStream) Send(p *proto.SnapshotProgress) error {
+	s.progress = append(s.progress, p)
+	return nil
+}
+
+func TestSearchAsOf(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.Database.DataDir = t.TempDir()
+	cfg.WAL.Enabled = true
+
+	ctx := context.Background()
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	insert := func(vector []float32, tag string) string {
+		t.Helper()
+		resp, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "docs",
+			Vector:    vector,
+			Metadata:  map[string]string{"tag": tag},
+		})
+		if err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		return resp.Id
+	}
+	snapshot := func() int64 {
+		t.Helper()
+		stream := &snapshotStream{ctx: ctx}
+		if err := server.Snapshot(&proto.SnapshotRequest{}, stream); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+		return stream.progress[len(stream.progress)-1].CapturedAt
+	}
+	// searchIDs returns the IDs found and the capture time of the snapshot
+	// searched
+	searchIDs := func(req *proto.SearchRequest) (map[string]bool, int64) {
+		t.Helper()
+		req.Namespace = "docs"
+		req.QueryVector = []float32{1, 0, 0}
+		req.K = 10
+		resp, err := server.Search(ctx, req)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, r := range resp.Results {
+			ids[r.Id] = true
+		}
+		return ids, resp.AsOf
+	}
+
+	before := []string{insert([]float32{1, 0, 0}, "a"), insert([]float32{0, 1, 0}, "b")}
+	first := snapshot()
+
+	// Writes after the snapshot: an insert and a delete
+	later := insert([]float32{1, 0.1, 0}, "a")
+	if _, err := server.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "docs",
+		Selector:  &proto.DeleteRequest_Id{Id: before[1]},
+	}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	live, served := searchIDs(&proto.SearchRequest{})
+	if !live[later] || live[before[1]] || served != 0 {
+		t.Errorf("Live search returned %v from %d, expected the later insert and not the deleted vector", live, served)
+	}
+
+	asOf, served := searchIDs(&proto.SearchRequest{AsOf: first})
+	if len(asOf) != 2 || !asOf[before[0]] || !asOf[before[1]] {
+		t.Errorf("as_of search returned %v, expected exactly %v", asOf, before)
+	}
+	if served != first {
+		t.Errorf("Expected the snapshot captured at %d, got %d", first, served)
+	}
+
+	// Filters are evaluated against the snapshot's metadata
+	filtered, _ := searchIDs(&proto.SearchRequest{
+		AsOf: first,
+		Filter: &proto.Filter{
+			FilterType: &proto.Filter_Comparison{
+				Comparison: &proto.ComparisonFilter{Field: "tag", Operator: "eq", Value: "a"},
+			},
+		},
+	})
+	if len(filtered) != 1 || !filtered[before[0]] {
+		t.Errorf("Filtered as_of search returned %v, expected only %s", filtered, before[0])
+	}
+
+	// A later snapshot serves times from its capture on
+	time.Sleep(2 * time.Millisecond)
+	second := snapshot()
+	if ids, served := searchIDs(&proto.SearchRequest{AsOf: second + 1000}); !ids[later] || ids[before[1]] || served != second {
+		t.Errorf("as_of after the second snapshot returned %v from %d", ids, served)
+	}
+	if ids, served := searchIDs(&proto.SearchRequest{AsOf: second - 1}); ids[later] || served != first {
+		t.Errorf("as_of before the second snapshot returned %v from %d", ids, served)
+	}
+
+	// Nothing was snapshotted before the first snapshot
+	_, err = server.Search(ctx, &proto.SearchRequest{
+		Namespace:   "docs",
+		QueryVector: []float32{1, 0, 0},
+		K:           10,
+		AsOf:        first - 1,
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound before the first snapshot, got %v", err)
+	}
+}
+
+func TestSearchDeadline(t *testing.T) {
+	cfg := config.Default()
+	cfg.HNSW.Dimensions = 3
+	cfg.HNSW.ExactSearchThreshold = 0 // Always search the graph
+	cfg.HNSW.FlatThreshold = 0
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		if _, err := server.Insert(ctx, &proto.InsertRequest{
+			Namespace: "default",
+			Vector:    []float32{float32(i), 1, 0},
+			Metadata:  map[string]string{"n": strconv.Itoa(i)},
+		}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	for name, req := range map[string]*proto.SearchRequest{
+		"graph": {Namespace: "default", QueryVector: []float32{1, 1, 0}, K: 5},
+		"backfill": {
+			Namespace:   "default",
+			QueryVector: []float32{1, 1, 0},
+			K:           5,
+			GuaranteeK:  true,
+			Filter: &proto.Filter{FilterType: &proto.Filter_Comparison{
+				Comparison: &proto.ComparisonFilter{Field: "n", Operator: "ne", Value: "3"},
+			}},
+		},
+	} {
+		if _, err := server.Search(expired, req); status.Code(err) != codes.DeadlineExceeded {
+			t.Errorf("%s: expected DeadlineExceeded, got %v", name, err)
+		}
+		if _, err := server.Search(canceled, req); status.Code(err) != codes.Canceled {
+			t.Errorf("%s: expected Canceled, got %v", name, err)
+		}
+		if _, err := server.Search(ctx, req); err != nil {
+			t.Errorf("%s: search with a live context failed: %v", name, err)
+		}
+	}
+}
+
+func TestInsertQuota(t *testing.T) {
+	cfg := config.Default()
+	cfg.Server.Port = 50056
+	cfg.HNSW.Dimensions = 3
+	cfg.Quota.MaxVectors = 3
+	cfg.Quota.Namespaces = map[string]config.NamespaceQuota{
+		"bulk": {MaxVectors: -1}, // No limit
+	}
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("localhost:50056",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewVectorDBClient(conn)
+	ctx := context.Background()
+
+	insert := func(namespace string, i int) (*proto.InsertResponse, error) {
+		return client.Insert(ctx, &proto.InsertRequest{
+			Namespace: namespace,
+			Vector:    []float32{float32(i), 1, 0},
+		})
+	}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		resp, err := insert("quota", i)
+		if err != nil {
+			t.Fatalf("Insert %d within quota failed: %v", i, err)
+		}
+		ids = append(ids, resp.Id)
+	}
+
+	// The fourth vector is over the namespace's quota
+	if _, err := insert("quota", 3); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted past the quota, got %v", err)
+	} else if !strings.Contains(err.Error(), "vector quota exceeded") {
+		t.Errorf("Expected quota in the error, got %v", err)
+	}
+
+	usage := observability.DefaultMetrics().TenantQuotaUsage.WithLabelValues("quota", "vectors")
+	if got := testutil.ToFloat64(usage); got != 100 {
+		t.Errorf("Expected 100%% vector quota usage, got %v", got)
+	}
+
+	// BatchInsert reports over-quota items without failing the stream
+	batch, err := streamBatchInsert(ctx, client, "", []*proto.InsertRequest{
+		{Namespace: "quota", Vector: []float32{4, 1, 0}},
+		{Namespace: "bulk", Vector: []float32{5, 1, 0}},
+	})
+	if err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+	if batch.InsertedCount != 1 || batch.FailedCount != 1 {
+		t.Fatalf("Expected 1 inserted and 1 failed, got %+v", batch)
+	}
+	if !strings.Contains(batch.Errors[0], "quota exceeded") {
+		t.Errorf("Expected quota error for item 0, got %v", batch.Errors)
+	}
+
+	// Deleting a vector frees room for another
+	if _, err := client.Delete(ctx, &proto.DeleteRequest{
+		Namespace: "quota",
+		Selector:  &proto.DeleteRequest_Id{Id: ids[0]},
+	}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := insert("quota", 6); err != nil {
+		t.Errorf("Insert after delete failed: %v", err)
+	}
+
+	// The override lifts the limit for its namespace
+	for i := 0; i < 5; i++ {
+		if _, err := insert("bulk", i); err != nil {
+			t.Fatalf("Insert %d into unlimited namespace failed: %v", i, err)
+		}
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	cfg := config.Default()
+	cfg.Server.Port = 50057
+	cfg.HNSW.Dimensions = 3
+	cfg.Server.AuthEnabled = true
+	cfg.Server.APIKeys = []string{"test-key"}
+	cfg.REST.JWTSecret = "test-secret"
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("localhost:50057",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewVectorDBClient(conn)
+
+	userToken, err := middleware.GenerateToken("u1", "user", []string{"user"}, "", "test-secret")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	forgedToken, err := middleware.GenerateToken("u1", "user", []string{"admin"}, "", "wrong-secret")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+
+	ctx := context.Background()
+	withAPIKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "x-api-key", key)
+	}
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	insert := &proto.InsertRequest{Namespace: "default", Vector: []float32{1, 0, 0}}
+
+	// Health checks are public
+	if _, err := client.HealthCheck(ctx, &proto.HealthCheckRequest{}); err != nil {
+		t.Errorf("Expected public HealthCheck to succeed, got %v", err)
+	}
+
+	for name, callCtx := range map[string]context.Context{
+		"no credentials": ctx,
+		"wrong API key":  withAPIKey("other-key"),
+		"forged token":   withToken(forgedToken),
+	} {
+		if _, err := client.Insert(callCtx, insert); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %v", name, err)
+		}
+	}
+
+	// Streams are checked too
+	if _, err := streamBatchInsert(ctx, client, "", []*proto.InsertRequest{insert}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected unauthenticated BatchInsert to fail, got %v", err)
+	}
+	if resp, err := streamBatchInsert(withAPIKey("test-key"), client, "", []*proto.InsertRequest{insert}); err != nil || resp.InsertedCount != 1 {
+		t.Errorf("Expected authenticated BatchInsert to insert 1 vector, got %+v, %v", resp, err)
+	}
+
+	// A JWT from the REST secret works for ordinary RPCs but not admin ones
+	if _, err := client.Insert(withToken(userToken), insert); err != nil {
+		t.Errorf("Expected Insert with a valid token to succeed, got %v", err)
+	}
+	if _, err := client.GetStats(withToken(userToken), &proto.StatsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected GetStats without the admin role to be denied, got %v", err)
+	}
+
+	// API keys carry the admin role
+	stats, err := client.GetStats(withAPIKey("test-key"), &proto.StatsRequest{})
+	if err != nil {
+		t.Fatalf("Expected GetStats with an API key to succeed, got %v", err)
+	}
+	if stats.TotalVectors != 2 {
+		t.Errorf("Expected 2 vectors, got %d", stats.TotalVectors)
+	}
 }
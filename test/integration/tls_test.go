@@ -0,0 +1,245 @@
+package integration
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	grpcserver "github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/api/grpc/proto"
+	"github.com/therealutkarshpriyadarshi/vector/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// testCA issues certificates for TLS tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(cryptorand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate failed: %v", err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a certificate for localhost signed by the CA, usable by
+// servers and clients, and returns its cert and key files
+func (ca *testCA) issue(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(cryptorand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey failed: %v", err)
+	}
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certFile, keyFile
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+// healthCheck calls HealthCheck over a new connection with the given
+// credentials
+func healthCheck(addr string, creds credentials.TransportCredentials) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = proto.NewVectorDBClient(conn).HealthCheck(ctx, &proto.HealthCheckRequest{})
+	return err
+}
+
+func TestGRPCTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "test CA")
+	certFile, keyFile := ca.issue(t, dir, "server")
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	cfg := config.Default()
+	cfg.Server.Port = 50058
+	cfg.HNSW.Dimensions = 3
+	cfg.Server.EnableTLS = true
+	cfg.Server.CertFile = certFile
+	cfg.Server.KeyFile = keyFile
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	addr := "localhost:50058"
+	if err := healthCheck(addr, credentials.NewTLS(&tls.Config{RootCAs: roots})); err != nil {
+		t.Errorf("Expected a TLS client trusting the CA to connect, got %v", err)
+	}
+	if err := healthCheck(addr, insecure.NewCredentials()); err == nil {
+		t.Error("Expected a plaintext client to be rejected")
+	}
+	if err := healthCheck(addr, credentials.NewTLS(&tls.Config{})); err == nil {
+		t.Error("Expected a client not trusting the CA to be rejected")
+	}
+
+	// The gateway credentials reach the server on its bind address
+	if err := healthCheck(cfg.Server.Address(), server.GatewayCredentials()); err != nil {
+		t.Errorf("Expected the gateway credentials to connect, got %v", err)
+	}
+}
+
+func TestGRPCMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "test CA")
+	certFile, keyFile := ca.issue(t, dir, "server")
+	clientCert, clientKey := ca.issue(t, dir, "client")
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, caFile, ca.pem)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	cfg := config.Default()
+	cfg.Server.Port = 50059
+	cfg.HNSW.Dimensions = 3
+	cfg.Server.EnableTLS = true
+	cfg.Server.CertFile = certFile
+	cfg.Server.KeyFile = keyFile
+	cfg.Server.ClientCAFile = caFile
+
+	server, err := grpcserver.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	addr := "localhost:50059"
+	if err := healthCheck(addr, credentials.NewTLS(&tls.Config{RootCAs: roots})); err == nil {
+		t.Error("Expected a client without a certificate to be rejected")
+	}
+
+	// A certificate from another CA is rejected
+	otherCert, otherKey := newTestCA(t, "other CA").issue(t, dir, "other")
+	other, err := tls.LoadX509KeyPair(otherCert, otherKey)
+	if err != nil {
+		t.Fatalf("LoadX509KeyPair failed: %v", err)
+	}
+	if err := healthCheck(addr, credentials.NewTLS(&tls.Config{RootCAs: roots, Certificates: []tls.Certificate{other}})); err == nil {
+		t.Error("Expected a client certificate from an untrusted CA to be rejected")
+	}
+
+	client, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatalf("LoadX509KeyPair failed: %v", err)
+	}
+	if err := healthCheck(addr, credentials.NewTLS(&tls.Config{RootCAs: roots, Certificates: []tls.Certificate{client}})); err != nil {
+		t.Errorf("Expected a client with a trusted certificate to connect, got %v", err)
+	}
+	if err := healthCheck(cfg.Server.Address(), server.GatewayCredentials()); err != nil {
+		t.Errorf("Expected the gateway credentials to connect, got %v", err)
+	}
+}
+
+func TestGRPCTLSInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "test CA")
+	certFile, keyFile := ca.issue(t, dir, "server")
+	garbage := filepath.Join(dir, "garbage.pem")
+	writeFile(t, garbage, []byte("not a certificate"))
+
+	tests := []struct {
+		name     string
+		cert     string
+		key      string
+		clientCA string
+		want     string
+	}{
+		{"missing cert", filepath.Join(dir, "missing.crt"), keyFile, "", "failed to load TLS certificates"},
+		{"invalid key", certFile, garbage, "", "failed to load TLS certificates"},
+		{"missing client CA", certFile, keyFile, filepath.Join(dir, "missing-ca.crt"), "failed to read TLS client CA file"},
+		{"invalid client CA", certFile, keyFile, garbage, "no PEM certificates found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.HNSW.Dimensions = 3
+			cfg.Server.EnableTLS = true
+			cfg.Server.CertFile = tt.cert
+			cfg.Server.KeyFile = tt.key
+			cfg.Server.ClientCAFile = tt.clientCA
+
+			_, err := grpcserver.NewServer(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected startup to fail with %q, got %v", tt.want, err)
+			}
+		})
+	}
+}